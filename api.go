@@ -1,11 +1,15 @@
 package messagebird
 
-import (
-	"net/url"
-	"strconv"
-)
+import "github.com/messagebird/go-rest-api/v9/internal/querystring"
 
-// PaginationRequest can be used to set pagination options in List().
+// DefaultLimit is the number of items the API returns per page if no limit
+// is set.
+const DefaultLimit = 20
+
+// PaginationRequest can be used to set pagination options in List(). Fields
+// that are zero or negative are left out of the query, so the API uses its
+// defaults: a limit of DefaultLimit and an offset of zero. A nil
+// PaginationRequest requests the first page with the default limit.
 type PaginationRequest struct {
 	Limit, Offset int
 }
@@ -15,12 +19,12 @@ func (cpr *PaginationRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 	if cpr.Limit > 0 {
-		query.Set("limit", strconv.Itoa(cpr.Limit))
+		query.SetInt("limit", cpr.Limit)
 	}
-	if cpr.Offset >= 0 {
-		query.Set("offset", strconv.Itoa(cpr.Offset))
+	if cpr.Offset > 0 {
+		query.SetInt("offset", cpr.Offset)
 	}
 
 	return query.Encode()
@@ -28,6 +32,6 @@ func (cpr *PaginationRequest) QueryParams() string {
 
 // DefaultPagination provides reasonable values for List requests.
 var DefaultPagination = &PaginationRequest{
-	Limit:  20,
+	Limit:  DefaultLimit,
 	Offset: 0,
 }
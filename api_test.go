@@ -0,0 +1,25 @@
+package messagebird
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationRequestQueryParams(t *testing.T) {
+	tests := []struct {
+		options  *PaginationRequest
+		expected string
+	}{
+		{nil, ""},
+		{&PaginationRequest{}, ""},
+		{&PaginationRequest{Limit: -1, Offset: -1}, ""},
+		{&PaginationRequest{Offset: 40}, "offset=40"},
+		{DefaultPagination, "limit=20"},
+		{&PaginationRequest{Limit: 10, Offset: 25}, "limit=10&offset=25"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.options.QueryParams())
+	}
+}
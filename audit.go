@@ -0,0 +1,120 @@
+package messagebird
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntry records a mutating request sent by a DefaultClient. It holds no
+// message contents or phone numbers, so audit logs can be kept longer than
+// the data they describe.
+type AuditEntry struct {
+	// Time is when the request was sent and Duration how long it took.
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+
+	// Key identifies the access key the request was sent with by its type
+	// and last four characters, e.g. "live_...Xy12".
+	Key string `json:"key"`
+
+	// Method and URL are those of the request, redacted like those of a
+	// RequestError.
+	Method string `json:"method"`
+	URL    string `json:"url"`
+
+	// Summary lists the fields of the request body, without their values,
+	// e.g. "body originator recipients[2]".
+	Summary string `json:"summary,omitempty"`
+
+	// StatusCode is the HTTP status of the response, or zero if no response
+	// was received. Error is the error of the request, if it failed.
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// An Auditor records the mutating requests of a DefaultClient, see
+// DefaultClient.Audit. It must be safe for concurrent use.
+type Auditor interface {
+	Audit(e *AuditEntry)
+}
+
+// AuditorFunc is an Auditor that calls the function.
+type AuditorFunc func(e *AuditEntry)
+
+// Audit implements Auditor.
+func (f AuditorFunc) Audit(e *AuditEntry) {
+	f(e)
+}
+
+// NewJSONAuditor returns an Auditor that writes every entry to w as a line
+// of JSON. Errors writing to w are ignored: use an AuditorFunc if requests
+// must fail when they can not be audited.
+func NewJSONAuditor(w io.Writer) Auditor {
+	return &jsonAuditor{enc: json.NewEncoder(w)}
+}
+
+type jsonAuditor struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (a *jsonAuditor) Audit(e *AuditEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.enc.Encode(e)
+}
+
+// isMutating reports whether requests with method may change data.
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// auditedRequest sends the request and records it with c.Audit.
+func (c *DefaultClient) auditedRequest(v interface{}, method, path string, data interface{}) (int, error) {
+	clock := ClockOf(c)
+	start := clock.Now()
+
+	status, err := c.request(v, method, path, data)
+
+	url := path
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		url = Endpoint + "/" + url
+	}
+
+	entry := &AuditEntry{
+		Time:       start,
+		Duration:   clock.Now().Sub(start),
+		Key:        maskKey(c.AccessKey),
+		Method:     method,
+		URL:        redactURL(url),
+		Summary:    summarize(data),
+		StatusCode: status,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	c.Audit.Audit(entry)
+
+	return status, err
+}
+
+// maskKey returns the type and last four characters of accessKey.
+func maskKey(accessKey string) string {
+	t := KeyTypeOf(accessKey)
+	if len(accessKey) <= 8 {
+		return t.String() + "_..."
+	}
+
+	return t.String() + "_..." + accessKey[len(accessKey)-4:]
+}
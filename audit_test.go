@@ -0,0 +1,68 @@
+package messagebird
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	c := newTestClient(http.StatusOK, `{"id":"id"}`)
+	c.AccessKey = "live_abcdefghijkl"
+
+	var entries []*AuditEntry
+	c.Audit = AuditorFunc(func(e *AuditEntry) {
+		entries = append(entries, e)
+	})
+
+	assert.NoError(t, c.Request(nil, http.MethodGet, "messages/id", nil))
+	assert.Empty(t, entries)
+
+	data := map[string]interface{}{"body": "Hello", "recipients": []string{"31612345678"}}
+	assert.NoError(t, c.Request(nil, http.MethodPost, "messages?token=secret", data))
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "live_...ijkl", entries[0].Key)
+		assert.Equal(t, http.MethodPost, entries[0].Method)
+		assert.Equal(t, Endpoint+"/messages", entries[0].URL)
+		assert.Equal(t, "body recipients[1]", entries[0].Summary)
+		assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+		assert.Empty(t, entries[0].Error)
+		assert.False(t, entries[0].Time.IsZero())
+	}
+
+	c = newTestClient(http.StatusUnprocessableEntity, `{"errors":[{"code":9,"description":"no (correct) recipients found"}]}`)
+	entries = nil
+	c.Audit = AuditorFunc(func(e *AuditEntry) {
+		entries = append(entries, e)
+	})
+
+	assert.Error(t, c.Request(nil, http.MethodDelete, "https://contacts.messagebird.com/v2/contacts/31612345678", nil))
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "test_...skey", entries[0].Key)
+		assert.Equal(t, "https://contacts.messagebird.com/v2/contacts/3161*******", entries[0].URL)
+		assert.Equal(t, http.StatusUnprocessableEntity, entries[0].StatusCode)
+		assert.Equal(t, "API errors: no (correct) recipients found", entries[0].Error)
+	}
+}
+
+func TestJSONAuditor(t *testing.T) {
+	var buf bytes.Buffer
+	c := newTestClient(http.StatusNoContent, ``)
+	c.Audit = NewJSONAuditor(&buf)
+
+	assert.NoError(t, c.Request(nil, http.MethodDelete, "messages/id", nil))
+	assert.NoError(t, c.Request(nil, http.MethodDelete, "messages/id2", nil))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if assert.Len(t, lines, 2) {
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal(lines[1], &entry))
+		assert.Equal(t, "DELETE", entry["method"])
+		assert.Equal(t, Endpoint+"/messages/id2", entry["url"])
+		assert.Equal(t, float64(http.StatusNoContent), entry["statusCode"])
+		assert.NotContains(t, entry, "summary")
+	}
+}
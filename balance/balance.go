@@ -6,11 +6,37 @@ import (
 	messagebird "github.com/messagebird/go-rest-api/v9"
 )
 
+// Possible values of Balance.Payment.
+const (
+	PaymentPrepaid  = "prepaid"
+	PaymentPostpaid = "postpaid"
+)
+
+// Possible values of Balance.Type.
+const (
+	TypeCredits = "credits"
+	TypeEuros   = "euros"
+)
+
 // Balance describes your balance information.
 type Balance struct {
 	Payment string
 	Type    string
 	Amount  float32
+
+	// Limit is the credit limit of postpaid accounts. It is nil when the
+	// API does not provide one.
+	Limit *float32
+}
+
+// IsPrepaid reports whether the account pays upfront for its usage.
+func (b *Balance) IsPrepaid() bool {
+	return b.Payment == PaymentPrepaid
+}
+
+// IsPostpaid reports whether the account is invoiced for its usage afterwards.
+func (b *Balance) IsPostpaid() bool {
+	return b.Payment == PaymentPostpaid
 }
 
 const path = "balance"
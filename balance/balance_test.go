@@ -44,3 +44,18 @@ func TestReadError(t *testing.T) {
 
 	assert.Equal(t, "access_key", errorResponse.Errors[0].Parameter)
 }
+
+func TestReadPostpaid(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "balancePostpaid.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	balance, err := Read(client)
+	assert.NoError(t, err)
+
+	assert.True(t, balance.IsPostpaid())
+	assert.Equal(t, TypeEuros, balance.Type)
+	assert.EqualValues(t, -120.5, balance.Amount)
+	if assert.NotNil(t, balance.Limit) {
+		assert.EqualValues(t, 500, *balance.Limit)
+	}
+}
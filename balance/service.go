@@ -0,0 +1,24 @@
+package balance
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Service is the Balance API bound to a client, see sms.Service.
+type Service interface {
+	Read() (*Balance, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Read() (*Balance, error) {
+	return Read(s.c)
+}
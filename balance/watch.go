@@ -0,0 +1,89 @@
+package balance
+
+import (
+	"context"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// EventType describes why an Event was emitted.
+type EventType int
+
+const (
+	// EventLow is emitted when the balance drops below the threshold.
+	EventLow EventType = iota
+
+	// EventRecovered is emitted when the balance is at or above the
+	// threshold again after an EventLow, e.g. after topping up.
+	EventRecovered
+
+	// EventError is emitted when the balance could not be read.
+	EventError
+)
+
+// DefaultInterval is the interval Watch and Notify read the balance at if
+// the interval they are given is not positive.
+const DefaultInterval = time.Minute
+
+// Event is emitted by Watch and Notify. Balance is nil for EventError.
+type Event struct {
+	Type    EventType
+	Balance *Balance
+	Err     error
+}
+
+// Watch reads the balance every interval, or DefaultInterval if interval is
+// zero or negative, and emits an Event when the amount drops below threshold
+// or recovers from it. If the balance is already below threshold when
+// watching starts, EventLow is emitted straight away. Errors are emitted as
+// EventError and do not stop watching.
+//
+// The returned channel is closed once ctx is done. Reading the balance waits
+// for the receiver to take the previous Event, so none are lost.
+func Watch(ctx context.Context, c messagebird.Client, threshold float32, interval time.Duration) <-chan Event {
+	events := make(chan Event, 1)
+
+	go func() {
+		defer close(events)
+
+		_ = Notify(ctx, c, threshold, interval, func(e Event) {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return events
+}
+
+// Notify is like Watch, but calls fn for every Event instead of sending it
+// over a channel. It blocks until ctx is done and returns ctx.Err().
+func Notify(ctx context.Context, c messagebird.Client, threshold float32, interval time.Duration, fn func(Event)) error {
+	clock := messagebird.ClockOf(c)
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	low := false
+	for {
+		balance, err := Read(c)
+		switch {
+		case err != nil:
+			fn(Event{Type: EventError, Err: err})
+		case balance.Amount < threshold && !low:
+			low = true
+			fn(Event{Type: EventLow, Balance: balance})
+		case balance.Amount >= threshold && low:
+			low = false
+			fn(Event{Type: EventRecovered, Balance: balance})
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceClient returns the next amount of a predefined sequence for every
+// balance request, and the last one once the sequence is exhausted. A
+// negative amount results in an error.
+type sequenceClient struct {
+	amounts []float32
+	calls   int
+	cancel  context.CancelFunc
+}
+
+func (c *sequenceClient) Request(v interface{}, method, path string, data interface{}) error {
+	amount := c.amounts[len(c.amounts)-1]
+	if c.calls < len(c.amounts) {
+		amount = c.amounts[c.calls]
+	}
+	c.calls++
+	if c.calls == len(c.amounts) {
+		c.cancel()
+	}
+
+	if amount < 0 {
+		return errors.New("unavailable")
+	}
+
+	v.(*Balance).Amount = amount
+	return nil
+}
+
+func TestNotify(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &sequenceClient{
+		amounts: []float32{12, 8, 7, -1, 15, 3},
+		cancel:  cancel,
+	}
+
+	var events []Event
+	err := Notify(ctx, client, 10, time.Millisecond, func(e Event) {
+		events = append(events, e)
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	assert.Len(t, events, 4)
+	assert.Equal(t, EventLow, events[0].Type)
+	assert.EqualValues(t, 8, events[0].Balance.Amount)
+	assert.Equal(t, EventError, events[1].Type)
+	assert.EqualError(t, events[1].Err, "unavailable")
+	assert.Equal(t, EventRecovered, events[2].Type)
+	assert.EqualValues(t, 15, events[2].Balance.Amount)
+	assert.Equal(t, EventLow, events[3].Type)
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &sequenceClient{
+		amounts: []float32{5},
+		cancel:  func() {},
+	}
+
+	events := Watch(ctx, client, 10, time.Hour)
+
+	e := <-events
+	assert.Equal(t, EventLow, e.Type)
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestWatchSlowReceiver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &sequenceClient{
+		amounts: []float32{-1, 5},
+		cancel:  func() {},
+	}
+
+	events := Watch(ctx, client, 10, time.Millisecond)
+
+	// Give Watch time to read the balance a few times before receiving.
+	time.Sleep(20 * time.Millisecond)
+
+	for _, want := range []EventType{EventError, EventLow} {
+		select {
+		case e := <-events:
+			assert.Equal(t, want, e.Type)
+		case <-time.After(time.Second):
+			t.Fatalf("no event received, want %d", want)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestNotifyDefaultInterval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := &sequenceClient{
+		amounts: []float32{12, 12},
+		cancel:  func() {},
+	}
+
+	err := Notify(ctx, client, 10, 0, func(Event) {})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestPayment(t *testing.T) {
+	assert.True(t, (&Balance{Payment: PaymentPrepaid}).IsPrepaid())
+	assert.False(t, (&Balance{Payment: PaymentPrepaid}).IsPostpaid())
+	assert.True(t, (&Balance{Payment: PaymentPostpaid}).IsPostpaid())
+}
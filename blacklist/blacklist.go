@@ -0,0 +1,65 @@
+// Package blacklist manages the recipients that opted out of receiving
+// messages. Messages to blacklisted recipients are rejected by the API: use
+// FilteringClient to drop them before sending, based on a local Store.
+package blacklist
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// path represents the path to the blacklisted recipients resource.
+const path = "blacklist/recipients"
+
+// errCodeNotFound is the API error code for resources that do not exist.
+const errCodeNotFound = 20
+
+type addRequest struct {
+	MSISDNs []string `json:"msisdns"`
+}
+
+// Add blacklists msisdns, so no messages can be sent to them.
+func Add(c messagebird.Client, msisdns []string) error {
+	if len(msisdns) == 0 {
+		return errors.New("at least one msisdn is required")
+	}
+
+	normalized := make([]string, 0, len(msisdns))
+	for _, msisdn := range msisdns {
+		normalized = append(normalized, normalize(msisdn))
+	}
+
+	return c.Request(nil, http.MethodPost, path, &addRequest{normalized})
+}
+
+// Remove removes msisdn from the blacklist.
+func Remove(c messagebird.Client, msisdn string) error {
+	return c.Request(nil, http.MethodDelete, path+"/"+normalize(msisdn), nil)
+}
+
+// Contains reports whether msisdn is blacklisted.
+func Contains(c messagebird.Client, msisdn string) (bool, error) {
+	err := c.Request(&struct{}{}, http.MethodGet, path+"/"+normalize(msisdn), nil)
+	if err == nil {
+		return true, nil
+	}
+
+	var response messagebird.ErrorResponse
+	if errors.As(err, &response) {
+		for _, e := range response.Errors {
+			if e.Code == errCodeNotFound {
+				return false, nil
+			}
+		}
+	}
+
+	return false, err
+}
+
+// normalize strips the + of MSISDNs in E.164 format, as the API expects.
+func normalize(msisdn string) string {
+	return strings.TrimPrefix(strings.TrimSpace(msisdn), "+")
+}
@@ -0,0 +1,67 @@
+package blacklist
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestAdd(t *testing.T) {
+	mbtest.WillReturnOnlyStatus(http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := Add(client, []string{"+31612345678", "31687654321"})
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/blacklist/recipients")
+	assert.JSONEq(t, `{"msisdns":["31612345678","31687654321"]}`, string(mbtest.LastRequest().Body))
+}
+
+func TestRemove(t *testing.T) {
+	mbtest.WillReturnOnlyStatus(http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := Remove(client, "+31612345678")
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/blacklist/recipients/31612345678")
+}
+
+func TestContains(t *testing.T) {
+	client := mbtest.Client(t)
+
+	mbtest.WillReturn([]byte(`{"msisdn":31612345678}`), http.StatusOK)
+	blacklisted, err := Contains(client, "31612345678")
+	assert.NoError(t, err)
+	assert.True(t, blacklisted)
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/blacklist/recipients/31612345678")
+
+	mbtest.WillReturn([]byte(`{"errors":[{"code":20,"description":"blacklist entry not found","parameter":null}]}`), http.StatusNotFound)
+	blacklisted, err = Contains(client, "31612345678")
+	assert.NoError(t, err)
+	assert.False(t, blacklisted)
+
+	mbtest.WillReturnAccessKeyError()
+	_, err = Contains(client, "31612345678")
+	assert.Error(t, err)
+}
+
+func TestContainsWrapErrors(t *testing.T) {
+	client := mbtest.Client(t)
+	client.WrapErrors = true
+
+	mbtest.WillReturn([]byte(`{"errors":[{"code":20,"description":"blacklist entry not found","parameter":null}]}`), http.StatusNotFound)
+	blacklisted, err := Contains(client, "31612345678")
+	assert.NoError(t, err)
+	assert.False(t, blacklisted)
+
+	mbtest.WillReturnAccessKeyError()
+	_, err = Contains(client, "31612345678")
+	assert.Error(t, err)
+}
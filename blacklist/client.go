@@ -0,0 +1,113 @@
+package blacklist
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// sendPaths are the paths of the resources that send messages, relative to
+// messagebird.Endpoint.
+var sendPaths = map[string]bool{
+	"messages":      true,
+	"mms":           true,
+	"voicemessages": true,
+}
+
+// FilteringClient is a messagebird.Client that removes opted out recipients
+// from SMS, MMS and voice messages before they are sent, and converts errors
+// about blacklisted recipients with DecodeError. All other requests are
+// passed to Client unchanged.
+//
+//	client := &blacklist.FilteringClient{Client: messagebird.New(accessKey), Store: store}
+//	sms.Create(client, originator, recipients, body, nil)
+//
+// If all recipients of a message opted out, the message is not sent and an
+// *Error listing them is returned.
+type FilteringClient struct {
+	Client messagebird.Client
+	Store  Store
+}
+
+// Request implements messagebird.Client.
+func (fc *FilteringClient) Request(v interface{}, method, path string, data interface{}) error {
+	if method != http.MethodPost || !sendPaths[path] || data == nil {
+		return fc.Client.Request(v, method, path, data)
+	}
+
+	filtered, err := fc.filter(data)
+	if err != nil {
+		return err
+	}
+
+	return DecodeError(fc.Client.Request(v, method, path, filtered))
+}
+
+// Unwrap implements messagebird.Wrapper.
+func (fc *FilteringClient) Unwrap() messagebird.Client {
+	return fc.Client
+}
+
+// filter removes opted out recipients from the request. The message types use
+// different request types, so the request is converted to a map first.
+func (fc *FilteringClient) filter(data interface{}) (interface{}, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var req map[string]interface{}
+	if err := json.Unmarshal(b, &req); err != nil {
+		return nil, err
+	}
+
+	// Recipients are sent as a list, or as a comma separated string.
+	var recipients []string
+	var joined bool
+	switch r := req["recipients"].(type) {
+	case []interface{}:
+		for _, recipient := range r {
+			if s, ok := recipient.(string); ok {
+				recipients = append(recipients, s)
+			}
+		}
+	case string:
+		recipients, joined = strings.Split(r, ","), true
+	default:
+		return data, nil
+	}
+
+	kept, optedOut := []string{}, []string{}
+	for _, recipient := range recipients {
+		out, err := fc.Store.IsOptedOut(strings.TrimSpace(recipient))
+		if err != nil {
+			return nil, err
+		}
+
+		if out {
+			optedOut = append(optedOut, recipient)
+		} else {
+			kept = append(kept, recipient)
+		}
+	}
+
+	if len(optedOut) == 0 {
+		return data, nil
+	}
+
+	// Messages to groups are still sent, even if none of the recipients is.
+	groupIDs, _ := req["groupIds"].([]interface{})
+	if len(kept) == 0 && len(groupIDs) == 0 {
+		return nil, &Error{Recipients: optedOut}
+	}
+
+	if joined {
+		req["recipients"] = strings.Join(kept, ",")
+	} else {
+		req["recipients"] = kept
+	}
+
+	return req, nil
+}
@@ -0,0 +1,134 @@
+package blacklist
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/mms"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingClient records the JSON body of the last request.
+type recordingClient struct {
+	path string
+	body string
+	err  error
+}
+
+func (rc *recordingClient) Request(v interface{}, method, path string, data interface{}) error {
+	b, _ := json.Marshal(data)
+	rc.path, rc.body = path, string(b)
+	return rc.err
+}
+
+func newFilteringClient() (*FilteringClient, *recordingClient) {
+	store := NewMemoryStore()
+	store.OptOut("+31600000000")
+
+	rc := &recordingClient{}
+	return &FilteringClient{Client: rc, Store: store}, rc
+}
+
+func recipients(t *testing.T, body string) interface{} {
+	var req map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &req))
+	return req["recipients"]
+}
+
+func TestFilteringClientSMS(t *testing.T) {
+	client, rc := newFilteringClient()
+
+	_, err := sms.Create(client, "Acme", []string{"31612345678", "31600000000"}, "Hello", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "messages", rc.path)
+	assert.Equal(t, []interface{}{"31612345678"}, recipients(t, rc.body))
+}
+
+func TestFilteringClientMMS(t *testing.T) {
+	client, rc := newFilteringClient()
+
+	_, err := mms.Create(client, &mms.CreateRequest{Recipients: "31600000000,31612345678", Body: "Hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, "31612345678", recipients(t, rc.body))
+}
+
+func TestFilteringClientAllOptedOut(t *testing.T) {
+	client, rc := newFilteringClient()
+
+	_, err := sms.Create(client, "Acme", []string{"31600000000"}, "Hello", nil)
+	assert.True(t, errors.Is(err, ErrBlacklisted))
+	assert.Equal(t, "recipient is blacklisted: 31600000000", err.Error())
+	assert.Empty(t, rc.path)
+
+	// Groups are still sent to.
+	_, err = sms.Create(client, "Acme", []string{"31600000000"}, "Hello", &sms.Params{GroupIds: []string{"group-id"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{}, recipients(t, rc.body))
+}
+
+func TestFilteringClientOtherRequests(t *testing.T) {
+	client, rc := newFilteringClient()
+
+	_, err := sms.Read(client, "message-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "messages/message-id", rc.path)
+}
+
+func TestFilteringClientDecodesErrors(t *testing.T) {
+	client, rc := newFilteringClient()
+	rc.err = messagebird.ErrorResponse{Errors: []messagebird.Error{
+		{Code: 9, Description: "Recipient is blacklisted", Parameter: "31612345678"},
+	}}
+
+	_, err := sms.Create(client, "Acme", []string{"31612345678"}, "Hello", nil)
+	assert.True(t, errors.Is(err, ErrBlacklisted))
+
+	var blacklisted *Error
+	assert.True(t, errors.As(err, &blacklisted))
+	assert.Equal(t, []string{"31612345678"}, blacklisted.Recipients)
+
+	var response messagebird.ErrorResponse
+	assert.True(t, errors.As(err, &response))
+}
+
+func TestDecodeErrorOther(t *testing.T) {
+	err := messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 2, Description: "Request not allowed"}}}
+	assert.Equal(t, err, DecodeError(err))
+	assert.Nil(t, DecodeError(nil))
+}
+
+func TestFilteringClientDecodesWrappedErrors(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"errors":[{"code":9,"description":"Recipient is blacklisted","parameter":"31612345678"}]}`), http.StatusUnprocessableEntity)
+	c := mbtest.Client(t)
+	c.WrapErrors = true
+	client := &FilteringClient{Client: c, Store: NewMemoryStore()}
+
+	_, err := sms.Create(client, "Acme", []string{"31612345678"}, "Hello", nil)
+	assert.True(t, errors.Is(err, ErrBlacklisted))
+
+	var blacklisted *Error
+	if assert.True(t, errors.As(err, &blacklisted)) {
+		assert.Equal(t, []string{"31612345678"}, blacklisted.Recipients)
+	}
+
+	var requestErr *messagebird.RequestError
+	assert.True(t, errors.As(err, &requestErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, requestErr.StatusCode)
+}
+
+func TestFilteringClientUnwrap(t *testing.T) {
+	c := messagebird.New("test_accesskey")
+	c.RequireConsent = true
+	c.Clock = messagebirdtest.NewFakeClock(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+	client := &FilteringClient{Client: c, Store: NewMemoryStore()}
+
+	assert.True(t, messagebird.RequiresConsent(client))
+	assert.Equal(t, c.Clock, messagebird.ClockOf(client))
+}
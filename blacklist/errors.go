@@ -0,0 +1,77 @@
+package blacklist
+
+import (
+	"errors"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// ErrBlacklisted is matched by errors returned when a message could not be
+// sent because its recipients are blacklisted.
+var ErrBlacklisted = errors.New("recipient is blacklisted")
+
+// Error is returned when a message could not be sent because its recipients
+// are blacklisted. Use errors.Is(err, ErrBlacklisted) to check for it.
+type Error struct {
+	// Recipients are the blacklisted recipients, if known.
+	Recipients []string
+
+	response error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := ErrBlacklisted.Error()
+	if len(e.Recipients) > 0 {
+		msg += ": " + strings.Join(e.Recipients, ", ")
+	}
+	if e.response != nil {
+		msg += ": " + e.response.Error()
+	}
+
+	return msg
+}
+
+// Is reports whether target is ErrBlacklisted.
+func (e *Error) Is(target error) bool {
+	return target == ErrBlacklisted
+}
+
+// Unwrap returns the error the API responded with, if any. It is a
+// messagebird.ErrorResponse, or a *messagebird.RequestError wrapping one if
+// the client wraps errors.
+func (e *Error) Unwrap() error {
+	return e.response
+}
+
+// DecodeError converts err into an *Error if the API rejected a message
+// because of blacklisted recipients. The API does not use a dedicated error
+// code for this, so the descriptions are inspected. Any other error is
+// returned as-is.
+func DecodeError(err error) error {
+	var response messagebird.ErrorResponse
+	if !errors.As(err, &response) {
+		return err
+	}
+
+	var blacklisted *Error
+	for _, e := range response.Errors {
+		if !strings.Contains(strings.ToLower(e.Description), "blacklist") {
+			continue
+		}
+
+		if blacklisted == nil {
+			blacklisted = &Error{response: err}
+		}
+		if e.Parameter != "" && e.Parameter != "recipients" {
+			blacklisted.Recipients = append(blacklisted.Recipients, e.Parameter)
+		}
+	}
+
+	if blacklisted == nil {
+		return err
+	}
+
+	return blacklisted
+}
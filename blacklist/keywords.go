@@ -0,0 +1,223 @@
+package blacklist
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"unicode"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+// Keyword is the meaning of a compliance keyword sent by a recipient.
+type Keyword string
+
+const (
+	// KeywordNone is returned for messages that are not a keyword.
+	KeywordNone Keyword = ""
+
+	// KeywordStop requests to no longer receive messages.
+	KeywordStop Keyword = "stop"
+
+	// KeywordStart requests to receive messages again.
+	KeywordStart Keyword = "start"
+
+	// KeywordHelp requests information about the messages.
+	KeywordHelp Keyword = "help"
+)
+
+// DefaultKeywords lists the keywords recognized per locale.
+var DefaultKeywords = map[string]map[Keyword][]string{
+	"en": {
+		KeywordStop:  {"STOP", "STOPALL", "UNSUBSCRIBE", "CANCEL", "END", "QUIT"},
+		KeywordStart: {"START", "YES", "UNSTOP", "SUBSCRIBE"},
+		KeywordHelp:  {"HELP", "INFO"},
+	},
+	"nl": {
+		KeywordStop:  {"STOP", "AFMELDEN", "UITSCHRIJVEN"},
+		KeywordStart: {"START", "AANMELDEN"},
+		KeywordHelp:  {"HELP", "INFO"},
+	},
+	"de": {
+		KeywordStop:  {"STOP", "STOPP", "ABMELDEN"},
+		KeywordStart: {"START", "ANMELDEN"},
+		KeywordHelp:  {"HILFE", "INFO"},
+	},
+	"fr": {
+		KeywordStop:  {"STOP", "ARRET", "ARRÊT", "DESABONNER"},
+		KeywordStart: {"START", "ABONNER"},
+		KeywordHelp:  {"AIDE", "INFO"},
+	},
+	"es": {
+		KeywordStop:  {"STOP", "BAJA", "PARAR"},
+		KeywordStart: {"START", "ALTA"},
+		KeywordHelp:  {"AYUDA", "INFO"},
+	},
+}
+
+// KeywordHandler handles STOP, START and HELP keywords in inbound SMS
+// messages: it updates the Store and, optionally, the blacklist, and replies
+// with the configured confirmation.
+//
+// KeywordHandler can be mounted as the webhook for inbound messages. Messages
+// that are not a keyword are passed to Next.
+type KeywordHandler struct {
+	// Client is used to update the blacklist and send replies. It must not
+	// be a FilteringClient using Store, as the STOP confirmation would be
+	// filtered.
+	Client messagebird.Client
+
+	// Store records opt-outs and opt-ins.
+	Store Store
+
+	// SyncBlacklist also adds recipients to the blacklist of your account on
+	// STOP, and removes them on START.
+	SyncBlacklist bool
+
+	// Locales are the locales in DefaultKeywords to recognize keywords for.
+	// Defaults to all locales.
+	Locales []string
+
+	// Keywords are recognized in addition to the ones of Locales.
+	Keywords map[Keyword][]string
+
+	// Replies are sent back to the recipient for each keyword. No reply is
+	// sent for keywords without one.
+	Replies map[Keyword]string
+
+	// Next handles inbound messages that are not a keyword. If nil, they are
+	// acknowledged and ignored.
+	Next http.Handler
+
+	// ErrorLog, if set, is called when handling a keyword fails.
+	ErrorLog func(err error)
+}
+
+// Match returns the keyword body represents, or KeywordNone. Only messages
+// that consist of just the keyword are matched, ignoring case, surrounding
+// whitespace and punctuation.
+func (h *KeywordHandler) Match(body string) Keyword {
+	word := strings.ToUpper(strings.TrimFunc(body, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}))
+	if word == "" {
+		return KeywordNone
+	}
+
+	match := func(keywords map[Keyword][]string) Keyword {
+		for keyword, words := range keywords {
+			for _, w := range words {
+				if w == word {
+					return keyword
+				}
+			}
+		}
+		return KeywordNone
+	}
+
+	if keyword := match(h.Keywords); keyword != KeywordNone {
+		return keyword
+	}
+
+	locales := h.Locales
+	if len(locales) == 0 {
+		for locale := range DefaultKeywords {
+			locales = append(locales, locale)
+		}
+	}
+
+	for _, locale := range locales {
+		if keyword := match(DefaultKeywords[locale]); keyword != KeywordNone {
+			return keyword
+		}
+	}
+
+	return KeywordNone
+}
+
+// Handle processes msg and returns the keyword it contained, if any.
+func (h *KeywordHandler) Handle(msg *sms.InboundMessage) (Keyword, error) {
+	keyword := h.Match(msg.Body)
+
+	var err error
+	switch keyword {
+	case KeywordNone:
+		return keyword, nil
+	case KeywordStop:
+		err = h.stop(msg.Originator)
+	case KeywordStart:
+		err = h.start(msg.Originator)
+	}
+	if err != nil {
+		return keyword, err
+	}
+
+	if reply := h.Replies[keyword]; reply != "" && msg.Recipient != "" {
+		if _, err := sms.Create(h.Client, msg.Recipient, []string{msg.Originator}, reply, nil); err != nil {
+			return keyword, err
+		}
+	}
+
+	return keyword, nil
+}
+
+func (h *KeywordHandler) stop(msisdn string) error {
+	if err := h.Store.OptOut(msisdn); err != nil {
+		return err
+	}
+
+	if h.SyncBlacklist {
+		return Add(h.Client, []string{msisdn})
+	}
+
+	return nil
+}
+
+func (h *KeywordHandler) start(msisdn string) error {
+	if err := h.Store.OptIn(msisdn); err != nil {
+		return err
+	}
+
+	if h.SyncBlacklist {
+		return Remove(h.Client, msisdn)
+	}
+
+	return nil
+}
+
+// ServeHTTP implements http.Handler. It responds with 400 Bad Request if the
+// request is not an inbound SMS, and with 500 Internal Server Error if the
+// keyword could not be handled, so MessageBird retries the webhook.
+func (h *KeywordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Keep the body around, so Next can read it too.
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	msg, err := sms.ParseInbound(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.Match(msg.Body) == KeywordNone && h.Next != nil {
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		h.Next.ServeHTTP(w, r)
+		return
+	}
+
+	if _, err := h.Handle(msg); err != nil {
+		if h.ErrorLog != nil {
+			h.ErrorLog(err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,104 @@
+package blacklist
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+// requestLog is a messagebird.Client that records requests.
+type requestLog struct {
+	requests []string
+	err      error
+}
+
+func (rl *requestLog) Request(v interface{}, method, path string, data interface{}) error {
+	rl.requests = append(rl.requests, method+" "+path)
+	return rl.err
+}
+
+func TestKeywordHandlerMatch(t *testing.T) {
+	h := &KeywordHandler{Keywords: map[Keyword][]string{KeywordStop: {"STOPPEN"}}}
+
+	assert.Equal(t, KeywordStop, h.Match(" stop! "))
+	assert.Equal(t, KeywordStop, h.Match("Stoppen"))
+	assert.Equal(t, KeywordStop, h.Match("afmelden"))
+	assert.Equal(t, KeywordStart, h.Match("START"))
+	assert.Equal(t, KeywordHelp, h.Match("hilfe"))
+	assert.Equal(t, KeywordNone, h.Match("please stop"))
+	assert.Equal(t, KeywordNone, h.Match(""))
+
+	h.Locales = []string{"en"}
+	assert.Equal(t, KeywordNone, h.Match("afmelden"))
+}
+
+func TestKeywordHandlerHandle(t *testing.T) {
+	client := &requestLog{}
+	store := NewMemoryStore()
+	h := &KeywordHandler{
+		Client:        client,
+		Store:         store,
+		SyncBlacklist: true,
+		Replies:       map[Keyword]string{KeywordStop: "You will no longer receive messages."},
+	}
+
+	keyword, err := h.Handle(&sms.InboundMessage{Originator: "31612345678", Recipient: "Acme", Body: "STOP"})
+	assert.NoError(t, err)
+	assert.Equal(t, KeywordStop, keyword)
+
+	optedOut, _ := store.IsOptedOut("31612345678")
+	assert.True(t, optedOut)
+	assert.Equal(t, []string{"POST blacklist/recipients", "POST messages"}, client.requests)
+
+	client.requests = nil
+	keyword, err = h.Handle(&sms.InboundMessage{Originator: "31612345678", Recipient: "Acme", Body: "start"})
+	assert.NoError(t, err)
+	assert.Equal(t, KeywordStart, keyword)
+
+	optedOut, _ = store.IsOptedOut("31612345678")
+	assert.False(t, optedOut)
+	assert.Equal(t, []string{"DELETE blacklist/recipients/31612345678"}, client.requests)
+}
+
+func TestKeywordHandlerServeHTTP(t *testing.T) {
+	client := &requestLog{}
+	var next string
+	h := &KeywordHandler{
+		Client: client,
+		Store:  NewMemoryStore(),
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			next = string(b)
+		}),
+	}
+
+	serve := func(body string) int {
+		r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(`{"id":"1","originator":"31612345678","body":"Hello"}`))
+	assert.Equal(t, `{"id":"1","originator":"31612345678","body":"Hello"}`, next)
+
+	assert.Equal(t, http.StatusOK, serve(`{"id":"2","originator":"31612345678","body":"STOP"}`))
+	optedOut, _ := h.Store.IsOptedOut("31612345678")
+	assert.True(t, optedOut)
+
+	assert.Equal(t, http.StatusBadRequest, serve(`{}`))
+
+	var logged error
+	h.ErrorLog = func(err error) { logged = err }
+	h.SyncBlacklist = true
+	client.err = errors.New("API error")
+	assert.Equal(t, http.StatusInternalServerError, serve(`{"id":"3","originator":"31612345678","body":"STOP"}`))
+	assert.EqualError(t, logged, "API error")
+}
@@ -0,0 +1,54 @@
+package blacklist
+
+import "sync"
+
+// Store keeps track of recipients that opted out, so they can be filtered
+// before sending. Implementations must be safe for concurrent use.
+type Store interface {
+	// IsOptedOut reports whether msisdn opted out.
+	IsOptedOut(msisdn string) (bool, error)
+
+	// OptOut records that msisdn opted out.
+	OptOut(msisdn string) error
+
+	// OptIn records that msisdn opted in again.
+	OptIn(msisdn string) error
+}
+
+// MemoryStore is a Store that keeps opt-outs in memory. They are lost when
+// the process exits.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	msisdns map[string]bool
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{msisdns: make(map[string]bool)}
+}
+
+// IsOptedOut implements Store.
+func (s *MemoryStore) IsOptedOut(msisdn string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.msisdns[normalize(msisdn)], nil
+}
+
+// OptOut implements Store.
+func (s *MemoryStore) OptOut(msisdn string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.msisdns[normalize(msisdn)] = true
+	return nil
+}
+
+// OptIn implements Store.
+func (s *MemoryStore) OptIn(msisdn string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.msisdns, normalize(msisdn))
+	return nil
+}
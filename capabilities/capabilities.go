@@ -0,0 +1,239 @@
+// Package capabilities reads what the account may send to each destination
+// country: which features are available, which types of originators are
+// allowed and which need to be registered first. Use it to validate
+// campaigns before submitting them:
+//
+//	country, err := capabilities.Read(client, "US")
+//	if err != nil {
+//		return err
+//	}
+//	if err := country.Validate("MessageBird"); err != nil {
+//		// The originator can not be used in the US, e.g. because
+//		// alphanumeric originators are not allowed.
+//	}
+package capabilities
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+const (
+	// path is the path of the capabilities, relative to the REST API.
+	path = "capabilities"
+
+	// countriesPath is the path of the capabilities per country, relative
+	// to path.
+	countriesPath = "countries"
+
+	// defaultsPath is the path of the defaults of the account, relative to
+	// path.
+	defaultsPath = "defaults"
+)
+
+var (
+	// ErrOriginatorNotAllowed is returned by Validate for originators of a
+	// type that can not be used in the country.
+	ErrOriginatorNotAllowed = errors.New("originator type not allowed")
+
+	// ErrRegistrationRequired is returned by Validate for originators that
+	// must be registered before they can be used in the country, but are
+	// not.
+	ErrRegistrationRequired = errors.New("originator registration required")
+)
+
+// OriginatorType is the kind of originator messages are sent from.
+type OriginatorType string
+
+const (
+	// OriginatorTypeAlphanumeric is a sender ID of up to 11 characters
+	// with at least one letter, e.g. "MessageBird".
+	OriginatorTypeAlphanumeric OriginatorType = "alphanumeric"
+
+	// OriginatorTypeShortcode is a number of up to 6 digits.
+	OriginatorTypeShortcode OriginatorType = "shortcode"
+
+	// OriginatorTypeNumeric is a phone number in international format.
+	OriginatorTypeNumeric OriginatorType = "numeric"
+)
+
+// Features a country can support.
+const (
+	FeatureSMS             = "sms"
+	FeatureMMS             = "mms"
+	FeatureVoice           = "voice"
+	FeatureUnicode         = "unicode"
+	FeatureDeliveryReports = "delivery_reports"
+)
+
+// Country holds the capabilities of the account for a destination country.
+type Country struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country, e.g. "NL".
+	Country string
+	Name    string
+
+	// Prefix is the calling code of the country, e.g. "31".
+	Prefix string
+
+	// Features are the features available in the country, e.g. FeatureSMS.
+	Features []string
+
+	// Originators are the rules for each type of originator.
+	Originators []*Originator
+
+	// RegisteredOriginators are the originators the account registered for
+	// the country.
+	RegisteredOriginators []string
+}
+
+// Originator holds whether a type of originator can be used in a country.
+type Originator struct {
+	Type    OriginatorType
+	Allowed bool
+
+	// RegistrationRequired is true if originators of the type must be
+	// registered before they can be used.
+	RegistrationRequired bool
+}
+
+// CountryList is a page of countries.
+type CountryList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Country
+}
+
+// Defaults holds the settings of the account that apply to messages to any
+// country.
+type Defaults struct {
+	// Originator is used for messages that are sent without one.
+	Originator     string
+	OriginatorType OriginatorType
+
+	// Country is the country of phone numbers in national format.
+	Country string
+}
+
+// Read retrieves the capabilities of the account for country, an ISO 3166-1
+// alpha-2 code.
+func Read(c messagebird.Client, country string) (*Country, error) {
+	if country == "" {
+		return nil, errors.New("country is required")
+	}
+
+	capabilities := &Country{}
+	if err := c.Request(capabilities, http.MethodGet, path+"/"+countriesPath+"/"+strings.ToUpper(country), nil); err != nil {
+		return nil, err
+	}
+
+	return capabilities, nil
+}
+
+// List retrieves the capabilities of the account for all countries.
+func List(c messagebird.Client, options *messagebird.PaginationRequest) (*CountryList, error) {
+	uri := path + "/" + countriesPath
+	if query := options.QueryParams(); query != "" {
+		uri += "?" + query
+	}
+
+	countries := &CountryList{}
+	if err := c.Request(countries, http.MethodGet, uri, nil); err != nil {
+		return nil, err
+	}
+
+	return countries, nil
+}
+
+// ReadDefaults retrieves the defaults of the account.
+func ReadDefaults(c messagebird.Client) (*Defaults, error) {
+	defaults := &Defaults{}
+	if err := c.Request(defaults, http.MethodGet, path+"/"+defaultsPath, nil); err != nil {
+		return nil, err
+	}
+
+	return defaults, nil
+}
+
+// Supports reports whether feature is available in the country.
+func (c *Country) Supports(feature string) bool {
+	for _, f := range c.Features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Originator returns the rules for originators of type t, or nil if the
+// country has none.
+func (c *Country) Originator(t OriginatorType) *Originator {
+	for _, o := range c.Originators {
+		if o.Type == t {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// Validate returns an error wrapping ErrOriginatorNotAllowed or
+// ErrRegistrationRequired if originator can not be used to send messages to
+// the country.
+func (c *Country) Validate(originator string) error {
+	if originator == "" {
+		return errors.New("originator is required")
+	}
+
+	t := OriginatorTypeOf(originator)
+
+	o := c.Originator(t)
+	if o == nil || !o.Allowed {
+		return fmt.Errorf("%w: %s originators in %s", ErrOriginatorNotAllowed, t, c.Country)
+	}
+
+	if o.RegistrationRequired && !c.registered(originator) {
+		return fmt.Errorf("%w: %q in %s", ErrRegistrationRequired, originator, c.Country)
+	}
+
+	return nil
+}
+
+func (c *Country) registered(originator string) bool {
+	for _, r := range c.RegisteredOriginators {
+		if strings.EqualFold(r, originator) || normalize(r) == normalize(originator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OriginatorTypeOf returns the type of originator: alphanumeric if it has a
+// character other than a digit or a leading +, a shortcode if it has at most
+// 6 digits and numeric otherwise.
+func OriginatorTypeOf(originator string) OriginatorType {
+	digits := normalize(originator)
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return OriginatorTypeAlphanumeric
+		}
+	}
+
+	if len(digits) <= 6 {
+		return OriginatorTypeShortcode
+	}
+
+	return OriginatorTypeNumeric
+}
+
+// normalize strips the leading + and spaces of numeric originators.
+func normalize(originator string) string {
+	return strings.Replace(strings.TrimPrefix(strings.TrimSpace(originator), "+"), " ", "", -1)
+}
@@ -0,0 +1,90 @@
+package capabilities
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "country.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	country, err := Read(client, "us")
+	assert.NoError(t, err)
+	assert.Equal(t, "US", country.Country)
+	assert.Equal(t, "1", country.Prefix)
+	assert.True(t, country.Supports(FeatureMMS))
+	assert.False(t, country.Supports("whatsapp"))
+	assert.Equal(t, &Originator{Type: OriginatorTypeShortcode, Allowed: true, RegistrationRequired: true}, country.Originator(OriginatorTypeShortcode))
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/capabilities/countries/US")
+
+	_, err = Read(client, "")
+	assert.EqualError(t, err, "country is required")
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "countryList.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	countries, err := List(client, &messagebird.PaginationRequest{Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, countries.TotalCount)
+	assert.Len(t, countries.Items, 2)
+	assert.Equal(t, "NL", countries.Items[0].Country)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/capabilities/countries")
+	assert.Equal(t, "limit=20", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestReadDefaults(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "defaults.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	defaults, err := ReadDefaults(client)
+	assert.NoError(t, err)
+	assert.Equal(t, &Defaults{Originator: "MessageBird", OriginatorType: OriginatorTypeAlphanumeric, Country: "NL"}, defaults)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/capabilities/defaults")
+}
+
+func TestValidate(t *testing.T) {
+	country := &Country{
+		Country: "US",
+		Originators: []*Originator{
+			{Type: OriginatorTypeAlphanumeric},
+			{Type: OriginatorTypeShortcode, Allowed: true},
+			{Type: OriginatorTypeNumeric, Allowed: true, RegistrationRequired: true},
+		},
+		RegisteredOriginators: []string{"+14155550100"},
+	}
+
+	assert.NoError(t, country.Validate("12345"))
+	assert.NoError(t, country.Validate("14155550100"))
+	assert.NoError(t, country.Validate("+1 415 555 0100"))
+
+	err := country.Validate("MessageBird")
+	assert.True(t, errors.Is(err, ErrOriginatorNotAllowed))
+	assert.EqualError(t, err, "originator type not allowed: alphanumeric originators in US")
+
+	err = country.Validate("+14155550199")
+	assert.True(t, errors.Is(err, ErrRegistrationRequired))
+
+	assert.EqualError(t, country.Validate(""), "originator is required")
+}
+
+func TestOriginatorTypeOf(t *testing.T) {
+	assert.Equal(t, OriginatorTypeAlphanumeric, OriginatorTypeOf("MessageBird"))
+	assert.Equal(t, OriginatorTypeAlphanumeric, OriginatorTypeOf("Bird 24"))
+	assert.Equal(t, OriginatorTypeShortcode, OriginatorTypeOf("2424"))
+	assert.Equal(t, OriginatorTypeNumeric, OriginatorTypeOf("+31 612345678"))
+}
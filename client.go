@@ -14,12 +14,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -32,6 +34,10 @@ const (
 
 	// httpClientTimeout is used to limit http.Client waiting time.
 	httpClientTimeout = 15 * time.Second
+
+	// maxPooledBufferSize is the capacity above which response buffers are
+	// not reused.
+	maxPooledBufferSize = 4 << 20
 )
 
 var (
@@ -39,6 +45,15 @@ var (
 	ErrUnexpectedResponse = errors.New("the MessageBird API is currently unavailable")
 )
 
+// bufferPool holds the buffers response bodies are read into. Reusing them
+// avoids growing a new buffer for every response, which dominates the
+// allocations of requests for large lists.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
 // A Feature can be enabled
 type Feature int
 
@@ -52,6 +67,60 @@ type DefaultClient struct {
 	AccessKey  string       // The API access key.
 	HTTPClient *http.Client // The HTTP client to send requests on.
 	DebugLog   *log.Logger  // Optional logger for debugging purposes.
+	Clock      Clock        // Optional clock, defaults to SystemClock.
+
+	// CoalesceGets makes concurrent, identical GET requests share a single
+	// request to the API, e.g. when many goroutines read the same
+	// conversation at once. Each caller still decodes its own copy of the
+	// response. It requires a client created with New.
+	CoalesceGets bool
+
+	// WrapErrors makes Request return every error as a *RequestError, which
+	// tells which request failed. The original error is available through
+	// errors.As and errors.Is, but not through a type assertion, so it is
+	// off by default. Errors of requests with a test key that the API
+	// rejected because of the key are wrapped in a *TestKeyError too.
+	WrapErrors bool
+
+	// APIVersions selects the version of the endpoints of an API by the
+	// name of the API, e.g. {"conversations": "v2"}. It only changes the
+	// paths of requests, see conversation.APIVersion2. APIs that are not in
+	// it use the version their package defaults to. Clients wrapping c
+	// keep them: see Wrapper.
+	APIVersions map[string]string
+
+	// RequireConsent enables compliance mode: helpers that send to
+	// contacts, such as contact.SendSMS, refuse contacts without a recorded
+	// opt-in for the channel. Clients wrapping c, e.g. a
+	// blacklist.FilteringClient, keep it: see Wrapper.
+	RequireConsent bool
+
+	// LenientTimes makes requests succeed when timestamps in the response
+	// can not be parsed, e.g. by the voice package, which then decodes them
+	// as the zero time. See LenientTimesOf.
+	LenientTimes bool
+
+	// Redaction, if set, masks personal data in the requests and responses
+	// logged to DebugLog, e.g. DefaultRedaction.
+	Redaction *RedactionPolicy
+
+	// Audit, if set, records every mutating request, i.e. every request
+	// but GET, HEAD and OPTIONS requests, after it completed. See
+	// NewJSONAuditor.
+	Audit Auditor
+
+	// BaseURL, if set, replaces Endpoint as the root of the REST API, e.g.
+	// for a proxy. Requests to other APIs are not affected.
+	BaseURL string
+
+	// Retry, if set, retries requests that failed because of network errors,
+	// rate limits or server errors. See RetryPolicy.
+	Retry *RetryPolicy
+
+	// Health, if set, tells whether the API is healthy, see Healthy.
+	Health HealthChecker
+
+	gets *callGroup
 }
 
 type contentType string
@@ -79,27 +148,64 @@ func New(accessKey string) *DefaultClient {
 		HTTPClient: &http.Client{
 			Timeout: httpClientTimeout,
 		},
+		gets: &callGroup{},
 	}
 }
 
-// Request is for internal use only and unstable.
+// Request is for internal use only and unstable. If v is an io.Writer, the
+// body of a successful response is written to it as is, instead of being
+// decoded as JSON.
 func (c *DefaultClient) Request(v interface{}, method, path string, data interface{}) error {
+	if c.BaseURL != "" && !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		path = strings.TrimSuffix(c.BaseURL, "/") + "/" + path
+	}
+
+	var status int
+	var err error
+	for attempt := 1; ; attempt++ {
+		if c.Audit != nil && isMutating(method) {
+			status, err = c.auditedRequest(v, method, path, data)
+		} else {
+			status, err = c.request(v, method, path, data)
+		}
+
+		if err == nil || !c.Retry.retries(method, status, err, attempt) {
+			break
+		}
+
+		<-ClockOf(c).After(c.Retry.delay(attempt))
+	}
+
+	if err != nil && c.WrapErrors {
+		if c.KeyType() == KeyTypeTest && isKeyRejected(status, err) {
+			err = &TestKeyError{Err: err}
+		}
+
+		return newRequestError(method, path, status, data, err)
+	}
+
+	return err
+}
+
+// request sends the request and returns the status of the response, or zero
+// if no response was received.
+func (c *DefaultClient) request(v interface{}, method, path string, data interface{}) (int, error) {
 	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
 		path = fmt.Sprintf("%s/%s", Endpoint, path)
 	}
 	uri, err := url.Parse(path)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	body, contentType, err := prepareRequestBody(data)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	request, err := http.NewRequest(method, uri.String(), bytes.NewBuffer(body))
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	request.Header.Set("Accept", "application/json")
@@ -111,30 +217,85 @@ func (c *DefaultClient) Request(v interface{}, method, path string, data interfa
 
 	if c.DebugLog != nil {
 		if data != nil {
-			c.DebugLog.Printf("HTTP REQUEST: %s %s %s", method, uri.String(), body)
+			c.DebugLog.Printf("HTTP REQUEST: %s %s %s", method, c.redactURL(uri.String()), c.redactBody(body, contentType))
 		} else {
-			c.DebugLog.Printf("HTTP REQUEST: %s %s", method, uri.String())
+			c.DebugLog.Printf("HTTP REQUEST: %s %s", method, c.redactURL(uri.String()))
 		}
 	}
 
+	if _, ok := v.(io.Writer); !ok && c.CoalesceGets && c.gets != nil && method == http.MethodGet && data == nil {
+		return c.coalescedRequest(v, request)
+	}
+
 	response, err := c.HTTPClient.Do(request)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	defer response.Body.Close()
 
-	responseBody, err := ioutil.ReadAll(response.Body)
+	// Downloads are streamed to the writer, unless the body has to be logged.
+	if w, ok := v.(io.Writer); ok && isSuccess(response.StatusCode) && c.DebugLog == nil {
+		_, err := io.Copy(w, response.Body)
+		return response.StatusCode, err
+	}
+
+	// The body is read into a pooled buffer rather than decoded with a
+	// json.Decoder: a decoder buffers the whole value before decoding it
+	// too, in a buffer of its own that can not be reused, which makes
+	// BenchmarkRequestList allocate about four times as many bytes.
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if _, err := buf.ReadFrom(response.Body); err != nil {
+		return response.StatusCode, err
+	}
+
+	return response.StatusCode, c.handleResponse(v, response.StatusCode, buf.Bytes())
+}
+
+// coalescedRequest sends request, unless an identical request is in flight.
+// In that case, it waits for that request and uses its response.
+func (c *DefaultClient) coalescedRequest(v interface{}, request *http.Request) (int, error) {
+	// Copies of c share its calls, so the access key is part of the key.
+	res, err := c.gets.do(c.AccessKey+" "+request.URL.String(), func() (*sharedResponse, error) {
+		response, err := c.HTTPClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+
+		defer response.Body.Close()
+
+		// The body outlives this call, so it can not be read into a pooled
+		// buffer.
+		body, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sharedResponse{status: response.StatusCode, body: body}, nil
+	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	return res.status, c.handleResponse(v, res.status, res.body)
+}
+
+// handleResponse decodes responseBody into v, or into an error, depending on
+// status.
+func (c *DefaultClient) handleResponse(v interface{}, status int, responseBody []byte) error {
 	if c.DebugLog != nil {
-		c.DebugLog.Printf("HTTP RESPONSE: %s", string(responseBody))
+		c.DebugLog.Printf("HTTP RESPONSE: %s", c.redactBody(responseBody, contentTypeJSON))
 	}
 
-	switch response.StatusCode {
+	switch status {
 	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
+		if w, ok := v.(io.Writer); ok {
+			_, err := w.Write(responseBody)
+			return err
+		}
+
 		// Status codes 200 and 201 are indicative of being able to convert the
 		// response body to the struct that was specified.
 		if err := json.Unmarshal(responseBody, &v); err != nil {
@@ -152,6 +313,8 @@ func (c *DefaultClient) Request(v interface{}, method, path string, data interfa
 		return ErrUnexpectedResponse
 	default:
 		// Anything else than a 200/201/204/500 should be a JSON error.
+		// responseBody may be reused or shared, so error readers get a copy.
+		responseBody = append([]byte(nil), responseBody...)
 		if customErrorReader != nil {
 			return customErrorReader(responseBody)
 		}
@@ -160,6 +323,27 @@ func (c *DefaultClient) Request(v interface{}, method, path string, data interfa
 	}
 }
 
+// isSuccess reports whether the response body of a request with status
+// holds the requested resource.
+func isSuccess(status int) bool {
+	return status == http.StatusOK || status == http.StatusCreated || status == http.StatusAccepted
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+
+	return buf
+}
+
+// putBuffer returns buf to the pool, unless it grew so large that keeping it
+// around would waste memory.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBufferSize {
+		bufferPool.Put(buf)
+	}
+}
+
 func defaultErrorReader(b []byte) error {
 	var errorResponse ErrorResponse
 
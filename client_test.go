@@ -0,0 +1,269 @@
+package messagebird
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// staticTransport responds to every request with status and body.
+type staticTransport struct {
+	status int
+	body   []byte
+}
+
+func (t *staticTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(t.body)),
+		Request:    r,
+	}, nil
+}
+
+func newTestClient(status int, body string) *DefaultClient {
+	c := New("test_accesskey")
+	c.HTTPClient.Transport = &staticTransport{status: status, body: []byte(body)}
+
+	return c
+}
+
+func TestRequestDecodesJSON(t *testing.T) {
+	c := newTestClient(http.StatusOK, `{"id":"id","count":2}`)
+
+	var v struct {
+		ID    string
+		Count int
+	}
+	assert.NoError(t, c.Request(&v, http.MethodGet, "messages/id", nil))
+	assert.Equal(t, "id", v.ID)
+	assert.Equal(t, 2, v.Count)
+}
+
+func TestRequestInvalidJSON(t *testing.T) {
+	c := newTestClient(http.StatusOK, `{"id":`)
+
+	var v struct{ ID string }
+	err := c.Request(&v, http.MethodGet, "messages/id", nil)
+	assert.EqualError(t, err, `could not decode response JSON, {"id":: unexpected end of JSON input`)
+
+	c = newTestClient(http.StatusOK, ``)
+	err = c.Request(&v, http.MethodGet, "messages/id", nil)
+	assert.EqualError(t, err, `could not decode response JSON, : unexpected end of JSON input`)
+}
+
+func TestRequestWriter(t *testing.T) {
+	c := newTestClient(http.StatusOK, "raw contents")
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.Request(&buf, http.MethodGet, "files/id", nil))
+	assert.Equal(t, "raw contents", buf.String())
+}
+
+func TestRequestError(t *testing.T) {
+	c := newTestClient(http.StatusUnprocessableEntity, `{"errors":[{"code":9,"description":"no (correct) recipients found","parameter":"recipients"}]}`)
+
+	err := c.Request(nil, http.MethodPost, "messages", nil)
+	assert.Equal(t, ErrorResponse{Errors: []Error{{Code: 9, Description: "no (correct) recipients found", Parameter: "recipients"}}}, err)
+
+	c = newTestClient(http.StatusInternalServerError, ``)
+	assert.Equal(t, ErrUnexpectedResponse, c.Request(nil, http.MethodGet, "messages", nil))
+}
+
+func TestRequestDebugLog(t *testing.T) {
+	c := newTestClient(http.StatusOK, "raw contents")
+
+	var logged bytes.Buffer
+	c.DebugLog = log.New(&logged, "", 0)
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.Request(&buf, http.MethodGet, "files/id", nil))
+	assert.Equal(t, "raw contents", buf.String())
+	assert.Contains(t, logged.String(), "HTTP RESPONSE: raw contents")
+}
+
+// listBody returns a JSON list of n message-like items.
+func listBody(n int) string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"id":"%032d","originator":"MessageBird","body":"This is message %d","recipients":{"totalCount":1,"items":[{"recipient":31612345678,"status":"delivered","statusDatetime":"2022-01-05T10:02:59+00:00"}]},"createdDatetime":"2022-01-05T10:02:59+00:00"}`, i, i)
+	}
+
+	return fmt.Sprintf(`{"offset":0,"limit":%d,"count":%d,"totalCount":%d,"items":[%s]}`, n, n, n, strings.Join(items, ","))
+}
+
+type benchmarkList struct {
+	Offset, Limit, Count, TotalCount int
+	Items                            []struct {
+		ID         string
+		Originator string
+		Body       string
+		Recipients struct {
+			TotalCount int
+			Items      []struct {
+				Recipient      int64
+				Status         string
+				StatusDatetime string
+			}
+		}
+		CreatedDatetime string
+	}
+}
+
+func BenchmarkRequestList(b *testing.B) {
+	for _, n := range []int{20, 500} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			c := newTestClient(http.StatusOK, listBody(n))
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				var list benchmarkList
+				if err := c.Request(&list, http.MethodGet, "messages", nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// blockingTransport counts requests and blocks them until release is closed.
+type blockingTransport struct {
+	staticTransport
+	release chan struct{}
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (t *blockingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.calls++
+	t.mu.Unlock()
+
+	<-t.release
+	return t.staticTransport.RoundTrip(r)
+}
+
+func TestRequestCoalesceGets(t *testing.T) {
+	transport := &blockingTransport{
+		staticTransport: staticTransport{status: http.StatusOK, body: []byte(`{"id":"id"}`)},
+		release:         make(chan struct{}),
+	}
+	c := New("test_accesskey")
+	c.HTTPClient.Transport = transport
+	c.CoalesceGets = true
+
+	const n = 10
+	results := make(chan error, n)
+	ids := make([]string, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			var v struct{ ID string }
+			err := c.Request(&v, http.MethodGet, "conversations/id", nil)
+			ids[i] = v.ID
+			results <- err
+		}(i)
+	}
+
+	// Release the request once all others wait for it.
+	assert.Eventually(t, func() bool {
+		c.gets.mu.Lock()
+		defer c.gets.mu.Unlock()
+		call, ok := c.gets.calls["test_accesskey "+Endpoint+"/conversations/id"]
+		return ok && call.dups == n-1
+	}, time.Second, time.Millisecond)
+	close(transport.release)
+
+	for i := 0; i < n; i++ {
+		assert.NoError(t, <-results)
+	}
+	assert.Equal(t, 1, transport.calls)
+	for _, id := range ids {
+		assert.Equal(t, "id", id)
+	}
+
+	// Requests that are not in flight at the same time are not coalesced.
+	assert.NoError(t, c.Request(nil, http.MethodGet, "conversations/id", nil))
+	assert.Equal(t, 2, transport.calls)
+}
+
+func TestRequestCoalesceGetsOnlyGets(t *testing.T) {
+	transport := &blockingTransport{
+		staticTransport: staticTransport{status: http.StatusOK, body: []byte(`{}`)},
+		release:         make(chan struct{}),
+	}
+	close(transport.release)
+
+	c := New("test_accesskey")
+	c.HTTPClient.Transport = transport
+	c.CoalesceGets = true
+
+	assert.NoError(t, c.Request(nil, http.MethodPost, "messages", nil))
+	var buf bytes.Buffer
+	assert.NoError(t, c.Request(&buf, http.MethodGet, "files/id", nil))
+
+	c.gets.mu.Lock()
+	defer c.gets.mu.Unlock()
+	assert.Empty(t, c.gets.calls)
+	assert.Equal(t, 2, transport.calls)
+}
+
+func TestCallGroupPanic(t *testing.T) {
+	var g callGroup
+	release := make(chan struct{})
+
+	panicked := make(chan interface{})
+	go func() {
+		defer func() { panicked <- recover() }()
+		_, _ = g.do("key", func() (*sharedResponse, error) {
+			<-release
+			panic("boom")
+		})
+	}()
+
+	assert.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["key"] != nil
+	}, time.Second, time.Millisecond)
+
+	waited := make(chan error)
+	go func() {
+		res, err := g.do("key", func() (*sharedResponse, error) {
+			return &sharedResponse{}, nil
+		})
+		assert.Nil(t, res)
+		waited <- err
+	}()
+
+	assert.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["key"].dups == 1
+	}, time.Second, time.Millisecond)
+	close(release)
+
+	assert.Equal(t, "boom", <-panicked)
+	assert.EqualError(t, <-waited, "coalesced request panicked: boom")
+	assert.Empty(t, g.calls)
+}
+
+func TestRequestCoalesceGetsCopy(t *testing.T) {
+	c := New("test_accesskey")
+	c.HTTPClient.Transport = &staticTransport{status: http.StatusOK, body: []byte(`{"id":"id"}`)}
+	c.CoalesceGets = true
+
+	// Clients may be copied, e.g. to change an option for some requests.
+	copied := *c
+	var v struct{ ID string }
+	assert.NoError(t, copied.Request(&v, http.MethodGet, "conversations/id", nil))
+	assert.Equal(t, "id", v.ID)
+}
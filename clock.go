@@ -0,0 +1,43 @@
+package messagebird
+
+import "time"
+
+// Clock tells the time and waits for durations to pass. Retries, polling,
+// rate limiting and caches in this SDK use the Clock of the client they are
+// given, so tests can control time. See messagebirdtest.FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for d to pass and then sends the current time on the
+	// returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock that uses the time of the system.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// ClockOf returns the Clock of c, which is SystemClock unless c is a
+// *DefaultClient with its Clock set, or a Wrapper of one.
+func ClockOf(c Client) Clock {
+	for ; c != nil; c = unwrap(c) {
+		if dc, ok := c.(*DefaultClient); ok {
+			if dc.Clock != nil {
+				return dc.Clock
+			}
+			break
+		}
+	}
+
+	return SystemClock
+}
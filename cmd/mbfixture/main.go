@@ -0,0 +1,33 @@
+// Command mbfixture writes the response of a MessageBird API read endpoint to
+// a sanitized JSON fixture. Use a test access key:
+//
+//	MESSAGEBIRD_ACCESS_KEY=test_... go run ./cmd/mbfixture -endpoint balance -out balance/testdata/balance.json
+//
+// See messagebirdtest.GenerateFixture.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+)
+
+func main() {
+	endpoint := flag.String("endpoint", "", "path relative to the REST API, or an absolute URL")
+	out := flag.String("out", "", "file to write the fixture to")
+	accessKey := flag.String("key", os.Getenv("MESSAGEBIRD_ACCESS_KEY"), "access key, defaults to $MESSAGEBIRD_ACCESS_KEY")
+	flag.Parse()
+
+	if *endpoint == "" || *out == "" || *accessKey == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := messagebirdtest.GenerateFixture(messagebird.New(*accessKey), *endpoint, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
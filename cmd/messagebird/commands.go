@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/lookup"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/verify"
+)
+
+// commands are the commands of the tool, in the order they are listed in
+// the usage.
+var commands = []*command{
+	{
+		name:    "balance",
+		summary: "show the balance of the account",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			return func(c messagebird.Client, _ []string) (interface{}, error) {
+				return balance.Read(c)
+			}
+		},
+	},
+	{
+		name:     "sms send",
+		required: []string{"from", "to", "body"},
+		summary:  "send an SMS",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			from := fs.String("from", "", "originator, a phone number or alphanumeric sender ID")
+			to := fs.String("to", "", "comma separated phone numbers of the recipients")
+			body := fs.String("body", "", "body of the message")
+			reference := fs.String("reference", "", "client reference")
+			return func(c messagebird.Client, _ []string) (interface{}, error) {
+				return sms.Send(c, &sms.SendRequest{
+					Originator: *from,
+					Recipients: strings.Split(*to, ","),
+					Body:       *body,
+					Reference:  *reference,
+				})
+			}
+		},
+	},
+	{
+		name:    "sms list",
+		summary: "list sent and received SMS, newest first",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			params := &sms.ListParams{}
+			fs.StringVar(&params.Originator, "from", "", "only list messages from this originator")
+			fs.StringVar(&params.Direction, "direction", "", "only list messages in this direction: mt (sent) or mo (received)")
+			fs.StringVar(&params.Status, "status", "", "only list messages with this status, e.g. delivered")
+			fs.IntVar(&params.Limit, "limit", 20, "maximum number of messages")
+			fs.IntVar(&params.Offset, "offset", 0, "number of messages to skip")
+			return func(c messagebird.Client, _ []string) (interface{}, error) {
+				return sms.List(c, params)
+			}
+		},
+	},
+	{
+		name:    "sms read",
+		args:    "ID",
+		nargs:   1,
+		summary: "show an SMS and its delivery status",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			return func(c messagebird.Client, args []string) (interface{}, error) {
+				return sms.Read(c, args[0])
+			}
+		},
+	},
+	{
+		name:     "conversation start",
+		required: []string{"channel", "to", "text"},
+		summary:  "start a conversation with a text message",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			channel := fs.String("channel", "", "ID of the channel to send on")
+			to := fs.String("to", "", "recipient, e.g. a phone number")
+			text := fs.String("text", "", "text of the message")
+			return func(c messagebird.Client, _ []string) (interface{}, error) {
+				return conversation.Start(c, &conversation.StartRequest{
+					ChannelID: *channel,
+					To:        conversation.MessageRecipient(*to),
+					Type:      conversation.MessageTypeText,
+					Content:   &conversation.MessageContent{Text: *text},
+				})
+			}
+		},
+	},
+	{
+		name:    "conversation messages",
+		args:    "ID",
+		nargs:   1,
+		summary: "list the messages of a conversation",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			req := &conversation.ListConversationMessagesRequest{}
+			fs.IntVar(&req.Limit, "limit", 20, "maximum number of messages")
+			fs.IntVar(&req.Offset, "offset", 0, "number of messages to skip")
+			return func(c messagebird.Client, args []string) (interface{}, error) {
+				return conversation.ListConversationMessages(c, args[0], req)
+			}
+		},
+	},
+	{
+		name:    "lookup",
+		args:    "NUMBER",
+		nargs:   1,
+		summary: "look up the format and network of a phone number",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			params := &lookup.Params{}
+			fs.StringVar(&params.CountryCode, "country", "", "country code of numbers in local format, e.g. NL")
+			return func(c messagebird.Client, args []string) (interface{}, error) {
+				return lookup.Read(c, args[0], params)
+			}
+		},
+	},
+	{
+		name:    "verify create",
+		args:    "RECIPIENT",
+		nargs:   1,
+		summary: "send a verification token to a phone number or email address",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			params := &verify.Params{}
+			fs.StringVar(&params.Originator, "from", "", "originator of the message")
+			fs.StringVar(&params.Type, "type", "", "how to send the token: sms, flash, tts or email")
+			fs.StringVar(&params.Template, "template", "", "template of the message, with %token for the token")
+			return func(c messagebird.Client, args []string) (interface{}, error) {
+				return verify.Create(c, args[0], params)
+			}
+		},
+	},
+	{
+		name:    "verify token",
+		args:    "ID TOKEN",
+		nargs:   2,
+		summary: "check the token a recipient entered",
+		setup: func(fs *flag.FlagSet) func(messagebird.Client, []string) (interface{}, error) {
+			return func(c messagebird.Client, args []string) (interface{}, error) {
+				return verify.VerifyToken(c, args[0], args[1])
+			}
+		},
+	},
+}
@@ -0,0 +1,163 @@
+// Command messagebird calls the MessageBird API from the command line and
+// prints the responses as JSON. It only uses the packages of this module, so
+// its source doubles as an example of their use.
+//
+//	export MESSAGEBIRD_ACCESS_KEY=test_...
+//	go run ./cmd/messagebird balance
+//	go run ./cmd/messagebird sms send -from TestMessage -to 31612345678 -body "Hello"
+//	go run ./cmd/messagebird -debug lookup 31612345678
+//
+// Run it without arguments for a list of commands.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// command is a command of the tool. Its setup defines its flags, and returns
+// the function that runs it with the remaining arguments once they are
+// parsed.
+type command struct {
+	// name is the name of the command, e.g. "sms send".
+	name string
+
+	// args describes the arguments after the flags, and nargs is their
+	// number.
+	args  string
+	nargs int
+
+	// required are the flags that must not be empty.
+	required []string
+
+	summary string
+	setup   func(fs *flag.FlagSet) func(c messagebird.Client, args []string) (interface{}, error)
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, newClient))
+}
+
+// newClient creates the client commands are run with.
+func newClient(accessKey string, debug io.Writer) messagebird.Client {
+	c := messagebird.New(accessKey)
+	if debug != nil {
+		c.DebugLog = log.New(debug, "", log.LstdFlags)
+		c.Redaction = messagebird.DefaultRedaction
+	}
+	c.WrapErrors = true
+
+	return c
+}
+
+// run runs the command in args, and returns the exit code: 0 on success, 1
+// if the request failed and 2 for invalid arguments.
+func run(args []string, stdout, stderr io.Writer, newClient func(accessKey string, debug io.Writer) messagebird.Client) int {
+	fs := flag.NewFlagSet("messagebird", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	accessKey := fs.String("key", os.Getenv("MESSAGEBIRD_ACCESS_KEY"), "access key, defaults to $MESSAGEBIRD_ACCESS_KEY")
+	debug := fs.Bool("debug", false, "log requests and responses to stderr, with personal data redacted")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cmd, args := find(fs.Args())
+	if cmd == nil {
+		fs.Usage()
+		return 2
+	}
+
+	cmdFlags := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	cmdFlags.SetOutput(stderr)
+	cmdFlags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: messagebird %s [flags] %s\n\n%s\n", cmd.name, cmd.args, cmd.summary)
+		cmdFlags.PrintDefaults()
+	}
+	exec := cmd.setup(cmdFlags)
+	if err := cmdFlags.Parse(args); err != nil {
+		return 2
+	}
+	if cmdFlags.NArg() != cmd.nargs {
+		cmdFlags.Usage()
+		return 2
+	}
+	for _, name := range cmd.required {
+		if cmdFlags.Lookup(name).Value.String() == "" {
+			fmt.Fprintf(stderr, "flag -%s is required\n", name)
+			cmdFlags.Usage()
+			return 2
+		}
+	}
+
+	if *accessKey == "" {
+		fmt.Fprintln(stderr, "no access key: set $MESSAGEBIRD_ACCESS_KEY or use -key")
+		return 2
+	}
+
+	var debugLog io.Writer
+	if *debug {
+		debugLog = stderr
+	}
+
+	v, err := exec(newClient(*accessKey, debugLog), cmdFlags.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// find returns the command named by the first one or two of args, and the
+// arguments that follow its name.
+func find(args []string) (*command, []string) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) {
+			continue
+		}
+
+		match := true
+		for i, word := range words {
+			if args[i] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return cmd, args[len(words):]
+		}
+	}
+
+	return nil, nil
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "usage: messagebird [flags] <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-22s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "messagebird <command> -h" for the flags of a command.`)
+}
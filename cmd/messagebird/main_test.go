@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/mocks"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func runWith(t *testing.T, c *mocks.Client, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(append([]string{"-key", "test_key"}, args...), &stdout, &stderr, func(string, io.Writer) messagebird.Client {
+		return c
+	})
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunBalance(t *testing.T) {
+	c := &mocks.Client{
+		RequestFunc: func(v interface{}, method, path string, data interface{}) error {
+			*v.(*balance.Balance) = balance.Balance{Payment: "prepaid", Type: "credits", Amount: 9.2}
+			return nil
+		},
+	}
+
+	code, stdout, stderr := runWith(t, c, "balance")
+	assert.Equal(t, 0, code, stderr)
+
+	var b balance.Balance
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &b))
+	assert.Equal(t, float32(9.2), b.Amount)
+
+	calls := c.Calls()
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, []interface{}{calls[0].Args[0], http.MethodGet, "balance", nil}, calls[0].Args)
+	}
+}
+
+func TestRunSMSSend(t *testing.T) {
+	var sent map[string]interface{}
+	c := &mocks.Client{
+		RequestFunc: func(v interface{}, method, path string, data interface{}) error {
+			b, _ := json.Marshal(data)
+			json.Unmarshal(b, &sent)
+			v.(*sms.Message).ID = "message-id"
+			return nil
+		},
+	}
+
+	code, stdout, stderr := runWith(t, c, "sms", "send", "-from", "TestMessage", "-to", "31612345678,31687654321", "-body", "Hello")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"ID": "message-id"`)
+
+	assert.Equal(t, "TestMessage", sent["originator"])
+	assert.Equal(t, []interface{}{"31612345678", "31687654321"}, sent["recipients"])
+	assert.Equal(t, "Hello", sent["body"])
+}
+
+func TestRunVerifyToken(t *testing.T) {
+	c := &mocks.Client{
+		RequestFunc: func(v interface{}, method, path string, data interface{}) error {
+			return nil
+		},
+	}
+
+	code, _, stderr := runWith(t, c, "verify", "token", "verify-id", "123456")
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "verify/verify-id?token=123456", c.Calls()[0].Args[2])
+}
+
+func TestRunError(t *testing.T) {
+	c := &mocks.Client{
+		RequestFunc: func(v interface{}, method, path string, data interface{}) error {
+			return errors.New("request failed")
+		},
+	}
+
+	code, stdout, stderr := runWith(t, c, "lookup", "31612345678")
+	assert.Equal(t, 1, code)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "request failed\n", stderr)
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := map[string][]string{
+		"no command":       {},
+		"unknown command":  {"sms", "schedule"},
+		"missing flag":     {"sms", "send", "-from", "TestMessage", "-to", "31612345678"},
+		"missing argument": {"verify", "token", "verify-id"},
+		"unknown flag":     {"balance", "-currency", "EUR"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &mocks.Client{}
+
+			code, stdout, stderr := runWith(t, c, args...)
+			assert.Equal(t, 2, code)
+			assert.Empty(t, stdout)
+			assert.Contains(t, stderr, "usage: messagebird")
+			assert.Empty(t, c.Calls())
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package messagebird
+
+import (
+	"fmt"
+	"sync"
+)
+
+// sharedResponse is a response shared by coalesced requests.
+type sharedResponse struct {
+	status int
+	body   []byte
+}
+
+// callGroup coalesces concurrent calls with the same key into one, like
+// golang.org/x/sync/singleflight. Its zero value is ready to use, but it must
+// not be copied, so clients hold it by pointer.
+type callGroup struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// call is a call in flight. Its result can be read once wg is done.
+type call struct {
+	wg   sync.WaitGroup
+	dups int
+
+	res *sharedResponse
+	err error
+}
+
+// do calls fn and returns its result, unless a call with key is in flight.
+// In that case, it waits for that call and returns its result instead. If fn
+// panics, the waiters get an error and the panic continues in the caller
+// that called fn.
+func (g *callGroup) do(key string, fn func() (*sharedResponse, error)) (*sharedResponse, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.res, c.err
+	}
+
+	c := &call{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	// Waiters must be released, even if fn panics.
+	panicked := true
+	defer func() {
+		var r interface{}
+		if panicked {
+			r = recover()
+			c.res, c.err = nil, fmt.Errorf("coalesced request panicked: %v", r)
+		}
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+
+		if panicked {
+			panic(r)
+		}
+	}()
+
+	c.res, c.err = fn()
+	panicked = false
+	return c.res, c.err
+}
@@ -0,0 +1,26 @@
+package messagebird
+
+// ConsentRequirer is implemented by clients that can be in compliance mode.
+// Clients that wrap a single client need not implement it: RequiresConsent
+// asks the wrapped client of a Wrapper.
+type ConsentRequirer interface {
+	RequiresConsent() bool
+}
+
+// RequiresConsent reports whether c is in compliance mode, i.e. it is a
+// ConsentRequirer that requires consent, like a *DefaultClient with
+// RequireConsent set, or a Wrapper of one.
+func RequiresConsent(c Client) bool {
+	for ; c != nil; c = unwrap(c) {
+		if r, ok := c.(ConsentRequirer); ok {
+			return r.RequiresConsent()
+		}
+	}
+
+	return false
+}
+
+// RequiresConsent implements ConsentRequirer.
+func (c *DefaultClient) RequiresConsent() bool {
+	return c.RequireConsent
+}
@@ -0,0 +1,31 @@
+package messagebird
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wrappingClient passes requests to Client, like the clients of other
+// packages that add behavior to a client.
+type wrappingClient struct {
+	Client Client
+}
+
+func (c *wrappingClient) Request(v interface{}, method, path string, data interface{}) error {
+	return c.Client.Request(v, method, path, data)
+}
+
+func (c *wrappingClient) Unwrap() Client {
+	return c.Client
+}
+
+func TestRequiresConsent(t *testing.T) {
+	client := New("key")
+	assert.False(t, RequiresConsent(client))
+
+	client.RequireConsent = true
+	assert.True(t, RequiresConsent(client))
+	assert.True(t, RequiresConsent(&wrappingClient{Client: client}))
+	assert.False(t, RequiresConsent(&wrappingClient{Client: &wrappingClient{Client: New("key")}}))
+}
@@ -0,0 +1,251 @@
+package messagebird
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings of a DefaultClient, so they can be kept out of
+// code. Use FromEnv to read them from environment variables, or FromConfig to
+// read them from a YAML or JSON file:
+//
+//	accessKey: ${MESSAGEBIRD_ACCESS_KEY}
+//	timeout: 10s
+//	retry:
+//	  maxAttempts: 4
+//	  delay: 200ms
+//	wrapErrors: true
+//	lenientTimes: true
+//	apiVersions:
+//	  conversations: v2
+type Config struct {
+	AccessKey string `yaml:"accessKey"`
+
+	// BaseURL replaces Endpoint as the root of the REST API, see
+	// DefaultClient.BaseURL.
+	BaseURL string `yaml:"baseURL"`
+
+	// Timeout limits the time of a request, including reading the
+	// response. 15 seconds is used if it is zero.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Retry enables retries. Requests are not retried if it is nil.
+	Retry *RetryPolicy `yaml:"retry"`
+
+	// Debug logs requests and responses to stderr, with personal data
+	// masked by DefaultRedaction.
+	Debug bool `yaml:"debug"`
+
+	// The remaining settings enable the options of DefaultClient with the
+	// same name.
+	WrapErrors     bool              `yaml:"wrapErrors"`
+	CoalesceGets   bool              `yaml:"coalesceGets"`
+	RequireConsent bool              `yaml:"requireConsent"`
+	LenientTimes   bool              `yaml:"lenientTimes"`
+	APIVersions    map[string]string `yaml:"apiVersions"`
+}
+
+// NewClient creates a DefaultClient with the settings of cfg.
+func (cfg *Config) NewClient() (*DefaultClient, error) {
+	if cfg.AccessKey == "" {
+		return nil, errors.New("accessKey is required")
+	}
+
+	c := New(cfg.AccessKey)
+	c.BaseURL = cfg.BaseURL
+	c.Retry = cfg.Retry
+	c.WrapErrors = cfg.WrapErrors
+	c.CoalesceGets = cfg.CoalesceGets
+	c.RequireConsent = cfg.RequireConsent
+	c.LenientTimes = cfg.LenientTimes
+	c.APIVersions = cfg.APIVersions
+
+	if cfg.Timeout != 0 {
+		c.HTTPClient = &http.Client{Timeout: cfg.Timeout}
+	}
+	if cfg.Debug {
+		c.DebugLog = log.New(os.Stderr, "messagebird: ", log.LstdFlags)
+		c.Redaction = DefaultRedaction
+	}
+
+	return c, nil
+}
+
+// FromConfig creates a DefaultClient with the settings in the YAML or JSON
+// file at path, see Config. References to environment variables in the file,
+// such as ${MESSAGEBIRD_ACCESS_KEY}, are replaced by their values, so secrets
+// need not be stored in it. Only references in braces are replaced, so other
+// dollar signs, e.g. in a password, are kept. Unknown settings are an error.
+func FromConfig(path string) (*DefaultClient, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(expandEnv(b)))
+	dec.KnownFields(true)
+
+	cfg := &Config{}
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cfg.NewClient()
+}
+
+// envReference matches references to environment variables, e.g. ${HOME}.
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces the references to environment variables in b by their
+// values. Variables that are not set are replaced by an empty string.
+func expandEnv(b []byte) []byte {
+	return envReference.ReplaceAllFunc(b, func(ref []byte) []byte {
+		return []byte(os.Getenv(string(ref[2 : len(ref)-1])))
+	})
+}
+
+// FromEnv creates a DefaultClient with the settings in environment variables:
+//
+//	MESSAGEBIRD_ACCESS_KEY        Config.AccessKey, required
+//	MESSAGEBIRD_BASE_URL          Config.BaseURL
+//	MESSAGEBIRD_TIMEOUT           Config.Timeout, e.g. 10s
+//	MESSAGEBIRD_RETRY_MAX_ATTEMPTS
+//	MESSAGEBIRD_RETRY_DELAY
+//	MESSAGEBIRD_RETRY_MAX_DELAY
+//	MESSAGEBIRD_RETRY_ALL_METHODS Config.Retry, enabled if any is set
+//	MESSAGEBIRD_DEBUG             Config.Debug, e.g. true
+//	MESSAGEBIRD_WRAP_ERRORS
+//	MESSAGEBIRD_COALESCE_GETS
+//	MESSAGEBIRD_REQUIRE_CONSENT
+//	MESSAGEBIRD_LENIENT_TIMES     the options of DefaultClient
+//	MESSAGEBIRD_API_VERSIONS      Config.APIVersions, e.g. conversations=v2
+func FromEnv() (*DefaultClient, error) {
+	cfg, err := configFromEnv(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.NewClient()
+}
+
+// configFromEnv reads a Config from the environment variables looked up
+// with lookup.
+func configFromEnv(lookup func(key string) (string, bool)) (*Config, error) {
+	env := &envReader{lookup: lookup}
+
+	cfg := &Config{
+		AccessKey:      env.string("MESSAGEBIRD_ACCESS_KEY"),
+		BaseURL:        env.string("MESSAGEBIRD_BASE_URL"),
+		Timeout:        env.duration("MESSAGEBIRD_TIMEOUT"),
+		Debug:          env.bool("MESSAGEBIRD_DEBUG"),
+		WrapErrors:     env.bool("MESSAGEBIRD_WRAP_ERRORS"),
+		CoalesceGets:   env.bool("MESSAGEBIRD_COALESCE_GETS"),
+		RequireConsent: env.bool("MESSAGEBIRD_REQUIRE_CONSENT"),
+		LenientTimes:   env.bool("MESSAGEBIRD_LENIENT_TIMES"),
+		APIVersions:    env.apiVersions("MESSAGEBIRD_API_VERSIONS"),
+	}
+
+	retry := &RetryPolicy{
+		MaxAttempts: env.int("MESSAGEBIRD_RETRY_MAX_ATTEMPTS"),
+		Delay:       env.duration("MESSAGEBIRD_RETRY_DELAY"),
+		MaxDelay:    env.duration("MESSAGEBIRD_RETRY_MAX_DELAY"),
+		AllMethods:  env.bool("MESSAGEBIRD_RETRY_ALL_METHODS"),
+	}
+	if *retry != (RetryPolicy{}) {
+		cfg.Retry = retry
+	}
+
+	if env.err != nil {
+		return nil, env.err
+	}
+
+	return cfg, nil
+}
+
+// envReader parses environment variables. It keeps the first error, so all
+// variables can be read before it is checked.
+type envReader struct {
+	lookup func(key string) (string, bool)
+	err    error
+}
+
+func (r *envReader) string(key string) string {
+	v, _ := r.lookup(key)
+	return strings.TrimSpace(v)
+}
+
+func (r *envReader) fail(key string, err error) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%s: %w", key, err)
+	}
+}
+
+func (r *envReader) bool(key string) bool {
+	v := r.string(key)
+	if v == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		r.fail(key, err)
+	}
+	return b
+}
+
+func (r *envReader) int(key string) int {
+	v := r.string(key)
+	if v == "" {
+		return 0
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		r.fail(key, err)
+	}
+	return n
+}
+
+func (r *envReader) duration(key string) time.Duration {
+	v := r.string(key)
+	if v == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		r.fail(key, err)
+	}
+	return d
+}
+
+// apiVersions parses a comma separated list of name=version pairs.
+func (r *envReader) apiVersions(key string) map[string]string {
+	v := r.string(key)
+	if v == "" {
+		return nil
+	}
+
+	versions := make(map[string]string)
+	for _, pair := range strings.Split(v, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			r.fail(key, fmt.Errorf("invalid API version %q, want name=version", pair))
+			continue
+		}
+		versions[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return versions
+}
@@ -0,0 +1,116 @@
+package messagebird
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromConfig(t *testing.T) {
+	os.Setenv("TEST_MESSAGEBIRD_KEY", "live_key")
+	defer os.Unsetenv("TEST_MESSAGEBIRD_KEY")
+	os.Setenv("TEST_MESSAGEBIRD_PROXY", "proxy")
+	defer os.Unsetenv("TEST_MESSAGEBIRD_PROXY")
+
+	path := filepath.Join(t.TempDir(), "messagebird.yaml")
+	config := `
+accessKey: ${TEST_MESSAGEBIRD_KEY}
+baseURL: https://$TEST_MESSAGEBIRD_PROXY.example.com/$1
+timeout: 5s
+retry:
+  maxAttempts: 4
+  delay: 200ms
+wrapErrors: true
+lenientTimes: true
+apiVersions:
+  conversations: v2
+`
+	assert.NoError(t, ioutil.WriteFile(path, []byte(config), 0600))
+
+	c, err := FromConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "live_key", c.AccessKey)
+	assert.Equal(t, "https://$TEST_MESSAGEBIRD_PROXY.example.com/$1", c.BaseURL)
+	assert.Equal(t, 5*time.Second, c.HTTPClient.Timeout)
+	assert.Equal(t, &RetryPolicy{MaxAttempts: 4, Delay: 200 * time.Millisecond}, c.Retry)
+	assert.True(t, c.WrapErrors)
+	assert.False(t, c.CoalesceGets)
+	assert.True(t, c.LenientTimes)
+	assert.Equal(t, map[string]string{"conversations": "v2"}, c.APIVersions)
+	assert.Nil(t, c.DebugLog)
+}
+
+func TestFromConfigJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messagebird.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"accessKey": "test_key", "debug": true}`), 0600))
+
+	c, err := FromConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_key", c.AccessKey)
+	assert.NotNil(t, c.DebugLog)
+	assert.Equal(t, DefaultRedaction, c.Redaction)
+	assert.Equal(t, httpClientTimeout, c.HTTPClient.Timeout)
+}
+
+func TestFromConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := FromConfig(filepath.Join(dir, "missing.yaml"))
+	assert.True(t, os.IsNotExist(err))
+
+	path := filepath.Join(dir, "unknown.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("accessKey: key\nretries: 3\n"), 0600))
+	_, err = FromConfig(path)
+	assert.Error(t, err, "unknown settings are an error")
+
+	path = filepath.Join(dir, "empty.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("timeout: 5s\n"), 0600))
+	_, err = FromConfig(path)
+	assert.EqualError(t, err, "accessKey is required")
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		"MESSAGEBIRD_ACCESS_KEY":        "live_key",
+		"MESSAGEBIRD_TIMEOUT":           "10s",
+		"MESSAGEBIRD_RETRY_MAX_DELAY":   "1m",
+		"MESSAGEBIRD_COALESCE_GETS":     "true",
+		"MESSAGEBIRD_LENIENT_TIMES":     "true",
+		"MESSAGEBIRD_API_VERSIONS":      "conversations=v2, voice=v1",
+		"MESSAGEBIRD_RETRY_ALL_METHODS": "1",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	cfg, err := configFromEnv(lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		AccessKey:    "live_key",
+		Timeout:      10 * time.Second,
+		Retry:        &RetryPolicy{MaxDelay: time.Minute, AllMethods: true},
+		CoalesceGets: true,
+		LenientTimes: true,
+		APIVersions:  map[string]string{"conversations": "v2", "voice": "v1"},
+	}, cfg)
+
+	delete(env, "MESSAGEBIRD_RETRY_MAX_DELAY")
+	delete(env, "MESSAGEBIRD_RETRY_ALL_METHODS")
+	cfg, err = configFromEnv(lookup)
+	assert.NoError(t, err)
+	assert.Nil(t, cfg.Retry)
+
+	env["MESSAGEBIRD_TIMEOUT"] = "10"
+	_, err = configFromEnv(lookup)
+	assert.EqualError(t, err, `MESSAGEBIRD_TIMEOUT: time: missing unit in duration "10"`)
+
+	env["MESSAGEBIRD_TIMEOUT"] = "10s"
+	env["MESSAGEBIRD_API_VERSIONS"] = "v2"
+	_, err = configFromEnv(lookup)
+	assert.EqualError(t, err, `MESSAGEBIRD_API_VERSIONS: invalid API version "v2", want name=version`)
+}
@@ -0,0 +1,131 @@
+package contact
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+// ErrNoConsent is returned, wrapped, by the helpers that send to contacts
+// when the client requires consent and the contact has not opted in to the
+// channel.
+var ErrNoConsent = errors.New("contact has not opted in")
+
+// ConsentField is the custom attribute consent is stored in. The API has no
+// consent fields of its own, so consent is kept in a single custom attribute
+// that must not be used for anything else.
+var ConsentField = CustomField4
+
+// Channels consent can be given for.
+const (
+	ChannelSMS      = "sms"
+	ChannelVoice    = "voice"
+	ChannelWhatsApp = "whatsapp"
+	ChannelEmail    = "email"
+)
+
+// withdrawn is the value of ConsentField of contacts that withdrew their
+// consent. An empty value is not sent on update, so it can not be used.
+const withdrawn = "-"
+
+// Consent records that a contact opted in to receive messages.
+type Consent struct {
+	// Channel is the channel the contact opted in to, e.g. ChannelSMS. An
+	// empty channel covers all channels.
+	Channel string
+
+	// OptedInAt is when the contact opted in.
+	OptedInAt time.Time
+
+	// Source is where the contact opted in, e.g. "checkout" or a URL.
+	Source string
+}
+
+// Covers reports whether the consent covers channel.
+func (c *Consent) Covers(channel string) bool {
+	return c != nil && (c.Channel == "" || c.Channel == channel)
+}
+
+// String encodes the consent as stored in ConsentField: the channel, the
+// Unix time of the opt-in and the source, separated by |.
+func (c *Consent) String() string {
+	return c.Channel + "|" + strconv.FormatInt(c.OptedInAt.Unix(), 10) + "|" + c.Source
+}
+
+// ParseConsent decodes consent encoded by Consent.String. It returns nil
+// for empty and withdrawn consent.
+func ParseConsent(s string) (*Consent, error) {
+	if s == "" || s == withdrawn {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(s, "|", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid consent %q", s)
+	}
+
+	unix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid consent %q: %w", s, err)
+	}
+
+	return &Consent{Channel: parts[0], OptedInAt: time.Unix(unix, 0).UTC(), Source: parts[2]}, nil
+}
+
+// Consent returns the consent of the contact, or nil if it has none.
+func (c *Contact) Consent() (*Consent, error) {
+	consent, err := ParseConsent(c.CustomDetails.Get(ConsentField))
+	if err != nil {
+		return nil, fmt.Errorf("custom%d: %w", ConsentField, err)
+	}
+
+	return consent, nil
+}
+
+// SetConsent stores consent in ConsentField of the request. A nil consent
+// withdraws the consent of the contact.
+func (r *CreateRequest) SetConsent(consent *Consent) {
+	value := withdrawn
+	if consent != nil {
+		value = consent.String()
+	}
+
+	d := r.CustomDetails()
+	d.Set(ConsentField, value)
+	r.SetCustomDetails(d)
+}
+
+// CheckConsent returns an error wrapping ErrNoConsent if c requires consent
+// and contact has not opted in to channel. See
+// messagebird.DefaultClient.RequireConsent.
+func CheckConsent(c messagebird.Client, contact *Contact, channel string) error {
+	if !messagebird.RequiresConsent(c) {
+		return nil
+	}
+
+	consent, err := contact.Consent()
+	if err != nil {
+		return err
+	}
+
+	if !consent.Covers(channel) {
+		return fmt.Errorf("%w to %s: %s", ErrNoConsent, channel, contact.ID)
+	}
+
+	return nil
+}
+
+// SendSMS sends an SMS to contact, after checking its consent with
+// CheckConsent.
+func SendSMS(c messagebird.Client, contact *Contact, originator, body string, params *sms.Params) (*sms.Message, error) {
+	if err := CheckConsent(c, contact, ChannelSMS); err != nil {
+		return nil, err
+	}
+
+	return sms.Create(c, originator, []string{strconv.FormatInt(contact.MSISDN, 10)}, body, params)
+}
@@ -0,0 +1,79 @@
+package contact
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/blacklist"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsent(t *testing.T) {
+	consent := &Consent{Channel: ChannelSMS, OptedInAt: time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC), Source: "checkout|step 2"}
+
+	req := &CreateRequest{MSISDN: "31612345678", Custom1: "gold"}
+	req.SetConsent(consent)
+	assert.Equal(t, "sms|1654084800|checkout|step 2", req.Custom4)
+	assert.Equal(t, "gold", req.Custom1)
+
+	contact := &Contact{CustomDetails: req.CustomDetails()}
+	parsed, err := contact.Consent()
+	assert.NoError(t, err)
+	assert.Equal(t, consent, parsed)
+	assert.True(t, parsed.Covers(ChannelSMS))
+	assert.False(t, parsed.Covers(ChannelWhatsApp))
+	assert.True(t, (&Consent{}).Covers(ChannelWhatsApp))
+
+	req.SetConsent(nil)
+	assert.Equal(t, "-", req.Custom4)
+	contact.CustomDetails = req.CustomDetails()
+	parsed, err = contact.Consent()
+	assert.NoError(t, err)
+	assert.Nil(t, parsed)
+	assert.False(t, parsed.Covers(ChannelSMS))
+
+	contact.CustomDetails.Custom4 = "gold"
+	_, err = contact.Consent()
+	assert.EqualError(t, err, `custom4: invalid consent "gold"`)
+}
+
+// requestCounter records the requests it receives, without sending them.
+type requestCounter struct {
+	paths []string
+}
+
+func (c *requestCounter) Request(v interface{}, method, path string, data interface{}) error {
+	c.paths = append(c.paths, method+" "+path)
+	return nil
+}
+
+func TestSendSMSConsent(t *testing.T) {
+	client := &requestCounter{}
+	contact := &Contact{ID: "contact-id", MSISDN: 31612345678}
+
+	// Consent is only checked in compliance mode.
+	_, err := SendSMS(client, contact, "MessageBird", "Hello World", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST messages"}, client.paths)
+
+	compliant := messagebird.New("test_key")
+	compliant.RequireConsent = true
+	_, err = SendSMS(compliant, contact, "MessageBird", "Hello World", nil)
+	assert.True(t, errors.Is(err, ErrNoConsent))
+	assert.EqualError(t, err, "contact has not opted in to sms: contact-id")
+
+	// Compliance mode is kept when the client is wrapped.
+	wrapped := &sms.StickyClient{
+		Client: &blacklist.FilteringClient{Client: compliant, Store: blacklist.NewMemoryStore()},
+		Store:  sms.NewMemoryOriginatorStore(),
+	}
+	_, err = SendSMS(wrapped, contact, "MessageBird", "Hello World", nil)
+	assert.True(t, errors.Is(err, ErrNoConsent))
+
+	contact.CustomDetails.Custom4 = (&Consent{OptedInAt: time.Now()}).String()
+	assert.NoError(t, CheckConsent(compliant, contact, ChannelSMS))
+	assert.NoError(t, CheckConsent(compliant, contact, ChannelWhatsApp))
+}
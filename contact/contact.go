@@ -1,10 +1,13 @@
 package contact
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+	"github.com/messagebird/go-rest-api/v9/sms"
 )
 
 // path represents the path to the Contacts resource.
@@ -17,13 +20,8 @@ type Contact struct {
 	MSISDN        int64
 	FirstName     string
 	LastName      string
-	CustomDetails struct {
-		Custom1 string
-		Custom2 string
-		Custom3 string
-		Custom4 string
-	}
-	Groups struct {
+	CustomDetails CustomDetails
+	Groups        struct {
 		TotalCount int
 		HRef       string
 	}
@@ -35,12 +33,66 @@ type Contact struct {
 	UpdatedDatetime *time.Time
 }
 
+// Link refers to a collection related to a contact.
+type Link struct {
+	TotalCount int
+	HRef       string
+}
+
+// ListGroups follows the Groups link of the contact. It returns the same
+// groups as Groups(client, contact.ID, options).
+func (c *Contact) ListGroups(client messagebird.Client, options *messagebird.PaginationRequest) (*GroupList, error) {
+	groupList := &GroupList{}
+	if err := follow(client, c.Groups, options, groupList); err != nil {
+		return nil, err
+	}
+
+	return groupList, nil
+}
+
+// ListMessages follows the Messages link of the contact, which lists the
+// messages sent to it.
+func (c *Contact) ListMessages(client messagebird.Client, options *messagebird.PaginationRequest) (*sms.MessageList, error) {
+	messageList := &sms.MessageList{}
+	if err := follow(client, c.Messages, options, messageList); err != nil {
+		return nil, err
+	}
+
+	return messageList, nil
+}
+
+// follow requests a page of the collection link refers to.
+func follow(client messagebird.Client, link Link, options *messagebird.PaginationRequest, v interface{}) error {
+	if link.HRef == "" {
+		return errors.New("contact has no link to follow, make sure it was read from the API")
+	}
+
+	return client.Request(v, http.MethodGet, link.HRef+"?"+options.QueryParams(), nil)
+}
+
 type Contacts struct {
 	Limit, Offset     int
 	Count, TotalCount int
 	Items             []Contact
 }
 
+// Group is a group a contact is a member of. See package group for managing
+// groups.
+type Group struct {
+	ID              string
+	HRef            string
+	Name            string
+	CreatedDatetime *time.Time
+	UpdatedDatetime *time.Time
+}
+
+// GroupList is a paginated list of the groups a contact is a member of.
+type GroupList struct {
+	Limit, Offset     int
+	Count, TotalCount int
+	Items             []Group
+}
+
 // CreateRequest represents a contact for write operations, e.g. for creating a new
 // contact or updating an existing one.
 type CreateRequest struct {
@@ -53,6 +105,42 @@ type CreateRequest struct {
 	Custom4   string `json:"custom4,omitempty"`
 }
 
+// SearchRequest filters the contacts returned by Search. The API matches
+// contacts by MSISDN and first name only: custom attributes can not be
+// searched.
+type SearchRequest struct {
+	MSISDN    string
+	FirstName string
+	Limit     int
+	Offset    int
+}
+
+func (sr *SearchRequest) QueryParams() string {
+	if sr == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if sr.MSISDN != "" {
+		query.Set("msisdn", sr.MSISDN)
+	}
+
+	if sr.FirstName != "" {
+		query.Set("firstName", sr.FirstName)
+	}
+
+	if sr.Limit > 0 {
+		query.SetInt("limit", sr.Limit)
+	}
+
+	if sr.Offset > 0 {
+		query.SetInt("offset", sr.Offset)
+	}
+
+	return query.Encode()
+}
+
 type ViewRequest struct {
 	MSISDN string `json:"msisdn,omitempty"`
 	Name   string `json:"firstName,omitempty"`
@@ -84,6 +172,30 @@ func List(c messagebird.Client, options *messagebird.PaginationRequest) (*Contac
 	return contactList, nil
 }
 
+// Search retrieves a paginated list of contacts matching query.
+func Search(c messagebird.Client, query *SearchRequest) (*Contacts, error) {
+	if query == nil || (query.MSISDN == "" && query.FirstName == "") {
+		return nil, errors.New("an msisdn or firstName to search for is required")
+	}
+
+	contactList := &Contacts{}
+	if err := c.Request(contactList, http.MethodGet, path+"?"+query.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return contactList, nil
+}
+
+// Groups retrieves a paginated list of the groups the contact is a member of.
+func Groups(c messagebird.Client, id string, options *messagebird.PaginationRequest) (*GroupList, error) {
+	groupList := &GroupList{}
+	if err := c.Request(groupList, http.MethodGet, path+"/"+id+"/groups?"+options.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return groupList, nil
+}
+
 // Read retrieves the information of an existing contact.
 func Read(c messagebird.Client, id string, req *ViewRequest) (*Contact, error) {
 	contact := &Contact{}
@@ -94,6 +206,57 @@ func Read(c messagebird.Client, id string, req *ViewRequest) (*Contact, error) {
 	return contact, nil
 }
 
+// Upsert updates the contact with the MSISDN in contactRequest, or creates it
+// if no such contact exists. It reports whether the contact was created.
+//
+// The API does not support conditional requests, so another client could
+// create the same contact between the lookup and the create. Upsert covers
+// this by looking up the contact again when the create fails, and updating it
+// if it was found.
+func Upsert(c messagebird.Client, contactRequest *CreateRequest) (*Contact, bool, error) {
+	if contactRequest == nil || contactRequest.MSISDN == "" {
+		return nil, false, errors.New("msisdn is required")
+	}
+
+	existing, err := findByMSISDN(c, contactRequest.MSISDN)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing == nil {
+		contact, err := Create(c, contactRequest)
+		if err == nil {
+			return contact, true, nil
+		}
+
+		if existing, _ = findByMSISDN(c, contactRequest.MSISDN); existing == nil {
+			return nil, false, err
+		}
+	}
+
+	contact, err := Update(c, existing.ID, contactRequest)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return contact, false, nil
+}
+
+// findByMSISDN returns the contact with the given MSISDN, or nil if there is
+// none.
+func findByMSISDN(c messagebird.Client, msisdn string) (*Contact, error) {
+	contactList, err := Search(c, &SearchRequest{MSISDN: msisdn, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contactList.Items) == 0 {
+		return nil, nil
+	}
+
+	return &contactList.Items[0], nil
+}
+
 // Update updates the record referenced by id with any values set in contactRequest.
 // Do not set any values that should not be updated.
 func Update(c messagebird.Client, id string, contactRequest *CreateRequest) (*Contact, error) {
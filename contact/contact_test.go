@@ -35,7 +35,7 @@ func TestCreate(t *testing.T) {
 	assert.NoError(t, err)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/contacts")
-	mbtest.AssertTestdata(t, "contactRequestObjectCreate.json", mbtest.Request.Body)
+	mbtest.AssertTestdata(t, "contactRequestObjectCreate.json", mbtest.LastRequest().Body)
 
 	assert.Equal(t, int64(31612345678), contact.MSISDN)
 
@@ -83,7 +83,7 @@ func TestListPagination(t *testing.T) {
 		expected string
 		options  *messagebird.PaginationRequest
 	}{
-		{"limit=20&offset=0", messagebird.DefaultPagination},
+		{"limit=20", messagebird.DefaultPagination},
 		{"limit=10&offset=25", &messagebird.PaginationRequest{Limit: 10, Offset: 25}},
 		{"limit=50&offset=10", &messagebird.PaginationRequest{Limit: 50, Offset: 10}},
 	}
@@ -92,7 +92,7 @@ func TestListPagination(t *testing.T) {
 		_, err := List(client, tc.options)
 		assert.NoError(t, err)
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, tc.expected, query)
 	}
 }
@@ -155,6 +155,72 @@ func TestUpdate(t *testing.T) {
 		assert.NoError(t, err)
 
 		mbtest.AssertEndpointCalled(t, http.MethodPatch, "/contacts/contact-id")
-		mbtest.AssertTestdata(t, tc.expectedTestdata, mbtest.Request.Body)
+		mbtest.AssertTestdata(t, tc.expectedTestdata, mbtest.LastRequest().Body)
 	}
 }
+
+func TestSearch(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "contactListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := Search(client, &SearchRequest{MSISDN: "31612345678", Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/contacts")
+	assert.Equal(t, "limit=10&msisdn=31612345678", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestSearchWithoutFilter(t *testing.T) {
+	_, err := Search(mbtest.Client(t), &SearchRequest{Limit: 10})
+	assert.Error(t, err)
+}
+
+func TestGroups(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "contactGroupListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	groups, err := Groups(client, "contact-id", messagebird.DefaultPagination)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, groups.TotalCount)
+	assert.Equal(t, "Friends", groups.Items[0].Name)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/contacts/contact-id/groups")
+	assert.Equal(t, "limit=20", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestContactListGroups(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "contactObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	contact, err := Read(client, "contact-id", nil)
+	assert.NoError(t, err)
+
+	mbtest.WillReturnTestdata(t, "contactGroupListObject.json", http.StatusOK)
+
+	groups, err := contact.ListGroups(client, &messagebird.PaginationRequest{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, "group-id", groups.Items[0].ID)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/contacts/contact-id/groups")
+	assert.Equal(t, "limit=10", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestContactListMessages(t *testing.T) {
+	contact := &Contact{ID: "contact-id"}
+	contact.Messages.HRef = "https://rest.messagebird.com/contacts/contact-id/messages"
+
+	mbtest.WillReturn([]byte(`{"offset":0,"limit":20,"count":1,"totalCount":1,"items":[{"id":"message-id","body":"Hello"}]}`), http.StatusOK)
+	client := mbtest.Client(t)
+
+	messages, err := contact.ListMessages(client, messagebird.DefaultPagination)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", messages.Items[0].Body)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/contacts/contact-id/messages")
+}
+
+func TestContactFollowWithoutLink(t *testing.T) {
+	_, err := (&Contact{}).ListMessages(mbtest.Client(t), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package contact
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CustomField identifies one of the four custom attributes a contact has.
+type CustomField int
+
+const (
+	CustomField1 CustomField = iota + 1
+	CustomField2
+	CustomField3
+	CustomField4
+)
+
+// CustomDetails holds the custom attributes of a contact. The API stores all
+// attributes as strings: the typed accessors convert values for you.
+type CustomDetails struct {
+	Custom1 string
+	Custom2 string
+	Custom3 string
+	Custom4 string
+}
+
+// Get returns the value of field, or an empty string if field is unknown.
+func (d *CustomDetails) Get(field CustomField) string {
+	if p := d.field(field); p != nil {
+		return *p
+	}
+
+	return ""
+}
+
+// Set sets the value of field. Unknown fields are ignored.
+func (d *CustomDetails) Set(field CustomField, value string) {
+	if p := d.field(field); p != nil {
+		*p = value
+	}
+}
+
+// Int parses the value of field as an integer.
+func (d *CustomDetails) Int(field CustomField) (int, error) {
+	v, err := strconv.Atoi(d.Get(field))
+	if err != nil {
+		return 0, fmt.Errorf("custom%d: %w", field, err)
+	}
+
+	return v, nil
+}
+
+// Bool parses the value of field as a boolean, accepting the values
+// strconv.ParseBool accepts.
+func (d *CustomDetails) Bool(field CustomField) (bool, error) {
+	v, err := strconv.ParseBool(d.Get(field))
+	if err != nil {
+		return false, fmt.Errorf("custom%d: %w", field, err)
+	}
+
+	return v, nil
+}
+
+// Time parses the value of field as an RFC 3339 timestamp.
+func (d *CustomDetails) Time(field CustomField) (time.Time, error) {
+	v, err := time.Parse(time.RFC3339, d.Get(field))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("custom%d: %w", field, err)
+	}
+
+	return v, nil
+}
+
+// SetInt sets field to the decimal representation of value.
+func (d *CustomDetails) SetInt(field CustomField, value int) {
+	d.Set(field, strconv.Itoa(value))
+}
+
+// SetBool sets field to "true" or "false".
+func (d *CustomDetails) SetBool(field CustomField, value bool) {
+	d.Set(field, strconv.FormatBool(value))
+}
+
+// SetTime sets field to value, formatted as an RFC 3339 timestamp.
+func (d *CustomDetails) SetTime(field CustomField, value time.Time) {
+	d.Set(field, value.Format(time.RFC3339))
+}
+
+func (d *CustomDetails) field(field CustomField) *string {
+	switch field {
+	case CustomField1:
+		return &d.Custom1
+	case CustomField2:
+		return &d.Custom2
+	case CustomField3:
+		return &d.Custom3
+	case CustomField4:
+		return &d.Custom4
+	}
+
+	return nil
+}
+
+// CustomDetails returns the custom attributes set on the request.
+func (r *CreateRequest) CustomDetails() CustomDetails {
+	return CustomDetails{
+		Custom1: r.Custom1,
+		Custom2: r.Custom2,
+		Custom3: r.Custom3,
+		Custom4: r.Custom4,
+	}
+}
+
+// SetCustomDetails copies all custom attributes in d to the request. Empty
+// attributes are not sent, so they keep their current value on update.
+func (r *CreateRequest) SetCustomDetails(d CustomDetails) {
+	r.Custom1 = d.Custom1
+	r.Custom2 = d.Custom2
+	r.Custom3 = d.Custom3
+	r.Custom4 = d.Custom4
+}
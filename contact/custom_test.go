@@ -0,0 +1,61 @@
+package contact
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomDetails(t *testing.T) {
+	var d CustomDetails
+	d.SetInt(CustomField1, 42)
+	d.SetBool(CustomField2, true)
+	d.SetTime(CustomField3, time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	d.Set(CustomField4, "gold")
+	d.Set(CustomField(5), "ignored")
+
+	assert.Equal(t, CustomDetails{"42", "true", "2022-06-01T12:00:00Z", "gold"}, d)
+
+	i, err := d.Int(CustomField1)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, i)
+
+	b, err := d.Bool(CustomField2)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	tm, err := d.Time(CustomField3)
+	assert.NoError(t, err)
+	assert.True(t, tm.Equal(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)))
+
+	_, err = d.Int(CustomField4)
+	assert.EqualError(t, err, `custom4: strconv.Atoi: parsing "gold": invalid syntax`)
+
+	assert.Equal(t, "", d.Get(CustomField(0)))
+}
+
+func TestReadCustomDetails(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "contactObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	contact, err := Read(client, "contact-id", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Third", contact.CustomDetails.Get(CustomField3))
+}
+
+func TestUpdateCustomDetails(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "contactObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	req := &CreateRequest{}
+	req.SetCustomDetails(CustomDetails{Custom1: "First", Custom2: "Second"})
+	assert.Equal(t, "Second", req.CustomDetails().Custom2)
+
+	_, err := Update(client, "contact-id", req)
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{"custom1":"First","custom2":"Second"}`, string(mbtest.LastRequest().Body))
+}
@@ -0,0 +1,347 @@
+package contact
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+)
+
+// SurvivorPolicy decides which contact of a set of duplicates is kept.
+type SurvivorPolicy string
+
+const (
+	// SurvivorOldest keeps the contact that was created first.
+	SurvivorOldest SurvivorPolicy = "oldest"
+
+	// SurvivorNewest keeps the contact that was created last.
+	SurvivorNewest SurvivorPolicy = "newest"
+
+	// SurvivorMostComplete keeps the contact with the most fields set,
+	// falling back to the oldest contact on ties.
+	SurvivorMostComplete SurvivorPolicy = "most_complete"
+)
+
+// DedupeOptions configures Dedupe.
+type DedupeOptions struct {
+	// Policy decides which contact survives. Defaults to SurvivorOldest.
+	Policy SurvivorPolicy
+
+	// EmailField is the custom attribute holding the e-mail address of
+	// contacts, if any. Contacts with the same e-mail address are considered
+	// duplicates, like contacts with the same MSISDN.
+	EmailField CustomField
+
+	// DryRun only reports duplicates without changing any contacts.
+	DryRun bool
+
+	// PageSize is the number of contacts requested at once while scanning.
+	// Defaults to DefaultExportPageSize.
+	PageSize int
+}
+
+// DuplicateSet is a set of contacts that were found to be duplicates.
+type DuplicateSet struct {
+	// Keys are the normalized MSISDNs and e-mail addresses the contacts
+	// share, e.g. msisdn:31612345678 or email:foo@example.com.
+	Keys         []string `json:"keys"`
+	SurvivorID   string   `json:"survivorId"`
+	DuplicateIDs []string `json:"duplicateIds"`
+
+	// Merged is true if the duplicates were merged into the survivor and
+	// deleted.
+	Merged bool   `json:"merged"`
+	Error  string `json:"error,omitempty"`
+}
+
+// DedupeReport is the outcome of Dedupe. It can be encoded as JSON.
+type DedupeReport struct {
+	Scanned int             `json:"scanned"`
+	DryRun  bool            `json:"dryRun"`
+	Sets    []*DuplicateSet `json:"sets"`
+}
+
+// Dedupe scans all contacts for duplicates and merges them into a single
+// contact chosen by opts.Policy. Merging fills in fields the survivor is
+// missing with values of the duplicates, adds the survivor to the groups of
+// the duplicates and deletes the duplicates.
+//
+// A set that fails to merge does not stop the others: its error is stored in
+// the report. If ctx is done, Dedupe stops and returns ctx.Err() alongside
+// the report so far: if it was still scanning, that only holds the number of
+// contacts scanned.
+func Dedupe(ctx context.Context, c messagebird.Client, opts *DedupeOptions) (*DedupeReport, error) {
+	if opts == nil {
+		opts = &DedupeOptions{}
+	}
+
+	contacts, err := scanContacts(ctx, c, opts.PageSize)
+	if err != nil {
+		if err == ctx.Err() {
+			return &DedupeReport{Scanned: len(contacts), DryRun: opts.DryRun}, err
+		}
+		return nil, err
+	}
+
+	report := &DedupeReport{Scanned: len(contacts), DryRun: opts.DryRun}
+
+	for _, set := range findDuplicates(contacts, opts) {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		report.Sets = append(report.Sets, set.DuplicateSet)
+		if opts.DryRun {
+			continue
+		}
+
+		if err := merge(c, set.contacts); err != nil {
+			set.Error = err.Error()
+			continue
+		}
+		set.Merged = true
+	}
+
+	return report, nil
+}
+
+// duplicateSet is a DuplicateSet along with its contacts, survivor first.
+type duplicateSet struct {
+	*DuplicateSet
+	contacts []*Contact
+}
+
+// scanContacts lists all contacts. If ctx is done, it returns the contacts
+// listed so far along with ctx.Err().
+func scanContacts(ctx context.Context, c messagebird.Client, pageSize int) ([]*Contact, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultExportPageSize
+	}
+
+	var contacts []*Contact
+	var pager paging.Pager
+	for !pager.Done() {
+		if err := ctx.Err(); err != nil {
+			return contacts, err
+		}
+
+		pager.Fetch(func(offset int) (int, int, error) {
+			page, err := List(c, &messagebird.PaginationRequest{Limit: pageSize, Offset: offset})
+			if err != nil {
+				return 0, 0, err
+			}
+
+			for i := range page.Items {
+				contacts = append(contacts, &page.Items[i])
+			}
+
+			return len(page.Items), page.TotalCount, nil
+		})
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// findDuplicates groups contacts that share a key. Sets are transitive: if A
+// shares an MSISDN with B and B an e-mail address with C, all three are in
+// the same set.
+func findDuplicates(contacts []*Contact, opts *DedupeOptions) []*duplicateSet {
+	// parent implements a union-find over the indices of contacts.
+	parent := make([]int, len(contacts))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	first := make(map[string]int)
+	shared := make(map[string]bool)
+	for i, contact := range contacts {
+		for _, key := range dedupeKeys(contact, opts.EmailField) {
+			j, ok := first[key]
+			if !ok {
+				first[key] = i
+				continue
+			}
+			shared[key] = true
+			parent[find(i)] = find(j)
+		}
+	}
+
+	members := make(map[int][]*Contact)
+	var roots []int
+	for i, contact := range contacts {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], contact)
+	}
+
+	var sets []*duplicateSet
+	for _, root := range roots {
+		if len(members[root]) < 2 {
+			continue
+		}
+
+		set := &duplicateSet{
+			DuplicateSet: &DuplicateSet{},
+			contacts:     rankSurvivors(members[root], opts.Policy),
+		}
+		for key, i := range first {
+			if shared[key] && find(i) == root {
+				set.Keys = append(set.Keys, key)
+			}
+		}
+		sort.Strings(set.Keys)
+
+		set.SurvivorID = set.contacts[0].ID
+		for _, d := range set.contacts[1:] {
+			set.DuplicateIDs = append(set.DuplicateIDs, d.ID)
+		}
+		sets = append(sets, set)
+	}
+
+	return sets
+}
+
+func dedupeKeys(c *Contact, emailField CustomField) []string {
+	var keys []string
+	if c.MSISDN != 0 {
+		keys = append(keys, "msisdn:"+strconv.FormatInt(c.MSISDN, 10))
+	}
+	if email := strings.ToLower(strings.TrimSpace(c.CustomDetails.Get(emailField))); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+
+	return keys
+}
+
+// rankSurvivors sorts contacts so the survivor comes first.
+func rankSurvivors(contacts []*Contact, policy SurvivorPolicy) []*Contact {
+	older := func(a, b *Contact) bool {
+		if a.CreatedDatetime == nil || b.CreatedDatetime == nil {
+			return a.CreatedDatetime != nil
+		}
+		return a.CreatedDatetime.Before(*b.CreatedDatetime)
+	}
+
+	sort.SliceStable(contacts, func(i, j int) bool {
+		a, b := contacts[i], contacts[j]
+		switch policy {
+		case SurvivorNewest:
+			return older(b, a)
+		case SurvivorMostComplete:
+			if ca, cb := completeness(a), completeness(b); ca != cb {
+				return ca > cb
+			}
+		}
+		return older(a, b)
+	})
+
+	return contacts
+}
+
+func completeness(c *Contact) int {
+	n := 0
+	for _, v := range []string{
+		c.FirstName, c.LastName,
+		c.CustomDetails.Custom1, c.CustomDetails.Custom2,
+		c.CustomDetails.Custom3, c.CustomDetails.Custom4,
+	} {
+		if v != "" {
+			n++
+		}
+	}
+
+	return n
+}
+
+// merge merges contacts[1:] into contacts[0].
+func merge(c messagebird.Client, contacts []*Contact) error {
+	survivor := contacts[0]
+
+	req := &CreateRequest{}
+	update := false
+	fill := func(dst *string, current string, get func(*Contact) string) {
+		if current != "" {
+			return
+		}
+		for _, d := range contacts[1:] {
+			if v := get(d); v != "" {
+				*dst, update = v, true
+				return
+			}
+		}
+	}
+
+	fill(&req.FirstName, survivor.FirstName, func(d *Contact) string { return d.FirstName })
+	fill(&req.LastName, survivor.LastName, func(d *Contact) string { return d.LastName })
+	for _, field := range []CustomField{CustomField1, CustomField2, CustomField3, CustomField4} {
+		field := field
+		var v string
+		fill(&v, survivor.CustomDetails.Get(field), func(d *Contact) string { return d.CustomDetails.Get(field) })
+		req.setCustom(field, v)
+	}
+
+	if update {
+		if _, err := Update(c, survivor.ID, req); err != nil {
+			return fmt.Errorf("updating survivor %s: %w", survivor.ID, err)
+		}
+	}
+
+	for _, d := range contacts[1:] {
+		if err := moveGroups(c, d.ID, survivor.ID); err != nil {
+			return err
+		}
+
+		if err := Delete(c, d.ID); err != nil {
+			return fmt.Errorf("deleting duplicate %s: %w", d.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// moveGroups adds the contact identified by to to all groups of from.
+func moveGroups(c messagebird.Client, from, to string) error {
+	var pager paging.Pager
+	for !pager.Done() {
+		pager.Fetch(func(offset int) (int, int, error) {
+			groups, err := Groups(c, from, &messagebird.PaginationRequest{Limit: DefaultExportPageSize, Offset: offset})
+			if err != nil {
+				return 0, 0, fmt.Errorf("listing groups of %s: %w", from, err)
+			}
+
+			for _, g := range groups.Items {
+				// Package group can not be used here, as it imports this package.
+				if err := c.Request(nil, http.MethodPut, "groups/"+g.ID+"/"+path, "ids[]="+to); err != nil {
+					return 0, 0, fmt.Errorf("adding %s to group %s: %w", to, g.ID, err)
+				}
+			}
+
+			return len(groups.Items), groups.TotalCount, nil
+		})
+	}
+
+	return pager.Err()
+}
+
+func (r *CreateRequest) setCustom(field CustomField, value string) {
+	d := r.CustomDetails()
+	d.Set(field, value)
+	r.SetCustomDetails(d)
+}
@@ -0,0 +1,140 @@
+package contact
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func dedupeClient(contacts []Contact, writes *[]string) mbtest.ClientFunc {
+	return func(v interface{}, method, path string, data interface{}) error {
+		switch {
+		case method == "GET" && strings.HasPrefix(path, "contacts?"):
+			list := v.(*Contacts)
+			list.Items, list.TotalCount = contacts, len(contacts)
+		case method == "GET" && strings.HasSuffix(strings.SplitN(path, "?", 2)[0], "/groups"):
+			if strings.HasPrefix(path, "contacts/b/") {
+				list := v.(*GroupList)
+				list.Items, list.TotalCount = []Group{{ID: "group-id"}}, 1
+			}
+		default:
+			body, _ := json.Marshal(data)
+			*writes = append(*writes, method+" "+path+" "+strings.Trim(string(body), `"`))
+			if contact, ok := v.(*Contact); ok {
+				contact.ID = "a"
+			}
+		}
+		return nil
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	t1 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	contacts := []Contact{
+		{ID: "b", MSISDN: 31612345678, FirstName: "Foo", CreatedDatetime: &t2, CustomDetails: CustomDetails{Custom1: "FOO@example.com"}},
+		{ID: "a", MSISDN: 31612345678, CreatedDatetime: &t1},
+		{ID: "c", MSISDN: 31600000000, LastName: "Bar", CreatedDatetime: &t3, CustomDetails: CustomDetails{Custom1: "foo@example.com "}},
+		{ID: "d", MSISDN: 31611111111, CreatedDatetime: &t1},
+	}
+
+	var writes []string
+	report, err := Dedupe(context.Background(), dedupeClient(contacts, &writes), &DedupeOptions{EmailField: CustomField1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 4, report.Scanned)
+	if assert.Len(t, report.Sets, 1) {
+		set := report.Sets[0]
+		assert.Equal(t, []string{"email:foo@example.com", "msisdn:31612345678"}, set.Keys)
+		assert.Equal(t, "a", set.SurvivorID)
+		assert.Equal(t, []string{"b", "c"}, set.DuplicateIDs)
+		assert.True(t, set.Merged)
+		assert.Empty(t, set.Error)
+	}
+
+	assert.Equal(t, []string{
+		`PATCH contacts/a {"firstName":"Foo","lastName":"Bar","custom1":"FOO@example.com"}`,
+		`PUT groups/group-id/contacts ids[]=a`,
+		`DELETE contacts/b null`,
+		`DELETE contacts/c null`,
+	}, writes)
+}
+
+func TestDedupeDryRun(t *testing.T) {
+	contacts := []Contact{
+		{ID: "a", MSISDN: 31612345678},
+		{ID: "b", MSISDN: 31612345678, FirstName: "Foo"},
+	}
+
+	var writes []string
+	report, err := Dedupe(context.Background(), dedupeClient(contacts, &writes), &DedupeOptions{
+		Policy: SurvivorMostComplete,
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, writes)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, "b", report.Sets[0].SurvivorID)
+	assert.False(t, report.Sets[0].Merged)
+
+	b, err := json.Marshal(report)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"scanned":2,"dryRun":true,"sets":[{"keys":["msisdn:31612345678"],"survivorId":"b","duplicateIds":["a"],"merged":false}]}`, string(b))
+}
+
+func TestDedupeCancelScan(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		list := v.(*Contacts)
+		list.Items, list.TotalCount = []Contact{{ID: "a", MSISDN: 31612345678}}, 2
+		cancel()
+		return nil
+	})
+
+	report, err := Dedupe(ctx, client, &DedupeOptions{PageSize: 1})
+	assert.Equal(t, context.Canceled, err)
+	if assert.NotNil(t, report) {
+		assert.Equal(t, 1, report.Scanned)
+		assert.Empty(t, report.Sets)
+	}
+}
+
+func TestDedupeCancelMerge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contacts := []Contact{
+		{ID: "a", MSISDN: 31612345678},
+		{ID: "b", MSISDN: 31612345678},
+		{ID: "c", MSISDN: 31600000000},
+		{ID: "d", MSISDN: 31600000000},
+	}
+
+	var writes []string
+	list := dedupeClient(contacts, &writes)
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		if method == "DELETE" {
+			cancel()
+		}
+		return list(v, method, path, data)
+	})
+
+	report, err := Dedupe(ctx, client, nil)
+	assert.Equal(t, context.Canceled, err)
+	if assert.NotNil(t, report) {
+		assert.Equal(t, 4, report.Scanned)
+		if assert.Len(t, report.Sets, 1) {
+			assert.True(t, report.Sets[0].Merged)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package contact
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+)
+
+// DefaultExportPageSize is the number of contacts Export requests per page
+// when no page size is set in ExportOptions.
+const DefaultExportPageSize = 100
+
+// ExportFormat is the encoding Export writes contacts in.
+type ExportFormat string
+
+const (
+	// ExportFormatCSV writes a header followed by a row per contact. The
+	// column names match DefaultImportMapping, so exports can be imported
+	// again.
+	ExportFormatCSV ExportFormat = "csv"
+
+	// ExportFormatJSON writes a JSON array with an object per contact.
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// ExportOptions filters the exported contacts and configures how Export
+// pages through them.
+type ExportOptions struct {
+	// GroupID limits the export to the contacts in a group.
+	GroupID string
+
+	// PageSize is the number of contacts requested at once. Defaults to
+	// DefaultExportPageSize.
+	PageSize int
+
+	// Interval is the minimum time between two page requests. Use it to stay
+	// below the rate limit of your account.
+	Interval time.Duration
+
+	// MaxRetries is the number of times a failed page request is retried,
+	// waiting RetryDelay before each attempt. This allows Export to get past
+	// temporary errors, such as hitting the rate limit.
+	MaxRetries int
+	RetryDelay time.Duration
+}
+
+// exportedContact is the representation of a contact in exports.
+type exportedContact struct {
+	ID              string     `json:"id"`
+	MSISDN          string     `json:"msisdn"`
+	FirstName       string     `json:"firstName"`
+	LastName        string     `json:"lastName"`
+	Custom1         string     `json:"custom1"`
+	Custom2         string     `json:"custom2"`
+	Custom3         string     `json:"custom3"`
+	Custom4         string     `json:"custom4"`
+	CreatedDatetime *time.Time `json:"createdDatetime"`
+	UpdatedDatetime *time.Time `json:"updatedDatetime"`
+}
+
+var exportCSVHeader = []string{"id", "msisdn", "firstName", "lastName", "custom1", "custom2", "custom3", "custom4", "createdDatetime", "updatedDatetime"}
+
+func newExportedContact(c *Contact) *exportedContact {
+	return &exportedContact{
+		ID:              c.ID,
+		MSISDN:          strconv.FormatInt(c.MSISDN, 10),
+		FirstName:       c.FirstName,
+		LastName:        c.LastName,
+		Custom1:         c.CustomDetails.Custom1,
+		Custom2:         c.CustomDetails.Custom2,
+		Custom3:         c.CustomDetails.Custom3,
+		Custom4:         c.CustomDetails.Custom4,
+		CreatedDatetime: c.CreatedDatetime,
+		UpdatedDatetime: c.UpdatedDatetime,
+	}
+}
+
+func (e *exportedContact) csvRecord() []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	return []string{
+		e.ID, e.MSISDN, e.FirstName, e.LastName,
+		e.Custom1, e.Custom2, e.Custom3, e.Custom4,
+		formatTime(e.CreatedDatetime), formatTime(e.UpdatedDatetime),
+	}
+}
+
+// exportWriter writes contacts in a specific format.
+type exportWriter interface {
+	begin() error
+	write(c *exportedContact) error
+	end() error
+}
+
+// Export pages through all contacts, or the contacts in opts.GroupID, and
+// writes them to w as they come in. It returns the number of contacts
+// written.
+//
+// If ctx is done, Export stops requesting pages and returns ctx.Err(). The
+// output is incomplete in that case.
+func Export(ctx context.Context, c messagebird.Client, w io.Writer, format ExportFormat, opts *ExportOptions) (int, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	var ew exportWriter
+	switch format {
+	case ExportFormatCSV:
+		ew = &csvExportWriter{w: csv.NewWriter(w)}
+	case ExportFormatJSON:
+		ew = &jsonExportWriter{w: w}
+	default:
+		return 0, fmt.Errorf("unknown export format %q", format)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultExportPageSize
+	}
+
+	listPath := path
+	if opts.GroupID != "" {
+		listPath = "groups/" + opts.GroupID + "/" + path
+	}
+
+	if err := ew.begin(); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	var pager paging.Pager
+	for !pager.Done() {
+		if pager.Offset > 0 && opts.Interval > 0 {
+			if err := sleep(ctx, messagebird.ClockOf(c), opts.Interval); err != nil {
+				return written, err
+			}
+		}
+
+		pager.Fetch(func(offset int) (int, int, error) {
+			pagination := &messagebird.PaginationRequest{Limit: pageSize, Offset: offset}
+			page, err := exportPage(ctx, c, listPath+"?"+pagination.QueryParams(), opts)
+			if err != nil {
+				return 0, 0, err
+			}
+
+			for i := range page.Items {
+				if err := ew.write(newExportedContact(&page.Items[i])); err != nil {
+					return 0, 0, err
+				}
+				written++
+			}
+
+			return len(page.Items), page.TotalCount, nil
+		})
+	}
+	if err := pager.Err(); err != nil {
+		return written, err
+	}
+
+	return written, ew.end()
+}
+
+func exportPage(ctx context.Context, c messagebird.Client, uri string, opts *ExportOptions) (*Contacts, error) {
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		page := &Contacts{}
+		err := c.Request(page, http.MethodGet, uri, nil)
+		if err == nil {
+			return page, nil
+		}
+
+		if attempt >= opts.MaxRetries {
+			return nil, err
+		}
+
+		if err := sleep(ctx, messagebird.ClockOf(c), opts.RetryDelay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for d on clock, or until ctx is done.
+func sleep(ctx context.Context, clock messagebird.Clock, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(d):
+		return nil
+	}
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvExportWriter) begin() error {
+	return cw.w.Write(exportCSVHeader)
+}
+
+func (cw *csvExportWriter) write(c *exportedContact) error {
+	if err := cw.w.Write(c.csvRecord()); err != nil {
+		return err
+	}
+
+	// Flush every row, so the output is streamed rather than buffered.
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvExportWriter) end() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (jw *jsonExportWriter) begin() error {
+	_, err := io.WriteString(jw.w, "[")
+	return err
+}
+
+func (jw *jsonExportWriter) write(c *exportedContact) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+	if jw.count == 0 {
+		sep = "\n"
+	}
+	jw.count++
+
+	_, err = io.WriteString(jw.w, sep+string(b))
+	return err
+}
+
+func (jw *jsonExportWriter) end() error {
+	_, err := io.WriteString(jw.w, "\n]\n")
+	return err
+}
@@ -0,0 +1,105 @@
+package contact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedClient serves total contacts, page by page.
+func pagedClient(total int, paths *[]string) mbtest.ClientFunc {
+	return func(v interface{}, method, path string, data interface{}) error {
+		*paths = append(*paths, path)
+
+		u, _ := url.Parse(path)
+		offset, _ := strconv.Atoi(u.Query().Get("offset"))
+		limit, _ := strconv.Atoi(u.Query().Get("limit"))
+
+		contacts := v.(*Contacts)
+		contacts.TotalCount = total
+		for i := offset; i < offset+limit && i < total; i++ {
+			contacts.Items = append(contacts.Items, Contact{
+				ID:        string(rune('a' + i)),
+				MSISDN:    int64(31612345670 + i),
+				FirstName: "Foo, Jr.",
+			})
+		}
+		return nil
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var paths []string
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), pagedClient(3, &paths), &buf, ExportFormatCSV, &ExportOptions{PageSize: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	assert.Equal(t, []string{"contacts?limit=2", "contacts?limit=2&offset=2"}, paths)
+	assert.Equal(t, "id,msisdn,firstName,lastName,custom1,custom2,custom3,custom4,createdDatetime,updatedDatetime\n"+
+		"a,31612345670,\"Foo, Jr.\",,,,,,,\n"+
+		"b,31612345671,\"Foo, Jr.\",,,,,,,\n"+
+		"c,31612345672,\"Foo, Jr.\",,,,,,,\n", buf.String())
+}
+
+func TestExportJSONGroup(t *testing.T) {
+	var paths []string
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), pagedClient(2, &paths), &buf, ExportFormatJSON, &ExportOptions{GroupID: "group-id"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"groups/group-id/contacts?limit=100"}, paths)
+
+	var exported []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+	assert.Len(t, exported, 2)
+	assert.Equal(t, "31612345671", exported[1]["msisdn"])
+}
+
+func TestExportEmptyJSON(t *testing.T) {
+	var paths []string
+	var buf bytes.Buffer
+
+	n, err := Export(context.Background(), pagedClient(0, &paths), &buf, ExportFormatJSON, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.JSONEq(t, "[]", buf.String())
+}
+
+func TestExportRetry(t *testing.T) {
+	var paths []string
+	failures := 2
+	next := pagedClient(1, &paths)
+
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		if failures > 0 {
+			failures--
+			return errors.New("rate limited")
+		}
+		return next(v, method, path, data)
+	})
+
+	opts := &ExportOptions{MaxRetries: 1, RetryDelay: time.Millisecond}
+	_, err := Export(context.Background(), client, &bytes.Buffer{}, ExportFormatCSV, opts)
+	assert.EqualError(t, err, "rate limited")
+
+	failures = 1
+	n, err := Export(context.Background(), client, &bytes.Buffer{}, ExportFormatCSV, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	_, err := Export(context.Background(), nil, &bytes.Buffer{}, "xml", nil)
+	assert.Error(t, err)
+}
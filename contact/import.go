@@ -0,0 +1,293 @@
+package contact
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+)
+
+// DefaultImportConcurrency is the number of contacts Import writes in
+// parallel when no concurrency is set in ImportMapping.
+const DefaultImportConcurrency = 5
+
+// ImportMapping tells Import which CSV columns hold which contact fields.
+// Columns are identified by their header. The MSISDN column must be present:
+// other fields are not imported if their column is empty or not in the CSV.
+type ImportMapping struct {
+	MSISDN    string
+	FirstName string
+	LastName  string
+	Custom1   string
+	Custom2   string
+	Custom3   string
+	Custom4   string
+
+	// DefaultCountry is the ISO 3166-1 alpha-2 country code used for MSISDNs
+	// in national format, e.g. NL. Without it, MSISDNs must be international
+	// numbers, with or without the leading + as Export writes them. See
+	// numberutil.Normalize.
+	DefaultCountry string
+
+	// Concurrency is the maximum number of contacts written at the same time.
+	// Defaults to DefaultImportConcurrency.
+	Concurrency int
+}
+
+// DefaultImportMapping maps the columns msisdn, firstName, lastName and
+// custom1 to custom4 to the fields of the same name.
+var DefaultImportMapping = &ImportMapping{
+	MSISDN:    "msisdn",
+	FirstName: "firstName",
+	LastName:  "lastName",
+	Custom1:   "custom1",
+	Custom2:   "custom2",
+	Custom3:   "custom3",
+	Custom4:   "custom4",
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusCreated ImportStatus = "created"
+	ImportStatusUpdated ImportStatus = "updated"
+	ImportStatusFailed  ImportStatus = "failed"
+)
+
+// ImportRow is the outcome of importing a single CSV row.
+type ImportRow struct {
+	// Line is the line number of the row in the CSV, starting at 1 for the
+	// header.
+	Line int
+
+	// MSISDN is the normalized MSISDN, or the raw value if it could not be
+	// normalized.
+	MSISDN    string
+	ContactID string
+	Status    ImportStatus
+	Err       error
+}
+
+// ImportReport lists the outcome of every imported row, in CSV order.
+type ImportReport struct {
+	Rows []*ImportRow
+}
+
+// Count returns the number of rows with the given status.
+func (r *ImportReport) Count(status ImportStatus) int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Failed returns the rows that could not be imported.
+func (r *ImportReport) Failed() []*ImportRow {
+	var failed []*ImportRow
+	for _, row := range r.Rows {
+		if row.Status == ImportStatusFailed {
+			failed = append(failed, row)
+		}
+	}
+
+	return failed
+}
+
+// Import reads contacts from CSV data in r and creates them, or updates the
+// existing contact with the same MSISDN. The first CSV row must be a header.
+// A nil mapping uses DefaultImportMapping.
+//
+// Rows that fail do not stop the import: they are reported in the returned
+// ImportReport. An error is only returned if the CSV can not be read, or if
+// ctx is done. In the latter case, no new rows are started and the report
+// holds the rows processed so far.
+func Import(ctx context.Context, c messagebird.Client, r io.Reader, mapping *ImportMapping) (*ImportReport, error) {
+	if mapping == nil {
+		mapping = DefaultImportMapping
+	}
+
+	concurrency := mapping.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultImportConcurrency
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	columns, err := mapping.columns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{}
+
+	var (
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, concurrency)
+		locks = &keyedMutex{locks: make(map[string]*sync.Mutex)}
+	)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			wg.Wait()
+			return report, fmt.Errorf("reading CSV line %d: %w", line, err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		row := &ImportRow{Line: line}
+		report.Rows = append(report.Rows, row)
+
+		wg.Add(1)
+		go func(record []string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			importRow(c, row, columns.request(record), mapping.DefaultCountry, locks)
+		}(record)
+	}
+
+	wg.Wait()
+
+	return report, ctx.Err()
+}
+
+func importRow(c messagebird.Client, row *ImportRow, req *CreateRequest, defaultCountry string, locks *keyedMutex) {
+	row.MSISDN = req.MSISDN
+
+	msisdn, err := numberutil.MSISDN(req.MSISDN, defaultCountry)
+	if err != nil {
+		row.Status, row.Err = ImportStatusFailed, err
+		return
+	}
+	row.MSISDN, req.MSISDN = msisdn, msisdn
+
+	// Rows for the same MSISDN are written one after another, so a contact
+	// is not created twice.
+	unlock := locks.lock(msisdn)
+	defer unlock()
+
+	contact, created, err := Upsert(c, req)
+	if err != nil {
+		row.Status, row.Err = ImportStatusFailed, err
+		return
+	}
+
+	row.Status = ImportStatusUpdated
+	if created {
+		row.Status = ImportStatusCreated
+	}
+	row.ContactID = contact.ID
+}
+
+// importColumns holds the index of every mapped column, or -1 if it is not
+// mapped.
+type importColumns struct {
+	msisdn, firstName, lastName        int
+	custom1, custom2, custom3, custom4 int
+}
+
+func (m *ImportMapping) columns(header []string) (*importColumns, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+
+	lookup := func(name string) int {
+		if i, ok := index[name]; ok && name != "" {
+			return i
+		}
+		return -1
+	}
+
+	if m.MSISDN == "" {
+		return nil, errors.New("mapping must include an MSISDN column")
+	}
+
+	cols := &importColumns{msisdn: lookup(m.MSISDN)}
+	if cols.msisdn < 0 {
+		return nil, fmt.Errorf("column %q not found in CSV header", m.MSISDN)
+	}
+
+	for _, f := range []struct {
+		dst  *int
+		name string
+	}{
+		{&cols.firstName, m.FirstName},
+		{&cols.lastName, m.LastName},
+		{&cols.custom1, m.Custom1},
+		{&cols.custom2, m.Custom2},
+		{&cols.custom3, m.Custom3},
+		{&cols.custom4, m.Custom4},
+	} {
+		*f.dst = lookup(f.name)
+	}
+
+	return cols, nil
+}
+
+func (cols *importColumns) request(record []string) *CreateRequest {
+	get := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	return &CreateRequest{
+		MSISDN:    get(cols.msisdn),
+		FirstName: get(cols.firstName),
+		LastName:  get(cols.lastName),
+		Custom1:   get(cols.custom1),
+		Custom2:   get(cols.custom2),
+		Custom3:   get(cols.custom3),
+		Custom4:   get(cols.custom4),
+	}
+}
+
+// keyedMutex serializes work per key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func (k *keyedMutex) lock(key string) (unlock func()) {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		k.locks[key] = l
+	}
+	k.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
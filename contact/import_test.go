@@ -0,0 +1,112 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport(t *testing.T) {
+	var mu sync.Mutex
+	var writes []string
+
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		switch {
+		case method == "GET" && strings.Contains(path, "msisdn=31612345678"):
+			v.(*Contacts).Items = []Contact{{ID: "existing-id"}}
+		case method == "GET":
+		case strings.Contains(path, "31687654321") || data.(*CreateRequest).MSISDN == "31687654321":
+			return errors.New("API error")
+		default:
+			req := data.(*CreateRequest)
+			mu.Lock()
+			writes = append(writes, method+" "+path+" "+req.MSISDN+" "+req.FirstName+" "+req.Custom1)
+			mu.Unlock()
+			v.(*Contact).ID = "id-" + req.MSISDN
+		}
+		return nil
+	})
+
+	csv := "Phone,Name,Tier\n" +
+		"06 12345678,Foo,gold\n" +
+		"+31 6 11111111,Bar,silver\n" +
+		"not a number,Baz,\n" +
+		"0687654321,Qux,bronze\n"
+
+	report, err := Import(context.Background(), client, strings.NewReader(csv), &ImportMapping{
+		MSISDN:         "Phone",
+		FirstName:      "Name",
+		Custom1:        "Tier",
+		DefaultCountry: "NL",
+		Concurrency:    2,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, report.Rows, 4)
+
+	assert.Equal(t, ImportStatusUpdated, report.Rows[0].Status)
+	assert.Equal(t, "31612345678", report.Rows[0].MSISDN)
+	assert.Equal(t, 2, report.Rows[0].Line)
+
+	assert.Equal(t, ImportStatusCreated, report.Rows[1].Status)
+	assert.Equal(t, "id-31611111111", report.Rows[1].ContactID)
+
+	assert.Equal(t, ImportStatusFailed, report.Rows[2].Status)
+	assert.Equal(t, "not a number", report.Rows[2].MSISDN)
+	assert.Error(t, report.Rows[2].Err)
+
+	assert.Equal(t, ImportStatusFailed, report.Rows[3].Status)
+	assert.EqualError(t, report.Rows[3].Err, "API error")
+
+	assert.Equal(t, 2, len(report.Failed()))
+	assert.Equal(t, 1, report.Count(ImportStatusCreated))
+
+	assert.ElementsMatch(t, []string{
+		"PATCH contacts/existing-id 31612345678 Foo gold",
+		"POST contacts 31611111111 Bar silver",
+	}, writes)
+}
+
+func TestImportInternational(t *testing.T) {
+	var msisdns []string
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		if method == "POST" {
+			msisdns = append(msisdns, data.(*CreateRequest).MSISDN)
+		}
+		return nil
+	})
+
+	// The format Export writes, without a default country.
+	report, err := Import(context.Background(), client, strings.NewReader("msisdn,firstName\n31612345678,Foo\n"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Count(ImportStatusCreated))
+	assert.Equal(t, []string{"31612345678"}, msisdns)
+}
+
+func TestImportMissingColumn(t *testing.T) {
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		t.Fatal("no requests expected")
+		return nil
+	})
+
+	_, err := Import(context.Background(), client, strings.NewReader("phone,name\n"), nil)
+	assert.EqualError(t, err, `column "msisdn" not found in CSV header`)
+}
+
+func TestImportCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		t.Fatal("no requests expected after cancellation")
+		return nil
+	})
+
+	report, err := Import(ctx, client, strings.NewReader("msisdn\n31612345678\n"), nil)
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, report.Rows)
+}
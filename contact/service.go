@@ -0,0 +1,70 @@
+package contact
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+// Service is the Contacts API bound to a client, see sms.Service.
+type Service interface {
+	CheckConsent(contact *Contact, channel string) error
+	SendSMS(contact *Contact, originator, body string, params *sms.Params) (*sms.Message, error)
+	Create(contactRequest *CreateRequest) (*Contact, error)
+	Delete(id string) error
+	List(options *messagebird.PaginationRequest) (*Contacts, error)
+	Search(query *SearchRequest) (*Contacts, error)
+	Groups(id string, options *messagebird.PaginationRequest) (*GroupList, error)
+	Read(id string, req *ViewRequest) (*Contact, error)
+	Upsert(contactRequest *CreateRequest) (*Contact, bool, error)
+	Update(id string, contactRequest *CreateRequest) (*Contact, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) CheckConsent(contact *Contact, channel string) error {
+	return CheckConsent(s.c, contact, channel)
+}
+
+func (s *service) SendSMS(contact *Contact, originator, body string, params *sms.Params) (*sms.Message, error) {
+	return SendSMS(s.c, contact, originator, body, params)
+}
+
+func (s *service) Create(contactRequest *CreateRequest) (*Contact, error) {
+	return Create(s.c, contactRequest)
+}
+
+func (s *service) Delete(id string) error {
+	return Delete(s.c, id)
+}
+
+func (s *service) List(options *messagebird.PaginationRequest) (*Contacts, error) {
+	return List(s.c, options)
+}
+
+func (s *service) Search(query *SearchRequest) (*Contacts, error) {
+	return Search(s.c, query)
+}
+
+func (s *service) Groups(id string, options *messagebird.PaginationRequest) (*GroupList, error) {
+	return Groups(s.c, id, options)
+}
+
+func (s *service) Read(id string, req *ViewRequest) (*Contact, error) {
+	return Read(s.c, id, req)
+}
+
+func (s *service) Upsert(contactRequest *CreateRequest) (*Contact, bool, error) {
+	return Upsert(s.c, contactRequest)
+}
+
+func (s *service) Update(id string, contactRequest *CreateRequest) (*Contact, error) {
+	return Update(s.c, id, contactRequest)
+}
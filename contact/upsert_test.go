@@ -0,0 +1,95 @@
+package contact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsert(t *testing.T) {
+	var requests []string
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		requests = append(requests, method+" "+path)
+		switch method {
+		case "GET":
+		case "POST":
+			v.(*Contact).ID = "new-id"
+		}
+		return nil
+	})
+
+	contact, created, err := Upsert(client, &CreateRequest{MSISDN: "31612345678"})
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "new-id", contact.ID)
+	assert.Equal(t, []string{"GET contacts?limit=1&msisdn=31612345678", "POST contacts"}, requests)
+}
+
+func TestUpsertExisting(t *testing.T) {
+	var requests []string
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		requests = append(requests, method+" "+path)
+		switch method {
+		case "GET":
+			v.(*Contacts).Items = []Contact{{ID: "existing-id"}}
+		case "PATCH":
+			v.(*Contact).ID = "existing-id"
+		}
+		return nil
+	})
+
+	contact, created, err := Upsert(client, &CreateRequest{MSISDN: "31612345678", FirstName: "Foo"})
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "existing-id", contact.ID)
+	assert.Equal(t, []string{"GET contacts?limit=1&msisdn=31612345678", "PATCH contacts/existing-id"}, requests)
+}
+
+func TestUpsertRace(t *testing.T) {
+	var requests []string
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		requests = append(requests, method+" "+path)
+		switch method {
+		case "GET":
+			// The contact only shows up after the create failed.
+			if len(requests) > 1 {
+				v.(*Contacts).Items = []Contact{{ID: "existing-id"}}
+			}
+		case "POST":
+			return errors.New("contact already exists")
+		case "PATCH":
+			v.(*Contact).ID = "existing-id"
+		}
+		return nil
+	})
+
+	contact, created, err := Upsert(client, &CreateRequest{MSISDN: "31612345678"})
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "existing-id", contact.ID)
+	assert.Equal(t, "GET POST GET PATCH", methods(requests))
+}
+
+func TestUpsertCreateError(t *testing.T) {
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		if method == "POST" {
+			return errors.New("invalid msisdn")
+		}
+		return nil
+	})
+
+	_, _, err := Upsert(client, &CreateRequest{MSISDN: "1"})
+	assert.EqualError(t, err, "invalid msisdn")
+}
+
+func methods(requests []string) string {
+	var m []string
+	for _, r := range requests {
+		m = append(m, strings.Fields(r)[0])
+	}
+
+	return strings.Join(m, " ")
+}
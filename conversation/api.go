@@ -6,12 +6,31 @@ import (
 )
 
 const (
-	// apiRoot is the absolute URL of the Converstations API. All paths are
-	// relative to apiRoot (e.g.
+	// APIName is the name of the Conversations API in the APIVersions of a
+	// messagebird.DefaultClient.
+	APIName = "conversations"
+
+	// APIVersion1 and APIVersion2 are the versions of the Conversations API.
+	// Requests use APIVersion1, unless the client selects another version:
+	//
+	//	client.APIVersions = map[string]string{conversation.APIName: conversation.APIVersion2}
+	//
+	// The version only changes the path of requests. The types of this
+	// package model v1, and are used for both versions as is: requests are
+	// not converted, and fields of v2 responses that the types do not have
+	// are dropped, except those of messages, which are kept in their
+	// RawContent and Extras.
+	APIVersion1 = "v1"
+	APIVersion2 = "v2"
+)
+
+const (
+	// apiHost is the absolute URL of the Converstations API. All paths are
+	// relative to apiHost and the API version (e.g.
 	// https://conversations.messagebird.com/v1/webhooks).
-	apiRoot = "https://conversations.messagebird.com/v1"
+	apiHost = "https://conversations.messagebird.com"
 
-	// path is the path for the Conversation resource, relative to apiRoot.
+	// path is the path for the Conversation resource, relative to apiHost.
 	path = "conversations"
 
 	// startConversationPath is the path for starting new conversation
@@ -20,20 +39,29 @@ const (
 	// contactPath is the path for fetching a collection of conversations by contact ID
 	contactPath = "contact"
 
-	// messagesPath is the path for the Message resource, relative to apiRoot
+	// messagesPath is the path for the Message resource, relative to apiHost
 	// and path.
 	messagesPath = "messages"
 
-	// sendMessagePath is the path for creating the Message resource relative to apiRoot
+	// sendMessagePath is the path for creating the Message resource relative to apiHost
 	sendMessagePath = "send"
 
-	// webhooksPath is the path for the Webhook resource, relative to apiRoot.
+	// webhooksPath is the path for the Webhook resource, relative to apiHost.
 	webhooksPath = "webhooks"
+
+	// channelsPath is the path for channels, relative to apiHost.
+	channelsPath = "channels"
+
+	// devicesPath is the path for the PushDevice resource, relative to
+	// apiHost and channelsPath.
+	devicesPath = "devices"
 )
 
 // request does the exact same thing as DefaultClient.Request. It does, however,
-// prefix the path with the Conversation API's root. This ensures the client
-// doesn't "handle" this for us: by default, it uses the REST API.
+// prefix the path with the Conversation API's root for the API version of c.
+// This ensures the client doesn't "handle" this for us: by default, it uses
+// the REST API.
 func request(c messagebird.Client, v interface{}, method, path string, data interface{}) error {
-	return c.Request(v, method, fmt.Sprintf("%s/%s", apiRoot, path), data)
+	version := messagebird.APIVersionOf(c, APIName, APIVersion1)
+	return c.Request(v, method, fmt.Sprintf("%s/%s/%s", apiHost, version, path), data)
 }
@@ -1,6 +1,7 @@
 package conversation
 
 import (
+	"github.com/messagebird/go-rest-api/v9/blacklist"
 	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -17,9 +18,26 @@ func TestRequestSandboxEnabled(t *testing.T) {
 	reqPath := "qwerty"
 
 	client := mbtest.MockClient().(*mbtest.ClientMock)
-	client.On("Request", data, method, apiRoot+"/"+reqPath, data).Return(nil)
+	client.On("Request", data, method, apiHost+"/v1/"+reqPath, data).Return(nil)
 
 	err := request(client, data, method, reqPath, data)
 
 	assert.NoError(t, err)
 }
+
+func TestRequestAPIVersion(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+	client.APIVersions = map[string]string{APIName: APIVersion2}
+
+	_, err := ReadMessage(client, "mesid")
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v2/messages/mesid")
+
+	// The version is kept when the client is wrapped.
+	_, err = ReadMessage(&blacklist.FilteringClient{Client: client, Store: blacklist.NewMemoryStore()}, "mesid")
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v2/messages/mesid")
+}
@@ -3,11 +3,11 @@ package conversation
 import (
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
 )
 
 const (
@@ -19,6 +19,10 @@ const (
 	// archived. When this is the case, a new Conversation is created when a
 	// message is received from a contact.
 	ConversationStatusArchived Status = "archived"
+
+	// ConversationStatusUnknown is decoded for statuses this package does
+	// not know.
+	ConversationStatusUnknown Status = "unknown"
 )
 
 type Conversation struct {
@@ -53,6 +57,8 @@ type MessagesCount struct {
 // Status indicates what state a Conversation is in.
 type Status string
 
+// Platform identifies the platform of a channel, e.g. WhatsApp. The platforms
+// differ in the message types they support: see platform.go.
 type Platform string
 
 type Conversations struct {
@@ -97,6 +103,11 @@ type ReplyRequest struct {
 	Tag       MessageTag             `json:"tag,omitempty"`
 	TrackId   string                 `json:"trackId,omitempty"`
 	TTL       string                 `json:"ttl,omitempty"`
+
+	// TemplateFallback, if set, is sent instead of Content when the customer
+	// service window of the conversation has closed, see CanSendFreeform.
+	// Outside of the window, WhatsApp only delivers templates.
+	TemplateFallback *HSM `json:"-"`
 }
 
 // UpdateRequest contains the request data for the Update endpoint.
@@ -117,10 +128,14 @@ func (lr *ListRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
-	query.Set("limit", strconv.Itoa(lr.Limit))
-	query.Set("offset", strconv.Itoa(lr.Offset))
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
 
 	if len(lr.Ids) > 0 {
 		query.Set("ids", lr.Ids)
@@ -143,10 +158,14 @@ func (lr *ListByContactRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
-	query.Set("limit", strconv.Itoa(lr.Limit))
-	query.Set("offset", strconv.Itoa(lr.Offset))
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
 
 	if len(lr.Id) > 0 {
 		query.Set("id", lr.Id)
@@ -191,8 +210,15 @@ func Read(c messagebird.Client, id string) (*Conversation, error) {
 }
 
 // Start creates a conversation by sending an initial message. If an active
-// conversation exists for the recipient, it is resumed.
+// conversation exists for the recipient, it is resumed. Recipients that are
+// phone numbers are normalized to MSISDNs, see numberutil.Recipient.
 func Start(c messagebird.Client, req *StartRequest) (*Conversation, error) {
+	if req != nil {
+		normalized := *req
+		normalized.To = MessageRecipient(numberutil.Recipient(string(req.To)))
+		req = &normalized
+	}
+
 	conv := &Conversation{}
 	if err := request(c, conv, http.MethodPost, path+"/"+startConversationPath, req); err != nil {
 		return nil, err
@@ -202,9 +228,24 @@ func Start(c messagebird.Client, req *StartRequest) (*Conversation, error) {
 }
 
 // Reply Send a new message to an existing conversation. In case the conversation is archived, a new conversation is created.
+// If req has a TemplateFallback, the conversation is read first to check
+// whether a free-form message may be sent.
 func Reply(c messagebird.Client, conversationID string, req *ReplyRequest) (*Message, error) {
 	uri := fmt.Sprintf("%s/%s/%s", path, conversationID, messagesPath)
 
+	if req != nil && req.TemplateFallback != nil && req.Type != MessageTypeHSM {
+		freeform, err := CanSendFreeform(c, conversationID)
+		if err != nil {
+			return nil, err
+		}
+		if !freeform {
+			fallback := *req
+			fallback.Type = MessageTypeHSM
+			fallback.Content = &MessageContent{HSM: req.TemplateFallback}
+			req = &fallback
+		}
+	}
+
 	message := &Message{}
 	if err := request(c, message, http.MethodPost, uri, req); err != nil {
 		return nil, err
@@ -24,10 +24,20 @@ func TestList(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "limit=10&offset=20", query)
 	})
 
+	t.Run("default pagination", func(t *testing.T) {
+		mbtest.WillReturnTestdata(t, "conversationListObject.json", http.StatusOK)
+		client := mbtest.Client(t)
+
+		_, err := List(client, &ListRequest{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "", mbtest.LastRequest().URL.RawQuery)
+	})
+
 	t.Run("all", func(t *testing.T) {
 		mbtest.WillReturnTestdata(t, "allConversationListObject.json", http.StatusOK)
 		client := mbtest.Client(t)
@@ -43,7 +53,7 @@ func TestList(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "", query)
 	})
 }
@@ -67,7 +77,7 @@ func TestListByContact(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations/contact/"+contactId)
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "limit=20&offset=2", query)
 	})
 
@@ -87,7 +97,7 @@ func TestListByContact(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations/contact/"+contactId)
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "", query)
 	})
 }
@@ -151,7 +161,7 @@ func TestStartHSM(t *testing.T) {
 	assert.Equal(t, "convid", conv.ID)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/conversations/start")
-	mbtest.AssertTestdataJson(t, "conversationStartHsmRequest.json", mbtest.Request.Body)
+	mbtest.AssertTestdataJson(t, "conversationStartHsmRequest.json", mbtest.LastRequest().Body)
 }
 
 func mustParseRFC3339(t *testing.T, s string) *time.Time {
@@ -179,7 +189,7 @@ func TestStartMedia(t *testing.T) {
 	assert.Equal(t, "convid", conv.ID)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/conversations/start")
-	mbtest.AssertTestdataJson(t, "conversationStartVideoRequest.json", mbtest.Request.Body)
+	mbtest.AssertTestdataJson(t, "conversationStartVideoRequest.json", mbtest.LastRequest().Body)
 }
 
 func TestStartText(t *testing.T) {
@@ -198,7 +208,7 @@ func TestStartText(t *testing.T) {
 	assert.Equal(t, "convid", conv.ID)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/conversations/start")
-	mbtest.AssertTestdataJson(t, "conversationStartTextRequest.json", mbtest.Request.Body)
+	mbtest.AssertTestdataJson(t, "conversationStartTextRequest.json", mbtest.LastRequest().Body)
 }
 
 func TestReply(t *testing.T) {
@@ -217,7 +227,7 @@ func TestReply(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/conversations/convid/messages")
 
-	mbtest.AssertTestdataJson(t, "conversationReplyRequest.json", mbtest.Request.Body)
+	mbtest.AssertTestdataJson(t, "conversationReplyRequest.json", mbtest.LastRequest().Body)
 }
 
 func TestUpdate(t *testing.T) {
@@ -231,5 +241,5 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(t, ConversationStatusArchived, conv.Status)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/v1/conversations/id")
-	mbtest.AssertTestdataJson(t, "conversationUpdateRequest.json", mbtest.Request.Body)
+	mbtest.AssertTestdataJson(t, "conversationUpdateRequest.json", mbtest.LastRequest().Body)
 }
@@ -5,3 +5,5 @@
 // functionality needed to send requests, as well as any structs required for
 // that - e.g. request data or pagination options.
 package conversation
+
+//go:generate go run ../internal/enumgen -type Status,MessageStatus,WebhookStatus,WhatsAppPricingCategory
@@ -0,0 +1,165 @@
+// Code generated by enumgen -type Status,MessageStatus,WebhookStatus,WhatsAppPricingCategory; DO NOT EDIT.
+
+package conversation
+
+// ConversationStatusValues returns the known values of Status.
+func ConversationStatusValues() []Status {
+	return []Status{
+		ConversationStatusActive,
+		ConversationStatusArchived,
+	}
+}
+
+// IsValid reports whether s is one of ConversationStatusValues.
+func (s Status) IsValid() bool {
+	switch s {
+	case ConversationStatusActive,
+		ConversationStatusArchived:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as ConversationStatusUnknown.
+func (s *Status) UnmarshalText(text []byte) error {
+	*s = Status(text)
+	if *s != "" && !s.IsValid() {
+		*s = ConversationStatusUnknown
+	}
+
+	return nil
+}
+
+// MessageStatusValues returns the known values of MessageStatus.
+func MessageStatusValues() []MessageStatus {
+	return []MessageStatus{
+		MessageStatusAccepted,
+		MessageStatusPending,
+		MessageStatusSent,
+		MessageStatusDelivered,
+		MessageStatusRejected,
+		MessageStatusFailed,
+		MessageStatusRead,
+		MessageStatusReceived,
+		MessageStatusDeleted,
+		MessageStatusTransmitted,
+		MessageStatusDeliveryFailed,
+		MessageStatusBuffered,
+		MessageStatusExpired,
+		MessageStatusClicked,
+		MessageStatusOpened,
+		MessageStatusBounce,
+		MessageStatusSpamComplaint,
+		MessageStatusOutOfBounded,
+		MessageStatusDelayed,
+		MessageStatusListUnsubscribe,
+		MessageStatusDispatched,
+	}
+}
+
+// IsValid reports whether m is one of MessageStatusValues.
+func (m MessageStatus) IsValid() bool {
+	switch m {
+	case MessageStatusAccepted,
+		MessageStatusPending,
+		MessageStatusSent,
+		MessageStatusDelivered,
+		MessageStatusRejected,
+		MessageStatusFailed,
+		MessageStatusRead,
+		MessageStatusReceived,
+		MessageStatusDeleted,
+		MessageStatusTransmitted,
+		MessageStatusDeliveryFailed,
+		MessageStatusBuffered,
+		MessageStatusExpired,
+		MessageStatusClicked,
+		MessageStatusOpened,
+		MessageStatusBounce,
+		MessageStatusSpamComplaint,
+		MessageStatusOutOfBounded,
+		MessageStatusDelayed,
+		MessageStatusListUnsubscribe,
+		MessageStatusDispatched:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as MessageStatusUnknown.
+func (m *MessageStatus) UnmarshalText(text []byte) error {
+	*m = MessageStatus(text)
+	if *m != "" && !m.IsValid() {
+		*m = MessageStatusUnknown
+	}
+
+	return nil
+}
+
+// WebhookStatusValues returns the known values of WebhookStatus.
+func WebhookStatusValues() []WebhookStatus {
+	return []WebhookStatus{
+		WebhookStatusEnabled,
+		WebhookStatusDisabled,
+	}
+}
+
+// IsValid reports whether w is one of WebhookStatusValues.
+func (w WebhookStatus) IsValid() bool {
+	switch w {
+	case WebhookStatusEnabled,
+		WebhookStatusDisabled:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as WebhookStatusUnknown.
+func (w *WebhookStatus) UnmarshalText(text []byte) error {
+	*w = WebhookStatus(text)
+	if *w != "" && !w.IsValid() {
+		*w = WebhookStatusUnknown
+	}
+
+	return nil
+}
+
+// WhatsAppPricingValues returns the known values of WhatsAppPricingCategory.
+func WhatsAppPricingValues() []WhatsAppPricingCategory {
+	return []WhatsAppPricingCategory{
+		WhatsAppPricingMarketing,
+		WhatsAppPricingUtility,
+		WhatsAppPricingAuthentication,
+		WhatsAppPricingService,
+	}
+}
+
+// IsValid reports whether w is one of WhatsAppPricingValues.
+func (w WhatsAppPricingCategory) IsValid() bool {
+	switch w {
+	case WhatsAppPricingMarketing,
+		WhatsAppPricingUtility,
+		WhatsAppPricingAuthentication,
+		WhatsAppPricingService:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as WhatsAppPricingUnknown.
+func (w *WhatsAppPricingCategory) UnmarshalText(text []byte) error {
+	*w = WhatsAppPricingCategory(text)
+	if *w != "" && !w.IsValid() {
+		*w = WhatsAppPricingUnknown
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package conversation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageStatusUnmarshalText(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected MessageStatus
+	}{
+		{`"on_hold"`, MessageStatusUnknown},
+		{`"read"`, MessageStatusRead},
+		{`"unknown"`, MessageStatusUnknown},
+		{`""`, ""},
+	}
+
+	for _, tt := range tests {
+		var status MessageStatus
+		assert.NoError(t, json.Unmarshal([]byte(tt.json), &status))
+		assert.Equal(t, tt.expected, status, tt.json)
+	}
+}
+
+func TestStatusValues(t *testing.T) {
+	assert.Equal(t, []Status{ConversationStatusActive, ConversationStatusArchived}, ConversationStatusValues())
+	assert.NotContains(t, MessageStatusValues(), MessageStatusUnknown)
+
+	for _, status := range WebhookStatusValues() {
+		assert.True(t, status.IsValid())
+	}
+	assert.False(t, WebhookStatus("paused").IsValid())
+}
@@ -0,0 +1,12 @@
+package conversation
+
+// InstagramStory is the content of a message received when a contact replies
+// to, or mentions your account in, an Instagram story. Both are received only:
+// they can not be sent.
+type InstagramStory struct {
+	// URL is the URL of the story's media. It expires with the story, after
+	// 24 hours.
+	URL  string `json:"url"`
+	ID   string `json:"id,omitempty"`
+	Text string `json:"text,omitempty"`
+}
@@ -0,0 +1,21 @@
+package conversation
+
+import (
+	"io"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/files"
+)
+
+// UploadMedia uploads the contents of r to the file storage of the Messaging
+// API, so it can be sent as an image, audio, video or file message without
+// hosting it yourself. The content type is detected from the contents, or from
+// the extension of name.
+func UploadMedia(c messagebird.Client, name string, r io.Reader, caption string) (*Media, error) {
+	file, err := files.Upload(c, name, "", r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Media{URL: file.URL, Caption: caption}, nil
+}
@@ -0,0 +1,25 @@
+package conversation
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadMedia(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"file-id"}`), http.StatusCreated)
+	client := mbtest.Client(t)
+
+	media, err := UploadMedia(client, "invoice.pdf", strings.NewReader("%PDF-1.4"), "Your invoice")
+	assert.NoError(t, err)
+	assert.Equal(t, &Media{URL: "https://messaging.messagebird.com/v1/files/file-id", Caption: "Your invoice"}, media)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/files")
+	mbtest.AssertBodyField(t, "name", "invoice.pdf")
+	mbtest.AssertBodyField(t, "contentType", "application/pdf")
+	mbtest.AssertBodyField(t, "content", base64.StdEncoding.EncodeToString([]byte("%PDF-1.4")))
+}
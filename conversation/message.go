@@ -1,13 +1,16 @@
 package conversation
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
+	"reflect"
+	"strings"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
 )
 
 const (
@@ -22,6 +25,7 @@ const (
 	MessageStatusAccepted        MessageStatus = "accepted"
 	MessageStatusPending         MessageStatus = "pending"
 	MessageStatusSent            MessageStatus = "sent"
+	MessageStatusDelivered       MessageStatus = "delivered"
 	MessageStatusRejected        MessageStatus = "rejected"
 	MessageStatusFailed          MessageStatus = "failed"
 	MessageStatusRead            MessageStatus = "read"
@@ -63,6 +67,17 @@ const (
 
 	MessageTypeExternalAttachment MessageType = "externalAttachment"
 	MessageTypeEmail              MessageType = "email"
+
+	MessageTypePush MessageType = "push"
+
+	MessageTypeRCSText     MessageType = "rcsText"
+	MessageTypeRCSRichCard MessageType = "rcsRichCard"
+	MessageTypeRCSCarousel MessageType = "rcsCarousel"
+	MessageTypeRCSFile     MessageType = "rcsFile"
+
+	MessageTypeInstagramStoryReply   MessageType = "instagramStoryReply"
+	MessageTypeInstagramStoryMention MessageType = "instagramStoryMention"
+	MessageTypeTelegramText          MessageType = "telegramText"
 )
 
 // MessageType indicates what kind of content a Message has, e.g. audio or text.
@@ -98,6 +113,173 @@ type Message struct {
 	Tag             MessageTag
 	Fallback        *Fallback
 	TTL             string
+
+	// Pricing is how WhatsApp charges the message, if the API reports it.
+	Pricing *WhatsAppPricing
+
+	// WhatsAppConversation is the WhatsApp conversation the message is
+	// charged in, if the API reports it.
+	WhatsAppConversation *WhatsAppConversation `json:"conversation"`
+
+	// RawContent is the content as returned by the API, including the
+	// payloads Content has no field for. Use ContentField to decode them.
+	RawContent json.RawMessage `json:"-"`
+
+	// Extras is a JSON object with the fields of the message that Message
+	// has no field for, or nil if there are none. Use ExtraField to decode
+	// them.
+	Extras json.RawMessage `json:"-"`
+}
+
+// messageFields are the lowercase JSON names of the fields of Message that
+// are decoded from JSON.
+var messageFields = func() map[string]bool {
+	fields := map[string]bool{}
+	t := reflect.TypeOf(Message{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+		case "":
+			fields[strings.ToLower(f.Name)] = true
+		default:
+			fields[strings.ToLower(name)] = true
+		}
+	}
+
+	return fields
+}()
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Besides the
+// fields of Message, it keeps the raw content in RawContent and any other
+// fields in Extras, so no inbound data is lost.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type message Message
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	extras := map[string]json.RawMessage{}
+	for name, value := range fields {
+		if strings.EqualFold(name, "content") {
+			if string(value) != "null" {
+				msg.RawContent = value
+			}
+		} else if !messageFields[strings.ToLower(name)] {
+			extras[name] = value
+		}
+	}
+
+	if len(extras) > 0 {
+		b, err := json.Marshal(extras)
+		if err != nil {
+			return err
+		}
+		msg.Extras = b
+	}
+
+	*m = Message(msg)
+	return nil
+}
+
+// ContentField decodes the field name of RawContent into v. It returns false
+// if the content has no such field.
+func (m *Message) ContentField(name string, v interface{}) (bool, error) {
+	return decodeField(m.RawContent, name, v)
+}
+
+// ExtraField decodes the field name of Extras into v. It returns false if
+// there is no such field.
+func (m *Message) ExtraField(name string, v interface{}) (bool, error) {
+	return decodeField(m.Extras, name, v)
+}
+
+func decodeField(object json.RawMessage, name string, v interface{}) (bool, error) {
+	if len(object) == 0 {
+		return false, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(object, &fields); err != nil {
+		return false, err
+	}
+
+	value, ok := fields[name]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(value, v)
+}
+
+// WhatsAppReferral returns the ad or post that a customer clicked to send
+// an inbound WhatsApp message, or nil if the message has no referral.
+func (m *Message) WhatsAppReferral() (*WhatsAppReferral, error) {
+	var referral *WhatsAppReferral
+	if _, err := m.ContentField("referral", &referral); referral != nil || err != nil {
+		return referral, err
+	}
+
+	var text struct {
+		Referral *WhatsAppReferral `json:"referral"`
+	}
+	if _, err := m.ContentField("whatsappText", &text); err != nil {
+		return nil, err
+	}
+
+	return text.Referral, nil
+}
+
+// WhatsAppInteractiveReply returns the button or list item a customer
+// picked in reply to an interactive WhatsApp message, or nil if the message
+// is not such a reply.
+func (m *Message) WhatsAppInteractiveReply() (*WhatsAppInteractiveReply, error) {
+	if m.Content != nil && m.Content.Interactive != nil && m.Content.Interactive.Reply != nil {
+		return m.Content.Interactive.Reply, nil
+	}
+
+	// Replies may also be in the format of the WhatsApp Business API.
+	type reply struct {
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	var interactive struct {
+		ButtonReply *reply `json:"button_reply"`
+		ListReply   *reply `json:"list_reply"`
+	}
+	if _, err := m.ContentField("interactive", &interactive); err != nil {
+		return nil, err
+	}
+
+	r := interactive.ButtonReply
+	if r == nil {
+		r = interactive.ListReply
+	}
+	if r == nil {
+		return nil, nil
+	}
+
+	return &WhatsAppInteractiveReply{Id: r.ID, Text: r.Title, Description: r.Description}, nil
+}
+
+// WhatsAppOrder returns the order of an inbound WhatsApp message, or nil if
+// the message is not an order.
+func (m *Message) WhatsAppOrder() (*WhatsAppOrder, error) {
+	if m.Content != nil && m.Content.WhatsAppOrder != nil {
+		return m.Content.WhatsAppOrder, nil
+	}
+
+	var order *WhatsAppOrder
+	_, err := m.ContentField("order", &order)
+	return order, err
 }
 
 // MessageContent holds a message's actual content. Only one field can be set
@@ -126,6 +308,19 @@ type MessageContent struct {
 	Email               *Email   `json:"email,omitempty"`
 	ExternalAttachments []*Media `json:"externalAttachments,omitempty"`
 	DisableUrlPreview   bool     `json:"disableUrlPreview,omitempty"`
+
+	// Push is a push notification for a mobile app. Its definition lives in
+	// push.go.
+	Push *Push `json:"push,omitempty"`
+
+	RCSText     *RCSText     `json:"rcsText,omitempty"`
+	RCSRichCard *RCSRichCard `json:"rcsRichCard,omitempty"`
+	RCSCarousel *RCSCarousel `json:"rcsCarousel,omitempty"`
+	RCSFile     *RCSFile     `json:"rcsFile,omitempty"`
+
+	InstagramStoryReply   *InstagramStory `json:"instagramStoryReply,omitempty"`
+	InstagramStoryMention *InstagramStory `json:"instagramStoryMention,omitempty"`
+	TelegramText          *TelegramText   `json:"telegramText,omitempty"`
 }
 
 type Audio Media
@@ -180,11 +375,17 @@ func (lr *ListConversationMessagesRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
-	query.Set("limit", strconv.Itoa(lr.Limit))
-	query.Set("offset", strconv.Itoa(lr.Offset))
-	query.Set("excludePlatforms", lr.ExcludePlatforms)
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
+	if lr.ExcludePlatforms != "" {
+		query.Set("excludePlatforms", lr.ExcludePlatforms)
+	}
 
 	return query.Encode()
 }
@@ -199,11 +400,13 @@ func (lr *ListMessagesRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
-	query.Set("ids", lr.Ids)
+	if lr.Ids != "" {
+		query.Set("ids", lr.Ids)
+	}
 	if lr.From != nil {
-		query.Set("from", lr.From.Format(time.RFC3339))
+		query.SetTime("from", *lr.From, time.RFC3339)
 	}
 
 	return query.Encode()
@@ -212,7 +415,15 @@ func (lr *ListMessagesRequest) QueryParams() string {
 // SendMessage send a message to a specific recipient in a specific platform.
 // If an active conversation already exists for the recipient, the conversation will be resumed.
 // In case there's no active conversation a new one is created.
+// Recipients that are phone numbers are normalized to MSISDNs, see
+// numberutil.Recipient.
 func SendMessage(c messagebird.Client, options *SendMessageRequest) (*Message, error) {
+	if options != nil {
+		normalized := *options
+		normalized.To = numberutil.Recipient(options.To)
+		options = &normalized
+	}
+
 	message := &Message{}
 	if err := request(c, message, http.MethodPost, sendMessagePath, options); err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package conversation
 
 import (
+	"encoding/json"
 	messagebird "github.com/messagebird/go-rest-api/v9"
 	"net/http"
 	"testing"
@@ -28,6 +29,7 @@ func TestSendMessage(t *testing.T) {
 	assert.Equal(t, MessageStatusAccepted, message.Status)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/send")
+	mbtest.AssertBodyField(t, "to", "31624971134")
 }
 
 func TestListMessages(t *testing.T) {
@@ -46,7 +48,7 @@ func TestListMessages(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "ids=5f3437fdb8444583aea093a047ac014b%2C4abc37fdb8444583aea093a047ac014c", query)
 	})
 
@@ -67,7 +69,7 @@ func TestListMessages(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "", query)
 	})
 }
@@ -90,7 +92,7 @@ func TestListConversationMessages(t *testing.T) {
 		assert.Equal(t, "mesid", messageList.Items[0].ID)
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations/"+conversationId+"/messages")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "excludePlatforms=sms%2Cwhatsapp%2Cfacebook&limit=20&offset=2", query)
 	})
 
@@ -105,7 +107,7 @@ func TestListConversationMessages(t *testing.T) {
 		assert.Equal(t, "mesid", messageList.Items[0].ID)
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations/"+conversationId+"/messages")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "", query)
 	})
 }
@@ -123,3 +125,66 @@ func TestReadMessage(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages/mesid")
 }
+
+func TestMessageUnmarshalJSONRaw(t *testing.T) {
+	var message Message
+	err := json.Unmarshal([]byte(`{
+		"id": "mesid",
+		"platform": "whatsapp",
+		"type": "interactive",
+		"content": {
+			"interactive": {
+				"type": "button_reply",
+				"button_reply": {"id": "yes", "title": "Yes please"}
+			},
+			"referral": {
+				"source_url": "https://fb.me/ad",
+				"source_type": "ad",
+				"headline": "Spring sale"
+			}
+		},
+		"status": "received",
+		"context": {"id": "previous-mesid"}
+	}`), &message)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "mesid", message.ID)
+	assert.Equal(t, WhatsAppInteractiveType("button_reply"), message.Content.Interactive.Type)
+	assert.Equal(t, MessageStatusReceived, message.Status)
+	assert.JSONEq(t, `{"context": {"id": "previous-mesid"}}`, string(message.Extras))
+
+	var context struct{ ID string }
+	ok, err := message.ExtraField("context", &context)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous-mesid", context.ID)
+
+	ok, err = message.ContentField("unknown", &context)
+	assert.False(t, ok)
+	assert.NoError(t, err)
+
+	reply, err := message.WhatsAppInteractiveReply()
+	assert.NoError(t, err)
+	assert.Equal(t, &WhatsAppInteractiveReply{Id: "yes", Text: "Yes please"}, reply)
+
+	referral, err := message.WhatsAppReferral()
+	assert.NoError(t, err)
+	assert.Equal(t, &WhatsAppReferral{SourceURL: "https://fb.me/ad", SourceType: "ad", Headline: "Spring sale"}, referral)
+
+	order, err := message.WhatsAppOrder()
+	assert.NoError(t, err)
+	assert.Nil(t, order)
+}
+
+func TestMessageUnmarshalJSONNoExtras(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+
+	message, err := ReadMessage(mbtest.Client(t), "mesid")
+	assert.NoError(t, err)
+	assert.Nil(t, message.Extras)
+	assert.NotEmpty(t, message.RawContent)
+
+	referral, err := message.WhatsAppReferral()
+	assert.NoError(t, err)
+	assert.Nil(t, referral)
+}
@@ -0,0 +1,140 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	PlatformSMS       Platform = "sms"
+	PlatformWhatsApp  Platform = "whatsapp"
+	PlatformFacebook  Platform = "facebook"
+	PlatformInstagram Platform = "instagram"
+	PlatformTelegram  Platform = "telegram"
+	PlatformLine      Platform = "line"
+	PlatformWeChat    Platform = "wechat"
+	PlatformEmail     Platform = "email"
+)
+
+// maxTelegramCallbackData is the maximum size in bytes of the CallbackData
+// of a TelegramInlineButton.
+const maxTelegramCallbackData = 64
+
+// platformRules describes the message types a platform accepts.
+type platformRules struct {
+	// maxTextLength is the maximum number of characters of a text message.
+	maxTextLength int
+
+	// types maps the message types that can be sent to the maximum size in
+	// bytes of their media, or 0 if they have no media.
+	types map[MessageType]int64
+}
+
+// rules lists the platforms for which the accepted message types are known.
+var rules = map[Platform]*platformRules{
+	PlatformInstagram: {
+		maxTextLength: 1000,
+		types: map[MessageType]int64{
+			MessageTypeText:  0,
+			MessageTypeImage: 8 * 1024 * 1024,
+			MessageTypeVideo: 25 * 1024 * 1024,
+			MessageTypeAudio: 25 * 1024 * 1024,
+		},
+	},
+	PlatformTelegram: {
+		maxTextLength: 4096,
+		types: map[MessageType]int64{
+			MessageTypeText:         0,
+			MessageTypeTelegramText: 0,
+			MessageTypeLocation:     0,
+			MessageTypeImage:        10 * 1024 * 1024,
+			MessageTypeVideo:        50 * 1024 * 1024,
+			MessageTypeAudio:        50 * 1024 * 1024,
+			MessageTypeFile:         50 * 1024 * 1024,
+		},
+	},
+}
+
+// Supports reports whether messages of type t can be sent on p. It returns
+// true for platforms whose rules are not known to this package, leaving the
+// decision to the API.
+func (p Platform) Supports(t MessageType) bool {
+	r, ok := rules[p]
+	if !ok {
+		return true
+	}
+
+	_, ok = r.types[t]
+	return ok
+}
+
+// MaxMediaSize returns the maximum size in bytes of the media of messages of
+// type t on p, or 0 if it is unknown or t has no media.
+func (p Platform) MaxMediaSize(t MessageType) int64 {
+	if r, ok := rules[p]; ok {
+		return r.types[t]
+	}
+
+	return 0
+}
+
+// Validate checks content of type t against the rules of p, so unsupported
+// messages can be caught before they are sent. Like Supports, it accepts any
+// content for platforms whose rules are not known.
+func (p Platform) Validate(t MessageType, content *MessageContent) error {
+	r, ok := rules[p]
+	if !ok {
+		return nil
+	}
+
+	if !p.Supports(t) {
+		return fmt.Errorf("message type %s is not supported on %s", t, p)
+	}
+
+	if content == nil {
+		return errors.New("content is required")
+	}
+
+	text := content.Text
+	if t == MessageTypeTelegramText {
+		if content.TelegramText == nil {
+			return errors.New("telegramText is required")
+		}
+		if err := validateTelegramText(content.TelegramText); err != nil {
+			return err
+		}
+		text = content.TelegramText.Text
+	}
+
+	if n := utf8.RuneCountInString(text); n > r.maxTextLength {
+		return fmt.Errorf("text of %d characters exceeds maximum of %d on %s", n, r.maxTextLength, p)
+	}
+
+	return nil
+}
+
+func validateTelegramText(tt *TelegramText) error {
+	switch tt.ParseMode {
+	case "", TelegramParseModeMarkdown, TelegramParseModeMarkdownV2, TelegramParseModeHTML:
+	default:
+		return fmt.Errorf("unsupported parse mode %q", tt.ParseMode)
+	}
+
+	if tt.InlineKeyboard == nil {
+		return nil
+	}
+
+	for _, row := range tt.InlineKeyboard.Rows {
+		for _, button := range row {
+			if (button.URL == "") == (button.CallbackData == "") {
+				return fmt.Errorf("button %q must have either a url or callback data", button.Text)
+			}
+			if len(button.CallbackData) > maxTelegramCallbackData {
+				return fmt.Errorf("callback data of button %q exceeds maximum of %d bytes", button.Text, maxTelegramCallbackData)
+			}
+		}
+	}
+
+	return nil
+}
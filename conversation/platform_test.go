@@ -0,0 +1,71 @@
+package conversation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlatformSupports(t *testing.T) {
+	assert.True(t, PlatformInstagram.Supports(MessageTypeImage))
+	assert.False(t, PlatformInstagram.Supports(MessageTypeFile))
+	assert.False(t, PlatformInstagram.Supports(MessageTypeInstagramStoryReply))
+
+	assert.True(t, PlatformTelegram.Supports(MessageTypeTelegramText))
+	assert.False(t, PlatformTelegram.Supports(MessageTypeHSM))
+
+	assert.True(t, PlatformWhatsApp.Supports(MessageTypeHSM))
+}
+
+func TestPlatformMaxMediaSize(t *testing.T) {
+	assert.Equal(t, int64(8*1024*1024), PlatformInstagram.MaxMediaSize(MessageTypeImage))
+	assert.Equal(t, int64(50*1024*1024), PlatformTelegram.MaxMediaSize(MessageTypeFile))
+	assert.Zero(t, PlatformTelegram.MaxMediaSize(MessageTypeText))
+	assert.Zero(t, PlatformSMS.MaxMediaSize(MessageTypeImage))
+}
+
+func TestPlatformValidate(t *testing.T) {
+	assert.NoError(t, PlatformInstagram.Validate(MessageTypeText, &MessageContent{Text: "Hi"}))
+	assert.NoError(t, PlatformLine.Validate(MessageTypeFile, nil))
+
+	err := PlatformInstagram.Validate(MessageTypeLocation, &MessageContent{Location: &Location{}})
+	assert.EqualError(t, err, "message type location is not supported on instagram")
+
+	err = PlatformInstagram.Validate(MessageTypeText, &MessageContent{Text: strings.Repeat("é", 1001)})
+	assert.EqualError(t, err, "text of 1001 characters exceeds maximum of 1000 on instagram")
+
+	err = PlatformTelegram.Validate(MessageTypeTelegramText, nil)
+	assert.EqualError(t, err, "content is required")
+
+	err = PlatformTelegram.Validate(MessageTypeTelegramText, &MessageContent{})
+	assert.EqualError(t, err, "telegramText is required")
+}
+
+func TestPlatformValidateTelegramText(t *testing.T) {
+	content := &MessageContent{
+		TelegramText: &TelegramText{
+			Text:      "*Order shipped*",
+			ParseMode: TelegramParseModeMarkdownV2,
+			InlineKeyboard: &TelegramInlineKeyboard{
+				Rows: [][]*TelegramInlineButton{
+					{
+						{Text: "Track", URL: "https://example.com/track/1234"},
+						{Text: "Cancel", CallbackData: "cancel:1234"},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, PlatformTelegram.Validate(MessageTypeTelegramText, content))
+
+	content.TelegramText.ParseMode = "BBCode"
+	assert.EqualError(t, PlatformTelegram.Validate(MessageTypeTelegramText, content), `unsupported parse mode "BBCode"`)
+
+	content.TelegramText.ParseMode = TelegramParseModeHTML
+	content.TelegramText.InlineKeyboard.Rows[0][0].CallbackData = "track"
+	assert.EqualError(t, PlatformTelegram.Validate(MessageTypeTelegramText, content), `button "Track" must have either a url or callback data`)
+
+	content.TelegramText.InlineKeyboard.Rows[0][0] = &TelegramInlineButton{Text: "Track", CallbackData: strings.Repeat("x", 65)}
+	assert.EqualError(t, PlatformTelegram.Validate(MessageTypeTelegramText, content), `callback data of button "Track" exceeds maximum of 64 bytes`)
+}
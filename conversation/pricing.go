@@ -0,0 +1,99 @@
+package conversation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// WhatsAppPricingCategory is the category WhatsApp charges a message, or
+// the WhatsApp conversation it is part of, in.
+type WhatsAppPricingCategory string
+
+const (
+	WhatsAppPricingMarketing      WhatsAppPricingCategory = "marketing"
+	WhatsAppPricingUtility        WhatsAppPricingCategory = "utility"
+	WhatsAppPricingAuthentication WhatsAppPricingCategory = "authentication"
+	WhatsAppPricingService        WhatsAppPricingCategory = "service"
+	WhatsAppPricingUnknown        WhatsAppPricingCategory = "unknown"
+)
+
+// WhatsAppPricing is how WhatsApp charges a message.
+type WhatsAppPricing struct {
+	Billable     bool                    `json:"billable"`
+	PricingModel string                  `json:"pricing_model"`
+	Category     WhatsAppPricingCategory `json:"category"`
+}
+
+// WhatsAppConversation is the WhatsApp conversation a message is charged in.
+// WhatsApp charges per conversation: all messages of a category sent within
+// the same 24 hours are charged once. It is unrelated to the Conversation of
+// the Conversations API.
+type WhatsAppConversation struct {
+	ID string
+
+	// Origin is the category of the message that opened the conversation,
+	// which is the category the conversation is charged in.
+	Origin WhatsAppPricingCategory
+
+	// ExpirationTimestamp is when the conversation ends, if known.
+	ExpirationTimestamp *time.Time
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The expiration
+// timestamp is sent as Unix time, as a number or string, by WhatsApp and
+// may also be sent as RFC 3339 time.
+func (c *WhatsAppConversation) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID     string `json:"id"`
+		Origin struct {
+			Type WhatsAppPricingCategory `json:"type"`
+		} `json:"origin"`
+		ExpirationTimestamp json.RawMessage `json:"expiration_timestamp"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	expiration, err := parseTimestamp(raw.ExpirationTimestamp)
+	if err != nil {
+		return fmt.Errorf("expiration_timestamp: %w", err)
+	}
+
+	*c = WhatsAppConversation{ID: raw.ID, Origin: raw.Origin.Type, ExpirationTimestamp: expiration}
+	return nil
+}
+
+// Open reports whether the conversation has not expired at now.
+func (c *WhatsAppConversation) Open(now time.Time) bool {
+	return c != nil && c.ExpirationTimestamp != nil && now.Before(*c.ExpirationTimestamp)
+}
+
+// parseTimestamp parses Unix time as a JSON number or string, or RFC 3339
+// time. It returns nil for missing, null and empty timestamps.
+func parseTimestamp(data json.RawMessage) (*time.Time, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		s = string(data)
+	}
+	if s == "" {
+		return nil, nil
+	}
+
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t := time.Unix(unix, 0).UTC()
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,59 @@
+package conversation
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessagePricing(t *testing.T) {
+	var message Message
+	err := json.Unmarshal([]byte(`{
+		"id": "mesid",
+		"platform": "whatsapp",
+		"status": "sent",
+		"pricing": {"billable": true, "pricing_model": "CBP", "category": "utility"},
+		"conversation": {
+			"id": "wa-conversation",
+			"origin": {"type": "utility"},
+			"expiration_timestamp": "1662541200"
+		}
+	}`), &message)
+	assert.NoError(t, err)
+	assert.Nil(t, message.Extras)
+
+	assert.Equal(t, &WhatsAppPricing{Billable: true, PricingModel: "CBP", Category: WhatsAppPricingUtility}, message.Pricing)
+
+	expiration := time.Date(2022, 9, 7, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, &WhatsAppConversation{ID: "wa-conversation", Origin: WhatsAppPricingUtility, ExpirationTimestamp: &expiration}, message.WhatsAppConversation)
+	assert.True(t, message.WhatsAppConversation.Open(expiration.Add(-time.Minute)))
+	assert.False(t, message.WhatsAppConversation.Open(expiration))
+}
+
+func TestWhatsAppConversationTimestamps(t *testing.T) {
+	expiration := time.Date(2022, 9, 7, 9, 0, 0, 0, time.UTC)
+
+	for _, timestamp := range []string{`1662541200`, `"1662541200"`, `"2022-09-07T09:00:00Z"`} {
+		var c WhatsAppConversation
+		assert.NoError(t, json.Unmarshal([]byte(`{"expiration_timestamp": `+timestamp+`}`), &c), timestamp)
+		assert.True(t, expiration.Equal(*c.ExpirationTimestamp), timestamp)
+	}
+
+	var c WhatsAppConversation
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": "wa-conversation"}`), &c))
+	assert.Nil(t, c.ExpirationTimestamp)
+	assert.False(t, c.Open(expiration))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"expiration_timestamp": "tomorrow"}`), &c))
+}
+
+func TestWhatsAppPricingCategory(t *testing.T) {
+	var category WhatsAppPricingCategory
+	assert.NoError(t, json.Unmarshal([]byte(`"authentication"`), &category))
+	assert.Equal(t, WhatsAppPricingAuthentication, category)
+
+	assert.NoError(t, json.Unmarshal([]byte(`"referral_conversion"`), &category))
+	assert.Equal(t, WhatsAppPricingUnknown, category)
+}
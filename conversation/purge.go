@@ -0,0 +1,184 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+)
+
+// DefaultPurgeInterval is the time Purge waits between purging two
+// conversations when PurgeOptions has no Interval.
+const DefaultPurgeInterval = 100 * time.Millisecond
+
+// purgeListLimit is the number of conversations Purge lists per request.
+const purgeListLimit = 100
+
+// PurgeOptions configures Purge.
+type PurgeOptions struct {
+	// Interval is the minimum time between purging two conversations, to
+	// stay below the rate limit of your account. Defaults to
+	// DefaultPurgeInterval.
+	Interval time.Duration
+
+	// DryRun reports the conversations that would be purged without
+	// purging them.
+	DryRun bool
+
+	// Remove, if set, is called for every conversation that is purged,
+	// before it is archived. Use it to remove what you keep of the
+	// conversation and its messages yourself, e.g. in a database or CRM. If
+	// it returns an error, Purge stops.
+	Remove func(ctx context.Context, conv *Conversation) error
+}
+
+// PurgeReport is the result of Purge.
+type PurgeReport struct {
+	// Checked is the number of conversations checked.
+	Checked int
+
+	// Purged are the conversations that were purged, or would have been in
+	// a dry run.
+	Purged []*PurgedConversation
+}
+
+// PurgedConversation is a conversation without activity within the
+// retention window of Purge.
+type PurgedConversation struct {
+	ID        string
+	ContactID string
+
+	// LastActivity is when the conversation was last created, updated or
+	// received a message.
+	LastActivity time.Time
+
+	// Messages is the number of messages in the conversation.
+	Messages int
+
+	// Archived reports whether the conversation was archived by Purge. It
+	// is false for conversations that were archived already.
+	Archived bool
+}
+
+// Purge archives the conversations without activity in the last olderThan,
+// for retention policies. The Conversations API can not delete conversations
+// or their messages: archived conversations are no longer active, and a new
+// conversation is started when the contact sends a message again. Set
+// opts.Remove to remove copies kept outside of MessageBird. opts may be nil.
+//
+// Archiving a conversation updates it, so conversations archived by Purge
+// are not purged again. Conversations that were archived otherwise are
+// reported, and passed to opts.Remove, every time. If an error is returned,
+// the report holds the conversations that were purged so far.
+func Purge(ctx context.Context, c messagebird.Client, olderThan time.Duration, opts *PurgeOptions) (*PurgeReport, error) {
+	if olderThan <= 0 {
+		return nil, errors.New("olderThan is required")
+	}
+	if opts == nil {
+		opts = &PurgeOptions{}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultPurgeInterval
+	}
+
+	clock := messagebird.ClockOf(c)
+	cutoff := clock.Now().Add(-olderThan)
+	report := &PurgeReport{}
+
+	// Archiving conversations while listing them would shift the pages, so
+	// all are listed first.
+	var expired []*Conversation
+	var pager paging.Pager
+	for !pager.Done() {
+		pager.Fetch(func(offset int) (int, int, error) {
+			if err := ctx.Err(); err != nil {
+				return 0, 0, err
+			}
+
+			conversations, err := List(c, &ListRequest{PaginationRequest: messagebird.PaginationRequest{Limit: purgeListLimit, Offset: offset}})
+			if err != nil {
+				return 0, 0, fmt.Errorf("listing conversations: %w", err)
+			}
+
+			for _, conv := range conversations.Items {
+				report.Checked++
+				if lastActivity(conv).Before(cutoff) {
+					expired = append(expired, conv)
+				}
+			}
+
+			return len(conversations.Items), conversations.TotalCount, nil
+		})
+	}
+	if err := pager.Err(); err != nil {
+		return report, err
+	}
+
+	for i, conv := range expired {
+		if i > 0 && !opts.DryRun {
+			select {
+			case <-ctx.Done():
+			case <-clock.After(interval):
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		purged := &PurgedConversation{
+			ID:           conv.ID,
+			ContactID:    conv.ContactID,
+			LastActivity: lastActivity(conv),
+			Archived:     conv.Status != ConversationStatusArchived,
+		}
+		if conv.Messages != nil {
+			purged.Messages = conv.Messages.TotalCount
+		}
+
+		if !opts.DryRun {
+			if err := purge(ctx, c, conv, opts); err != nil {
+				return report, err
+			}
+		}
+
+		report.Purged = append(report.Purged, purged)
+	}
+
+	return report, nil
+}
+
+func purge(ctx context.Context, c messagebird.Client, conv *Conversation, opts *PurgeOptions) error {
+	if opts.Remove != nil {
+		if err := opts.Remove(ctx, conv); err != nil {
+			return fmt.Errorf("removing conversation %s: %w", conv.ID, err)
+		}
+	}
+
+	if conv.Status == ConversationStatusArchived {
+		return nil
+	}
+
+	if _, err := Update(c, conv.ID, &UpdateRequest{Status: ConversationStatusArchived}); err != nil {
+		return fmt.Errorf("archiving conversation %s: %w", conv.ID, err)
+	}
+
+	return nil
+}
+
+// lastActivity returns the latest of the times conv was created, updated and
+// received a message.
+func lastActivity(conv *Conversation) time.Time {
+	last := conv.CreatedDatetime
+	for _, t := range []*time.Time{conv.UpdatedDatetime, conv.LastReceivedDatetime} {
+		if t != nil && t.After(last) {
+			last = *t
+		}
+	}
+
+	return last
+}
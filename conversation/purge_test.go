@@ -0,0 +1,89 @@
+package conversation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// purgeClient serves a single page of conversations and records the
+// conversations that are archived.
+type purgeClient struct {
+	conversations string
+	archived      []string
+}
+
+func (c *purgeClient) Request(v interface{}, method, path string, data interface{}) error {
+	switch {
+	case method == http.MethodGet && strings.HasSuffix(path, "/conversations?limit=100"):
+		return json.Unmarshal([]byte(c.conversations), v)
+	case method == http.MethodPatch:
+		c.archived = append(c.archived, path[strings.LastIndex(path, "/")+1:])
+		return json.Unmarshal([]byte(`{"status": "archived"}`), v)
+	}
+
+	return fmt.Errorf("unexpected %s %s", method, path)
+}
+
+func TestPurge(t *testing.T) {
+	day := 24 * time.Hour
+	conversations := fmt.Sprintf(`{"totalCount": 4, "items": [
+		{"id": "recent", "status": "active", "createdDatetime": %q, "lastReceivedDatetime": %q},
+		{"id": "old", "contactId": "contact-1", "status": "active", "createdDatetime": %q, "updatedDatetime": %q, "messages": {"totalCount": 12}},
+		{"id": "updated", "status": "active", "createdDatetime": %q, "updatedDatetime": %q},
+		{"id": "archived", "status": "archived", "createdDatetime": %q}
+	]}`, ago(400*day), ago(day), ago(400*day), ago(100*day), ago(400*day), ago(2*day), ago(200*day))
+
+	client := &purgeClient{conversations: conversations}
+	var removed []string
+	opts := &PurgeOptions{
+		Interval: time.Millisecond,
+		Remove: func(ctx context.Context, conv *Conversation) error {
+			removed = append(removed, conv.ID)
+			return nil
+		},
+	}
+
+	report, err := Purge(context.Background(), client, 90*day, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Checked)
+	assert.Equal(t, []string{"old"}, client.archived)
+	assert.Equal(t, []string{"old", "archived"}, removed)
+	if assert.Len(t, report.Purged, 2) {
+		assert.Equal(t, "old", report.Purged[0].ID)
+		assert.Equal(t, "contact-1", report.Purged[0].ContactID)
+		assert.Equal(t, 12, report.Purged[0].Messages)
+		assert.True(t, report.Purged[0].Archived)
+		assert.False(t, report.Purged[1].Archived)
+	}
+
+	client = &purgeClient{conversations: conversations}
+	removed = nil
+	opts.DryRun = true
+
+	report, err = Purge(context.Background(), client, 90*day, opts)
+	assert.NoError(t, err)
+	assert.Len(t, report.Purged, 2)
+	assert.Empty(t, client.archived)
+	assert.Empty(t, removed)
+
+	client = &purgeClient{conversations: conversations}
+	opts = &PurgeOptions{Remove: func(ctx context.Context, conv *Conversation) error {
+		return errors.New("database unavailable")
+	}}
+
+	report, err = Purge(context.Background(), client, 90*day, opts)
+	assert.EqualError(t, err, "removing conversation old: database unavailable")
+	assert.Empty(t, report.Purged)
+	assert.Empty(t, client.archived)
+
+	_, err = Purge(context.Background(), client, 0, nil)
+	assert.EqualError(t, err, "olderThan is required")
+}
@@ -0,0 +1,121 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// PushPlatform is the notification service a PushDevice receives push
+// notifications from.
+type PushPlatform string
+
+const (
+	// PushPlatformAPNS is the Apple Push Notification service, for iOS apps.
+	PushPlatformAPNS PushPlatform = "apns"
+
+	// PushPlatformFCM is Firebase Cloud Messaging, for Android apps.
+	PushPlatformFCM PushPlatform = "fcm"
+)
+
+// Push is the content of a push notification. Send it with SendMessage, to the
+// ID of a PushDevice, and MessageTypePush. A Fallback can be set to reach
+// the contact by SMS or WhatsApp if the notification can not be delivered.
+type Push struct {
+	Title    string            `json:"title"`
+	Body     string            `json:"body"`
+	ImageURL string            `json:"imageUrl,omitempty"`
+	Sound    string            `json:"sound,omitempty"`
+	Badge    int               `json:"badge,omitempty"`
+	Data     map[string]string `json:"data,omitempty"`
+}
+
+// PushDevice is an installation of a mobile app, registered on a push
+// channel to receive push notifications.
+type PushDevice struct {
+	ID              string
+	ChannelID       string
+	ContactID       string
+	Platform        PushPlatform
+	Token           string
+	CreatedDatetime *time.Time
+	UpdatedDatetime *time.Time
+}
+
+type PushDeviceList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*PushDevice
+}
+
+// PushDeviceRequest contains the request data to register a PushDevice.
+type PushDeviceRequest struct {
+	// ContactID links the device to an existing contact, so messages to the
+	// contact can be delivered as push notifications. It is optional.
+	ContactID string       `json:"contactId,omitempty"`
+	Platform  PushPlatform `json:"platform"`
+
+	// Token is the device token issued to the app by the Platform.
+	Token string `json:"token"`
+}
+
+// RegisterPushDevice registers a device on the push channel with channelID.
+// Registering a token that is already registered updates the existing device.
+func RegisterPushDevice(c messagebird.Client, channelID string, req *PushDeviceRequest) (*PushDevice, error) {
+	if err := validatePushDeviceRequest(channelID, req); err != nil {
+		return nil, err
+	}
+
+	device := &PushDevice{}
+	if err := request(c, device, http.MethodPost, pushDevicesPath(channelID), req); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// ListPushDevices gets the devices registered on the push channel with
+// channelID. Pagination can be set in options.
+func ListPushDevices(c messagebird.Client, channelID string, options *messagebird.PaginationRequest) (*PushDeviceList, error) {
+	deviceList := &PushDeviceList{}
+	if err := request(c, deviceList, http.MethodGet, pushDevicesPath(channelID)+"?"+options.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return deviceList, nil
+}
+
+// DeletePushDevice unregisters a device, e.g. when the app was uninstalled.
+// If the error is nil, the deletion was successful.
+func DeletePushDevice(c messagebird.Client, channelID, id string) error {
+	return request(c, nil, http.MethodDelete, pushDevicesPath(channelID)+"/"+id, nil)
+}
+
+func pushDevicesPath(channelID string) string {
+	return fmt.Sprintf("%s/%s/%s", channelsPath, channelID, devicesPath)
+}
+
+func validatePushDeviceRequest(channelID string, req *PushDeviceRequest) error {
+	if channelID == "" {
+		return errors.New("channelID is required")
+	}
+
+	if req == nil {
+		return errors.New("push device request should not be nil")
+	}
+
+	if req.Platform != PushPlatformAPNS && req.Platform != PushPlatformFCM {
+		return fmt.Errorf("unsupported platform %q", req.Platform)
+	}
+
+	if req.Token == "" {
+		return errors.New("token is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package conversation
+
+import (
+	"net/http"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterPushDevice(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "pushDeviceObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	device, err := RegisterPushDevice(client, "2fb6f6fb0bd24ee1b2a3c4d5e6f7a8b9", &PushDeviceRequest{
+		ContactID: "9354647c5d1a47cd9d5f1e7a3f1dbd3f",
+		Platform:  PushPlatformFCM,
+		Token:     "fcm-device-token",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "b4c9a1f2e3d84c5b9a7e6f1d2c3b4a59", device.ID)
+	assert.Equal(t, PushPlatformFCM, device.Platform)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/channels/2fb6f6fb0bd24ee1b2a3c4d5e6f7a8b9/devices")
+	mbtest.AssertBodyJSONEq(t, `{
+		"contactId": "9354647c5d1a47cd9d5f1e7a3f1dbd3f",
+		"platform": "fcm",
+		"token": "fcm-device-token"
+	}`)
+}
+
+func TestRegisterPushDeviceValidation(t *testing.T) {
+	client := mbtest.Client(t)
+
+	_, err := RegisterPushDevice(client, "", &PushDeviceRequest{Platform: PushPlatformAPNS, Token: "token"})
+	assert.EqualError(t, err, "channelID is required")
+
+	_, err = RegisterPushDevice(client, "channel", nil)
+	assert.EqualError(t, err, "push device request should not be nil")
+
+	_, err = RegisterPushDevice(client, "channel", &PushDeviceRequest{Platform: "wns", Token: "token"})
+	assert.EqualError(t, err, `unsupported platform "wns"`)
+
+	_, err = RegisterPushDevice(client, "channel", &PushDeviceRequest{Platform: PushPlatformAPNS})
+	assert.EqualError(t, err, "token is required")
+}
+
+func TestListPushDevices(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "pushDeviceListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := ListPushDevices(client, "2fb6f6fb0bd24ee1b2a3c4d5e6f7a8b9", messagebird.DefaultPagination)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.TotalCount)
+	assert.Equal(t, PushPlatformAPNS, list.Items[1].Platform)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/channels/2fb6f6fb0bd24ee1b2a3c4d5e6f7a8b9/devices")
+	mbtest.AssertQueryParam(t, "limit", "20")
+}
+
+func TestDeletePushDevice(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := DeletePushDevice(client, "2fb6f6fb0bd24ee1b2a3c4d5e6f7a8b9", "b4c9a1f2e3d84c5b9a7e6f1d2c3b4a59")
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/channels/2fb6f6fb0bd24ee1b2a3c4d5e6f7a8b9/devices/b4c9a1f2e3d84c5b9a7e6f1d2c3b4a59")
+}
+
+func TestSendPushWithFallback(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageSendResponse.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	_, err := SendMessage(client, &SendMessageRequest{
+		To:   "b4c9a1f2e3d84c5b9a7e6f1d2c3b4a59",
+		From: "2fb6f6fb0bd24ee1b2a3c4d5e6f7a8b9",
+		Type: MessageTypePush,
+		Content: &MessageContent{
+			Push: &Push{
+				Title: "Order shipped",
+				Body:  "Your order is on its way",
+				Data:  map[string]string{"orderId": "1234"},
+			},
+		},
+		Fallback: &Fallback{From: "MessageBird", After: "5m"},
+	})
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/send")
+	mbtest.AssertBodyField(t, "type", "push")
+	mbtest.AssertBodyField(t, "content.push", map[string]interface{}{
+		"title": "Order shipped",
+		"body":  "Your order is on its way",
+		"data":  map[string]string{"orderId": "1234"},
+	})
+	mbtest.AssertBodyField(t, "fallback.after", "5m")
+}
@@ -0,0 +1,113 @@
+package conversation
+
+// RCSText is a text message with suggested replies and actions, shown as
+// chips below the message.
+type RCSText struct {
+	Text        string           `json:"text"`
+	Suggestions []*RCSSuggestion `json:"suggestions,omitempty"`
+}
+
+// RCSCardOrientation is the layout of an RCSRichCard.
+type RCSCardOrientation string
+
+const (
+	RCSCardOrientationVertical   RCSCardOrientation = "vertical"
+	RCSCardOrientationHorizontal RCSCardOrientation = "horizontal"
+)
+
+// RCSRichCard is a single card with media, a title, a description and
+// suggestions.
+type RCSRichCard struct {
+	Orientation RCSCardOrientation `json:"orientation"`
+	Content     *RCSCardContent    `json:"content"`
+	Suggestions []*RCSSuggestion   `json:"suggestions,omitempty"`
+}
+
+// RCSCardWidth is the width of the cards of an RCSCarousel.
+type RCSCardWidth string
+
+const (
+	RCSCardWidthSmall  RCSCardWidth = "small"
+	RCSCardWidthMedium RCSCardWidth = "medium"
+)
+
+// RCSCarousel is a horizontally scrollable list of 2 to 10 cards.
+type RCSCarousel struct {
+	CardWidth   RCSCardWidth      `json:"cardWidth"`
+	Cards       []*RCSCardContent `json:"cards"`
+	Suggestions []*RCSSuggestion  `json:"suggestions,omitempty"`
+}
+
+// RCSCardContent is the content of a card in an RCSRichCard or RCSCarousel.
+// At least one of Title, Description and Media must be set.
+type RCSCardContent struct {
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Media       *RCSMedia        `json:"media,omitempty"`
+	Suggestions []*RCSSuggestion `json:"suggestions,omitempty"`
+}
+
+// RCSMediaHeight is the height of the media of a vertical card.
+type RCSMediaHeight string
+
+const (
+	RCSMediaHeightShort  RCSMediaHeight = "short"
+	RCSMediaHeightMedium RCSMediaHeight = "medium"
+	RCSMediaHeightTall   RCSMediaHeight = "tall"
+)
+
+// RCSMedia is an image or video shown on a card.
+type RCSMedia struct {
+	URL          string         `json:"url"`
+	ThumbnailURL string         `json:"thumbnailUrl,omitempty"`
+	Height       RCSMediaHeight `json:"height,omitempty"`
+}
+
+// RCSFile is a file transfer. The recipient's app downloads the file from
+// URL, showing ThumbnailURL while it does.
+type RCSFile struct {
+	URL          string           `json:"url"`
+	ThumbnailURL string           `json:"thumbnailUrl,omitempty"`
+	Suggestions  []*RCSSuggestion `json:"suggestions,omitempty"`
+}
+
+// RCSSuggestion is a suggested reply or a suggested action. Only one of
+// Reply and Action can be set.
+type RCSSuggestion struct {
+	Reply  *RCSSuggestedReply  `json:"reply,omitempty"`
+	Action *RCSSuggestedAction `json:"action,omitempty"`
+}
+
+// RCSSuggestedReply sends Text back when tapped. The resulting inbound
+// message contains PostbackData.
+type RCSSuggestedReply struct {
+	Text         string `json:"text"`
+	PostbackData string `json:"postbackData"`
+}
+
+// RCSSuggestedAction makes the recipient's device perform an action when
+// tapped. Only one of OpenURL, Dial and ViewLocation can be set.
+type RCSSuggestedAction struct {
+	Text         string                 `json:"text"`
+	PostbackData string                 `json:"postbackData"`
+	OpenURL      *RCSOpenURLAction      `json:"openUrlAction,omitempty"`
+	Dial         *RCSDialAction         `json:"dialAction,omitempty"`
+	ViewLocation *RCSViewLocationAction `json:"viewLocationAction,omitempty"`
+}
+
+// RCSOpenURLAction opens URL in the browser.
+type RCSOpenURLAction struct {
+	URL string `json:"url"`
+}
+
+// RCSDialAction opens the dialer with PhoneNumber.
+type RCSDialAction struct {
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// RCSViewLocationAction shows a location on a map.
+type RCSViewLocationAction struct {
+	LatLong *Location `json:"latLong,omitempty"`
+	Label   string    `json:"label,omitempty"`
+	Query   string    `json:"query,omitempty"`
+}
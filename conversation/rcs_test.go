@@ -0,0 +1,105 @@
+package conversation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRCSRichCard(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageSendResponse.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	_, err := SendMessage(client, &SendMessageRequest{
+		To:   "+31612345678",
+		From: "rcs-channel-id",
+		Type: MessageTypeRCSRichCard,
+		Content: &MessageContent{
+			RCSRichCard: &RCSRichCard{
+				Orientation: RCSCardOrientationVertical,
+				Content: &RCSCardContent{
+					Title:       "Your order",
+					Description: "Arrives tomorrow",
+					Media:       &RCSMedia{URL: "https://example.com/parcel.jpg", Height: RCSMediaHeightMedium},
+				},
+				Suggestions: []*RCSSuggestion{
+					{Reply: &RCSSuggestedReply{Text: "Thanks!", PostbackData: "thanks"}},
+					{Action: &RCSSuggestedAction{
+						Text:         "Track",
+						PostbackData: "track",
+						OpenURL:      &RCSOpenURLAction{URL: "https://example.com/track/1234"},
+					}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	mbtest.AssertBodyField(t, "type", "rcsRichCard")
+	mbtest.AssertBodyJSONEq(t, `{
+		"to": "31612345678",
+		"from": "rcs-channel-id",
+		"type": "rcsRichCard",
+		"content": {
+			"rcsRichCard": {
+				"orientation": "vertical",
+				"content": {
+					"title": "Your order",
+					"description": "Arrives tomorrow",
+					"media": {"url": "https://example.com/parcel.jpg", "height": "medium"}
+				},
+				"suggestions": [
+					{"reply": {"text": "Thanks!", "postbackData": "thanks"}},
+					{"action": {"text": "Track", "postbackData": "track", "openUrlAction": {"url": "https://example.com/track/1234"}}}
+				]
+			}
+		}
+	}`)
+}
+
+func TestSendRCSCarousel(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageSendResponse.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	_, err := SendMessage(client, &SendMessageRequest{
+		To:   "+31612345678",
+		From: "rcs-channel-id",
+		Type: MessageTypeRCSCarousel,
+		Content: &MessageContent{
+			RCSCarousel: &RCSCarousel{
+				CardWidth: RCSCardWidthMedium,
+				Cards: []*RCSCardContent{
+					{Title: "Small"},
+					{Title: "Large", Suggestions: []*RCSSuggestion{
+						{Action: &RCSSuggestedAction{Text: "Call us", PostbackData: "call", Dial: &RCSDialAction{PhoneNumber: "+31201234567"}}},
+					}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	mbtest.AssertBodyField(t, "content.rcsCarousel.cardWidth", "medium")
+	mbtest.AssertBodyField(t, "content.rcsCarousel.cards.1.suggestions.0.action.dialAction.phoneNumber", "+31201234567")
+}
+
+func TestSendRCSFile(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageSendResponse.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	_, err := SendMessage(client, &SendMessageRequest{
+		To:   "+31612345678",
+		From: "rcs-channel-id",
+		Type: MessageTypeRCSFile,
+		Content: &MessageContent{
+			RCSFile: &RCSFile{URL: "https://example.com/invoice.pdf"},
+		},
+	})
+	assert.NoError(t, err)
+
+	mbtest.AssertBodyField(t, "content", map[string]interface{}{
+		"rcsFile": map[string]interface{}{"url": "https://example.com/invoice.pdf"},
+	})
+}
@@ -0,0 +1,274 @@
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Receipt is the latest known delivery status of a sent message.
+type Receipt struct {
+	MessageID string
+
+	// Status is empty until the status of the message is known.
+	Status MessageStatus
+
+	// Reason explains why the message failed, if the API told.
+	Reason string
+
+	UpdatedDatetime *time.Time
+}
+
+// Final reports whether the status of the message will not change anymore,
+// except from delivered to read.
+func (r *Receipt) Final() bool {
+	switch receiptStateOf(r.Status) {
+	case receiptPending, receiptSent:
+		return false
+	default:
+		return true
+	}
+}
+
+// ReceiptSummary counts the messages of Receipts by delivery status. Every
+// message is counted once, in its latest state: a message that was read is
+// not counted as delivered.
+type ReceiptSummary struct {
+	Total int
+
+	// Pending are the messages that have not been sent yet, or of which the
+	// status is not known yet.
+	Pending int
+
+	// Sent are the messages that were sent, but not delivered yet.
+	Sent int
+
+	Delivered int
+	Read      int
+	Failed    int
+
+	// Other are the messages with another final status, e.g. deleted.
+	Other int
+
+	// Statuses counts the messages per status.
+	Statuses map[MessageStatus]int
+
+	// Reasons counts the failed messages per reason. The status is used as
+	// the reason of messages that failed without one.
+	Reasons map[string]int
+}
+
+// Receipts aggregates the delivery statuses of sent messages, e.g. those of
+// a campaign, into a ReceiptSummary. Keep them up to date with Poll, or with
+// Update for the messages of message.updated webhooks:
+//
+//	receipts := conversation.NewReceipts(ids)
+//	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+//	defer cancel()
+//	err := receipts.Poll(ctx, client, time.Minute)
+//	summary := receipts.Summary()
+//
+// Receipts is safe for concurrent use.
+type Receipts struct {
+	mu       sync.Mutex
+	ids      []string
+	receipts map[string]*Receipt
+}
+
+// NewReceipts returns Receipts for the messages with the given IDs.
+func NewReceipts(messageIDs []string) *Receipts {
+	r := &Receipts{receipts: make(map[string]*Receipt, len(messageIDs))}
+	for _, id := range messageIDs {
+		if _, ok := r.receipts[id]; !ok {
+			r.ids = append(r.ids, id)
+			r.receipts[id] = &Receipt{MessageID: id}
+		}
+	}
+
+	return r
+}
+
+// Update records the status of message. It returns false if the message is
+// not one of the Receipts. Updates older than the recorded status, e.g. of
+// webhooks that arrive out of order, are ignored.
+func (r *Receipts) Update(message *Message) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	receipt, ok := r.receipts[message.ID]
+	if !ok {
+		return false
+	}
+
+	if receipt.UpdatedDatetime != nil && message.UpdatedDatetime != nil && message.UpdatedDatetime.Before(*receipt.UpdatedDatetime) {
+		return true
+	}
+
+	receipt.Status = message.Status
+	receipt.Reason = failureReason(message)
+	receipt.UpdatedDatetime = message.UpdatedDatetime
+
+	return true
+}
+
+// Poll reads the messages that do not have a final status every interval,
+// until all of them have one. It returns nil once they do, ctx.Err() if ctx
+// is done first and the error of reading a message otherwise. Poll can be
+// called again after an error to resume.
+func (r *Receipts) Poll(ctx context.Context, c messagebird.Client, interval time.Duration) error {
+	clock := messagebird.ClockOf(c)
+
+	for {
+		pending := r.pending()
+		if len(pending) == 0 {
+			return nil
+		}
+
+		for _, id := range pending {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			message, err := ReadMessage(c, id)
+			if err != nil {
+				return fmt.Errorf("reading message %s: %w", id, err)
+			}
+			r.Update(message)
+		}
+
+		if len(r.pending()) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
+}
+
+// Receipt returns the receipt of the message with id, or nil if it is not one
+// of the Receipts.
+func (r *Receipts) Receipt(id string) *Receipt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	receipt, ok := r.receipts[id]
+	if !ok {
+		return nil
+	}
+
+	copied := *receipt
+	return &copied
+}
+
+// Summary counts the messages by their latest status.
+func (r *Receipts) Summary() *ReceiptSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := &ReceiptSummary{
+		Total:    len(r.ids),
+		Statuses: make(map[MessageStatus]int),
+		Reasons:  make(map[string]int),
+	}
+
+	for _, id := range r.ids {
+		receipt := r.receipts[id]
+		if receipt.Status != "" {
+			summary.Statuses[receipt.Status]++
+		}
+
+		switch receiptStateOf(receipt.Status) {
+		case receiptPending:
+			summary.Pending++
+		case receiptSent:
+			summary.Sent++
+		case receiptDelivered:
+			summary.Delivered++
+		case receiptRead:
+			summary.Read++
+		case receiptFailed:
+			summary.Failed++
+			reason := receipt.Reason
+			if reason == "" {
+				reason = string(receipt.Status)
+			}
+			summary.Reasons[reason]++
+		default:
+			summary.Other++
+		}
+	}
+
+	return summary
+}
+
+func (r *Receipts) pending() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []string
+	for _, id := range r.ids {
+		if !r.receipts[id].Final() {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+type receiptState int
+
+const (
+	receiptPending receiptState = iota
+	receiptSent
+	receiptDelivered
+	receiptRead
+	receiptFailed
+	receiptOther
+)
+
+func receiptStateOf(status MessageStatus) receiptState {
+	switch status {
+	case MessageStatusSent, MessageStatusTransmitted, MessageStatusDispatched:
+		return receiptSent
+	case MessageStatusDelivered:
+		return receiptDelivered
+	case MessageStatusRead, MessageStatusOpened, MessageStatusClicked:
+		return receiptRead
+	case MessageStatusRejected, MessageStatusFailed, MessageStatusDeliveryFailed, MessageStatusExpired,
+		MessageStatusBounce, MessageStatusSpamComplaint, MessageStatusOutOfBounded:
+		return receiptFailed
+	case MessageStatusReceived, MessageStatusDeleted, MessageStatusListUnsubscribe:
+		return receiptOther
+	default:
+		// Accepted, pending, buffered, delayed, unknown or not known yet.
+		return receiptPending
+	}
+}
+
+// failureReason returns the description of the error of message, if the API
+// returned one.
+func failureReason(message *Message) string {
+	if receiptStateOf(message.Status) != receiptFailed {
+		return ""
+	}
+
+	var e struct {
+		Code        int
+		Description string
+	}
+	if ok, err := message.ExtraField("error", &e); !ok || err != nil {
+		return ""
+	}
+
+	if e.Description == "" && e.Code != 0 {
+		return fmt.Sprintf("error code %d", e.Code)
+	}
+
+	return e.Description
+}
@@ -0,0 +1,101 @@
+package conversation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// statusClient returns the next of the statuses of a message every time it
+// is read.
+type statusClient struct {
+	statuses map[string][]string
+	reads    int
+}
+
+func (c *statusClient) Request(v interface{}, method, path string, data interface{}) error {
+	if method != http.MethodGet {
+		return fmt.Errorf("unexpected %s %s", method, path)
+	}
+	c.reads++
+
+	id := path[strings.LastIndex(path, "/")+1:]
+	statuses := c.statuses[id]
+	if len(statuses) == 0 {
+		return errors.New("not found")
+	}
+
+	status := statuses[0]
+	if len(statuses) > 1 {
+		c.statuses[id] = statuses[1:]
+	}
+
+	return json.Unmarshal([]byte(fmt.Sprintf(`{"id": %q, "status": %q}`, id, status)), v)
+}
+
+func TestReceiptsPoll(t *testing.T) {
+	client := &statusClient{statuses: map[string][]string{
+		"m1": {"accepted", "sent", "delivered"},
+		"m2": {"sent", "read"},
+		"m3": {"failed"},
+	}}
+
+	receipts := NewReceipts([]string{"m1", "m2", "m3", "m1"})
+	assert.NoError(t, receipts.Poll(context.Background(), client, 0))
+	assert.Equal(t, 6, client.reads)
+
+	assert.Equal(t, &ReceiptSummary{
+		Total:     3,
+		Delivered: 1,
+		Read:      1,
+		Failed:    1,
+		Statuses:  map[MessageStatus]int{MessageStatusDelivered: 1, MessageStatusRead: 1, MessageStatusFailed: 1},
+		Reasons:   map[string]int{"failed": 1},
+	}, receipts.Summary())
+}
+
+func TestReceiptsPollError(t *testing.T) {
+	client := &statusClient{statuses: map[string][]string{"m1": {"sent"}}}
+
+	receipts := NewReceipts([]string{"m1", "m2"})
+	err := receipts.Poll(context.Background(), client, 0)
+	assert.EqualError(t, err, "reading message m2: not found")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, receipts.Poll(ctx, client, time.Hour))
+
+	summary := receipts.Summary()
+	assert.Equal(t, 1, summary.Sent)
+	assert.Equal(t, 1, summary.Pending)
+}
+
+func TestReceiptsUpdate(t *testing.T) {
+	receipts := NewReceipts([]string{"m1", "m2"})
+
+	var updated, failed Message
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": "m1", "status": "delivered", "updatedDatetime": "2022-01-01T12:00:05Z"}`), &updated))
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": "m2", "status": "delivery_failed", "error": {"code": 470, "description": "Message failed to send because more than 24 hours have passed"}}`), &failed))
+
+	assert.True(t, receipts.Update(&updated))
+	assert.True(t, receipts.Update(&failed))
+	assert.False(t, receipts.Update(&Message{ID: "m3", Status: MessageStatusRead}))
+
+	// Webhooks may arrive out of order.
+	earlier := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, receipts.Update(&Message{ID: "m1", Status: MessageStatusSent, UpdatedDatetime: &earlier}))
+	assert.Equal(t, MessageStatusDelivered, receipts.Receipt("m1").Status)
+	assert.True(t, receipts.Receipt("m1").Final())
+	assert.Nil(t, receipts.Receipt("m3"))
+
+	summary := receipts.Summary()
+	assert.Equal(t, 1, summary.Delivered)
+	assert.Equal(t, map[string]int{"Message failed to send because more than 24 hours have passed": 1}, summary.Reasons)
+}
@@ -0,0 +1,126 @@
+package conversation
+
+import (
+	"io"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Service is the Conversations API bound to a client, see sms.Service.
+type Service interface {
+	List(options *ListRequest) (*Conversations, error)
+	ListByContact(contactId string, options *messagebird.PaginationRequest) (*ConversationsByContact, error)
+	Read(id string) (*Conversation, error)
+	Start(req *StartRequest) (*Conversation, error)
+	Reply(conversationID string, req *ReplyRequest) (*Message, error)
+	Update(id string, req *UpdateRequest) (*Conversation, error)
+	UploadMedia(name string, r io.Reader, caption string) (*Media, error)
+	SendMessage(options *SendMessageRequest) (*Message, error)
+	ListConversationMessages(conversationID string, options *ListConversationMessagesRequest) (*MessageList, error)
+	ListMessages(options *ListMessagesRequest) (*MessageList, error)
+	ReadMessage(messageID string) (*Message, error)
+	RegisterPushDevice(channelID string, req *PushDeviceRequest) (*PushDevice, error)
+	ListPushDevices(channelID string, options *messagebird.PaginationRequest) (*PushDeviceList, error)
+	DeletePushDevice(channelID, id string) error
+	CreateWebhook(req *WebhookCreateRequest) (*Webhook, error)
+	DeleteWebhook(id string) error
+	ListWebhooks(options *messagebird.PaginationRequest) (*WebhookList, error)
+	ReadWebhook(id string) (*Webhook, error)
+	UpdateWebhook(id string, req *WebhookUpdateRequest) (*Webhook, error)
+	CanSendFreeform(id string) (bool, error)
+	CheckServiceWindow(id string) error
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) List(options *ListRequest) (*Conversations, error) {
+	return List(s.c, options)
+}
+
+func (s *service) ListByContact(contactId string, options *messagebird.PaginationRequest) (*ConversationsByContact, error) {
+	return ListByContact(s.c, contactId, options)
+}
+
+func (s *service) Read(id string) (*Conversation, error) {
+	return Read(s.c, id)
+}
+
+func (s *service) Start(req *StartRequest) (*Conversation, error) {
+	return Start(s.c, req)
+}
+
+func (s *service) Reply(conversationID string, req *ReplyRequest) (*Message, error) {
+	return Reply(s.c, conversationID, req)
+}
+
+func (s *service) Update(id string, req *UpdateRequest) (*Conversation, error) {
+	return Update(s.c, id, req)
+}
+
+func (s *service) UploadMedia(name string, r io.Reader, caption string) (*Media, error) {
+	return UploadMedia(s.c, name, r, caption)
+}
+
+func (s *service) SendMessage(options *SendMessageRequest) (*Message, error) {
+	return SendMessage(s.c, options)
+}
+
+func (s *service) ListConversationMessages(conversationID string, options *ListConversationMessagesRequest) (*MessageList, error) {
+	return ListConversationMessages(s.c, conversationID, options)
+}
+
+func (s *service) ListMessages(options *ListMessagesRequest) (*MessageList, error) {
+	return ListMessages(s.c, options)
+}
+
+func (s *service) ReadMessage(messageID string) (*Message, error) {
+	return ReadMessage(s.c, messageID)
+}
+
+func (s *service) RegisterPushDevice(channelID string, req *PushDeviceRequest) (*PushDevice, error) {
+	return RegisterPushDevice(s.c, channelID, req)
+}
+
+func (s *service) ListPushDevices(channelID string, options *messagebird.PaginationRequest) (*PushDeviceList, error) {
+	return ListPushDevices(s.c, channelID, options)
+}
+
+func (s *service) DeletePushDevice(channelID, id string) error {
+	return DeletePushDevice(s.c, channelID, id)
+}
+
+func (s *service) CreateWebhook(req *WebhookCreateRequest) (*Webhook, error) {
+	return CreateWebhook(s.c, req)
+}
+
+func (s *service) DeleteWebhook(id string) error {
+	return DeleteWebhook(s.c, id)
+}
+
+func (s *service) ListWebhooks(options *messagebird.PaginationRequest) (*WebhookList, error) {
+	return ListWebhooks(s.c, options)
+}
+
+func (s *service) ReadWebhook(id string) (*Webhook, error) {
+	return ReadWebhook(s.c, id)
+}
+
+func (s *service) UpdateWebhook(id string, req *WebhookUpdateRequest) (*Webhook, error) {
+	return UpdateWebhook(s.c, id, req)
+}
+
+func (s *service) CanSendFreeform(id string) (bool, error) {
+	return CanSendFreeform(s.c, id)
+}
+
+func (s *service) CheckServiceWindow(id string) error {
+	return CheckServiceWindow(s.c, id)
+}
@@ -0,0 +1,19 @@
+package conversation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "conversationObject.json", http.StatusOK)
+	s := NewService(mbtest.Client(t))
+
+	conv, err := s.Read("convid")
+	assert.NoError(t, err)
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations/convid")
+	assert.Equal(t, "convid", conv.ID)
+}
@@ -0,0 +1,33 @@
+package conversation
+
+// TelegramParseMode is the formatting syntax of a TelegramText.
+type TelegramParseMode string
+
+const (
+	TelegramParseModeMarkdown   TelegramParseMode = "Markdown"
+	TelegramParseModeMarkdownV2 TelegramParseMode = "MarkdownV2"
+	TelegramParseModeHTML       TelegramParseMode = "HTML"
+)
+
+// TelegramText is a formatted Telegram message, optionally with buttons
+// below it.
+type TelegramText struct {
+	Text                  string                  `json:"text"`
+	ParseMode             TelegramParseMode       `json:"parseMode,omitempty"`
+	DisableWebPagePreview bool                    `json:"disableWebPagePreview,omitempty"`
+	InlineKeyboard        *TelegramInlineKeyboard `json:"inlineKeyboard,omitempty"`
+}
+
+// TelegramInlineKeyboard is a grid of buttons, attached to a message.
+type TelegramInlineKeyboard struct {
+	Rows [][]*TelegramInlineButton `json:"rows"`
+}
+
+// TelegramInlineButton is a button of a TelegramInlineKeyboard. Only one of
+// URL and CallbackData can be set: the button either opens URL, or sends
+// CallbackData back in an inbound message.
+type TelegramInlineButton struct {
+	Text         string `json:"text"`
+	URL          string `json:"url,omitempty"`
+	CallbackData string `json:"callbackData,omitempty"`
+}
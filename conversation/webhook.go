@@ -65,6 +65,9 @@ const (
 	WebhookStatusEnabled WebhookStatus = "enabled"
 	// WebhookStatusDisabled indictates that the webhook is disabled.
 	WebhookStatusDisabled WebhookStatus = "disabled"
+	// WebhookStatusUnknown is decoded for statuses this package does not
+	// know.
+	WebhookStatusUnknown WebhookStatus = "unknown"
 )
 
 // CreateWebhook registers a webhook that is invoked when something interesting
@@ -25,7 +25,7 @@ func TestCreateWebhook(t *testing.T) {
 	assert.Equal(t, "whid", webhook.ID)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/webhooks")
-	mbtest.AssertTestdataJson(t, "webhookCreateRequest.json", mbtest.Request.Body)
+	mbtest.AssertTestdataJson(t, "webhookCreateRequest.json", mbtest.LastRequest().Body)
 }
 
 func TestDeleteWebhook(t *testing.T) {
@@ -52,7 +52,7 @@ func TestListWebhooks(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/webhooks")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "limit=20&offset=2", query)
 	})
 
@@ -75,7 +75,7 @@ func TestListWebhooks(t *testing.T) {
 
 		mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/webhooks")
 
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, "", query)
 	})
 }
@@ -114,5 +114,5 @@ func TestUpdateWebhook(t *testing.T) {
 	assert.Equal(t, WebhookStatusDisabled, webhook.Status)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/v1/webhooks/whid")
-	mbtest.AssertTestdataJson(t, "webhookUpdateRequest.json", mbtest.Request.Body)
+	mbtest.AssertTestdataJson(t, "webhookUpdateRequest.json", mbtest.LastRequest().Body)
 }
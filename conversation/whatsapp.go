@@ -101,6 +101,20 @@ type WhatsAppInteractiveReply struct {
 	Description string `json:"description,omitempty"`
 }
 
+// WhatsAppReferral is the ad or post a customer clicked to start a
+// conversation. See Message.WhatsAppReferral.
+type WhatsAppReferral struct {
+	SourceURL    string `json:"source_url"`
+	SourceType   string `json:"source_type"`
+	SourceID     string `json:"source_id"`
+	Headline     string `json:"headline"`
+	Body         string `json:"body"`
+	MediaType    string `json:"media_type"`
+	ImageURL     string `json:"image_url,omitempty"`
+	VideoURL     string `json:"video_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
 // WhatsAppSticker
 // URL of the sticker image. The format must be image/webp and the maximum size is 100 KB.
 type WhatsAppSticker struct {
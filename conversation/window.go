@@ -0,0 +1,104 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// CustomerServiceWindow is how long after the last message of a customer
+// free-form messages may be sent to them on WhatsApp. Outside of it, only
+// templates (see HSM) may be sent.
+const CustomerServiceWindow = 24 * time.Hour
+
+// ErrServiceWindowClosed is returned, wrapped, by CheckServiceWindow when
+// free-form messages can not be sent.
+var ErrServiceWindowClosed = errors.New("customer service window closed")
+
+// ServiceWindowExpiry returns when the customer service window of the
+// conversation closes. It returns false if the customer never sent a
+// message.
+func (c *Conversation) ServiceWindowExpiry() (time.Time, bool) {
+	if c.LastReceivedDatetime == nil {
+		return time.Time{}, false
+	}
+
+	return c.LastReceivedDatetime.Add(CustomerServiceWindow), true
+}
+
+// ServiceWindowOpen reports whether free-form messages may be sent in the
+// conversation at now.
+func (c *Conversation) ServiceWindowOpen(now time.Time) bool {
+	expiry, ok := c.ServiceWindowExpiry()
+	return ok && now.Before(expiry)
+}
+
+// CanSendFreeform reports whether free-form messages may be sent in the
+// conversation with id now, or a template is required. It uses the
+// lastReceivedDatetime of the conversation, or the last message received in
+// it if the API did not return one.
+func CanSendFreeform(c messagebird.Client, id string) (bool, error) {
+	lastReceived, err := lastReceivedDatetime(c, id)
+	if err != nil {
+		return false, err
+	}
+
+	return lastReceived != nil && messagebird.ClockOf(c).Now().Before(lastReceived.Add(CustomerServiceWindow)), nil
+}
+
+// CheckServiceWindow is like CanSendFreeform, but returns an error wrapping
+// ErrServiceWindowClosed if a template is required.
+func CheckServiceWindow(c messagebird.Client, id string) error {
+	lastReceived, err := lastReceivedDatetime(c, id)
+	if err != nil {
+		return err
+	}
+
+	if lastReceived == nil {
+		return fmt.Errorf("%w: conversation %s has no messages from the customer", ErrServiceWindowClosed, id)
+	}
+
+	if !messagebird.ClockOf(c).Now().Before(lastReceived.Add(CustomerServiceWindow)) {
+		return fmt.Errorf("%w: last message in conversation %s received at %s", ErrServiceWindowClosed, id, lastReceived.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// lastReceivedMessages is the number of recent messages lastReceivedDatetime
+// looks for a received message in.
+const lastReceivedMessages = 20
+
+// lastReceivedDatetime returns when the last message from the customer was
+// received in the conversation with id, or nil if there is none.
+func lastReceivedDatetime(c messagebird.Client, id string) (*time.Time, error) {
+	conv, err := Read(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if conv.LastReceivedDatetime != nil {
+		return conv.LastReceivedDatetime, nil
+	}
+
+	messages, err := ListConversationMessages(c, id, &ListConversationMessagesRequest{
+		PaginationRequest: messagebird.PaginationRequest{Limit: lastReceivedMessages},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var last *time.Time
+	for _, m := range messages.Items {
+		if m.Direction != MessageDirectionReceived || m.CreatedDatetime == nil {
+			continue
+		}
+		if last == nil || m.CreatedDatetime.After(*last) {
+			last = m.CreatedDatetime
+		}
+	}
+
+	return last, nil
+}
@@ -0,0 +1,121 @@
+package conversation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func (c fixedClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Time(c).Add(d)
+	return ch
+}
+
+func TestCheckServiceWindow(t *testing.T) {
+	// The customer last sent a message at 2018-08-22T15:47:34Z.
+	lastReceived := time.Date(2018, 8, 22, 15, 47, 34, 0, time.UTC)
+
+	mbtest.WillReturnTestdata(t, "conversationObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	client.Clock = fixedClock(lastReceived.Add(23 * time.Hour))
+	assert.NoError(t, CheckServiceWindow(client, "convid"))
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations/convid")
+
+	client.Clock = fixedClock(lastReceived.Add(CustomerServiceWindow))
+	err := CheckServiceWindow(client, "convid")
+	assert.True(t, errors.Is(err, ErrServiceWindowClosed))
+	assert.EqualError(t, err, "customer service window closed: last message in conversation convid received at 2018-08-22T15:47:34Z")
+
+	conv := &Conversation{}
+	_, ok := conv.ServiceWindowExpiry()
+	assert.False(t, ok)
+	assert.False(t, conv.ServiceWindowOpen(lastReceived))
+}
+
+// windowClient serves a conversation and its messages, and records the
+// messages sent to it.
+type windowClient struct {
+	conversation string
+	messages     string
+	sent         []*ReplyRequest
+}
+
+func (c *windowClient) Request(v interface{}, method, path string, data interface{}) error {
+	switch {
+	case method == http.MethodGet && strings.HasSuffix(path, "/messages?"+fmt.Sprintf("limit=%d", lastReceivedMessages)):
+		return json.Unmarshal([]byte(c.messages), v)
+	case method == http.MethodGet:
+		return json.Unmarshal([]byte(c.conversation), v)
+	case method == http.MethodPost:
+		c.sent = append(c.sent, data.(*ReplyRequest))
+		return json.Unmarshal([]byte(`{"id": "mesid"}`), v)
+	}
+
+	return fmt.Errorf("unexpected %s %s", method, path)
+}
+
+// ago returns the time d ago in RFC 3339 format. The stub clients use the
+// system clock.
+func ago(d time.Duration) string {
+	return time.Now().Add(-d).UTC().Format(time.RFC3339)
+}
+
+func TestCanSendFreeform(t *testing.T) {
+	ok, err := CanSendFreeform(&windowClient{conversation: `{"id": "convid", "lastReceivedDatetime": "` + ago(time.Hour) + `"}`}, "convid")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = CanSendFreeform(&windowClient{conversation: `{"id": "convid", "lastReceivedDatetime": "` + ago(25*time.Hour) + `"}`}, "convid")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Without lastReceivedDatetime, the last received message is used.
+	ok, err = CanSendFreeform(&windowClient{
+		conversation: `{"id": "convid"}`,
+		messages: `{"items": [
+			{"id": "1", "direction": "sent", "createdDatetime": "` + ago(time.Minute) + `"},
+			{"id": "2", "direction": "received", "createdDatetime": "` + ago(48*time.Hour) + `"},
+			{"id": "3", "direction": "received", "createdDatetime": "` + ago(time.Hour) + `"}
+		]}`,
+	}, "convid")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = CanSendFreeform(&windowClient{conversation: `{"id": "convid"}`, messages: `{"items": []}`}, "convid")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestReplyTemplateFallback(t *testing.T) {
+	template := &HSM{Namespace: "namespace", TemplateName: "follow_up", Language: &HSMLanguage{Policy: HSMLanguagePolicyDeterministic, Code: "en"}}
+	req := &ReplyRequest{Type: MessageTypeText, Content: &MessageContent{Text: "Hello!"}, TemplateFallback: template}
+
+	closed := &windowClient{conversation: `{"id": "convid", "lastReceivedDatetime": "` + ago(48*time.Hour) + `"}`}
+	_, err := Reply(closed, "convid", req)
+	assert.NoError(t, err)
+	if assert.Len(t, closed.sent, 1) {
+		assert.Equal(t, MessageTypeHSM, closed.sent[0].Type)
+		assert.Equal(t, &MessageContent{HSM: template}, closed.sent[0].Content)
+	}
+	assert.Equal(t, MessageTypeText, req.Type)
+
+	open := &windowClient{conversation: `{"id": "convid", "lastReceivedDatetime": "` + ago(time.Hour) + `"}`}
+	_, err = Reply(open, "convid", req)
+	assert.NoError(t, err)
+	if assert.Len(t, open.sent, 1) {
+		assert.Equal(t, req, open.sent[0])
+	}
+}
@@ -0,0 +1,431 @@
+// Package deliverystats keeps delivery rate and latency statistics of SMS
+// messages per country and network, for monitoring deliverability without a
+// separate data pipeline. Statistics are collected from status report
+// webhooks, from listed messages, or both:
+//
+//	c := deliverystats.NewCollector()
+//	c.Publish("sms")
+//	http.Handle("/metrics", c)
+//
+//	d := &webhooks.Dispatcher{OnSMSStatus: c.StatusReport}
+//
+// A Collector is served in the Prometheus text format and can be published
+// with expvar. To export statistics elsewhere, set Collector.OnUpdate or read
+// Collector.Snapshot.
+package deliverystats
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/webhooks"
+)
+
+// Final statuses of an SMS message to a recipient. Only messages that reached
+// one of them are counted.
+const (
+	StatusDelivered      = "delivered"
+	StatusDeliveryFailed = "delivery_failed"
+	StatusExpired        = "expired"
+)
+
+// LatencyBuckets are the upper bounds of the latency histogram of Stats.
+var LatencyBuckets = []time.Duration{
+	5 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+}
+
+// maxDone is the number of finished messages a Collector remembers, to
+// ignore repeated status reports of them.
+const maxDone = 100000
+
+// Key identifies the statistics of a country and network. Country is the
+// ISO 3166-1 alpha-2 code of the country of the recipients and Network the
+// MCCMNC of their network. Either is empty if it is unknown.
+type Key struct {
+	Country string
+	Network string
+}
+
+func (k Key) String() string {
+	return k.Country + "/" + k.Network
+}
+
+// Stats are the delivery statistics of messages to a country and network.
+type Stats struct {
+	Delivered int
+	Failed    int
+	Expired   int
+
+	// LatencyCount is the number of delivered messages of which the latency,
+	// the time from sending to delivery, is known. LatencySum is their
+	// total latency and LatencyMax the highest.
+	LatencyCount int
+	LatencySum   time.Duration
+	LatencyMax   time.Duration
+
+	// LatencyBuckets counts the latencies up to each bound of the package
+	// level LatencyBuckets.
+	LatencyBuckets []int
+}
+
+// Total returns the number of messages that reached a final status.
+func (s Stats) Total() int {
+	return s.Delivered + s.Failed + s.Expired
+}
+
+// DeliveryRate returns the fraction of messages that were delivered, or 0 if
+// there are none.
+func (s Stats) DeliveryRate() float64 {
+	if s.Total() == 0 {
+		return 0
+	}
+
+	return float64(s.Delivered) / float64(s.Total())
+}
+
+// MeanLatency returns the mean latency of delivered messages, or 0 if none
+// is known.
+func (s Stats) MeanLatency() time.Duration {
+	if s.LatencyCount == 0 {
+		return 0
+	}
+
+	return s.LatencySum / time.Duration(s.LatencyCount)
+}
+
+func (s *Stats) add(status string, latency time.Duration, knownLatency bool) {
+	switch status {
+	case StatusDelivered:
+		s.Delivered++
+	case StatusDeliveryFailed:
+		s.Failed++
+	case StatusExpired:
+		s.Expired++
+	}
+
+	if status != StatusDelivered || !knownLatency {
+		return
+	}
+
+	if latency < 0 {
+		latency = 0
+	}
+
+	s.LatencyCount++
+	s.LatencySum += latency
+	if latency > s.LatencyMax {
+		s.LatencyMax = latency
+	}
+
+	if s.LatencyBuckets == nil {
+		s.LatencyBuckets = make([]int, len(LatencyBuckets))
+	}
+	for i, bound := range LatencyBuckets {
+		if latency <= bound {
+			s.LatencyBuckets[i]++
+		}
+	}
+}
+
+func (s Stats) copy() Stats {
+	s.LatencyBuckets = append([]int(nil), s.LatencyBuckets...)
+	return s
+}
+
+// Collector aggregates Stats per Key. It is safe for concurrent use.
+type Collector struct {
+	// OnUpdate, if set, is called with the updated statistics of a key after
+	// a message to it reached a final status. It is called synchronously, so
+	// it must not block.
+	OnUpdate func(key Key, s Stats)
+
+	mu    sync.Mutex
+	stats map[Key]*Stats
+
+	// sent holds when the messages that did not reach a final status yet
+	// were sent, by message ID and recipient.
+	sent map[string]time.Time
+
+	// done holds the finished messages, of which doneOrder is the order
+	// they finished in.
+	done      map[string]bool
+	doneOrder []string
+}
+
+// NewCollector returns an empty Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		stats: make(map[Key]*Stats),
+		sent:  make(map[string]time.Time),
+		done:  make(map[string]bool),
+	}
+}
+
+// Sent records that the message with id was sent to recipient at at, to
+// measure its latency when its status report arrives. Without it, latency is
+// measured from the first status report of the message.
+func (c *Collector) Sent(id, recipient string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.done[messageKey(id, recipient)] {
+		c.sent[messageKey(id, recipient)] = at
+	}
+}
+
+// StatusReport records an SMS status report. Its signature matches
+// webhooks.Dispatcher.OnSMSStatus. Repeated reports of a final status are
+// ignored.
+func (c *Collector) StatusReport(ctx context.Context, r *webhooks.SMSStatusReport) error {
+	var at time.Time
+	if r.StatusDatetime != nil {
+		at = *r.StatusDatetime
+	}
+
+	c.record(r.ID, r.Recipient, r.MCCMNC, r.Status, time.Time{}, at)
+	return nil
+}
+
+// AddMessage records the statuses of the recipients of m, as returned by
+// sms.Read or sms.List. Latency is measured from the creation, or scheduled
+// time, of m.
+func (c *Collector) AddMessage(m *sms.Message) {
+	var sentAt time.Time
+	if m.CreatedDatetime != nil {
+		sentAt = *m.CreatedDatetime
+	}
+	if m.ScheduledDatetime != nil && m.ScheduledDatetime.After(sentAt) {
+		sentAt = *m.ScheduledDatetime
+	}
+
+	for _, r := range m.Recipients.Items {
+		var at time.Time
+		if r.StatusDatetime != nil {
+			at = *r.StatusDatetime
+		}
+
+		var network string
+		if r.Mccmnc != nil {
+			network = *r.Mccmnc
+		}
+
+		c.record(m.ID, strconv.FormatInt(r.Recipient, 10), network, r.Status, sentAt, at)
+	}
+}
+
+// Load records the messages listed with params, see AddMessage. All pages
+// are read, starting at params.Offset.
+func (c *Collector) Load(client messagebird.Client, params *sms.ListParams) error {
+	p := sms.ListParams{}
+	if params != nil {
+		p = *params
+	}
+
+	pager := paging.Pager{Offset: p.Offset}
+	for !pager.Done() {
+		pager.Fetch(func(offset int) (int, int, error) {
+			p.Offset = offset
+
+			list, err := sms.List(client, &p)
+			if err != nil {
+				return 0, 0, err
+			}
+
+			for i := range list.Items {
+				c.AddMessage(&list.Items[i])
+			}
+
+			return len(list.Items), list.TotalCount, nil
+		})
+	}
+
+	return pager.Err()
+}
+
+// record records status of the message with id to recipient at at. sentAt is
+// when it was sent, or the zero time if it is unknown.
+func (c *Collector) record(id, recipient, network, status string, sentAt, at time.Time) {
+	key := messageKey(id, recipient)
+
+	c.mu.Lock()
+
+	if c.done[key] {
+		c.mu.Unlock()
+		return
+	}
+
+	if recorded, ok := c.sent[key]; ok && sentAt.IsZero() {
+		sentAt = recorded
+	}
+
+	if !isFinal(status) {
+		if _, ok := c.sent[key]; !ok && !at.IsZero() {
+			c.sent[key] = at
+		}
+		c.mu.Unlock()
+		return
+	}
+
+	delete(c.sent, key)
+	c.finish(key)
+
+	statsKey := Key{Network: network}
+	statsKey.Country, _ = numberutil.Country(recipient)
+
+	s, ok := c.stats[statsKey]
+	if !ok {
+		s = &Stats{}
+		c.stats[statsKey] = s
+	}
+	s.add(status, at.Sub(sentAt), !sentAt.IsZero() && !at.IsZero())
+	updated := s.copy()
+
+	c.mu.Unlock()
+
+	if c.OnUpdate != nil {
+		c.OnUpdate(statsKey, updated)
+	}
+}
+
+// finish remembers key as finished, forgetting the oldest finished message if
+// there are more than maxDone.
+func (c *Collector) finish(key string) {
+	c.done[key] = true
+	c.doneOrder = append(c.doneOrder, key)
+
+	if len(c.doneOrder) > maxDone {
+		delete(c.done, c.doneOrder[0])
+		c.doneOrder = c.doneOrder[1:]
+	}
+}
+
+func messageKey(id, recipient string) string {
+	return id + "/" + recipient
+}
+
+func isFinal(status string) bool {
+	return status == StatusDelivered || status == StatusDeliveryFailed || status == StatusExpired
+}
+
+// Snapshot returns a copy of the statistics of all keys.
+func (c *Collector) Snapshot() map[Key]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make(map[Key]Stats, len(c.stats))
+	for key, s := range c.stats {
+		snapshot[key] = s.copy()
+	}
+
+	return snapshot
+}
+
+// Publish publishes the statistics with expvar under name, as a map from
+// "country/network" to the statistics with their delivery rate and mean
+// latency in seconds. Like expvar.Publish, it panics if name is in use.
+func (c *Collector) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		type published struct {
+			Stats
+			DeliveryRate       float64
+			MeanLatencySeconds float64
+		}
+
+		vars := make(map[string]published)
+		for key, s := range c.Snapshot() {
+			vars[key.String()] = published{
+				Stats:              s,
+				DeliveryRate:       s.DeliveryRate(),
+				MeanLatencySeconds: s.MeanLatency().Seconds(),
+			}
+		}
+
+		return vars
+	}))
+}
+
+// ServeHTTP serves the statistics in the Prometheus text format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	c.WritePrometheus(w)
+}
+
+// WritePrometheus writes the statistics to w in the Prometheus text format.
+func (c *Collector) WritePrometheus(w io.Writer) error {
+	snapshot := c.Snapshot()
+
+	keys := make([]Key, 0, len(snapshot))
+	for key := range snapshot {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Country != keys[j].Country {
+			return keys[i].Country < keys[j].Country
+		}
+		return keys[i].Network < keys[j].Network
+	})
+
+	pw := &promWriter{w: w}
+
+	pw.printf("# HELP messagebird_sms_final_status_total SMS messages that reached a final status.\n")
+	pw.printf("# TYPE messagebird_sms_final_status_total counter\n")
+	for _, key := range keys {
+		s := snapshot[key]
+		pw.printf("messagebird_sms_final_status_total{%s,status=%q} %d\n", labels(key), StatusDelivered, s.Delivered)
+		pw.printf("messagebird_sms_final_status_total{%s,status=%q} %d\n", labels(key), StatusDeliveryFailed, s.Failed)
+		pw.printf("messagebird_sms_final_status_total{%s,status=%q} %d\n", labels(key), StatusExpired, s.Expired)
+	}
+
+	pw.printf("# HELP messagebird_sms_delivery_latency_seconds Time from sending to delivery of SMS messages.\n")
+	pw.printf("# TYPE messagebird_sms_delivery_latency_seconds histogram\n")
+	for _, key := range keys {
+		s := snapshot[key]
+		for i, bound := range LatencyBuckets {
+			count := 0
+			if s.LatencyBuckets != nil {
+				count = s.LatencyBuckets[i]
+			}
+			pw.printf("messagebird_sms_delivery_latency_seconds_bucket{%s,le=%q} %d\n", labels(key), strconv.FormatFloat(bound.Seconds(), 'g', -1, 64), count)
+		}
+		pw.printf("messagebird_sms_delivery_latency_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels(key), s.LatencyCount)
+		pw.printf("messagebird_sms_delivery_latency_seconds_sum{%s} %g\n", labels(key), s.LatencySum.Seconds())
+		pw.printf("messagebird_sms_delivery_latency_seconds_count{%s} %d\n", labels(key), s.LatencyCount)
+	}
+
+	return pw.err
+}
+
+func labels(key Key) string {
+	return fmt.Sprintf("country=%q,network=%q", key.Country, key.Network)
+}
+
+// promWriter writes to w until the first error.
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (pw *promWriter) printf(format string, args ...interface{}) {
+	if pw.err != nil {
+		return
+	}
+
+	_, pw.err = fmt.Fprintf(pw.w, format, args...)
+}
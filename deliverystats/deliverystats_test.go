@@ -0,0 +1,121 @@
+package deliverystats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+var sentAt = time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+func report(id, recipient, status string, after time.Duration) *webhooks.SMSStatusReport {
+	at := sentAt.Add(after)
+	return &webhooks.SMSStatusReport{ID: id, Recipient: recipient, Status: status, MCCMNC: "20408", StatusDatetime: &at}
+}
+
+func TestStatusReport(t *testing.T) {
+	ctx := context.Background()
+	c := NewCollector()
+
+	var updates []Key
+	c.OnUpdate = func(key Key, s Stats) {
+		updates = append(updates, key)
+	}
+
+	c.Sent("m1", "31612345678", sentAt)
+	assert.NoError(t, c.StatusReport(ctx, report("m1", "31612345678", "buffered", 2*time.Second)))
+	assert.NoError(t, c.StatusReport(ctx, report("m1", "31612345678", StatusDelivered, 10*time.Second)))
+	assert.NoError(t, c.StatusReport(ctx, report("m1", "31612345678", StatusDelivered, 10*time.Second)))
+
+	// Without Sent, latency is measured from the first status report.
+	assert.NoError(t, c.StatusReport(ctx, report("m2", "31612345679", "sent", 0)))
+	assert.NoError(t, c.StatusReport(ctx, report("m2", "31612345679", StatusDelivered, 2*time.Minute)))
+
+	assert.NoError(t, c.StatusReport(ctx, report("m3", "31612345670", StatusDeliveryFailed, time.Minute)))
+
+	// Without any earlier time, the latency is unknown.
+	assert.NoError(t, c.StatusReport(ctx, report("m4", "31612345671", StatusDelivered, time.Minute)))
+
+	key := Key{Country: "NL", Network: "20408"}
+	assert.Equal(t, []Key{key, key, key, key}, updates)
+
+	s := c.Snapshot()[key]
+	assert.Equal(t, 3, s.Delivered)
+	assert.Equal(t, 1, s.Failed)
+	assert.Equal(t, 4, s.Total())
+	assert.Equal(t, 0.75, s.DeliveryRate())
+	assert.Equal(t, 2, s.LatencyCount)
+	assert.Equal(t, 65*time.Second, s.MeanLatency())
+	assert.Equal(t, 2*time.Minute, s.LatencyMax)
+	assert.Equal(t, []int{0, 1, 1, 1, 2, 2, 2}, s.LatencyBuckets)
+}
+
+func TestAddMessage(t *testing.T) {
+	c := NewCollector()
+
+	var m sms.Message
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"id": "m1",
+		"createdDatetime": "2026-01-01T09:00:00Z",
+		"recipients": {
+			"items": [
+				{"recipient": 31612345678, "status": "delivered", "statusDatetime": "2026-01-01T09:00:04Z", "mccmnc": "20408"},
+				{"recipient": 32470123456, "status": "expired", "statusDatetime": "2026-01-02T09:00:00Z", "mccmnc": "20610"},
+				{"recipient": 14155552671, "status": "sent", "statusDatetime": "2026-01-01T09:00:01Z"}
+			]
+		}
+	}`), &m))
+
+	c.AddMessage(&m)
+	c.AddMessage(&m)
+
+	snapshot := c.Snapshot()
+	assert.Len(t, snapshot, 2)
+	assert.Equal(t, 1, snapshot[Key{"NL", "20408"}].Delivered)
+	assert.Equal(t, 4*time.Second, snapshot[Key{"NL", "20408"}].MeanLatency())
+	assert.Equal(t, 1, snapshot[Key{"BE", "20610"}].Expired)
+	assert.Equal(t, 0.0, snapshot[Key{"BE", "20610"}].DeliveryRate())
+}
+
+func TestWritePrometheus(t *testing.T) {
+	c := NewCollector()
+	c.Sent("m1", "31612345678", sentAt)
+	assert.NoError(t, c.StatusReport(context.Background(), report("m1", "31612345678", StatusDelivered, 10*time.Second)))
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.WritePrometheus(&buf))
+
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE messagebird_sms_final_status_total counter\n")
+	assert.Contains(t, out, `messagebird_sms_final_status_total{country="NL",network="20408",status="delivered"} 1`+"\n")
+	assert.Contains(t, out, `messagebird_sms_final_status_total{country="NL",network="20408",status="delivery_failed"} 0`+"\n")
+	assert.Contains(t, out, `messagebird_sms_delivery_latency_seconds_bucket{country="NL",network="20408",le="5"} 0`+"\n")
+	assert.Contains(t, out, `messagebird_sms_delivery_latency_seconds_bucket{country="NL",network="20408",le="15"} 1`+"\n")
+	assert.Contains(t, out, `messagebird_sms_delivery_latency_seconds_bucket{country="NL",network="20408",le="+Inf"} 1`+"\n")
+	assert.Contains(t, out, `messagebird_sms_delivery_latency_seconds_sum{country="NL",network="20408"} 10`+"\n")
+}
+
+func TestPublish(t *testing.T) {
+	c := NewCollector()
+	c.Sent("m1", "31612345678", sentAt)
+	assert.NoError(t, c.StatusReport(context.Background(), report("m1", "31612345678", StatusDelivered, 10*time.Second)))
+
+	c.Publish("deliverystats_test")
+
+	var vars map[string]struct {
+		Delivered          int
+		DeliveryRate       float64
+		MeanLatencySeconds float64
+	}
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("deliverystats_test").String()), &vars))
+	assert.Equal(t, 1, vars["NL/20408"].Delivered)
+	assert.Equal(t, 1.0, vars["NL/20408"].DeliveryRate)
+	assert.Equal(t, 10.0, vars["NL/20408"].MeanLatencySeconds)
+}
@@ -0,0 +1,88 @@
+package email
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Types of an Event.
+const (
+	EventSent         = "sent"
+	EventDelivered    = "delivered"
+	EventBounced      = "bounced"
+	EventFailed       = "failed"
+	EventOpened       = "opened"
+	EventClicked      = "clicked"
+	EventUnsubscribed = "unsubscribed"
+	EventComplained   = "complained"
+)
+
+// InboundMessage is an email received on one of your inbound domains, as
+// delivered to your webhook.
+type InboundMessage struct {
+	ID               string              `json:"id"`
+	From             Address             `json:"from"`
+	To               []Address           `json:"to"`
+	Cc               []Address           `json:"cc"`
+	Subject          string              `json:"subject"`
+	HTML             string              `json:"html"`
+	Text             string              `json:"text"`
+	Headers          map[string]string   `json:"headers"`
+	Attachments      []InboundAttachment `json:"attachments"`
+	ReceivedDatetime *time.Time          `json:"receivedDatetime"`
+}
+
+// InboundAttachment is a file attached to an InboundMessage. Its contents
+// can be downloaded from URL.
+type InboundAttachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+}
+
+// Event is a change in the status of a sent message for one recipient, as
+// delivered to the ReportURL of the message.
+type Event struct {
+	MessageID string     `json:"messageId"`
+	Type      string     `json:"event"`
+	Recipient string     `json:"recipient"`
+	Reference string     `json:"reference"`
+	Reason    string     `json:"reason"`
+	URL       string     `json:"url"`
+	Datetime  *time.Time `json:"datetime"`
+}
+
+// ParseInbound parses the request MessageBird sends to your webhook when an
+// email is received. Use signature_jwt to verify the request first.
+func ParseInbound(r *http.Request) (*InboundMessage, error) {
+	msg := &InboundMessage{}
+	if err := json.NewDecoder(r.Body).Decode(msg); err != nil {
+		return nil, fmt.Errorf("decoding inbound email: %w", err)
+	}
+
+	if msg.ID == "" || msg.From.Address == "" {
+		return nil, errors.New("request is not an inbound email: id and from are required")
+	}
+
+	return msg, nil
+}
+
+// ParseEvent parses the request MessageBird sends to the ReportURL of a
+// message when its status changes. Use signature_jwt to verify the request
+// first.
+func ParseEvent(r *http.Request) (*Event, error) {
+	event := &Event{}
+	if err := json.NewDecoder(r.Body).Decode(event); err != nil {
+		return nil, fmt.Errorf("decoding email event: %w", err)
+	}
+
+	if event.MessageID == "" || event.Type == "" {
+		return nil, errors.New("request is not an email event: messageId and event are required")
+	}
+
+	return event, nil
+}
@@ -0,0 +1,52 @@
+package email
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInbound(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(mbtest.Testdata(t, "inboundObject.json")))
+
+	msg, err := ParseInbound(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "7b3cfd7e9e0c4bcd8b1b2b6f3a2a1c0d", msg.ID)
+	assert.Equal(t, Address{Address: "jane@example.com", Name: "Jane"}, msg.From)
+	assert.Equal(t, "support@example.com", msg.To[0].Address)
+	assert.Equal(t, "Thanks!", msg.Text)
+	assert.Equal(t, "receipt.pdf", msg.Attachments[0].Name)
+	assert.Equal(t, 10240, msg.Attachments[0].Size)
+	assert.Equal(t, time.Date(2022, 1, 5, 11, 0, 0, 0, time.UTC), msg.ReceivedDatetime.UTC())
+}
+
+func TestParseInboundInvalid(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"subject":"Hi"}`))
+
+	_, err := ParseInbound(r)
+	assert.EqualError(t, err, "request is not an inbound email: id and from are required")
+}
+
+func TestParseEvent(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(mbtest.Testdata(t, "eventObject.json")))
+
+	event, err := ParseEvent(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "a5ff0e4b1c1f4c1b8ea1d3f7cd3e0d2f", event.MessageID)
+	assert.Equal(t, EventClicked, event.Type)
+	assert.Equal(t, "jane@example.com", event.Recipient)
+	assert.Equal(t, "https://example.com/orders/1234", event.URL)
+}
+
+func TestParseEventInvalid(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("not json"))
+
+	_, err := ParseEvent(r)
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+// Package email sends transactional email with the MessageBird Email API and
+// parses the webhooks it sends for inbound email and delivery events. It
+// mirrors package sms:
+//
+//	message, err := email.Send(client, email.Address{Address: "noreply@example.com"}, []email.Address{
+//		{Address: "jane@example.com", Name: "Jane"},
+//	}, "Your order has shipped", &email.Params{
+//		Text: "It will arrive tomorrow.",
+//	})
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// apiRoot is the absolute URL of the Email API.
+const apiRoot = "https://email.messagebird.com/v1"
+
+// path represents the path to the Message resource.
+const path = "messages"
+
+// MaxAttachmentSize is the maximum total size in bytes of the attachments of
+// a message.
+const MaxAttachmentSize = 20 * 1024 * 1024
+
+// Statuses of a Message.
+const (
+	StatusAccepted  = "accepted"
+	StatusSent      = "sent"
+	StatusDelivered = "delivered"
+	StatusBounced   = "bounced"
+	StatusFailed    = "failed"
+)
+
+// Address is an email address with an optional display name.
+type Address struct {
+	Address string `json:"address"`
+	Name    string `json:"name,omitempty"`
+}
+
+// Message is an email message sent with Send.
+type Message struct {
+	ID              string
+	Status          string
+	From            *Address
+	To              []Address
+	Cc              []Address
+	Bcc             []Address
+	Subject         string
+	Reference       string
+	CreatedDatetime *time.Time
+	UpdatedDatetime *time.Time
+}
+
+// Attachment is a file attached to a message. Its contents are read from
+// Content when the message is sent.
+type Attachment struct {
+	Name string
+
+	// ContentType is the media type of the file. If empty, it is detected
+	// from the extension of Name and the contents.
+	ContentType string
+
+	Content io.Reader
+}
+
+// Template is a template stored in your MessageBird account, rendered with
+// Variables as the content of a message.
+type Template struct {
+	ID        string
+	Variables map[string]interface{}
+}
+
+// Tracking enables tracking of opened messages and clicked links.
+type Tracking struct {
+	Open  bool `json:"open"`
+	Click bool `json:"click"`
+}
+
+// Params provide additional message send options. At least one of HTML,
+// Text or Template is required.
+type Params struct {
+	Cc      []Address
+	Bcc     []Address
+	ReplyTo string
+
+	HTML     string
+	Text     string
+	Template *Template
+
+	Attachments []Attachment
+	Tracking    *Tracking
+	Headers     map[string]string
+	Reference   string
+
+	// ReportURL receives the events of the message. See ParseEvent.
+	ReportURL string
+}
+
+type messageRequest struct {
+	From        Address              `json:"from"`
+	To          []Address            `json:"to"`
+	Cc          []Address            `json:"cc,omitempty"`
+	Bcc         []Address            `json:"bcc,omitempty"`
+	ReplyTo     string               `json:"replyTo,omitempty"`
+	Subject     string               `json:"subject"`
+	Content     *contentRequest      `json:"content,omitempty"`
+	Template    *templateRequest     `json:"template,omitempty"`
+	Attachments []*attachmentRequest `json:"attachments,omitempty"`
+	Tracking    *Tracking            `json:"tracking,omitempty"`
+	Headers     map[string]string    `json:"headers,omitempty"`
+	Reference   string               `json:"reference,omitempty"`
+	ReportURL   string               `json:"reportUrl,omitempty"`
+}
+
+type contentRequest struct {
+	HTML string `json:"html,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+type templateRequest struct {
+	ID        string                 `json:"id"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type attachmentRequest struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+// Send sends an email message from from to one or more recipients.
+func Send(c messagebird.Client, from Address, to []Address, subject string, params *Params) (*Message, error) {
+	requestData, err := paramsToRequest(from, to, subject, params)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Message{}
+	if err := c.Request(message, http.MethodPost, apiRoot+"/"+path, requestData); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Read retrieves the information of an existing Message, including its
+// status.
+func Read(c messagebird.Client, id string) (*Message, error) {
+	message := &Message{}
+	if err := c.Request(message, http.MethodGet, apiRoot+"/"+path+"/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func paramsToRequest(from Address, to []Address, subject string, params *Params) (*messageRequest, error) {
+	if from.Address == "" {
+		return nil, errors.New("from is required")
+	}
+	if len(to) == 0 {
+		return nil, errors.New("at least 1 recipient is required")
+	}
+	if subject == "" {
+		return nil, errors.New("subject is required")
+	}
+	if params == nil || (params.HTML == "" && params.Text == "" && params.Template == nil) {
+		return nil, errors.New("html, text or template is required")
+	}
+
+	request := &messageRequest{
+		From:      from,
+		To:        to,
+		Cc:        params.Cc,
+		Bcc:       params.Bcc,
+		ReplyTo:   params.ReplyTo,
+		Subject:   subject,
+		Tracking:  params.Tracking,
+		Headers:   params.Headers,
+		Reference: params.Reference,
+		ReportURL: params.ReportURL,
+	}
+
+	if params.HTML != "" || params.Text != "" {
+		request.Content = &contentRequest{HTML: params.HTML, Text: params.Text}
+	}
+
+	if params.Template != nil {
+		request.Template = &templateRequest{
+			ID:        params.Template.ID,
+			Variables: params.Template.Variables,
+		}
+	}
+
+	size := 0
+	for _, attachment := range params.Attachments {
+		a, n, err := attachmentToRequest(attachment, MaxAttachmentSize-size)
+		if err != nil {
+			return nil, err
+		}
+		size += n
+		request.Attachments = append(request.Attachments, a)
+	}
+
+	return request, nil
+}
+
+// attachmentToRequest reads the contents of a, which may be at most max
+// bytes.
+func attachmentToRequest(a Attachment, max int) (*attachmentRequest, int, error) {
+	if a.Name == "" || a.Content == nil {
+		return nil, 0, errors.New("attachments require a name and content")
+	}
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(a.Content, int64(max)+1))
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading attachment %s: %w", a.Name, err)
+	}
+	if n > int64(max) {
+		return nil, 0, fmt.Errorf("attachments exceed maximum size of %d bytes", MaxAttachmentSize)
+	}
+
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(a.Name))
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(buf.Bytes())
+	}
+
+	return &attachmentRequest{
+		Name:        a.Name,
+		ContentType: contentType,
+		Content:     base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, int(n), nil
+}
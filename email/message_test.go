@@ -0,0 +1,118 @@
+package email
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+var (
+	from = Address{Address: "noreply@example.com", Name: "Example"}
+	to   = []Address{{Address: "jane@example.com", Name: "Jane"}}
+)
+
+func TestSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	message, err := Send(client, from, to, "Your order has shipped", &Params{
+		HTML:      "<p>It will arrive tomorrow.</p>",
+		Text:      "It will arrive tomorrow.",
+		Bcc:       []Address{{Address: "archive@example.com"}},
+		Tracking:  &Tracking{Open: true},
+		Reference: "order-1234",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "a5ff0e4b1c1f4c1b8ea1d3f7cd3e0d2f", message.ID)
+	assert.Equal(t, StatusAccepted, message.Status)
+	assert.Equal(t, "noreply@example.com", message.From.Address)
+	assert.Equal(t, to, message.To)
+	assert.Equal(t, "order-1234", message.Reference)
+	assert.Equal(t, time.Date(2022, 1, 5, 10, 2, 59, 0, time.UTC), message.CreatedDatetime.UTC())
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/messages")
+	mbtest.AssertBodyJSONEq(t, `{
+		"from": {"address": "noreply@example.com", "name": "Example"},
+		"to": [{"address": "jane@example.com", "name": "Jane"}],
+		"bcc": [{"address": "archive@example.com"}],
+		"subject": "Your order has shipped",
+		"content": {"html": "<p>It will arrive tomorrow.</p>", "text": "It will arrive tomorrow."},
+		"tracking": {"open": true, "click": false},
+		"reference": "order-1234"
+	}`)
+}
+
+func TestSendTemplate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	_, err := Send(client, from, to, "Your order has shipped", &Params{
+		Template: &Template{ID: "shipped", Variables: map[string]interface{}{"order": 1234}},
+	})
+	assert.NoError(t, err)
+	mbtest.AssertBodyField(t, "template", map[string]interface{}{"id": "shipped", "variables": map[string]interface{}{"order": 1234}})
+	mbtest.AssertBodyFieldAbsent(t, "content")
+}
+
+func TestSendAttachments(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	_, err := Send(client, from, to, "Your receipt", &Params{
+		Text: "See attached.",
+		Attachments: []Attachment{
+			{Name: "receipt.txt", Content: strings.NewReader("total: 10")},
+			{Name: "data", ContentType: "application/json", Content: strings.NewReader("{}")},
+		},
+	})
+	assert.NoError(t, err)
+	mbtest.AssertBodyField(t, "attachments.0.name", "receipt.txt")
+	mbtest.AssertBodyField(t, "attachments.0.contentType", "text/plain; charset=utf-8")
+	mbtest.AssertBodyField(t, "attachments.0.content", "dG90YWw6IDEw")
+	mbtest.AssertBodyField(t, "attachments.1.contentType", "application/json")
+}
+
+func TestSendValidation(t *testing.T) {
+	client := mbtest.Client(t)
+	params := &Params{Text: "Hi"}
+
+	_, err := Send(client, Address{}, to, "Hi", params)
+	assert.EqualError(t, err, "from is required")
+
+	_, err = Send(client, from, nil, "Hi", params)
+	assert.EqualError(t, err, "at least 1 recipient is required")
+
+	_, err = Send(client, from, to, "", params)
+	assert.EqualError(t, err, "subject is required")
+
+	_, err = Send(client, from, to, "Hi", nil)
+	assert.EqualError(t, err, "html, text or template is required")
+
+	_, err = Send(client, from, to, "Hi", &Params{Text: "Hi", Attachments: []Attachment{{Name: "empty"}}})
+	assert.EqualError(t, err, "attachments require a name and content")
+
+	large := strings.Repeat("a", MaxAttachmentSize/2+1)
+	_, err = Send(client, from, to, "Hi", &Params{Text: "Hi", Attachments: []Attachment{
+		{Name: "a.txt", Content: strings.NewReader(large)},
+		{Name: "b.txt", Content: strings.NewReader(large)},
+	}})
+	assert.EqualError(t, err, "attachments exceed maximum size of 20971520 bytes")
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := Read(client, "a5ff0e4b1c1f4c1b8ea1d3f7cd3e0d2f")
+	assert.NoError(t, err)
+	assert.Equal(t, "Your order has shipped", message.Subject)
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages/a5ff0e4b1c1f4c1b8ea1d3f7cd3e0d2f")
+}
@@ -0,0 +1,121 @@
+// Package emailverify checks whether email addresses can receive email,
+// complementing package lookup for validating the contact details entered in
+// signup forms:
+//
+//	v, err := emailverify.Read(client, "jane@example.com", nil)
+//	if err == nil && !v.Acceptable() {
+//		// Ask for another address.
+//	}
+package emailverify
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+// path represents the path to the Verification resource.
+const path = "lookup/email"
+
+// Results of a Verification.
+const (
+	// ResultDeliverable means the mailbox exists and accepts email.
+	ResultDeliverable = "deliverable"
+
+	// ResultUndeliverable means the domain or mailbox does not exist, or
+	// rejects all email.
+	ResultUndeliverable = "undeliverable"
+
+	// ResultRisky means email is accepted, but may bounce or not be read,
+	// e.g. because the domain accepts email for any mailbox.
+	ResultRisky = "risky"
+
+	// ResultUnknown means the mail server could not be reached in time.
+	ResultUnknown = "unknown"
+)
+
+// Verification is the result of checking an email address.
+type Verification struct {
+	Email  string
+	Domain string
+	Result string
+
+	// Reason explains the Result, e.g. "mailbox_not_found" or
+	// "accept_all".
+	Reason string
+
+	// Disposable is true if the domain provides temporary mailboxes.
+	Disposable bool
+
+	// RoleAccount is true if the mailbox belongs to a role, like info@ or
+	// support@, rather than a person.
+	RoleAccount bool
+
+	// FreeProvider is true if the domain is a free email provider.
+	FreeProvider bool
+
+	// MXFound is true if the domain has MX records.
+	MXFound   bool
+	MXRecords []string
+
+	// Suggestion is a correction for a probable typo in the domain, e.g.
+	// "jane@gmail.com" for "jane@gmial.com".
+	Suggestion string
+
+	CheckedDatetime *time.Time
+}
+
+// Params provide additional verification options.
+type Params struct {
+	// SkipSMTP only checks the syntax, domain and MX records, without
+	// connecting to the mail server. It is faster, but can not detect
+	// mailboxes that do not exist.
+	SkipSMTP bool
+
+	Reference string
+}
+
+func (p *Params) QueryParams() string {
+	if p == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if p.SkipSMTP {
+		query.Set("skipSmtp", "true")
+	}
+
+	if p.Reference != "" {
+		query.Set("reference", p.Reference)
+	}
+
+	return query.Encode()
+}
+
+// Read checks whether address can receive email. Addresses that are not
+// syntactically valid are rejected without a request.
+func Read(c messagebird.Client, address string, params *Params) (*Verification, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address {
+		return nil, errors.New("invalid email address")
+	}
+
+	verification := &Verification{}
+	if err := c.Request(verification, http.MethodGet, path+"/"+url.PathEscape(address)+"?"+params.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return verification, nil
+}
+
+// Acceptable reports whether v is deliverable and not disposable, which is
+// what most signup forms require.
+func (v *Verification) Acceptable() bool {
+	return v.Result == ResultDeliverable && !v.Disposable
+}
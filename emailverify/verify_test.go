@@ -0,0 +1,46 @@
+package emailverify
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "verificationObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	v, err := Read(client, "jane@example.com", &Params{SkipSMTP: true, Reference: "signup"})
+	assert.NoError(t, err)
+	assert.Equal(t, ResultDeliverable, v.Result)
+	assert.True(t, v.MXFound)
+	assert.Equal(t, []string{"mx1.example.com", "mx2.example.com"}, v.MXRecords)
+	assert.Equal(t, time.Date(2022, 6, 1, 10, 15, 0, 0, time.UTC), *v.CheckedDatetime)
+	assert.True(t, v.Acceptable())
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/lookup/email/jane@example.com")
+	mbtest.AssertQueryParam(t, "skipSmtp", "true")
+	mbtest.AssertQueryParam(t, "reference", "signup")
+}
+
+func TestReadInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	for _, address := range []string{"", "jane", "jane@", "Jane <jane@example.com>"} {
+		_, err := Read(client, address, nil)
+		assert.EqualError(t, err, "invalid email address", address)
+	}
+}
+
+func TestAcceptable(t *testing.T) {
+	assert.False(t, (&Verification{Result: ResultDeliverable, Disposable: true}).Acceptable())
+	assert.False(t, (&Verification{Result: ResultRisky}).Acceptable())
+	assert.False(t, (&Verification{Result: ResultUndeliverable}).Acceptable())
+}
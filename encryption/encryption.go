@@ -0,0 +1,160 @@
+// Package encryption encrypts message content before it is stored, so
+// transcripts and queued messages kept on disk or in a database meet security
+// requirements. The stores of packages outbox, inbox and webhooks can be
+// wrapped to encrypt what they persist, and decrypt it transparently:
+//
+//	cipher, err := encryption.NewAESGCM(key)
+//	store := &outbox.EncryptedStore{Store: fileStore, Cipher: cipher}
+//
+// Keys are rotated by passing the previous keys to NewAESGCM, so content
+// encrypted with them can still be read. Content stored before encryption was
+// enabled is only read if the store allows plaintext, see the AllowPlaintext
+// fields of the stores.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDecrypt is returned, wrapped, when content can not be decrypted with any
+// of the keys, e.g. because it was changed or the key is wrong.
+var ErrDecrypt = errors.New("content can not be decrypted")
+
+// ErrNotSealed is returned by Open for content that was not sealed by Seal.
+var ErrNotSealed = errors.New("content is not encrypted")
+
+// KeySize is the size of the keys GenerateKey returns, for AES-256.
+const KeySize = 32
+
+// sealedPrefix marks strings sealed by Seal.
+const sealedPrefix = "enc:v1:"
+
+// Cipher encrypts and decrypts content. Implementations must authenticate
+// the content and the additional data, so changes are detected, and be safe
+// for concurrent use. The additional data is not encrypted: it binds the
+// content to what it belongs to, e.g. the ID of a record, so it can not be
+// moved to another record.
+type Cipher interface {
+	Encrypt(plaintext, additionalData []byte) ([]byte, error)
+	Decrypt(ciphertext, additionalData []byte) ([]byte, error)
+}
+
+// AESGCM is a Cipher that uses AES in Galois/Counter Mode, with a random
+// nonce per encryption.
+type AESGCM struct {
+	aeads []cipher.AEAD
+}
+
+// NewAESGCM creates an AESGCM that encrypts with key, and decrypts with key
+// and oldKeys. Keys must be 16, 24 or 32 bytes, for AES-128, AES-192 or
+// AES-256.
+func NewAESGCM(key []byte, oldKeys ...[]byte) (*AESGCM, error) {
+	c := &AESGCM{}
+	for _, k := range append([][]byte{key}, oldKeys...) {
+		block, err := aes.NewCipher(k)
+		if err != nil {
+			return nil, err
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		c.aeads = append(c.aeads, aead)
+	}
+
+	return c, nil
+}
+
+// Encrypt implements Cipher. The nonce is prepended to the ciphertext.
+func (c *AESGCM) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	aead := c.aeads[0]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt implements Cipher.
+func (c *AESGCM) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	for _, aead := range c.aeads {
+		if len(ciphertext) < aead.NonceSize() {
+			continue
+		}
+
+		nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+		if plaintext, err := aead.Open(nil, nonce, sealed, additionalData); err == nil {
+			return plaintext, nil
+		}
+	}
+
+	return nil, ErrDecrypt
+}
+
+// GenerateKey returns a random key of KeySize bytes.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Seal encrypts s with c, and returns it as text that can be stored where s
+// was, e.g. in a JSON string or text column. Empty strings are not
+// encrypted. id identifies the record s is stored in: Open fails unless it is
+// given the same id.
+func Seal(c Cipher, s, id string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	b, err := c.Encrypt([]byte(s), []byte(id))
+	if err != nil {
+		return "", err
+	}
+
+	return sealedPrefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Open decrypts s, sealed by Seal for the record identified by id, with c.
+// It returns ErrNotSealed if s was not sealed, so content can not be injected
+// unencrypted by anyone who can write to the store, and ErrDecrypt if s was
+// sealed for another record. Empty strings are returned as is, as Seal does
+// not encrypt them.
+func Open(c Cipher, s, id string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if !IsSealed(s) {
+		return "", ErrNotSealed
+	}
+
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, sealedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+
+	plaintext, err := c.Decrypt(b, []byte(id))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// IsSealed reports whether s was sealed by Seal.
+func IsSealed(s string) bool {
+	return strings.HasPrefix(s, sealedPrefix)
+}
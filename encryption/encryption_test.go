@@ -0,0 +1,99 @@
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESGCM(t *testing.T) {
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	c, err := NewAESGCM(key)
+	assert.NoError(t, err)
+
+	ciphertext, err := c.Encrypt([]byte("Hello, world!"), []byte("id"))
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(ciphertext, []byte("Hello")))
+
+	again, err := c.Encrypt([]byte("Hello, world!"), []byte("id"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again, "every encryption uses a new nonce")
+
+	plaintext, err := c.Decrypt(ciphertext, []byte("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, world!", string(plaintext))
+
+	_, err = c.Decrypt(ciphertext, []byte("other id"))
+	assert.True(t, errors.Is(err, ErrDecrypt), "content is bound to its additional data")
+
+	ciphertext[len(ciphertext)-1] ^= 1
+	_, err = c.Decrypt(ciphertext, []byte("id"))
+	assert.True(t, errors.Is(err, ErrDecrypt), "changes are detected")
+
+	_, err = c.Decrypt([]byte("short"), []byte("id"))
+	assert.True(t, errors.Is(err, ErrDecrypt))
+}
+
+func TestAESGCMKeyRotation(t *testing.T) {
+	oldKey, _ := GenerateKey()
+	newKey, _ := GenerateKey()
+
+	old, err := NewAESGCM(oldKey)
+	assert.NoError(t, err)
+	ciphertext, err := old.Encrypt([]byte("Hello"), []byte("id"))
+	assert.NoError(t, err)
+
+	rotated, err := NewAESGCM(newKey, oldKey)
+	assert.NoError(t, err)
+	plaintext, err := rotated.Decrypt(ciphertext, []byte("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", string(plaintext))
+
+	ciphertext, err = rotated.Encrypt([]byte("Hello"), []byte("id"))
+	assert.NoError(t, err)
+	_, err = old.Decrypt(ciphertext, []byte("id"))
+	assert.True(t, errors.Is(err, ErrDecrypt), "new content is encrypted with the new key")
+}
+
+func TestNewAESGCMInvalidKey(t *testing.T) {
+	_, err := NewAESGCM([]byte("too short"))
+	assert.Error(t, err)
+
+	key, _ := GenerateKey()
+	_, err = NewAESGCM(key, []byte("too short"))
+	assert.Error(t, err)
+}
+
+func TestSealOpen(t *testing.T) {
+	key, _ := GenerateKey()
+	c, _ := NewAESGCM(key)
+
+	sealed, err := Seal(c, "Hello, world!", "id")
+	assert.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, sealed, "Hello")
+
+	opened, err := Open(c, sealed, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, world!", opened)
+
+	_, err = Open(c, sealed, "other id")
+	assert.True(t, errors.Is(err, ErrDecrypt), "content can not be moved to another record")
+
+	_, err = Open(c, "stored before encryption was enabled", "id")
+	assert.Equal(t, ErrNotSealed, err)
+
+	sealed, err = Seal(c, "", "id")
+	assert.NoError(t, err)
+	assert.Empty(t, sealed)
+	opened, err = Open(c, sealed, "id")
+	assert.NoError(t, err)
+	assert.Empty(t, opened)
+
+	_, err = Open(c, sealedPrefix+"not base64!", "id")
+	assert.True(t, errors.Is(err, ErrDecrypt))
+}
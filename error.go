@@ -1,7 +1,11 @@
 package messagebird
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 )
 
@@ -30,3 +34,108 @@ func (r ErrorResponse) Error() string {
 	}
 	return fmt.Sprintf("API errors: %s", strings.Join(inners, ", "))
 }
+
+// RequestError is the error of a failed request, returned by DefaultClient
+// when WrapErrors is set. It identifies the request, so it can be logged as
+// is, and wraps the error that occurred.
+type RequestError struct {
+	// Method and URL are those of the request. The query string is left out
+	// and long numbers, which usually are phone numbers, are masked.
+	Method string
+	URL    string
+
+	// StatusCode is the HTTP status of the response, or zero if no response
+	// was received.
+	StatusCode int
+
+	// Summary lists the fields of the request body, without their values,
+	// e.g. "body originator recipients[2]".
+	Summary string
+
+	Err error
+}
+
+func newRequestError(method, path string, status int, data interface{}, err error) *RequestError {
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		path = Endpoint + "/" + path
+	}
+
+	return &RequestError{
+		Method:     method,
+		URL:        redactURL(path),
+		StatusCode: status,
+		Summary:    summarize(data),
+		Err:        err,
+	}
+}
+
+// Error implements error interface.
+func (e *RequestError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s %s: %v", e.Method, e.URL, e.Err)
+	}
+
+	return fmt.Sprintf("%s %s: status %d: %v", e.Method, e.URL, e.StatusCode, e.Err)
+}
+
+// Unwrap returns the error that occurred.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// APIErrors returns the errors the API responded with, if any.
+func (e *RequestError) APIErrors() []Error {
+	var errorResponse ErrorResponse
+	if !errors.As(e.Err, &errorResponse) {
+		return nil
+	}
+
+	return errorResponse.Errors
+}
+
+// redactURL removes the query string of u, which may hold tokens, and masks
+// numbers of 8 digits or more after their first 4 digits.
+func redactURL(u string) string {
+	if i := strings.IndexByte(u, '?'); i >= 0 {
+		u = u[:i]
+	}
+
+	return maskNumbers(u)
+}
+
+// summarize returns the sorted names of the fields of a request body, with
+// the length of arrays.
+func summarize(data interface{}) string {
+	var fields map[string]interface{}
+
+	switch data := data.(type) {
+	case nil:
+		return ""
+	case string:
+		form, err := url.ParseQuery(data)
+		if err != nil {
+			return ""
+		}
+
+		fields = make(map[string]interface{}, len(form))
+		for key, values := range form {
+			fields[key] = values[0]
+		}
+	default:
+		b, err := json.Marshal(data)
+		if err != nil || json.Unmarshal(b, &fields) != nil {
+			return ""
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for key, value := range fields {
+		if items, ok := value.([]interface{}); ok {
+			key = fmt.Sprintf("%s[%d]", key, len(items))
+		}
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, " ")
+}
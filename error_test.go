@@ -1,8 +1,11 @@
 package messagebird
 
 import (
-	"github.com/stretchr/testify/assert"
+	"errors"
+	"net/http"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestError(t *testing.T) {
@@ -55,3 +58,52 @@ func TestErrorWithOneEmptyError(t *testing.T) {
 	assert.Error(t, errRes)
 	assert.Equal(t, "API errors: ", errRes.Error())
 }
+
+func TestRequestErrorWrapping(t *testing.T) {
+	c := newTestClient(http.StatusUnprocessableEntity, `{"errors":[{"code":9,"description":"no (correct) recipients found","parameter":"recipients"}]}`)
+	c.WrapErrors = true
+
+	data := map[string]interface{}{
+		"originator": "MessageBird",
+		"recipients": []string{"31612345678", "31687654321"},
+		"body":       "Your code is 1234",
+	}
+	err := c.Request(nil, http.MethodPost, "messages", data)
+
+	var requestErr *RequestError
+	if assert.True(t, errors.As(err, &requestErr)) {
+		assert.Equal(t, http.MethodPost, requestErr.Method)
+		assert.Equal(t, "https://rest.messagebird.com/messages", requestErr.URL)
+		assert.Equal(t, http.StatusUnprocessableEntity, requestErr.StatusCode)
+		assert.Equal(t, "body originator recipients[2]", requestErr.Summary)
+		assert.Equal(t, []Error{{Code: 9, Description: "no (correct) recipients found", Parameter: "recipients"}}, requestErr.APIErrors())
+	}
+	assert.EqualError(t, err, "POST https://rest.messagebird.com/messages: status 422: API errors: no (correct) recipients found")
+	assert.NotContains(t, err.Error(), "1234")
+
+	var errorResponse ErrorResponse
+	assert.True(t, errors.As(err, &errorResponse))
+}
+
+func TestRequestErrorUnwrap(t *testing.T) {
+	c := newTestClient(http.StatusInternalServerError, "")
+	c.WrapErrors = true
+
+	err := c.Request(nil, http.MethodGet, "lookup/31612345678?countryCode=NL", nil)
+	assert.True(t, errors.Is(err, ErrUnexpectedResponse))
+	assert.EqualError(t, err, "GET https://rest.messagebird.com/lookup/3161*******: status 500: the MessageBird API is currently unavailable")
+	assert.Nil(t, err.(*RequestError).APIErrors())
+
+	c.WrapErrors = false
+	assert.Equal(t, ErrUnexpectedResponse, c.Request(nil, http.MethodGet, "balance", nil))
+}
+
+func TestSummarize(t *testing.T) {
+	assert.Equal(t, "", summarize(nil))
+	assert.Equal(t, "body recipients", summarize("recipients=31612345678&body=Hello"))
+	assert.Equal(t, "content to type", summarize(struct {
+		To      string      `json:"to"`
+		Type    string      `json:"type"`
+		Content interface{} `json:"content"`
+	}{To: "+31612345678", Type: "text", Content: map[string]string{"text": "Hello"}}))
+}
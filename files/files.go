@@ -0,0 +1,167 @@
+// Package files stores files with the file storage of the MessageBird
+// Messaging API, so media can be sent by MMS and conversations without hosting
+// it yourself. Packages mms and conversation build their media helpers on
+// it.
+package files
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// apiRoot is the absolute URL of the file storage.
+const apiRoot = "https://messaging.messagebird.com/v1"
+
+// path represents the path to the File resource.
+const path = "files"
+
+// File is a stored file. Its contents can be downloaded from URL, which can
+// be used as a media URL in messages.
+type File struct {
+	ID              string
+	Name            string
+	ContentType     string
+	Size            int
+	URL             string
+	CreatedDatetime *time.Time
+}
+
+type uploadRequest struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+// Upload stores the contents of r as a file called name. If contentType is
+// empty, it is detected with DetectContentType.
+func Upload(c messagebird.Client, name, contentType string, r io.Reader) (*File, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, r)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	if n == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	if contentType == "" {
+		contentType = DetectContentType(name, buf.Bytes())
+	}
+
+	file := &File{}
+	req := &uploadRequest{
+		Name:        name,
+		ContentType: contentType,
+		Content:     base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}
+	if err := c.Request(file, http.MethodPost, apiRoot+"/"+path, req); err != nil {
+		return nil, err
+	}
+
+	if file.URL == "" {
+		file.URL = URL(file.ID)
+	}
+	if file.Name == "" {
+		file.Name = name
+	}
+	if file.ContentType == "" {
+		file.ContentType = contentType
+	}
+	if file.Size == 0 {
+		file.Size = int(n)
+	}
+
+	return file, nil
+}
+
+// Read retrieves the metadata of an existing File.
+func Read(c messagebird.Client, id string) (*File, error) {
+	file := &File{}
+	if err := c.Request(file, http.MethodGet, URL(id)+"/metadata", nil); err != nil {
+		return nil, err
+	}
+
+	if file.URL == "" {
+		file.URL = URL(id)
+	}
+
+	return file, nil
+}
+
+// Download writes the contents of the file with id to w.
+func Download(c messagebird.Client, id string, w io.Writer) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	return c.Request(w, http.MethodGet, URL(id), nil)
+}
+
+// Delete deletes a file. If nil is returned, the file was deleted
+// successfully.
+func Delete(c messagebird.Client, id string) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, URL(id), nil)
+}
+
+// URL returns the URL the contents of the file with id can be downloaded
+// from.
+func URL(id string) string {
+	return apiRoot + "/" + path + "/" + id
+}
+
+// contentTypesByExtension covers formats that can not be sniffed and may be
+// missing from the system's MIME tables.
+var contentTypesByExtension = map[string]string{
+	".vcf": "text/vcard",
+	".ics": "text/calendar",
+	".csv": "text/csv",
+	".rtf": "text/rtf",
+}
+
+// DetectContentType sniffs the media type of b. Formats that can not be
+// sniffed, like vCards, are detected by the extension of name. Parameters
+// like the charset are not included.
+func DetectContentType(name string, b []byte) string {
+	contentType := http.DetectContentType(b)
+	if strings.HasPrefix(contentType, "text/plain") || contentType == "application/octet-stream" {
+		if byExt := TypeByExtension(name); byExt != "" {
+			return byExt
+		}
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+
+	return contentType
+}
+
+// TypeByExtension returns the media type of a file called name based on its
+// extension, or an empty string if it is unknown.
+func TypeByExtension(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if contentType, ok := contentTypesByExtension[ext]; ok {
+		return contentType
+	}
+
+	contentType, _, _ := mime.ParseMediaType(mime.TypeByExtension(ext))
+	return contentType
+}
@@ -0,0 +1,110 @@
+package files
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestUpload(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "fileObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	file, err := Upload(client, "invoice.pdf", "", strings.NewReader("%PDF-1.4"))
+	assert.NoError(t, err)
+	assert.Equal(t, "5f8a6cd4-0b9b-4b6a-9ad2-a2d8a5c3e6f1", file.ID)
+	assert.Equal(t, 2048, file.Size)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/files")
+	mbtest.AssertBodyJSONEq(t, `{
+		"name": "invoice.pdf",
+		"contentType": "application/pdf",
+		"content": "`+base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))+`"
+	}`)
+}
+
+func TestUploadDefaults(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"file-id"}`), http.StatusCreated)
+	client := mbtest.Client(t)
+
+	file, err := Upload(client, "contact.vcf", "text/x-vcard", strings.NewReader("BEGIN:VCARD\nEND:VCARD\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &File{
+		ID:          "file-id",
+		Name:        "contact.vcf",
+		ContentType: "text/x-vcard",
+		Size:        22,
+		URL:         "https://messaging.messagebird.com/v1/files/file-id",
+	}, file)
+
+	mbtest.AssertBodyField(t, "contentType", "text/x-vcard")
+}
+
+func TestUploadInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	_, err := Upload(client, "", "", strings.NewReader("contents"))
+	assert.EqualError(t, err, "name is required")
+
+	_, err = Upload(client, "empty.txt", "", strings.NewReader(""))
+	assert.EqualError(t, err, "file is empty")
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "fileObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	file, err := Read(client, "5f8a6cd4-0b9b-4b6a-9ad2-a2d8a5c3e6f1")
+	assert.NoError(t, err)
+	assert.Equal(t, "invoice.pdf", file.Name)
+	assert.Equal(t, "application/pdf", file.ContentType)
+	assert.Equal(t, time.Date(2022, 10, 11, 9, 42, 3, 0, time.UTC), *file.CreatedDatetime)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/files/5f8a6cd4-0b9b-4b6a-9ad2-a2d8a5c3e6f1/metadata")
+}
+
+func TestDownload(t *testing.T) {
+	mbtest.WillReturn([]byte("%PDF-1.4"), http.StatusOK)
+	client := mbtest.Client(t)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Download(client, "file-id", &buf))
+	assert.Equal(t, "%PDF-1.4", buf.String())
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/files/file-id")
+}
+
+func TestDownloadError(t *testing.T) {
+	mbtest.WillReturnAccessKeyError()
+	client := mbtest.Client(t)
+
+	var buf bytes.Buffer
+	assert.Error(t, Download(client, "file-id", &buf))
+	assert.Zero(t, buf.Len())
+}
+
+func TestDelete(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	assert.NoError(t, Delete(client, "file-id"))
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/files/file-id")
+
+	assert.EqualError(t, Delete(client, ""), "id is required")
+}
+
+func TestDetectContentType(t *testing.T) {
+	assert.Equal(t, "text/vcard", DetectContentType("contact.VCF", []byte("BEGIN:VCARD")))
+	assert.Equal(t, "text/plain", DetectContentType("notes.txt", []byte("plain text")))
+	assert.Equal(t, "image/gif", DetectContentType("pixel", []byte("GIF89a")))
+}
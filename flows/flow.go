@@ -0,0 +1,181 @@
+// Package flows lists the Flow Builder flows of your account and invokes them
+// from your own systems. A flow with a webhook trigger runs every time it is
+// invoked, with the payload available to its steps:
+//
+//	type orderShipped struct {
+//		OrderID string `json:"orderId"`
+//		Phone   string `json:"phone"`
+//	}
+//
+//	invocation, err := flows.Invoke(client, "your flow ID", &orderShipped{
+//		OrderID: "1234",
+//		Phone:   "+31612345678",
+//	})
+package flows
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+// apiRoot is the absolute URL of the Flow Builder API.
+const apiRoot = "https://flows.messagebird.com"
+
+// path represents the path to the Flow resource.
+const path = "flows"
+
+// invokePath represents the path to the invocations of a Flow, relative to
+// the Flow.
+const invokePath = "invoke"
+
+// Triggers that start a Flow.
+const (
+	TriggerWebhook         = "onWebhook"
+	TriggerIncomingMessage = "onIncomingMessage"
+	TriggerIncomingCall    = "onIncomingCall"
+	TriggerSchedule        = "onSchedule"
+)
+
+// Statuses of an Invocation.
+const (
+	InvocationStatusQueued    = "queued"
+	InvocationStatusRunning   = "running"
+	InvocationStatusCompleted = "completed"
+	InvocationStatusFailed    = "failed"
+)
+
+// Flow is an automation built with Flow Builder.
+type Flow struct {
+	ID          string
+	Title       string
+	Description string
+	Trigger     string
+	Enabled     bool
+
+	// InvokeURL is the webhook URL of flows with TriggerWebhook. It can be
+	// passed to InvokeURL, or configured in other systems.
+	InvokeURL string
+
+	CreatedDatetime *time.Time
+	UpdatedDatetime *time.Time
+}
+
+// FlowList represents a list of flows.
+type FlowList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Flow
+}
+
+// Invocation is a run of a Flow, started by Invoke or InvokeURL.
+type Invocation struct {
+	ID              string
+	FlowID          string
+	Status          string
+	CreatedDatetime *time.Time
+}
+
+// ListRequest can be used to set query params in List().
+type ListRequest struct {
+	Trigger string // Possible values: the Trigger constants.
+	Limit   int
+	Offset  int
+}
+
+func (lr *ListRequest) QueryParams() string {
+	if lr == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if lr.Trigger != "" {
+		query.Set("trigger", lr.Trigger)
+	}
+
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
+
+	return query.Encode()
+}
+
+// List retrieves the flows of your account.
+func List(c messagebird.Client, options *ListRequest) (*FlowList, error) {
+	flowList := &FlowList{}
+	if err := c.Request(flowList, http.MethodGet, apiRoot+"/"+path+"?"+options.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return flowList, nil
+}
+
+// Read retrieves a single Flow.
+func Read(c messagebird.Client, id string) (*Flow, error) {
+	flow := &Flow{}
+	if err := c.Request(flow, http.MethodGet, apiRoot+"/"+path+"/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return flow, nil
+}
+
+// Invoke starts the Flow with id. payload is encoded as JSON and must be an
+// object, like a struct or a map. Its fields are available to the steps of
+// the flow. A nil payload sends an empty object.
+func Invoke(c messagebird.Client, id string, payload interface{}) (*Invocation, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	return InvokeURL(c, apiRoot+"/"+path+"/"+id+"/"+invokePath, payload)
+}
+
+// InvokeURL is like Invoke, for the InvokeURL of a Flow.
+func InvokeURL(c messagebird.Client, invokeURL string, payload interface{}) (*Invocation, error) {
+	if invokeURL == "" {
+		return nil, errors.New("invokeURL is required")
+	}
+
+	body, err := encodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	invocation := &Invocation{}
+	if err := c.Request(invocation, http.MethodPost, invokeURL, body); err != nil {
+		return nil, err
+	}
+
+	return invocation, nil
+}
+
+// encodePayload encodes payload as a JSON object.
+func encodePayload(payload interface{}) (json.RawMessage, error) {
+	if payload == nil {
+		return json.RawMessage("{}"), nil
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(b, []byte("{")) {
+		return nil, errors.New("payload must be a JSON object")
+	}
+
+	return b, nil
+}
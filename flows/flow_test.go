@@ -0,0 +1,110 @@
+package flows
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "flowListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := List(client, &ListRequest{Trigger: TriggerWebhook, Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.TotalCount)
+	assert.Equal(t, "Support line", list.Items[1].Title)
+	assert.False(t, list.Items[1].Enabled)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/flows")
+	mbtest.AssertQuery(t, url.Values{
+		"trigger": {"onWebhook"},
+		"limit":   {"20"},
+	})
+}
+
+func TestListNilParams(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "flowListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := List(client, nil)
+	assert.NoError(t, err)
+	mbtest.AssertQuery(t, nil)
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "flowObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	flow, err := Read(client, "de3ed163-d5fc-45f4-b8c4-7eea7458c635")
+	assert.NoError(t, err)
+	assert.Equal(t, "Order shipped", flow.Title)
+	assert.Equal(t, TriggerWebhook, flow.Trigger)
+	assert.True(t, flow.Enabled)
+	assert.Equal(t, "https://flows.messagebird.com/flows/de3ed163-d5fc-45f4-b8c4-7eea7458c635/invoke", flow.InvokeURL)
+	assert.Equal(t, time.Date(2022, 3, 14, 8, 12, 45, 0, time.UTC), *flow.CreatedDatetime)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/flows/de3ed163-d5fc-45f4-b8c4-7eea7458c635")
+}
+
+func TestInvoke(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "invocationObject.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	type orderShipped struct {
+		OrderID string `json:"orderId"`
+		Phone   string `json:"phone"`
+	}
+
+	invocation, err := Invoke(client, "de3ed163-d5fc-45f4-b8c4-7eea7458c635", &orderShipped{
+		OrderID: "1234",
+		Phone:   "+31612345678",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "0f3c5e9a-8d1b-4c7e-a2f6-5b4d3c2a1e0f", invocation.ID)
+	assert.Equal(t, InvocationStatusQueued, invocation.Status)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/flows/de3ed163-d5fc-45f4-b8c4-7eea7458c635/invoke")
+	mbtest.AssertBodyJSONEq(t, `{"orderId": "1234", "phone": "+31612345678"}`)
+}
+
+func TestInvokeNilPayload(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "invocationObject.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	_, err := Invoke(client, "de3ed163-d5fc-45f4-b8c4-7eea7458c635", nil)
+	assert.NoError(t, err)
+	mbtest.AssertBodyJSONEq(t, `{}`)
+}
+
+func TestInvokeURL(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "invocationObject.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	_, err := InvokeURL(client, "https://flows.messagebird.com/flows/de3ed163-d5fc-45f4-b8c4-7eea7458c635/invoke", map[string]interface{}{"orderId": "1234"})
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/flows/de3ed163-d5fc-45f4-b8c4-7eea7458c635/invoke")
+	mbtest.AssertBodyField(t, "orderId", "1234")
+}
+
+func TestInvokeValidation(t *testing.T) {
+	client := mbtest.Client(t)
+
+	_, err := Invoke(client, "", nil)
+	assert.EqualError(t, err, "id is required")
+
+	_, err = InvokeURL(client, "", nil)
+	assert.EqualError(t, err, "invokeURL is required")
+
+	_, err = Invoke(client, "de3ed163-d5fc-45f4-b8c4-7eea7458c635", []string{"orderId"})
+	assert.EqualError(t, err, "payload must be a JSON object")
+}
@@ -21,23 +21,35 @@ const (
 	contactPath = "contacts"
 
 	// maximumContactsPerRequest is the maxium number of contacts that can be
-	// created in a single request.
+	// added to or removed from a group in a single request.
 	maximumContactsPerRequest = 50
 )
 
 // Group gets returned by the API.
 type Group struct {
-	ID       string
-	HRef     string
-	Name     string
-	Contacts struct {
-		TotalCount int
-		HRef       string
-	}
+	ID              string
+	HRef            string
+	Name            string
+	Contacts        contact.Link
 	CreatedDatetime *time.Time
 	UpdatedDatetime *time.Time
 }
 
+// ListContacts follows the Contacts link of the group. It returns the same
+// contacts as ListContacts(c, group.ID, options).
+func (g *Group) ListContacts(c messagebird.Client, options *messagebird.PaginationRequest) (*contact.Contacts, error) {
+	if g.Contacts.HRef == "" {
+		return nil, errors.New("group has no link to follow, make sure it was read from the API")
+	}
+
+	contacts := &contact.Contacts{}
+	if err := c.Request(contacts, http.MethodGet, g.Contacts.HRef+"?"+options.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
 type Groups struct {
 	Offset     int
 	Limit      int
@@ -139,33 +151,60 @@ func validateUpdate(request *Request) error {
 	return nil
 }
 
-// AddContacts adds a maximum of 50 contacts to the group.
+// AddContacts adds contacts to the group. The API accepts a maximum of 50
+// contacts per request, so larger slices are split into multiple requests. If
+// one of them fails, the contacts in later requests are not added.
 func AddContacts(c messagebird.Client, groupID string, contactIDs []string) error {
-	if err := validateAddContacts(contactIDs); err != nil {
+	if err := validateContactIDs(contactIDs); err != nil {
 		return err
 	}
 
-	data := addContactsData(contactIDs)
-
-	return c.Request(nil, http.MethodPut, path+"/"+groupID+"/"+contactPath, data)
+	return inChunks(contactIDs, func(chunk []string) error {
+		return c.Request(nil, http.MethodPut, path+"/"+groupID+"/"+contactPath, addContactsData(chunk))
+	})
 }
 
-func validateAddContacts(contactIDs []string) error {
-	count := len(contactIDs)
+// RemoveContacts removes contacts from the group. Like AddContacts, larger
+// slices are split into multiple requests.
+func RemoveContacts(c messagebird.Client, groupID string, contactIDs []string) error {
+	if err := validateContactIDs(contactIDs); err != nil {
+		return err
+	}
+
+	return inChunks(contactIDs, func(chunk []string) error {
+		formattedPath := fmt.Sprintf("%s/%s/%s?%s", path, groupID, contactPath, addContactsData(chunk))
+		return c.Request(nil, http.MethodDelete, formattedPath, nil)
+	})
+}
 
+func validateContactIDs(contactIDs []string) error {
 	// len(nil) == 0: https://golang.org/ref/spec#Length_and_capacity
-	if count == 0 {
+	if len(contactIDs) == 0 {
 		return fmt.Errorf("at least one contactID is required")
 	}
 
-	if count > maximumContactsPerRequest {
-		return fmt.Errorf("can not add more than %d contacts per request, got %d", maximumContactsPerRequest, count)
+	return nil
+}
+
+// inChunks calls fn for consecutive chunks of at most
+// maximumContactsPerRequest contactIDs, until fn returns an error.
+func inChunks(contactIDs []string, fn func(chunk []string) error) error {
+	for start := 0; start < len(contactIDs); start += maximumContactsPerRequest {
+		end := start + maximumContactsPerRequest
+		if end > len(contactIDs) {
+			end = len(contactIDs)
+		}
+
+		if err := fn(contactIDs[start:end]); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// addContactsData gets the data string for adding a contact to a group. We're
+// addContactsData gets the data string for adding a contact to a group. It is
+// also used as the query string for removing contacts. We're
 // intentionally not using url.Values for building the string: the API expects
 // `ids[]=foo&ids[]=bar` format, while url.Values encodes to `ids=foo&ids=bar`.
 func addContactsData(contactIDs []string) string {
@@ -2,7 +2,10 @@ package group
 
 import (
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,7 +26,7 @@ func TestCreate(t *testing.T) {
 	assert.NoError(t, err)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/groups")
-	mbtest.AssertTestdata(t, "groupRequestCreateObject.json", mbtest.Request.Body)
+	mbtest.AssertTestdata(t, "groupRequestCreateObject.json", mbtest.LastRequest().Body)
 	assert.Equal(t, "Friends", group.Name)
 }
 
@@ -62,7 +65,7 @@ func TestListPagination(t *testing.T) {
 		expected string
 		options  *messagebird.PaginationRequest
 	}{
-		{"limit=20&offset=0", messagebird.DefaultPagination},
+		{"limit=20", messagebird.DefaultPagination},
 		{"limit=10&offset=25", &messagebird.PaginationRequest{Limit: 10, Offset: 25}},
 		{"limit=50&offset=10", &messagebird.PaginationRequest{Limit: 50, Offset: 10}},
 	}
@@ -70,7 +73,7 @@ func TestListPagination(t *testing.T) {
 	for _, tc := range tt {
 		_, err := List(client, tc.options)
 		assert.NoError(t, err)
-		query := mbtest.Request.URL.RawQuery
+		query := mbtest.LastRequest().URL.RawQuery
 		assert.Equal(t, tc.expected, query)
 	}
 }
@@ -104,8 +107,8 @@ func TestUpdate(t *testing.T) {
 	assert.NoError(t, err)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/groups/group-id")
-	mbtest.AssertTestdata(t, "groupRequestUpdateObject.json", mbtest.Request.Body)
-	assert.Equal(t, "application/json", mbtest.Request.ContentType)
+	mbtest.AssertTestdata(t, "groupRequestUpdateObject.json", mbtest.LastRequest().Body)
+	assert.Equal(t, "application/json", mbtest.LastRequest().ContentType)
 }
 
 func TestAddContacts(t *testing.T) {
@@ -116,8 +119,8 @@ func TestAddContacts(t *testing.T) {
 	assert.NoError(t, err)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPut, "/groups/group-id/contacts")
-	mbtest.AssertTestdata(t, "groupRequestAddContactsObject.txt", mbtest.Request.Body)
-	assert.Equal(t, "application/x-www-form-urlencoded", mbtest.Request.ContentType)
+	mbtest.AssertTestdata(t, "groupRequestAddContactsObject.txt", mbtest.LastRequest().Body)
+	assert.Equal(t, "application/x-www-form-urlencoded", mbtest.LastRequest().ContentType)
 }
 
 func TestAddContactsWithEmptyContacts(t *testing.T) {
@@ -136,14 +139,50 @@ func TestAddContactsWithEmptyContacts(t *testing.T) {
 	}
 }
 
-func TestAddContactsWithTooManyContacts(t *testing.T) {
+func TestAddContactsInChunks(t *testing.T) {
+	var bodies []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer stop()
+
 	client := mbtest.Client(t)
+	client.HTTPClient.Transport = transport
 
 	// Only 50 contacts are allowed at a time.
 	contactIDs := make([]string, 51)
+	for i := range contactIDs {
+		contactIDs[i] = strconv.Itoa(i)
+	}
 
 	err := AddContacts(client, "group-id", contactIDs)
+	assert.NoError(t, err)
+
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, 50, strings.Count(bodies[0], "ids[]="))
+	assert.Equal(t, "ids[]=50", bodies[1])
+}
+
+func TestRemoveContacts(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := RemoveContacts(client, "group-id", []string{"first-contact-id", "second-contact-id"})
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/groups/group-id/contacts")
+	assert.Equal(t, "ids[]=first-contact-id&ids[]=second-contact-id", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestRemoveContactsError(t *testing.T) {
+	mbtest.WillReturnAccessKeyError()
+	client := mbtest.Client(t)
+
+	err := RemoveContacts(client, "group-id", []string{"first-contact-id"})
 	assert.Error(t, err)
+	assert.IsType(t, messagebird.ErrorResponse{}, err)
 }
 
 func TestListContacts(t *testing.T) {
@@ -172,3 +211,18 @@ func TestRemoveContact(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/groups/group-id/contacts/contact-id")
 }
+
+func TestGroupListContacts(t *testing.T) {
+	group := &Group{ID: "group-id"}
+	group.Contacts.HRef = "https://rest.messagebird.com/groups/group-id/contacts"
+
+	mbtest.WillReturnTestdata(t, "groupContactListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := group.ListContacts(client, messagebird.DefaultPagination)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, list.TotalCount)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/groups/group-id/contacts")
+	assert.Equal(t, "limit=20", mbtest.LastRequest().URL.RawQuery)
+}
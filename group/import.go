@@ -0,0 +1,342 @@
+package group
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+)
+
+// Columns of the CSV files read by Import. The group column is required, and
+// either contactId or msisdn. Rows with an MSISDN create the contact, or
+// update the existing contact with that MSISDN, with the first and last name
+// of the row. MSISDNs are international numbers, with or without the leading
+// +.
+const (
+	ImportColumnGroup     = "group"
+	ImportColumnContactID = "contactId"
+	ImportColumnMSISDN    = "msisdn"
+	ImportColumnFirstName = "firstName"
+	ImportColumnLastName  = "lastName"
+)
+
+// importBatchSize is the number of CSV rows Import adds to their groups at
+// a time. Progress is recorded after every batch.
+const importBatchSize = 500
+
+// ImportProgress is the state of an import. It can be stored, e.g. as JSON,
+// to resume an import that was interrupted.
+type ImportProgress struct {
+	// Groups maps the names of the groups in the CSV to their IDs.
+	Groups map[string]string
+
+	// Lines is the number of CSV rows, not counting the header, of which the
+	// contacts were added to their groups.
+	Lines int
+
+	// Failed are the rows that could not be imported.
+	Failed []*ImportFailure
+}
+
+// ImportFailure is a CSV row that could not be imported.
+type ImportFailure struct {
+	// Line is the line number of the row in the CSV, starting at 1 for the
+	// header.
+	Line int
+
+	Group   string
+	Contact string
+	Err     string
+}
+
+// Import reads group memberships from CSV data in r, creates the groups that
+// do not exist yet, and adds the contacts to them. Groups are matched by
+// name. The first CSV row must be a header, see ImportColumnGroup and the
+// other columns. MSISDNs must be in international format.
+//
+// Rows that fail do not stop the import: they are reported in the returned
+// ImportProgress. If an error is returned, the progress holds the rows that
+// were imported so far: pass it to Resume, with the same CSV data, to
+// continue.
+func Import(ctx context.Context, c messagebird.Client, r io.Reader) (*ImportProgress, error) {
+	return Resume(ctx, c, r, nil)
+}
+
+// Resume continues the import of the CSV data in r, skipping the rows done
+// according to progress. It updates and returns progress, or new progress if
+// progress is nil.
+func Resume(ctx context.Context, c messagebird.Client, r io.Reader, progress *ImportProgress) (*ImportProgress, error) {
+	if progress == nil {
+		progress = &ImportProgress{}
+	}
+	if progress.Groups == nil {
+		progress.Groups = make(map[string]string)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return progress, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	columns, err := importColumnsOf(header)
+	if err != nil {
+		return progress, err
+	}
+
+	imp := &importer{c: c, progress: progress}
+
+	var batch []*importRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return progress, fmt.Errorf("reading CSV line %d: %w", line, err)
+		}
+
+		if line-1 <= progress.Lines {
+			continue
+		}
+
+		batch = append(batch, columns.row(line, record))
+		if len(batch) == importBatchSize {
+			if err := imp.importBatch(ctx, batch); err != nil {
+				return progress, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := imp.importBatch(ctx, batch); err != nil {
+			return progress, err
+		}
+	}
+
+	return progress, nil
+}
+
+type importRow struct {
+	line                int
+	group               string
+	contactID, msisdn   string
+	firstName, lastName string
+}
+
+type importer struct {
+	c        messagebird.Client
+	progress *ImportProgress
+
+	// existing maps the names of the existing groups to their IDs, once
+	// they were listed.
+	existing map[string]string
+}
+
+// importBatch adds the contacts of the rows to their groups. Progress is
+// only recorded once all of them were added, so a failed batch is imported
+// again when the import is resumed. Adding a contact to a group it is in
+// already is not an error.
+func (imp *importer) importBatch(ctx context.Context, rows []*importRow) error {
+	var failed []*ImportFailure
+	fail := func(row *importRow, err error) {
+		contactName := row.contactID
+		if contactName == "" {
+			contactName = row.msisdn
+		}
+		failed = append(failed, &ImportFailure{Line: row.line, Group: row.group, Contact: contactName, Err: err.Error()})
+	}
+
+	members := make(map[string][]string)
+	var groupIDs []string
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		groupID, err := imp.groupID(row.group)
+		if err != nil {
+			return err
+		}
+		if groupID == "" {
+			fail(row, errors.New("group is required"))
+			continue
+		}
+
+		contactID, err := imp.contactID(row)
+		if err != nil {
+			fail(row, err)
+			continue
+		}
+
+		if _, ok := members[groupID]; !ok {
+			groupIDs = append(groupIDs, groupID)
+		}
+		members[groupID] = append(members[groupID], contactID)
+	}
+
+	for _, groupID := range groupIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := AddContacts(imp.c, groupID, members[groupID]); err != nil {
+			return fmt.Errorf("adding contacts to group %s: %w", groupID, err)
+		}
+	}
+
+	imp.progress.Lines = rows[len(rows)-1].line - 1
+	imp.progress.Failed = append(imp.progress.Failed, failed...)
+
+	return nil
+}
+
+// groupID returns the ID of the group with name, which is created if it does
+// not exist. It returns an empty ID for an empty name.
+func (imp *importer) groupID(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	if id, ok := imp.progress.Groups[name]; ok {
+		return id, nil
+	}
+
+	if imp.existing == nil {
+		existing, err := existingGroups(imp.c)
+		if err != nil {
+			return "", fmt.Errorf("listing groups: %w", err)
+		}
+		imp.existing = existing
+	}
+
+	if id, ok := imp.existing[name]; ok {
+		imp.progress.Groups[name] = id
+		return id, nil
+	}
+
+	group, err := Create(imp.c, &Request{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("creating group %q: %w", name, err)
+	}
+	imp.progress.Groups[name] = group.ID
+
+	return group.ID, nil
+}
+
+// existingGroups maps the names of all groups to their IDs. Of groups with
+// the same name, the first is used.
+func existingGroups(c messagebird.Client) (map[string]string, error) {
+	existing := make(map[string]string)
+
+	var pager paging.Pager
+	for !pager.Done() {
+		pager.Fetch(func(offset int) (int, int, error) {
+			groups, err := List(c, &messagebird.PaginationRequest{Limit: 100, Offset: offset})
+			if err != nil {
+				return 0, 0, err
+			}
+
+			for _, g := range groups.Items {
+				if _, ok := existing[g.Name]; !ok {
+					existing[g.Name] = g.ID
+				}
+			}
+
+			return len(groups.Items), groups.TotalCount, nil
+		})
+	}
+
+	return existing, pager.Err()
+}
+
+// contactID returns the ID of the contact of row, creating or updating the
+// contact if the row has an MSISDN.
+func (imp *importer) contactID(row *importRow) (string, error) {
+	if row.contactID != "" {
+		return row.contactID, nil
+	}
+	if row.msisdn == "" {
+		return "", errors.New("contactId or msisdn is required")
+	}
+
+	msisdn, err := numberutil.MSISDN(row.msisdn, "")
+	if err != nil {
+		return "", err
+	}
+
+	c, _, err := contact.Upsert(imp.c, &contact.CreateRequest{
+		MSISDN:    msisdn,
+		FirstName: row.firstName,
+		LastName:  row.lastName,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return c.ID, nil
+}
+
+// importColumns holds the index of every column, or -1 if it is not in the
+// CSV.
+type importColumns struct {
+	group, contactID, msisdn, firstName, lastName int
+}
+
+func importColumnsOf(header []string) (*importColumns, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+
+	lookup := func(name string) int {
+		if i, ok := index[name]; ok {
+			return i
+		}
+		return -1
+	}
+
+	cols := &importColumns{
+		group:     lookup(ImportColumnGroup),
+		contactID: lookup(ImportColumnContactID),
+		msisdn:    lookup(ImportColumnMSISDN),
+		firstName: lookup(ImportColumnFirstName),
+		lastName:  lookup(ImportColumnLastName),
+	}
+
+	if cols.group < 0 {
+		return nil, fmt.Errorf("column %q not found in CSV header", ImportColumnGroup)
+	}
+	if cols.contactID < 0 && cols.msisdn < 0 {
+		return nil, fmt.Errorf("column %q or %q not found in CSV header", ImportColumnContactID, ImportColumnMSISDN)
+	}
+
+	return cols, nil
+}
+
+func (cols *importColumns) row(line int, record []string) *importRow {
+	get := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	return &importRow{
+		line:      line,
+		group:     get(cols.group),
+		contactID: get(cols.contactID),
+		msisdn:    get(cols.msisdn),
+		firstName: get(cols.firstName),
+		lastName:  get(cols.lastName),
+	}
+}
@@ -0,0 +1,135 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/stretchr/testify/assert"
+)
+
+// importClient serves the requests of Import. Existing groups are listed,
+// contacts are never found and added contacts are recorded per group.
+type importClient struct {
+	existing string
+	created  []string
+	members  map[string][]string
+
+	// failGroup is the ID of a group adding contacts to fails for once.
+	failGroup string
+}
+
+func (c *importClient) Request(v interface{}, method, path string, data interface{}) error {
+	switch {
+	case method == http.MethodGet && strings.HasPrefix(path, "groups?"):
+		return json.Unmarshal([]byte(c.existing), v)
+	case method == http.MethodPost && path == "groups":
+		name := data.(*Request).Name
+		c.created = append(c.created, name)
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"id": "group-%s", "name": %q}`, name, name)), v)
+	case method == http.MethodGet && strings.HasPrefix(path, "contacts?"):
+		return json.Unmarshal([]byte(`{"items": []}`), v)
+	case method == http.MethodPost && path == "contacts":
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"id": "contact-%s"}`, data.(*contact.CreateRequest).MSISDN)), v)
+	case method == http.MethodPut && strings.HasSuffix(path, "/contacts"):
+		groupID := strings.Split(path, "/")[1]
+		if groupID == c.failGroup {
+			c.failGroup = ""
+			return errors.New("service unavailable")
+		}
+		for _, id := range strings.Split(data.(string), "&") {
+			c.members[groupID] = append(c.members[groupID], strings.TrimPrefix(id, "ids[]="))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unexpected %s %s", method, path)
+}
+
+const importCSV = `group,contactId,msisdn,firstName
+customers,contact-a,,
+customers,,+31612345678,Jane
+newsletter,contact-a,,
+newsletter,,not a number,
+,contact-b,,
+`
+
+func TestImport(t *testing.T) {
+	client := &importClient{
+		existing: `{"totalCount": 1, "items": [{"id": "group-existing", "name": "customers"}]}`,
+		members:  make(map[string][]string),
+	}
+
+	progress, err := Import(context.Background(), client, strings.NewReader(importCSV))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"newsletter"}, client.created)
+	assert.Equal(t, map[string]string{"customers": "group-existing", "newsletter": "group-newsletter"}, progress.Groups)
+	assert.Equal(t, map[string][]string{
+		"group-existing":   {"contact-a", "contact-31612345678"},
+		"group-newsletter": {"contact-a"},
+	}, client.members)
+
+	assert.Equal(t, 5, progress.Lines)
+	if assert.Len(t, progress.Failed, 2) {
+		assert.Equal(t, 5, progress.Failed[0].Line)
+		assert.Equal(t, "not a number", progress.Failed[0].Contact)
+		assert.Equal(t, &ImportFailure{Line: 6, Contact: "contact-b", Err: "group is required"}, progress.Failed[1])
+	}
+}
+
+func TestImportInternationalMSISDN(t *testing.T) {
+	client := &importClient{
+		existing: `{"totalCount": 0, "items": []}`,
+		members:  make(map[string][]string),
+	}
+
+	csv := "group,msisdn\ncustomers,31612345678\ncustomers,0031687654321\n"
+	progress, err := Import(context.Background(), client, strings.NewReader(csv))
+	assert.NoError(t, err)
+	assert.Empty(t, progress.Failed)
+	assert.Equal(t, []string{"contact-31612345678", "contact-31687654321"}, client.members["group-customers"])
+}
+
+func TestImportResume(t *testing.T) {
+	client := &importClient{
+		existing:  `{"totalCount": 0, "items": []}`,
+		members:   make(map[string][]string),
+		failGroup: "group-newsletter",
+	}
+
+	progress, err := Import(context.Background(), client, strings.NewReader(importCSV))
+	assert.EqualError(t, err, "adding contacts to group group-newsletter: service unavailable")
+	assert.Zero(t, progress.Lines)
+
+	// The groups created before the error are not created again.
+	b, err := json.Marshal(progress)
+	assert.NoError(t, err)
+	var stored ImportProgress
+	assert.NoError(t, json.Unmarshal(b, &stored))
+
+	progress, err = Resume(context.Background(), client, strings.NewReader(importCSV), &stored)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, progress.Lines)
+	assert.Equal(t, []string{"customers", "newsletter"}, client.created)
+	assert.Equal(t, []string{"contact-a"}, client.members["group-newsletter"])
+
+	// Resuming a finished import does nothing.
+	progress, err = Resume(context.Background(), client, strings.NewReader(importCSV), progress)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"contact-a"}, client.members["group-newsletter"])
+	assert.Len(t, progress.Failed, 2)
+}
+
+func TestImportColumns(t *testing.T) {
+	_, err := Import(context.Background(), &importClient{}, strings.NewReader("name,msisdn\n"))
+	assert.EqualError(t, err, `column "group" not found in CSV header`)
+
+	_, err = Import(context.Background(), &importClient{}, strings.NewReader("group,firstName\n"))
+	assert.EqualError(t, err, `column "contactId" or "msisdn" not found in CSV header`)
+}
@@ -0,0 +1,84 @@
+package group
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+)
+
+// A ContactIterator walks through all contacts in a group, fetching pages from
+// the API as needed.
+//
+//	it := group.IterateContacts(client, groupID, nil)
+//	for it.Next() {
+//		fmt.Println(it.Contact().MSISDN)
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle the error.
+//	}
+//
+// Iterators are single use and can therefore not be reset.
+type ContactIterator struct {
+	client  messagebird.Client
+	groupID string
+	options messagebird.PaginationRequest
+	page    []contact.Contact
+	contact *contact.Contact
+	pager   paging.Pager
+}
+
+// IterateContacts returns a ContactIterator over the contacts in the group,
+// starting at options.Offset and requesting options.Limit contacts per page.
+// If options is nil, messagebird.DefaultPagination is used.
+func IterateContacts(c messagebird.Client, groupID string, options *messagebird.PaginationRequest) *ContactIterator {
+	if options == nil {
+		options = messagebird.DefaultPagination
+	}
+
+	return &ContactIterator{
+		client:  c,
+		groupID: groupID,
+		options: *options,
+		pager:   paging.Pager{Offset: options.Offset},
+	}
+}
+
+// Next advances the iterator to the next contact, which is then available
+// through Contact. It returns false when no more contacts are available or an
+// error occurred.
+func (it *ContactIterator) Next() bool {
+	if len(it.page) == 0 {
+		it.fetch()
+	}
+
+	if len(it.page) == 0 {
+		it.contact = nil
+		return false
+	}
+
+	it.contact, it.page = &it.page[0], it.page[1:]
+	return true
+}
+
+// Contact returns the contact the iterator currently points at.
+func (it *ContactIterator) Contact() *contact.Contact {
+	return it.contact
+}
+
+// Err returns the first error that occurred while fetching contacts, if any.
+func (it *ContactIterator) Err() error {
+	return it.pager.Err()
+}
+
+func (it *ContactIterator) fetch() {
+	it.pager.Fetch(func(offset int) (int, int, error) {
+		it.options.Offset = offset
+		contacts, err := ListContacts(it.client, it.groupID, &it.options)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		it.page = contacts.Items
+		return len(contacts.Items), contacts.TotalCount, nil
+	})
+}
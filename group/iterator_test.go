@@ -0,0 +1,55 @@
+package group
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterateContacts(t *testing.T) {
+	var queries []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		items := fmt.Sprintf(`{"id":"contact-%d"}`, offset)
+		if offset == 0 {
+			items += `,{"id":"contact-1"}`
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"offset":%d,"limit":2,"totalCount":3,"items":[%s]}`, offset, items)
+	}))
+	defer stop()
+
+	client := mbtest.Client(t)
+	client.HTTPClient.Transport = transport
+
+	it := IterateContacts(client, "group-id", &messagebird.PaginationRequest{Limit: 2})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Contact().ID)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"contact-0", "contact-1", "contact-2"}, ids)
+	assert.Equal(t, []string{
+		"/groups/group-id/contacts?limit=2",
+		"/groups/group-id/contacts?limit=2&offset=2",
+	}, queries)
+}
+
+func TestIterateContactsError(t *testing.T) {
+	mbtest.WillReturnAccessKeyError()
+	client := mbtest.Client(t)
+
+	it := IterateContacts(client, "group-id", nil)
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+}
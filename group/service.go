@@ -0,0 +1,70 @@
+package group
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/contact"
+)
+
+// Service is the Groups API bound to a client, see sms.Service.
+type Service interface {
+	Create(request *Request) (*Group, error)
+	Delete(id string) error
+	List(options *messagebird.PaginationRequest) (*Groups, error)
+	Read(id string) (*Group, error)
+	Update(id string, request *Request) error
+	AddContacts(groupID string, contactIDs []string) error
+	RemoveContacts(groupID string, contactIDs []string) error
+	ListContacts(groupID string, options *messagebird.PaginationRequest) (*contact.Contacts, error)
+	RemoveContact(groupID, contactID string) error
+	IterateContacts(groupID string, options *messagebird.PaginationRequest) *ContactIterator
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Create(request *Request) (*Group, error) {
+	return Create(s.c, request)
+}
+
+func (s *service) Delete(id string) error {
+	return Delete(s.c, id)
+}
+
+func (s *service) List(options *messagebird.PaginationRequest) (*Groups, error) {
+	return List(s.c, options)
+}
+
+func (s *service) Read(id string) (*Group, error) {
+	return Read(s.c, id)
+}
+
+func (s *service) Update(id string, request *Request) error {
+	return Update(s.c, id, request)
+}
+
+func (s *service) AddContacts(groupID string, contactIDs []string) error {
+	return AddContacts(s.c, groupID, contactIDs)
+}
+
+func (s *service) RemoveContacts(groupID string, contactIDs []string) error {
+	return RemoveContacts(s.c, groupID, contactIDs)
+}
+
+func (s *service) ListContacts(groupID string, options *messagebird.PaginationRequest) (*contact.Contacts, error) {
+	return ListContacts(s.c, groupID, options)
+}
+
+func (s *service) RemoveContact(groupID, contactID string) error {
+	return RemoveContact(s.c, groupID, contactID)
+}
+
+func (s *service) IterateContacts(groupID string, options *messagebird.PaginationRequest) *ContactIterator {
+	return IterateContacts(s.c, groupID, options)
+}
@@ -11,6 +11,26 @@ import (
 // path represents the path to the HLR resource.
 const path = "hlr"
 
+// Possible values of HLR.Status.
+const (
+	// StatusSent indicates the HLR request was sent and no answer has been
+	// received yet.
+	StatusSent = "sent"
+
+	// StatusAbsent indicates the subscriber is known but currently not
+	// reachable.
+	StatusAbsent = "absent"
+
+	// StatusActive indicates the subscriber is known and reachable.
+	StatusActive = "active"
+
+	// StatusUnknown indicates the subscriber is unknown to the network.
+	StatusUnknown = "unknown"
+
+	// StatusFailed indicates the HLR request could not be completed.
+	StatusFailed = "failed"
+)
+
 // HLR stands for Home Location Register. Contains information about the
 // subscribers identity, telephone number, the associated services and general
 // information about the location of the subscriber.
@@ -36,6 +56,12 @@ type HLRList struct {
 	Items      []HLR
 }
 
+// IsFinal reports whether the HLR has reached a status that will not change
+// anymore.
+func (h *HLR) IsFinal() bool {
+	return h.Status != "" && h.Status != StatusSent
+}
+
 type hlrRequest struct {
 	MSISDN    string `json:"msisdn"`
 	Reference string `json:"reference"`
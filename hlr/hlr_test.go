@@ -82,3 +82,11 @@ func TestList(t *testing.T) {
 		assertHLRObject(t, &hlr)
 	}
 }
+
+func TestIsFinal(t *testing.T) {
+	assert.False(t, (&HLR{}).IsFinal())
+	assert.False(t, (&HLR{Status: StatusSent}).IsFinal())
+	assert.True(t, (&HLR{Status: StatusActive}).IsFinal())
+	assert.True(t, (&HLR{Status: StatusAbsent}).IsFinal())
+	assert.True(t, (&HLR{Status: StatusFailed}).IsFinal())
+}
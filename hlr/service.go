@@ -0,0 +1,34 @@
+package hlr
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Service is the HLR API bound to a client, see sms.Service.
+type Service interface {
+	Read(id string) (*HLR, error)
+	List() (*HLRList, error)
+	Create(msisdn string, reference string) (*HLR, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Read(id string) (*HLR, error) {
+	return Read(s.c, id)
+}
+
+func (s *service) List() (*HLRList, error) {
+	return List(s.c)
+}
+
+func (s *service) Create(msisdn string, reference string) (*HLR, error) {
+	return Create(s.c, msisdn, reference)
+}
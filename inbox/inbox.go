@@ -0,0 +1,224 @@
+// Package inbox presents the SMS messages sent and received on your
+// purchased numbers as threads per remote MSISDN, without the Conversations
+// API. Received messages come in through the inbound SMS webhook, or by
+// polling the API, and are kept in a pluggable Store:
+//
+//	in := &inbox.Inbox{Client: client, Store: store}
+//	d := &webhooks.Dispatcher{OnSMSInbound: in.Receive}
+//
+//	threads, err := in.Threads(ctx, "3197000000")
+//	_, err = in.Send(ctx, "3197000000", threads[0].Remote, "Thanks, see you then!")
+package inbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+	"github.com/messagebird/go-rest-api/v9/number"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+// Directions of a Message.
+const (
+	DirectionReceived = "received"
+	DirectionSent     = "sent"
+)
+
+// DefaultPollLimit is the number of messages Poll reads per request when
+// the Inbox has no PollLimit.
+const DefaultPollLimit = 50
+
+// Message is an SMS message sent or received on one of your numbers.
+type Message struct {
+	ID string
+
+	// Number is your number, and Remote the MSISDN of the other party.
+	Number string
+	Remote string
+
+	Direction       string
+	Body            string
+	CreatedDatetime time.Time
+}
+
+// Thread is the messages between one of your numbers and a remote MSISDN.
+type Thread struct {
+	Number string
+	Remote string
+
+	// Last is the most recent message of the thread.
+	Last *Message
+
+	// Messages is the number of messages in the thread.
+	Messages int
+}
+
+// Inbox stores the messages of your numbers in Store.
+type Inbox struct {
+	Client messagebird.Client
+	Store  Store
+
+	// PollLimit is the number of messages Poll reads per request.
+	// DefaultPollLimit is used if it is zero.
+	PollLimit int
+}
+
+// Numbers returns your purchased numbers that can send and receive SMS.
+func (in *Inbox) Numbers() ([]*number.Number, error) {
+	var numbers []*number.Number
+
+	it := number.Iterate(in.Client, &number.ListRequest{Features: number.FeatureNames(number.FeatureSMS)})
+	for it.Next() {
+		numbers = append(numbers, it.Number())
+	}
+
+	return numbers, it.Err()
+}
+
+// Receive stores a message received through the inbound SMS webhook. Its
+// signature matches webhooks.Dispatcher.OnSMSInbound.
+func (in *Inbox) Receive(ctx context.Context, m *sms.InboundMessage) error {
+	message := &Message{
+		ID:        m.ID,
+		Number:    normalize(m.Recipient),
+		Remote:    normalize(m.Originator),
+		Direction: DirectionReceived,
+		Body:      m.Body,
+	}
+	if m.CreatedDatetime != nil {
+		message.CreatedDatetime = *m.CreatedDatetime
+	} else {
+		message.CreatedDatetime = messagebird.ClockOf(in.Client).Now()
+	}
+
+	_, err := in.Store.Add(ctx, message)
+	return err
+}
+
+// Poll reads the messages received on your numbers from the API, newest
+// first, until it reaches a message that is stored already or the oldest
+// message. It returns the number of messages stored. Poll can be used
+// instead of, or to catch up with messages missed by, the webhook.
+func (in *Inbox) Poll(ctx context.Context) (int, error) {
+	limit := in.PollLimit
+	if limit <= 0 {
+		limit = DefaultPollLimit
+	}
+
+	added := 0
+	done := false
+	var pager paging.Pager
+	for !pager.Done() && !done {
+		if err := ctx.Err(); err != nil {
+			return added, err
+		}
+
+		pager.Fetch(func(offset int) (int, int, error) {
+			list, err := sms.List(in.Client, &sms.ListParams{Direction: "mo", Limit: limit, Offset: offset})
+			if err != nil {
+				return 0, 0, fmt.Errorf("listing received messages: %w", err)
+			}
+
+			// A short page is the last one.
+			done = len(list.Items) < limit
+			for i := range list.Items {
+				message := received(&list.Items[i])
+				if message == nil {
+					continue
+				}
+
+				ok, err := in.Store.Add(ctx, message)
+				if err != nil {
+					return 0, 0, err
+				}
+				if !ok {
+					done = true
+					break
+				}
+				added++
+			}
+
+			return len(list.Items), list.TotalCount, nil
+		})
+	}
+
+	return added, pager.Err()
+}
+
+// received converts a message listed by the API to a received Message, or
+// returns nil if it has no recipient.
+func received(m *sms.Message) *Message {
+	if len(m.Recipients.Items) == 0 {
+		return nil
+	}
+
+	message := &Message{
+		ID:        m.ID,
+		Number:    strconv.FormatInt(m.Recipients.Items[0].Recipient, 10),
+		Remote:    normalize(m.Originator),
+		Direction: DirectionReceived,
+		Body:      m.Body,
+	}
+	if m.CreatedDatetime != nil {
+		message.CreatedDatetime = *m.CreatedDatetime
+	}
+
+	return message
+}
+
+// Send sends body from your number num to remote and stores the message in
+// the thread.
+func (in *Inbox) Send(ctx context.Context, num, remote, body string) (*Message, error) {
+	num, remote = normalize(num), normalize(remote)
+	if num == "" || remote == "" {
+		return nil, errors.New("number and remote are required")
+	}
+
+	sent, err := sms.Create(in.Client, num, []string{remote}, body, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Message{
+		ID:              sent.ID,
+		Number:          num,
+		Remote:          remote,
+		Direction:       DirectionSent,
+		Body:            body,
+		CreatedDatetime: messagebird.ClockOf(in.Client).Now(),
+	}
+	if sent.CreatedDatetime != nil {
+		message.CreatedDatetime = *sent.CreatedDatetime
+	}
+
+	if _, err := in.Store.Add(ctx, message); err != nil {
+		return message, err
+	}
+
+	return message, nil
+}
+
+// Threads returns the threads of your number num, with the most recent
+// message first.
+func (in *Inbox) Threads(ctx context.Context, num string) ([]*Thread, error) {
+	return in.Store.Threads(ctx, normalize(num))
+}
+
+// Thread returns the messages between your number num and remote, oldest
+// first.
+func (in *Inbox) Thread(ctx context.Context, num, remote string) ([]*Message, error) {
+	return in.Store.Messages(ctx, normalize(num), normalize(remote))
+}
+
+// normalize removes the + and spaces from MSISDNs, so the number formats of
+// webhooks, the API and callers thread together. Alphanumeric originators
+// are kept as they are.
+func normalize(msisdn string) string {
+	return strings.TrimPrefix(strings.ReplaceAll(strings.TrimSpace(msisdn), " ", ""), "+")
+}
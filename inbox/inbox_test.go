@@ -0,0 +1,222 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/encryption"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+// inboxClient serves the requests of an Inbox. received is the list of
+// received messages, newest first, of which pages are returned.
+type inboxClient struct {
+	received []string
+	lists    int
+	sent     []map[string]interface{}
+}
+
+func (c *inboxClient) Request(v interface{}, method, path string, data interface{}) error {
+	switch {
+	case method == http.MethodGet && strings.Contains(path, "phone-numbers?"):
+		return json.Unmarshal([]byte(`{"items": [{"number": "3197000000", "features": ["sms"]}], "totalCount": 1}`), v)
+	case method == http.MethodGet && strings.HasPrefix(path, "messages?"):
+		c.lists++
+
+		var offset, limit int
+		for _, param := range strings.Split(strings.TrimPrefix(path, "messages?"), "&") {
+			fmt.Sscanf(param, "offset=%d", &offset)
+			fmt.Sscanf(param, "limit=%d", &limit)
+		}
+		end := offset + limit
+		if end > len(c.received) {
+			end = len(c.received)
+		}
+
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"totalCount": %d, "items": [%s]}`, len(c.received), strings.Join(c.received[offset:end], ","))), v)
+	case method == http.MethodPost && path == "messages":
+		var req map[string]interface{}
+		b, _ := json.Marshal(data)
+		if err := json.Unmarshal(b, &req); err != nil {
+			return err
+		}
+		c.sent = append(c.sent, req)
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"id": "sent-%d", "createdDatetime": "2026-01-02T10:00:00Z"}`, len(c.sent))), v)
+	}
+
+	return fmt.Errorf("unexpected %s %s", method, path)
+}
+
+func receivedJSON(id, originator, created string) string {
+	return fmt.Sprintf(`{"id": %q, "direction": "mo", "originator": %q, "body": "Hi", "createdDatetime": %q, "recipients": {"items": [{"recipient": 3197000000}]}}`, id, originator, created)
+}
+
+func TestReceiveAndThreads(t *testing.T) {
+	ctx := context.Background()
+	in := &Inbox{Client: &inboxClient{}, Store: NewMemoryStore()}
+
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 1, d, 9, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	assert.NoError(t, in.Receive(ctx, &sms.InboundMessage{ID: "a1", Originator: "+31612345678", Recipient: "3197000000", Body: "Hello", CreatedDatetime: day(1)}))
+	assert.NoError(t, in.Receive(ctx, &sms.InboundMessage{ID: "b1", Originator: "31687654321", Recipient: "+31 97000000", Body: "Hi", CreatedDatetime: day(2)}))
+	assert.NoError(t, in.Receive(ctx, &sms.InboundMessage{ID: "a2", Originator: "31612345678", Recipient: "3197000000", Body: "Still there?", CreatedDatetime: day(3)}))
+	assert.NoError(t, in.Receive(ctx, &sms.InboundMessage{ID: "a2", Originator: "31612345678", Recipient: "3197000000", Body: "Still there?", CreatedDatetime: day(3)}))
+
+	threads, err := in.Threads(ctx, "+3197000000")
+	assert.NoError(t, err)
+	if assert.Len(t, threads, 2) {
+		assert.Equal(t, "31612345678", threads[0].Remote)
+		assert.Equal(t, 2, threads[0].Messages)
+		assert.Equal(t, "a2", threads[0].Last.ID)
+		assert.Equal(t, "31687654321", threads[1].Remote)
+	}
+
+	messages, err := in.Thread(ctx, "3197000000", "+31612345678")
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "a1", messages[0].ID)
+		assert.Equal(t, DirectionReceived, messages[0].Direction)
+		assert.Equal(t, "a2", messages[1].ID)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	ctx := context.Background()
+	client := &inboxClient{received: []string{
+		receivedJSON("m3", "31612345678", "2026-01-03T09:00:00Z"),
+		receivedJSON("m2", "31687654321", "2026-01-02T09:00:00Z"),
+		receivedJSON("m1", "31612345678", "2026-01-01T09:00:00Z"),
+	}}
+	in := &Inbox{Client: client, Store: NewMemoryStore(), PollLimit: 2}
+
+	added, err := in.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, added)
+	assert.Equal(t, 2, client.lists)
+
+	messages, err := in.Thread(ctx, "3197000000", "31612345678")
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "m1", messages[0].ID)
+		assert.Equal(t, "m3", messages[1].ID)
+	}
+
+	// Polling again stops at the first message stored already.
+	client.lists = 0
+	client.received = append([]string{receivedJSON("m4", "31687654321", "2026-01-04T09:00:00Z")}, client.received...)
+
+	added, err = in.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, client.lists)
+}
+
+func TestSend(t *testing.T) {
+	ctx := context.Background()
+	client := &inboxClient{}
+	in := &Inbox{Client: client, Store: NewMemoryStore()}
+
+	assert.NoError(t, in.Receive(ctx, &sms.InboundMessage{ID: "a1", Originator: "31612345678", Recipient: "3197000000", Body: "Hello"}))
+
+	message, err := in.Send(ctx, "+3197000000", "+31612345678", "Hi there")
+	assert.NoError(t, err)
+	assert.Equal(t, "sent-1", message.ID)
+	assert.Equal(t, DirectionSent, message.Direction)
+	assert.Equal(t, time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), message.CreatedDatetime)
+
+	if assert.Len(t, client.sent, 1) {
+		assert.Equal(t, "3197000000", client.sent[0]["originator"])
+		assert.Equal(t, []interface{}{"31612345678"}, client.sent[0]["recipients"])
+	}
+
+	messages, err := in.Thread(ctx, "3197000000", "31612345678")
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+
+	_, err = in.Send(ctx, "3197000000", "", "Hi")
+	assert.EqualError(t, err, "number and remote are required")
+}
+
+func TestNumbers(t *testing.T) {
+	in := &Inbox{Client: &inboxClient{}, Store: NewMemoryStore()}
+
+	numbers, err := in.Numbers()
+	assert.NoError(t, err)
+	if assert.Len(t, numbers, 1) {
+		assert.Equal(t, "3197000000", numbers[0].Number)
+	}
+}
+
+func TestEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	key, err := encryption.GenerateKey()
+	assert.NoError(t, err)
+	cipher, err := encryption.NewAESGCM(key)
+	assert.NoError(t, err)
+
+	memory := NewMemoryStore()
+	store := &EncryptedStore{Store: memory, Cipher: cipher}
+
+	m := &Message{ID: "1", Number: "3197001", Remote: "31612345678", Body: "secret", CreatedDatetime: now}
+	added, err := store.Add(ctx, m)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, "secret", m.Body, "the message is not changed")
+
+	added, err = store.Add(ctx, m)
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	stored, err := memory.Messages(ctx, "3197001", "31612345678")
+	assert.NoError(t, err)
+	assert.True(t, encryption.IsSealed(stored[0].Body))
+
+	messages, err := store.Messages(ctx, "3197001", "31612345678")
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 1) {
+		assert.Equal(t, "secret", messages[0].Body)
+	}
+
+	threads, err := store.Threads(ctx, "3197001")
+	assert.NoError(t, err)
+	if assert.Len(t, threads, 1) {
+		assert.Equal(t, "secret", threads[0].Last.Body)
+	}
+
+	// A message added without encryption is reported, without hiding the
+	// others, unless plaintext is allowed.
+	var logged []error
+	store.ErrorLog = func(err error) { logged = append(logged, err) }
+	_, err = memory.Add(ctx, &Message{ID: "2", Number: "3197001", Remote: "31612345678", Body: "plain", CreatedDatetime: now.Add(time.Minute)})
+	assert.NoError(t, err)
+
+	messages, err = store.Messages(ctx, "3197001", "31612345678")
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "secret", messages[0].Body)
+		assert.Empty(t, messages[1].Body)
+	}
+	if assert.Len(t, logged, 1) {
+		assert.True(t, errors.Is(logged[0], encryption.ErrNotSealed))
+		assert.EqualError(t, logged[0], "message 2: content is not encrypted")
+	}
+
+	store.AllowPlaintext = true
+	threads, err = store.Threads(ctx, "3197001")
+	assert.NoError(t, err)
+	if assert.Len(t, threads, 1) {
+		assert.Equal(t, "plain", threads[0].Last.Body)
+	}
+}
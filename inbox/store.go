@@ -0,0 +1,189 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/messagebird/go-rest-api/v9/encryption"
+)
+
+// Store persists the messages of an Inbox. Implementations backed by a
+// database only need to store messages by ID and find them by number and
+// remote MSISDN. They must be safe for concurrent use.
+type Store interface {
+	// Add stores m. It reports false, and stores nothing, if a message with
+	// the same ID is stored already.
+	Add(ctx context.Context, m *Message) (bool, error)
+
+	// Messages returns the messages between number and remote, oldest
+	// first.
+	Messages(ctx context.Context, number, remote string) ([]*Message, error)
+
+	// Threads returns the threads of number, with the most recent message
+	// first.
+	Threads(ctx context.Context, number string) ([]*Thread, error)
+}
+
+// MemoryStore is a Store that keeps messages in memory. Messages do not
+// survive restarts, so it is only suitable for tests.
+type MemoryStore struct {
+	mu       sync.Mutex
+	ids      map[string]bool
+	messages map[threadKey][]*Message
+}
+
+type threadKey struct {
+	number, remote string
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		ids:      make(map[string]bool),
+		messages: make(map[threadKey][]*Message),
+	}
+}
+
+// Add implements Store.
+func (s *MemoryStore) Add(_ context.Context, m *Message) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ids[m.ID] {
+		return false, nil
+	}
+	s.ids[m.ID] = true
+
+	copied := *m
+	key := threadKey{m.Number, m.Remote}
+	messages := append(s.messages[key], &copied)
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedDatetime.Before(messages[j].CreatedDatetime)
+	})
+	s.messages[key] = messages
+
+	return true, nil
+}
+
+// Messages implements Store.
+func (s *MemoryStore) Messages(_ context.Context, number, remote string) ([]*Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copyMessages(s.messages[threadKey{number, remote}]), nil
+}
+
+// Threads implements Store.
+func (s *MemoryStore) Threads(_ context.Context, number string) ([]*Thread, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var threads []*Thread
+	for key, messages := range s.messages {
+		if key.number != number {
+			continue
+		}
+
+		last := *messages[len(messages)-1]
+		threads = append(threads, &Thread{
+			Number:   key.number,
+			Remote:   key.remote,
+			Last:     &last,
+			Messages: len(messages),
+		})
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		return threads[i].Last.CreatedDatetime.After(threads[j].Last.CreatedDatetime)
+	})
+
+	return threads, nil
+}
+
+// EncryptedStore is a Store that encrypts the Body of messages with Cipher
+// before they are added to Store, and decrypts them when they are read. The
+// other fields are needed to find messages, and are stored as is. The
+// encrypted body is bound to the ID of its message.
+//
+// Messages that can not be decrypted, e.g. because their key was removed or
+// they were changed or moved to another ID, are returned with an empty Body and reported to
+// ErrorLog, so they do not hide the rest of the thread.
+type EncryptedStore struct {
+	Store  Store
+	Cipher encryption.Cipher
+
+	// AllowPlaintext returns messages added before encryption was enabled
+	// unchanged. Only enable it while migrating a store: anyone who can
+	// write to the store can add messages while it is enabled.
+	AllowPlaintext bool
+
+	// ErrorLog, if set, is called for every message that can not be
+	// decrypted.
+	ErrorLog func(err error)
+}
+
+// Add implements Store.
+func (s *EncryptedStore) Add(ctx context.Context, m *Message) (bool, error) {
+	body, err := encryption.Seal(s.Cipher, m.Body, m.ID)
+	if err != nil {
+		return false, err
+	}
+
+	copied := *m
+	copied.Body = body
+	return s.Store.Add(ctx, &copied)
+}
+
+// Messages implements Store.
+func (s *EncryptedStore) Messages(ctx context.Context, number, remote string) ([]*Message, error) {
+	messages, err := s.Store.Messages(ctx, number, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range messages {
+		s.open(m)
+	}
+
+	return messages, nil
+}
+
+// Threads implements Store.
+func (s *EncryptedStore) Threads(ctx context.Context, number string) ([]*Thread, error) {
+	threads, err := s.Store.Threads(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range threads {
+		s.open(t.Last)
+	}
+
+	return threads, nil
+}
+
+// open decrypts the body of m in place.
+func (s *EncryptedStore) open(m *Message) {
+	if s.AllowPlaintext && !encryption.IsSealed(m.Body) {
+		return
+	}
+
+	body, err := encryption.Open(s.Cipher, m.Body, m.ID)
+	if err != nil && s.ErrorLog != nil {
+		s.ErrorLog(fmt.Errorf("message %s: %w", m.ID, err))
+	}
+
+	m.Body = body
+}
+
+func copyMessages(messages []*Message) []*Message {
+	copied := make([]*Message, 0, len(messages))
+	for _, m := range messages {
+		c := *m
+		copied = append(copied, &c)
+	}
+
+	return copied
+}
@@ -0,0 +1,289 @@
+// Package contract checks the JSON fixtures in the testdata directories of
+// this module against the API. Every fixture is validated against a JSON
+// Schema in the schemas directory, which describes the API, and decoded into
+// the struct the SDK uses for it, to find fields the struct silently drops.
+//
+// Only the part of JSON Schema the schemas need is supported: type,
+// properties, required, items, enum and $ref to other schema files.
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Schema is a JSON Schema.
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       typeList           `json:"type"`
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *Schema            `json:"items"`
+	Enum       []interface{}      `json:"enum"`
+}
+
+// typeList is the type keyword, which is a string or a list of strings.
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = typeList{s}
+		return nil
+	}
+
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+// Schemas loads schemas from a directory. $ref values are file names in that
+// directory.
+type Schemas struct {
+	dir   string
+	cache map[string]*Schema
+}
+
+// NewSchemas returns the Schemas in dir.
+func NewSchemas(dir string) *Schemas {
+	return &Schemas{dir: dir, cache: make(map[string]*Schema)}
+}
+
+// Load returns the schema in file name.
+func (s *Schemas) Load(name string) (*Schema, error) {
+	if schema, ok := s.cache[name]; ok {
+		return schema, nil
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{}
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, fmt.Errorf("decoding schema %s: %w", name, err)
+	}
+	s.cache[name] = schema
+
+	return schema, nil
+}
+
+// Validate validates the JSON document doc against the schema in file name.
+// It returns a description of every violation.
+func (s *Schemas) Validate(name string, doc []byte) ([]string, error) {
+	schema, err := s.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	if err := s.validate(schema, v, "$", &violations); err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
+
+func (s *Schemas) validate(schema *Schema, v interface{}, path string, violations *[]string) error {
+	if schema.Ref != "" {
+		ref, err := s.Load(schema.Ref)
+		if err != nil {
+			return err
+		}
+		return s.validate(ref, v, path, violations)
+	}
+
+	fail := func(format string, args ...interface{}) {
+		*violations = append(*violations, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if len(schema.Type) > 0 && !schema.Type.matches(v) {
+		fail("expected %s, got %s", strings.Join(schema.Type, " or "), jsonType(v))
+		return nil
+	}
+
+	if len(schema.Enum) > 0 && !contains(schema.Enum, v) {
+		fail("%v is not one of %v", v, schema.Enum)
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			if property, ok := schema.Properties[name]; ok {
+				if err := s.validate(property, v[name], path+"."+name, violations); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range v {
+				if err := s.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), violations); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t typeList) matches(v interface{}) bool {
+	actual := jsonType(v)
+	for _, expected := range t {
+		if expected == actual || (expected == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", v)
+}
+
+func contains(values []interface{}, v interface{}) bool {
+	for _, value := range values {
+		if reflect.DeepEqual(value, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DroppedFields decodes the JSON document doc into v, encodes v again and
+// returns the paths of the fields of doc that are missing from the result.
+// Those fields are not available to users of the SDK. Fields with empty
+// values, like null or "", are ignored, as they may be omitted when encoding.
+// Names are compared case-insensitively, like encoding/json does when
+// decoding.
+func DroppedFields(doc []byte, v interface{}) ([]string, error) {
+	if err := json.Unmarshal(doc, v); err != nil {
+		return nil, fmt.Errorf("decoding into %T: %w", v, err)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %T: %w", v, err)
+	}
+
+	var expected, actual interface{}
+	if err := json.Unmarshal(doc, &expected); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &actual); err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	compare(expected, actual, "$", &dropped)
+
+	return dropped, nil
+}
+
+func compare(expected, actual interface{}, path string, dropped *[]string) {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !ok {
+			// The struct decodes the object into something else, like a
+			// string or a custom type: it is not dropped.
+			return
+		}
+		for _, name := range sortedKeys(expected) {
+			value, ok := lookup(actual, name)
+			if !ok {
+				if !isEmpty(expected[name]) {
+					*dropped = append(*dropped, path+"."+name)
+				}
+				continue
+			}
+			compare(expected[name], value, path+"."+name, dropped)
+		}
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !ok {
+			return
+		}
+		for i := 0; i < len(expected) && i < len(actual); i++ {
+			compare(expected[i], actual[i], fmt.Sprintf("%s[%d]", path, i), dropped)
+		}
+	}
+}
+
+func lookup(m map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+
+	for key, v := range m {
+		if strings.EqualFold(key, name) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,292 @@
+package contract_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/capabilities"
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/email"
+	"github.com/messagebird/go-rest-api/v9/emailverify"
+	"github.com/messagebird/go-rest-api/v9/files"
+	"github.com/messagebird/go-rest-api/v9/flows"
+	"github.com/messagebird/go-rest-api/v9/group"
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/internal/contract"
+	"github.com/messagebird/go-rest-api/v9/lookup"
+	"github.com/messagebird/go-rest-api/v9/messaging"
+	"github.com/messagebird/go-rest-api/v9/mms"
+	"github.com/messagebird/go-rest-api/v9/number"
+	"github.com/messagebird/go-rest-api/v9/partner_accounts"
+	"github.com/messagebird/go-rest-api/v9/reporting"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/tendlc"
+	"github.com/messagebird/go-rest-api/v9/transaction"
+	"github.com/messagebird/go-rest-api/v9/verify"
+	"github.com/messagebird/go-rest-api/v9/voicemessage"
+	"github.com/messagebird/go-rest-api/v9/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+// moduleRoot is the root of this module, relative to this package.
+const moduleRoot = "../.."
+
+type fixture struct {
+	// schema is the file in the schemas directory the fixture must conform
+	// to.
+	schema string
+
+	// decode returns the value the SDK decodes the fixture into.
+	decode func() interface{}
+}
+
+// fixtures lists the API responses in the testdata directories of this
+// module, relative to its root.
+var fixtures = map[string]fixture{
+	"balance/testdata/balance.json":                                    {"balance.json", func() interface{} { return &balance.Balance{} }},
+	"balance/testdata/balancePostpaid.json":                            {"balance.json", func() interface{} { return &balance.Balance{} }},
+	"messagebirdtest/testdata/balance.json":                            {"balance.json", func() interface{} { return &balance.Balance{} }},
+	"contact/testdata/contactObject.json":                              {"contact.json", func() interface{} { return &contact.Contact{} }},
+	"contact/testdata/contactObjectWithCustomDetails.json":             {"contact.json", func() interface{} { return &contact.Contact{} }},
+	"contact/testdata/contactListObject.json":                          {"contactList.json", func() interface{} { return &contact.Contacts{} }},
+	"group/testdata/groupContactListObject.json":                       {"contactList.json", func() interface{} { return &contact.Contacts{} }},
+	"group/testdata/groupObject.json":                                  {"group.json", func() interface{} { return &group.Group{} }},
+	"group/testdata/groupListObject.json":                              {"groupList.json", func() interface{} { return &group.Groups{} }},
+	"contact/testdata/contactGroupListObject.json":                     {"groupList.json", func() interface{} { return &group.Groups{} }},
+	"conversation/testdata/conversationObject.json":                    {"conversation.json", func() interface{} { return &conversation.Conversation{} }},
+	"conversation/testdata/conversationUpdatedObject.json":             {"conversation.json", func() interface{} { return &conversation.Conversation{} }},
+	"conversation/testdata/allConversationListObject.json":             {"conversationList.json", func() interface{} { return &conversation.Conversations{} }},
+	"conversation/testdata/conversationListObject.json":                {"conversationList.json", func() interface{} { return &conversation.Conversations{} }},
+	"conversation/testdata/conversationListByContact.json":             {"conversationListByContact.json", func() interface{} { return &conversation.ConversationsByContact{} }},
+	"conversation/testdata/contact.json":                               {"conversationContact.json", func() interface{} { return &conversation.Contact{} }},
+	"conversation/testdata/messageObject.json":                         {"conversationMessage.json", func() interface{} { return &conversation.Message{} }},
+	"conversation/testdata/messageSendResponse.json":                   {"conversationMessage.json", func() interface{} { return &conversation.Message{} }},
+	"conversation/testdata/allMessageListObject.json":                  {"conversationMessageList.json", func() interface{} { return &conversation.MessageList{} }},
+	"conversation/testdata/messageListObject.json":                     {"conversationMessageList.json", func() interface{} { return &conversation.MessageList{} }},
+	"conversation/testdata/webhookObject.json":                         {"conversationWebhook.json", func() interface{} { return &conversation.Webhook{} }},
+	"conversation/testdata/webhookUpdatedObject.json":                  {"conversationWebhook.json", func() interface{} { return &conversation.Webhook{} }},
+	"conversation/testdata/allWebhookListObject.json":                  {"conversationWebhookList.json", func() interface{} { return &conversation.WebhookList{} }},
+	"conversation/testdata/webhookListObject.json":                     {"conversationWebhookList.json", func() interface{} { return &conversation.WebhookList{} }},
+	"webhooks/testdata/conversation.json":                              {"conversationEvent.json", func() interface{} { return &webhooks.ConversationEvent{} }},
+	"hlr/testdata/hlrObject.json":                                      {"hlr.json", func() interface{} { return &hlr.HLR{} }},
+	"lookup/testdata/lookupHLRObject.json":                             {"hlr.json", func() interface{} { return &hlr.HLR{} }},
+	"hlr/testdata/hlrListObject.json":                                  {"hlrList.json", func() interface{} { return &hlr.HLRList{} }},
+	"lookup/testdata/lookupObject.json":                                {"lookup.json", func() interface{} { return &lookup.Lookup{} }},
+	"mms/testdata/mmsMessageObject.json":                               {"mmsMessage.json", func() interface{} { return &mms.Message{} }},
+	"mms/testdata/mmsMessageListObject.json":                           {"mmsMessageList.json", func() interface{} { return &mms.MessageList{} }},
+	"number/testdata/numberObject.json":                                {"number.json", func() interface{} { return &number.Number{} }},
+	"number/testdata/numberRead.json":                                  {"number.json", func() interface{} { return &number.Number{} }},
+	"number/testdata/numberUpdatedObject.json":                         {"number.json", func() interface{} { return &number.Number{} }},
+	"number/testdata/numberCreateObject.json":                          {"number.json", func() interface{} { return &number.Number{} }},
+	"number/testdata/numberList.json":                                  {"numberList.json", func() interface{} { return &number.Numbers{} }},
+	"number/testdata/numberSearch.json":                                {"numberSearch.json", func() interface{} { return &number.NumbersSearching{} }},
+	"number/testdata/addNumberToPool.json":                             {"poolNumbersResult.json", func() interface{} { return &number.AddNumberToPollResult{} }},
+	"number/testdata/createPool.json":                                  {"pool.json", func() interface{} { return &number.Pool{} }},
+	"number/testdata/listPool.json":                                    {"poolList.json", func() interface{} { return &number.Pools{} }},
+	"number/testdata/listPoolNumbers.json":                             {"poolNumbers.json", func() interface{} { return &number.PoolNumbers{} }},
+	"number/testdata/readBackorder.json":                               {"backorder.json", func() interface{} { return &number.Backorder{} }},
+	"number/testdata/placeBackorder.json":                              {"backorder.json", func() interface{} { return &number.Backorder{} }},
+	"number/testdata/listBackorders.json":                              {"backorderList.json", func() interface{} { return &number.Backorders{} }},
+	"number/testdata/listBackorderDocuments.json":                      {"backorderDocuments.json", func() interface{} { return &number.BackorderDocuments{} }},
+	"number/testdata/listBackorderEndUserDetails.json":                 {"backorderEndUserDetails.json", func() interface{} { return &number.EndUserDetails{} }},
+	"number/testdata/readProduct.json":                                 {"product.json", func() interface{} { return &number.Product{} }},
+	"number/testdata/searchProducts.json":                              {"productList.json", func() interface{} { return &number.Products{} }},
+	"partner_accounts/testdata/createAccessKeyResponse.json":           {"accessKey.json", func() interface{} { return &partner_accounts.AccessKey{} }},
+	"partner_accounts/testdata/listAccessKeysResponse.json":            {"accessKeyList.json", func() interface{} { return &[]partner_accounts.AccessKey{} }},
+	"partner_accounts/testdata/createChildAccountResponse.json":        {"account.json", func() interface{} { return &partner_accounts.Account{} }},
+	"partner_accounts/testdata/readChildAccountResponse.json":          {"account.json", func() interface{} { return &partner_accounts.Account{} }},
+	"partner_accounts/testdata/updateChildAccountResponse.json":        {"account.json", func() interface{} { return &partner_accounts.Account{} }},
+	"partner_accounts/testdata/listChildAccountResponse.json":          {"accountList.json", func() interface{} { return &[]partner_accounts.Account{} }},
+	"sms/testdata/binaryMessageObject.json":                            {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/flashMessageObject.json":                             {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/messageObject.json":                                  {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/messageObjectWithScheduledDatetime.json":             {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/messageWithParamsObject.json":                        {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/premiumMessageObject.json":                           {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/readMessageObject.json":                              {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/readScheduledMessageObject.json":                     {"smsMessage.json", func() interface{} { return &sms.Message{} }},
+	"sms/testdata/messageListObject.json":                              {"smsMessageList.json", func() interface{} { return &sms.MessageList{} }},
+	"sms/testdata/messageListScheduledObject.json":                     {"smsMessageList.json", func() interface{} { return &sms.MessageList{} }},
+	"tendlc/testdata/brand.json":                                       {"brand.json", func() interface{} { return &tendlc.Brand{} }},
+	"tendlc/testdata/campaign.json":                                    {"campaign.json", func() interface{} { return &tendlc.Campaign{} }},
+	"tendlc/testdata/campaignList.json":                                {"campaignList.json", func() interface{} { return &tendlc.Campaigns{} }},
+	"transaction/testdata/transactionObject.json":                      {"transaction.json", func() interface{} { return &transaction.Transaction{} }},
+	"transaction/testdata/transactionListObject.json":                  {"transactionList.json", func() interface{} { return &transaction.Transactions{} }},
+	"verify/testdata/verifyObject.json":                                {"verify.json", func() interface{} { return &verify.Verify{} }},
+	"verify/testdata/verifyTokenObject.json":                           {"verify.json", func() interface{} { return &verify.Verify{} }},
+	"webhooks/testdata/verify.json":                                    {"verify.json", func() interface{} { return &verify.Verify{} }},
+	"verify/testdata/verifyEmailMessageObject.json":                    {"verifyEmailMessage.json", func() interface{} { return &verify.VerifyMessage{} }},
+	"voicemessage/testdata/voiceMessageObject.json":                    {"voiceMessage.json", func() interface{} { return &voicemessage.VoiceMessage{} }},
+	"voicemessage/testdata/voiceMessageObjectWithCreatedDatetime.json": {"voiceMessage.json", func() interface{} { return &voicemessage.VoiceMessage{} }},
+	"voicemessage/testdata/voiceMessageObjectWithParams.json":          {"voiceMessage.json", func() interface{} { return &voicemessage.VoiceMessage{} }},
+	"voicemessage/testdata/voiceMessageListObject.json":                {"voiceMessageList.json", func() interface{} { return &voicemessage.VoiceMessageList{} }},
+	"email/testdata/messageObject.json":                                {"emailMessage.json", func() interface{} { return &email.Message{} }},
+	"email/testdata/inboundObject.json":                                {"emailInbound.json", func() interface{} { return &email.InboundMessage{} }},
+	"email/testdata/eventObject.json":                                  {"emailEvent.json", func() interface{} { return &email.Event{} }},
+	"messaging/testdata/messageObject.json":                            {"messagingMessage.json", func() interface{} { return &messaging.Message{} }},
+	"messaging/testdata/messageListObject.json":                        {"messagingMessageList.json", func() interface{} { return &messaging.MessageList{} }},
+	"reporting/testdata/reportObject.json":                             {"report.json", func() interface{} { return &reporting.Report{} }},
+	"files/testdata/fileObject.json":                                   {"file.json", func() interface{} { return &files.File{} }},
+	"flows/testdata/flowObject.json":                                   {"flow.json", func() interface{} { return &flows.Flow{} }},
+	"flows/testdata/flowListObject.json":                               {"flowList.json", func() interface{} { return &flows.FlowList{} }},
+	"flows/testdata/invocationObject.json":                             {"flowInvocation.json", func() interface{} { return &flows.Invocation{} }},
+	"conversation/testdata/pushDeviceObject.json":                      {"pushDevice.json", func() interface{} { return &conversation.PushDevice{} }},
+	"conversation/testdata/pushDeviceListObject.json":                  {"pushDeviceList.json", func() interface{} { return &conversation.PushDeviceList{} }},
+	"emailverify/testdata/verificationObject.json":                     {"emailVerification.json", func() interface{} { return &emailverify.Verification{} }},
+	"lookup/testdata/lookupRiskObject.json":                            {"lookupRisk.json", func() interface{} { return &lookup.Risk{} }},
+	"capabilities/testdata/country.json":                               {"capabilitiesCountry.json", func() interface{} { return &capabilities.Country{} }},
+	"capabilities/testdata/countryList.json":                           {"capabilitiesCountryList.json", func() interface{} { return &capabilities.CountryList{} }},
+	"capabilities/testdata/defaults.json":                              {"capabilitiesDefaults.json", func() interface{} { return &capabilities.Defaults{} }},
+}
+
+// excluded lists the files in testdata directories that are not API
+// responses the SDK decodes, with the reason why.
+var excluded = map[string]string{
+	"contact/testdata/contactRequestObjectCreate.json":         "request body",
+	"contact/testdata/contactRequestObjectUpdateCustom.json":   "request body",
+	"contact/testdata/contactRequestObjectUpdateMSISDN.json":   "request body",
+	"contact/testdata/contactRequestObjectUpdateName.json":     "request body",
+	"conversation/testdata/conversationReplyRequest.json":      "request body",
+	"conversation/testdata/conversationStartHsmRequest.json":   "request body",
+	"conversation/testdata/conversationStartTextRequest.json":  "request body",
+	"conversation/testdata/conversationStartVideoRequest.json": "request body",
+	"conversation/testdata/conversationUpdateRequest.json":     "request body",
+	"conversation/testdata/messageCreateRequest.json":          "request body",
+	"conversation/testdata/webhookCreateRequest.json":          "request body",
+	"conversation/testdata/webhookUpdateRequest.json":          "request body",
+	"group/testdata/groupRequestCreateObject.json":             "request body",
+	"group/testdata/groupRequestUpdateObject.json":             "request body",
+	"number/testdata/numberCreateRequestObject.json":           "request body",
+	"number/testdata/numberUpdateRequestObject.json":           "request body",
+	"partner_accounts/testdata/accountNotFound.json":           "error response",
+	"pricing/testdata/smsPricing.json":                         "decoded in part, per country, by package pricing",
+	"sms/testdata/messageNotFound.json":                        "error response",
+	"status/testdata/summary.json":                             "status page, not an API response",
+	"voice/testdata/error.json":                                "error response",
+	"voice/testdata/errors.json":                               "error response",
+	"voice/testdata/recordingObject.json":                      "decoded from a data envelope by package voice",
+	"voice/testdata/recordingPaginatorObject.json":             "decoded from a data envelope by package voice",
+	"voice/testdata/transcriptObject.json":                     "decoded from a data envelope by package voice",
+	"webhooks/testdata/voice.json":                             "decoded per item by package webhooks",
+	"signature_jwt/testdata/reference.json":                    "signature test vectors",
+}
+
+// knownDropped lists the fields of fixtures that the SDK is known to drop.
+// Remove entries once the structs support them.
+var knownDropped = map[string][]string{
+	"contact/testdata/contactListObject.json":    {"$.links"},
+	"group/testdata/groupContactListObject.json": {"$.links"},
+}
+
+func TestFixturesAreListed(t *testing.T) {
+	err := filepath.Walk(moduleRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(path) != ".json" || filepath.Base(filepath.Dir(path)) != "testdata" {
+			return nil
+		}
+
+		name, err := filepath.Rel(moduleRoot, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+
+		_, isFixture := fixtures[name]
+		_, isExcluded := excluded[name]
+		if !isFixture && !isExcluded {
+			t.Errorf("%s is not listed in fixtures or excluded", name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	schemas := contract.NewSchemas("schemas")
+
+	for name, f := range fixtures {
+		name, f := name, f
+		t.Run(strings.TrimSuffix(name, ".json"), func(t *testing.T) {
+			doc, err := ioutil.ReadFile(filepath.Join(moduleRoot, filepath.FromSlash(name)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			violations, err := schemas.Validate(f.schema, doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, violation := range violations {
+				t.Errorf("does not conform to %s: %s", f.schema, violation)
+			}
+
+			dropped, err := contract.DroppedFields(doc, f.decode())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, field := range unknown(dropped, knownDropped[name]) {
+				t.Errorf("%s is dropped when decoding", field)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	violations, err := contract.NewSchemas("schemas").Validate("balance.json", []byte(`{"payment":"prepaid","amount":"9.2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{
+		`$: missing required property "type"`,
+		"$.amount: expected number, got string",
+	}, violations)
+}
+
+func TestDroppedFields(t *testing.T) {
+	var v struct {
+		ID    string
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+
+	dropped, err := contract.DroppedFields([]byte(`{"id":"1","href":"h","empty":null,"items":[{"name":"a","extra":1}]}`), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"$.href", "$.items[0].extra"}, dropped)
+}
+
+func unknown(dropped, known []string) []string {
+	var fields []string
+	for _, field := range dropped {
+		if !containsString(known, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
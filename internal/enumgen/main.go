@@ -0,0 +1,191 @@
+// Command enumgen generates methods for string enum types: a function that
+// returns their known values, IsValid, and an UnmarshalText that decodes
+// values it does not know as the Unknown constant of the type, so new values
+// the API starts returning do not pass through unnoticed. Run it with go
+// generate from the package directory:
+//
+//	//go:generate go run ../internal/enumgen -type CallStatus,LegStatus
+//
+// Every type needs a constant of the type with a name that ends in Unknown,
+// e.g. CallStatusUnknown. Its name without the suffix names the function
+// with the values, e.g. CallStatusValues. The known values are the other
+// constants of the type with a string literal value.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultOutput is the file enumgen writes to.
+const defaultOutput = "enum_gen.go"
+
+func main() {
+	types := flag.String("type", "", "comma-separated list of type names")
+	output := flag.String("output", defaultOutput, "file to write to")
+	flag.Parse()
+
+	if *types == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(".", strings.Split(*types, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "enumgen:", err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "enumgen:", err)
+		os.Exit(1)
+	}
+}
+
+// enum is a string type and its constants.
+type enum struct {
+	Type    string
+	Prefix  string
+	Unknown string
+	Values  []string
+}
+
+// generate returns the source of the methods of types, declared in the
+// package in dir.
+func generate(dir string, types []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && name != defaultOutput
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, got %d", dir, len(pkgs))
+	}
+
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by enumgen -type %s; DO NOT EDIT.\n\n", strings.Join(types, ","))
+	fmt.Fprintf(&buf, "package %s\n", pkg.Name)
+
+	for _, typ := range types {
+		e, err := collect(pkg, typ)
+		if err != nil {
+			return nil, err
+		}
+		write(&buf, e)
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// collect finds the constants of typ in pkg, in the order they are declared.
+func collect(pkg *ast.Package, typ string) (*enum, error) {
+	e := &enum{Type: typ}
+	seen := map[string]bool{}
+
+	for _, name := range sortedFiles(pkg) {
+		for _, decl := range pkg.Files[name].Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if ident, ok := vs.Type.(*ast.Ident); !ok || ident.Name != typ {
+					continue
+				}
+
+				for i, n := range vs.Names {
+					if strings.HasSuffix(n.Name, "Unknown") {
+						e.Unknown = n.Name
+						continue
+					}
+
+					if i >= len(vs.Values) {
+						continue
+					}
+					lit, ok := vs.Values[i].(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					value, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						return nil, err
+					}
+
+					// Constants with the same value would be duplicate cases.
+					if !seen[value] {
+						seen[value] = true
+						e.Values = append(e.Values, n.Name)
+					}
+				}
+			}
+		}
+	}
+
+	if e.Unknown == "" {
+		return nil, fmt.Errorf("type %s has no Unknown constant", typ)
+	}
+	if len(e.Values) == 0 {
+		return nil, fmt.Errorf("type %s has no constants", typ)
+	}
+	e.Prefix = strings.TrimSuffix(e.Unknown, "Unknown")
+
+	return e, nil
+}
+
+func sortedFiles(pkg *ast.Package) []string {
+	var names []string
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+
+	// Sort by base name, so the output does not depend on the directory.
+	sort.Slice(names, func(i, j int) bool {
+		return filepath.Base(names[i]) < filepath.Base(names[j])
+	})
+
+	return names
+}
+
+func write(buf *bytes.Buffer, e *enum) {
+	recv := string(unicode.ToLower(rune(e.Type[0])))
+
+	fmt.Fprintf(buf, "\n// %sValues returns the known values of %s.\n", e.Prefix, e.Type)
+	fmt.Fprintf(buf, "func %sValues() []%s {\n", e.Prefix, e.Type)
+	fmt.Fprintf(buf, "return []%s{\n", e.Type)
+	for _, v := range e.Values {
+		fmt.Fprintf(buf, "%s,\n", v)
+	}
+	fmt.Fprintf(buf, "}\n}\n")
+
+	fmt.Fprintf(buf, "\n// IsValid reports whether %s is one of %sValues.\n", recv, e.Prefix)
+	fmt.Fprintf(buf, "func (%s %s) IsValid() bool {\n", recv, e.Type)
+	fmt.Fprintf(buf, "switch %s {\ncase %s:\nreturn true\n}\n\nreturn false\n}\n", recv, strings.Join(e.Values, ",\n"))
+
+	fmt.Fprintf(buf, "\n// UnmarshalText implements the encoding.TextUnmarshaler interface. Values\n")
+	fmt.Fprintf(buf, "// that are not empty or valid are decoded as %s.\n", e.Unknown)
+	fmt.Fprintf(buf, "func (%s *%s) UnmarshalText(text []byte) error {\n", recv, e.Type)
+	fmt.Fprintf(buf, "*%s = %s(text)\n", recv, e.Type)
+	fmt.Fprintf(buf, "if *%s != \"\" && !%s.IsValid() {\n*%s = %s\n}\n\nreturn nil\n}\n", recv, recv, recv, e.Unknown)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const directive = "//go:generate go run ../internal/enumgen -type "
+
+// TestGenerated checks that the generated files of all packages with an
+// enumgen directive are up to date.
+func TestGenerated(t *testing.T) {
+	root, err := filepath.Abs("../..")
+	assert.NoError(t, err)
+
+	found := 0
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+
+		types, err := directiveTypes(path)
+		if err != nil || types == nil {
+			return err
+		}
+		found++
+
+		dir := filepath.Dir(path)
+		expected, err := generate(dir, types)
+		if !assert.NoError(t, err, dir) {
+			return nil
+		}
+
+		actual, err := ioutil.ReadFile(filepath.Join(dir, defaultOutput))
+		if assert.NoError(t, err, dir) {
+			assert.Equal(t, string(expected), string(actual), "%s is out of date, run go generate", dir)
+		}
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, found)
+}
+
+func directiveTypes(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, directive) {
+			return strings.Split(strings.TrimPrefix(line, directive), ","), nil
+		}
+	}
+
+	return nil, scanner.Err()
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enumgen")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	src := `package p
+
+type Color string
+
+const (
+	ColorRed  Color = "red"
+	ColorBlue Color = "blue"
+)
+
+type Size string
+
+const SizeUnknown Size = "unknown"
+`
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644))
+
+	_, err = generate(dir, []string{"Color"})
+	assert.EqualError(t, err, "type Color has no Unknown constant")
+
+	_, err = generate(dir, []string{"Size"})
+	assert.EqualError(t, err, "type Size has no constants")
+}
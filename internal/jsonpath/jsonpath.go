@@ -0,0 +1,72 @@
+// Package jsonpath looks up fields in JSON documents by a dot-separated
+// path, like "content.hsm.params.0.default". Array elements are addressed by
+// their index. It is used by the assertion helpers of the test packages.
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Get returns the value at path in the JSON document doc. Values are decoded
+// like encoding/json decodes into an interface{}. An empty path returns the
+// whole document.
+func Get(doc []byte, path string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return nil, fmt.Errorf("decoding JSON: %w", err)
+	}
+
+	if path == "" {
+		return v, nil
+	}
+
+	for i, segment := range strings.Split(path, ".") {
+		switch current := v.(type) {
+		case map[string]interface{}:
+			value, ok := current[segment]
+			if !ok {
+				return nil, &NotFoundError{Path: path}
+			}
+			v = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, &NotFoundError{Path: path}
+			}
+			v = current[index]
+		default:
+			return nil, fmt.Errorf("%s is not an object or array", strings.Join(strings.Split(path, ".")[:i], "."))
+		}
+	}
+
+	return v, nil
+}
+
+// NotFoundError is returned by Get when a path does not exist.
+type NotFoundError struct {
+	Path string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Path + " not found"
+}
+
+// Normalize returns v as Get would return it after encoding v as JSON, so
+// it can be compared to values returned by Get: numbers become float64 and
+// slices become []interface{}.
+func Normalize(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
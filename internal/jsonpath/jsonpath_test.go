@@ -0,0 +1,38 @@
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	doc := []byte(`{"content":{"hsm":{"params":[{"default":"a"},{"default":"b"}]}},"count":2}`)
+
+	v, err := Get(doc, "content.hsm.params.1.default")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	v, err = Get(doc, "count")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), v)
+
+	_, err = Get(doc, "content.hsm.params.2")
+	assert.EqualError(t, err, "content.hsm.params.2 not found")
+
+	_, err = Get(doc, "count.value")
+	assert.EqualError(t, err, "count is not an object or array")
+
+	_, err = Get([]byte("not json"), "count")
+	assert.Error(t, err)
+}
+
+func TestNormalize(t *testing.T) {
+	v, err := Normalize([]string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a"}, v)
+
+	v, err = Normalize(map[string]int{"n": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"n": float64(1)}, v)
+}
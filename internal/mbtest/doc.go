@@ -1,3 +1,5 @@
-// Package mbtest provides a test server that effectively mocks the
-// MessageBird API.
+// Package mbtest wraps package messagebirdtest for the tests of this module,
+// which configure the response and inspect the last request through package
+// level functions rather than a Server. Users of the library can use package
+// messagebirdtest instead.
 package mbtest
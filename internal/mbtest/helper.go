@@ -2,24 +2,19 @@ package mbtest
 
 import (
 	"bytes"
-	"io/ioutil"
-	"path/filepath"
+	"net/url"
 	"testing"
 
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
 	"github.com/stretchr/testify/assert"
 )
 
-const testdataDir = "testdata"
-
 // Testdata returns a file's bytes based on the path relative to the testdata
 // directory. It fails the test if the testdata file can not be read.
 func Testdata(t *testing.T, relativePath string) []byte {
-	path := filepath.Join(testdataDir, relativePath)
-
-	b, err := ioutil.ReadFile(path)
-	assert.NoError(t, err)
+	t.Helper()
 
-	return b
+	return messagebirdtest.Testdata(t, relativePath)
 }
 
 // AssertTestdata gets testdata and asserts it equals actual. We start by
@@ -42,8 +37,47 @@ func AssertTestdataJson(t *testing.T, relativePath string, actual []byte) {
 // AssertEndpointCalled fails the test if the last request was not made to the
 // provided endpoint (e.g. combination of HTTP method and path).
 func AssertEndpointCalled(t *testing.T, method, path string) {
-	assert.Equal(t, method, Request.Method)
+	t.Helper()
+
+	LastRequest().AssertEndpointCalled(t, method, path)
+}
+
+// AssertBodyJSONEq is like messagebirdtest.Request.AssertBodyJSONEq, for the
+// last request.
+func AssertBodyJSONEq(t *testing.T, expected string) {
+	t.Helper()
+
+	LastRequest().AssertBodyJSONEq(t, expected)
+}
+
+// AssertBodyField is like messagebirdtest.Request.AssertBodyField, for the
+// last request.
+func AssertBodyField(t *testing.T, path string, expected interface{}) {
+	t.Helper()
+
+	LastRequest().AssertBodyField(t, path, expected)
+}
+
+// AssertBodyFieldAbsent is like messagebirdtest.Request.AssertBodyFieldAbsent,
+// for the last request.
+func AssertBodyFieldAbsent(t *testing.T, path string) {
+	t.Helper()
+
+	LastRequest().AssertBodyFieldAbsent(t, path)
+}
+
+// AssertQueryParam is like messagebirdtest.Request.AssertQueryParam, for the
+// last request.
+func AssertQueryParam(t *testing.T, name, expected string) {
+	t.Helper()
+
+	LastRequest().AssertQueryParam(t, name, expected)
+}
+
+// AssertQuery is like messagebirdtest.Request.AssertQuery, for the last
+// request.
+func AssertQuery(t *testing.T, expected url.Values) {
+	t.Helper()
 
-	escapedPath := Request.URL.EscapedPath()
-	assert.Equal(t, path, escapedPath)
+	LastRequest().AssertQuery(t, expected)
 }
@@ -1,14 +1,11 @@
 package mbtest
 
 import (
-	"crypto/tls"
-	"github.com/stretchr/testify/mock"
 	"log"
-	"net"
-	"net/http"
 	"testing"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/stretchr/testify/mock"
 )
 
 type ClientMock struct {
@@ -31,22 +28,20 @@ func MockClient() messagebird.Client {
 	return &ClientMock{}
 }
 
-// Client initializes a new MessageBird client that uses the
-func Client(t *testing.T) *messagebird.DefaultClient {
-	return newClient(t, "")
+// ClientFunc is a messagebird.Client that hands requests to a func, so tests
+// can respond differently per request.
+type ClientFunc func(v interface{}, method, path string, data interface{}) error
+
+// Request implements messagebird.Client.
+func (f ClientFunc) Request(v interface{}, method, path string, data interface{}) error {
+	return f(v, method, path, data)
 }
 
-func newClient(t *testing.T, accessKey string) *messagebird.DefaultClient {
-	transport := &http.Transport{
-		DialTLS: func(network, _ string) (net.Conn, error) {
-			addr := server.Listener.Addr().String()
-			return tls.Dial(network, addr, &tls.Config{
-				InsecureSkipVerify: true,
-			})
-		},
-	}
-	client := messagebird.New(accessKey)
-	client.HTTPClient.Transport = transport
+// Client initializes a new MessageBird client that sends its requests to a
+// server of its own, see messagebirdtest.Server.
+func Client(t *testing.T) *messagebird.DefaultClient {
+	client := newServer(t).Client()
+	client.AccessKey = ""
 	client.DebugLog = newTestLogger(t)
 
 	return client
@@ -1,83 +1,89 @@
 package mbtest
 
 import (
-	"context"
-	"crypto/tls"
-	"io/ioutil"
-	"net"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"os"
+	"sync"
 	"testing"
-)
 
-type request struct {
-	Body                []byte
-	ContentType, Method string
-	URL                 *url.URL
-}
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+)
 
-// Request contains the lastly received http.Request by the fake server.
-var Request request
+var (
+	mu sync.Mutex
 
-var server *httptest.Server
+	// server is the server of the client created last by Client.
+	server *messagebirdtest.Server
 
-var responseBody []byte
-var status int
+	// response configures the response of server, and of the servers of
+	// clients created later. Until a test sets it, requests fail.
+	response = func(s *messagebirdtest.Server) {
+		s.WillReturnStatus(http.StatusInternalServerError)
+	}
+)
 
-// EnableServer starts a fake server, runs the test and closes the server.
+// EnableServer runs the tests. Every client returned by Client has a server
+// of its own, which is closed when its test finishes.
 func EnableServer(m *testing.M) {
-	initAndStartServer()
-	exitCode := m.Run()
-	closeServer()
+	os.Exit(m.Run())
+}
+
+// newServer starts the server of a new client.
+func newServer(t *testing.T) *messagebirdtest.Server {
+	s := messagebirdtest.NewServer(t)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	response(s)
+	server = s
 
-	os.Exit(exitCode)
+	return s
 }
 
-func initAndStartServer() {
-	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		Request = request{
-			ContentType: r.Header.Get("Content-Type"),
-			Method:      r.Method,
-			URL:         r.URL,
-		}
+// willReturn sets the response of the servers.
+func willReturn(fn func(s *messagebirdtest.Server)) {
+	mu.Lock()
+	defer mu.Unlock()
 
-		var err error
+	response = fn
+	if server != nil {
+		fn(server)
+	}
+}
 
-		// Reading from the request body is fine, as it's not used elsewhere.
-		// Server always returns fake data/testdata.
-		Request.Body, err = ioutil.ReadAll(r.Body)
-		if err != nil {
-			panic(err.Error())
-		}
+// LastRequest returns the last request received by the server of the client
+// created last, or an empty request if it received none.
+func LastRequest() *messagebirdtest.Request {
+	mu.Lock()
+	s := server
+	mu.Unlock()
 
-		// status and responseBody are defined in returns.go.
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(status)
-		if _, err := w.Write(responseBody); err != nil {
-			panic(err.Error())
+	if s != nil {
+		if r := s.LastRequest(); r != nil {
+			return r
 		}
-	}))
-}
+	}
 
-func closeServer() {
-	server.Close()
+	return &messagebirdtest.Request{}
 }
 
 // WillReturn sets the response body (b) and status (s) to be returned by the
 // server for incoming requests. These can be used by tests to assert
 // unmarshalling responses works as intended.
 func WillReturn(b []byte, s int) {
-	responseBody = b
-	status = s
+	willReturn(func(server *messagebirdtest.Server) {
+		server.WillReturn(b, s)
+	})
 }
 
 // WillReturnOnlyStatus sets the response status (s) to be returned by the
 // server for incoming requests.
 func WillReturnOnlyStatus(s int) {
-	responseBody = []byte{}
-	status = s
+	willReturn(func(server *messagebirdtest.Server) {
+		server.WillReturnStatus(s)
+	})
 }
 
 // WillReturnTestdata sets the status (s) for the test server to respond with.
@@ -92,31 +98,14 @@ func WillReturnTestdata(t *testing.T, relativePath string, s int) {
 // WillReturnAccessKeyError sets the response body and status for requests to
 // indicate the request is not allowed due to an incorrect access key.
 func WillReturnAccessKeyError() {
-	WillReturn([]byte(`
-		{
-			"errors": [
-				{
-					"code":2,
-					"description":"Request not allowed (incorrect access_key)",
-					"parameter":"access_key"
-				}
-			]
-		}
-	`), http.StatusUnauthorized)
+	willReturn(func(server *messagebirdtest.Server) {
+		server.WillReturnAccessKeyError()
+	})
 }
 
 // HTTPTestTransport builds http transport that allows to pass custom http handler to http server
 func HTTPTestTransport(handler http.Handler) (*http.Transport, func()) {
 	s := httptest.NewTLSServer(handler)
 
-	transport := &http.Transport{
-		DialTLSContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
-			d := &tls.Dialer{Config: &tls.Config{
-				InsecureSkipVerify: true,
-			}}
-			return d.DialContext(ctx, network, s.Listener.Addr().String())
-		},
-	}
-
-	return transport, s.Close
+	return messagebirdtest.TransportTo(s.Listener.Addr().String()), s.Close
 }
@@ -0,0 +1,451 @@
+// Command mockgen generates the fakes of package mocks: for every interface, a
+// struct with a function field per method, which the method calls and
+// records. Run it with go generate from the mocks directory:
+//
+//	//go:generate go run ../internal/mockgen ..:Client=Client ../sms:Service=SMSService
+//
+// Every argument names the directory of a package of this module, an
+// interface declared in it and the name of its fake.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultOutput is the file mockgen writes to.
+const defaultOutput = "mocks_gen.go"
+
+func main() {
+	output := flag.String("output", defaultOutput, "file to write to")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(".", flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "mockgen:", err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "mockgen:", err)
+		os.Exit(1)
+	}
+}
+
+// fake is an interface to generate a fake for.
+type fake struct {
+	Name      string
+	Interface string
+	Methods   []*method
+}
+
+type method struct {
+	Name     string
+	Params   []param
+	Results  []string
+	Variadic bool
+}
+
+type param struct {
+	Name string
+	Type string
+}
+
+// generator collects the fakes and the imports they need.
+type generator struct {
+	module  string
+	root    string
+	imports map[string]string
+}
+
+// generate returns the source of the fakes of specs, of the form
+// dir:Interface=Name, for the package in dir.
+func generate(dir string, specs []string) ([]byte, error) {
+	root, module, err := findModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgName, err := packageName(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{module: module, root: root, imports: map[string]string{"sync": "sync"}}
+
+	var fakes []*fake
+	for _, spec := range specs {
+		f, err := g.collect(dir, spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", spec, err)
+		}
+		fakes = append(fakes, f)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen %s; DO NOT EDIT.\n\n", strings.Join(specs, " "))
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	g.writeImports(&buf)
+	for _, f := range fakes {
+		write(&buf, f)
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// findModule returns the root directory and path of the module dir is in.
+func findModule(dir string) (string, string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for d := abs; ; d = filepath.Dir(d) {
+		b, err := ioutil.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(b), "\n") {
+				if strings.HasPrefix(line, "module ") {
+					return d, strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+				}
+			}
+			return "", "", fmt.Errorf("%s has no module directive", filepath.Join(d, "go.mod"))
+		}
+		if filepath.Dir(d) == d {
+			return "", "", errors.New("no go.mod found")
+		}
+	}
+}
+
+// packageName returns the name of the package in dir, from any of its
+// files.
+func packageName(dir string) (string, error) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.PackageClauseOnly)
+	if err != nil {
+		return "", err
+	}
+
+	for name := range pkgs {
+		return name, nil
+	}
+
+	return "", fmt.Errorf("no package in %s", dir)
+}
+
+// collect finds the interface of spec and the types of its methods.
+func (g *generator) collect(dir, spec string) (*fake, error) {
+	i := strings.LastIndex(spec, ":")
+	j := strings.LastIndex(spec, "=")
+	if i < 0 || j < i {
+		return nil, errors.New("expected dir:Interface=Name")
+	}
+	srcDir, iface, name := filepath.Join(dir, spec[:i]), spec[i+1:j], spec[j+1:]
+
+	abs, err := filepath.Abs(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(g.root, abs)
+	if err != nil {
+		return nil, err
+	}
+	importPath := g.module
+	if rel != "." {
+		importPath = path.Join(g.module, filepath.ToSlash(rel))
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, srcDir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, got %d", srcDir, len(pkgs))
+	}
+
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+
+	for _, fileName := range sortedFiles(pkg) {
+		file := pkg.Files[fileName]
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, s := range gen.Specs {
+				ts := s.(*ast.TypeSpec)
+				if ts.Name.Name != iface {
+					continue
+				}
+				it, ok := ts.Type.(*ast.InterfaceType)
+				if !ok {
+					return nil, fmt.Errorf("%s is not an interface", iface)
+				}
+
+				q := &qualifier{g: g, pkg: pkg.Name, file: file}
+				g.imports[pkg.Name] = importPath
+
+				f := &fake{Name: name, Interface: pkg.Name + "." + iface}
+				for _, field := range it.Methods.List {
+					ft, ok := field.Type.(*ast.FuncType)
+					if !ok || len(field.Names) != 1 {
+						return nil, fmt.Errorf("%s embeds an interface, which is not supported", iface)
+					}
+
+					m, err := q.method(field.Names[0].Name, ft)
+					if err != nil {
+						return nil, err
+					}
+					f.Methods = append(f.Methods, m)
+				}
+
+				return f, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("interface %s not found in %s", iface, srcDir)
+}
+
+func sortedFiles(pkg *ast.Package) []string {
+	var names []string
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+
+	// Sort by base name, so the output does not depend on the directory.
+	sort.Slice(names, func(i, j int) bool {
+		return filepath.Base(names[i]) < filepath.Base(names[j])
+	})
+
+	return names
+}
+
+// qualifier prints the types of a package, as used from package mocks.
+type qualifier struct {
+	g    *generator
+	pkg  string
+	file *ast.File
+}
+
+func (q *qualifier) method(name string, ft *ast.FuncType) (*method, error) {
+	m := &method{Name: name}
+
+	for _, field := range ft.Params.List {
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			m.Variadic = true
+		}
+
+		typ, err := q.typ(field.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(field.Names) == 0 {
+			m.Params = append(m.Params, param{Name: "p" + strconv.Itoa(len(m.Params)), Type: typ})
+		}
+		for _, n := range field.Names {
+			m.Params = append(m.Params, param{Name: n.Name, Type: typ})
+		}
+	}
+
+	if ft.Results != nil {
+		for _, field := range ft.Results.List {
+			typ, err := q.typ(field.Type)
+			if err != nil {
+				return nil, err
+			}
+
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				m.Results = append(m.Results, typ)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// typ returns expr as source, with the types of the package qualified by its
+// name, and records the imports it needs.
+func (q *qualifier) typ(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return q.pkg + "." + t.Name, nil
+		}
+		return t.Name, nil
+	case *ast.StarExpr:
+		elem, err := q.typ(t.X)
+		return "*" + elem, err
+	case *ast.Ellipsis:
+		elem, err := q.typ(t.Elt)
+		return "..." + elem, err
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "", errors.New("arrays are not supported")
+		}
+		elem, err := q.typ(t.Elt)
+		return "[]" + elem, err
+	case *ast.MapType:
+		key, err := q.typ(t.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := q.typ(t.Value)
+		return "map[" + key + "]" + value, err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return "", errors.New("interface literals are not supported")
+		}
+		return "interface{}", nil
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported type %T", t.X)
+		}
+		importPath, err := q.importOf(x.Name)
+		if err != nil {
+			return "", err
+		}
+		if existing, ok := q.g.imports[x.Name]; ok && existing != importPath {
+			return "", fmt.Errorf("%s is imported as both %s and %s", x.Name, existing, importPath)
+		}
+		q.g.imports[x.Name] = importPath
+		return x.Name + "." + t.Sel.Name, nil
+	}
+
+	return "", fmt.Errorf("unsupported type %T", expr)
+}
+
+// importOf returns the path of the import with name in the file of the
+// interface.
+func (q *qualifier) importOf(name string) (string, error) {
+	for _, imp := range q.file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return "", err
+		}
+
+		if imp.Name != nil && imp.Name.Name == name || imp.Name == nil && path.Base(importPath) == name {
+			return importPath, nil
+		}
+	}
+
+	return "", fmt.Errorf("no import for %s", name)
+}
+
+func (g *generator) writeImports(buf *bytes.Buffer) {
+	var std, module []string
+	for name, importPath := range g.imports {
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			module = append(module, name)
+		} else {
+			std = append(std, name)
+		}
+	}
+
+	fmt.Fprintf(buf, "import (\n")
+	for i, names := range [][]string{std, module} {
+		if i > 0 && len(std) > 0 {
+			fmt.Fprintf(buf, "\n")
+		}
+
+		sort.Slice(names, func(i, j int) bool {
+			return g.imports[names[i]] < g.imports[names[j]]
+		})
+		for _, name := range names {
+			importPath := g.imports[name]
+			if path.Base(importPath) == name {
+				fmt.Fprintf(buf, "%q\n", importPath)
+			} else {
+				fmt.Fprintf(buf, "%s %q\n", name, importPath)
+			}
+		}
+	}
+	fmt.Fprintf(buf, ")\n")
+}
+
+func write(buf *bytes.Buffer, f *fake) {
+	fmt.Fprintf(buf, "\n// %s is a fake %s, see the package documentation.\n", f.Name, f.Interface)
+	fmt.Fprintf(buf, "type %s struct {\n", f.Name)
+	for _, m := range f.Methods {
+		fmt.Fprintf(buf, "%sFunc func(%s) %s\n", m.Name, m.params(), m.results())
+	}
+	fmt.Fprintf(buf, "\nmu sync.Mutex\ncalls []Call\n}\n")
+
+	fmt.Fprintf(buf, "\nvar _ %s = (*%s)(nil)\n", f.Interface, f.Name)
+
+	for _, m := range f.Methods {
+		fmt.Fprintf(buf, "\n// %s implements %s.\n", m.Name, f.Interface)
+		fmt.Fprintf(buf, "func (m *%s) %s(%s) %s {\n", f.Name, m.Name, m.params(), m.results())
+		fmt.Fprintf(buf, "m.mu.Lock()\nm.calls = append(m.calls, Call{Method: %q, Args: []interface{}{%s}})\nm.mu.Unlock()\n\n", m.Name, m.names(false))
+		fmt.Fprintf(buf, "if m.%sFunc == nil {\npanic(%q)\n}\n\n", m.Name, "mocks: "+f.Name+"."+m.Name+" called without "+m.Name+"Func")
+		if len(m.Results) > 0 {
+			fmt.Fprintf(buf, "return ")
+		}
+		fmt.Fprintf(buf, "m.%sFunc(%s)\n}\n", m.Name, m.names(true))
+	}
+
+	fmt.Fprintf(buf, "\n// Calls returns the calls of the methods of m, in the order they were made.\n")
+	fmt.Fprintf(buf, "func (m *%s) Calls() []Call {\nm.mu.Lock()\ndefer m.mu.Unlock()\n\nreturn append([]Call(nil), m.calls...)\n}\n", f.Name)
+}
+
+func (m *method) params() string {
+	parts := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		parts[i] = p.Name + " " + p.Type
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (m *method) results() string {
+	if len(m.Results) <= 1 {
+		return strings.Join(m.Results, "")
+	}
+
+	return "(" + strings.Join(m.Results, ", ") + ")"
+}
+
+// names returns the names of the parameters, spreading the variadic one if
+// spread is set.
+func (m *method) names(spread bool) string {
+	parts := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		parts[i] = p.Name
+	}
+	if spread && m.Variadic {
+		parts[len(parts)-1] += "..."
+	}
+
+	return strings.Join(parts, ", ")
+}
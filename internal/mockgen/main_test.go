@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const directive = "//go:generate go run ../internal/mockgen "
+
+// TestGenerated checks that the fakes of package mocks are up to date.
+func TestGenerated(t *testing.T) {
+	dir := filepath.Join("..", "..", "mocks")
+
+	specs, err := directiveSpecs(filepath.Join(dir, "doc.go"))
+	if !assert.NoError(t, err) || !assert.NotEmpty(t, specs) {
+		return
+	}
+
+	expected, err := generate(dir, specs)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	actual, err := ioutil.ReadFile(filepath.Join(dir, defaultOutput))
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(expected), string(actual), "%s is out of date, run go generate", dir)
+	}
+}
+
+func directiveSpecs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, directive) {
+			return strings.Fields(strings.TrimPrefix(line, directive)), nil
+		}
+	}
+
+	return nil, scanner.Err()
+}
+
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mockgen")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":     "module example.com/m\n",
+		"p/p.go":     "package p\n\nimport \"io\"\n\ntype Thing struct{}\n\ntype Service interface {\n\tGet(id string, opts ...string) (*Thing, error)\n\tPut(io.Reader)\n}\n\ntype NotAnInterface struct{}\n",
+		"fakes/a.go": "package fakes\n\ntype Call struct {\n\tMethod string\n\tArgs   []interface{}\n}\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(src), 0644))
+	}
+
+	src, err := generate(filepath.Join(dir, "fakes"), []string{"../p:Service=Service"})
+	if assert.NoError(t, err) {
+		s := string(src)
+		assert.Contains(t, s, "\"example.com/m/p\"")
+		assert.Contains(t, s, "GetFunc func(id string, opts ...string) (*p.Thing, error)")
+		assert.Contains(t, s, "return m.GetFunc(id, opts...)")
+		assert.Contains(t, s, "PutFunc func(p0 io.Reader)")
+		assert.Contains(t, s, "var _ p.Service = (*Service)(nil)")
+	}
+
+	_, err = generate(filepath.Join(dir, "fakes"), []string{"../p:NotAnInterface=Fake"})
+	assert.EqualError(t, err, "../p:NotAnInterface=Fake: NotAnInterface is not an interface")
+
+	_, err = generate(filepath.Join(dir, "fakes"), []string{"../p:Missing=Fake"})
+	assert.Error(t, err)
+}
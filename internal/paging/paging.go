@@ -0,0 +1,63 @@
+// Package paging keeps track of the position of iterators over lists that
+// the API paginates by offset, which all lists with Offset, Limit, Count and
+// TotalCount fields are. Iterators fetch a page with Fetch whenever they
+// run out of items:
+//
+//	func (it *Iterator) fetch() {
+//		it.pager.Fetch(func(offset int) (int, int, error) {
+//			it.params.Offset = offset
+//			messages, err := List(it.client, &it.params)
+//			if err != nil {
+//				return 0, 0, err
+//			}
+//
+//			it.page = messages.Items
+//			return len(messages.Items), messages.TotalCount, nil
+//		})
+//	}
+//
+// This way, the iterators of all packages end at the same point and handle
+// errors the same way, while keeping their typed items and accessors.
+package paging
+
+// Pager tracks the offset of the next page of a list and whether the list
+// ended. The zero value starts at the beginning of the list.
+type Pager struct {
+	// Offset is the offset of the next page.
+	Offset int
+
+	done bool
+	err  error
+}
+
+// Fetch calls fetch with the offset of the next page, unless the list ended
+// or an error occurred. fetch returns the number of items on the page and
+// the total count of the list. The list ends after a page that is empty or
+// that reaches the total count, and after an error.
+func (p *Pager) Fetch(fetch func(offset int) (n, total int, err error)) {
+	if p.done {
+		return
+	}
+
+	n, total, err := fetch(p.Offset)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return
+	}
+
+	p.Offset += n
+	if n == 0 || p.Offset >= total {
+		p.done = true
+	}
+}
+
+// Done reports whether all pages were fetched.
+func (p *Pager) Done() bool {
+	return p.done
+}
+
+// Err returns the error that ended the list, if any.
+func (p *Pager) Err() error {
+	return p.err
+}
@@ -0,0 +1,50 @@
+package paging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPager(t *testing.T) {
+	p := &Pager{Offset: 1}
+
+	var offsets []int
+	fetch := func(offset int) (int, int, error) {
+		offsets = append(offsets, offset)
+		return 2, 5, nil
+	}
+
+	for !p.Done() {
+		p.Fetch(fetch)
+	}
+	p.Fetch(fetch)
+
+	assert.Equal(t, []int{1, 3}, offsets)
+	assert.Equal(t, 5, p.Offset)
+	assert.NoError(t, p.Err())
+}
+
+func TestPagerEmptyPage(t *testing.T) {
+	p := &Pager{}
+
+	p.Fetch(func(offset int) (int, int, error) { return 0, 10, nil })
+
+	assert.True(t, p.Done())
+	assert.Zero(t, p.Offset)
+}
+
+func TestPagerError(t *testing.T) {
+	p := &Pager{}
+	err := errors.New("request failed")
+
+	p.Fetch(func(offset int) (int, int, error) { return 0, 0, err })
+	p.Fetch(func(offset int) (int, int, error) {
+		t.Error("unexpected fetch")
+		return 0, 0, nil
+	})
+
+	assert.True(t, p.Done())
+	assert.Equal(t, err, p.Err())
+}
@@ -0,0 +1,192 @@
+// Package querystring builds URL query strings for the QueryParams methods
+// of list requests. It produces the same output as url.Values.Encode, but
+// Builders are pooled and values are formatted directly into the output, so
+// building a query string allocates only the returned string.
+//
+//	query := querystring.New()
+//	query.Set("originator", "MessageBird")
+//	query.SetInt("limit", 20)
+//	return query.Encode()
+package querystring
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindInt
+	kindBool
+	kindTime
+)
+
+// param is a single key/value pair. Only the field matching kind is used.
+type param struct {
+	key  string
+	kind kind
+
+	s      string
+	i      int64
+	b      bool
+	t      time.Time
+	layout string
+}
+
+// Builder collects the parameters of a query string. A Builder must not be
+// used after calling Encode.
+type Builder struct {
+	params  []param
+	buf     []byte
+	scratch []byte
+}
+
+var pool = sync.Pool{
+	New: func() interface{} {
+		return &Builder{
+			params:  make([]param, 0, 16),
+			buf:     make([]byte, 0, 256),
+			scratch: make([]byte, 0, 64),
+		}
+	},
+}
+
+// New returns an empty Builder from the pool.
+func New() *Builder {
+	return pool.Get().(*Builder)
+}
+
+// Set sets key to value, replacing any values set or added before.
+func (b *Builder) Set(key, value string) {
+	b.set(param{key: key, kind: kindString, s: value})
+}
+
+// SetInt is like Set, with the decimal representation of value.
+func (b *Builder) SetInt(key string, value int) {
+	b.set(param{key: key, kind: kindInt, i: int64(value)})
+}
+
+// SetBool is like Set, with "true" or "false".
+func (b *Builder) SetBool(key string, value bool) {
+	b.set(param{key: key, kind: kindBool, b: value})
+}
+
+// SetTime is like Set, with value formatted with layout.
+func (b *Builder) SetTime(key string, value time.Time, layout string) {
+	b.set(param{key: key, kind: kindTime, t: value, layout: layout})
+}
+
+// Add adds value to key, keeping the values set or added before.
+func (b *Builder) Add(key, value string) {
+	b.params = append(b.params, param{key: key, kind: kindString, s: value})
+}
+
+func (b *Builder) set(p param) {
+	n := 0
+	for _, existing := range b.params {
+		if existing.key != p.key {
+			b.params[n] = existing
+			n++
+		}
+	}
+
+	b.params = append(b.params[:n], p)
+}
+
+// Encode returns the query string, sorted by key like url.Values.Encode,
+// and returns b to the pool.
+func (b *Builder) Encode() string {
+	b.sort()
+
+	for i := range b.params {
+		p := &b.params[i]
+		if i > 0 {
+			b.buf = append(b.buf, '&')
+		}
+
+		b.buf = appendEscaped(b.buf, p.key)
+		b.buf = append(b.buf, '=')
+
+		switch p.kind {
+		case kindString:
+			b.buf = appendEscaped(b.buf, p.s)
+		case kindInt:
+			b.buf = strconv.AppendInt(b.buf, p.i, 10)
+		case kindBool:
+			b.buf = strconv.AppendBool(b.buf, p.b)
+		case kindTime:
+			// Formatted times contain characters like ':' and '+' that
+			// must be escaped.
+			b.scratch = p.t.AppendFormat(b.scratch[:0], p.layout)
+			for _, c := range b.scratch {
+				b.buf = appendEscapedByte(b.buf, c)
+			}
+		}
+	}
+
+	s := string(b.buf)
+	b.release()
+
+	return s
+}
+
+// sort sorts the params by key. Params with equal keys keep the order they
+// were added in. Insertion sort is used because queries have few parameters
+// and, unlike package sort, it does not allocate.
+func (b *Builder) sort() {
+	for i := 1; i < len(b.params); i++ {
+		for j := i; j > 0 && b.params[j].key < b.params[j-1].key; j-- {
+			b.params[j], b.params[j-1] = b.params[j-1], b.params[j]
+		}
+	}
+}
+
+func (b *Builder) release() {
+	for i := range b.params {
+		b.params[i] = param{}
+	}
+
+	b.params = b.params[:0]
+	b.buf = b.buf[:0]
+	b.scratch = b.scratch[:0]
+	pool.Put(b)
+}
+
+const upperhex = "0123456789ABCDEF"
+
+// appendEscaped appends s to dst, escaped like url.QueryEscape.
+func appendEscaped(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		dst = appendEscapedByte(dst, s[i])
+	}
+
+	return dst
+}
+
+func appendEscapedByte(dst []byte, c byte) []byte {
+	switch {
+	case !shouldEscape(c):
+		return append(dst, c)
+	case c == ' ':
+		return append(dst, '+')
+	default:
+		return append(dst, '%', upperhex[c>>4], upperhex[c&15])
+	}
+}
+
+// shouldEscape reports whether c must be escaped in a query component.
+func shouldEscape(c byte) bool {
+	if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' {
+		return false
+	}
+
+	switch c {
+	case '-', '_', '.', '~':
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,118 @@
+package querystring
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	from := time.Date(2022, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	query := New()
+	query.Set("originator", "Message Bird")
+	query.SetInt("limit", 20)
+	query.SetBool("prices", true)
+	query.SetTime("from", from, time.RFC3339)
+	query.Add("features", "sms")
+	query.Add("features", "voice")
+	query.Set("search", "a&b=c/d?é~_-.")
+
+	expected := url.Values{}
+	expected.Set("originator", "Message Bird")
+	expected.Set("limit", "20")
+	expected.Set("prices", "true")
+	expected.Set("from", from.Format(time.RFC3339))
+	expected.Add("features", "sms")
+	expected.Add("features", "voice")
+	expected.Set("search", "a&b=c/d?é~_-.")
+
+	assert.Equal(t, expected.Encode(), query.Encode())
+}
+
+func TestEncodeEmpty(t *testing.T) {
+	assert.Equal(t, "", New().Encode())
+}
+
+func TestSetReplaces(t *testing.T) {
+	query := New()
+	query.Add("ids", "1")
+	query.Set("limit", "10")
+	query.Add("ids", "2")
+	query.SetInt("limit", 20)
+	query.Set("ids", "3")
+
+	assert.Equal(t, "ids=3&limit=20", query.Encode())
+}
+
+func TestBuilderIsReset(t *testing.T) {
+	query := New()
+	query.Set("a", "1")
+	assert.Equal(t, "a=1", query.Encode())
+
+	query = New()
+	query.Set("b", "2")
+	assert.Equal(t, "b=2", query.Encode())
+}
+
+func TestEscapeAllBytes(t *testing.T) {
+	for c := 0; c < 256; c++ {
+		query := New()
+		query.Set("k", string([]byte{byte(c)}))
+
+		assert.Equal(t, "k="+url.QueryEscape(string([]byte{byte(c)})), query.Encode(), strconv.Itoa(c))
+	}
+}
+
+func TestEncodeAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+
+	from := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		query := New()
+		query.Set("originator", "MessageBird")
+		query.SetTime("from", from, time.RFC3339)
+		query.SetInt("limit", 20)
+		query.SetInt("offset", 40)
+		_ = query.Encode()
+	})
+
+	// Only the returned string is allocated.
+	assert.Equal(t, float64(1), allocs)
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	from := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		query := New()
+		query.Set("originator", "MessageBird")
+		query.Set("direction", "mt")
+		query.SetTime("from", from, time.RFC3339)
+		query.SetInt("limit", 20)
+		query.SetInt("offset", 40)
+		_ = query.Encode()
+	}
+}
+
+func BenchmarkValues(b *testing.B) {
+	from := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		query := url.Values{}
+		query.Set("originator", "MessageBird")
+		query.Set("direction", "mt")
+		query.Set("from", from.Format(time.RFC3339))
+		query.Set("limit", strconv.Itoa(20))
+		query.Set("offset", strconv.Itoa(40))
+		_ = query.Encode()
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+// +build race
+
+package querystring
+
+// raceEnabled reports whether the race detector is on, which allocates.
+const raceEnabled = true
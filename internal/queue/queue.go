@@ -0,0 +1,206 @@
+// Package queue implements the durable queues of packages outbox and
+// webhooks: entries are persisted in a Store and handled in the background,
+// and retried with an exponential backoff when handling them fails.
+//
+// The package does not import the messagebird package, so it can be used by
+// it.
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// BatchSize is the number of entries Drain handles at once.
+const BatchSize = 100
+
+// Entry is a queued item.
+type Entry struct {
+	ID   string
+	Kind string
+
+	// Payload is the item, in a format that depends on Kind.
+	Payload json.RawMessage
+
+	Attempts    int
+	LastError   string
+	NextAttempt time.Time
+	CreatedAt   time.Time
+
+	// Err is set by a Store for an entry that can never be handled, e.g.
+	// because it can not be decrypted. It is not stored.
+	Err error `json:"-"`
+}
+
+// Clock is the time source of a Queue. It is implemented by
+// messagebird.Clock.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// Queue handles the entries in Store. The packages that use it set all of
+// its options, with their own defaults. Only one Queue may run per Store.
+type Queue struct {
+	Store Store
+	Clock Clock
+
+	// PollInterval is the time between checks for due entries when the
+	// queue is empty.
+	PollInterval time.Duration
+
+	// MaxAttempts is the number of times an entry is handled before it
+	// fails permanently.
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first retry. It doubles for every
+	// following one, up to MaxDelay. See Backoff.
+	RetryDelay time.Duration
+	MaxDelay   time.Duration
+
+	// Handle handles e, and returns a result for OnHandled.
+	Handle func(ctx context.Context, e *Entry) (interface{}, error)
+
+	// IsPermanent reports whether err, returned by Handle, means handling
+	// will never succeed, so the entry is not retried. If it is nil, all
+	// errors are retried.
+	IsPermanent func(err error) bool
+
+	// OnHandled is called after an entry was handled and removed from the
+	// Store, with the result of Handle.
+	OnHandled func(e *Entry, result interface{})
+
+	// OnRetry is called when handling an entry failed, before it is put back
+	// in the Store to be retried.
+	OnRetry func(e *Entry, err error)
+
+	// OnFailed is called when an entry fails permanently, after which it is
+	// removed from the Store.
+	OnFailed func(e *Entry, err error)
+}
+
+// Enqueue puts e in the Store with a new ID, which it returns. e is created
+// now, and is due at e.NextAttempt, or now if that has passed.
+func (q *Queue) Enqueue(ctx context.Context, e *Entry) (string, error) {
+	id, err := NewID()
+	if err != nil {
+		return "", err
+	}
+
+	now := q.Clock.Now()
+	e.ID, e.CreatedAt = id, now
+	if e.NextAttempt.Before(now) {
+		e.NextAttempt = now
+	}
+	if err := q.Store.Put(ctx, e); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Run handles due entries until ctx is done, and returns ctx.Err(). Errors of
+// the Store are retried after PollInterval.
+func (q *Queue) Run(ctx context.Context) error {
+	for {
+		n, err := q.Drain(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil && n > 0 {
+			// There may be more due entries than fit in a batch.
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.Clock.After(q.PollInterval):
+		}
+	}
+}
+
+// Drain handles a batch of due entries once and returns how many it handled.
+func (q *Queue) Drain(ctx context.Context) (int, error) {
+	entries, err := q.Store.Due(ctx, q.Clock.Now(), BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		if err := q.handle(ctx, e); err != nil {
+			return i, err
+		}
+	}
+
+	return len(entries), nil
+}
+
+// handle handles e and updates the Store. Only errors of the Store are
+// returned.
+func (q *Queue) handle(ctx context.Context, e *Entry) error {
+	err := e.Err
+	if err == nil {
+		var result interface{}
+		result, err = q.Handle(ctx, e)
+		if err == nil {
+			if err := q.Store.Delete(ctx, e.ID); err != nil {
+				return err
+			}
+			if q.OnHandled != nil {
+				q.OnHandled(e, result)
+			}
+			return nil
+		}
+	}
+
+	e.Attempts++
+	e.LastError = err.Error()
+
+	permanent := e.Err != nil || (q.IsPermanent != nil && q.IsPermanent(err))
+	if permanent || e.Attempts >= q.MaxAttempts {
+		if err := q.Store.Delete(ctx, e.ID); err != nil {
+			return err
+		}
+		if q.OnFailed != nil {
+			q.OnFailed(e, err)
+		}
+		return nil
+	}
+
+	if q.OnRetry != nil {
+		q.OnRetry(e, err)
+	}
+
+	e.NextAttempt = q.Clock.Now().Add(Backoff(q.RetryDelay, q.MaxDelay, e.Attempts))
+	return q.Store.Put(ctx, e)
+}
+
+// Backoff returns the time to wait after the nth failed attempt: delay after
+// the first one, doubling for every following one, up to maxDelay.
+func Backoff(delay, maxDelay time.Duration, n int) time.Duration {
+	for i := 1; i < n && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	return delay
+}
+
+// NewID returns a random ID for an entry.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
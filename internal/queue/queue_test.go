@@ -0,0 +1,17 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, Backoff(time.Second, 10*time.Second, 1))
+	assert.Equal(t, 2*time.Second, Backoff(time.Second, 10*time.Second, 2))
+	assert.Equal(t, 8*time.Second, Backoff(time.Second, 10*time.Second, 4))
+	assert.Equal(t, 10*time.Second, Backoff(time.Second, 10*time.Second, 5))
+	assert.Equal(t, 10*time.Second, Backoff(time.Second, 10*time.Second, 100))
+	assert.Equal(t, time.Second, Backoff(time.Second, 10*time.Second, 0))
+}
@@ -0,0 +1,292 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/encryption"
+)
+
+// Store persists the entries of a Queue. Implementations backed by a
+// database, like SQLite or Redis, only need to store entries by ID and find
+// the ones that are due. They must be safe for concurrent use.
+type Store interface {
+	// Put adds e, or replaces the entry with the same ID.
+	Put(ctx context.Context, e *Entry) error
+
+	// Due returns at most n entries whose NextAttempt is not after now,
+	// oldest first.
+	Due(ctx context.Context, now time.Time, n int) ([]*Entry, error)
+
+	// Delete removes the entry with id. Deleting an entry that does not
+	// exist is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryStore is a Store that keeps entries in memory. Entries do not
+// survive restarts, so it is only suitable for tests.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*Entry
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*Entry)}
+}
+
+// Put implements Store.
+func (s *MemoryStore) Put(_ context.Context, e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *e
+	s.entries[e.ID] = &copied
+	return nil
+}
+
+// Due implements Store.
+func (s *MemoryStore) Due(_ context.Context, now time.Time, n int) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*Entry
+	for _, e := range s.entries {
+		if !e.NextAttempt.After(now) {
+			copied := *e
+			due = append(due, &copied)
+		}
+	}
+
+	return oldest(due, n), nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, id)
+	return nil
+}
+
+// Len returns the number of entries in s.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.entries)
+}
+
+// FileStore is a Store that keeps every entry in a JSON file in Dir. Files
+// are replaced atomically, so entries survive crashes. Due reads all files,
+// so it suits queues of up to a few thousand entries.
+//
+// Files that can not be parsed, e.g. because they were changed by hand, are
+// renamed to end in .corrupt, so they do not block the queue, and reported
+// to ErrorLog.
+type FileStore struct {
+	Dir string
+
+	// ErrorLog, if set, is called for every file that can not be parsed.
+	ErrorLog func(err error)
+}
+
+// NewFileStore returns a FileStore in dir, creating dir if it does not
+// exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &FileStore{Dir: dir}, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(_ context.Context, e *Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(s.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path(e.ID))
+}
+
+// Due implements Store.
+func (s *FileStore) Due(_ context.Context, now time.Time, n int) ([]*Entry, error) {
+	files, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []*Entry
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		b, err := ioutil.ReadFile(filepath.Join(s.Dir, file.Name()))
+		if os.IsNotExist(err) {
+			// Deleted since reading the directory.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		e := &Entry{}
+		if err := json.Unmarshal(b, e); err != nil {
+			s.quarantine(file.Name(), err)
+			continue
+		}
+		if !e.NextAttempt.After(now) {
+			due = append(due, e)
+		}
+	}
+
+	return oldest(due, n), nil
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(_ context.Context, id string) error {
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// quarantine renames the file name, which can not be parsed, so it is
+// skipped from now on.
+func (s *FileStore) quarantine(name string, err error) {
+	path := filepath.Join(s.Dir, name)
+	err = fmt.Errorf("queue entry %s is corrupt, moved to %s.corrupt: %w", path, name, err)
+	if renameErr := os.Rename(path, path+".corrupt"); renameErr != nil {
+		err = fmt.Errorf("queue entry %s is corrupt: %v", path, renameErr)
+	}
+
+	if s.ErrorLog != nil {
+		s.ErrorLog(err)
+	}
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
+
+// EncryptedStore is a Store that encrypts the Payload of entries with Cipher
+// before they are put in Store, and decrypts them when they are due. The
+// other fields are needed to schedule entries, and are stored as is. The
+// encrypted payload is bound to the ID of its entry.
+//
+// Entries that can not be decrypted, e.g. because their key was removed or
+// they were changed or moved to another ID, are returned nonetheless, with Err set, so they do not
+// block the queue: they fail permanently.
+type EncryptedStore struct {
+	Store  Store
+	Cipher encryption.Cipher
+
+	// AllowPlaintext returns entries put before encryption was enabled
+	// unchanged, instead of failing them. Only enable it while migrating
+	// a store: anyone who can write to the store can queue messages while
+	// it is enabled.
+	AllowPlaintext bool
+}
+
+// Put implements Store.
+func (s *EncryptedStore) Put(ctx context.Context, e *Entry) error {
+	sealed, err := encryption.Seal(s.Cipher, string(e.Payload), e.ID)
+	if err != nil {
+		return err
+	}
+
+	// The payload must remain valid JSON, so it is stored as a string.
+	payload, err := json.Marshal(sealed)
+	if err != nil {
+		return err
+	}
+
+	copied := *e
+	copied.Payload = payload
+	return s.Store.Put(ctx, &copied)
+}
+
+// Due implements Store.
+func (s *EncryptedStore) Due(ctx context.Context, now time.Time, n int) ([]*Entry, error) {
+	entries, err := s.Store.Due(ctx, now, n)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if err := s.open(e); err != nil {
+			e.Err = fmt.Errorf("entry %s: %w", e.ID, err)
+		}
+	}
+
+	return entries, nil
+}
+
+// open decrypts the payload of e in place.
+func (s *EncryptedStore) open(e *Entry) error {
+	var sealed string
+	if err := json.Unmarshal(e.Payload, &sealed); err != nil || !encryption.IsSealed(sealed) {
+		if s.AllowPlaintext {
+			return nil
+		}
+		return encryption.ErrNotSealed
+	}
+
+	payload, err := encryption.Open(s.Cipher, sealed, e.ID)
+	if err != nil {
+		return err
+	}
+
+	e.Payload = json.RawMessage(payload)
+	return nil
+}
+
+// Delete implements Store.
+func (s *EncryptedStore) Delete(ctx context.Context, id string) error {
+	return s.Store.Delete(ctx, id)
+}
+
+// oldest sorts entries by creation time and returns the first n.
+func oldest(entries []*Entry, n int) []*Entry {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+
+	return entries
+}
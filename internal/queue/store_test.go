@@ -0,0 +1,181 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "queue")
+	ctx := context.Background()
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	entries := []*Entry{
+		{ID: "second", Kind: "sms", Payload: json.RawMessage(`{"Body":"2"}`), NextAttempt: now, CreatedAt: now.Add(time.Second)},
+		{ID: "first", Kind: "sms", Payload: json.RawMessage(`{"Body":"1"}`), NextAttempt: now, CreatedAt: now},
+		{ID: "later", Kind: "sms", Payload: json.RawMessage(`{"Body":"3"}`), NextAttempt: now.Add(time.Minute), CreatedAt: now},
+	}
+	for _, e := range entries {
+		require.NoError(t, store.Put(ctx, e))
+	}
+
+	// A new store in the same directory sees the same entries, as after a
+	// restart.
+	store, err = NewFileStore(dir)
+	require.NoError(t, err)
+
+	due, err := store.Due(ctx, now, 10)
+	require.NoError(t, err)
+	if assert.Len(t, due, 2) {
+		assert.Equal(t, "first", due[0].ID)
+		assert.Equal(t, "second", due[1].ID)
+		assert.JSONEq(t, `{"Body":"1"}`, string(due[0].Payload))
+	}
+
+	due, err = store.Due(ctx, now, 1)
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	assert.NoError(t, store.Delete(ctx, "first"))
+	assert.NoError(t, store.Delete(ctx, "first"))
+
+	due, err = store.Due(ctx, now.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Len(t, due, 2)
+
+	// No temporary files are left behind.
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestFileStoreCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var logged []error
+	store := &FileStore{Dir: dir, ErrorLog: func(err error) { logged = append(logged, err) }}
+	require.NoError(t, store.Put(ctx, &Entry{ID: "good", Kind: "sms", NextAttempt: now}))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{"ID": "bad", "Kin`), 0o600))
+
+	due, err := store.Due(ctx, now, 10)
+	require.NoError(t, err)
+	if assert.Len(t, due, 1) {
+		assert.Equal(t, "good", due[0].ID)
+	}
+	assert.Len(t, logged, 1)
+	assert.FileExists(t, filepath.Join(dir, "bad.json.corrupt"))
+
+	// The corrupt file is not read again.
+	_, err = store.Due(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Len(t, logged, 1)
+}
+
+func TestMemoryStoreCopies(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	e := &Entry{ID: "id"}
+	require.NoError(t, store.Put(ctx, e))
+	e.Attempts = 5
+
+	due, err := store.Due(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Zero(t, due[0].Attempts)
+}
+
+func TestEncryptedStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "queue")
+	ctx := context.Background()
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	fileStore, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	key, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	cipher, err := encryption.NewAESGCM(key)
+	require.NoError(t, err)
+
+	// An entry put before encryption was enabled.
+	require.NoError(t, fileStore.Put(ctx, &Entry{ID: "plain", Payload: json.RawMessage(`{"Body":"1"}`), CreatedAt: now}))
+
+	store := &EncryptedStore{Store: fileStore, Cipher: cipher, AllowPlaintext: true}
+	e := &Entry{ID: "encrypted", Payload: json.RawMessage(`{"Body":"secret"}`), CreatedAt: now.Add(time.Second)}
+	require.NoError(t, store.Put(ctx, e))
+	assert.JSONEq(t, `{"Body":"secret"}`, string(e.Payload), "the entry is not changed")
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "encrypted.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "secret")
+
+	due, err := store.Due(ctx, now, 10)
+	require.NoError(t, err)
+	if assert.Len(t, due, 2) {
+		assert.JSONEq(t, `{"Body":"1"}`, string(due[0].Payload))
+		assert.JSONEq(t, `{"Body":"secret"}`, string(due[1].Payload))
+	}
+
+	// Without AllowPlaintext, the plain entry is refused.
+	store.AllowPlaintext = false
+	due, err = store.Due(ctx, now, 10)
+	require.NoError(t, err)
+	if assert.Len(t, due, 2) {
+		assert.True(t, errors.Is(due[0].Err, encryption.ErrNotSealed))
+		assert.NoError(t, due[1].Err)
+	}
+
+	// With another key, the payload can not be read.
+	key, err = encryption.GenerateKey()
+	require.NoError(t, err)
+	store.Cipher, err = encryption.NewAESGCM(key)
+	require.NoError(t, err)
+
+	due, err = store.Due(ctx, now, 10)
+	require.NoError(t, err)
+	if assert.Len(t, due, 2) {
+		assert.True(t, errors.Is(due[1].Err, encryption.ErrDecrypt))
+	}
+}
+
+func TestEncryptedStoreMovedPayload(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	key, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	cipher, err := encryption.NewAESGCM(key)
+	require.NoError(t, err)
+
+	memory := NewMemoryStore()
+	store := &EncryptedStore{Store: memory, Cipher: cipher}
+	require.NoError(t, store.Put(ctx, &Entry{ID: "original", Payload: json.RawMessage(`{"Body":"secret"}`), CreatedAt: now}))
+
+	// Someone with access to the store copies the payload to another entry.
+	stored, err := memory.Due(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.NoError(t, memory.Put(ctx, &Entry{ID: "moved", Payload: stored[0].Payload, CreatedAt: now.Add(time.Second)}))
+
+	due, err := store.Due(ctx, now, 10)
+	require.NoError(t, err)
+	if assert.Len(t, due, 2) {
+		assert.NoError(t, due[0].Err)
+		assert.JSONEq(t, `{"Body":"secret"}`, string(due[0].Payload))
+		assert.True(t, errors.Is(due[1].Err, encryption.ErrDecrypt))
+	}
+}
@@ -0,0 +1,96 @@
+package messagebird
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// KeyType is the kind of an access key. Requests with a test key are not
+// charged and do not send messages, but not every endpoint accepts them.
+type KeyType int
+
+const (
+	// KeyTypeUnknown is the type of keys without a known prefix.
+	KeyTypeUnknown KeyType = iota
+	KeyTypeTest
+	KeyTypeLive
+)
+
+func (t KeyType) String() string {
+	switch t {
+	case KeyTypeTest:
+		return "test"
+	case KeyTypeLive:
+		return "live"
+	default:
+		return "unknown"
+	}
+}
+
+// KeyTypeOf returns the type of accessKey by its prefix.
+func KeyTypeOf(accessKey string) KeyType {
+	switch {
+	case strings.HasPrefix(accessKey, "test_"):
+		return KeyTypeTest
+	case strings.HasPrefix(accessKey, "live_"):
+		return KeyTypeLive
+	default:
+		return KeyTypeUnknown
+	}
+}
+
+// KeyType returns the type of the access key of c.
+func (c *DefaultClient) KeyType() KeyType {
+	return KeyTypeOf(c.AccessKey)
+}
+
+// ErrTestKeyRejected is matched by errors.Is for requests with a test access
+// key that the API rejected because of the key.
+var ErrTestKeyRejected = errors.New("test access key rejected")
+
+// TestKeyError is the error of a request with a test access key that the API
+// rejected because of the key, typically because the endpoint is not
+// available for test keys. DefaultClient returns it, wrapped in a
+// RequestError, when WrapErrors is set.
+type TestKeyError struct {
+	Err error
+}
+
+// Error implements error interface.
+func (e *TestKeyError) Error() string {
+	return fmt.Sprintf("%v, the endpoint may not be available for test keys, use a live key: %v", ErrTestKeyRejected, e.Err)
+}
+
+// Unwrap returns the error the API responded with.
+func (e *TestKeyError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTestKeyRejected.
+func (e *TestKeyError) Is(target error) bool {
+	return target == ErrTestKeyRejected
+}
+
+// isKeyRejected reports whether a request failed with status and err because
+// of its access key.
+func isKeyRejected(status int, err error) bool {
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return true
+	}
+
+	var errorResponse ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		return false
+	}
+
+	for _, e := range errorResponse.Errors {
+		// Request not allowed (incorrect access_key).
+		if e.Code == 2 {
+			return true
+		}
+	}
+
+	return false
+}
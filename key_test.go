@@ -0,0 +1,36 @@
+package messagebird
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyTypeOf(t *testing.T) {
+	assert.Equal(t, KeyTypeTest, KeyTypeOf("test_gshuPaZoeEG6ovbc8M79w0QyM"))
+	assert.Equal(t, KeyTypeLive, KeyTypeOf("live_gshuPaZoeEG6ovbc8M79w0QyM"))
+	assert.Equal(t, KeyTypeUnknown, KeyTypeOf("gshuPaZoeEG6ovbc8M79w0QyM"))
+	assert.Equal(t, "test", New("test_key").KeyType().String())
+}
+
+func TestTestKeyError(t *testing.T) {
+	c := newTestClient(http.StatusUnauthorized, `{"errors":[{"code":2,"description":"Request not allowed (incorrect access_key)","parameter":"access_key"}]}`)
+	c.WrapErrors = true
+
+	err := c.Request(nil, http.MethodGet, "https://conversations.messagebird.com/v1/conversations", nil)
+	assert.True(t, errors.Is(err, ErrTestKeyRejected))
+	assert.EqualError(t, err, "GET https://conversations.messagebird.com/v1/conversations: status 401: test access key rejected, the endpoint may not be available for test keys, use a live key: API errors: Request not allowed (incorrect access_key)")
+
+	var errorResponse ErrorResponse
+	assert.True(t, errors.As(err, &errorResponse))
+
+	// Live keys and other errors are not affected.
+	c.AccessKey = "live_key"
+	assert.False(t, errors.Is(c.Request(nil, http.MethodGet, "balance", nil), ErrTestKeyRejected))
+
+	c = newTestClient(http.StatusUnprocessableEntity, `{"errors":[{"code":9,"description":"no (correct) recipients found"}]}`)
+	c.WrapErrors = true
+	assert.False(t, errors.Is(c.Request(nil, http.MethodPost, "messages", nil), ErrTestKeyRejected))
+}
@@ -0,0 +1,183 @@
+package lookup
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// DefaultCacheTTL is how long a cached lookup is considered valid when no TTL
+// is provided to NewCache. Formatting and validity data for a phone number
+// rarely change, so a day is a reasonable default.
+const DefaultCacheTTL = 24 * time.Hour
+
+// Store persists lookup results for a Cache. Implementations must be safe for
+// concurrent use.
+//
+// A Redis backed store can be as small as:
+//
+//	type redisStore struct{ rdb *redis.Client }
+//
+//	func (s redisStore) Get(key string) (*lookup.Lookup, bool) {
+//		b, err := s.rdb.Get(ctx, "lookup:"+key).Bytes()
+//		if err != nil {
+//			return nil, false
+//		}
+//		l := &lookup.Lookup{}
+//		return l, json.Unmarshal(b, l) == nil
+//	}
+//
+//	func (s redisStore) Set(key string, l *lookup.Lookup, ttl time.Duration) {
+//		b, _ := json.Marshal(l)
+//		s.rdb.Set(ctx, "lookup:"+key, b, ttl)
+//	}
+type Store interface {
+	// Get returns the lookup stored for key, if it exists and has not
+	// expired.
+	Get(key string) (*Lookup, bool)
+
+	// Set stores lookup for key. It should expire after ttl.
+	Set(key string, lookup *Lookup, ttl time.Duration)
+}
+
+// Cache wraps Read so repeated lookups of the same phone number are served
+// from a Store instead of the API. HLR data is never cached, as it describes
+// the live state of a subscriber.
+type Cache struct {
+	store Store
+	ttl   time.Duration
+}
+
+// NewCache creates a Cache backed by store. If store is nil, an in-memory
+// store is used. If ttl is zero, DefaultCacheTTL is used.
+func NewCache(store Store, ttl time.Duration) *Cache {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Cache{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Read returns the cached lookup for phoneNumber, or performs a new lookup
+// and caches it. Failed lookups are not cached. Cached lookups are returned
+// as copies, so callers may modify them.
+func (c *Cache) Read(client messagebird.Client, phoneNumber string, params *Params) (*Lookup, error) {
+	key := cacheKey(phoneNumber, params)
+	if lookup, ok := c.store.Get(key); ok {
+		return copyLookup(lookup), nil
+	}
+
+	lookup, err := Read(client, phoneNumber, params)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := *lookup
+	cached.HLR = nil
+	c.store.Set(key, &cached, c.ttl)
+
+	return lookup, nil
+}
+
+// copyLookup returns a copy of lookup that shares no memory with it.
+func copyLookup(lookup *Lookup) *Lookup {
+	c := *lookup
+	if lookup.HLR != nil {
+		h := *lookup.HLR
+		if h.Details != nil {
+			h.Details = make(map[string]interface{}, len(lookup.HLR.Details))
+			for k, v := range lookup.HLR.Details {
+				h.Details[k] = v
+			}
+		}
+		if h.CreatedDatetime != nil {
+			t := *h.CreatedDatetime
+			h.CreatedDatetime = &t
+		}
+		if h.StatusDatetime != nil {
+			t := *h.StatusDatetime
+			h.StatusDatetime = &t
+		}
+		c.HLR = &h
+	}
+
+	return &c
+}
+
+// cacheKey builds the key a lookup is stored under. The reference does not
+// influence the result, so only the country code is taken into account.
+func cacheKey(phoneNumber string, params *Params) string {
+	if params == nil || params.CountryCode == "" {
+		return phoneNumber
+	}
+
+	return strings.ToUpper(params.CountryCode) + ":" + phoneNumber
+}
+
+// MemoryStore is an in-memory Store. Expired entries are removed when they
+// are read.
+type MemoryStore struct {
+	// Clock determines when entries expire. Defaults to
+	// messagebird.SystemClock.
+	Clock messagebird.Clock
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	lookup    *Lookup
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(key string) (*Lookup, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if s.now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false
+	}
+
+	return entry.lookup, true
+}
+
+// Set implements Store.
+func (s *MemoryStore) Set(key string, lookup *Lookup, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = memoryEntry{
+		lookup:    lookup,
+		expiresAt: s.now().Add(ttl),
+	}
+}
+
+func (s *MemoryStore) now() time.Time {
+	if s.Clock == nil {
+		return messagebird.SystemClock.Now()
+	}
+
+	return s.Clock.Now()
+}
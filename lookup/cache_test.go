@@ -0,0 +1,130 @@
+package lookup
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheRead(t *testing.T) {
+	calls := 0
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		calls++
+		l := v.(*Lookup)
+		l.Href = path
+		l.HLR = &hlr.HLR{ID: "hlr-id"}
+		return nil
+	})
+
+	cache := NewCache(nil, time.Hour)
+
+	first, err := cache.Read(client, "31612345678", &Params{CountryCode: "nl"})
+	assert.NoError(t, err)
+	assert.NotNil(t, first.HLR)
+
+	second, err := cache.Read(client, "31612345678", &Params{CountryCode: "NL", Reference: "other"})
+	assert.NoError(t, err)
+	assert.Equal(t, first.Href, second.Href)
+	assert.Nil(t, second.HLR)
+
+	assert.Equal(t, 1, calls)
+
+	_, err = cache.Read(client, "31612345678", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheReadCopy(t *testing.T) {
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		l := v.(*Lookup)
+		l.Href = path
+		l.Formats.E164 = "+31612345678"
+		return nil
+	})
+
+	cache := NewCache(nil, time.Hour)
+
+	first, err := cache.Read(client, "31612345678", nil)
+	assert.NoError(t, err)
+	first.Formats.E164 = "changed"
+
+	second, err := cache.Read(client, "31612345678", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "+31612345678", second.Formats.E164)
+	second.Href = "changed"
+	second.Formats.E164 = "changed"
+
+	third, err := cache.Read(client, "31612345678", nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "changed", third.Href)
+	assert.Equal(t, "+31612345678", third.Formats.E164)
+}
+
+func TestCopyLookup(t *testing.T) {
+	created := time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)
+	l := &Lookup{
+		Formats: Formats{E164: "+31612345678"},
+		HLR: &hlr.HLR{
+			Details:         map[string]interface{}{"ported": false},
+			CreatedDatetime: &created,
+		},
+	}
+
+	c := copyLookup(l)
+	c.Formats.E164 = "changed"
+	c.HLR.Details["ported"] = true
+	*c.HLR.CreatedDatetime = time.Time{}
+
+	assert.Equal(t, "+31612345678", l.Formats.E164)
+	assert.Equal(t, false, l.HLR.Details["ported"])
+	assert.Equal(t, 2022, l.HLR.CreatedDatetime.Year())
+}
+
+func TestCacheReadError(t *testing.T) {
+	calls := 0
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		calls++
+		return errors.New("boom")
+	})
+
+	cache := NewCache(nil, 0)
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.Read(client, "31612345678", nil)
+		assert.EqualError(t, err, "boom")
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	store := NewMemoryStore()
+
+	store.Set("key", &Lookup{Href: "href"}, time.Hour)
+	l, ok := store.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "href", l.Href)
+
+	store.Set("key", &Lookup{}, -time.Second)
+	_, ok = store.Get("key")
+	assert.False(t, ok)
+}
+
+func TestMemoryStoreClock(t *testing.T) {
+	clock := messagebirdtest.NewFakeClock(time.Date(2021, 7, 5, 12, 0, 0, 0, time.UTC))
+	store := NewMemoryStore()
+	store.Clock = clock
+
+	store.Set("key", &Lookup{}, time.Hour)
+	clock.Advance(59 * time.Minute)
+	_, ok := store.Get("key")
+	assert.True(t, ok)
+
+	clock.Advance(2 * time.Minute)
+	_, ok = store.Get("key")
+	assert.False(t, ok)
+}
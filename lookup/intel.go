@@ -0,0 +1,103 @@
+package lookup
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/hlr"
+)
+
+// HLRPollInterval is the time ReadWithHLR waits between checking whether an
+// HLR lookup has completed.
+var HLRPollInterval = time.Second
+
+// NumberIntel combines the results of a lookup and an HLR lookup for a single
+// phone number.
+type NumberIntel struct {
+	Lookup *Lookup
+	HLR    *hlr.HLR
+
+	// Valid is true if the number is known to its network.
+	Valid bool
+
+	// Reachable is true if the subscriber is currently connected to the
+	// network.
+	Reachable bool
+
+	Type NumberType
+
+	// Network is the MCCMNC of the network the subscriber is currently
+	// connected to.
+	Network int
+
+	// Ported is true if the number was moved from its original network to
+	// another one.
+	Ported bool
+
+	// Roaming is true if the subscriber is connected to a network in another
+	// country than the one of its home network.
+	Roaming bool
+}
+
+// ReadWithHLR performs a lookup for phoneNumber, triggers an HLR lookup and
+// waits until it completes. The results are merged into a NumberIntel.
+//
+// The HLR is polled every HLRPollInterval until it reaches a final status or
+// ctx is done. In the latter case, ctx.Err() is returned.
+func ReadWithHLR(ctx context.Context, c messagebird.Client, phoneNumber string, params *Params) (*NumberIntel, error) {
+	lookup, err := Read(c, phoneNumber, params)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := CreateHLR(c, phoneNumber, params)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := messagebird.ClockOf(c)
+	for !h.IsFinal() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-clock.After(HLRPollInterval):
+		}
+
+		if h, err = ReadHLR(c, phoneNumber, params); err != nil {
+			return nil, err
+		}
+	}
+
+	return newNumberIntel(lookup, h), nil
+}
+
+func newNumberIntel(lookup *Lookup, h *hlr.HLR) *NumberIntel {
+	return &NumberIntel{
+		Lookup:    lookup,
+		HLR:       h,
+		Valid:     h.Status == hlr.StatusActive || h.Status == hlr.StatusAbsent,
+		Reachable: h.Status == hlr.StatusActive,
+		Type:      lookup.Type,
+		Network:   h.Network,
+		Ported:    detailFlag(h.Details, "ported"),
+		Roaming:   detailFlag(h.Details, "roaming"),
+	}
+}
+
+// detailFlag reads a boolean from the HLR details. Depending on the network,
+// flags are returned as booleans, numbers or strings.
+func detailFlag(details map[string]interface{}, key string) bool {
+	switch val := details[key].(type) {
+	case bool:
+		return val
+	case float64:
+		return val != 0
+	case string:
+		b, err := strconv.ParseBool(val)
+		return err == nil && b
+	}
+
+	return false
+}
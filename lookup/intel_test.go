@@ -0,0 +1,81 @@
+package lookup
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadWithHLR(t *testing.T) {
+	defer func(d time.Duration) { HLRPollInterval = d }(HLRPollInterval)
+	HLRPollInterval = time.Millisecond
+
+	hlrReads := 0
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		switch val := v.(type) {
+		case *Lookup:
+			val.Type = NumberTypeMobile
+		case *hlr.HLR:
+			val.Network = 20416
+			val.Status = hlr.StatusSent
+			if method == http.MethodGet {
+				hlrReads++
+				if hlrReads == 2 {
+					val.Status = hlr.StatusActive
+					val.Details = map[string]interface{}{
+						"ported":  float64(1),
+						"roaming": "false",
+					}
+				}
+			}
+		}
+		return nil
+	})
+
+	intel, err := ReadWithHLR(context.Background(), client, "31612345678", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, hlrReads)
+
+	assert.True(t, intel.Valid)
+	assert.True(t, intel.Reachable)
+	assert.Equal(t, NumberTypeMobile, intel.Type)
+	assert.Equal(t, 20416, intel.Network)
+	assert.True(t, intel.Ported)
+	assert.False(t, intel.Roaming)
+}
+
+func TestReadWithHLRContextDone(t *testing.T) {
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		if h, ok := v.(*hlr.HLR); ok {
+			h.Status = hlr.StatusSent
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := ReadWithHLR(ctx, client, "31612345678", nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestDetailFlag(t *testing.T) {
+	details := map[string]interface{}{
+		"bool":   true,
+		"number": float64(0),
+		"string": "1",
+		"bogus":  []string{},
+	}
+
+	assert.True(t, detailFlag(details, "bool"))
+	assert.False(t, detailFlag(details, "number"))
+	assert.True(t, detailFlag(details, "string"))
+	assert.False(t, detailFlag(details, "bogus"))
+	assert.False(t, detailFlag(details, "missing"))
+	assert.False(t, detailFlag(nil, "missing"))
+}
@@ -3,8 +3,8 @@ package lookup
 import (
 	messagebird "github.com/messagebird/go-rest-api/v9"
 	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
 	"net/http"
-	"net/url"
 )
 
 // Formats represents phone number in multiple formats.
@@ -21,7 +21,7 @@ type Lookup struct {
 	CountryCode   string
 	CountryPrefix int
 	PhoneNumber   int64
-	Type          string
+	Type          NumberType
 	Formats       Formats
 	HLR           *hlr.HLR
 }
@@ -37,7 +37,7 @@ func (p *Params) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
 	if p.CountryCode != "" {
 		query.Set("countryCode", p.CountryCode)
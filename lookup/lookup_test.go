@@ -64,3 +64,21 @@ func TestCreateHLR(t *testing.T) {
 
 	checkHLR(t, hlr)
 }
+
+func TestReadTypes(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "lookupObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	lookup, err := Read(client, "31624971134", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, NumberTypeMobile, lookup.Type)
+	assert.True(t, lookup.IsMobile())
+	assert.False(t, lookup.IsFixedLine())
+	assert.False(t, lookup.IsVoIP())
+
+	assert.Equal(t, "+31624971134", lookup.E164())
+	assert.Equal(t, "06 24971134", lookup.Formats.Get(FormatNational))
+	assert.Equal(t, "tel:+31-6-24971134", lookup.Formats.Get(FormatRFC3966))
+	assert.Equal(t, "", lookup.Formats.Get(Format("unknown")))
+}
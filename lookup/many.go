@@ -0,0 +1,217 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+)
+
+// DefaultReadManyConcurrency is the number of lookups ReadMany performs in
+// parallel when no concurrency is set in ReadManyOptions.
+const DefaultReadManyConcurrency = 5
+
+// Defaults for retrying lookups that were rate limited, see ReadManyOptions.
+const (
+	DefaultReadManyRateLimitRetries = 3
+	DefaultReadManyRateLimitBackoff = time.Second
+)
+
+// ReadManyOptions configures the behaviour of ReadMany.
+type ReadManyOptions struct {
+	// Params are sent along with every lookup.
+	Params *Params
+
+	// Concurrency is the maximum number of lookups in flight at any time.
+	// Defaults to DefaultReadManyConcurrency.
+	Concurrency int
+
+	// Interval is the minimum time between starting two lookups. Use it to
+	// stay below the rate limit of your account. Zero disables pacing.
+	Interval time.Duration
+
+	// Normalize converts the phone numbers to E.164 before looking them up,
+	// using Params.CountryCode as the country for national numbers. Inputs
+	// that resolve to the same number are looked up once. Inputs that can not
+	// be normalized are not looked up at all.
+	Normalize bool
+
+	// Cache, if set, is consulted before hitting the API and filled with the
+	// results of new lookups.
+	Cache *Cache
+
+	// RateLimitRetries is the number of times a lookup is retried when the
+	// API responds with 429 Too Many Requests. Defaults to
+	// DefaultReadManyRateLimitRetries. A negative number disables retries.
+	RateLimitRetries int
+
+	// RateLimitBackoff is the delay before the first retry of a rate limited
+	// lookup. It doubles for every following one. Defaults to
+	// DefaultReadManyRateLimitBackoff.
+	RateLimitBackoff time.Duration
+}
+
+// Result holds the outcome of a single lookup performed by ReadMany. Exactly
+// one of Lookup and Err is set.
+type Result struct {
+	Lookup *Lookup
+	Err    error
+}
+
+// ReadMany performs a lookup for every phone number in phoneNumbers and returns
+// the results keyed by the phone number as it was provided. Duplicate phone
+// numbers are looked up only once. A failing lookup does not stop the others:
+// its error is stored in the corresponding Result.
+//
+// Lookups that are rate limited are retried after a backoff, see
+// ReadManyOptions.RateLimitRetries.
+//
+// When ctx is cancelled, no new lookups are started and ctx.Err() is returned
+// alongside the results that were gathered so far.
+func ReadMany(ctx context.Context, c messagebird.Client, phoneNumbers []string, opts *ReadManyOptions) (map[string]*Result, error) {
+	if opts == nil {
+		opts = &ReadManyOptions{}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultReadManyConcurrency
+	}
+
+	retries := opts.RateLimitRetries
+	if retries == 0 {
+		retries = DefaultReadManyRateLimitRetries
+	}
+	backoff := opts.RateLimitBackoff
+	if backoff <= 0 {
+		backoff = DefaultReadManyRateLimitBackoff
+	}
+
+	results := make(map[string]*Result, len(phoneNumbers))
+
+	// inputs maps the phone number that is looked up to all inputs that
+	// resolved to it, so each distinct number is only looked up once.
+	inputs := make(map[string][]string, len(phoneNumbers))
+	var targets []string
+	for _, phoneNumber := range phoneNumbers {
+		target := phoneNumber
+		if opts.Normalize {
+			var err error
+			if target, err = numberutil.MSISDN(phoneNumber, countryCode(opts.Params)); err != nil {
+				results[phoneNumber] = &Result{Err: err}
+				continue
+			}
+		}
+
+		if _, ok := inputs[target]; !ok {
+			targets = append(targets, target)
+		}
+		inputs[target] = append(inputs[target], phoneNumber)
+	}
+
+	clock := messagebird.ClockOf(c)
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+
+	for i, target := range targets {
+		if opts.Interval > 0 && i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-clock.After(opts.Interval):
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(target string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			read := func() (*Lookup, error) {
+				if opts.Cache != nil {
+					return opts.Cache.Read(c, target, opts.Params)
+				}
+				return Read(c, target, opts.Params)
+			}
+
+			lookup, err := read()
+			for retry := 0; retry < retries && isRateLimited(err); retry++ {
+				if !wait(ctx, clock, backoff<<retry) {
+					break
+				}
+
+				lookup, err = read()
+			}
+
+			mu.Lock()
+			for _, input := range inputs[target] {
+				results[input] = &Result{Lookup: lookup, Err: err}
+			}
+			mu.Unlock()
+		}(target)
+	}
+
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+// wait waits for d to pass on clock. It returns false if ctx is done first.
+func wait(ctx context.Context, clock messagebird.Clock, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-clock.After(d):
+		return true
+	}
+}
+
+// isRateLimited reports whether err means the API responded with 429 Too
+// Many Requests.
+func isRateLimited(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var requestErr *messagebird.RequestError
+	if errors.As(err, &requestErr) && requestErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	var errorResponse messagebird.ErrorResponse
+	if errors.As(err, &errorResponse) {
+		for _, e := range errorResponse.Errors {
+			if e.Code == http.StatusTooManyRequests {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func countryCode(params *Params) string {
+	if params == nil {
+		return ""
+	}
+
+	return params.CountryCode
+}
@@ -0,0 +1,168 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMany(t *testing.T) {
+	var calls int32
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		atomic.AddInt32(&calls, 1)
+
+		if strings.Contains(path, "invalid") {
+			return errors.New("invalid number")
+		}
+
+		v.(*Lookup).Href = path
+		return nil
+	})
+
+	results, err := ReadMany(context.Background(), client, []string{"31612345678", "invalid", "31612345678", "31687654321"}, &ReadManyOptions{
+		Params:      &Params{CountryCode: "NL"},
+		Concurrency: 2,
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, calls)
+	assert.Len(t, results, 3)
+
+	assert.NoError(t, results["31612345678"].Err)
+	assert.Equal(t, "lookup/31612345678?countryCode=NL", results["31612345678"].Lookup.Href)
+	assert.NoError(t, results["31687654321"].Err)
+
+	assert.Nil(t, results["invalid"].Lookup)
+	assert.EqualError(t, results["invalid"].Err, "invalid number")
+}
+
+func TestReadManyConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	release := make(chan struct{})
+
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		<-release
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	})
+
+	go func() {
+		for i := 0; i < 6; i++ {
+			release <- struct{}{}
+		}
+	}()
+
+	results, err := ReadMany(context.Background(), client, []string{"1", "2", "3", "4", "5", "6"}, &ReadManyOptions{Concurrency: 2})
+	assert.NoError(t, err)
+	assert.Len(t, results, 6)
+	assert.LessOrEqual(t, maxInFlight, 2)
+}
+
+func TestReadManyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		t.Fatal("no lookups expected after cancellation")
+		return nil
+	})
+
+	results, err := ReadMany(ctx, client, []string{"31612345678"}, nil)
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, results)
+}
+
+func TestReadManyNormalize(t *testing.T) {
+	var paths []string
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		paths = append(paths, path)
+		return nil
+	})
+
+	results, err := ReadMany(context.Background(), client, []string{"06 12345678", "+31612345678", "abc"}, &ReadManyOptions{
+		Params:      &Params{CountryCode: "NL"},
+		Concurrency: 1,
+		Normalize:   true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"lookup/31612345678?countryCode=NL"}, paths)
+
+	assert.Len(t, results, 3)
+	assert.NoError(t, results["06 12345678"].Err)
+	assert.Same(t, results["06 12345678"].Lookup, results["+31612345678"].Lookup)
+	assert.Error(t, results["abc"].Err)
+}
+
+func TestReadManyRateLimited(t *testing.T) {
+	var calls int32
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			return messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: http.StatusTooManyRequests}}}
+		}
+		v.(*Lookup).Href = path
+		return nil
+	})
+
+	results, err := ReadMany(context.Background(), client, []string{"31612345678"}, &ReadManyOptions{
+		RateLimitBackoff: time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, results["31612345678"].Err)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestReadManyRateLimitedGivesUp(t *testing.T) {
+	var calls int32
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		atomic.AddInt32(&calls, 1)
+		return &messagebird.RequestError{StatusCode: http.StatusTooManyRequests, Err: errors.New("too many requests")}
+	})
+
+	results, err := ReadMany(context.Background(), client, []string{"31612345678"}, &ReadManyOptions{
+		RateLimitRetries: 2,
+		RateLimitBackoff: time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.Error(t, results["31612345678"].Err)
+	assert.Equal(t, int32(3), calls)
+
+	calls = 0
+	_, err = ReadMany(context.Background(), client, []string{"31612345678"}, &ReadManyOptions{RateLimitRetries: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestReadManyRateLimitedCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int32
+	client := mbtest.ClientFunc(func(v interface{}, method, path string, data interface{}) error {
+		atomic.AddInt32(&calls, 1)
+		cancel()
+		return messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: http.StatusTooManyRequests}}}
+	})
+
+	results, err := ReadMany(ctx, client, []string{"31612345678"}, &ReadManyOptions{RateLimitBackoff: time.Hour})
+	assert.Equal(t, context.Canceled, err)
+	assert.Error(t, results["31612345678"].Err)
+	assert.Equal(t, int32(1), calls)
+}
@@ -0,0 +1,143 @@
+package lookup
+
+import (
+	"net/http"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+// riskPath represents the path to the Risk resource within the lookup
+// resource.
+const riskPath = "risk"
+
+// RiskLevel summarizes the Score of a Risk.
+type RiskLevel string
+
+const (
+	RiskLevelLow    RiskLevel = "low"
+	RiskLevelMedium RiskLevel = "medium"
+	RiskLevelHigh   RiskLevel = "high"
+)
+
+// RiskIndicator is a signal that contributed to the Score of a Risk.
+type RiskIndicator string
+
+const (
+	// RiskIndicatorRecentSIMSwap means the SIM card of the number was
+	// replaced within the period of RiskParams.SIMSwapPeriod.
+	RiskIndicatorRecentSIMSwap RiskIndicator = "recent_sim_swap"
+
+	// RiskIndicatorRecentPort means the number was recently moved to
+	// another network.
+	RiskIndicatorRecentPort RiskIndicator = "recent_port"
+
+	// RiskIndicatorDisposable means the number is offered by a temporary or
+	// virtual number service.
+	RiskIndicatorDisposable RiskIndicator = "disposable_number"
+
+	RiskIndicatorVoIP        RiskIndicator = "voip"
+	RiskIndicatorInactive    RiskIndicator = "inactive"
+	RiskIndicatorSpamReports RiskIndicator = "spam_reports"
+)
+
+// Risk is the fraud risk of a phone number, for deciding whether logins and
+// payments need additional verification.
+type Risk struct {
+	Href        string
+	PhoneNumber int64
+
+	// Score ranges from 0, no known risk, to 100.
+	Score      int
+	Level      RiskLevel
+	Indicators []RiskIndicator
+
+	// SIMSwap and Reputation are nil if the network of the number does not
+	// provide them.
+	SIMSwap    *SIMSwap
+	Reputation *Reputation
+
+	CheckedDatetime *time.Time
+}
+
+// SIMSwap holds when the SIM card of a number was last replaced, which
+// attackers do to take over accounts secured by SMS.
+type SIMSwap struct {
+	Swapped          bool
+	LastSwapDatetime *time.Time
+}
+
+// Reputation holds what is known about the use of a number.
+type Reputation struct {
+	SpamReports       int
+	FirstSeenDatetime *time.Time
+}
+
+// RiskParams provide additional risk lookup options.
+type RiskParams struct {
+	CountryCode string
+	Reference   string
+
+	// SIMSwapPeriod is the period in which a SIM swap is considered recent.
+	// It is rounded down to whole hours. The API's default is used if it is
+	// zero.
+	SIMSwapPeriod time.Duration
+}
+
+func (p *RiskParams) QueryParams() string {
+	if p == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if p.CountryCode != "" {
+		query.Set("countryCode", p.CountryCode)
+	}
+
+	if p.Reference != "" {
+		query.Set("reference", p.Reference)
+	}
+
+	if hours := int(p.SIMSwapPeriod / time.Hour); hours > 0 {
+		query.SetInt("simSwapPeriod", hours)
+	}
+
+	return query.Encode()
+}
+
+// ReadRisk performs a fraud risk lookup for the specified number. Which
+// indicators are available depends on the country and network of the number.
+func ReadRisk(c messagebird.Client, phoneNumber string, params *RiskParams) (*Risk, error) {
+	path := lookupPath + "/" + phoneNumber + "/" + riskPath + "?" + params.QueryParams()
+
+	risk := &Risk{}
+	if err := c.Request(risk, http.MethodGet, path, nil); err != nil {
+		return nil, err
+	}
+
+	return risk, nil
+}
+
+// Has reports whether indicator contributed to the score of r.
+func (r *Risk) Has(indicator RiskIndicator) bool {
+	for _, i := range r.Indicators {
+		if i == indicator {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AtLeast reports whether the Level of r is level or higher.
+func (r *Risk) AtLeast(level RiskLevel) bool {
+	return riskLevelRank[r.Level] >= riskLevelRank[level]
+}
+
+var riskLevelRank = map[RiskLevel]int{
+	RiskLevelLow:    1,
+	RiskLevelMedium: 2,
+	RiskLevelHigh:   3,
+}
@@ -0,0 +1,44 @@
+package lookup
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadRisk(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "lookupRiskObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	risk, err := ReadRisk(client, "31624971134", &RiskParams{
+		CountryCode:   "NL",
+		SIMSwapPeriod: 72 * time.Hour,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 72, risk.Score)
+	assert.Equal(t, RiskLevelHigh, risk.Level)
+	assert.True(t, risk.SIMSwap.Swapped)
+	assert.Equal(t, time.Date(2022, 6, 14, 22, 10, 0, 0, time.UTC), *risk.SIMSwap.LastSwapDatetime)
+	assert.Equal(t, 0, risk.Reputation.SpamReports)
+
+	assert.True(t, risk.Has(RiskIndicatorRecentSIMSwap))
+	assert.False(t, risk.Has(RiskIndicatorDisposable))
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/lookup/31624971134/risk")
+	mbtest.AssertQuery(t, url.Values{
+		"countryCode":   {"NL"},
+		"simSwapPeriod": {"72"},
+	})
+}
+
+func TestRiskAtLeast(t *testing.T) {
+	risk := &Risk{Level: RiskLevelMedium}
+
+	assert.True(t, risk.AtLeast(RiskLevelLow))
+	assert.True(t, risk.AtLeast(RiskLevelMedium))
+	assert.False(t, risk.AtLeast(RiskLevelHigh))
+}
@@ -0,0 +1,40 @@
+package lookup
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/hlr"
+)
+
+// Service is the Lookup API bound to a client, see sms.Service.
+type Service interface {
+	Read(phoneNumber string, params *Params) (*Lookup, error)
+	CreateHLR(phoneNumber string, params *Params) (*hlr.HLR, error)
+	ReadHLR(phoneNumber string, params *Params) (*hlr.HLR, error)
+	ReadRisk(phoneNumber string, params *RiskParams) (*Risk, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Read(phoneNumber string, params *Params) (*Lookup, error) {
+	return Read(s.c, phoneNumber, params)
+}
+
+func (s *service) CreateHLR(phoneNumber string, params *Params) (*hlr.HLR, error) {
+	return CreateHLR(s.c, phoneNumber, params)
+}
+
+func (s *service) ReadHLR(phoneNumber string, params *Params) (*hlr.HLR, error) {
+	return ReadHLR(s.c, phoneNumber, params)
+}
+
+func (s *service) ReadRisk(phoneNumber string, params *RiskParams) (*Risk, error) {
+	return ReadRisk(s.c, phoneNumber, params)
+}
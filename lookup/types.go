@@ -0,0 +1,70 @@
+package lookup
+
+// NumberType is the type of phone number as determined by the lookup.
+type NumberType string
+
+const (
+	NumberTypeFixedLine             NumberType = "fixed line"
+	NumberTypeMobile                NumberType = "mobile"
+	NumberTypeFixedLineOrMobile     NumberType = "fixed line or mobile"
+	NumberTypeTollFree              NumberType = "toll free"
+	NumberTypePremiumRate           NumberType = "premium rate"
+	NumberTypeSharedCost            NumberType = "shared cost"
+	NumberTypeVoIP                  NumberType = "voip"
+	NumberTypePersonalNumber        NumberType = "personal number"
+	NumberTypePager                 NumberType = "pager"
+	NumberTypeUniversalAccessNumber NumberType = "universal access number"
+	NumberTypeVoiceMail             NumberType = "voice mail"
+	NumberTypeUnknown               NumberType = "unknown"
+)
+
+// Format identifies one of the representations in Formats.
+type Format string
+
+const (
+	FormatE164          Format = "e164"
+	FormatInternational Format = "international"
+	FormatNational      Format = "national"
+	FormatRFC3966       Format = "rfc3966"
+)
+
+// Get returns the phone number in the requested format, or an empty string if
+// the format is unknown.
+func (f Formats) Get(format Format) string {
+	switch format {
+	case FormatE164:
+		return f.E164
+	case FormatInternational:
+		return f.International
+	case FormatNational:
+		return f.National
+	case FormatRFC3966:
+		return f.Rfc3966
+	}
+
+	return ""
+}
+
+// IsMobile reports whether the number can be reached on a mobile network. This
+// includes numbers for which the lookup could not tell mobile and fixed line
+// apart.
+func (l *Lookup) IsMobile() bool {
+	return l.Type == NumberTypeMobile || l.Type == NumberTypeFixedLineOrMobile
+}
+
+// IsFixedLine reports whether the number is a fixed line number. This
+// includes numbers for which the lookup could not tell mobile and fixed line
+// apart.
+func (l *Lookup) IsFixedLine() bool {
+	return l.Type == NumberTypeFixedLine || l.Type == NumberTypeFixedLineOrMobile
+}
+
+// IsVoIP reports whether the number is a VoIP number.
+func (l *Lookup) IsVoIP() bool {
+	return l.Type == NumberTypeVoIP
+}
+
+// E164 returns the phone number in E.164 format, e.g. +31612345678.
+func (l *Lookup) E164() string {
+	return l.Formats.E164
+}
@@ -0,0 +1,104 @@
+package messagebirdtest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a messagebird.Clock that only moves when told to. Set it as
+// the Clock of a client to test retries, polling and caching without waiting:
+//
+//	clock := messagebirdtest.NewFakeClock(time.Now())
+//	client := s.Client()
+//	client.Clock = clock
+//
+//	go balance.Notify(ctx, client, 10, time.Minute, fn)
+//	clock.BlockUntil(1)
+//	clock.Advance(time.Minute)
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	until time.Time
+	c     chan time.Time
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+
+	return c
+}
+
+// Now implements messagebird.Clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After implements messagebird.Clock. The channel receives a value once the
+// clock is advanced by d or more.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &fakeWaiter{until: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.c <- c.now
+		return w.c
+	}
+
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+
+	return w.c
+}
+
+// Advance moves the clock forward by d and wakes the waiters that are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to t and wakes the waiters that are due.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(t)
+}
+
+func (c *FakeClock) set(t time.Time) {
+	c.now = t
+
+	waiting := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.until.After(t) {
+			waiting = append(waiting, w)
+			continue
+		}
+		w.c <- t
+	}
+	c.waiters = waiting
+	c.cond.Broadcast()
+}
+
+// BlockUntil blocks until n goroutines are waiting on channels returned by
+// After. Use it before Advance, so the code under test is known to be waiting.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
@@ -0,0 +1,60 @@
+package messagebirdtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2021, 7, 5, 12, 0, 0, 0, time.UTC)
+	clock := messagebirdtest.NewFakeClock(start)
+
+	c := clock.After(time.Minute)
+	clock.Advance(59 * time.Second)
+	select {
+	case <-c:
+		t.Fatal("fired before the duration passed")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-c)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+
+	select {
+	case <-clock.After(0):
+	default:
+		t.Fatal("After(0) did not fire immediately")
+	}
+}
+
+func TestFakeClockClient(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturn([]byte(`{"payment":"prepaid","type":"credits","amount":5}`), http.StatusOK)
+
+	clock := messagebirdtest.NewFakeClock(time.Now())
+	client := s.Client()
+	client.Clock = clock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reads := make(chan struct{}, 10)
+	go balance.Notify(ctx, client, 10, time.Hour, func(balance.Event) {
+		reads <- struct{}{}
+	})
+
+	<-reads
+	clock.BlockUntil(1)
+	assert.Len(t, s.Requests(), 1)
+
+	clock.Advance(time.Hour)
+	clock.BlockUntil(1)
+	assert.Len(t, s.Requests(), 2)
+}
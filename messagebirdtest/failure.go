@@ -0,0 +1,147 @@
+package messagebirdtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Failure is a failure a Server injects instead of its configured response,
+// to test how code handles errors of the API. See Server.FailOnCall.
+type Failure struct {
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// FailWithAPIError returns a Failure that responds with status and an API
+// error with code and description, which the client returns as a
+// messagebird.ErrorResponse.
+func FailWithAPIError(status, code int, description string) Failure {
+	return Failure{status: status, body: errorBody(code, description)}
+}
+
+// FailWithServerError returns a Failure that responds with 500 Internal
+// Server Error, which the client returns as messagebird.ErrUnexpectedResponse.
+func FailWithServerError() Failure {
+	return Failure{status: http.StatusInternalServerError, body: []byte{}}
+}
+
+// FailWithRateLimit returns a Failure that responds with 429 Too Many
+// Requests and a Retry-After header of retryAfter, rounded up to seconds.
+func FailWithRateLimit(retryAfter time.Duration) Failure {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+
+	return Failure{
+		status: http.StatusTooManyRequests,
+		header: http.Header{"Retry-After": []string{strconv.Itoa(seconds)}},
+		body:   errorBody(http.StatusTooManyRequests, "Too many requests"),
+	}
+}
+
+// FailWithMalformedJSON returns a Failure that responds with 200 OK and a
+// body that is not valid JSON, as with a truncated response.
+func FailWithMalformedJSON() Failure {
+	return Failure{status: http.StatusOK, body: []byte(`{"id": "`)}
+}
+
+// FailWithTimeout returns a Failure that fails the request as if it timed
+// out, without waiting. The client returns an error that is a net.Error with
+// Timeout() true, and that matches context.DeadlineExceeded with errors.Is.
+func FailWithTimeout() Failure {
+	return Failure{err: timeoutError{}}
+}
+
+func errorBody(code int, description string) []byte {
+	b, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{"code": code, "description": description, "parameter": nil},
+		},
+	})
+
+	return b
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "messagebirdtest: request timed out" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func (timeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// FailOnCall makes the nth request to s fail with f, instead of getting the
+// response set with WillReturn. Requests are counted from 1, since the start
+// of the server, so the outcome of every call is deterministic:
+//
+//	s.FailOnCall(1, messagebirdtest.FailWithRateLimit(time.Second))
+//	s.FailOnCall(2, messagebirdtest.FailWithTimeout())
+//	// The third request gets the response set with WillReturn.
+//
+// Failed requests are recorded like any other request.
+func (s *Server) FailOnCall(n int, f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures == nil {
+		s.failures = make(map[int]Failure)
+	}
+	s.failures[n] = f
+}
+
+// failingTransport injects the failures of server, and sends all other
+// requests on to next.
+type failingTransport struct {
+	server *Server
+	next   http.RoundTripper
+}
+
+func (t *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	s := t.server
+
+	// Requests are numbered when they are sent rather than when they are
+	// recorded, so concurrent requests each get a number of their own.
+	s.mu.Lock()
+	s.calls++
+	f, ok := s.failures[s.calls]
+	if !ok {
+		s.mu.Unlock()
+		return t.next.RoundTrip(r)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, _ = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+	}
+	s.record(r, body)
+	s.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	for name, values := range f.header {
+		header[name] = values
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(f.status) + " " + http.StatusText(f.status),
+		StatusCode:    f.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(f.body)),
+		ContentLength: int64(len(f.body)),
+		Request:       r,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package messagebirdtest_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailOnCall(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnFile("balance.json", http.StatusOK)
+	s.FailOnCall(1, messagebirdtest.FailWithAPIError(http.StatusUnprocessableEntity, 25, "Not enough balance"))
+	s.FailOnCall(2, messagebirdtest.FailWithServerError())
+	s.FailOnCall(3, messagebirdtest.FailWithRateLimit(1500*time.Millisecond))
+	s.FailOnCall(4, messagebirdtest.FailWithMalformedJSON())
+	s.FailOnCall(5, messagebirdtest.FailWithTimeout())
+	client := s.Client()
+
+	_, err := balance.Read(client)
+	var apiErr messagebird.ErrorResponse
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, 25, apiErr.Errors[0].Code)
+	}
+
+	_, err = balance.Read(client)
+	assert.Equal(t, messagebird.ErrUnexpectedResponse, err)
+
+	_, err = balance.Read(client)
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.Errors[0].Code)
+	}
+
+	_, err = balance.Read(client)
+	assert.Error(t, err)
+
+	_, err = balance.Read(client)
+	var netErr net.Error
+	if assert.True(t, errors.As(err, &netErr)) {
+		assert.True(t, netErr.Timeout())
+	}
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = balance.Read(client)
+	assert.NoError(t, err)
+
+	assert.Len(t, s.Requests(), 6)
+	s.AssertEndpointCalled(http.MethodGet, "/balance")
+}
+
+func TestFailOnCallConcurrent(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnFile("balance.json", http.StatusOK)
+	for n := 2; n <= 10; n += 2 {
+		s.FailOnCall(n, messagebirdtest.FailWithServerError())
+	}
+	client := s.Client()
+
+	var mu sync.Mutex
+	failed := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := balance.Read(client); err != nil {
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 5, failed)
+	assert.Len(t, s.Requests(), 10)
+}
+
+func TestFailWithRateLimitRetryAfter(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.FailOnCall(1, messagebirdtest.FailWithRateLimit(1500*time.Millisecond))
+
+	resp, err := (&http.Client{Transport: s.Transport()}).Get("https://rest.messagebird.com/balance")
+	if assert.NoError(t, err) {
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	}
+	assert.Equal(t, "rest.messagebird.com", s.LastRequest().Host)
+}
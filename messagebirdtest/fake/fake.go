@@ -0,0 +1,664 @@
+// Package fake provides a fake MessageBird API that models state, for
+// end-to-end tests of code that sends messages, see API. Use package
+// messagebirdtest for tests that only need canned responses.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/webhooks"
+)
+
+// Statuses of SMS recipients in an API.
+const (
+	StatusScheduled      = "scheduled"
+	StatusSent           = "sent"
+	StatusDelivered      = "delivered"
+	StatusDeliveryFailed = "delivery_failed"
+)
+
+// Statuses of conversation messages in an API.
+const (
+	ConversationStatusPending   = "pending"
+	ConversationStatusSent      = "sent"
+	ConversationStatusDelivered = "delivered"
+)
+
+// Options configure an API.
+type Options struct {
+	// Balance is the initial balance in credits. Defaults to 100.
+	Balance float64
+
+	// PricePerPart is the number of credits charged per message part and
+	// recipient. Defaults to 1.
+	PricePerPart float64
+
+	// WebhookURL receives SMS status reports and conversation events.
+	// Webhooks are not sent if it is empty.
+	WebhookURL string
+
+	// SigningKey signs webhooks with a JWT, if set. See package
+	// signature_jwt.
+	SigningKey string
+
+	// FailingRecipients never receive messages: their status ends as
+	// delivery_failed.
+	FailingRecipients []string
+
+	// WebhookClient sends webhooks. Defaults to http.DefaultClient.
+	WebhookClient *http.Client
+}
+
+// API is a fake MessageBird API that models state, for end-to-end tests that
+// should not use credits. It supports:
+//
+//   - SMS messages: creating, reading, listing and deleting them. Created
+//     messages get an ID and are charged from the balance. Their recipients
+//     progress through statuses when Advance is called.
+//   - The balance, which decreases with every message sent.
+//   - Conversations: starting them, replying, and reading and listing their
+//     messages, which also progress through statuses when Advance is called.
+//
+// Status changes are sent as webhooks to Options.WebhookURL. Requests to
+// endpoints that are not modelled get a 404 response.
+type API struct {
+	t      testing.TB
+	server *httptest.Server
+	opts   Options
+
+	mu            sync.Mutex
+	balance       float64
+	ids           int
+	messages      []*fakeMessage
+	conversations []*fakeConversation
+}
+
+type fakeMessage struct {
+	ID                string          `json:"id"`
+	HRef              string          `json:"href"`
+	Direction         string          `json:"direction"`
+	Type              string          `json:"type"`
+	Originator        string          `json:"originator"`
+	Body              string          `json:"body"`
+	Reference         string          `json:"reference,omitempty"`
+	MClass            int             `json:"mclass"`
+	ScheduledDatetime *time.Time      `json:"scheduledDatetime"`
+	CreatedDatetime   time.Time       `json:"createdDatetime"`
+	Recipients        *fakeRecipients `json:"recipients"`
+}
+
+type fakeRecipients struct {
+	TotalCount               int              `json:"totalCount"`
+	TotalSentCount           int              `json:"totalSentCount"`
+	TotalDeliveredCount      int              `json:"totalDeliveredCount"`
+	TotalDeliveryFailedCount int              `json:"totalDeliveryFailedCount"`
+	Items                    []*fakeRecipient `json:"items"`
+}
+
+type fakeRecipient struct {
+	Recipient        int64     `json:"recipient"`
+	Status           string    `json:"status"`
+	StatusDatetime   time.Time `json:"statusDatetime"`
+	MessagePartCount int       `json:"messagePartCount"`
+}
+
+type fakeConversation struct {
+	ID              string                 `json:"id"`
+	ContactID       string                 `json:"contactId"`
+	Contact         map[string]interface{} `json:"contact"`
+	Status          string                 `json:"status"`
+	CreatedDatetime time.Time              `json:"createdDatetime"`
+	UpdatedDatetime time.Time              `json:"updatedDatetime"`
+	LastChannelID   string                 `json:"lastUsedChannelId"`
+	MessagesCount   map[string]interface{} `json:"messages"`
+	messages        []*fakeConversationMessage
+	to              string
+}
+
+type fakeConversationMessage struct {
+	ID              string                       `json:"id"`
+	ConversationID  string                       `json:"conversationId"`
+	ChannelID       string                       `json:"channelId"`
+	Platform        string                       `json:"platform"`
+	To              string                       `json:"to"`
+	Direction       string                       `json:"direction"`
+	Status          string                       `json:"status"`
+	Type            string                       `json:"type"`
+	Content         *conversation.MessageContent `json:"content"`
+	CreatedDatetime time.Time                    `json:"createdDatetime"`
+	UpdatedDatetime time.Time                    `json:"updatedDatetime"`
+}
+
+// NewAPI starts an API. A nil opts uses the defaults. It is closed when
+// the test finishes.
+func NewAPI(t testing.TB, opts *Options) *API {
+	f := &API{t: t}
+	if opts != nil {
+		f.opts = *opts
+	}
+	if f.opts.Balance == 0 {
+		f.opts.Balance = 100
+	}
+	if f.opts.PricePerPart == 0 {
+		f.opts.PricePerPart = 1
+	}
+	f.balance = f.opts.Balance
+
+	f.server = httptest.NewTLSServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+// Client returns a client that sends all requests to f.
+func (f *API) Client() *messagebird.DefaultClient {
+	client := messagebird.New("test_accesskey")
+	client.HTTPClient.Transport = f.Transport()
+
+	return client
+}
+
+// Transport returns an http.RoundTripper that sends requests for any host to
+// f.
+func (f *API) Transport() http.RoundTripper {
+	return messagebirdtest.TransportTo(f.server.Listener.Addr().String())
+}
+
+// Balance returns the current balance in credits.
+func (f *API) Balance() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.balance
+}
+
+// Advance moves every SMS recipient and conversation message that has not
+// reached a final status to its next status, and sends a webhook for every
+// change. SMS recipients go from scheduled to sent to delivered (or
+// delivery_failed), and conversation messages from pending to sent to
+// delivered.
+func (f *API) Advance() {
+	f.t.Helper()
+
+	f.mu.Lock()
+	var events []interface{}
+	now := time.Now().UTC()
+
+	for _, m := range f.messages {
+		for _, r := range m.Recipients.Items {
+			next := f.nextStatus(r)
+			if next == "" {
+				continue
+			}
+
+			r.Status, r.StatusDatetime = next, now
+			statusDatetime := now
+			events = append(events, &webhooks.SMSStatusReport{
+				ID:               m.ID,
+				Reference:        m.Reference,
+				Recipient:        strconv.FormatInt(r.Recipient, 10),
+				Status:           next,
+				StatusDatetime:   &statusDatetime,
+				MessagePartCount: r.MessagePartCount,
+			})
+		}
+		m.Recipients.count()
+	}
+
+	for _, c := range f.conversations {
+		for _, m := range c.messages {
+			switch m.Status {
+			case ConversationStatusPending:
+				m.Status = ConversationStatusSent
+			case ConversationStatusSent:
+				m.Status = ConversationStatusDelivered
+			default:
+				continue
+			}
+
+			m.UpdatedDatetime = now
+			events = append(events, conversationEvent(conversation.WebhookEventMessageUpdated, c, m))
+		}
+	}
+	f.mu.Unlock()
+
+	for _, e := range events {
+		f.sendWebhook(e)
+	}
+}
+
+func (f *API) nextStatus(r *fakeRecipient) string {
+	switch r.Status {
+	case StatusScheduled:
+		return StatusSent
+	case StatusSent:
+		for _, failing := range f.opts.FailingRecipients {
+			if failing == strconv.FormatInt(r.Recipient, 10) {
+				return StatusDeliveryFailed
+			}
+		}
+		return StatusDelivered
+	}
+
+	return ""
+}
+
+func (rs *fakeRecipients) count() {
+	rs.TotalSentCount, rs.TotalDeliveredCount, rs.TotalDeliveryFailedCount = 0, 0, 0
+	for _, r := range rs.Items {
+		switch r.Status {
+		case StatusSent:
+			rs.TotalSentCount++
+		case StatusDelivered:
+			rs.TotalSentCount++
+			rs.TotalDeliveredCount++
+		case StatusDeliveryFailed:
+			rs.TotalSentCount++
+			rs.TotalDeliveryFailedCount++
+		}
+	}
+}
+
+func conversationEvent(eventType conversation.WebhookEvent, c *fakeConversation, m *fakeConversationMessage) *webhooks.ConversationEvent {
+	// The webhook carries the same JSON as the API responses.
+	e := &webhooks.ConversationEvent{Type: eventType}
+	decodeInto(c, &e.Conversation)
+	decodeInto(m, &e.Message)
+
+	return e
+}
+
+func decodeInto(from, to interface{}) {
+	b, _ := json.Marshal(from)
+	json.Unmarshal(b, to)
+}
+
+func (f *API) sendWebhook(event interface{}) {
+	f.t.Helper()
+
+	if f.opts.WebhookURL == "" {
+		return
+	}
+
+	var (
+		r   *http.Request
+		err error
+	)
+	if f.opts.SigningKey != "" {
+		r, err = webhooks.NewSignedRequest(f.opts.WebhookURL, f.opts.SigningKey, event)
+	} else {
+		r, err = webhooks.NewRequest(f.opts.WebhookURL, event)
+	}
+	if err != nil {
+		f.t.Errorf("creating webhook: %v", err)
+		return
+	}
+
+	client := f.opts.WebhookClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		f.t.Errorf("sending webhook: %v", err)
+		return
+	}
+	resp.Body.Close()
+}
+
+func (f *API) nextID() string {
+	f.ids++
+	return fmt.Sprintf("%032x", f.ids)
+}
+
+func (f *API) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if strings.HasPrefix(r.Host, "conversations.") && len(segments) > 0 && (segments[0] == "v1" || segments[0] == "v2") {
+		f.serveConversations(w, r, segments[1:])
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 1 && segments[0] == "balance":
+		f.readBalance(w)
+	case len(segments) >= 1 && segments[0] == "messages":
+		f.serveMessages(w, r, segments[1:])
+	default:
+		writeError(w, http.StatusNotFound, 20, "not found")
+	}
+}
+
+func (f *API) readBalance(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"payment": "prepaid",
+		"type":    "credits",
+		"amount":  f.Balance(),
+	})
+}
+
+func (f *API) serveMessages(w http.ResponseWriter, r *http.Request, segments []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && len(segments) == 0:
+		f.createMessage(w, r)
+	case r.Method == http.MethodGet && len(segments) == 0:
+		f.listMessages(w, r)
+	case len(segments) == 1:
+		for i, m := range f.messages {
+			if m.ID != segments[0] {
+				continue
+			}
+
+			switch r.Method {
+			case http.MethodGet:
+				writeJSON(w, http.StatusOK, m)
+			case http.MethodDelete:
+				f.messages = append(f.messages[:i], f.messages[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				writeError(w, http.StatusMethodNotAllowed, 20, "method not allowed")
+			}
+			return
+		}
+		writeError(w, http.StatusNotFound, 20, "message not found")
+	default:
+		writeError(w, http.StatusNotFound, 20, "not found")
+	}
+}
+
+func (f *API) createMessage(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Originator        string   `json:"originator"`
+		Body              string   `json:"body"`
+		Recipients        []string `json:"recipients"`
+		Type              string   `json:"type"`
+		Reference         string   `json:"reference"`
+		MClass            int      `json:"mclass"`
+		ScheduledDatetime string   `json:"scheduledDatetime"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, 9, "invalid request body")
+		return
+	}
+
+	if req.Originator == "" || req.Body == "" || len(req.Recipients) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, 9, "originator, body and recipients are required")
+		return
+	}
+
+	parts := messageParts(req.Body)
+	cost := f.opts.PricePerPart * float64(parts*len(req.Recipients))
+	if cost > f.balance {
+		writeError(w, http.StatusUnprocessableEntity, 25, "Not enough balance")
+		return
+	}
+
+	now := time.Now().UTC()
+	m := &fakeMessage{
+		ID:              f.nextID(),
+		Direction:       "mt",
+		Type:            "sms",
+		Originator:      req.Originator,
+		Body:            req.Body,
+		Reference:       req.Reference,
+		MClass:          req.MClass,
+		CreatedDatetime: now,
+		Recipients:      &fakeRecipients{},
+	}
+	m.HRef = messagebird.Endpoint + "/messages/" + m.ID
+	if req.Type != "" {
+		m.Type = req.Type
+	}
+
+	status := StatusSent
+	if req.ScheduledDatetime != "" {
+		scheduled, err := time.Parse(time.RFC3339, req.ScheduledDatetime)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, 9, "invalid scheduledDatetime")
+			return
+		}
+		m.ScheduledDatetime, status = &scheduled, StatusScheduled
+	}
+
+	for _, recipient := range req.Recipients {
+		msisdn, err := strconv.ParseInt(strings.TrimPrefix(recipient, "+"), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, 9, "invalid recipient "+recipient)
+			return
+		}
+
+		m.Recipients.Items = append(m.Recipients.Items, &fakeRecipient{
+			Recipient:        msisdn,
+			Status:           status,
+			StatusDatetime:   now,
+			MessagePartCount: parts,
+		})
+	}
+	m.Recipients.TotalCount = len(m.Recipients.Items)
+	m.Recipients.count()
+
+	f.balance -= cost
+	f.messages = append(f.messages, m)
+
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (f *API) listMessages(w http.ResponseWriter, r *http.Request) {
+	offset, limit := pagination(r.URL.Query())
+
+	items := []*fakeMessage{}
+	for i := offset; i < len(f.messages) && len(items) < limit; i++ {
+		items = append(items, f.messages[i])
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"offset":     offset,
+		"limit":      limit,
+		"count":      len(items),
+		"totalCount": len(f.messages),
+		"items":      items,
+	})
+}
+
+func (f *API) serveConversations(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] != "conversations" {
+		writeError(w, http.StatusNotFound, 20, "not found")
+		return
+	}
+	segments = segments[1:]
+
+	switch {
+	case r.Method == http.MethodPost && len(segments) == 1 && segments[0] == "start":
+		f.startConversation(w, r)
+	case len(segments) >= 1:
+		f.mu.Lock()
+		c := f.conversation(segments[0])
+		f.mu.Unlock()
+
+		switch {
+		case c == nil:
+			writeError(w, http.StatusNotFound, 20, "conversation not found")
+		case r.Method == http.MethodGet && len(segments) == 1:
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			writeJSON(w, http.StatusOK, c)
+		case r.Method == http.MethodPost && len(segments) == 2 && segments[1] == "messages":
+			f.replyConversation(w, r, c)
+		case r.Method == http.MethodGet && len(segments) == 2 && segments[1] == "messages":
+			f.listConversationMessages(w, r, c)
+		default:
+			writeError(w, http.StatusNotFound, 20, "not found")
+		}
+	default:
+		writeError(w, http.StatusNotFound, 20, "not found")
+	}
+}
+
+func (f *API) conversation(id string) *fakeConversation {
+	for _, c := range f.conversations {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (f *API) startConversation(w http.ResponseWriter, r *http.Request) {
+	req := &conversation.StartRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.To == "" || req.ChannelID == "" {
+		writeError(w, http.StatusBadRequest, 21, "to and channelId are required")
+		return
+	}
+
+	f.mu.Lock()
+
+	// Messages to the same recipient end up in the same conversation.
+	var c *fakeConversation
+	for _, existing := range f.conversations {
+		if existing.to == string(req.To) {
+			c = existing
+		}
+	}
+
+	now := time.Now().UTC()
+	if c == nil {
+		contactID := f.nextID()
+		c = &fakeConversation{
+			ID:              f.nextID(),
+			ContactID:       contactID,
+			Contact:         map[string]interface{}{"id": contactID, "msisdn": string(req.To)},
+			Status:          "active",
+			CreatedDatetime: now,
+			to:              string(req.To),
+		}
+		f.conversations = append(f.conversations, c)
+	}
+
+	m := f.addConversationMessage(c, req.ChannelID, req.Type, req.Content)
+	event := conversationEvent(conversation.WebhookEventMessageCreated, c, m)
+	writeJSON(w, http.StatusOK, c)
+	f.mu.Unlock()
+
+	f.sendWebhook(event)
+}
+
+func (f *API) replyConversation(w http.ResponseWriter, r *http.Request, c *fakeConversation) {
+	req := &conversation.ReplyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.Content == nil {
+		writeError(w, http.StatusBadRequest, 21, "type and content are required")
+		return
+	}
+
+	f.mu.Lock()
+	channelID := req.ChannelID
+	if channelID == "" {
+		channelID = c.LastChannelID
+	}
+	m := f.addConversationMessage(c, channelID, req.Type, req.Content)
+	event := conversationEvent(conversation.WebhookEventMessageCreated, c, m)
+	writeJSON(w, http.StatusOK, m)
+	f.mu.Unlock()
+
+	f.sendWebhook(event)
+}
+
+func (f *API) addConversationMessage(c *fakeConversation, channelID string, messageType conversation.MessageType, content *conversation.MessageContent) *fakeConversationMessage {
+	now := time.Now().UTC()
+	m := &fakeConversationMessage{
+		ID:              f.nextID(),
+		ConversationID:  c.ID,
+		ChannelID:       channelID,
+		Platform:        "sms",
+		To:              c.to,
+		Direction:       "sent",
+		Status:          ConversationStatusPending,
+		Type:            string(messageType),
+		Content:         content,
+		CreatedDatetime: now,
+		UpdatedDatetime: now,
+	}
+
+	c.messages = append(c.messages, m)
+	c.LastChannelID, c.UpdatedDatetime = channelID, now
+	c.MessagesCount = map[string]interface{}{
+		"totalCount":    len(c.messages),
+		"href":          fmt.Sprintf("https://conversations.messagebird.com/v1/conversations/%s/messages", c.ID),
+		"lastMessageId": m.ID,
+	}
+
+	return m
+}
+
+func (f *API) listConversationMessages(w http.ResponseWriter, r *http.Request, c *fakeConversation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	offset, limit := pagination(r.URL.Query())
+
+	items := []*fakeConversationMessage{}
+	for i := offset; i < len(c.messages) && len(items) < limit; i++ {
+		items = append(items, c.messages[i])
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"offset":     offset,
+		"limit":      limit,
+		"count":      len(items),
+		"totalCount": len(c.messages),
+		"items":      items,
+	})
+}
+
+// messageParts returns the number of SMS parts needed for body, assuming the
+// GSM 03.38 alphabet.
+func messageParts(body string) int {
+	n := len([]rune(body))
+	if n <= 160 {
+		return 1
+	}
+
+	return (n + 152) / 153
+}
+
+func pagination(query url.Values) (offset, limit int) {
+	offset, _ = strconv.Atoi(query.Get("offset"))
+	limit, _ = strconv.Atoi(query.Get("limit"))
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	return offset, limit
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status, code int, description string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{"code": code, "description": description, "parameter": nil},
+		},
+	})
+}
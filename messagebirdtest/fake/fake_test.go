@@ -0,0 +1,143 @@
+package fake_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest/fake"
+	"github.com/messagebird/go-rest-api/v9/signature_jwt"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPISMS(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		reports []*webhooks.SMSStatusReport
+	)
+	d := &webhooks.Dispatcher{
+		Validator: signature_jwt.NewValidator("secret"),
+		OnSMSStatus: func(ctx context.Context, r *webhooks.SMSStatusReport) error {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, r)
+			return nil
+		},
+	}
+	receiver := httptest.NewServer(d)
+	defer receiver.Close()
+	d.BaseURL = receiver.URL
+
+	f := fake.NewAPI(t, &fake.Options{
+		Balance:           10,
+		WebhookURL:        receiver.URL,
+		SigningKey:        "secret",
+		FailingRecipients: []string{"31600000002"},
+	})
+	client := f.Client()
+
+	m, err := sms.Create(client, "TestName", []string{"31600000001", "31600000002"}, strings.Repeat("a", 200), nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, m.ID)
+	assert.Equal(t, 2, m.Recipients.TotalSentCount)
+
+	b, err := balance.Read(client)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 6, b.Amount)
+
+	f.Advance()
+
+	m, err = sms.Read(client, m.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "delivered", m.Recipients.Items[0].Status)
+	assert.Equal(t, "delivery_failed", m.Recipients.Items[1].Status)
+	assert.Equal(t, 1, m.Recipients.TotalDeliveredCount)
+	assert.Equal(t, 1, m.Recipients.TotalDeliveryFailedCount)
+
+	mu.Lock()
+	if assert.Len(t, reports, 2) {
+		assert.Equal(t, m.ID, reports[0].ID)
+		assert.Equal(t, "delivered", reports[0].Status)
+		assert.Equal(t, "delivery_failed", reports[1].Status)
+	}
+	mu.Unlock()
+
+	list, err := sms.List(client, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, list.TotalCount)
+
+	// The balance is insufficient for 4 parts to 2 recipients.
+	_, err = sms.Create(client, "TestName", []string{"31600000001", "31600000002"}, strings.Repeat("a", 500), nil)
+	if errorResponse, ok := err.(messagebird.ErrorResponse); assert.True(t, ok) {
+		assert.Equal(t, 25, errorResponse.Errors[0].Code)
+	}
+
+	assert.NoError(t, sms.Delete(client, m.ID))
+	_, err = sms.Read(client, m.ID)
+	assert.Error(t, err)
+}
+
+func TestAPIConversations(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []*webhooks.ConversationEvent
+	)
+	receiver := httptest.NewServer(&webhooks.Dispatcher{
+		OnConversation: func(ctx context.Context, e *webhooks.ConversationEvent) error {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+			return nil
+		},
+	})
+	defer receiver.Close()
+
+	f := fake.NewAPI(t, &fake.Options{WebhookURL: receiver.URL})
+	client := f.Client()
+
+	c, err := conversation.Start(client, &conversation.StartRequest{
+		ChannelID: "619747f69cf940a98fb443140ce9aed2",
+		To:        "31600000001",
+		Type:      conversation.MessageTypeText,
+		Content:   &conversation.MessageContent{Text: "Hello"},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, c.ID)
+
+	reply, err := conversation.Reply(client, c.ID, &conversation.ReplyRequest{
+		Type:    conversation.MessageTypeText,
+		Content: &conversation.MessageContent{Text: "Are you there?"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "619747f69cf940a98fb443140ce9aed2", reply.ChannelID)
+
+	f.Advance()
+
+	messages, err := conversation.ListConversationMessages(client, c.ID, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, messages.Items, 2) {
+		assert.Equal(t, "Hello", messages.Items[0].Content.Text)
+		assert.EqualValues(t, "sent", messages.Items[1].Status)
+	}
+
+	c, err = conversation.Read(client, c.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.Messages.TotalCount)
+
+	mu.Lock()
+	assert.Len(t, events, 4)
+	mu.Unlock()
+
+	// Unknown endpoints are not found.
+	_, err = conversation.Read(client, "unknown")
+	if errorResponse, ok := err.(messagebird.ErrorResponse); assert.True(t, ok) {
+		assert.Equal(t, 20, errorResponse.Errors[0].Code)
+	}
+}
@@ -0,0 +1,61 @@
+package messagebirdtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// GenerateFixture reads endpoint with a GET request and writes the response to
+// path, so fixtures stay in sync with the API. Use a test access key. endpoint
+// is a path like "balance", relative to the REST API, or an absolute URL.
+//
+// The response is sanitized like the cassettes of a Recorder, with the access
+// key of c redacted. Object keys are sorted and the JSON is indented with four
+// spaces, so regenerating a fixture only shows actual changes in a diff.
+func GenerateFixture(c *messagebird.DefaultClient, endpoint, path string) error {
+	var raw json.RawMessage
+	if err := c.Request(&raw, http.MethodGet, endpoint, nil); err != nil {
+		return fmt.Errorf("reading %s: %w", endpoint, err)
+	}
+
+	b, err := FormatFixture(raw, c.AccessKey)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0o644)
+}
+
+// FormatFixture sanitizes a JSON document, redacting secrets, and formats it
+// with sorted object keys and an indentation of four spaces.
+func FormatFixture(b []byte, secrets ...string) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader([]byte(sanitize(string(b), secrets))))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decoding fixture: %w", err)
+	}
+
+	// Maps are encoded with sorted keys.
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "    ")
+	if err := e.Encode(sanitizeJSON(v, false)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
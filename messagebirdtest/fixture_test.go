@@ -0,0 +1,36 @@
+package messagebirdtest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateFixture(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturn([]byte(`{"type":"mobile","phoneNumber":31612345678,"countryPrefix":31,"href":"https://rest.messagebird.com/lookup/31612345678?access_key=test_accesskey","amount":9.20}`), http.StatusOK)
+
+	path := filepath.Join(t.TempDir(), "testdata", "lookup.json")
+	assert.NoError(t, messagebirdtest.GenerateFixture(s.Client(), "lookup/31612345678", path))
+	s.AssertEndpointCalled(http.MethodGet, "/lookup/31612345678")
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+    "amount": 9.20,
+    "countryPrefix": 31,
+    "href": "https://rest.messagebird.com/lookup/31101426001?access_key=REDACTED",
+    "phoneNumber": 31101426001,
+    "type": "mobile"
+}
+`, string(b))
+}
+
+func TestFormatFixtureInvalidJSON(t *testing.T) {
+	_, err := messagebirdtest.FormatFixture([]byte("not json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,284 @@
+// Package messagebirdtest provides a fake MessageBird API server for testing
+// code that uses this library. Every test gets its own server, which returns
+// the responses you configure and records the requests it receives:
+//
+//	func TestSendReminder(t *testing.T) {
+//		s := messagebirdtest.NewServer(t)
+//		s.WillReturnFile("message.json", http.StatusCreated)
+//
+//		err := SendReminder(s.Client(), "31612345678")
+//
+//		assert.NoError(t, err)
+//		s.AssertEndpointCalled(http.MethodPost, "/messages")
+//	}
+//
+// Unlike the packages it supports, messagebirdtest is not used in production,
+// but its exported API is covered by the same compatibility guarantee as the
+// rest of this module: it only changes in backwards-incompatible ways in a
+// new major version.
+package messagebirdtest
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// TestdataDir is the directory WillReturnFile reads files from, relative to
+// the package being tested.
+const TestdataDir = "testdata"
+
+// accessKeyError is the response of the API for an incorrect access key.
+const accessKeyError = `{
+	"errors": [
+		{
+			"code": 2,
+			"description": "Request not allowed (incorrect access_key)",
+			"parameter": "access_key"
+		}
+	]
+}`
+
+// Request is a request received by a Server.
+type Request struct {
+	Method      string
+	URL         *url.URL
+	Host        string
+	Header      http.Header
+	ContentType string
+	Body        []byte
+}
+
+// Server is a fake MessageBird API. Requests to all MessageBird hosts, e.g.
+// rest.messagebird.com and numbers.messagebird.com, are handled by the same
+// server. It is safe for concurrent use.
+type Server struct {
+	t      testing.TB
+	server *httptest.Server
+
+	mu       sync.Mutex
+	status   int
+	body     []byte
+	requests []*Request
+	calls    int
+	failures map[int]Failure
+}
+
+// NewServer starts a Server that responds with 200 OK and an empty JSON
+// object until told otherwise. It is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	s := &Server{t: t, status: http.StatusOK, body: []byte("{}")}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.record(r, body)
+	status, responseBody := s.status, s.body
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(responseBody)
+}
+
+// record adds r to the received requests. s.mu must be held.
+func (s *Server) record(r *http.Request, body []byte) {
+	host := r.Host
+	if host == "" {
+		host = r.URL.Host
+	}
+
+	s.requests = append(s.requests, &Request{
+		Method:      r.Method,
+		URL:         r.URL,
+		Host:        host,
+		Header:      r.Header,
+		ContentType: r.Header.Get("Content-Type"),
+		Body:        body,
+	})
+}
+
+// Client returns a client that sends all requests to s, using a fake access
+// key.
+func (s *Server) Client() *messagebird.DefaultClient {
+	client := messagebird.New("test_accesskey")
+	client.HTTPClient.Transport = s.Transport()
+
+	return client
+}
+
+// Transport returns an http.RoundTripper that sends requests for any host to
+// s. Use it to configure your own http.Client.
+func (s *Server) Transport() http.RoundTripper {
+	return &failingTransport{server: s, next: s.transport()}
+}
+
+func (s *Server) transport() *http.Transport {
+	return TransportTo(s.server.Listener.Addr().String())
+}
+
+// TransportTo returns an http.Transport that sends requests for any host to
+// the server listening at addr, e.g. an httptest.Server with a handler of
+// your own.
+func TransportTo(addr string) *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+		DialTLSContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := &tls.Dialer{Config: &tls.Config{
+				InsecureSkipVerify: true,
+			}}
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// WillReturn sets the body and status of the responses to all following
+// requests.
+func (s *Server) WillReturn(body []byte, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.body, s.status = body, status
+}
+
+// WillReturnStatus is like WillReturn, with an empty body.
+func (s *Server) WillReturnStatus(status int) {
+	s.WillReturn([]byte{}, status)
+}
+
+// WillReturnFile is like WillReturn, with the contents of a file in the
+// testdata directory as body. It fails the test if the file can not be read.
+func (s *Server) WillReturnFile(relativePath string, status int) {
+	s.t.Helper()
+
+	s.WillReturn(Testdata(s.t, relativePath), status)
+}
+
+// WillReturnAccessKeyError makes the server respond to all following requests
+// as if the access key is incorrect.
+func (s *Server) WillReturnAccessKeyError() {
+	s.WillReturn([]byte(accessKeyError), http.StatusUnauthorized)
+}
+
+// Requests returns all requests received so far, oldest first.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request(nil), s.requests...)
+}
+
+// LastRequest returns the most recent request, or nil if no request was
+// received.
+func (s *Server) LastRequest() *Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.requests) == 0 {
+		return nil
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+// AssertEndpointCalled fails the test if the last request was not made with
+// method to path, e.g. "/messages". The path does not include the query
+// string.
+func (s *Server) AssertEndpointCalled(method, path string) bool {
+	s.t.Helper()
+
+	r := s.LastRequest()
+	if r == nil {
+		s.t.Errorf("expected %s %s, but no request was received", method, path)
+		return false
+	}
+
+	return r.AssertEndpointCalled(s.t, method, path)
+}
+
+// AssertBodyJSONEq is like Request.AssertBodyJSONEq, for the last request.
+func (s *Server) AssertBodyJSONEq(expected string) bool {
+	s.t.Helper()
+
+	r := s.lastRequest()
+	return r != nil && r.AssertBodyJSONEq(s.t, expected)
+}
+
+// AssertBodyField is like Request.AssertBodyField, for the last request.
+func (s *Server) AssertBodyField(path string, expected interface{}) bool {
+	s.t.Helper()
+
+	r := s.lastRequest()
+	return r != nil && r.AssertBodyField(s.t, path, expected)
+}
+
+// AssertBodyFieldAbsent is like Request.AssertBodyFieldAbsent, for the last
+// request.
+func (s *Server) AssertBodyFieldAbsent(path string) bool {
+	s.t.Helper()
+
+	r := s.lastRequest()
+	return r != nil && r.AssertBodyFieldAbsent(s.t, path)
+}
+
+// AssertQueryParam is like Request.AssertQueryParam, for the last request.
+func (s *Server) AssertQueryParam(name, expected string) bool {
+	s.t.Helper()
+
+	r := s.lastRequest()
+	return r != nil && r.AssertQueryParam(s.t, name, expected)
+}
+
+// AssertQuery is like Request.AssertQuery, for the last request.
+func (s *Server) AssertQuery(expected url.Values) bool {
+	s.t.Helper()
+
+	r := s.lastRequest()
+	return r != nil && r.AssertQuery(s.t, expected)
+}
+
+// lastRequest returns the last request, or fails the test if no request was
+// received.
+func (s *Server) lastRequest() *Request {
+	s.t.Helper()
+
+	r := s.LastRequest()
+	if r == nil {
+		s.t.Errorf("expected a request, but no request was received")
+	}
+
+	return r
+}
+
+// Testdata returns the contents of a file in the testdata directory. It fails
+// the test if the file can not be read.
+func Testdata(t testing.TB, relativePath string) []byte {
+	t.Helper()
+
+	b, err := ioutil.ReadFile(filepath.Join(TestdataDir, relativePath))
+	if err != nil {
+		t.Fatalf("reading testdata: %v", err)
+	}
+
+	return b
+}
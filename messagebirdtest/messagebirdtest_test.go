@@ -0,0 +1,128 @@
+package messagebirdtest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/number"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnFile("balance.json", http.StatusOK)
+
+	b, err := balance.Read(s.Client())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 9.2, b.Amount)
+	assert.True(t, s.AssertEndpointCalled(http.MethodGet, "/balance"))
+	assert.Equal(t, "rest.messagebird.com", s.LastRequest().Host)
+	assert.Equal(t, "AccessKey test_accesskey", s.LastRequest().Header.Get("Authorization"))
+}
+
+func TestServerHandlesAllHosts(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnStatus(http.StatusNoContent)
+
+	assert.NoError(t, number.Delete(s.Client(), "31612345678"))
+	s.AssertEndpointCalled(http.MethodDelete, "/v1/phone-numbers/31612345678")
+	assert.Equal(t, "numbers.messagebird.com", s.LastRequest().Host)
+	assert.Len(t, s.Requests(), 1)
+}
+
+func TestServerAccessKeyError(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnAccessKeyError()
+
+	_, err := balance.Read(s.Client())
+	errorResponse, ok := err.(messagebird.ErrorResponse)
+	if assert.True(t, ok) {
+		assert.Equal(t, 2, errorResponse.Errors[0].Code)
+	}
+}
+
+// recorder is a testing.TB that records errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEndpointCalled(t *testing.T) {
+	rec := &recorder{TB: t}
+	s := messagebirdtest.NewServer(rec)
+
+	assert.False(t, s.AssertEndpointCalled(http.MethodGet, "/balance"))
+
+	balance.Read(s.Client())
+	assert.False(t, s.AssertEndpointCalled(http.MethodPost, "/balance"))
+
+	assert.Equal(t, []string{
+		"expected GET /balance, but no request was received",
+		"expected POST /balance, got GET /balance",
+	}, rec.errors)
+}
+
+func TestAssertBody(t *testing.T) {
+	rec := &recorder{TB: t}
+	s := messagebirdtest.NewServer(rec)
+
+	_, err := sms.Create(s.Client(), "TestName", []string{"31612345678"}, "Hello World", &sms.Params{Validity: 60})
+	assert.NoError(t, err)
+
+	assert.True(t, s.AssertBodyField("originator", "TestName"))
+	assert.True(t, s.AssertBodyField("recipients", []string{"31612345678"}))
+	assert.True(t, s.AssertBodyField("validity", 60))
+	assert.True(t, s.AssertBodyJSONEq(string(s.LastRequest().Body)))
+	assert.Empty(t, rec.errors)
+
+	assert.False(t, s.AssertBodyField("recipients.1", "31612345678"))
+	assert.False(t, s.AssertBodyField("body", "Goodbye"))
+	assert.False(t, s.AssertBodyJSONEq(`{}`))
+	assert.Len(t, rec.errors, 3)
+
+	assert.True(t, s.AssertBodyFieldAbsent("reference"))
+	assert.False(t, s.AssertBodyFieldAbsent("body"))
+	assert.Equal(t, "expected body field body to be absent", rec.errors[3])
+}
+
+func TestAssertQueryParam(t *testing.T) {
+	rec := &recorder{TB: t}
+	s := messagebirdtest.NewServer(rec)
+
+	_, err := number.Search(s.Client(), "NL", &number.SearchRequest{Limit: 10})
+	assert.NoError(t, err)
+
+	assert.True(t, s.AssertQueryParam("limit", "10"))
+	assert.False(t, s.AssertQueryParam("limit", "20"))
+	assert.Equal(t, []string{`expected query parameter limit to be "20", got ["10"]`}, rec.errors)
+}
+
+func TestAssertQuery(t *testing.T) {
+	rec := &recorder{TB: t}
+	s := messagebirdtest.NewServer(rec)
+
+	_, err := number.Search(s.Client(), "NL", &number.SearchRequest{Limit: 10})
+	assert.NoError(t, err)
+	_, err = balance.Read(s.Client())
+	assert.NoError(t, err)
+
+	requests := s.Requests()
+	assert.True(t, requests[0].AssertQuery(rec, url.Values{
+		"limit":                                {"10"},
+		"prices":                               {"false"},
+		"exclude_numbers_require_verification": {"false"},
+	}))
+	assert.True(t, s.AssertQuery(nil))
+	assert.False(t, s.AssertQuery(url.Values{"limit": {"10"}}))
+	assert.Equal(t, []string{"expected query map[limit:[10]], got map[]"}, rec.errors)
+}
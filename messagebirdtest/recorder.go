@@ -0,0 +1,406 @@
+package messagebirdtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// RecordEnv is the environment variable that selects ModeRecord in
+// ModeFromEnv when set to a non-empty value.
+const RecordEnv = "MESSAGEBIRD_RECORD"
+
+// Mode determines whether a Recorder talks to the real API.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette, without network access.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to the real API and writes the interactions
+	// to the cassette when the test finishes.
+	ModeRecord
+)
+
+// ModeFromEnv returns ModeRecord if the RecordEnv environment variable is set,
+// and ModeReplay otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+var (
+	// msisdnPattern matches a phone number in international format, with or
+	// without a leading +.
+	msisdnPattern = regexp.MustCompile(`^\+?[1-9][0-9]{9,14}$`)
+
+	// plusMSISDNPattern matches phone numbers with a leading + in text.
+	plusMSISDNPattern = regexp.MustCompile(`\+[1-9][0-9]{9,14}\b`)
+
+	// emailPattern matches email addresses.
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// phoneFields are the JSON fields that hold phone numbers. Numbers without a
+// leading + are only replaced in these fields, in URL paths and in query
+// parameters, as other digits, such as IDs and timestamps, look alike.
+var phoneFields = map[string]bool{
+	"msisdn":        true,
+	"recipient":     true,
+	"recipients":    true,
+	"originator":    true,
+	"phoneNumber":   true,
+	"number":        true,
+	"e164":          true,
+	"international": true,
+	"source":        true,
+	"destination":   true,
+	"from":          true,
+	"to":            true,
+}
+
+// Cassette holds the recorded interactions of a test.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records interactions with the real
+// API to a cassette file, and replays them in later runs. Recorded
+// interactions are sanitized: the Authorization header is not stored, secrets
+// are replaced by REDACTED, and phone numbers and email addresses are replaced
+// by fake ones. Phone numbers are recognized in URLs, in JSON fields such as
+// "msisdn" and "recipients", and anywhere if they start with a +. Replacements are derived from the original value, so the same
+// number is always replaced by the same fake number, and requests made in
+// replay mode are matched after applying the same replacements.
+type Recorder struct {
+	// Transport sends requests in ModeRecord. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Secrets are redacted from the cassette, in addition to the access key
+	// passed to Client.
+	Secrets []string
+
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	next     int
+}
+
+// NewRecorder returns a Recorder for the cassette at path, relative to the
+// testdata directory unless it is absolute. In ModeReplay, the test fails if the cassette can not
+// be read. In ModeRecord, the cassette is written when the test finishes.
+func NewRecorder(t testing.TB, path string, mode Mode) *Recorder {
+	t.Helper()
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(TestdataDir, path)
+	}
+
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		cassette: &Cassette{},
+	}
+
+	if mode == ModeReplay {
+		b, err := ioutil.ReadFile(r.path)
+		if err != nil {
+			t.Fatalf("reading cassette: %v", err)
+		}
+		if err := json.Unmarshal(b, r.cassette); err != nil {
+			t.Fatalf("decoding cassette %s: %v", r.path, err)
+		}
+		return r
+	}
+
+	t.Cleanup(func() {
+		if err := r.save(); err != nil {
+			t.Errorf("writing cassette: %v", err)
+		}
+	})
+
+	return r
+}
+
+// Client returns a client that uses r. In ModeReplay, accessKey may be
+// empty.
+func (r *Recorder) Client(accessKey string) *messagebird.DefaultClient {
+	r.mu.Lock()
+	if accessKey != "" {
+		r.Secrets = append(r.Secrets, accessKey)
+	}
+	r.mu.Unlock()
+
+	client := messagebird.New(accessKey)
+	client.HTTPClient.Transport = r
+
+	return client
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := &Interaction{
+		Request:  RecordedRequest{Method: req.Method, URL: r.sanitizeURL(req.URL.String())},
+		Response: RecordedResponse{Status: resp.StatusCode},
+	}
+	i.Request.Body, i.Request.Text = r.sanitizeBody(body)
+	i.Response.Body, i.Response.Text = r.sanitizeBody(respBody)
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+
+	// The caller gets the sanitized response, so tests behave the same in
+	// record and replay mode.
+	return response(req, i.Response), nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := r.sanitizeURL(req.URL.String())
+	for ; r.next < len(r.cassette.Interactions); r.next++ {
+		i := r.cassette.Interactions[r.next]
+		if i.Request.Method == req.Method && i.Request.URL == url {
+			r.next++
+			return response(req, i.Response), nil
+		}
+	}
+
+	return nil, fmt.Errorf("messagebirdtest: no recorded interaction for %s %s in %s", req.Method, url, r.path)
+}
+
+func response(req *http.Request, recorded RecordedResponse) *http.Response {
+	body := []byte(recorded.Text)
+	if len(recorded.Body) != 0 {
+		body = recorded.Body
+	}
+
+	return &http.Response{
+		StatusCode:    recorded.Status,
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func (r *Recorder) save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// sanitizeBody sanitizes body and returns it as JSON if it is valid JSON, or
+// as text otherwise.
+func (r *Recorder) sanitizeBody(body []byte) (json.RawMessage, string) {
+	s := r.sanitize(string(body))
+	if s == "" {
+		return nil, ""
+	}
+
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(sanitizeJSON(v, false)); err == nil {
+			return bytes.TrimSpace(buf.Bytes()), ""
+		}
+	}
+
+	return nil, plusMSISDNPattern.ReplaceAllStringFunc(s, fakeMSISDN)
+}
+
+// sanitizeJSON replaces the phone numbers in v, a decoded JSON value. phone
+// is true if v is the value of one of phoneFields.
+func sanitizeJSON(v interface{}, phone bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = sanitizeJSON(value, phoneFields[key])
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = sanitizeJSON(value, phone)
+		}
+	case json.Number:
+		if phone && msisdnPattern.MatchString(string(v)) {
+			return json.Number(fakeMSISDN(string(v)))
+		}
+	case string:
+		switch {
+		case phone && msisdnPattern.MatchString(v):
+			return fakeMSISDN(v)
+		case strings.HasPrefix(v, "https://") || strings.HasPrefix(v, "http://"):
+			return sanitizeURL(v)
+		default:
+			return plusMSISDNPattern.ReplaceAllStringFunc(v, fakeMSISDN)
+		}
+	}
+
+	return v
+}
+
+func (r *Recorder) sanitize(s string) string {
+	return sanitize(s, r.Secrets)
+}
+
+func (r *Recorder) sanitizeURL(s string) string {
+	return sanitizeURL(r.sanitize(s))
+}
+
+// sanitize replaces secrets by REDACTED and email addresses by fake ones
+// derived from the original value.
+func sanitize(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, "REDACTED", -1)
+		}
+	}
+
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		return "user-" + digest(email)[:8] + "@example.com"
+	})
+}
+
+// sanitizeURL replaces the path segments and query parameters of rawURL
+// that are phone numbers by fake ones.
+func sanitizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		if msisdnPattern.MatchString(segment) {
+			segments[i] = fakeMSISDN(segment)
+		}
+	}
+	u.Path, u.RawPath = strings.Join(segments, "/"), ""
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for _, values := range query {
+			for i, value := range values {
+				if msisdnPattern.MatchString(value) {
+					values[i] = fakeMSISDN(value)
+				}
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// fakeMSISDN replaces all but the first two digits of msisdn with digits
+// derived from its hash, keeping its length and format.
+func fakeMSISDN(msisdn string) string {
+	prefix := ""
+	if strings.HasPrefix(msisdn, "+") {
+		prefix, msisdn = "+", msisdn[1:]
+	}
+
+	n := new(big.Int)
+	n.SetString(digest(msisdn), 16)
+	digits := n.String()
+
+	return prefix + msisdn[:2] + digits[:len(msisdn)-2]
+}
+
+func digest(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
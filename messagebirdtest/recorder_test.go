@@ -0,0 +1,53 @@
+package messagebirdtest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/lookup"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "lookup.json")
+
+	// Record against a fake API, in a subtest so the cassette is written
+	// when it finishes.
+	t.Run("record", func(t *testing.T) {
+		api := messagebirdtest.NewServer(t)
+		api.WillReturn([]byte(`{"href":"https://rest.messagebird.com/lookup/31612345678","countryCode":"NL","countryPrefix":31,"phoneNumber":31612345678,"type":"mobile","formats":{"e164":"+31612345678"},"email":"jane@example.org","reference":"20220102150405123","checkedAt":1641135845000}`), http.StatusOK)
+
+		rec := messagebirdtest.NewRecorder(t, cassette, messagebirdtest.ModeRecord)
+		rec.Transport = api.Transport()
+
+		l, err := lookup.Read(rec.Client("live_secret_key"), "31612345678", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "NL", l.CountryCode)
+		assert.NotEqual(t, int64(31612345678), l.PhoneNumber)
+	})
+
+	b, err := ioutil.ReadFile(cassette)
+	assert.NoError(t, err)
+	for _, secret := range []string{"live_secret_key", "31612345678", "jane@example.org"} {
+		assert.False(t, strings.Contains(string(b), secret), "cassette contains %s", secret)
+	}
+
+	// Digits that are not phone numbers are kept.
+	for _, kept := range []string{"20220102150405123", "1641135845000"} {
+		assert.True(t, strings.Contains(string(b), kept), "cassette does not contain %s", kept)
+	}
+
+	// Replay without network access.
+	rec := messagebirdtest.NewRecorder(t, cassette, messagebirdtest.ModeReplay)
+
+	l, err := lookup.Read(rec.Client(""), "31612345678", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "NL", l.CountryCode)
+
+	_, err = lookup.Read(rec.Client(""), "31612345678", nil)
+	assert.Error(t, err)
+}
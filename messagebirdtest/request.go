@@ -0,0 +1,126 @@
+package messagebirdtest
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/jsonpath"
+)
+
+// AssertEndpointCalled fails t if r, e.g. one of the requests returned by
+// Server.Requests, was not made with method to path, e.g. "/messages". The
+// path does not include the query string.
+func (r *Request) AssertEndpointCalled(t testing.TB, method, path string) bool {
+	t.Helper()
+
+	if r.Method != method || r.URL.EscapedPath() != path {
+		t.Errorf("expected %s %s, got %s %s", method, path, r.Method, r.URL.EscapedPath())
+		return false
+	}
+
+	return true
+}
+
+// AssertBodyJSONEq fails t if the body of r is not JSON equal to expected.
+// The order of object keys and whitespace are ignored.
+func (r *Request) AssertBodyJSONEq(t testing.TB, expected string) bool {
+	t.Helper()
+
+	expectedValue, err := jsonpath.Get([]byte(expected), "")
+	if err != nil {
+		t.Errorf("expected body: %v", err)
+		return false
+	}
+
+	actual, err := jsonpath.Get(r.Body, "")
+	if err != nil {
+		t.Errorf("body %s: %v", r.Body, err)
+		return false
+	}
+
+	if !reflect.DeepEqual(expectedValue, actual) {
+		t.Errorf("expected body %s, got %s", expected, r.Body)
+		return false
+	}
+
+	return true
+}
+
+// AssertBodyField fails t if the field at path in the JSON body of r does
+// not equal expected. Fields are addressed by a dot-separated path, with
+// array indexes as numbers, e.g. "recipients.0". Values are compared as JSON,
+// so an int equals the float64 the body decodes into and a []string equals a
+// JSON array of strings.
+func (r *Request) AssertBodyField(t testing.TB, path string, expected interface{}) bool {
+	t.Helper()
+
+	actual, err := jsonpath.Get(r.Body, path)
+	if err != nil {
+		t.Errorf("body field %s: %v", path, err)
+		return false
+	}
+
+	expected, err = jsonpath.Normalize(expected)
+	if err != nil {
+		t.Errorf("expected body field %s: %v", path, err)
+		return false
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected body field %s to be %v, got %v", path, expected, actual)
+		return false
+	}
+
+	return true
+}
+
+// AssertBodyFieldAbsent fails t if the JSON body of r has a field at path,
+// e.g. because it should have been omitted.
+func (r *Request) AssertBodyFieldAbsent(t testing.TB, path string) bool {
+	t.Helper()
+
+	_, err := jsonpath.Get(r.Body, path)
+
+	var notFound *jsonpath.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected body field %s to be absent", path)
+		return false
+	}
+
+	return true
+}
+
+// AssertQueryParam fails t if the query parameter name of r does not have
+// the single value expected.
+func (r *Request) AssertQueryParam(t testing.TB, name, expected string) bool {
+	t.Helper()
+
+	actual := r.URL.Query()[name]
+	if len(actual) != 1 || actual[0] != expected {
+		t.Errorf("expected query parameter %s to be %q, got %q", name, expected, actual)
+		return false
+	}
+
+	return true
+}
+
+// AssertQuery fails t if the query parameters of r are not exactly expected.
+// Unlike comparing raw query strings, the order of the parameters and their
+// encoding do not matter.
+func (r *Request) AssertQuery(t testing.TB, expected url.Values) bool {
+	t.Helper()
+
+	actual := r.URL.Query()
+	if len(expected) == 0 && len(actual) == 0 {
+		return true
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected query %v, got %v", expected, actual)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,236 @@
+// Package messaging sends and lists messages with the unified Messages API.
+// Unlike packages sms, mms and conversation, it uses a single request shape
+// for every channel: the channel determines the platform the message is sent
+// over, and the content is a plain JSON object. New channels and content
+// types can be used without waiting for channel-specific types in this SDK:
+//
+//	message, err := messaging.Send(client, &messaging.SendRequest{
+//		ChannelID: "your channel ID",
+//		To:        "+31612345678",
+//		Content:   messaging.Text("Hello!"),
+//	})
+package messaging
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+// apiRoot is the absolute URL of the Messages API.
+const apiRoot = "https://messaging.messagebird.com/v1"
+
+// path represents the path to the Message resource.
+const path = "messages"
+
+// Directions of a Message.
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
+
+// Statuses of a Message.
+const (
+	StatusAccepted  = "accepted"
+	StatusPending   = "pending"
+	StatusSent      = "sent"
+	StatusDelivered = "delivered"
+	StatusRead      = "read"
+	StatusReceived  = "received"
+	StatusFailed    = "failed"
+	StatusRejected  = "rejected"
+)
+
+// Content is the content of a message, as expected by the platform of the
+// channel. Text, Media and Location build the content types all platforms
+// support, but any content type can be sent by building the object yourself.
+type Content map[string]interface{}
+
+// Text returns the Content of a text message.
+func Text(text string) Content {
+	return Content{"type": "text", "text": text}
+}
+
+// Media returns the Content of a message with a file of mediaType, e.g.
+// "image", "video", "audio" or "file", hosted at url. caption is optional.
+func Media(mediaType, url, caption string) Content {
+	media := map[string]interface{}{"url": url}
+	if caption != "" {
+		media["caption"] = caption
+	}
+
+	return Content{"type": mediaType, mediaType: media}
+}
+
+// Location returns the Content of a message with a location.
+func Location(latitude, longitude float64) Content {
+	return Content{
+		"type":     "location",
+		"location": map[string]interface{}{"latitude": latitude, "longitude": longitude},
+	}
+}
+
+// Type returns the content type, e.g. "text".
+func (c Content) Type() string {
+	t, _ := c["type"].(string)
+	return t
+}
+
+// Message is a message sent or received on any channel.
+type Message struct {
+	ID              string
+	ChannelID       string
+	Platform        string
+	From            string
+	To              string
+	Direction       string
+	Status          string
+	Content         Content
+	Reference       string
+	CreatedDatetime *time.Time
+	UpdatedDatetime *time.Time
+}
+
+// MessageList represents a list of messages.
+type MessageList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Message
+}
+
+// SendRequest is the request to send a message on the channel with
+// ChannelID.
+type SendRequest struct {
+	ChannelID string  `json:"channelId"`
+	To        string  `json:"to"`
+	Content   Content `json:"content"`
+	Reference string  `json:"reference,omitempty"`
+
+	// ReportURL receives status updates of the message.
+	ReportURL string `json:"reportUrl,omitempty"`
+}
+
+// ListRequest can be used to set query params in List().
+type ListRequest struct {
+	ChannelID string
+	Platform  string
+	Direction string // Possible values: DirectionSent and DirectionReceived.
+	Status    string
+	From      string
+	To        string
+
+	// Since and Until limit the messages to those created in this time
+	// range.
+	Since *time.Time
+	Until *time.Time
+
+	Limit  int
+	Offset int
+}
+
+func (lr *ListRequest) QueryParams() string {
+	if lr == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if lr.ChannelID != "" {
+		query.Set("channelId", lr.ChannelID)
+	}
+
+	if lr.Platform != "" {
+		query.Set("platform", lr.Platform)
+	}
+
+	if lr.Direction != "" {
+		query.Set("direction", lr.Direction)
+	}
+
+	if lr.Status != "" {
+		query.Set("status", lr.Status)
+	}
+
+	if lr.From != "" {
+		query.Set("from", lr.From)
+	}
+
+	if lr.To != "" {
+		query.Set("to", lr.To)
+	}
+
+	if lr.Since != nil {
+		query.SetTime("since", *lr.Since, time.RFC3339)
+	}
+
+	if lr.Until != nil {
+		query.SetTime("until", *lr.Until, time.RFC3339)
+	}
+
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
+
+	return query.Encode()
+}
+
+// Send sends a message on the channel of req.
+func Send(c messagebird.Client, req *SendRequest) (*Message, error) {
+	if err := validateSendRequest(req); err != nil {
+		return nil, err
+	}
+
+	message := &Message{}
+	if err := c.Request(message, http.MethodPost, apiRoot+"/"+path, req); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Read retrieves the information of an existing Message.
+func Read(c messagebird.Client, id string) (*Message, error) {
+	message := &Message{}
+	if err := c.Request(message, http.MethodGet, apiRoot+"/"+path+"/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// List retrieves a paginated list of messages of all channels matching
+// params.
+func List(c messagebird.Client, params *ListRequest) (*MessageList, error) {
+	messageList := &MessageList{}
+	if err := c.Request(messageList, http.MethodGet, apiRoot+"/"+path+"?"+params.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return messageList, nil
+}
+
+func validateSendRequest(req *SendRequest) error {
+	if req == nil {
+		return errors.New("send request should not be nil")
+	}
+	if req.ChannelID == "" {
+		return errors.New("channelId is required")
+	}
+	if req.To == "" {
+		return errors.New("to is required")
+	}
+	if req.Content.Type() == "" {
+		return errors.New("content with a type is required")
+	}
+
+	return nil
+}
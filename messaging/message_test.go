@@ -0,0 +1,108 @@
+package messaging
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	message, err := Send(client, &SendRequest{
+		ChannelID: "619747f69cf940a98fb443140ce9aed2",
+		To:        "+31612345678",
+		Content:   Text("Hello!"),
+		Reference: "order-1234",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "f9a7fd4d3a4c4d1e9b9f2a2f3c4d5e6f", message.ID)
+	assert.Equal(t, "whatsapp", message.Platform)
+	assert.Equal(t, StatusAccepted, message.Status)
+	assert.Equal(t, "text", message.Content.Type())
+	assert.Equal(t, "Hello!", message.Content["text"])
+	assert.Equal(t, time.Date(2022, 1, 5, 10, 2, 59, 0, time.UTC), *message.CreatedDatetime)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/messages")
+	mbtest.AssertBodyJSONEq(t, `{
+		"channelId": "619747f69cf940a98fb443140ce9aed2",
+		"to": "+31612345678",
+		"content": {"type": "text", "text": "Hello!"},
+		"reference": "order-1234"
+	}`)
+}
+
+func TestSendValidation(t *testing.T) {
+	client := mbtest.Client(t)
+
+	_, err := Send(client, nil)
+	assert.EqualError(t, err, "send request should not be nil")
+
+	_, err = Send(client, &SendRequest{To: "+31612345678", Content: Text("Hi")})
+	assert.EqualError(t, err, "channelId is required")
+
+	_, err = Send(client, &SendRequest{ChannelID: "id", Content: Text("Hi")})
+	assert.EqualError(t, err, "to is required")
+
+	_, err = Send(client, &SendRequest{ChannelID: "id", To: "+31612345678", Content: Content{"text": "Hi"}})
+	assert.EqualError(t, err, "content with a type is required")
+}
+
+func TestContent(t *testing.T) {
+	assert.Equal(t, Content{
+		"type":  "image",
+		"image": map[string]interface{}{"url": "https://example.com/photo.jpg", "caption": "Look"},
+	}, Media("image", "https://example.com/photo.jpg", "Look"))
+
+	assert.Equal(t, Content{
+		"type":     "location",
+		"location": map[string]interface{}{"latitude": 52.37, "longitude": 4.89},
+	}, Location(52.37, 4.89))
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := Read(client, "f9a7fd4d3a4c4d1e9b9f2a2f3c4d5e6f")
+	assert.NoError(t, err)
+	assert.Equal(t, "+31612345678", message.To)
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages/f9a7fd4d3a4c4d1e9b9f2a2f3c4d5e6f")
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	since := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	list, err := List(client, &ListRequest{Direction: DirectionReceived, Since: &since, Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.TotalCount)
+	assert.Equal(t, "telegram", list.Items[1].Platform)
+	assert.Equal(t, "image", list.Items[1].Content.Type())
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages")
+	mbtest.AssertQuery(t, url.Values{
+		"direction": {"received"},
+		"since":     {"2022-01-01T00:00:00Z"},
+		"limit":     {"20"},
+	})
+}
+
+func TestListNilParams(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := List(client, nil)
+	assert.NoError(t, err)
+	mbtest.AssertQuery(t, nil)
+}
@@ -0,0 +1,162 @@
+package mms
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/files"
+)
+
+// InboundMessage is an MMS message received on one of your numbers, as
+// delivered to your webhook.
+type InboundMessage struct {
+	ID              string
+	Originator      string
+	Recipient       string
+	Subject         string
+	Body            string
+	Attachments     []*Attachment
+	CreatedDatetime *time.Time
+}
+
+// Attachment is a media file of an InboundMessage.
+type Attachment struct {
+	URL string
+
+	// ContentType is the media type of the file, e.g. image/jpeg. It is
+	// guessed from the URL if the webhook does not include it.
+	ContentType string
+}
+
+type inboundJSON struct {
+	ID                string   `json:"id"`
+	Originator        string   `json:"originator"`
+	Recipient         string   `json:"recipient"`
+	Subject           string   `json:"subject"`
+	Body              string   `json:"body"`
+	MediaUrls         []string `json:"mediaUrls"`
+	MediaContentTypes []string `json:"mediaContentTypes"`
+	CreatedDatetime   string   `json:"createdDatetime"`
+}
+
+// ParseInbound parses the request MessageBird sends to your webhook when an
+// MMS message is received. Both query string, form and JSON encoded requests
+// are supported. Use signature_jwt to verify the request first.
+func ParseInbound(r *http.Request) (*InboundMessage, error) {
+	raw := &inboundJSON{}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(raw); err != nil {
+			return nil, fmt.Errorf("decoding inbound MMS: %w", err)
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("parsing inbound MMS: %w", err)
+		}
+
+		raw.ID = r.Form.Get("id")
+		raw.Originator = r.Form.Get("originator")
+		raw.Recipient = r.Form.Get("recipient")
+		raw.Subject = r.Form.Get("subject")
+		raw.Body = r.Form.Get("body")
+		raw.MediaUrls = formValues(r.Form, "mediaUrls")
+		raw.MediaContentTypes = formValues(r.Form, "mediaContentTypes")
+		raw.CreatedDatetime = r.Form.Get("createdDatetime")
+	}
+
+	if raw.ID == "" || raw.Originator == "" {
+		return nil, fmt.Errorf("request is not an inbound MMS: id and originator are required")
+	}
+
+	msg := &InboundMessage{
+		ID:         raw.ID,
+		Originator: raw.Originator,
+		Recipient:  raw.Recipient,
+		Subject:    raw.Subject,
+		Body:       raw.Body,
+	}
+
+	if raw.CreatedDatetime != "" {
+		created, err := time.Parse(time.RFC3339, raw.CreatedDatetime)
+		if err != nil {
+			return nil, fmt.Errorf("parsing createdDatetime: %w", err)
+		}
+		msg.CreatedDatetime = &created
+	}
+
+	for i, mediaURL := range raw.MediaUrls {
+		a := &Attachment{URL: mediaURL}
+		if i < len(raw.MediaContentTypes) {
+			a.ContentType = raw.MediaContentTypes[i]
+		}
+		if a.ContentType == "" {
+			a.ContentType = contentTypeFromURL(mediaURL)
+		}
+		msg.Attachments = append(msg.Attachments, a)
+	}
+
+	return msg, nil
+}
+
+// formValues returns the values of key, which may be sent as key or key[].
+func formValues(form url.Values, key string) []string {
+	if values, ok := form[key+"[]"]; ok {
+		return values
+	}
+
+	return form[key]
+}
+
+func contentTypeFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return files.TypeByExtension(u.Path)
+}
+
+// DownloadAttachment downloads the file of a and writes it to w. It returns
+// the number of bytes written. The access key of c is only sent along if the
+// file is hosted by MessageBird.
+//
+// If the attachment has no content type yet, it is set from the response.
+func DownloadAttachment(c *messagebird.DefaultClient, a *Attachment, w io.Writer) (int64, error) {
+	u, err := url.Parse(a.URL)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if u.Scheme == "https" && (u.Hostname() == "messagebird.com" || strings.HasSuffix(u.Hostname(), ".messagebird.com")) {
+		req.Header.Set("Authorization", "AccessKey "+c.AccessKey)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("downloading attachment: unexpected status %s", resp.Status)
+	}
+
+	if a.ContentType == "" {
+		a.ContentType, _, _ = mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	}
+
+	return io.Copy(w, resp.Body)
+}
@@ -0,0 +1,88 @@
+package mms
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInboundQuery(t *testing.T) {
+	query := url.Values{}
+	query.Set("id", "inbound-id")
+	query.Set("originator", "31612345678")
+	query.Set("recipient", "31687654321")
+	query.Set("subject", "Holiday")
+	query.Set("body", "Look at this!")
+	query.Add("mediaUrls[]", "https://media.example.com/beach.jpg")
+	query.Add("mediaUrls[]", "https://media.example.com/card.vcf")
+	query.Set("createdDatetime", "2022-06-01T12:00:00+00:00")
+
+	r := httptest.NewRequest(http.MethodGet, "/webhook?"+query.Encode(), nil)
+
+	msg, err := ParseInbound(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "inbound-id", msg.ID)
+	assert.Equal(t, "31612345678", msg.Originator)
+	assert.Equal(t, "31687654321", msg.Recipient)
+	assert.Equal(t, "Holiday", msg.Subject)
+	assert.Equal(t, "Look at this!", msg.Body)
+	assert.True(t, msg.CreatedDatetime.Equal(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, []*Attachment{
+		{URL: "https://media.example.com/beach.jpg", ContentType: "image/jpeg"},
+		{URL: "https://media.example.com/card.vcf", ContentType: "text/vcard"},
+	}, msg.Attachments)
+}
+
+func TestParseInboundJSON(t *testing.T) {
+	body := `{"id":"inbound-id","originator":"31612345678","mediaUrls":["https://media.example.com/file"],"mediaContentTypes":["image/png"]}`
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	msg, err := ParseInbound(r)
+	assert.NoError(t, err)
+	assert.Nil(t, msg.CreatedDatetime)
+	assert.Equal(t, []*Attachment{{URL: "https://media.example.com/file", ContentType: "image/png"}}, msg.Attachments)
+}
+
+func TestParseInboundInvalid(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/webhook?body=hi", nil)
+
+	_, err := ParseInbound(r)
+	assert.Error(t, err)
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	var authorization []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "image/gif")
+		w.Write(gif)
+	}))
+	defer stop()
+
+	client := mbtest.Client(t)
+	client.AccessKey = "test-key"
+	client.HTTPClient.Transport = transport
+
+	a := &Attachment{URL: "https://messaging.messagebird.com/v1/files/file-id"}
+
+	var buf bytes.Buffer
+	n, err := DownloadAttachment(client, a, &buf)
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(gif), n)
+	assert.Equal(t, gif, buf.Bytes())
+	assert.Equal(t, "image/gif", a.ContentType)
+
+	_, err = DownloadAttachment(client, &Attachment{URL: "https://media.example.com/file"}, &buf)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"AccessKey test-key", ""}, authorization)
+}
@@ -0,0 +1,85 @@
+package mms
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+)
+
+// defaultIteratorLimit is the page size used by an Iterator when the
+// ListRequest does not set a limit.
+const defaultIteratorLimit = 20
+
+// An Iterator walks through all MMS messages matching a ListRequest,
+// fetching pages from the API as needed.
+//
+//	it := mms.Iterate(client, &mms.ListRequest{Direction: mms.DirectionReceived})
+//	for it.Next() {
+//		fmt.Println(it.Message().Subject)
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle the error.
+//	}
+//
+// Iterators are single use and can therefore not be reset.
+type Iterator struct {
+	client  messagebird.Client
+	params  ListRequest
+	page    []*Message
+	message *Message
+	pager   paging.Pager
+}
+
+// Iterate returns an Iterator over the MMS messages matching params,
+// starting at params.Offset. params may be nil to iterate over all messages.
+func Iterate(c messagebird.Client, params *ListRequest) *Iterator {
+	it := &Iterator{client: c}
+	if params != nil {
+		it.params = *params
+	}
+	if it.params.Limit == 0 {
+		it.params.Limit = defaultIteratorLimit
+	}
+	it.pager.Offset = it.params.Offset
+
+	return it
+}
+
+// Next advances the iterator to the next message, which is then available
+// through Message. It returns false when no more messages are available or
+// an error occurred.
+func (it *Iterator) Next() bool {
+	if len(it.page) == 0 {
+		it.fetch()
+	}
+
+	if len(it.page) == 0 {
+		it.message = nil
+		return false
+	}
+
+	it.message, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Message returns the message the iterator currently points at.
+func (it *Iterator) Message() *Message {
+	return it.message
+}
+
+// Err returns the first error that occurred while fetching messages, if any.
+func (it *Iterator) Err() error {
+	return it.pager.Err()
+}
+
+func (it *Iterator) fetch() {
+	it.pager.Fetch(func(offset int) (int, int, error) {
+		it.params.Offset = offset
+		messages, err := List(it.client, &it.params)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		it.page = messages.Items
+		return len(messages.Items), messages.TotalCount, nil
+	})
+}
@@ -0,0 +1,118 @@
+package mms
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/files"
+)
+
+const (
+	// MaxMediaSize is the maximum size in bytes of a single media file.
+	MaxMediaSize = 1024 * 1024
+
+	// MaxMediaItems is the maximum number of media files per message.
+	MaxMediaItems = 10
+)
+
+// supportedContentTypes lists the media types that can be sent by MMS.
+var supportedContentTypes = map[string]bool{
+	"audio/basic":            true,
+	"audio/L24":              true,
+	"audio/mp4":              true,
+	"audio/mpeg":             true,
+	"audio/ogg":              true,
+	"audio/vorbis":           true,
+	"audio/vnd.rn-realaudio": true,
+	"audio/vnd.wave":         true,
+	"audio/3gpp":             true,
+	"audio/3gpp2":            true,
+	"audio/ac3":              true,
+	"audio/webm":             true,
+	"audio/amr-nb":           true,
+	"audio/amr":              true,
+	"video/mpeg":             true,
+	"video/mp4":              true,
+	"video/quicktime":        true,
+	"video/webm":             true,
+	"video/3gpp":             true,
+	"video/3gpp2":            true,
+	"video/3gpp-tt":          true,
+	"video/H261":             true,
+	"video/H263":             true,
+	"video/H263-1998":        true,
+	"video/H263-2000":        true,
+	"video/H264":             true,
+	"image/jpeg":             true,
+	"image/gif":              true,
+	"image/png":              true,
+	"image/bmp":              true,
+	"text/vcard":             true,
+	"text/csv":               true,
+	"text/rtf":               true,
+	"text/richtext":          true,
+	"text/calendar":          true,
+	"application/pdf":        true,
+}
+
+// Media is a file uploaded with UploadMedia.
+type Media struct {
+	ID          string
+	URL         string
+	ContentType string
+	Size        int
+}
+
+// UploadMedia uploads the contents of r, so it can be sent by MMS without
+// hosting it yourself. The content type is detected from the contents, or
+// from the extension of name if the contents are not conclusive. An error is
+// returned if the file is larger than MaxMediaSize or the content type can not
+// be sent by MMS.
+func UploadMedia(c messagebird.Client, name string, r io.Reader) (*Media, error) {
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, MaxMediaSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > MaxMediaSize {
+		return nil, fmt.Errorf("media exceeds maximum size of %d bytes", MaxMediaSize)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("media is empty")
+	}
+
+	contentType := files.DetectContentType(name, buf.Bytes())
+	if !supportedContentTypes[contentType] {
+		return nil, fmt.Errorf("content type %q is not supported by MMS", contentType)
+	}
+
+	file, err := files.Upload(c, name, contentType, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Media{
+		ID:          file.ID,
+		URL:         file.URL,
+		ContentType: contentType,
+		Size:        int(n),
+	}, nil
+}
+
+// AttachMedia uploads the contents of r with UploadMedia and adds it to the
+// MediaUrls of req.
+func (req *CreateRequest) AttachMedia(c messagebird.Client, name string, r io.Reader) error {
+	if len(req.MediaUrls) >= MaxMediaItems {
+		return fmt.Errorf("can not attach more than %d media files", MaxMediaItems)
+	}
+
+	media, err := UploadMedia(c, name, r)
+	if err != nil {
+		return err
+	}
+
+	req.MediaUrls = append(req.MediaUrls, media.URL)
+	return nil
+}
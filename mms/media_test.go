@@ -0,0 +1,68 @@
+package mms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// gif is the smallest valid GIF image.
+var gif, _ = base64.StdEncoding.DecodeString("R0lGODlhAQABAIAAAP///wAAACH5BAEAAAAALAAAAAABAAEAAAICRAEAOw==")
+
+func TestUploadMedia(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"file-id"}`), http.StatusCreated)
+	client := mbtest.Client(t)
+
+	media, err := UploadMedia(client, "pixel.gif", bytes.NewReader(gif))
+	assert.NoError(t, err)
+	assert.Equal(t, "file-id", media.ID)
+	assert.Equal(t, "https://messaging.messagebird.com/v1/files/file-id", media.URL)
+	assert.Equal(t, "image/gif", media.ContentType)
+	assert.Equal(t, len(gif), media.Size)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/files")
+
+	mbtest.AssertBodyField(t, "name", "pixel.gif")
+	mbtest.AssertBodyField(t, "contentType", "image/gif")
+	mbtest.AssertBodyField(t, "content", base64.StdEncoding.EncodeToString(gif))
+}
+
+func TestUploadMediaVCard(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"file-id","url":"https://media.example.com/file-id"}`), http.StatusCreated)
+	client := mbtest.Client(t)
+
+	media, err := UploadMedia(client, "contact.VCF", strings.NewReader("BEGIN:VCARD\nEND:VCARD\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "text/vcard", media.ContentType)
+	assert.Equal(t, "https://media.example.com/file-id", media.URL)
+}
+
+func TestUploadMediaInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	_, err := UploadMedia(client, "notes.txt", strings.NewReader("plain text"))
+	assert.EqualError(t, err, `content type "text/plain" is not supported by MMS`)
+
+	_, err = UploadMedia(client, "empty.gif", strings.NewReader(""))
+	assert.EqualError(t, err, "media is empty")
+
+	_, err = UploadMedia(client, "large.gif", bytes.NewReader(make([]byte, MaxMediaSize+1)))
+	assert.EqualError(t, err, "media exceeds maximum size of 1048576 bytes")
+}
+
+func TestAttachMedia(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"file-id"}`), http.StatusCreated)
+	client := mbtest.Client(t)
+
+	req := &CreateRequest{MediaUrls: []string{"https://example.com/existing.gif"}}
+	assert.NoError(t, req.AttachMedia(client, "pixel.gif", bytes.NewReader(gif)))
+	assert.Equal(t, []string{"https://example.com/existing.gif", "https://messaging.messagebird.com/v1/files/file-id"}, req.MediaUrls)
+
+	req.MediaUrls = make([]string, MaxMediaItems)
+	assert.Error(t, req.AttachMedia(client, "pixel.gif", bytes.NewReader(gif)))
+}
@@ -6,11 +6,22 @@ import (
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
 )
 
 // path represents the path to the MMS resource.
 const path = "mms"
 
+const (
+	// DirectionSent is the direction of messages sent by you (mobile
+	// terminated).
+	DirectionSent = "mt"
+
+	// DirectionReceived is the direction of messages sent to you (mobile
+	// originated).
+	DirectionReceived = "mo"
+)
+
 // Message represents a MMS Message.
 type Message struct {
 	ID                string
@@ -26,6 +37,67 @@ type Message struct {
 	Recipients        messagebird.Recipients
 }
 
+// MessageList represents a list of MMS messages.
+type MessageList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Message
+}
+
+// ListRequest can be used to set query params in List().
+type ListRequest struct {
+	Originator string
+	Recipient  string
+	Direction  string // Possible values: DirectionSent and DirectionReceived.
+
+	// From and Until limit the messages to those created in this time range.
+	From  *time.Time
+	Until *time.Time
+
+	Limit  int
+	Offset int
+}
+
+func (lr *ListRequest) QueryParams() string {
+	if lr == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if lr.Originator != "" {
+		query.Set("originator", lr.Originator)
+	}
+
+	if lr.Recipient != "" {
+		query.Set("recipient", lr.Recipient)
+	}
+
+	if lr.Direction != "" {
+		query.Set("direction", lr.Direction)
+	}
+
+	if lr.From != nil {
+		query.SetTime("from", *lr.From, time.RFC3339)
+	}
+
+	if lr.Until != nil {
+		query.SetTime("until", *lr.Until, time.RFC3339)
+	}
+
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
+
+	return query.Encode()
+}
+
 type CreateRequest struct {
 	Originator        string     `json:"originator"` // the sender of the message.
 	Recipients        string     `json:"recipients"` // comma separated list
@@ -46,6 +118,26 @@ func Read(c messagebird.Client, id string) (*Message, error) {
 	return mmsMessage, nil
 }
 
+// List retrieves a paginated list of MMS messages matching params.
+func List(c messagebird.Client, params *ListRequest) (*MessageList, error) {
+	messageList := &MessageList{}
+	if err := c.Request(messageList, http.MethodGet, path+"?"+params.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return messageList, nil
+}
+
+// Delete deletes an MMS message. If nil is returned, the resource was deleted
+// successfully.
+func Delete(c messagebird.Client, id string) error {
+	if id == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, path+"/"+id, nil)
+}
+
 // Create creates a new MMS message for one or more recipients.
 // Max of 50 recipients can be entered per request.
 func Create(c messagebird.Client, req *CreateRequest) (*Message, error) {
@@ -70,5 +162,9 @@ func validateCreateRequest(req *CreateRequest) error {
 		return fmt.Errorf("body or mediaUrls is required")
 	}
 
+	if len(req.MediaUrls) > MaxMediaItems {
+		return fmt.Errorf("mediaUrls can not contain more than %d items", MaxMediaItems)
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package mms
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -109,3 +110,56 @@ func TestRead(t *testing.T) {
 	_, ok := err.(messagebird.ErrorResponse)
 	assert.False(t, ok)
 }
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "mmsMessageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	from := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	list, err := List(client, &ListRequest{
+		Originator: "31612345678",
+		Direction:  DirectionReceived,
+		From:       &from,
+		Limit:      10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, list.TotalCount)
+	assert.Equal(t, "TestSubject", list.Items[0].Subject)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/mms")
+	mbtest.AssertQuery(t, url.Values{
+		"direction":  {"mo"},
+		"from":       {"2022-05-01T00:00:00Z"},
+		"limit":      {"10"},
+		"originator": {"31612345678"},
+	})
+}
+
+func TestDelete(t *testing.T) {
+	mbtest.WillReturnOnlyStatus(http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := Delete(client, "6d9e7100b1f9406c81a3c303c30ccf05")
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/mms/6d9e7100b1f9406c81a3c303c30ccf05")
+}
+
+func TestIterate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "mmsMessageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	it := Iterate(client, &ListRequest{Recipient: "31612345678"})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Message().ID)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"6d9e7100b1f9406c81a3c303c30ccf05"}, ids)
+	mbtest.AssertQuery(t, url.Values{
+		"limit":     {"20"},
+		"recipient": {"31612345678"},
+	})
+}
@@ -0,0 +1,51 @@
+package mms
+
+import (
+	"io"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Service is the MMS API bound to a client, see sms.Service.
+type Service interface {
+	Iterate(params *ListRequest) *Iterator
+	UploadMedia(name string, r io.Reader) (*Media, error)
+	Read(id string) (*Message, error)
+	List(params *ListRequest) (*MessageList, error)
+	Delete(id string) error
+	Create(req *CreateRequest) (*Message, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Iterate(params *ListRequest) *Iterator {
+	return Iterate(s.c, params)
+}
+
+func (s *service) UploadMedia(name string, r io.Reader) (*Media, error) {
+	return UploadMedia(s.c, name, r)
+}
+
+func (s *service) Read(id string) (*Message, error) {
+	return Read(s.c, id)
+}
+
+func (s *service) List(params *ListRequest) (*MessageList, error) {
+	return List(s.c, params)
+}
+
+func (s *service) Delete(id string) error {
+	return Delete(s.c, id)
+}
+
+func (s *service) Create(req *CreateRequest) (*Message, error) {
+	return Create(s.c, req)
+}
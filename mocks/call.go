@@ -0,0 +1,7 @@
+package mocks
+
+// Call is a call of a method of a fake.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
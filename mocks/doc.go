@@ -0,0 +1,18 @@
+// Package mocks provides fakes of messagebird.Client and the Service
+// interfaces of the API packages, for testing code that uses them:
+//
+//	fake := &mocks.SMSService{
+//		SendFunc: func(req *sms.SendRequest) (*sms.Message, error) {
+//			return &sms.Message{ID: "message-id"}, nil
+//		},
+//	}
+//	n := &Notifier{SMS: fake}
+//	// ...
+//	calls := fake.Calls()
+//
+// The fakes are generated from the interfaces of this version of the SDK, so
+// they stay in sync with it. Every method calls the function in the field
+// named after it, which must be set, and records the call.
+package mocks
+
+//go:generate go run ../internal/mockgen ..:Client=Client ../balance:Service=BalanceService ../contact:Service=ContactService ../conversation:Service=ConversationService ../group:Service=GroupService ../hlr:Service=HLRService ../lookup:Service=LookupService ../mms:Service=MMSService ../sms:Service=SMSService ../verify:Service=VerifyService ../voicemessage:Service=VoiceMessageService
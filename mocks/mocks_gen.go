@@ -0,0 +1,1201 @@
+// Code generated by mockgen ..:Client=Client ../balance:Service=BalanceService ../contact:Service=ContactService ../conversation:Service=ConversationService ../group:Service=GroupService ../hlr:Service=HLRService ../lookup:Service=LookupService ../mms:Service=MMSService ../sms:Service=SMSService ../verify:Service=VerifyService ../voicemessage:Service=VoiceMessageService; DO NOT EDIT.
+
+package mocks
+
+import (
+	"io"
+	"sync"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/group"
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/lookup"
+	"github.com/messagebird/go-rest-api/v9/mms"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/verify"
+	"github.com/messagebird/go-rest-api/v9/voicemessage"
+)
+
+// Client is a fake messagebird.Client, see the package documentation.
+type Client struct {
+	RequestFunc func(v interface{}, method string, path string, data interface{}) error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ messagebird.Client = (*Client)(nil)
+
+// Request implements messagebird.Client.
+func (m *Client) Request(v interface{}, method string, path string, data interface{}) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Request", Args: []interface{}{v, method, path, data}})
+	m.mu.Unlock()
+
+	if m.RequestFunc == nil {
+		panic("mocks: Client.Request called without RequestFunc")
+	}
+
+	return m.RequestFunc(v, method, path, data)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// BalanceService is a fake balance.Service, see the package documentation.
+type BalanceService struct {
+	ReadFunc func() (*balance.Balance, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ balance.Service = (*BalanceService)(nil)
+
+// Read implements balance.Service.
+func (m *BalanceService) Read() (*balance.Balance, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: BalanceService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc()
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *BalanceService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// ContactService is a fake contact.Service, see the package documentation.
+type ContactService struct {
+	CheckConsentFunc func(contact *contact.Contact, channel string) error
+	SendSMSFunc      func(contact *contact.Contact, originator string, body string, params *sms.Params) (*sms.Message, error)
+	CreateFunc       func(contactRequest *contact.CreateRequest) (*contact.Contact, error)
+	DeleteFunc       func(id string) error
+	ListFunc         func(options *messagebird.PaginationRequest) (*contact.Contacts, error)
+	SearchFunc       func(query *contact.SearchRequest) (*contact.Contacts, error)
+	GroupsFunc       func(id string, options *messagebird.PaginationRequest) (*contact.GroupList, error)
+	ReadFunc         func(id string, req *contact.ViewRequest) (*contact.Contact, error)
+	UpsertFunc       func(contactRequest *contact.CreateRequest) (*contact.Contact, bool, error)
+	UpdateFunc       func(id string, contactRequest *contact.CreateRequest) (*contact.Contact, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ contact.Service = (*ContactService)(nil)
+
+// CheckConsent implements contact.Service.
+func (m *ContactService) CheckConsent(contact *contact.Contact, channel string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "CheckConsent", Args: []interface{}{contact, channel}})
+	m.mu.Unlock()
+
+	if m.CheckConsentFunc == nil {
+		panic("mocks: ContactService.CheckConsent called without CheckConsentFunc")
+	}
+
+	return m.CheckConsentFunc(contact, channel)
+}
+
+// SendSMS implements contact.Service.
+func (m *ContactService) SendSMS(contact *contact.Contact, originator string, body string, params *sms.Params) (*sms.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "SendSMS", Args: []interface{}{contact, originator, body, params}})
+	m.mu.Unlock()
+
+	if m.SendSMSFunc == nil {
+		panic("mocks: ContactService.SendSMS called without SendSMSFunc")
+	}
+
+	return m.SendSMSFunc(contact, originator, body, params)
+}
+
+// Create implements contact.Service.
+func (m *ContactService) Create(contactRequest *contact.CreateRequest) (*contact.Contact, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Create", Args: []interface{}{contactRequest}})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks: ContactService.Create called without CreateFunc")
+	}
+
+	return m.CreateFunc(contactRequest)
+}
+
+// Delete implements contact.Service.
+func (m *ContactService) Delete(id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Delete", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks: ContactService.Delete called without DeleteFunc")
+	}
+
+	return m.DeleteFunc(id)
+}
+
+// List implements contact.Service.
+func (m *ContactService) List(options *messagebird.PaginationRequest) (*contact.Contacts, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "List", Args: []interface{}{options}})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks: ContactService.List called without ListFunc")
+	}
+
+	return m.ListFunc(options)
+}
+
+// Search implements contact.Service.
+func (m *ContactService) Search(query *contact.SearchRequest) (*contact.Contacts, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Search", Args: []interface{}{query}})
+	m.mu.Unlock()
+
+	if m.SearchFunc == nil {
+		panic("mocks: ContactService.Search called without SearchFunc")
+	}
+
+	return m.SearchFunc(query)
+}
+
+// Groups implements contact.Service.
+func (m *ContactService) Groups(id string, options *messagebird.PaginationRequest) (*contact.GroupList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Groups", Args: []interface{}{id, options}})
+	m.mu.Unlock()
+
+	if m.GroupsFunc == nil {
+		panic("mocks: ContactService.Groups called without GroupsFunc")
+	}
+
+	return m.GroupsFunc(id, options)
+}
+
+// Read implements contact.Service.
+func (m *ContactService) Read(id string, req *contact.ViewRequest) (*contact.Contact, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id, req}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: ContactService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id, req)
+}
+
+// Upsert implements contact.Service.
+func (m *ContactService) Upsert(contactRequest *contact.CreateRequest) (*contact.Contact, bool, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Upsert", Args: []interface{}{contactRequest}})
+	m.mu.Unlock()
+
+	if m.UpsertFunc == nil {
+		panic("mocks: ContactService.Upsert called without UpsertFunc")
+	}
+
+	return m.UpsertFunc(contactRequest)
+}
+
+// Update implements contact.Service.
+func (m *ContactService) Update(id string, contactRequest *contact.CreateRequest) (*contact.Contact, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Update", Args: []interface{}{id, contactRequest}})
+	m.mu.Unlock()
+
+	if m.UpdateFunc == nil {
+		panic("mocks: ContactService.Update called without UpdateFunc")
+	}
+
+	return m.UpdateFunc(id, contactRequest)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *ContactService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// ConversationService is a fake conversation.Service, see the package documentation.
+type ConversationService struct {
+	ListFunc                     func(options *conversation.ListRequest) (*conversation.Conversations, error)
+	ListByContactFunc            func(contactId string, options *messagebird.PaginationRequest) (*conversation.ConversationsByContact, error)
+	ReadFunc                     func(id string) (*conversation.Conversation, error)
+	StartFunc                    func(req *conversation.StartRequest) (*conversation.Conversation, error)
+	ReplyFunc                    func(conversationID string, req *conversation.ReplyRequest) (*conversation.Message, error)
+	UpdateFunc                   func(id string, req *conversation.UpdateRequest) (*conversation.Conversation, error)
+	UploadMediaFunc              func(name string, r io.Reader, caption string) (*conversation.Media, error)
+	SendMessageFunc              func(options *conversation.SendMessageRequest) (*conversation.Message, error)
+	ListConversationMessagesFunc func(conversationID string, options *conversation.ListConversationMessagesRequest) (*conversation.MessageList, error)
+	ListMessagesFunc             func(options *conversation.ListMessagesRequest) (*conversation.MessageList, error)
+	ReadMessageFunc              func(messageID string) (*conversation.Message, error)
+	RegisterPushDeviceFunc       func(channelID string, req *conversation.PushDeviceRequest) (*conversation.PushDevice, error)
+	ListPushDevicesFunc          func(channelID string, options *messagebird.PaginationRequest) (*conversation.PushDeviceList, error)
+	DeletePushDeviceFunc         func(channelID string, id string) error
+	CreateWebhookFunc            func(req *conversation.WebhookCreateRequest) (*conversation.Webhook, error)
+	DeleteWebhookFunc            func(id string) error
+	ListWebhooksFunc             func(options *messagebird.PaginationRequest) (*conversation.WebhookList, error)
+	ReadWebhookFunc              func(id string) (*conversation.Webhook, error)
+	UpdateWebhookFunc            func(id string, req *conversation.WebhookUpdateRequest) (*conversation.Webhook, error)
+	CanSendFreeformFunc          func(id string) (bool, error)
+	CheckServiceWindowFunc       func(id string) error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ conversation.Service = (*ConversationService)(nil)
+
+// List implements conversation.Service.
+func (m *ConversationService) List(options *conversation.ListRequest) (*conversation.Conversations, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "List", Args: []interface{}{options}})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks: ConversationService.List called without ListFunc")
+	}
+
+	return m.ListFunc(options)
+}
+
+// ListByContact implements conversation.Service.
+func (m *ConversationService) ListByContact(contactId string, options *messagebird.PaginationRequest) (*conversation.ConversationsByContact, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ListByContact", Args: []interface{}{contactId, options}})
+	m.mu.Unlock()
+
+	if m.ListByContactFunc == nil {
+		panic("mocks: ConversationService.ListByContact called without ListByContactFunc")
+	}
+
+	return m.ListByContactFunc(contactId, options)
+}
+
+// Read implements conversation.Service.
+func (m *ConversationService) Read(id string) (*conversation.Conversation, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: ConversationService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id)
+}
+
+// Start implements conversation.Service.
+func (m *ConversationService) Start(req *conversation.StartRequest) (*conversation.Conversation, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Start", Args: []interface{}{req}})
+	m.mu.Unlock()
+
+	if m.StartFunc == nil {
+		panic("mocks: ConversationService.Start called without StartFunc")
+	}
+
+	return m.StartFunc(req)
+}
+
+// Reply implements conversation.Service.
+func (m *ConversationService) Reply(conversationID string, req *conversation.ReplyRequest) (*conversation.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Reply", Args: []interface{}{conversationID, req}})
+	m.mu.Unlock()
+
+	if m.ReplyFunc == nil {
+		panic("mocks: ConversationService.Reply called without ReplyFunc")
+	}
+
+	return m.ReplyFunc(conversationID, req)
+}
+
+// Update implements conversation.Service.
+func (m *ConversationService) Update(id string, req *conversation.UpdateRequest) (*conversation.Conversation, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Update", Args: []interface{}{id, req}})
+	m.mu.Unlock()
+
+	if m.UpdateFunc == nil {
+		panic("mocks: ConversationService.Update called without UpdateFunc")
+	}
+
+	return m.UpdateFunc(id, req)
+}
+
+// UploadMedia implements conversation.Service.
+func (m *ConversationService) UploadMedia(name string, r io.Reader, caption string) (*conversation.Media, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "UploadMedia", Args: []interface{}{name, r, caption}})
+	m.mu.Unlock()
+
+	if m.UploadMediaFunc == nil {
+		panic("mocks: ConversationService.UploadMedia called without UploadMediaFunc")
+	}
+
+	return m.UploadMediaFunc(name, r, caption)
+}
+
+// SendMessage implements conversation.Service.
+func (m *ConversationService) SendMessage(options *conversation.SendMessageRequest) (*conversation.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "SendMessage", Args: []interface{}{options}})
+	m.mu.Unlock()
+
+	if m.SendMessageFunc == nil {
+		panic("mocks: ConversationService.SendMessage called without SendMessageFunc")
+	}
+
+	return m.SendMessageFunc(options)
+}
+
+// ListConversationMessages implements conversation.Service.
+func (m *ConversationService) ListConversationMessages(conversationID string, options *conversation.ListConversationMessagesRequest) (*conversation.MessageList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ListConversationMessages", Args: []interface{}{conversationID, options}})
+	m.mu.Unlock()
+
+	if m.ListConversationMessagesFunc == nil {
+		panic("mocks: ConversationService.ListConversationMessages called without ListConversationMessagesFunc")
+	}
+
+	return m.ListConversationMessagesFunc(conversationID, options)
+}
+
+// ListMessages implements conversation.Service.
+func (m *ConversationService) ListMessages(options *conversation.ListMessagesRequest) (*conversation.MessageList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ListMessages", Args: []interface{}{options}})
+	m.mu.Unlock()
+
+	if m.ListMessagesFunc == nil {
+		panic("mocks: ConversationService.ListMessages called without ListMessagesFunc")
+	}
+
+	return m.ListMessagesFunc(options)
+}
+
+// ReadMessage implements conversation.Service.
+func (m *ConversationService) ReadMessage(messageID string) (*conversation.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ReadMessage", Args: []interface{}{messageID}})
+	m.mu.Unlock()
+
+	if m.ReadMessageFunc == nil {
+		panic("mocks: ConversationService.ReadMessage called without ReadMessageFunc")
+	}
+
+	return m.ReadMessageFunc(messageID)
+}
+
+// RegisterPushDevice implements conversation.Service.
+func (m *ConversationService) RegisterPushDevice(channelID string, req *conversation.PushDeviceRequest) (*conversation.PushDevice, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "RegisterPushDevice", Args: []interface{}{channelID, req}})
+	m.mu.Unlock()
+
+	if m.RegisterPushDeviceFunc == nil {
+		panic("mocks: ConversationService.RegisterPushDevice called without RegisterPushDeviceFunc")
+	}
+
+	return m.RegisterPushDeviceFunc(channelID, req)
+}
+
+// ListPushDevices implements conversation.Service.
+func (m *ConversationService) ListPushDevices(channelID string, options *messagebird.PaginationRequest) (*conversation.PushDeviceList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ListPushDevices", Args: []interface{}{channelID, options}})
+	m.mu.Unlock()
+
+	if m.ListPushDevicesFunc == nil {
+		panic("mocks: ConversationService.ListPushDevices called without ListPushDevicesFunc")
+	}
+
+	return m.ListPushDevicesFunc(channelID, options)
+}
+
+// DeletePushDevice implements conversation.Service.
+func (m *ConversationService) DeletePushDevice(channelID string, id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "DeletePushDevice", Args: []interface{}{channelID, id}})
+	m.mu.Unlock()
+
+	if m.DeletePushDeviceFunc == nil {
+		panic("mocks: ConversationService.DeletePushDevice called without DeletePushDeviceFunc")
+	}
+
+	return m.DeletePushDeviceFunc(channelID, id)
+}
+
+// CreateWebhook implements conversation.Service.
+func (m *ConversationService) CreateWebhook(req *conversation.WebhookCreateRequest) (*conversation.Webhook, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "CreateWebhook", Args: []interface{}{req}})
+	m.mu.Unlock()
+
+	if m.CreateWebhookFunc == nil {
+		panic("mocks: ConversationService.CreateWebhook called without CreateWebhookFunc")
+	}
+
+	return m.CreateWebhookFunc(req)
+}
+
+// DeleteWebhook implements conversation.Service.
+func (m *ConversationService) DeleteWebhook(id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "DeleteWebhook", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.DeleteWebhookFunc == nil {
+		panic("mocks: ConversationService.DeleteWebhook called without DeleteWebhookFunc")
+	}
+
+	return m.DeleteWebhookFunc(id)
+}
+
+// ListWebhooks implements conversation.Service.
+func (m *ConversationService) ListWebhooks(options *messagebird.PaginationRequest) (*conversation.WebhookList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ListWebhooks", Args: []interface{}{options}})
+	m.mu.Unlock()
+
+	if m.ListWebhooksFunc == nil {
+		panic("mocks: ConversationService.ListWebhooks called without ListWebhooksFunc")
+	}
+
+	return m.ListWebhooksFunc(options)
+}
+
+// ReadWebhook implements conversation.Service.
+func (m *ConversationService) ReadWebhook(id string) (*conversation.Webhook, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ReadWebhook", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadWebhookFunc == nil {
+		panic("mocks: ConversationService.ReadWebhook called without ReadWebhookFunc")
+	}
+
+	return m.ReadWebhookFunc(id)
+}
+
+// UpdateWebhook implements conversation.Service.
+func (m *ConversationService) UpdateWebhook(id string, req *conversation.WebhookUpdateRequest) (*conversation.Webhook, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "UpdateWebhook", Args: []interface{}{id, req}})
+	m.mu.Unlock()
+
+	if m.UpdateWebhookFunc == nil {
+		panic("mocks: ConversationService.UpdateWebhook called without UpdateWebhookFunc")
+	}
+
+	return m.UpdateWebhookFunc(id, req)
+}
+
+// CanSendFreeform implements conversation.Service.
+func (m *ConversationService) CanSendFreeform(id string) (bool, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "CanSendFreeform", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.CanSendFreeformFunc == nil {
+		panic("mocks: ConversationService.CanSendFreeform called without CanSendFreeformFunc")
+	}
+
+	return m.CanSendFreeformFunc(id)
+}
+
+// CheckServiceWindow implements conversation.Service.
+func (m *ConversationService) CheckServiceWindow(id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "CheckServiceWindow", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.CheckServiceWindowFunc == nil {
+		panic("mocks: ConversationService.CheckServiceWindow called without CheckServiceWindowFunc")
+	}
+
+	return m.CheckServiceWindowFunc(id)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *ConversationService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// GroupService is a fake group.Service, see the package documentation.
+type GroupService struct {
+	CreateFunc          func(request *group.Request) (*group.Group, error)
+	DeleteFunc          func(id string) error
+	ListFunc            func(options *messagebird.PaginationRequest) (*group.Groups, error)
+	ReadFunc            func(id string) (*group.Group, error)
+	UpdateFunc          func(id string, request *group.Request) error
+	AddContactsFunc     func(groupID string, contactIDs []string) error
+	RemoveContactsFunc  func(groupID string, contactIDs []string) error
+	ListContactsFunc    func(groupID string, options *messagebird.PaginationRequest) (*contact.Contacts, error)
+	RemoveContactFunc   func(groupID string, contactID string) error
+	IterateContactsFunc func(groupID string, options *messagebird.PaginationRequest) *group.ContactIterator
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ group.Service = (*GroupService)(nil)
+
+// Create implements group.Service.
+func (m *GroupService) Create(request *group.Request) (*group.Group, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Create", Args: []interface{}{request}})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks: GroupService.Create called without CreateFunc")
+	}
+
+	return m.CreateFunc(request)
+}
+
+// Delete implements group.Service.
+func (m *GroupService) Delete(id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Delete", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks: GroupService.Delete called without DeleteFunc")
+	}
+
+	return m.DeleteFunc(id)
+}
+
+// List implements group.Service.
+func (m *GroupService) List(options *messagebird.PaginationRequest) (*group.Groups, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "List", Args: []interface{}{options}})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks: GroupService.List called without ListFunc")
+	}
+
+	return m.ListFunc(options)
+}
+
+// Read implements group.Service.
+func (m *GroupService) Read(id string) (*group.Group, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: GroupService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id)
+}
+
+// Update implements group.Service.
+func (m *GroupService) Update(id string, request *group.Request) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Update", Args: []interface{}{id, request}})
+	m.mu.Unlock()
+
+	if m.UpdateFunc == nil {
+		panic("mocks: GroupService.Update called without UpdateFunc")
+	}
+
+	return m.UpdateFunc(id, request)
+}
+
+// AddContacts implements group.Service.
+func (m *GroupService) AddContacts(groupID string, contactIDs []string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "AddContacts", Args: []interface{}{groupID, contactIDs}})
+	m.mu.Unlock()
+
+	if m.AddContactsFunc == nil {
+		panic("mocks: GroupService.AddContacts called without AddContactsFunc")
+	}
+
+	return m.AddContactsFunc(groupID, contactIDs)
+}
+
+// RemoveContacts implements group.Service.
+func (m *GroupService) RemoveContacts(groupID string, contactIDs []string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "RemoveContacts", Args: []interface{}{groupID, contactIDs}})
+	m.mu.Unlock()
+
+	if m.RemoveContactsFunc == nil {
+		panic("mocks: GroupService.RemoveContacts called without RemoveContactsFunc")
+	}
+
+	return m.RemoveContactsFunc(groupID, contactIDs)
+}
+
+// ListContacts implements group.Service.
+func (m *GroupService) ListContacts(groupID string, options *messagebird.PaginationRequest) (*contact.Contacts, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ListContacts", Args: []interface{}{groupID, options}})
+	m.mu.Unlock()
+
+	if m.ListContactsFunc == nil {
+		panic("mocks: GroupService.ListContacts called without ListContactsFunc")
+	}
+
+	return m.ListContactsFunc(groupID, options)
+}
+
+// RemoveContact implements group.Service.
+func (m *GroupService) RemoveContact(groupID string, contactID string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "RemoveContact", Args: []interface{}{groupID, contactID}})
+	m.mu.Unlock()
+
+	if m.RemoveContactFunc == nil {
+		panic("mocks: GroupService.RemoveContact called without RemoveContactFunc")
+	}
+
+	return m.RemoveContactFunc(groupID, contactID)
+}
+
+// IterateContacts implements group.Service.
+func (m *GroupService) IterateContacts(groupID string, options *messagebird.PaginationRequest) *group.ContactIterator {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "IterateContacts", Args: []interface{}{groupID, options}})
+	m.mu.Unlock()
+
+	if m.IterateContactsFunc == nil {
+		panic("mocks: GroupService.IterateContacts called without IterateContactsFunc")
+	}
+
+	return m.IterateContactsFunc(groupID, options)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *GroupService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// HLRService is a fake hlr.Service, see the package documentation.
+type HLRService struct {
+	ReadFunc   func(id string) (*hlr.HLR, error)
+	ListFunc   func() (*hlr.HLRList, error)
+	CreateFunc func(msisdn string, reference string) (*hlr.HLR, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ hlr.Service = (*HLRService)(nil)
+
+// Read implements hlr.Service.
+func (m *HLRService) Read(id string) (*hlr.HLR, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: HLRService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id)
+}
+
+// List implements hlr.Service.
+func (m *HLRService) List() (*hlr.HLRList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "List", Args: []interface{}{}})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks: HLRService.List called without ListFunc")
+	}
+
+	return m.ListFunc()
+}
+
+// Create implements hlr.Service.
+func (m *HLRService) Create(msisdn string, reference string) (*hlr.HLR, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Create", Args: []interface{}{msisdn, reference}})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks: HLRService.Create called without CreateFunc")
+	}
+
+	return m.CreateFunc(msisdn, reference)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *HLRService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// LookupService is a fake lookup.Service, see the package documentation.
+type LookupService struct {
+	ReadFunc      func(phoneNumber string, params *lookup.Params) (*lookup.Lookup, error)
+	CreateHLRFunc func(phoneNumber string, params *lookup.Params) (*hlr.HLR, error)
+	ReadHLRFunc   func(phoneNumber string, params *lookup.Params) (*hlr.HLR, error)
+	ReadRiskFunc  func(phoneNumber string, params *lookup.RiskParams) (*lookup.Risk, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ lookup.Service = (*LookupService)(nil)
+
+// Read implements lookup.Service.
+func (m *LookupService) Read(phoneNumber string, params *lookup.Params) (*lookup.Lookup, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{phoneNumber, params}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: LookupService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(phoneNumber, params)
+}
+
+// CreateHLR implements lookup.Service.
+func (m *LookupService) CreateHLR(phoneNumber string, params *lookup.Params) (*hlr.HLR, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "CreateHLR", Args: []interface{}{phoneNumber, params}})
+	m.mu.Unlock()
+
+	if m.CreateHLRFunc == nil {
+		panic("mocks: LookupService.CreateHLR called without CreateHLRFunc")
+	}
+
+	return m.CreateHLRFunc(phoneNumber, params)
+}
+
+// ReadHLR implements lookup.Service.
+func (m *LookupService) ReadHLR(phoneNumber string, params *lookup.Params) (*hlr.HLR, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ReadHLR", Args: []interface{}{phoneNumber, params}})
+	m.mu.Unlock()
+
+	if m.ReadHLRFunc == nil {
+		panic("mocks: LookupService.ReadHLR called without ReadHLRFunc")
+	}
+
+	return m.ReadHLRFunc(phoneNumber, params)
+}
+
+// ReadRisk implements lookup.Service.
+func (m *LookupService) ReadRisk(phoneNumber string, params *lookup.RiskParams) (*lookup.Risk, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ReadRisk", Args: []interface{}{phoneNumber, params}})
+	m.mu.Unlock()
+
+	if m.ReadRiskFunc == nil {
+		panic("mocks: LookupService.ReadRisk called without ReadRiskFunc")
+	}
+
+	return m.ReadRiskFunc(phoneNumber, params)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *LookupService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// MMSService is a fake mms.Service, see the package documentation.
+type MMSService struct {
+	IterateFunc     func(params *mms.ListRequest) *mms.Iterator
+	UploadMediaFunc func(name string, r io.Reader) (*mms.Media, error)
+	ReadFunc        func(id string) (*mms.Message, error)
+	ListFunc        func(params *mms.ListRequest) (*mms.MessageList, error)
+	DeleteFunc      func(id string) error
+	CreateFunc      func(req *mms.CreateRequest) (*mms.Message, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ mms.Service = (*MMSService)(nil)
+
+// Iterate implements mms.Service.
+func (m *MMSService) Iterate(params *mms.ListRequest) *mms.Iterator {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Iterate", Args: []interface{}{params}})
+	m.mu.Unlock()
+
+	if m.IterateFunc == nil {
+		panic("mocks: MMSService.Iterate called without IterateFunc")
+	}
+
+	return m.IterateFunc(params)
+}
+
+// UploadMedia implements mms.Service.
+func (m *MMSService) UploadMedia(name string, r io.Reader) (*mms.Media, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "UploadMedia", Args: []interface{}{name, r}})
+	m.mu.Unlock()
+
+	if m.UploadMediaFunc == nil {
+		panic("mocks: MMSService.UploadMedia called without UploadMediaFunc")
+	}
+
+	return m.UploadMediaFunc(name, r)
+}
+
+// Read implements mms.Service.
+func (m *MMSService) Read(id string) (*mms.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: MMSService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id)
+}
+
+// List implements mms.Service.
+func (m *MMSService) List(params *mms.ListRequest) (*mms.MessageList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "List", Args: []interface{}{params}})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks: MMSService.List called without ListFunc")
+	}
+
+	return m.ListFunc(params)
+}
+
+// Delete implements mms.Service.
+func (m *MMSService) Delete(id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Delete", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks: MMSService.Delete called without DeleteFunc")
+	}
+
+	return m.DeleteFunc(id)
+}
+
+// Create implements mms.Service.
+func (m *MMSService) Create(req *mms.CreateRequest) (*mms.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Create", Args: []interface{}{req}})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks: MMSService.Create called without CreateFunc")
+	}
+
+	return m.CreateFunc(req)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *MMSService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// SMSService is a fake sms.Service, see the package documentation.
+type SMSService struct {
+	ReadFunc   func(id string) (*sms.Message, error)
+	DeleteFunc func(id string) error
+	ListFunc   func(params *sms.ListParams) (*sms.MessageList, error)
+	CreateFunc func(originator string, recipients []string, body string, msgParams *sms.Params) (*sms.Message, error)
+	SendFunc   func(req *sms.SendRequest) (*sms.Message, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ sms.Service = (*SMSService)(nil)
+
+// Read implements sms.Service.
+func (m *SMSService) Read(id string) (*sms.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: SMSService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id)
+}
+
+// Delete implements sms.Service.
+func (m *SMSService) Delete(id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Delete", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks: SMSService.Delete called without DeleteFunc")
+	}
+
+	return m.DeleteFunc(id)
+}
+
+// List implements sms.Service.
+func (m *SMSService) List(params *sms.ListParams) (*sms.MessageList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "List", Args: []interface{}{params}})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks: SMSService.List called without ListFunc")
+	}
+
+	return m.ListFunc(params)
+}
+
+// Create implements sms.Service.
+func (m *SMSService) Create(originator string, recipients []string, body string, msgParams *sms.Params) (*sms.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Create", Args: []interface{}{originator, recipients, body, msgParams}})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks: SMSService.Create called without CreateFunc")
+	}
+
+	return m.CreateFunc(originator, recipients, body, msgParams)
+}
+
+// Send implements sms.Service.
+func (m *SMSService) Send(req *sms.SendRequest) (*sms.Message, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Send", Args: []interface{}{req}})
+	m.mu.Unlock()
+
+	if m.SendFunc == nil {
+		panic("mocks: SMSService.Send called without SendFunc")
+	}
+
+	return m.SendFunc(req)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *SMSService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// VerifyService is a fake verify.Service, see the package documentation.
+type VerifyService struct {
+	CreateFunc                 func(recipient string, params *verify.Params) (*verify.Verify, error)
+	SendFunc                   func(req *verify.SendRequest) (*verify.Verify, error)
+	DeleteFunc                 func(id string) error
+	ReadFunc                   func(id string) (*verify.Verify, error)
+	VerifyTokenFunc            func(id string, token string) (*verify.Verify, error)
+	ReadVerifyEmailMessageFunc func(id string) (*verify.VerifyMessage, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ verify.Service = (*VerifyService)(nil)
+
+// Create implements verify.Service.
+func (m *VerifyService) Create(recipient string, params *verify.Params) (*verify.Verify, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Create", Args: []interface{}{recipient, params}})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks: VerifyService.Create called without CreateFunc")
+	}
+
+	return m.CreateFunc(recipient, params)
+}
+
+// Send implements verify.Service.
+func (m *VerifyService) Send(req *verify.SendRequest) (*verify.Verify, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Send", Args: []interface{}{req}})
+	m.mu.Unlock()
+
+	if m.SendFunc == nil {
+		panic("mocks: VerifyService.Send called without SendFunc")
+	}
+
+	return m.SendFunc(req)
+}
+
+// Delete implements verify.Service.
+func (m *VerifyService) Delete(id string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Delete", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks: VerifyService.Delete called without DeleteFunc")
+	}
+
+	return m.DeleteFunc(id)
+}
+
+// Read implements verify.Service.
+func (m *VerifyService) Read(id string) (*verify.Verify, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: VerifyService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id)
+}
+
+// VerifyToken implements verify.Service.
+func (m *VerifyService) VerifyToken(id string, token string) (*verify.Verify, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "VerifyToken", Args: []interface{}{id, token}})
+	m.mu.Unlock()
+
+	if m.VerifyTokenFunc == nil {
+		panic("mocks: VerifyService.VerifyToken called without VerifyTokenFunc")
+	}
+
+	return m.VerifyTokenFunc(id, token)
+}
+
+// ReadVerifyEmailMessage implements verify.Service.
+func (m *VerifyService) ReadVerifyEmailMessage(id string) (*verify.VerifyMessage, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "ReadVerifyEmailMessage", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadVerifyEmailMessageFunc == nil {
+		panic("mocks: VerifyService.ReadVerifyEmailMessage called without ReadVerifyEmailMessageFunc")
+	}
+
+	return m.ReadVerifyEmailMessageFunc(id)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *VerifyService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// VoiceMessageService is a fake voicemessage.Service, see the package documentation.
+type VoiceMessageService struct {
+	ReadFunc   func(id string) (*voicemessage.VoiceMessage, error)
+	ListFunc   func() (*voicemessage.VoiceMessageList, error)
+	CreateFunc func(recipients []string, body string, params *voicemessage.Params) (*voicemessage.VoiceMessage, error)
+	SendFunc   func(req *voicemessage.SendRequest) (*voicemessage.VoiceMessage, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ voicemessage.Service = (*VoiceMessageService)(nil)
+
+// Read implements voicemessage.Service.
+func (m *VoiceMessageService) Read(id string) (*voicemessage.VoiceMessage, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Read", Args: []interface{}{id}})
+	m.mu.Unlock()
+
+	if m.ReadFunc == nil {
+		panic("mocks: VoiceMessageService.Read called without ReadFunc")
+	}
+
+	return m.ReadFunc(id)
+}
+
+// List implements voicemessage.Service.
+func (m *VoiceMessageService) List() (*voicemessage.VoiceMessageList, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "List", Args: []interface{}{}})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks: VoiceMessageService.List called without ListFunc")
+	}
+
+	return m.ListFunc()
+}
+
+// Create implements voicemessage.Service.
+func (m *VoiceMessageService) Create(recipients []string, body string, params *voicemessage.Params) (*voicemessage.VoiceMessage, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Create", Args: []interface{}{recipients, body, params}})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks: VoiceMessageService.Create called without CreateFunc")
+	}
+
+	return m.CreateFunc(recipients, body, params)
+}
+
+// Send implements voicemessage.Service.
+func (m *VoiceMessageService) Send(req *voicemessage.SendRequest) (*voicemessage.VoiceMessage, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: "Send", Args: []interface{}{req}})
+	m.mu.Unlock()
+
+	if m.SendFunc == nil {
+		panic("mocks: VoiceMessageService.Send called without SendFunc")
+	}
+
+	return m.SendFunc(req)
+}
+
+// Calls returns the calls of the methods of m, in the order they were made.
+func (m *VoiceMessageService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
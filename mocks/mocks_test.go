@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMSService(t *testing.T) {
+	fake := &SMSService{
+		SendFunc: func(req *sms.SendRequest) (*sms.Message, error) {
+			return &sms.Message{ID: "message-id", Body: req.Body}, nil
+		},
+	}
+
+	var s sms.Service = fake
+	message, err := s.Send(&sms.SendRequest{Originator: "MessageBird", Recipients: []string{"31612345678"}, Body: "Hi"})
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", message.ID)
+
+	calls := fake.Calls()
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, "Send", calls[0].Method)
+		assert.Equal(t, "Hi", calls[0].Args[0].(*sms.SendRequest).Body)
+	}
+
+	assert.PanicsWithValue(t, "mocks: SMSService.Read called without ReadFunc", func() {
+		s.Read("message-id")
+	})
+}
+
+func TestClient(t *testing.T) {
+	fake := &Client{
+		RequestFunc: func(v interface{}, method, path string, data interface{}) error {
+			if path != "balance" {
+				return errors.New("unexpected path")
+			}
+			v.(*balance.Balance).Amount = 9.2
+			return nil
+		},
+	}
+
+	b, err := balance.Read(fake)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 9.2, b.Amount)
+
+	if calls := fake.Calls(); assert.Len(t, calls, 1) {
+		assert.Equal(t, []interface{}{b, http.MethodGet, "balance", nil}, calls[0].Args)
+	}
+}
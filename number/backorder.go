@@ -1,11 +1,39 @@
 package number
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
-	messagebird "github.com/messagebird/go-rest-api/v9"
+	"io"
 	"net/http"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+// MaxBackorderDocumentSize is the maximum size in bytes of a document uploaded
+// with UploadBackorderDocument.
+const MaxBackorderDocumentSize = 5 * 1024 * 1024
+
+// Reason codes explain why a backorder is blocked.
+const (
+	// ReasonMissingKYC indicates documents are missing, see
+	// ListBackorderDocuments.
+	ReasonMissingKYC = "MISSING_KYC"
+
+	// ReasonMissingEUD indicates end-user details are missing, see
+	// ListBackorderEndUserDetails.
+	ReasonMissingEUD = "MISSING_EUD"
 )
 
+// BackorderStatusBlocked is the status of a backorder that can not be
+// fulfilled until the issues in its ReasonCodes are resolved.
+const BackorderStatusBlocked = "blocked"
+
+// BackorderDocumentStatusMissing is the status of a document that has yet to
+// be uploaded.
+const BackorderDocumentStatusMissing = "missing"
+
 type BackOrderID string
 
 type Backorder struct {
@@ -17,6 +45,58 @@ type Backorder struct {
 	ReasonCodes []string
 }
 
+// IsBlocked reports whether the backorder requires action before it can be
+// fulfilled.
+func (b *Backorder) IsBlocked() bool {
+	return b.Status == BackorderStatusBlocked
+}
+
+// HasReason reports whether code is one of the reasons the backorder is
+// blocked.
+func (b *Backorder) HasReason(code string) bool {
+	for _, c := range b.ReasonCodes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Backorders struct {
+	Limit, Offset, Count, TotalCount int
+	Items                            []*Backorder
+}
+
+// ListBackordersRequest can be used to set query params in ListBackorders().
+type ListBackordersRequest struct {
+	Limit  int
+	Offset int
+	Status string
+}
+
+func (req *ListBackordersRequest) QueryParams() string {
+	if req == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if req.Limit > 0 {
+		query.SetInt("limit", req.Limit)
+	}
+
+	if req.Offset > 0 {
+		query.SetInt("offset", req.Offset)
+	}
+
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+
+	return query.Encode()
+}
+
 type BackorderDocument struct {
 	ID          int
 	Name        string
@@ -29,6 +109,18 @@ type BackorderDocuments struct {
 	Items        []*BackorderDocument
 }
 
+// Missing returns the documents that have yet to be uploaded.
+func (d *BackorderDocuments) Missing() []*BackorderDocument {
+	var missing []*BackorderDocument
+	for _, doc := range d.Items {
+		if doc.Status == BackorderDocumentStatusMissing {
+			missing = append(missing, doc)
+		}
+	}
+
+	return missing
+}
+
 type EndUserDetail struct {
 	ID    string
 	Label string
@@ -83,6 +175,18 @@ func ReadBackorder(c messagebird.Client, backOrderID string) (*Backorder, error)
 	return bo, nil
 }
 
+// ListBackorders lists the backorders placed by the account.
+func ListBackorders(c messagebird.Client, params *ListBackordersRequest) (*Backorders, error) {
+	uri := fmt.Sprintf("%s?%s", pathBackorders, params.QueryParams())
+
+	bo := &Backorders{}
+	if err := request(c, bo, http.MethodGet, uri, nil); err != nil {
+		return nil, err
+	}
+
+	return bo, nil
+}
+
 func ListBackorderDocuments(c messagebird.Client, backOrderID string) (*BackorderDocuments, error) {
 	uri := fmt.Sprintf("%s/%s/%s", pathBackorders, backOrderID, pathDocuments)
 
@@ -100,6 +204,31 @@ func CreateBackorderDocument(c messagebird.Client, backOrderID string, req *Crea
 	return request(c, nil, http.MethodPost, uri, req)
 }
 
+// UploadBackorderDocument reads a document from r and uploads it for the
+// document with the given documentID, as listed by ListBackorderDocuments.
+// The MIME type is detected from the content. Documents can not be larger than
+// MaxBackorderDocumentSize.
+func UploadBackorderDocument(c messagebird.Client, backOrderID string, documentID int, name string, r io.Reader) error {
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, MaxBackorderDocumentSize+1))
+	if err != nil {
+		return err
+	}
+	if n > MaxBackorderDocumentSize {
+		return fmt.Errorf("document exceeds maximum size of %d bytes", MaxBackorderDocumentSize)
+	}
+	if n == 0 {
+		return fmt.Errorf("document is empty")
+	}
+
+	return CreateBackorderDocument(c, backOrderID, &CreateBackorderDocumentRequest{
+		ID:       documentID,
+		Name:     name,
+		MimeType: http.DetectContentType(buf.Bytes()),
+		Content:  base64.StdEncoding.EncodeToString(buf.Bytes()),
+	})
+}
+
 func ListBackorderEndUserDetails(c messagebird.Client, backOrderID string) (*EndUserDetails, error) {
 	uri := fmt.Sprintf("%s/%s/%s", pathBackorders, backOrderID, pathEndUserDetails)
 
@@ -1,10 +1,13 @@
 package number
 
 import (
-	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
-	"github.com/stretchr/testify/assert"
+	"bytes"
 	"net/http"
+	"strings"
 	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestPlaceBackorder(t *testing.T) {
@@ -118,3 +121,51 @@ func TestCreateBackorderEndUserDetail(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/backorders/vn4oor3c21de42d4bbf73fb86caaf361/end-user-details")
 }
+
+func TestListBackorders(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "listBackorders.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := ListBackorders(client, &ListBackordersRequest{Limit: 20, Status: BackorderStatusBlocked})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, list.TotalCount)
+	assert.True(t, list.Items[0].IsBlocked())
+	assert.True(t, list.Items[0].HasReason(ReasonMissingKYC))
+	assert.False(t, list.Items[0].HasReason(ReasonMissingEUD))
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/backorders")
+	assert.Equal(t, "limit=20&status=blocked", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestMissingBackorderDocuments(t *testing.T) {
+	docs := &BackorderDocuments{Items: []*BackorderDocument{
+		{ID: 1, Status: BackorderDocumentStatusMissing},
+		{ID: 2, Status: "uploaded"},
+	}}
+
+	missing := docs.Missing()
+	assert.Len(t, missing, 1)
+	assert.Equal(t, 1, missing[0].ID)
+}
+
+func TestUploadBackorderDocument(t *testing.T) {
+	mbtest.WillReturnOnlyStatus(http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := UploadBackorderDocument(client, "vn4oor3c21de42d4bbf73fb86caaf361", 62, "proof.txt", strings.NewReader("proof of address"))
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/backorders/vn4oor3c21de42d4bbf73fb86caaf361/documents")
+	assert.JSONEq(t, `{"id":62,"name":"proof.txt","mimeType":"text/plain; charset=utf-8","content":"cHJvb2Ygb2YgYWRkcmVzcw=="}`, string(mbtest.LastRequest().Body))
+}
+
+func TestUploadBackorderDocumentTooLarge(t *testing.T) {
+	client := mbtest.Client(t)
+
+	r := bytes.NewReader(make([]byte, MaxBackorderDocumentSize+1))
+	err := UploadBackorderDocument(client, "vn4oor3c21de42d4bbf73fb86caaf361", 62, "proof.pdf", r)
+	assert.Error(t, err)
+
+	err = UploadBackorderDocument(client, "vn4oor3c21de42d4bbf73fb86caaf361", 62, "proof.pdf", strings.NewReader(""))
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package number
+
+import (
+	"errors"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+var (
+	// ErrNotAvailable is returned by Purchase when the number can not be
+	// purchased, e.g. because somebody else bought it first.
+	ErrNotAvailable = errors.New("number is not available")
+
+	// ErrActiveSubscriptions is returned by Cancel when the number is still
+	// in use by active subscriptions and can not be cancelled yet.
+	ErrActiveSubscriptions = errors.New("number has active subscriptions")
+)
+
+// Error is returned for API errors that have a meaning specific to the Numbers
+// API. Use errors.Is to check for ErrNotAvailable or ErrActiveSubscriptions.
+// The original messagebird.ErrorResponse can be retrieved with errors.As.
+type Error struct {
+	kind error
+
+	// response is the error the API responded with: a
+	// messagebird.ErrorResponse, or a *messagebird.RequestError wrapping one
+	// if the client wraps errors.
+	response error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.kind.Error() + ": " + e.response.Error()
+}
+
+// Is reports whether target is the kind of this error.
+func (e *Error) Is(target error) bool {
+	return e.kind == target
+}
+
+// Unwrap returns the error the API responded with.
+func (e *Error) Unwrap() error {
+	return e.response
+}
+
+// classifyError converts err into an *Error of the given kind when the API
+// response describes that kind of failure. The Numbers API does not use
+// dedicated error codes for these cases, so the descriptions are inspected.
+// Any other error is returned as-is.
+func classifyError(err error, kind error, phrases ...string) error {
+	var response messagebird.ErrorResponse
+	if !errors.As(err, &response) {
+		return err
+	}
+
+	for _, e := range response.Errors {
+		description := strings.ToLower(e.Description)
+		for _, phrase := range phrases {
+			if strings.Contains(description, phrase) {
+				return &Error{kind: kind, response: err}
+			}
+		}
+	}
+
+	return err
+}
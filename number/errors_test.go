@@ -0,0 +1,86 @@
+package number
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurchaseNotAvailable(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"errors":[{"code":21,"description":"Number is not available for purchase","parameter":"number"}]}`), http.StatusUnprocessableEntity)
+	client := mbtest.Client(t)
+
+	_, err := Purchase(client, &PurchaseRequest{Number: "31971234567", Country: "NL", BillingIntervalMonths: 1})
+	assert.True(t, errors.Is(err, ErrNotAvailable))
+	assert.False(t, errors.Is(err, ErrActiveSubscriptions))
+
+	var response messagebird.ErrorResponse
+	assert.True(t, errors.As(err, &response))
+	assert.Equal(t, "number", response.Errors[0].Parameter)
+}
+
+func TestPurchaseValidation(t *testing.T) {
+	client := mbtest.Client(t)
+
+	tt := []*PurchaseRequest{
+		nil,
+		{Country: "NL"},
+		{Number: "31971234567"},
+		{Number: "31971234567", Country: "NL", BillingIntervalMonths: 2},
+	}
+
+	for _, req := range tt {
+		_, err := Purchase(client, req)
+		assert.Error(t, err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	assert.NoError(t, Cancel(client, "31612345670"))
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/phone-numbers/31612345670")
+}
+
+func TestCancelActiveSubscriptions(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"errors":[{"code":21,"description":"Number has active subscriptions","parameter":"number"}]}`), http.StatusConflict)
+	client := mbtest.Client(t)
+
+	err := Cancel(client, "31612345670")
+	assert.True(t, errors.Is(err, ErrActiveSubscriptions))
+	assert.Contains(t, err.Error(), "Number has active subscriptions")
+}
+
+func TestCancelOtherError(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"errors":[{"code":20,"description":"Number not found","parameter":"number"}]}`), http.StatusNotFound)
+	client := mbtest.Client(t)
+
+	err := Cancel(client, "31612345670")
+	assert.False(t, errors.Is(err, ErrActiveSubscriptions))
+	_, ok := err.(messagebird.ErrorResponse)
+	assert.True(t, ok)
+}
+
+func TestErrorsWrapErrors(t *testing.T) {
+	client := mbtest.Client(t)
+	client.WrapErrors = true
+
+	mbtest.WillReturn([]byte(`{"errors":[{"code":21,"description":"Number is not available for purchase","parameter":"number"}]}`), http.StatusUnprocessableEntity)
+	_, err := Purchase(client, &PurchaseRequest{Number: "31971234567", Country: "NL", BillingIntervalMonths: 1})
+	assert.True(t, errors.Is(err, ErrNotAvailable))
+
+	var requestErr *messagebird.RequestError
+	assert.True(t, errors.As(err, &requestErr))
+
+	mbtest.WillReturn([]byte(`{"errors":[{"code":21,"description":"Number has active subscriptions","parameter":"number"}]}`), http.StatusConflict)
+	err = Cancel(client, "31612345670")
+	assert.True(t, errors.Is(err, ErrActiveSubscriptions))
+
+	var response messagebird.ErrorResponse
+	assert.True(t, errors.As(err, &response))
+}
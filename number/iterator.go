@@ -0,0 +1,85 @@
+package number
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+)
+
+// defaultIteratorLimit is the page size used by an Iterator when the
+// ListRequest does not set a limit.
+const defaultIteratorLimit = 20
+
+// An Iterator walks through all purchased phone numbers matching a
+// ListRequest, fetching pages from the API as needed.
+//
+//	it := number.Iterate(client, &number.ListRequest{Features: number.FeatureNames(number.FeatureSMS)})
+//	for it.Next() {
+//		fmt.Println(it.Number().Number)
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle the error.
+//	}
+//
+// Iterators are single use and can therefore not be reset.
+type Iterator struct {
+	client messagebird.Client
+	params ListRequest
+	page   []*Number
+	number *Number
+	pager  paging.Pager
+}
+
+// Iterate returns an Iterator over the purchased numbers matching params,
+// starting at params.Offset. params may be nil to iterate over all numbers.
+func Iterate(c messagebird.Client, params *ListRequest) *Iterator {
+	it := &Iterator{client: c}
+	if params != nil {
+		it.params = *params
+	}
+	if it.params.Limit == 0 {
+		it.params.Limit = defaultIteratorLimit
+	}
+	it.pager.Offset = it.params.Offset
+
+	return it
+}
+
+// Next advances the iterator to the next number, which is then available
+// through Number. It returns false when no more numbers are available or an
+// error occurred.
+func (it *Iterator) Next() bool {
+	if len(it.page) == 0 {
+		it.fetch()
+	}
+
+	if len(it.page) == 0 {
+		it.number = nil
+		return false
+	}
+
+	it.number, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Number returns the number the iterator currently points at.
+func (it *Iterator) Number() *Number {
+	return it.number
+}
+
+// Err returns the first error that occurred while fetching numbers, if any.
+func (it *Iterator) Err() error {
+	return it.pager.Err()
+}
+
+func (it *Iterator) fetch() {
+	it.pager.Fetch(func(offset int) (int, int, error) {
+		it.params.Offset = offset
+		numbers, err := List(it.client, &it.params)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		it.page = numbers.Items
+		return len(numbers.Items), numbers.TotalCount, nil
+	})
+}
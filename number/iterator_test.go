@@ -0,0 +1,87 @@
+package number
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterate(t *testing.T) {
+	var queries []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		items := ""
+		for i := offset; i < offset+2 && i < 3; i++ {
+			if items != "" {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"number":"3161234567%d"}`, i)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"offset":%d,"limit":2,"totalCount":3,"items":[%s]}`, offset, items)
+	}))
+	defer stop()
+
+	client := mbtest.Client(t)
+	client.HTTPClient.Transport = transport
+
+	it := Iterate(client, &ListRequest{Limit: 2, Features: FeatureNames(FeatureSMS)})
+
+	var numbers []string
+	for it.Next() {
+		numbers = append(numbers, it.Number().Number)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"31612345670", "31612345671", "31612345672"}, numbers)
+	assert.Equal(t, []string{"features=sms&limit=2", "features=sms&limit=2&offset=2"}, queries)
+}
+
+func TestIterateOffset(t *testing.T) {
+	var queries []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"offset":2,"limit":20,"totalCount":3,"items":[{"number":"31612345672"}]}`)
+	}))
+	defer stop()
+
+	client := mbtest.Client(t)
+	client.HTTPClient.Transport = transport
+
+	it := Iterate(client, &ListRequest{Offset: 2})
+
+	assert.True(t, it.Next())
+	assert.Equal(t, "31612345672", it.Number().Number)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"limit=20&offset=2"}, queries)
+}
+
+func TestIterateError(t *testing.T) {
+	mbtest.WillReturnAccessKeyError()
+	client := mbtest.Client(t)
+
+	it := Iterate(client, nil)
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+	assert.Nil(t, it.Number())
+}
+
+func TestListSearchPattern(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberList.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := List(client, &ListRequest{Number: "3161", SearchPattern: SearchPatternAnyWhere, Region: "Texel"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "number=3161&region=Texel&search_pattern=anywhere", mbtest.LastRequest().URL.RawQuery)
+}
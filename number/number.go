@@ -1,13 +1,13 @@
 package number
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
 )
 
 const (
@@ -32,6 +32,14 @@ const (
 
 	// pathNumbersAvailable is the path for the Search Number resource, relative to apiRoot.
 	pathNumbersAvailable = "available-phone-numbers"
+
+	// voiceAPIRoot is the absolute URL of the Voice API, which is used to link
+	// numbers to call flows.
+	voiceAPIRoot = "https://voice.messagebird.com/v1"
+
+	// pathCallFlows is the path for the Call Flow resource, relative to
+	// voiceAPIRoot.
+	pathCallFlows = "call-flows"
 )
 
 type SearchPattern string
@@ -64,6 +72,17 @@ const (
 	FeatureMMS   Feature = "mms"
 )
 
+// FeatureNames converts features to the strings used by the Features fields of
+// ListRequest and SearchRequest.
+func FeatureNames(features ...Feature) []string {
+	names := make([]string, 0, len(features))
+	for _, f := range features {
+		names = append(names, string(f))
+	}
+
+	return names
+}
+
 // Number represents a specific phone number.
 type Number struct {
 	Number                  string
@@ -84,6 +103,17 @@ type Number struct {
 	RenewalAt               *time.Time
 }
 
+// HasFeature reports whether the number supports feature.
+func (n *Number) HasFeature(feature Feature) bool {
+	for _, f := range n.Features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Numbers provide a list of all purchased phone numbers.
 type Numbers struct {
 	Offset     int
@@ -105,12 +135,16 @@ type NumbersSearching struct {
 type ListRequest struct {
 	Limit    int
 	Offset   int
-	Features []string // Possible values: sms, voice, mms.
+	Features []string // Possible values: sms, voice, mms. See FeatureNames.
 	Tags     []string
 	Number   string
 	Region   string
 	Locality string
 	Type     string // Possible values: landline, mobile, premium_rate and toll_free.
+
+	// SearchPattern determines how Number is matched. The API matches
+	// numbers starting with Number if it is not set.
+	SearchPattern SearchPattern
 }
 
 func (lr *ListRequest) QueryParams() string {
@@ -118,22 +152,22 @@ func (lr *ListRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
 	if len(lr.Features) > 0 {
-		paramsForArrays("features", lr.Features, &query)
+		paramsForArrays("features", lr.Features, query)
 	}
 
 	if len(lr.Tags) > 0 {
-		paramsForArrays("tags", lr.Tags, &query)
+		paramsForArrays("tags", lr.Tags, query)
 	}
 
 	if lr.Limit != 0 {
-		query.Set("limit", strconv.Itoa(lr.Limit))
+		query.SetInt("limit", lr.Limit)
 	}
 
 	if lr.Offset != 0 {
-		query.Set("offset", strconv.Itoa(lr.Offset))
+		query.SetInt("offset", lr.Offset)
 	}
 
 	if lr.Type != "" {
@@ -152,6 +186,10 @@ func (lr *ListRequest) QueryParams() string {
 		query.Set("region", lr.Region)
 	}
 
+	if lr.SearchPattern != "" {
+		query.Set("search_pattern", string(lr.SearchPattern))
+	}
+
 	return query.Encode()
 }
 
@@ -177,21 +215,21 @@ func (sr *SearchRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
 	if len(sr.Features) > 0 {
-		paramsForArrays("features", sr.Features, &query)
+		paramsForArrays("features", sr.Features, query)
 	}
 
 	if len(sr.Tags) > 0 {
-		paramsForArrays("tags", sr.Tags, &query)
+		paramsForArrays("tags", sr.Tags, query)
 	}
 
 	if sr.Limit > 0 {
-		query.Set("limit", strconv.Itoa(sr.Limit))
+		query.SetInt("limit", sr.Limit)
 	}
 	if sr.Offset > 0 {
-		query.Set("offset", strconv.Itoa(sr.Offset))
+		query.SetInt("offset", sr.Offset)
 	}
 
 	if len(sr.Type) > 0 {
@@ -213,8 +251,8 @@ func (sr *SearchRequest) QueryParams() string {
 	if len(sr.Status) > 0 {
 		query.Set("status", sr.Status)
 	}
-	query.Set("exclude_numbers_require_verification", strconv.FormatBool(sr.ExcludeNumbersRequireVerification))
-	query.Set("prices", strconv.FormatBool(sr.Prices))
+	query.SetBool("exclude_numbers_require_verification", sr.ExcludeNumbersRequireVerification)
+	query.SetBool("prices", sr.Prices)
 
 	if sr.SearchPattern != "" {
 		query.Set("search_pattern", string(sr.SearchPattern))
@@ -223,9 +261,15 @@ func (sr *SearchRequest) QueryParams() string {
 	return query.Encode()
 }
 
-// UpdateRequest can be used to set tags update.
+// UpdateRequest can be used to reconfigure a purchased number.
 type UpdateRequest struct {
+	// Tags replace the tags of the number. Leave nil to keep the current
+	// tags.
 	Tags []string `json:"tags"`
+
+	// CallFlowID links the number to a voice call flow, so incoming calls
+	// are handled by it. See package voice for managing call flows.
+	CallFlowID string `json:"-"`
 }
 
 // PurchaseRequest can be used to purchase a number.
@@ -248,7 +292,13 @@ func List(c messagebird.Client, params *ListRequest) (*Numbers, error) {
 }
 
 // Search for phone numbers available for purchase, countryCode needs to be in Alpha-2 country code (example: NL)
+// Set SearchRequest.Prices to include the monthly price of every number in the
+// results.
 func Search(c messagebird.Client, countryCode string, params *SearchRequest) (*NumbersSearching, error) {
+	if len(countryCode) != 2 {
+		return nil, fmt.Errorf("countryCode must be an ISO 3166-1 alpha-2 code, got %q", countryCode)
+	}
+
 	uri := fmt.Sprintf("%s/%s?%s", pathNumbersAvailable, countryCode, params.QueryParams())
 
 	numberList := &NumbersSearching{}
@@ -281,9 +331,40 @@ func Delete(c messagebird.Client, phoneNumber string) error {
 	return request(c, nil, http.MethodDelete, uri, nil)
 }
 
-// Update updates a purchased phone number.
-// Only updating *tags* is supported at the moment.
+// Cancel cancels the subscription of a purchased phone number, so it is no
+// longer billed. Like Delete, but returns an error matching
+// ErrActiveSubscriptions when the number is still in use.
+func Cancel(c messagebird.Client, phoneNumber string) error {
+	if err := Delete(c, phoneNumber); err != nil {
+		return classifyError(err, ErrActiveSubscriptions, "active subscription")
+	}
+
+	return nil
+}
+
+// Update updates a purchased phone number. Tags are updated through the
+// Numbers API, while a call flow is linked through the Voice API. Inbound SMS
+// webhook destinations are managed with Flow Builder and can not be set here.
 func Update(c messagebird.Client, phoneNumber string, req *UpdateRequest) (*Number, error) {
+	if req == nil {
+		return nil, errors.New("update request should not be nil")
+	}
+
+	if req.CallFlowID != "" {
+		uri := fmt.Sprintf("%s/%s/%s/%s", voiceAPIRoot, pathCallFlows, req.CallFlowID, pathNumbers)
+		data := &struct {
+			Numbers []string `json:"numbers"`
+		}{[]string{phoneNumber}}
+
+		if err := c.Request(nil, http.MethodPost, uri, data); err != nil {
+			return nil, err
+		}
+
+		if req.Tags == nil {
+			return Read(c, phoneNumber)
+		}
+	}
+
 	uri := fmt.Sprintf("%s/%s", pathPhoneNumbers, phoneNumber)
 
 	number := &Number{}
@@ -294,20 +375,44 @@ func Update(c messagebird.Client, phoneNumber string, req *UpdateRequest) (*Numb
 	return number, nil
 }
 
-// Purchase purchases a phone number.
+// Purchase purchases a phone number. If the number can no longer be
+// purchased, the returned error matches ErrNotAvailable.
 func Purchase(c messagebird.Client, numberPurchaseRequest *PurchaseRequest) (*Number, error) {
+	if err := validatePurchase(numberPurchaseRequest); err != nil {
+		return nil, err
+	}
+
 	number := &Number{}
 	if err := request(c, number, http.MethodPost, pathPhoneNumbers, numberPurchaseRequest); err != nil {
-		return nil, err
+		return nil, classifyError(err, ErrNotAvailable, "not available", "unavailable")
 	}
 
 	return number, nil
 }
 
+func validatePurchase(req *PurchaseRequest) error {
+	if req == nil {
+		return errors.New("purchase request should not be nil")
+	}
+	if req.Number == "" {
+		return errors.New("number is required")
+	}
+	if req.Country == "" {
+		return errors.New("countryCode is required")
+	}
+
+	switch req.BillingIntervalMonths {
+	case 0, 1, 3, 6, 12:
+		return nil
+	default:
+		return fmt.Errorf("billingIntervalMonths must be 1, 3, 6 or 12, got %d", req.BillingIntervalMonths)
+	}
+}
+
 // paramsForArrays build query for array params
-func paramsForArrays(field string, values []string, urlParams *url.Values) {
+func paramsForArrays(field string, values []string, query *querystring.Builder) {
 	for _, value := range values {
-		urlParams.Add(field, value)
+		query.Add(field, value)
 	}
 }
 
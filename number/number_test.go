@@ -1,6 +1,7 @@
 package number
 
 import (
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -28,10 +29,35 @@ func TestSearch(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/available-phone-numbers/NL")
 
-	query := mbtest.Request.URL.RawQuery
+	query := mbtest.LastRequest().URL.RawQuery
 	assert.Equal(t, "exclude_numbers_require_verification=false&features=sms&features=voice&limit=10&prices=false&search_pattern=end&type=mobile", query)
 }
 
+func TestSearchWithPrices(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberSearch.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	numLis, err := Search(client, "NL", &SearchRequest{Number: "3197", SearchPattern: SearchPatternStart, Prices: true})
+	assert.NoError(t, err)
+
+	query := mbtest.LastRequest().URL.RawQuery
+	assert.Equal(t, "exclude_numbers_require_verification=false&number=3197&prices=true&search_pattern=start", query)
+
+	number := numLis.Items[0]
+	assert.Equal(t, float64(1), number.MonthlyPrice)
+	assert.Equal(t, "EUR", number.Currency)
+	assert.True(t, number.HasFeature(FeatureSMS))
+	assert.True(t, number.HasFeature(FeatureVoice))
+	assert.False(t, number.HasFeature(FeatureMMS))
+}
+
+func TestSearchInvalidCountryCode(t *testing.T) {
+	client := mbtest.Client(t)
+
+	_, err := Search(client, "NLD", nil)
+	assert.Error(t, err)
+}
+
 func TestList(t *testing.T) {
 	mbtest.WillReturnTestdata(t, "numberList.json", http.StatusOK)
 	client := mbtest.Client(t)
@@ -42,7 +68,7 @@ func TestList(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/phone-numbers")
 
-	query := mbtest.Request.URL.RawQuery
+	query := mbtest.LastRequest().URL.RawQuery
 	assert.Equal(t, "limit=10", query)
 }
 
@@ -78,7 +104,7 @@ func TestUpdate(t *testing.T) {
 	assert.NoError(t, err)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/v1/phone-numbers/31612345670")
-	mbtest.AssertTestdata(t, "numberUpdateRequestObject.json", mbtest.Request.Body)
+	mbtest.AssertTestdata(t, "numberUpdateRequestObject.json", mbtest.LastRequest().Body)
 
 	if !reflect.DeepEqual(number.Tags, []string{"tag1", "tag2", "tag3"}) {
 		t.Errorf("Unexpected number tags: %s, expected: ['tag1', 'tag2', 'tag3']", number.Tags)
@@ -97,7 +123,50 @@ func TestPurchase(t *testing.T) {
 	assert.NoError(t, err)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/phone-numbers")
-	mbtest.AssertTestdata(t, "numberCreateRequestObject.json", mbtest.Request.Body)
+	mbtest.AssertTestdata(t, "numberCreateRequestObject.json", mbtest.LastRequest().Body)
 	assert.Equal(t, "31971234567", number.Number)
 	assert.Equal(t, "NL", number.Country)
 }
+
+func TestUpdateCallFlow(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberRead.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	number, err := Update(client, "31612345670", &UpdateRequest{CallFlowID: "callflow-id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "31612345670", number.Number)
+
+	// The number is read after linking it to the call flow, so the last
+	// request is the read.
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/phone-numbers/31612345670")
+}
+
+func TestUpdateCallFlowAndTags(t *testing.T) {
+	var requests []string
+	var bodies []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.Host+r.URL.Path)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusOK)
+		w.Write(mbtest.Testdata(t, "numberUpdatedObject.json"))
+	}))
+	defer stop()
+
+	client := mbtest.Client(t)
+	client.HTTPClient.Transport = transport
+
+	_, err := Update(client, "31612345670", &UpdateRequest{Tags: []string{"tag1"}, CallFlowID: "callflow-id"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST voice.messagebird.com/v1/call-flows/callflow-id/numbers",
+		"PATCH numbers.messagebird.com/v1/phone-numbers/31612345670",
+	}, requests)
+	assert.Equal(t, []string{`{"numbers":["31612345670"]}`, `{"tags":["tag1"]}`}, bodies)
+}
+
+func TestUpdateNilRequest(t *testing.T) {
+	_, err := Update(mbtest.Client(t), "31612345670", nil)
+	assert.Error(t, err)
+}
@@ -2,10 +2,13 @@ package number
 
 import (
 	"fmt"
-	messagebird "github.com/messagebird/go-rest-api/v9"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
 )
 
 type Pool struct {
@@ -59,12 +62,55 @@ type ListPoolRequest struct {
 	Offset   int    `json:"offset,omitempty"`
 }
 
+func (req *ListPoolRequest) QueryParams() string {
+	if req == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if req.PoolName != "" {
+		query.Set("poolName", req.PoolName)
+	}
+	if req.Service != "" {
+		query.Set("service", req.Service)
+	}
+	if req.Limit > 0 {
+		query.SetInt("limit", req.Limit)
+	}
+	if req.Offset > 0 {
+		query.SetInt("offset", req.Offset)
+	}
+
+	return query.Encode()
+}
+
 type ListPoolNumbersRequest struct {
 	Number string `json:"number,omitempty"`
 	Limit  int    `json:"limit,omitempty"`
 	Offset int    `json:"offset,omitempty"`
 }
 
+func (req *ListPoolNumbersRequest) QueryParams() string {
+	if req == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if req.Number != "" {
+		query.Set("number", req.Number)
+	}
+	if req.Limit > 0 {
+		query.SetInt("limit", req.Limit)
+	}
+	if req.Offset > 0 {
+		query.SetInt("offset", req.Offset)
+	}
+
+	return query.Encode()
+}
+
 func CreatePool(c messagebird.Client, req *CreatePoolRequest) (*Pool, error) {
 	p := &Pool{}
 	if err := request(c, p, http.MethodPost, pathPools, req); err != nil {
@@ -103,8 +149,10 @@ func DeletePool(c messagebird.Client, poolName string) error {
 }
 
 func ListPool(c messagebird.Client, req *ListPoolRequest) (*Pools, error) {
+	uri := fmt.Sprintf("%s?%s", pathPools, req.QueryParams())
+
 	p := &Pools{}
-	if err := request(c, p, http.MethodGet, pathPools, req); err != nil {
+	if err := request(c, p, http.MethodGet, uri, nil); err != nil {
 		return nil, err
 	}
 
@@ -112,10 +160,10 @@ func ListPool(c messagebird.Client, req *ListPoolRequest) (*Pools, error) {
 }
 
 func ListPoolNumbers(c messagebird.Client, poolName string, req *ListPoolNumbersRequest) (*PoolNumbers, error) {
-	uri := fmt.Sprintf("%s/%s/%s", pathPools, poolName, pathNumbers)
+	uri := fmt.Sprintf("%s/%s/%s?%s", pathPools, poolName, pathNumbers, req.QueryParams())
 
 	p := &PoolNumbers{}
-	if err := request(c, p, http.MethodGet, uri, req); err != nil {
+	if err := request(c, p, http.MethodGet, uri, nil); err != nil {
 		return nil, err
 	}
 
@@ -126,7 +174,7 @@ func AddNumberToPool(c messagebird.Client, poolName string, numbers []string) (*
 	uri := fmt.Sprintf("%s/%s/%s", pathPools, poolName, pathNumbers)
 
 	req := &struct {
-		numbers []string
+		Numbers []string `json:"numbers"`
 	}{numbers}
 
 	p := &AddNumberToPollResult{}
@@ -138,11 +186,10 @@ func AddNumberToPool(c messagebird.Client, poolName string, numbers []string) (*
 }
 
 func DeleteNumberFromPool(c messagebird.Client, poolName string, numbers []string) error {
-	uri := fmt.Sprintf("%s/%s/%s", pathPools, poolName, pathNumbers)
+	query := url.Values{}
+	query.Set("numbers", strings.Join(numbers, ","))
 
-	req := &struct {
-		numbers string
-	}{strings.Join(numbers, ",")}
+	uri := fmt.Sprintf("%s/%s/%s?%s", pathPools, poolName, pathNumbers, query.Encode())
 
-	return request(c, nil, http.MethodDelete, uri, req)
+	return request(c, nil, http.MethodDelete, uri, nil)
 }
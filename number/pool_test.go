@@ -108,6 +108,8 @@ func TestListPool(t *testing.T) {
 	assert.Equal(t, 10, list.Items[0].NumbersCount)
 
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/pools")
+	assert.Equal(t, "limit=1&poolName=name&service=randomcli", mbtest.LastRequest().URL.RawQuery)
+	assert.Empty(t, mbtest.LastRequest().Body)
 }
 
 func TestListPoolNumbers(t *testing.T) {
@@ -128,6 +130,8 @@ func TestListPoolNumbers(t *testing.T) {
 	assert.Equal(t, []string{"31612345678", "31612345679", "31612345670"}, list.Numbers)
 
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/pools/pool-name/numbers")
+	assert.Equal(t, "limit=20&number=316", mbtest.LastRequest().URL.RawQuery)
+	assert.Empty(t, mbtest.LastRequest().Body)
 }
 
 func TestAddNumberToPool(t *testing.T) {
@@ -143,6 +147,7 @@ func TestAddNumberToPool(t *testing.T) {
 	assert.Equal(t, "number is not verified", num.Fail[0].Error)
 
 	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/pools/pool-name/numbers")
+	mbtest.AssertBodyJSONEq(t, `{"numbers":["31612345678","31612345679","31612345670"]}`)
 }
 
 func TestDeleteNumberFromPool(t *testing.T) {
@@ -153,4 +158,5 @@ func TestDeleteNumberFromPool(t *testing.T) {
 	assert.NoError(t, err)
 
 	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/pools/pool-name/numbers")
+	assert.Equal(t, "numbers=31612345678%2C31612345679%2C31612345670", mbtest.LastRequest().URL.RawQuery)
 }
@@ -3,9 +3,8 @@ package number
 import (
 	"fmt"
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
 	"net/http"
-	"net/url"
-	"strconv"
 )
 
 type Product struct {
@@ -60,14 +59,14 @@ func (req *ProductsRequest) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
 	if len(req.Features) > 0 {
-		paramsForArrays("features", req.Features, &query)
+		paramsForArrays("features", req.Features, query)
 	}
 
 	if req.Limit > 0 {
-		query.Set("limit", strconv.Itoa(req.Limit))
+		query.SetInt("limit", req.Limit)
 	}
 
 	if len(req.Type) > 0 {
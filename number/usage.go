@@ -0,0 +1,95 @@
+package number
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/reporting"
+)
+
+// Period is the time range a UsageReport covers. Start is inclusive, End is
+// exclusive.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// LastDays returns the Period covering the n days before now.
+func LastDays(n int) Period {
+	end := time.Now().UTC()
+	return Period{Start: end.AddDate(0, 0, -n), End: end}
+}
+
+// UsageReport contains the traffic handled by a purchased number during a
+// Period.
+type UsageReport struct {
+	Number           string
+	Period           Period
+	InboundMessages  int
+	OutboundMessages int
+	InboundCalls     int
+	OutboundCalls    int
+}
+
+// Total returns the total number of messages and calls.
+func (u *UsageReport) Total() int {
+	return u.InboundMessages + u.OutboundMessages + u.InboundCalls + u.OutboundCalls
+}
+
+// IsIdle reports whether the number did not handle any traffic in the period,
+// which makes it a candidate for cancellation.
+func (u *UsageReport) IsIdle() bool {
+	return u.Total() == 0
+}
+
+// Usage aggregates the inbound and outbound messages and calls of
+// phoneNumber during period, using the Reporting API.
+func Usage(c messagebird.Client, phoneNumber string, period Period) (*UsageReport, error) {
+	if phoneNumber == "" {
+		return nil, errors.New("phoneNumber is required")
+	}
+	if !period.End.After(period.Start) {
+		return nil, errors.New("period end must be after its start")
+	}
+
+	report := &UsageReport{Number: phoneNumber, Period: period}
+
+	var err error
+	if report.InboundMessages, report.OutboundMessages, err = usage(c, reporting.ProductSMS, phoneNumber, period); err != nil {
+		return nil, err
+	}
+	if report.InboundCalls, report.OutboundCalls, err = usage(c, reporting.ProductVoice, phoneNumber, period); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// usage requests a report for a single product and returns the inbound and
+// outbound counts.
+func usage(c messagebird.Client, product reporting.Product, phoneNumber string, period Period) (inbound, outbound int, err error) {
+	resp, err := reporting.Read(c, product, &reporting.Request{
+		Start:       period.Start,
+		End:         period.End,
+		PeriodGroup: reporting.PeriodGroupNone,
+		GroupBy:     []string{reporting.GroupByDirection},
+		Number:      phoneNumber,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, item := range resp.Items {
+		// SMS reports use mo/mt, voice reports use incoming/outgoing.
+		switch strings.ToLower(item.Direction) {
+		case "mo", "incoming", "inbound":
+			inbound += item.Count
+		case "mt", "outgoing", "outbound":
+			outbound += item.Count
+		}
+	}
+
+	return inbound, outbound, nil
+}
@@ -0,0 +1,56 @@
+package number
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	var requests []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Host+r.URL.Path+"?"+r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+
+		if r.URL.Path == "/sms" {
+			w.Write([]byte(`{"items":[{"direction":"mo","count":4},{"direction":"mt","count":10}]}`))
+		} else {
+			w.Write([]byte(`{"items":[{"direction":"incoming","count":2}]}`))
+		}
+	}))
+	defer stop()
+
+	client := mbtest.Client(t)
+	client.HTTPClient.Transport = transport
+
+	period := Period{
+		Start: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	report, err := Usage(client, "31612345670", period)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 4, report.InboundMessages)
+	assert.Equal(t, 10, report.OutboundMessages)
+	assert.Equal(t, 2, report.InboundCalls)
+	assert.Equal(t, 0, report.OutboundCalls)
+	assert.Equal(t, 16, report.Total())
+	assert.False(t, report.IsIdle())
+
+	query := "?groupBy=direction&number=31612345670&periodEnd=2022-02-01T00%3A00%3A00Z&periodGroup=none&periodStart=2022-01-01T00%3A00%3A00Z"
+	assert.Equal(t, []string{
+		"reporting.messagebird.com/sms" + query,
+		"reporting.messagebird.com/voice" + query,
+	}, requests)
+}
+
+func TestUsageInvalidPeriod(t *testing.T) {
+	now := time.Now()
+
+	_, err := Usage(mbtest.Client(t), "31612345670", Period{Start: now, End: now})
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package numberutil
+
+// country holds the dialing information numberutil needs for a single country.
+type country struct {
+	// callingCode is the country calling code, e.g. 31 for the Netherlands.
+	callingCode string
+
+	// trunkPrefix is dialed before national numbers within the country and
+	// must be removed when converting to E.164. Empty if there is none.
+	trunkPrefix string
+}
+
+// countries maps ISO 3166-1 alpha-2 country codes to their dialing information.
+var countries = map[string]country{
+	"AD": {"376", ""},
+	"AE": {"971", "0"},
+	"AF": {"93", "0"},
+	"AL": {"355", "0"},
+	"AM": {"374", "0"},
+	"AO": {"244", ""},
+	"AR": {"54", "0"},
+	"AT": {"43", "0"},
+	"AU": {"61", "0"},
+	"AZ": {"994", "0"},
+	"BA": {"387", "0"},
+	"BD": {"880", "0"},
+	"BE": {"32", "0"},
+	"BG": {"359", "0"},
+	"BH": {"973", ""},
+	"BO": {"591", "0"},
+	"BR": {"55", "0"},
+	"BY": {"375", "8"},
+	"CA": {"1", "1"},
+	"CH": {"41", "0"},
+	"CL": {"56", ""},
+	"CN": {"86", "0"},
+	"CO": {"57", ""},
+	"CR": {"506", ""},
+	"CY": {"357", ""},
+	"CZ": {"420", ""},
+	"DE": {"49", "0"},
+	"DK": {"45", ""},
+	"DO": {"1", "1"},
+	"DZ": {"213", "0"},
+	"EC": {"593", "0"},
+	"EE": {"372", ""},
+	"EG": {"20", "0"},
+	"ES": {"34", ""},
+	"ET": {"251", "0"},
+	"FI": {"358", "0"},
+	"FR": {"33", "0"},
+	"GB": {"44", "0"},
+	"GE": {"995", "0"},
+	"GH": {"233", "0"},
+	"GR": {"30", ""},
+	"GT": {"502", ""},
+	"HK": {"852", ""},
+	"HR": {"385", "0"},
+	"HU": {"36", "06"},
+	"ID": {"62", "0"},
+	"IE": {"353", "0"},
+	"IL": {"972", "0"},
+	"IN": {"91", "0"},
+	"IQ": {"964", "0"},
+	"IR": {"98", "0"},
+	"IS": {"354", ""},
+	"IT": {"39", ""},
+	"JM": {"1", "1"},
+	"JO": {"962", "0"},
+	"JP": {"81", "0"},
+	"KE": {"254", "0"},
+	"KR": {"82", "0"},
+	"KW": {"965", ""},
+	"KZ": {"7", "8"},
+	"LB": {"961", "0"},
+	"LI": {"423", ""},
+	"LK": {"94", "0"},
+	"LT": {"370", "8"},
+	"LU": {"352", ""},
+	"LV": {"371", ""},
+	"MA": {"212", "0"},
+	"MC": {"377", ""},
+	"MD": {"373", "0"},
+	"ME": {"382", "0"},
+	"MK": {"389", "0"},
+	"MT": {"356", ""},
+	"MX": {"52", ""},
+	"MY": {"60", "0"},
+	"NG": {"234", "0"},
+	"NL": {"31", "0"},
+	"NO": {"47", ""},
+	"NZ": {"64", "0"},
+	"OM": {"968", ""},
+	"PA": {"507", ""},
+	"PE": {"51", "0"},
+	"PH": {"63", "0"},
+	"PK": {"92", "0"},
+	"PL": {"48", ""},
+	"PR": {"1", "1"},
+	"PT": {"351", ""},
+	"PY": {"595", "0"},
+	"QA": {"974", ""},
+	"RO": {"40", "0"},
+	"RS": {"381", "0"},
+	"RU": {"7", "8"},
+	"SA": {"966", "0"},
+	"SE": {"46", "0"},
+	"SG": {"65", ""},
+	"SI": {"386", "0"},
+	"SK": {"421", "0"},
+	"SN": {"221", ""},
+	"TH": {"66", "0"},
+	"TN": {"216", ""},
+	"TR": {"90", "0"},
+	"TT": {"1", "1"},
+	"TW": {"886", "0"},
+	"TZ": {"255", "0"},
+	"UA": {"380", "0"},
+	"UG": {"256", "0"},
+	"US": {"1", "1"},
+	"UY": {"598", "0"},
+	"UZ": {"998", "8"},
+	"VE": {"58", "0"},
+	"VN": {"84", "0"},
+	"ZA": {"27", "0"},
+	"ZW": {"263", "0"},
+}
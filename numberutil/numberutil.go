@@ -0,0 +1,184 @@
+// Package numberutil provides offline helpers for normalizing phone numbers to
+// E.164 before they are sent to the MessageBird API. It does not validate
+// whether a number exists: use package lookup for that.
+package numberutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// minDigits is the minimum number of digits, including the country
+	// calling code, we accept as a phone number.
+	minDigits = 7
+
+	// maxDigits is the maximum number of digits allowed by E.164.
+	maxDigits = 15
+)
+
+var (
+	// ErrInvalidNumber is returned when the input can not be interpreted as a
+	// phone number.
+	ErrInvalidNumber = errors.New("invalid phone number")
+
+	// ErrUnknownCountry is returned when a national number is provided
+	// without a default country that is known to this package.
+	ErrUnknownCountry = errors.New("unknown country")
+)
+
+// Normalize converts input to E.164 format, e.g. +31612345678. Numbers that
+// start with + or 00 are treated as international numbers. Other numbers are
+// interpreted as national numbers of defaultCountry, which must be an ISO
+// 3166-1 alpha-2 country code (e.g. NL), or as international numbers without
+// the leading +, the format of the API, if defaultCountry is empty.
+// Separators like spaces, dashes, dots and parentheses are ignored.
+func Normalize(input, defaultCountry string) (string, error) {
+	digits, international, err := clean(input)
+	if err != nil {
+		return "", err
+	}
+
+	if !international && defaultCountry != "" {
+		c, ok := countries[strings.ToUpper(defaultCountry)]
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnknownCountry, defaultCountry)
+		}
+
+		if c.trunkPrefix != "" {
+			digits = strings.TrimPrefix(digits, c.trunkPrefix)
+		}
+		digits = c.callingCode + digits
+	}
+
+	if len(digits) < minDigits || len(digits) > maxDigits || digits[0] == '0' {
+		return "", fmt.Errorf("%w: %q", ErrInvalidNumber, input)
+	}
+
+	return "+" + digits, nil
+}
+
+// MSISDN is like Normalize, but returns the number without the leading +,
+// e.g. 31612345678. This is the format most MessageBird APIs use.
+func MSISDN(input, defaultCountry string) (string, error) {
+	e164, err := Normalize(input, defaultCountry)
+	if err != nil {
+		return "", err
+	}
+
+	return e164[1:], nil
+}
+
+// Recipient returns input as an MSISDN if it is a phone number in
+// international format, and unchanged otherwise, e.g. if it is an email
+// address or the ID of a user on another platform. The API rejects the
+// numbers that can not be normalized, so they are left for it to explain.
+func Recipient(input string) string {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" || (trimmed[0] != '+' && trimmed[0] != '(' && (trimmed[0] < '0' || trimmed[0] > '9')) {
+		return input
+	}
+
+	msisdn, err := MSISDN(trimmed, "")
+	if err != nil {
+		return input
+	}
+
+	return msisdn
+}
+
+// NormalizeAll normalizes every input using Normalize. It stops at the first
+// input that can not be normalized and returns an error that includes its
+// index.
+func NormalizeAll(inputs []string, defaultCountry string) ([]string, error) {
+	normalized := make([]string, 0, len(inputs))
+	for i, input := range inputs {
+		n, err := Normalize(input, defaultCountry)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+
+		normalized = append(normalized, n)
+	}
+
+	return normalized, nil
+}
+
+// CallingCode returns the country calling code (e.g. 31) for an ISO 3166-1
+// alpha-2 country code (e.g. NL).
+func CallingCode(country string) (string, bool) {
+	c, ok := countries[strings.ToUpper(country)]
+	return c.callingCode, ok
+}
+
+// sharedCallingCodes are the country calling codes shared by several
+// countries, with the country Country returns for them.
+var sharedCallingCodes = map[string]string{
+	"1": "US",
+	"7": "RU",
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country of number, which
+// must be in international format, with or without the leading +. Numbers
+// with a calling code shared by several countries, like 1 for the United
+// States and Canada, are attributed to the largest of them, see
+// sharedCallingCodes.
+func Country(number string) (string, bool) {
+	digits, _, err := clean(number)
+	if err != nil {
+		return "", false
+	}
+
+	// Calling codes are prefix free, so at most one matches.
+	for n := 1; n <= 3 && n <= len(digits); n++ {
+		code := digits[:n]
+		if country, ok := sharedCallingCodes[code]; ok {
+			return country, true
+		}
+		for country, c := range countries {
+			if c.callingCode == code {
+				return country, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// clean strips all separators from input. It reports whether the number was
+// written in international format, in which case the returned digits start
+// with the country calling code.
+func clean(input string) (string, bool, error) {
+	input = strings.TrimSpace(input)
+	international := strings.HasPrefix(input, "+")
+	if international {
+		// Numbers are commonly written as +31 (0)6 12345678, where the
+		// trunk prefix in parentheses must not be dialed.
+		input = strings.Replace(input, "(0)", "", 1)
+	}
+
+	var b strings.Builder
+	for i, r := range input {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ', r == '-', r == '.', r == '(', r == ')', r == '/':
+		default:
+			return "", false, fmt.Errorf("%w: %q", ErrInvalidNumber, input)
+		}
+	}
+
+	digits := b.String()
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+
+	if digits == "" {
+		return "", false, fmt.Errorf("%w: %q", ErrInvalidNumber, input)
+	}
+
+	return digits, international, nil
+}
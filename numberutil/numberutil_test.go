@@ -0,0 +1,112 @@
+package numberutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tt := []struct {
+		input, country, expected string
+	}{
+		{"+31612345678", "", "+31612345678"},
+		{"+31 (0)6-1234 5678", "NL", "+31612345678"},
+		{"0031612345678", "", "+31612345678"},
+		{"31612345678", "", "+31612345678"},
+		{"31 6 1234 5678", "", "+31612345678"},
+		{"06 12345678", "NL", "+31612345678"},
+		{"06 12345678", "nl", "+31612345678"},
+		{"(415) 555-2671", "US", "+14155552671"},
+		{"1 415 555 2671", "US", "+14155552671"},
+		{"06 1234 5678", "IT", "+390612345678"},
+		{"8 912 345 67 89", "RU", "+79123456789"},
+		{"020.7946.0018", "GB", "+442079460018"},
+	}
+
+	for _, tc := range tt {
+		actual, err := Normalize(tc.input, tc.country)
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, actual, tc.input)
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	tt := []struct {
+		input, country string
+		expected       error
+	}{
+		{"", "NL", ErrInvalidNumber},
+		{"+", "", ErrInvalidNumber},
+		{"06 12345678", "", ErrInvalidNumber},
+		{"06 12345678", "XX", ErrUnknownCountry},
+		{"0612abc", "NL", ErrInvalidNumber},
+		{"+3161234567890123", "", ErrInvalidNumber},
+		{"+123", "", ErrInvalidNumber},
+		{"+0612345678", "", ErrInvalidNumber},
+	}
+
+	for _, tc := range tt {
+		_, err := Normalize(tc.input, tc.country)
+		assert.True(t, errors.Is(err, tc.expected), "%q: %v", tc.input, err)
+	}
+}
+
+func TestMSISDN(t *testing.T) {
+	msisdn, err := MSISDN("06 12345678", "NL")
+	assert.NoError(t, err)
+	assert.Equal(t, "31612345678", msisdn)
+
+	_, err = MSISDN("", "NL")
+	assert.Error(t, err)
+}
+
+func TestRecipient(t *testing.T) {
+	assert.Equal(t, "31612345678", Recipient("+31 6 1234 5678"))
+	assert.Equal(t, "31612345678", Recipient("31612345678"))
+	assert.Equal(t, "info@example.com", Recipient("info@example.com"))
+	assert.Equal(t, "-1001234567890", Recipient("-1001234567890"), "Telegram group IDs are kept")
+	assert.Equal(t, "06 12345678", Recipient("06 12345678"), "national numbers are left for the API to reject")
+}
+
+func TestNormalizeAll(t *testing.T) {
+	normalized, err := NormalizeAll([]string{"0612345678", "+32470123456"}, "NL")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"+31612345678", "+32470123456"}, normalized)
+
+	_, err = NormalizeAll([]string{"0612345678", "bogus"}, "NL")
+	assert.EqualError(t, err, `input 1: invalid phone number: "bogus"`)
+}
+
+func TestCallingCode(t *testing.T) {
+	code, ok := CallingCode("nl")
+	assert.True(t, ok)
+	assert.Equal(t, "31", code)
+
+	_, ok = CallingCode("XX")
+	assert.False(t, ok)
+}
+
+func TestCountry(t *testing.T) {
+	tt := []struct {
+		number, expected string
+	}{
+		{"+31612345678", "NL"},
+		{"31612345678", "NL"},
+		{"+1 415 555 2671", "US"},
+		{"79123456789", "RU"},
+		{"+35312345678", "IE"},
+	}
+
+	for _, tc := range tt {
+		country, ok := Country(tc.number)
+		assert.True(t, ok, tc.number)
+		assert.Equal(t, tc.expected, country, tc.number)
+	}
+
+	_, ok := Country("+999123456")
+	assert.False(t, ok)
+	_, ok = Country("TestName")
+	assert.False(t, ok)
+}
@@ -0,0 +1,259 @@
+// Package outbox queues outbound messages in a durable Store and sends them
+// in the background, so messages survive process restarts and temporary API
+// failures:
+//
+//	store, err := outbox.NewFileStore("/var/lib/myapp/outbox")
+//	o := &outbox.Outbox{Client: client, Store: store}
+//	go o.Run(ctx)
+//
+//	id, err := o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "Hello!", nil)
+//
+// Delivery is at least once: if the process stops after a message was sent
+// but before its entry was removed, it is sent again after a restart. The ID
+// of an entry is sent along as an idempotency key, in the reference of SMS
+// messages and the trackId of conversation messages, unless these are set
+// already, so duplicates can be recognized in status reports.
+//
+// To keep the content of queued messages encrypted at rest, wrap the store in
+// an EncryptedStore.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/internal/queue"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+// Kinds of Entry.
+const (
+	KindSMS          = "sms"
+	KindConversation = "conversation"
+)
+
+// Defaults for the options of an Outbox.
+const (
+	DefaultPollInterval = time.Second
+	DefaultMaxAttempts  = 10
+	DefaultRetryDelay   = time.Second
+	DefaultMaxDelay     = 5 * time.Minute
+	DefaultBatchSize    = 100
+)
+
+// Entry is a queued message. Its Payload is the message, as SMS or
+// conversation.SendMessageRequest depending on Kind. Err is set by a Store
+// for an entry that can never be sent, e.g. because it can not be
+// decrypted.
+type Entry = queue.Entry
+
+// SMS is the Payload of entries of KindSMS.
+type SMS struct {
+	Originator string
+	Recipients []string
+	Body       string
+	Params     *sms.Params
+}
+
+// Outbox sends queued messages with Client. Its fields must not be changed
+// once Run is called. Only one Outbox may run per Store.
+type Outbox struct {
+	Client messagebird.Client
+	Store  Store
+
+	// PollInterval is the time between checks for due entries when the
+	// queue is empty. DefaultPollInterval is used if it is zero.
+	PollInterval time.Duration
+
+	// MaxAttempts is the number of times a message is sent before it fails
+	// permanently. DefaultMaxAttempts is used if it is zero.
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first retry. It doubles for every
+	// following retry, up to MaxDelay. DefaultRetryDelay and DefaultMaxDelay
+	// are used if they are zero.
+	RetryDelay time.Duration
+	MaxDelay   time.Duration
+
+	// IsPermanent reports whether err means sending will never succeed, so
+	// the message is not retried. IsPermanentError is used if it is nil.
+	IsPermanent func(err error) bool
+
+	// OnSent is called after a message was sent, with the message returned
+	// by the API: a *sms.Message or *conversation.Message.
+	OnSent func(e *Entry, message interface{})
+
+	// OnFailed is called when a message fails permanently, after which it
+	// is removed from the Store.
+	OnFailed func(e *Entry, err error)
+}
+
+// IsPermanentError reports whether err was returned by the API for a
+// request it will not accept, e.g. because of an invalid recipient.
+// Rate limits, server errors and network errors are temporary.
+func IsPermanentError(err error) bool {
+	var errorResponse messagebird.ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		return false
+	}
+
+	for _, e := range errorResponse.Errors {
+		if e.Code == 429 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EnqueueSMS queues an SMS for sending with sms.Create and returns the ID
+// of its entry.
+func (o *Outbox) EnqueueSMS(ctx context.Context, originator string, recipients []string, body string, params *sms.Params) (string, error) {
+	if len(recipients) == 0 {
+		return "", errors.New("at least 1 recipient is required")
+	}
+	if body == "" {
+		return "", errors.New("body is required")
+	}
+
+	return o.enqueue(ctx, KindSMS, &SMS{
+		Originator: originator,
+		Recipients: recipients,
+		Body:       body,
+		Params:     params,
+	}, time.Time{})
+}
+
+// EnqueueConversation queues a message for sending with
+// conversation.SendMessage and returns the ID of its entry.
+func (o *Outbox) EnqueueConversation(ctx context.Context, req *conversation.SendMessageRequest) (string, error) {
+	return o.EnqueueConversationAt(ctx, req, time.Time{})
+}
+
+// EnqueueConversationAt is like EnqueueConversation, but the message is not
+// sent before at. The Conversations API can not schedule messages itself.
+func (o *Outbox) EnqueueConversationAt(ctx context.Context, req *conversation.SendMessageRequest, at time.Time) (string, error) {
+	if req == nil {
+		return "", errors.New("send message request should not be nil")
+	}
+	if req.To == "" {
+		return "", errors.New("to is required")
+	}
+
+	return o.enqueue(ctx, KindConversation, req, at)
+}
+
+// enqueue stores payload as an entry that is due at, or now if at is zero or
+// has passed.
+func (o *Outbox) enqueue(ctx context.Context, kind string, payload interface{}, at time.Time) (string, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return o.queue().Enqueue(ctx, &Entry{Kind: kind, Payload: b, NextAttempt: at})
+}
+
+// Run sends due entries until ctx is done, and returns ctx.Err(). Errors of
+// the Store are retried after PollInterval.
+func (o *Outbox) Run(ctx context.Context) error {
+	return o.queue().Run(ctx)
+}
+
+// Drain sends a batch of due entries once and returns how many it handled.
+// Use it instead of Run to send from a scheduled job.
+func (o *Outbox) Drain(ctx context.Context) (int, error) {
+	return o.queue().Drain(ctx)
+}
+
+// queue returns the queue that sends the entries in o.Store.
+func (o *Outbox) queue() *queue.Queue {
+	q := &queue.Queue{
+		Store:        o.Store,
+		Clock:        messagebird.ClockOf(o.Client),
+		PollInterval: o.PollInterval,
+		MaxAttempts:  o.MaxAttempts,
+		RetryDelay:   o.RetryDelay,
+		MaxDelay:     o.MaxDelay,
+		Handle: func(_ context.Context, e *Entry) (interface{}, error) {
+			return o.deliver(e)
+		},
+		IsPermanent: o.isPermanent,
+		OnHandled:   o.OnSent,
+		OnFailed:    o.OnFailed,
+	}
+	if q.PollInterval == 0 {
+		q.PollInterval = DefaultPollInterval
+	}
+	if q.MaxAttempts == 0 {
+		q.MaxAttempts = DefaultMaxAttempts
+	}
+	if q.RetryDelay == 0 {
+		q.RetryDelay = DefaultRetryDelay
+	}
+	if q.MaxDelay == 0 {
+		q.MaxDelay = DefaultMaxDelay
+	}
+
+	return q
+}
+
+// isPermanent reports whether err, returned by deliver, means the message
+// can never be sent.
+func (o *Outbox) isPermanent(err error) bool {
+	var corrupt permanentError
+	if errors.As(err, &corrupt) {
+		return true
+	}
+
+	if o.IsPermanent != nil {
+		return o.IsPermanent(err)
+	}
+
+	return IsPermanentError(err)
+}
+
+// deliver sends the message of e with the entry ID as idempotency key.
+func (o *Outbox) deliver(e *Entry) (interface{}, error) {
+	switch e.Kind {
+	case KindSMS:
+		var m SMS
+		if err := json.Unmarshal(e.Payload, &m); err != nil {
+			return nil, permanentError{err}
+		}
+
+		params := &sms.Params{}
+		if m.Params != nil {
+			params = m.Params
+		}
+		if params.Reference == "" {
+			params.Reference = e.ID
+		}
+
+		return sms.Create(o.Client, m.Originator, m.Recipients, m.Body, params)
+	case KindConversation:
+		var req conversation.SendMessageRequest
+		if err := json.Unmarshal(e.Payload, &req); err != nil {
+			return nil, permanentError{err}
+		}
+
+		if req.TrackId == "" {
+			req.TrackId = e.ID
+		}
+
+		return conversation.SendMessage(o.Client, &req)
+	default:
+		return nil, permanentError{fmt.Errorf("unknown kind %q", e.Kind)}
+	}
+}
+
+// permanentError is an error for an entry that can never be sent, e.g.
+// because its payload is corrupt.
+type permanentError struct {
+	error
+}
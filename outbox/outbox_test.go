@@ -0,0 +1,249 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/encryption"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOutbox(t *testing.T) (*Outbox, *messagebirdtest.Server, *messagebirdtest.FakeClock, *MemoryStore) {
+	s := messagebirdtest.NewServer(t)
+	clock := messagebirdtest.NewFakeClock(time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := s.Client()
+	client.Clock = clock
+	store := NewMemoryStore()
+
+	return &Outbox{Client: client, Store: store}, s, clock, store
+}
+
+func TestDrainSMS(t *testing.T) {
+	o, s, _, store := newTestOutbox(t)
+	ctx := context.Background()
+
+	var sent []*Entry
+	o.OnSent = func(e *Entry, message interface{}) {
+		assert.IsType(t, &sms.Message{}, message)
+		sent = append(sent, e)
+	}
+
+	id, err := o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "Hello!", &sms.Params{Type: "flash"})
+	require.NoError(t, err)
+	assert.Len(t, id, 32)
+	assert.Empty(t, s.Requests())
+
+	n, err := o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	s.AssertEndpointCalled(http.MethodPost, "/messages")
+	s.AssertBodyField("reference", id)
+	s.AssertBodyField("body", "Hello!")
+	s.AssertBodyField("type", "flash")
+
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, id, sent[0].ID)
+	}
+	assert.Zero(t, store.Len())
+}
+
+func TestDrainConversation(t *testing.T) {
+	o, s, _, _ := newTestOutbox(t)
+	ctx := context.Background()
+
+	id, err := o.EnqueueConversation(ctx, &conversation.SendMessageRequest{
+		To:      "+31612345678",
+		From:    "channel-id",
+		Type:    conversation.MessageTypeText,
+		Content: &conversation.MessageContent{Text: "Hello!"},
+	})
+	require.NoError(t, err)
+
+	_, err = o.Drain(ctx)
+	assert.NoError(t, err)
+
+	s.AssertEndpointCalled(http.MethodPost, "/v1/send")
+	s.AssertBodyField("trackId", id)
+	s.AssertBodyField("content.text", "Hello!")
+}
+
+func TestEnqueueConversationAt(t *testing.T) {
+	o, s, clock, _ := newTestOutbox(t)
+	ctx := context.Background()
+
+	_, err := o.EnqueueConversationAt(ctx, &conversation.SendMessageRequest{
+		To:      "+31612345678",
+		From:    "channel-id",
+		Type:    conversation.MessageTypeText,
+		Content: &conversation.MessageContent{Text: "Good morning!"},
+	}, clock.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	n, err := o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Empty(t, s.Requests())
+
+	clock.Advance(time.Hour)
+	n, err = o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	s.AssertBodyField("content.text", "Good morning!")
+}
+
+func TestDrainRetriesTemporaryErrors(t *testing.T) {
+	o, s, clock, store := newTestOutbox(t)
+	ctx := context.Background()
+	s.FailOnCall(1, messagebirdtest.FailWithServerError())
+	s.FailOnCall(2, messagebirdtest.FailWithRateLimit(time.Second))
+
+	_, err := o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "Hello!", nil)
+	require.NoError(t, err)
+
+	n, err := o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, store.Len())
+
+	// Not due until the retry delay passed.
+	n, err = o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+
+	clock.Advance(DefaultRetryDelay)
+	_, err = o.Drain(ctx)
+	assert.NoError(t, err)
+
+	entries, _ := store.Due(ctx, clock.Now().Add(time.Hour), 10)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, 2, entries[0].Attempts)
+		assert.Equal(t, clock.Now().Add(2*DefaultRetryDelay), entries[0].NextAttempt)
+		assert.Contains(t, entries[0].LastError, "Too many requests")
+	}
+
+	clock.Advance(2 * DefaultRetryDelay)
+	_, err = o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, store.Len())
+	assert.Len(t, s.Requests(), 3)
+}
+
+func TestDrainPermanentFailure(t *testing.T) {
+	o, s, _, store := newTestOutbox(t)
+	ctx := context.Background()
+	s.FailOnCall(1, messagebirdtest.FailWithAPIError(http.StatusUnprocessableEntity, 9, "no (correct) recipients found"))
+
+	var failed error
+	o.OnFailed = func(e *Entry, err error) {
+		assert.Equal(t, 1, e.Attempts)
+		failed = err
+	}
+
+	_, err := o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "Hello!", nil)
+	require.NoError(t, err)
+
+	_, err = o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.EqualError(t, failed, "API errors: no (correct) recipients found")
+	assert.Zero(t, store.Len())
+}
+
+func TestDrainMaxAttempts(t *testing.T) {
+	o, s, clock, store := newTestOutbox(t)
+	ctx := context.Background()
+	o.MaxAttempts = 2
+	s.FailOnCall(1, messagebirdtest.FailWithTimeout())
+	s.FailOnCall(2, messagebirdtest.FailWithTimeout())
+
+	var failed int
+	o.OnFailed = func(e *Entry, err error) { failed++ }
+
+	_, err := o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "Hello!", nil)
+	require.NoError(t, err)
+
+	_, _ = o.Drain(ctx)
+	clock.Advance(time.Hour)
+	_, _ = o.Drain(ctx)
+
+	assert.Equal(t, 1, failed)
+	assert.Zero(t, store.Len())
+}
+
+func TestEnqueueValidation(t *testing.T) {
+	o, _, _, _ := newTestOutbox(t)
+	ctx := context.Background()
+
+	_, err := o.EnqueueSMS(ctx, "MessageBird", nil, "Hello!", nil)
+	assert.EqualError(t, err, "at least 1 recipient is required")
+
+	_, err = o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "", nil)
+	assert.EqualError(t, err, "body is required")
+
+	_, err = o.EnqueueConversation(ctx, &conversation.SendMessageRequest{})
+	assert.EqualError(t, err, "to is required")
+}
+
+func TestRun(t *testing.T) {
+	o, _, clock, _ := newTestOutbox(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sent := make(chan string, 1)
+	o.OnSent = func(e *Entry, _ interface{}) { sent <- e.ID }
+
+	done := make(chan error)
+	go func() { done <- o.Run(ctx) }()
+
+	clock.BlockUntil(1)
+	id, err := o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "Hello!", nil)
+	require.NoError(t, err)
+	clock.Advance(DefaultPollInterval)
+
+	assert.Equal(t, id, <-sent)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestIsPermanentError(t *testing.T) {
+	assert.True(t, IsPermanentError(messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 9}}}))
+	assert.False(t, IsPermanentError(messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 429}}}))
+	assert.False(t, IsPermanentError(messagebird.ErrUnexpectedResponse))
+	assert.False(t, IsPermanentError(errors.New("connection reset")))
+}
+
+func TestDrainUndecryptable(t *testing.T) {
+	o, s, _, memoryStore := newTestOutbox(t)
+	ctx := context.Background()
+
+	key, err := encryption.GenerateKey()
+	require.NoError(t, err)
+	cipher, err := encryption.NewAESGCM(key)
+	require.NoError(t, err)
+	o.Store = &EncryptedStore{Store: memoryStore, Cipher: cipher}
+
+	// An entry injected into the store without encryption.
+	require.NoError(t, memoryStore.Put(ctx, &Entry{ID: "injected", Kind: KindSMS, Payload: json.RawMessage(`{"Body":"1"}`)}))
+
+	var failed error
+	o.OnFailed = func(e *Entry, err error) { failed = err }
+
+	_, err = o.EnqueueSMS(ctx, "MessageBird", []string{"31612345678"}, "Hello!", nil)
+	require.NoError(t, err)
+
+	n, err := o.Drain(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.EqualError(t, failed, "entry injected: content is not encrypted")
+	assert.Len(t, s.Requests(), 1, "the other entry is sent")
+	assert.Zero(t, memoryStore.Len())
+}
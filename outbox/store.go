@@ -0,0 +1,44 @@
+package outbox
+
+import "github.com/messagebird/go-rest-api/v9/internal/queue"
+
+// Store persists the entries of an Outbox. Implementations backed by a
+// database, like SQLite or Redis, only need to store entries by ID and find
+// the ones that are due. They must be safe for concurrent use.
+//
+// The same stores are used by webhooks.RetryQueue.
+type Store = queue.Store
+
+// MemoryStore is a Store that keeps entries in memory. Entries do not
+// survive restarts, so it is only suitable for tests.
+type MemoryStore = queue.MemoryStore
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return queue.NewMemoryStore()
+}
+
+// FileStore is a Store that keeps every entry in a JSON file in Dir. Files
+// are replaced atomically, so entries survive crashes. Due reads all files,
+// so it suits queues of up to a few thousand entries.
+//
+// Files that can not be parsed, e.g. because they were changed by hand, are
+// renamed to end in .corrupt, so they do not block the queue, and reported
+// to ErrorLog.
+type FileStore = queue.FileStore
+
+// NewFileStore returns a FileStore in dir, creating dir if it does not
+// exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	return queue.NewFileStore(dir)
+}
+
+// EncryptedStore is a Store that encrypts the Payload of entries with Cipher
+// before they are put in Store, and decrypts them when they are due. The
+// other fields are needed to schedule entries, and are stored as is. The
+// encrypted payload is bound to the ID of its entry.
+//
+// Entries that can not be decrypted, e.g. because their key was removed or
+// they were changed or moved to another ID, are returned nonetheless, so they do not block the
+// queue: the Outbox fails them permanently, see Outbox.OnFailed.
+type EncryptedStore = queue.EncryptedStore
@@ -0,0 +1,82 @@
+package partner_accounts
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+const accessKeysPath = "access-keys"
+
+// AccessKeyMode determines whether an access key sends real messages (live)
+// or only validates requests (test).
+type AccessKeyMode string
+
+const (
+	AccessKeyModeLive AccessKeyMode = "live"
+	AccessKeyModeTest AccessKeyMode = "test"
+)
+
+type AccessKeys []AccessKey
+
+type createAccessKeyRequest struct {
+	Mode AccessKeyMode `json:"mode"`
+}
+
+func accessKeysURI(accountID string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", apiRoot, childAccountsPath, accountID, accessKeysPath)
+}
+
+// CreateAccessKey creates a new access key for a child account. The key is
+// only included in the response of this call, so store it right away.
+func CreateAccessKey(c messagebird.Client, accountID string, mode AccessKeyMode) (*AccessKey, error) {
+	if mode != AccessKeyModeLive && mode != AccessKeyModeTest {
+		return nil, fmt.Errorf("mode must be %q or %q, got %q", AccessKeyModeLive, AccessKeyModeTest, mode)
+	}
+
+	k := &AccessKey{}
+	if err := c.Request(k, http.MethodPost, accessKeysURI(accountID), &createAccessKeyRequest{mode}); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// ListAccessKeys fetches the access keys of a child account. The keys
+// themselves are not included, only their IDs and modes.
+func ListAccessKeys(c messagebird.Client, accountID string) (*AccessKeys, error) {
+	k := &AccessKeys{}
+	if err := c.Request(k, http.MethodGet, accessKeysURI(accountID), nil); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// RevokeAccessKey deletes an access key of a child account. Requests using
+// the key are rejected afterwards.
+func RevokeAccessKey(c messagebird.Client, accountID, keyID string) error {
+	if keyID == "" {
+		return errors.New("keyID is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, accessKeysURI(accountID)+"/"+keyID, nil)
+}
+
+// RotateAccessKey creates a new access key with the same mode as the key
+// identified by keyID, and then revokes the old key. If revoking fails, the
+// new key is returned along with the error, so it is not lost.
+func RotateAccessKey(c messagebird.Client, accountID, keyID string, mode AccessKeyMode) (*AccessKey, error) {
+	k, err := CreateAccessKey(c, accountID, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := RevokeAccessKey(c, accountID, keyID); err != nil {
+		return k, err
+	}
+
+	return k, nil
+}
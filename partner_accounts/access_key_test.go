@@ -0,0 +1,74 @@
+package partner_accounts
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAccessKey(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "createAccessKeyResponse.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	k, err := CreateAccessKey(client, "6249799", AccessKeyModeLive)
+	assert.NoError(t, err)
+	assert.Equal(t, "NewKeyXXlHK9h3SHaAC4VAbCd", k.Key)
+	assert.Equal(t, "live", k.Mode)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/child-accounts/6249799/access-keys")
+	assert.JSONEq(t, `{"mode":"live"}`, string(mbtest.LastRequest().Body))
+}
+
+func TestCreateAccessKeyInvalidMode(t *testing.T) {
+	_, err := CreateAccessKey(mbtest.Client(t), "6249799", "sandbox")
+	assert.Error(t, err)
+}
+
+func TestListAccessKeys(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "listAccessKeysResponse.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	keys, err := ListAccessKeys(client, "6249799")
+	assert.NoError(t, err)
+	assert.Len(t, *keys, 2)
+	assert.Equal(t, "test", (*keys)[1].Mode)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/child-accounts/6249799/access-keys")
+}
+
+func TestRevokeAccessKey(t *testing.T) {
+	mbtest.WillReturnOnlyStatus(http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := RevokeAccessKey(client, "6249799", "6912036c-dd42-489b-8588-8c430aec37ef")
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/child-accounts/6249799/access-keys/6912036c-dd42-489b-8588-8c430aec37ef")
+}
+
+func TestRotateAccessKey(t *testing.T) {
+	var requests []string
+	transport, stop := mbtest.HTTPTestTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write(mbtest.Testdata(t, "createAccessKeyResponse.json"))
+	}))
+	defer stop()
+
+	client := mbtest.Client(t)
+	client.HTTPClient.Transport = transport
+
+	k, err := RotateAccessKey(client, "6249799", "old-key-id", AccessKeyModeLive)
+	assert.NoError(t, err)
+	assert.Equal(t, "NewKeyXXlHK9h3SHaAC4VAbCd", k.Key)
+	assert.Equal(t, []string{
+		"POST /v1/child-accounts/6249799/access-keys",
+		"DELETE /v1/child-accounts/6249799/access-keys/old-key-id",
+	}, requests)
+}
@@ -0,0 +1,197 @@
+// Package personalize renders message bodies per recipient from the
+// attributes of their contact, using text/template, and checks that every
+// rendered body still fits the SMS length budget:
+//
+//	tmpl, err := personalize.Parse("Hi {{.FirstName}}, your code is {{.Custom1}}.")
+//	tmpl.MaxParts = 1
+//
+//	ids, err := tmpl.Enqueue(ctx, o, "MessageBird", contacts, nil)
+//
+// All messages are rendered before any is sent or queued, so a single
+// recipient whose body is too long does not leave a campaign half sent.
+package personalize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/outbox"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+var (
+	// ErrTooLong is returned, wrapped, for bodies that take more than
+	// MaxParts SMS parts.
+	ErrTooLong = errors.New("message too long")
+
+	// ErrUnicode is returned, wrapped, for bodies that need the unicode data
+	// coding when it is not allowed.
+	ErrUnicode = errors.New("message needs unicode")
+)
+
+// Template renders the body of a message per recipient. The data of a
+// template is the Data of the recipient's contact. Referring to attributes
+// that do not exist is an error.
+type Template struct {
+	tmpl *template.Template
+
+	// MaxParts is the maximum number of SMS parts a rendered body may take.
+	// Zero means no maximum.
+	MaxParts int
+
+	// AllowUnicode allows bodies that need the unicode data coding. A
+	// single character outside the GSM 03.38 alphabet, e.g. in a name,
+	// reduces a part from 160 to 70 characters, so they are refused by
+	// default.
+	AllowUnicode bool
+}
+
+// Parse parses the text of a template.
+func Parse(text string) (*Template, error) {
+	tmpl, err := template.New("body").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Data returns the attributes of c that templates can refer to: FirstName,
+// LastName, Name, which is both, MSISDN and Custom1 to Custom4.
+func Data(c *contact.Contact) map[string]interface{} {
+	return map[string]interface{}{
+		"FirstName": c.FirstName,
+		"LastName":  c.LastName,
+		"Name":      strings.TrimSpace(c.FirstName + " " + c.LastName),
+		"MSISDN":    strconv.FormatInt(c.MSISDN, 10),
+		"Custom1":   c.CustomDetails.Custom1,
+		"Custom2":   c.CustomDetails.Custom2,
+		"Custom3":   c.CustomDetails.Custom3,
+		"Custom4":   c.CustomDetails.Custom4,
+	}
+}
+
+// Message is a body rendered for a recipient.
+type Message struct {
+	Contact *contact.Contact
+	Body    string
+	Length  sms.Length
+}
+
+// Render renders the template with data and checks the length of the body.
+func (t *Template) Render(data interface{}) (string, sms.Length, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", sms.Length{}, err
+	}
+
+	body := buf.String()
+	length := sms.LengthOf(body)
+	if length.DataCoding == sms.DataCodingUnicode && !t.AllowUnicode {
+		return "", length, fmt.Errorf("%w: %q", ErrUnicode, body)
+	}
+	if t.MaxParts > 0 && length.Parts > t.MaxParts {
+		return "", length, fmt.Errorf("%w: %d parts, at most %d allowed", ErrTooLong, length.Parts, t.MaxParts)
+	}
+
+	return body, length, nil
+}
+
+// RenderContacts renders a message for every contact. It fails on the first
+// contact for which the template can not be rendered or its body does not
+// pass the checks.
+func (t *Template) RenderContacts(contacts []contact.Contact) ([]*Message, error) {
+	messages := make([]*Message, 0, len(contacts))
+	for i := range contacts {
+		c := &contacts[i]
+
+		body, length, err := t.Render(Data(c))
+		if err != nil {
+			return nil, fmt.Errorf("contact %s: %w", contactName(c), err)
+		}
+
+		messages = append(messages, &Message{Contact: c, Body: body, Length: length})
+	}
+
+	return messages, nil
+}
+
+// Send renders a message for every contact and, if all pass the checks,
+// sends each of them with contact.SendSMS, which checks consent in
+// compliance mode. It returns the messages sent before an error occurred.
+func (t *Template) Send(c messagebird.Client, originator string, contacts []contact.Contact, params *sms.Params) ([]*sms.Message, error) {
+	messages, err := t.RenderContacts(contacts)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make([]*sms.Message, 0, len(messages))
+	for _, m := range messages {
+		message, err := contact.SendSMS(c, m.Contact, originator, m.Body, withDataCoding(params, m.Length))
+		if err != nil {
+			return sent, fmt.Errorf("contact %s: %w", contactName(m.Contact), err)
+		}
+		sent = append(sent, message)
+	}
+
+	return sent, nil
+}
+
+// Enqueue renders a message for every contact and, if all pass the checks,
+// queues them in o. It returns the IDs of the queued entries.
+func (t *Template) Enqueue(ctx context.Context, o *outbox.Outbox, originator string, contacts []contact.Contact, params *sms.Params) ([]string, error) {
+	messages, err := t.RenderContacts(contacts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range messages {
+		if err := contact.CheckConsent(o.Client, m.Contact, contact.ChannelSMS); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, m := range messages {
+		recipient := strconv.FormatInt(m.Contact.MSISDN, 10)
+		id, err := o.EnqueueSMS(ctx, originator, []string{recipient}, m.Body, withDataCoding(params, m.Length))
+		if err != nil {
+			return ids, fmt.Errorf("contact %s: %w", contactName(m.Contact), err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// withDataCoding returns params with the data coding of length, unless
+// params sets one. The API would otherwise send unicode bodies as plain
+// text.
+func withDataCoding(params *sms.Params, length sms.Length) *sms.Params {
+	if params != nil && params.DataCoding != "" {
+		return params
+	}
+
+	p := &sms.Params{}
+	if params != nil {
+		*p = *params
+	}
+	p.DataCoding = length.DataCoding
+
+	return p
+}
+
+func contactName(c *contact.Contact) string {
+	if c.ID != "" {
+		return c.ID
+	}
+
+	return strconv.FormatInt(c.MSISDN, 10)
+}
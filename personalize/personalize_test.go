@@ -0,0 +1,107 @@
+package personalize
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/outbox"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+var contacts = []contact.Contact{
+	{ID: "c1", MSISDN: 31612345678, FirstName: "Anna", CustomDetails: contact.CustomDetails{Custom1: "1234"}},
+	{ID: "c2", MSISDN: 31687654321, FirstName: "Łukasz", LastName: "Nowak", CustomDetails: contact.CustomDetails{Custom1: "5678"}},
+}
+
+func TestRender(t *testing.T) {
+	tmpl, err := Parse("Hi {{.FirstName}}, your code is {{.Custom1}}.")
+	assert.NoError(t, err)
+
+	body, length, err := tmpl.Render(Data(&contacts[0]))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hi Anna, your code is 1234.", body)
+	assert.Equal(t, sms.Length{DataCoding: sms.DataCodingPlain, Characters: 27, Parts: 1}, length)
+
+	_, _, err = tmpl.Render(Data(&contacts[1]))
+	assert.True(t, errors.Is(err, ErrUnicode))
+
+	tmpl.AllowUnicode = true
+	body, length, err = tmpl.Render(Data(&contacts[1]))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hi Łukasz, your code is 5678.", body)
+	assert.Equal(t, sms.DataCodingUnicode, length.DataCoding)
+
+	_, _, err = tmpl.Render(map[string]interface{}{"FirstName": "Anna"})
+	assert.Error(t, err)
+
+	_, err = Parse("Hi {{.FirstName")
+	assert.Error(t, err)
+}
+
+func TestRenderContactsTooLong(t *testing.T) {
+	tmpl, err := Parse("Dear {{.Name}}, " + strings.Repeat("x", 150))
+	assert.NoError(t, err)
+	tmpl.MaxParts = 2
+	tmpl.AllowUnicode = true
+
+	_, err = tmpl.RenderContacts(contacts)
+	assert.True(t, errors.Is(err, ErrTooLong))
+	assert.EqualError(t, err, "contact c2: message too long: 3 parts, at most 2 allowed")
+
+	tmpl.MaxParts = 3
+	messages, err := tmpl.RenderContacts(contacts)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, 2, messages[0].Length.Parts)
+}
+
+func TestSend(t *testing.T) {
+	server := messagebirdtest.NewServer(t)
+	tmpl, err := Parse("Hi {{.FirstName}}, your code is {{.Custom1}}.")
+	assert.NoError(t, err)
+	tmpl.AllowUnicode = true
+
+	messages, err := tmpl.Send(server.Client(), "MessageBird", contacts, &sms.Params{Reference: "campaign"})
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+
+	requests := server.Requests()
+	if assert.Len(t, requests, 2) {
+		var body struct {
+			Body       string
+			Recipients []string
+			Datacoding string
+			Reference  string
+		}
+		assert.NoError(t, json.Unmarshal(requests[1].Body, &body))
+		assert.Equal(t, "Hi Łukasz, your code is 5678.", body.Body)
+		assert.Equal(t, []string{"31687654321"}, body.Recipients)
+		assert.Equal(t, "unicode", body.Datacoding)
+		assert.Equal(t, "campaign", body.Reference)
+	}
+	server.AssertEndpointCalled(http.MethodPost, "/messages")
+}
+
+func TestEnqueue(t *testing.T) {
+	store := outbox.NewMemoryStore()
+	o := &outbox.Outbox{Client: messagebirdtest.NewServer(t).Client(), Store: store}
+
+	tmpl, err := Parse("Hi {{.FirstName}}!")
+	assert.NoError(t, err)
+
+	_, err = tmpl.Enqueue(context.Background(), o, "MessageBird", contacts, nil)
+	assert.True(t, errors.Is(err, ErrUnicode))
+	assert.Equal(t, 0, store.Len())
+
+	ids, err := tmpl.Enqueue(context.Background(), o, "MessageBird", contacts[:1], nil)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 1)
+	assert.Equal(t, 1, store.Len())
+}
@@ -0,0 +1,182 @@
+package messagebird
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPingInterval is the interval of a Pinger without Interval. It is
+// shorter than the time net/http keeps idle connections open by default.
+const DefaultPingInterval = 30 * time.Second
+
+// PingPath is the path of the REST API a Pinger without URLs requests. It is
+// cheap to serve and requires the access key, so it also checks the
+// credentials are accepted.
+const PingPath = "balance"
+
+// Pinger periodically requests the MessageBird API in the background. This
+// keeps connections of the client's HTTP client open, so latency-sensitive
+// requests do not pay for a new TLS handshake after being idle, and tracks
+// whether the API is reachable:
+//
+//	p := &messagebird.Pinger{Client: client, OnChange: func(healthy bool, err error) {
+//		log.Printf("MessageBird API healthy: %v (%v)", healthy, err)
+//	}}
+//	go p.Run(ctx)
+//	client.Health = p
+//
+// The fields must not be changed once Run is called.
+type Pinger struct {
+	Client *DefaultClient
+
+	// Interval is the time between pings. DefaultPingInterval is used if it
+	// is zero or negative.
+	Interval time.Duration
+
+	// URLs are requested on every ping, e.g. to warm connections to both
+	// the REST and the Conversations API. If it is empty, PingPath is
+	// requested on the REST API, or on the BaseURL of the client if set.
+	URLs []string
+
+	// OnChange is called when the API becomes healthy or unhealthy, and
+	// after the first ping. err is the reason the API is unhealthy.
+	OnChange func(healthy bool, err error)
+
+	mu      sync.Mutex
+	pinged  bool
+	healthy bool
+	err     error
+}
+
+// Run pings every Interval, starting straight away. It blocks until ctx is
+// done and returns ctx.Err().
+func (p *Pinger) Run(ctx context.Context) error {
+	clock := ClockOf(p.Client)
+
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultPingInterval
+	}
+
+	for {
+		_ = p.Ping(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
+}
+
+// Ping requests all URLs once and updates the health. Call it before the
+// first request to warm up the connections. The API is healthy if every URL
+// responds, even with an error, except a server error: an incorrect access
+// key still means the API is reachable.
+func (p *Pinger) Ping(ctx context.Context) error {
+	urls := p.URLs
+	if len(urls) == 0 {
+		urls = []string{p.defaultURL()}
+	}
+
+	var err error
+	for _, u := range urls {
+		if err = p.ping(ctx, u); err != nil {
+			break
+		}
+	}
+
+	p.update(err)
+	return err
+}
+
+// defaultURL returns the URL of PingPath on the API the client sends
+// requests to.
+func (p *Pinger) defaultURL() string {
+	root := Endpoint
+	if p.Client.BaseURL != "" {
+		root = strings.TrimSuffix(p.Client.BaseURL, "/")
+	}
+
+	return root + "/" + PingPath
+}
+
+func (p *Pinger) ping(ctx context.Context, url string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", "AccessKey "+p.Client.AccessKey)
+	request.Header.Set("User-Agent", "MessageBird/ApiClient/"+ClientVersion+" Go/"+runtime.Version())
+
+	response, err := p.Client.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	// The body must be read completely for the connection to be reused.
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("ping %s: %s", url, response.Status)
+	}
+
+	return nil
+}
+
+func (p *Pinger) update(err error) {
+	healthy := err == nil
+
+	p.mu.Lock()
+	changed := !p.pinged || healthy != p.healthy
+	p.pinged, p.healthy, p.err = true, healthy, err
+	p.mu.Unlock()
+
+	if changed && p.OnChange != nil {
+		p.OnChange(healthy, err)
+	}
+}
+
+// HealthChecker tracks whether the MessageBird API is healthy, e.g. a Pinger,
+// or a status.Monitor, which also follows the status page of MessageBird.
+type HealthChecker interface {
+	Healthy() bool
+}
+
+// Healthy reports whether the API is healthy according to c.Health, so
+// deployments can stop sending traffic, or alert, when it is not. It is true
+// if c.Health is nil.
+func (c *DefaultClient) Healthy() bool {
+	if c.Health == nil {
+		return true
+	}
+
+	return c.Health.Healthy()
+}
+
+// Healthy reports whether the last ping succeeded. It is false until the
+// first ping completes.
+func (p *Pinger) Healthy() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.healthy
+}
+
+// Err returns the reason the last ping failed, or nil.
+func (p *Pinger) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.err
+}
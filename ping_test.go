@@ -0,0 +1,118 @@
+package messagebird_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type healthChange struct {
+	healthy bool
+	err     error
+}
+
+func TestPinger(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.FailOnCall(2, messagebirdtest.FailWithServerError())
+	s.FailOnCall(3, messagebirdtest.FailWithTimeout())
+
+	clock := messagebirdtest.NewFakeClock(time.Now())
+	client := s.Client()
+	client.Clock = clock
+
+	var mu sync.Mutex
+	var changes []healthChange
+	p := &messagebird.Pinger{
+		Client:   client,
+		Interval: time.Minute,
+		OnChange: func(healthy bool, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, healthChange{healthy, err})
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx) }()
+
+	// Healthy, unhealthy twice, healthy again.
+	for i := 0; i < 3; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}
+	clock.BlockUntil(1)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	assert.Len(t, s.Requests(), 4)
+	s.AssertEndpointCalled(http.MethodGet, "/balance")
+	assert.Equal(t, "AccessKey test_accesskey", s.LastRequest().Header.Get("Authorization"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, changes, 3) {
+		assert.True(t, changes[0].healthy)
+		assert.False(t, changes[1].healthy)
+		assert.EqualError(t, changes[1].err, "ping https://rest.messagebird.com/balance: 500 Internal Server Error")
+		assert.True(t, changes[2].healthy)
+	}
+	assert.True(t, p.Healthy())
+	assert.NoError(t, p.Err())
+}
+
+func TestPingerPing(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnAccessKeyError()
+
+	p := &messagebird.Pinger{
+		Client: s.Client(),
+		URLs:   []string{"https://rest.messagebird.com/balance", "https://conversations.messagebird.com/v1/conversations"},
+	}
+	assert.False(t, p.Healthy())
+
+	// An API error still means the API is reachable.
+	assert.NoError(t, p.Ping(context.Background()))
+	assert.True(t, p.Healthy())
+
+	requests := s.Requests()
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "rest.messagebird.com", requests[0].Host)
+		assert.Equal(t, "conversations.messagebird.com", requests[1].Host)
+	}
+}
+
+func TestPingerBaseURL(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+
+	client := s.Client()
+	client.BaseURL = "https://proxy.example.com/messagebird/"
+
+	p := &messagebird.Pinger{Client: client}
+	assert.NoError(t, p.Ping(context.Background()))
+
+	if assert.NotNil(t, s.LastRequest()) {
+		assert.Equal(t, "proxy.example.com", s.LastRequest().Host)
+		assert.Equal(t, "/messagebird/balance", s.LastRequest().URL.Path)
+	}
+}
+
+func TestClientHealthy(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnStatus(http.StatusServiceUnavailable)
+
+	client := s.Client()
+	assert.True(t, client.Healthy(), "healthy without a HealthChecker")
+
+	p := &messagebird.Pinger{Client: client}
+	client.Health = p
+	assert.Error(t, p.Ping(context.Background()))
+	assert.False(t, client.Healthy())
+}
@@ -0,0 +1,221 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// ErrBudgetExceeded is matched by errors returned when a request was not
+// sent because the budget of a Budget is spent.
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
+// Period is the period a budget applies to. Periods start at midnight UTC.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodMonth Period = "month"
+)
+
+// key returns the key of the period t is in, e.g. "2024-05-31" for a day and
+// "2024-05" for a month.
+func (p Period) key(t time.Time) string {
+	if p == PeriodMonth {
+		return t.UTC().Format("2006-01")
+	}
+
+	return t.UTC().Format("2006-01-02")
+}
+
+// BudgetStore keeps the spend per period for a Budget, so it can be shared by
+// processes and survive restarts. Implementations must be safe for concurrent
+// use.
+type BudgetStore interface {
+	// Spent returns the spend of the period with key.
+	Spent(key string) (float64, error)
+
+	// Add adds amount, which may be negative, to the spend of the period
+	// with key, and returns the new spend. It must be atomic, e.g. INCRBYFLOAT
+	// in Redis.
+	Add(key string, amount float64) (float64, error)
+}
+
+// MemoryBudgetStore is a BudgetStore that keeps the spend in memory. It is
+// lost when the process exits.
+type MemoryBudgetStore struct {
+	mu    sync.Mutex
+	spent map[string]float64
+}
+
+// NewMemoryBudgetStore creates an empty MemoryBudgetStore.
+func NewMemoryBudgetStore() *MemoryBudgetStore {
+	return &MemoryBudgetStore{spent: make(map[string]float64)}
+}
+
+// Spent implements BudgetStore.
+func (s *MemoryBudgetStore) Spent(key string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.spent[key], nil
+}
+
+// Add implements BudgetStore.
+func (s *MemoryBudgetStore) Add(key string, amount float64) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spent[key] += amount
+	return s.spent[key], nil
+}
+
+// BudgetError is returned when a request was not sent because the budget is
+// spent, or would be by the message. Use errors.Is(err, ErrBudgetExceeded) to
+// check for it.
+type BudgetError struct {
+	// Period is the key of the period, e.g. "2024-05" for a month.
+	Period string
+
+	Spent float64
+	Cap   float64
+
+	// Estimate is the estimated cost of the refused message, or nil if the
+	// request was refused because the budget is spent.
+	Estimate *Estimate
+}
+
+// Error implements the error interface.
+func (e *BudgetError) Error() string {
+	if e.Estimate != nil {
+		return fmt.Sprintf("%s: message estimated at %.4f %s, %.4f of %.4f spent in %s",
+			ErrBudgetExceeded, e.Estimate.Total, e.Estimate.Currency, e.Spent, e.Cap, e.Period)
+	}
+
+	return fmt.Sprintf("%s: %.4f of %.4f spent in %s", ErrBudgetExceeded, e.Spent, e.Cap, e.Period)
+}
+
+// Is reports whether target is ErrBudgetExceeded.
+func (e *BudgetError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// Budget is a messagebird.Client that caps the spend per day or month. It
+// records the estimated cost of every SMS and voice message it sends, and
+// refuses messages that would exceed the cap with a *BudgetError. Once the
+// budget is spent, all mutating requests, i.e. all but GET, HEAD and OPTIONS
+// requests, are refused until the next period starts. Other requests are
+// passed on unchanged.
+//
+//	budget := pricing.NewBudget(messagebird.New(accessKey), pricing.PeriodDay, 100, nil)
+//	_, err := sms.Send(budget, req)
+//	if errors.Is(err, pricing.ErrBudgetExceeded) {
+//		// The message was not sent.
+//	}
+//
+// The cost of a message is reserved before it is sent, so concurrent
+// requests can not exceed the cap together. Messages of which the cost can
+// not be estimated are refused, see LimitingClient.
+type Budget struct {
+	// Rates holds the rates messages are estimated with. DefaultRates is
+	// used if it is nil.
+	Rates *Rates
+
+	// Actual, if set, returns the actual cost of a sent message from the
+	// response v of the request to path, e.g. "messages". The estimate is
+	// recorded if it returns false.
+	Actual func(path string, v interface{}) (float64, bool)
+
+	client messagebird.Client
+	period Period
+	limit  float64
+	store  BudgetStore
+}
+
+// NewBudget creates a Budget that caps the spend of requests sent with c per
+// period at limit, in the currency of the rates. If store is nil, the spend is
+// kept in memory.
+func NewBudget(c messagebird.Client, period Period, limit float64, store BudgetStore) *Budget {
+	if store == nil {
+		store = NewMemoryBudgetStore()
+	}
+
+	return &Budget{client: c, period: period, limit: limit, store: store}
+}
+
+// Spent returns the spend of the current period.
+func (b *Budget) Spent() (float64, error) {
+	return b.store.Spent(b.key())
+}
+
+// Record adds amount to the spend of the current period, e.g. the cost of
+// calls, which the Budget can not estimate, or a correction once the actual
+// cost of messages is known.
+func (b *Budget) Record(amount float64) error {
+	_, err := b.store.Add(b.key(), amount)
+	return err
+}
+
+func (b *Budget) key() string {
+	return b.period.key(messagebird.ClockOf(b.client).Now())
+}
+
+// Request implements messagebird.Client.
+func (b *Budget) Request(v interface{}, method, path string, data interface{}) error {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return b.client.Request(v, method, path, data)
+	}
+
+	key := b.key()
+
+	product, ok := sendProducts[path]
+	if method != http.MethodPost || !ok || data == nil {
+		spent, err := b.store.Spent(key)
+		if err != nil {
+			return err
+		}
+		if spent >= b.limit {
+			return &BudgetError{Period: key, Spent: spent, Cap: b.limit}
+		}
+
+		return b.client.Request(v, method, path, data)
+	}
+
+	estimate, err := estimateRequest(b.Rates, product, data)
+	if err != nil {
+		return err
+	}
+
+	spent, err := b.store.Add(key, estimate.Total)
+	if err != nil {
+		return err
+	}
+	if spent > b.limit {
+		b.store.Add(key, -estimate.Total)
+		return &BudgetError{Period: key, Spent: spent - estimate.Total, Cap: b.limit, Estimate: estimate}
+	}
+
+	if err := b.client.Request(v, method, path, data); err != nil {
+		b.store.Add(key, -estimate.Total)
+		return err
+	}
+
+	// The message was sent, so errors correcting the spend are not returned.
+	if b.Actual != nil {
+		if actual, ok := b.Actual(path, v); ok {
+			b.store.Add(key, actual-estimate.Total)
+		}
+	}
+
+	return nil
+}
+
+// Unwrap implements messagebird.Wrapper.
+func (b *Budget) Unwrap() messagebird.Client {
+	return b.client
+}
@@ -0,0 +1,92 @@
+package pricing
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	mbtest.WillReturn([]byte(`{}`), http.StatusCreated)
+	client := mbtest.Client(t)
+	clock := messagebirdtest.NewFakeClock(time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC))
+	client.Clock = clock
+
+	budget := NewBudget(client, PeriodDay, 1, nil)
+	budget.Rates = NewRates(&Rate{Country: DefaultCountry, Product: ProductSMS, Price: 0.4, Currency: "EUR"})
+
+	recipients := []string{"31612345678", "31612345679"}
+
+	_, err := sms.Create(budget, "MessageBird", recipients, "Hi", nil)
+	assert.NoError(t, err)
+
+	spent, err := budget.Spent()
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.8, spent, 1e-9)
+
+	_, err = sms.Create(budget, "MessageBird", recipients, "Hi", nil)
+	var budgetErr *BudgetError
+	if assert.True(t, errors.As(err, &budgetErr)) {
+		assert.True(t, errors.Is(err, ErrBudgetExceeded))
+		assert.Equal(t, "2024-05-31", budgetErr.Period)
+		assert.InDelta(t, 0.8, budgetErr.Spent, 1e-9)
+		assert.EqualError(t, err, "budget exceeded: message estimated at 0.8000 EUR, 0.8000 of 1.0000 spent in 2024-05-31")
+	}
+
+	// Mutating requests are sent until the budget is spent.
+	assert.NoError(t, contact.Delete(budget, "contact-id"))
+	assert.NoError(t, budget.Record(0.2))
+	assert.True(t, errors.Is(contact.Delete(budget, "contact-id"), ErrBudgetExceeded))
+
+	mbtest.WillReturn([]byte(`{"id": "contact-id"}`), http.StatusOK)
+	_, err = contact.Read(budget, "contact-id", nil)
+	assert.NoError(t, err)
+
+	clock.Advance(time.Hour)
+	mbtest.WillReturn([]byte(`{}`), http.StatusCreated)
+	_, err = sms.Create(budget, "MessageBird", recipients, "Hi", nil)
+	assert.NoError(t, err)
+}
+
+func TestBudgetMonth(t *testing.T) {
+	mbtest.WillReturn([]byte(`{}`), http.StatusCreated)
+	client := mbtest.Client(t)
+	client.Clock = messagebirdtest.NewFakeClock(time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC))
+
+	store := NewMemoryBudgetStore()
+	store.Add("2024-05", 9)
+
+	budget := NewBudget(client, PeriodMonth, 10, store)
+	budget.Rates = NewRates(&Rate{Country: DefaultCountry, Product: ProductSMS, Price: 0.5, Currency: "EUR"})
+	budget.Actual = func(path string, v interface{}) (float64, bool) {
+		return 0.25, path == "messages"
+	}
+
+	_, err := sms.Create(budget, "MessageBird", []string{"31612345678"}, "Hi", nil)
+	assert.NoError(t, err)
+
+	spent, _ := store.Spent("2024-05")
+	assert.InDelta(t, 9.25, spent, 1e-9)
+}
+
+func TestBudgetFailedRequest(t *testing.T) {
+	mbtest.WillReturnAccessKeyError()
+	client := mbtest.Client(t)
+
+	budget := NewBudget(client, PeriodDay, 1, nil)
+	budget.Rates = NewRates(&Rate{Country: DefaultCountry, Product: ProductSMS, Price: 0.5, Currency: "EUR"})
+
+	_, err := sms.Create(budget, "MessageBird", []string{"31612345678"}, "Hi", nil)
+	assert.Error(t, err)
+
+	spent, err := budget.Spent()
+	assert.NoError(t, err)
+	assert.Zero(t, spent)
+}
@@ -0,0 +1,128 @@
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// ErrCostLimit is matched by errors returned when a message was not sent
+// because its estimated cost exceeds the limit of a LimitingClient.
+var ErrCostLimit = errors.New("cost limit exceeded")
+
+// LimitError is returned when a message was not sent because its estimated
+// cost exceeds the limit. Use errors.Is(err, ErrCostLimit) to check for it.
+type LimitError struct {
+	Estimate *Estimate
+	MaxCost  float64
+}
+
+// Error implements the error interface.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: estimated %.4f %s for %d recipients, limit is %.4f %s",
+		ErrCostLimit, e.Estimate.Total, e.Estimate.Currency, len(e.Estimate.Recipients), e.MaxCost, e.Estimate.Currency)
+}
+
+// Is reports whether target is ErrCostLimit.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrCostLimit
+}
+
+// sendProducts are the products of the resources that send messages, by
+// their path relative to messagebird.Endpoint.
+var sendProducts = map[string]Product{
+	"messages":      ProductSMS,
+	"voicemessages": ProductVoice,
+}
+
+// LimitingClient is a messagebird.Client that estimates the cost of SMS and
+// voice messages before they are sent, and refuses messages estimated to
+// cost more than MaxCost with a *LimitError. All other requests are passed
+// to Client unchanged.
+//
+// Messages of which the cost can not be estimated are refused too, with an
+// error wrapping ErrUnknownCost. This includes messages to groups, of which
+// the number of recipients is unknown.
+type LimitingClient struct {
+	Client messagebird.Client
+
+	// Rates holds the rates messages are estimated with. DefaultRates is
+	// used if it is nil.
+	Rates *Rates
+
+	// MaxCost is the maximum estimated cost of a message, in the currency
+	// of the rates.
+	MaxCost float64
+}
+
+// Request implements messagebird.Client.
+func (lc *LimitingClient) Request(v interface{}, method, path string, data interface{}) error {
+	product, ok := sendProducts[path]
+	if method != http.MethodPost || !ok || data == nil {
+		return lc.Client.Request(v, method, path, data)
+	}
+
+	estimate, err := estimateRequest(lc.Rates, product, data)
+	if err != nil {
+		return err
+	}
+	if estimate.Total > lc.MaxCost {
+		return &LimitError{Estimate: estimate, MaxCost: lc.MaxCost}
+	}
+
+	return lc.Client.Request(v, method, path, data)
+}
+
+// Unwrap implements messagebird.Wrapper.
+func (lc *LimitingClient) Unwrap() messagebird.Client {
+	return lc.Client
+}
+
+// estimateRequest estimates the cost of the message in data with rates, or
+// DefaultRates if it is nil. The message types use different request types,
+// so the request is converted to JSON first.
+func estimateRequest(rates *Rates, product Product, data interface{}) (*Estimate, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		Recipients json.RawMessage `json:"recipients"`
+		GroupIDs   []string        `json:"groupIds"`
+		Body       string          `json:"body"`
+		DataCoding string          `json:"datacoding"`
+		Repeat     int             `json:"repeat"`
+	}
+	if err := json.Unmarshal(b, &req); err != nil {
+		return nil, err
+	}
+
+	if len(req.GroupIDs) > 0 {
+		return nil, fmt.Errorf("%w: messages to groups can not be estimated", ErrUnknownCost)
+	}
+
+	// Recipients are sent as a list, or as a comma separated string.
+	var recipients []string
+	if err := json.Unmarshal(req.Recipients, &recipients); err != nil {
+		var joined string
+		if err := json.Unmarshal(req.Recipients, &joined); err != nil {
+			return nil, fmt.Errorf("%w: invalid recipients", ErrUnknownCost)
+		}
+		recipients = strings.Split(joined, ",")
+	}
+
+	if rates == nil {
+		rates = DefaultRates()
+	}
+
+	if product == ProductVoice {
+		return rates.EstimateVoice(recipients, req.Body, req.Repeat)
+	}
+
+	return rates.estimate(ProductSMS, recipients, smsParts(req.Body, req.DataCoding))
+}
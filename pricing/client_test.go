@@ -0,0 +1,81 @@
+package pricing
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/voicemessage"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingClient struct {
+	paths []string
+}
+
+func (c *recordingClient) Request(v interface{}, method, path string, data interface{}) error {
+	c.paths = append(c.paths, method+" "+path)
+	return nil
+}
+
+func TestLimitingClient(t *testing.T) {
+	next := &recordingClient{}
+	client := &LimitingClient{
+		Client:  next,
+		Rates:   NewRates(&Rate{Country: DefaultCountry, Product: ProductSMS, Price: 0.1, Currency: "EUR"}, &Rate{Country: DefaultCountry, Product: ProductVoice, Price: 0.5, Currency: "EUR"}),
+		MaxCost: 1,
+	}
+
+	recipients := make([]string, 10)
+	for i := range recipients {
+		recipients[i] = "3161234567" + string(rune('0'+i))
+	}
+
+	_, err := sms.Create(client, "MessageBird", recipients, "Hi", nil)
+	assert.NoError(t, err)
+
+	_, err = sms.Create(client, "MessageBird", recipients, strings.Repeat("a", 161), nil)
+	var limitErr *LimitError
+	if assert.True(t, errors.As(err, &limitErr)) {
+		assert.True(t, errors.Is(err, ErrCostLimit))
+		assert.InDelta(t, 2, limitErr.Estimate.Total, 1e-9)
+		assert.EqualError(t, err, "cost limit exceeded: estimated 2.0000 EUR for 10 recipients, limit is 1.0000 EUR")
+	}
+
+	_, err = sms.Send(client, &sms.SendRequest{Originator: "MessageBird", Recipients: recipients[:1], GroupIds: []string{"group-id"}, Body: "Hi"})
+	assert.True(t, errors.Is(err, ErrUnknownCost))
+
+	_, err = voicemessage.Create(client, recipients[:2], "Hello", &voicemessage.Params{Repeat: 2})
+	assert.NoError(t, err)
+
+	_, err = voicemessage.Create(client, recipients[:3], "Hello", nil)
+	assert.True(t, errors.Is(err, ErrCostLimit))
+
+	_, err = sms.Read(client, "message-id")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		http.MethodPost + " messages",
+		http.MethodPost + " voicemessages",
+		http.MethodGet + " messages/message-id",
+	}, next.paths)
+}
+
+func TestLimitingClientUnwrap(t *testing.T) {
+	c := messagebird.New("test_accesskey")
+	c.Clock = messagebirdtest.NewFakeClock(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+	c.APIVersions = map[string]string{"conversations": "v2"}
+
+	for _, client := range []messagebird.Client{
+		&LimitingClient{Client: c},
+		NewBudget(c, PeriodDay, 10, nil),
+	} {
+		assert.Equal(t, c.Clock, messagebird.ClockOf(client))
+		assert.Equal(t, "v2", messagebird.APIVersionOf(client, "conversations", "v1"))
+	}
+}
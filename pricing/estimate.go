@@ -0,0 +1,108 @@
+package pricing
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+// WordsPerMinute is the speed at which the body of voice messages is assumed
+// to be read.
+const WordsPerMinute = 150
+
+// Estimate is the estimated cost of a message.
+type Estimate struct {
+	Product Product
+
+	// Units is the number of units every recipient is charged for: message
+	// parts for ProductSMS, and minutes for ProductVoice.
+	Units int
+
+	// Recipients are the estimated costs per recipient.
+	Recipients []*RecipientCost
+
+	// Total is the estimated cost of the message, in Currency.
+	Total    float64
+	Currency string
+}
+
+// RecipientCost is the estimated cost of sending a message to a recipient.
+type RecipientCost struct {
+	Recipient string
+
+	// Country is the country of the recipient, or empty if it is unknown,
+	// in which case the rate of DefaultCountry is used.
+	Country string
+
+	Price float64
+}
+
+// EstimateSMS estimates the cost of sending body to recipients as SMS, with
+// DataCodingAuto.
+func (t *Rates) EstimateSMS(recipients []string, body string) (*Estimate, error) {
+	return t.estimate(ProductSMS, recipients, sms.LengthOf(body).Parts)
+}
+
+// EstimateVoice estimates the cost of sending body to recipients as voice
+// message, read repeat times, see VoiceMinutes.
+func (t *Rates) EstimateVoice(recipients []string, body string, repeat int) (*Estimate, error) {
+	return t.estimate(ProductVoice, recipients, VoiceMinutes(body, repeat))
+}
+
+// VoiceMinutes returns the number of minutes a voice message with body,
+// read repeat times, is charged for, assuming it is read at WordsPerMinute.
+// Calls are charged per started minute, so it is at least one.
+func VoiceMinutes(body string, repeat int) int {
+	if repeat < 1 {
+		repeat = 1
+	}
+
+	words := len(strings.Fields(body)) * repeat
+	if words == 0 {
+		return 1
+	}
+
+	return (words + WordsPerMinute - 1) / WordsPerMinute
+}
+
+func (t *Rates) estimate(product Product, recipients []string, units int) (*Estimate, error) {
+	e := &Estimate{Product: product, Units: units}
+
+	for _, recipient := range recipients {
+		country, _ := numberutil.Country(recipient)
+
+		rate, ok := t.Rate(product, country)
+		if !ok {
+			return nil, fmt.Errorf("%w: no %s rate for %s", ErrUnknownCost, product, recipient)
+		}
+
+		if e.Currency == "" {
+			e.Currency = rate.Currency
+		} else if rate.Currency != e.Currency {
+			return nil, fmt.Errorf("%w: rates in %s and %s can not be added", ErrUnknownCost, e.Currency, rate.Currency)
+		}
+
+		cost := &RecipientCost{Recipient: recipient, Country: country, Price: rate.Price * float64(units)}
+		e.Recipients = append(e.Recipients, cost)
+		e.Total += cost.Price
+	}
+
+	return e, nil
+}
+
+// smsParts returns the number of parts body is sent in with dataCoding.
+func smsParts(body, dataCoding string) int {
+	if dataCoding != sms.DataCodingUnicode {
+		return sms.LengthOf(body).Parts
+	}
+
+	units := len(utf16.Encode([]rune(body)))
+	if units <= 70 {
+		return 1
+	}
+
+	return (units + 66) / 67
+}
@@ -0,0 +1,199 @@
+// Package pricing estimates the cost of messages before they are sent, and
+// refuses messages that would cost more than a limit:
+//
+//	client := &pricing.LimitingClient{Client: messagebird.New(accessKey), MaxCost: 50}
+//	_, err := sms.Send(client, req)
+//	if errors.Is(err, pricing.ErrCostLimit) {
+//		// The message was not sent.
+//	}
+//
+// A Budget caps the spend per day or month instead.
+//
+// Estimates use the rates of a Rates table. DefaultRates has indicative
+// rates of a number of countries, which differ from the rates of your
+// account: fetch these with FetchSMS, or set your own.
+package pricing
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// ErrUnknownCost is returned, wrapped, for messages of which the cost can not
+// be estimated, e.g. because there is no rate for a recipient.
+var ErrUnknownCost = errors.New("cost unknown")
+
+// Product is a product that is charged for.
+type Product string
+
+const (
+	// ProductSMS is charged per message part.
+	ProductSMS Product = "sms"
+
+	// ProductVoice is charged per started minute of a call, including
+	// voice messages.
+	ProductVoice Product = "voice"
+)
+
+// DefaultCountry is the country of the rates that apply to countries
+// without a rate of their own.
+const DefaultCountry = "XX"
+
+// smsPricingPath is the path of the SMS pricing resource.
+const smsPricingPath = "pricing/sms/outbound"
+
+//go:embed rates.json
+var defaultRates []byte
+
+// Rate is the price of a product in a country.
+type Rate struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country, e.g. "NL", or
+	// DefaultCountry.
+	Country string  `json:"country"`
+	Product Product `json:"product"`
+
+	// Price is the price of a unit of the product, see Product, in
+	// Currency, e.g. "EUR".
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency"`
+}
+
+// Rates holds the rates per product and country. It is safe for concurrent
+// use.
+type Rates struct {
+	mu    sync.RWMutex
+	rates map[rateKey]*Rate
+}
+
+type rateKey struct {
+	product Product
+	country string
+}
+
+var (
+	defaultTable     *Rates
+	defaultTableOnce sync.Once
+)
+
+// DefaultRates returns the rates embedded in this package. Rates set on it
+// apply to all estimates without a Rates table.
+func DefaultRates() *Rates {
+	defaultTableOnce.Do(func() {
+		t, err := ParseRates(bytes.NewReader(defaultRates))
+		if err != nil {
+			panic(fmt.Sprintf("pricing: invalid embedded rates: %v", err))
+		}
+		defaultTable = t
+	})
+
+	return defaultTable
+}
+
+// NewRates returns a table with rates.
+func NewRates(rates ...*Rate) *Rates {
+	t := &Rates{rates: make(map[rateKey]*Rate, len(rates))}
+	for _, rate := range rates {
+		t.Set(rate)
+	}
+
+	return t
+}
+
+// ParseRates reads a table from a JSON array of rates, as in rates.json.
+func ParseRates(r io.Reader) (*Rates, error) {
+	var rates []*Rate
+	if err := json.NewDecoder(r).Decode(&rates); err != nil {
+		return nil, err
+	}
+
+	for i, rate := range rates {
+		switch {
+		case rate.Country == "":
+			return nil, fmt.Errorf("rate %d: country is required", i)
+		case rate.Product == "":
+			return nil, fmt.Errorf("rate %d: product is required", i)
+		case rate.Currency == "":
+			return nil, fmt.Errorf("rate %d: currency is required", i)
+		}
+	}
+
+	return NewRates(rates...), nil
+}
+
+// Set adds rate to the table, replacing the rate of its product and country.
+func (t *Rates) Set(rate *Rate) {
+	copied := *rate
+	copied.Country = strings.ToUpper(rate.Country)
+	copied.Currency = strings.ToUpper(rate.Currency)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rates[rateKey{copied.Product, copied.Country}] = &copied
+}
+
+// Rate returns the rate of product in country, or the rate of product in
+// DefaultCountry if country has none. It returns false if neither has a
+// rate.
+func (t *Rates) Rate(product Product, country string) (*Rate, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rate, ok := t.rates[rateKey{product, strings.ToUpper(country)}]
+	if !ok {
+		rate, ok = t.rates[rateKey{product, DefaultCountry}]
+	}
+	if !ok {
+		return nil, false
+	}
+
+	copied := *rate
+	return &copied, true
+}
+
+// smsPricing is the response of the SMS pricing resource.
+type smsPricing struct {
+	Prices []struct {
+		Price          float64
+		CurrencyCode   string
+		CountryIsoCode string
+	}
+}
+
+// FetchSMS sets the SMS rates of the account of c in the table. The API has
+// rates per operator: the highest rate of a country is used, so estimates do
+// not depend on the operator of recipients.
+func (t *Rates) FetchSMS(c messagebird.Client) error {
+	pricing := &smsPricing{}
+	if err := c.Request(pricing, http.MethodGet, smsPricingPath, nil); err != nil {
+		return err
+	}
+
+	highest := make(map[string]*Rate)
+	for _, p := range pricing.Prices {
+		country := strings.ToUpper(p.CountryIsoCode)
+		if country == "" {
+			continue
+		}
+
+		if rate, ok := highest[country]; ok && rate.Price >= p.Price {
+			continue
+		}
+		highest[country] = &Rate{Country: country, Product: ProductSMS, Price: p.Price, Currency: p.CurrencyCode}
+	}
+
+	for _, rate := range highest {
+		t.Set(rate)
+	}
+
+	return nil
+}
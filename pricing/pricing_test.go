@@ -0,0 +1,100 @@
+package pricing
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestDefaultRates(t *testing.T) {
+	rate, ok := DefaultRates().Rate(ProductSMS, "nl")
+	assert.True(t, ok)
+	assert.Equal(t, &Rate{Country: "NL", Product: ProductSMS, Price: 0.078, Currency: "EUR"}, rate)
+
+	rate, ok = DefaultRates().Rate(ProductVoice, "ZW")
+	assert.True(t, ok)
+	assert.Equal(t, DefaultCountry, rate.Country)
+}
+
+func TestParseRates(t *testing.T) {
+	rates, err := ParseRates(strings.NewReader(`[{"country": "nl", "product": "sms", "price": 0.05, "currency": "eur"}]`))
+	assert.NoError(t, err)
+
+	rate, ok := rates.Rate(ProductSMS, "NL")
+	assert.True(t, ok)
+	assert.Equal(t, "EUR", rate.Currency)
+
+	_, ok = rates.Rate(ProductVoice, "NL")
+	assert.False(t, ok)
+
+	_, err = ParseRates(strings.NewReader(`[{"country": "NL", "price": 0.05, "currency": "EUR"}]`))
+	assert.EqualError(t, err, "rate 0: product is required")
+}
+
+func TestFetchSMS(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "smsPricing.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	rates := NewRates()
+	assert.NoError(t, rates.FetchSMS(client))
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/pricing/sms/outbound")
+
+	rate, ok := rates.Rate(ProductSMS, "NL")
+	assert.True(t, ok)
+	assert.Equal(t, 0.07, rate.Price)
+
+	rate, ok = rates.Rate(ProductSMS, "BE")
+	assert.True(t, ok)
+	assert.Equal(t, 0.06, rate.Price)
+}
+
+func TestEstimateSMS(t *testing.T) {
+	rates := NewRates(
+		&Rate{Country: "NL", Product: ProductSMS, Price: 0.1, Currency: "EUR"},
+		&Rate{Country: "BE", Product: ProductSMS, Price: 0.2, Currency: "EUR"},
+	)
+
+	estimate, err := rates.EstimateSMS([]string{"31612345678", "+32470123456"}, strings.Repeat("a", 161))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, estimate.Units)
+	assert.Equal(t, "EUR", estimate.Currency)
+	assert.InDelta(t, 0.6, estimate.Total, 1e-9)
+	assert.Equal(t, "BE", estimate.Recipients[1].Country)
+	assert.InDelta(t, 0.4, estimate.Recipients[1].Price, 1e-9)
+
+	_, err = rates.EstimateSMS([]string{"4915112345678"}, "Hi")
+	assert.True(t, errors.Is(err, ErrUnknownCost))
+
+	rates.Set(&Rate{Country: "DE", Product: ProductSMS, Price: 0.1, Currency: "USD"})
+	_, err = rates.EstimateSMS([]string{"31612345678", "4915112345678"}, "Hi")
+	assert.EqualError(t, err, "cost unknown: rates in EUR and USD can not be added")
+}
+
+func TestEstimateVoice(t *testing.T) {
+	rates := NewRates(&Rate{Country: DefaultCountry, Product: ProductVoice, Price: 0.05, Currency: "EUR"})
+
+	estimate, err := rates.EstimateVoice([]string{"31612345678"}, strings.Repeat("word ", 100), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, estimate.Units)
+	assert.InDelta(t, 0.1, estimate.Total, 1e-9)
+}
+
+func TestVoiceMinutes(t *testing.T) {
+	assert.Equal(t, 1, VoiceMinutes("", 0))
+	assert.Equal(t, 1, VoiceMinutes(strings.Repeat("word ", WordsPerMinute), 1))
+	assert.Equal(t, 2, VoiceMinutes(strings.Repeat("word ", WordsPerMinute+1), 1))
+	assert.Equal(t, 3, VoiceMinutes(strings.Repeat("word ", WordsPerMinute), 3))
+}
+
+func TestSMSParts(t *testing.T) {
+	assert.Equal(t, 1, smsParts(strings.Repeat("a", 160), ""))
+	assert.Equal(t, 3, smsParts(strings.Repeat("a", 160), "unicode"))
+}
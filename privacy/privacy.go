@@ -0,0 +1,352 @@
+// Package privacy erases the data of a data subject across MessageBird
+// products, for GDPR erasure requests:
+//
+//	report, err := privacy.Erase(ctx, client, "+31612345678", nil)
+//
+// Erase deletes what the APIs allow to delete and reports what it did, so
+// the report can be kept as a record of the request. Not everything can be
+// deleted through the APIs: conversations are archived, as the Conversations
+// API can not delete them, and sent messages remain in the message logs.
+// Request their deletion from MessageBird support with the report.
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/voice"
+)
+
+// ErrIncomplete is returned, wrapped, by Erase when some data could not be
+// erased. The report lists why.
+var ErrIncomplete = errors.New("erasure incomplete")
+
+// pageSize is the number of items Erase lists per request.
+const pageSize = 100
+
+// EraseOptions configures Erase.
+type EraseOptions struct {
+	// DryRun reports what would be erased without erasing it.
+	DryRun bool
+}
+
+// ErasureReport is the outcome of Erase. It can be encoded as JSON.
+type ErasureReport struct {
+	MSISDN string `json:"msisdn"`
+	DryRun bool   `json:"dryRun"`
+
+	// Contacts are the IDs of the deleted contacts with the MSISDN.
+	Contacts []string `json:"contacts"`
+
+	// Conversations are the IDs of the archived conversations of these
+	// contacts.
+	Conversations []string `json:"conversations"`
+
+	// Recordings are the deleted recordings of calls from or to the MSISDN,
+	// which include voicemails.
+	Recordings []*ErasedRecording `json:"recordings"`
+
+	// ScheduledMessages are the IDs of the deleted SMS messages that were
+	// scheduled to be sent to the MSISDN.
+	ScheduledMessages []string `json:"scheduledMessages"`
+
+	// Errors describe the data that could not be erased.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ErasedRecording is a deleted recording of a call leg.
+type ErasedRecording struct {
+	CallID string `json:"callId"`
+	LegID  string `json:"legId"`
+	ID     string `json:"id"`
+}
+
+func (r *ErasureReport) fail(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// Erase deletes the data of the data subject with msisdn, e.g. 31612345678
+// or +31 6 12345678: the SMS messages scheduled to it, the recordings of
+// calls from or to it, and the contacts with it. The conversations of these
+// contacts are archived. opts may be nil.
+//
+// A failure does not stop the erasure of the other data: if any data could
+// not be erased, an error wrapping ErrIncomplete is returned along with the
+// report. Erase can be run again to retry.
+func Erase(ctx context.Context, c messagebird.Client, msisdn string, opts *EraseOptions) (*ErasureReport, error) {
+	if opts == nil {
+		opts = &EraseOptions{}
+	}
+
+	// MSISDNs are international numbers, whether or not they start with +.
+	normalized, err := numberutil.MSISDN(msisdn, "")
+	if err != nil {
+		return nil, err
+	}
+
+	e := &eraser{c: c, msisdn: normalized, dryRun: opts.DryRun}
+	e.report = &ErasureReport{MSISDN: normalized, DryRun: opts.DryRun}
+
+	// Contacts go last, as their conversations are found through them.
+	for _, step := range []func(context.Context){e.scheduledMessages, e.recordings, e.contacts} {
+		if err := ctx.Err(); err != nil {
+			return e.report, err
+		}
+		step(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return e.report, err
+	}
+
+	if len(e.report.Errors) > 0 {
+		return e.report, fmt.Errorf("%w: %d errors", ErrIncomplete, len(e.report.Errors))
+	}
+
+	return e.report, nil
+}
+
+type eraser struct {
+	c      messagebird.Client
+	msisdn string
+	dryRun bool
+	report *ErasureReport
+}
+
+// scheduledMessages deletes the SMS messages scheduled to the MSISDN. Messages
+// to other recipients as well are not deleted, as that would cancel them for
+// everyone.
+func (e *eraser) scheduledMessages(ctx context.Context) {
+	var scheduled []sms.Message
+
+	var pager paging.Pager
+	for !pager.Done() && ctx.Err() == nil {
+		pager.Fetch(func(offset int) (int, int, error) {
+			list, err := sms.List(e.c, &sms.ListParams{Status: "scheduled", Limit: pageSize, Offset: offset})
+			if err != nil {
+				return 0, 0, err
+			}
+
+			scheduled = append(scheduled, list.Items...)
+			return len(list.Items), list.TotalCount, nil
+		})
+	}
+	if err := pager.Err(); err != nil {
+		e.report.fail("listing scheduled SMS messages: %v", err)
+		return
+	}
+
+	for _, m := range scheduled {
+		if ctx.Err() != nil {
+			return
+		}
+
+		to := false
+		for _, r := range m.Recipients.Items {
+			if strconv.FormatInt(r.Recipient, 10) == e.msisdn {
+				to = true
+			}
+		}
+		if !to {
+			continue
+		}
+
+		if len(m.Recipients.Items) > 1 {
+			e.report.fail("scheduled SMS message %s has other recipients as well", m.ID)
+			continue
+		}
+
+		if !e.dryRun {
+			if err := sms.Delete(e.c, m.ID); err != nil {
+				e.report.fail("deleting SMS message %s: %v", m.ID, err)
+				continue
+			}
+		}
+		e.report.ScheduledMessages = append(e.report.ScheduledMessages, m.ID)
+	}
+}
+
+// recordings deletes the recordings of the legs of calls from or to the
+// MSISDN. All legs of a call involving it are included, as they record the
+// conversation with it.
+func (e *eraser) recordings(ctx context.Context) {
+	calls := voice.Calls(e.c)
+	for ctx.Err() == nil {
+		page, err := calls.NextPage()
+		if err != nil && err != io.EOF {
+			e.report.fail("listing calls: %v", err)
+			return
+		}
+
+		for _, call := range page.([]voice.Call) {
+			if ctx.Err() != nil {
+				return
+			}
+			e.callRecordings(ctx, &call)
+		}
+
+		if err == io.EOF {
+			return
+		}
+	}
+}
+
+func (e *eraser) callRecordings(ctx context.Context, call *voice.Call) {
+	var legs []voice.Leg
+	if err := allPages(call.Legs(e.c), func(page interface{}) {
+		legs = append(legs, page.([]voice.Leg)...)
+	}); err != nil {
+		e.report.fail("listing legs of call %s: %v", call.ID, err)
+		return
+	}
+
+	involved := e.is(call.Source) || e.is(call.Destination)
+	for _, leg := range legs {
+		involved = involved || e.is(leg.Source) || e.is(leg.Destination)
+	}
+	if !involved {
+		return
+	}
+
+	for _, leg := range legs {
+		var recordings []voice.Recording
+		if err := allPages(leg.Recordings(e.c), func(page interface{}) {
+			recordings = append(recordings, page.([]voice.Recording)...)
+		}); err != nil {
+			e.report.fail("listing recordings of leg %s: %v", leg.ID, err)
+			continue
+		}
+
+		for _, rec := range recordings {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !e.dryRun {
+				if err := voice.Delete(e.c, call.ID, leg.ID, rec.ID); err != nil {
+					e.report.fail("deleting recording %s: %v", rec.ID, err)
+					continue
+				}
+			}
+			e.report.Recordings = append(e.report.Recordings, &ErasedRecording{CallID: call.ID, LegID: leg.ID, ID: rec.ID})
+		}
+	}
+}
+
+// contacts archives the conversations of the contacts with the MSISDN, and
+// deletes the contacts. A contact whose conversations could not be archived
+// is kept, so they are found again when Erase is retried.
+func (e *eraser) contacts(ctx context.Context) {
+	var contacts []contact.Contact
+
+	var pager paging.Pager
+	for !pager.Done() && ctx.Err() == nil {
+		pager.Fetch(func(offset int) (int, int, error) {
+			list, err := contact.Search(e.c, &contact.SearchRequest{MSISDN: e.msisdn, Limit: pageSize, Offset: offset})
+			if err != nil {
+				return 0, 0, err
+			}
+
+			contacts = append(contacts, list.Items...)
+			return len(list.Items), list.TotalCount, nil
+		})
+	}
+	if err := pager.Err(); err != nil {
+		e.report.fail("searching contacts: %v", err)
+		return
+	}
+
+	for _, ct := range contacts {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Only contacts with exactly the MSISDN are erased.
+		if strconv.FormatInt(ct.MSISDN, 10) != e.msisdn {
+			continue
+		}
+
+		if !e.conversations(ctx, ct.ID) {
+			continue
+		}
+
+		if !e.dryRun {
+			if err := contact.Delete(e.c, ct.ID); err != nil {
+				e.report.fail("deleting contact %s: %v", ct.ID, err)
+				continue
+			}
+		}
+		e.report.Contacts = append(e.report.Contacts, ct.ID)
+	}
+}
+
+// conversations archives the conversations of the contact with contactID. It
+// reports whether all were archived.
+func (e *eraser) conversations(ctx context.Context, contactID string) bool {
+	var ids []string
+
+	var pager paging.Pager
+	for !pager.Done() && ctx.Err() == nil {
+		pager.Fetch(func(offset int) (int, int, error) {
+			list, err := conversation.ListByContact(e.c, contactID, &messagebird.PaginationRequest{Limit: pageSize, Offset: offset})
+			if err != nil {
+				return 0, 0, err
+			}
+
+			for _, id := range list.Items {
+				if id != nil {
+					ids = append(ids, *id)
+				}
+			}
+			return len(list.Items), list.TotalCount, nil
+		})
+	}
+	if err := pager.Err(); err != nil {
+		e.report.fail("listing conversations of contact %s: %v", contactID, err)
+		return false
+	}
+
+	ok := true
+	for _, id := range ids {
+		if !e.dryRun {
+			if _, err := conversation.Update(e.c, id, &conversation.UpdateRequest{Status: conversation.ConversationStatusArchived}); err != nil {
+				e.report.fail("archiving conversation %s: %v", id, err)
+				ok = false
+				continue
+			}
+		}
+		e.report.Conversations = append(e.report.Conversations, id)
+	}
+
+	return ok
+}
+
+// is reports whether number, as used by the Voice API, is the MSISDN.
+func (e *eraser) is(number string) bool {
+	return strings.TrimPrefix(number, "+") == e.msisdn
+}
+
+// allPages calls f with every page of pag.
+func allPages(pag *voice.Paginator, f func(page interface{})) error {
+	for {
+		page, err := pag.NextPage()
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		f(page)
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
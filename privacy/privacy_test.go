@@ -0,0 +1,124 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eraseClient serves the data of 31612345678 in every product and records
+// the writes. Writes to the paths in failing fail.
+type eraseClient struct {
+	writes  []string
+	failing map[string]bool
+}
+
+const voicePage = `{"data": [%s], "pagination": {"pageCount": 1}}`
+
+func (c *eraseClient) Request(v interface{}, method, path string, data interface{}) error {
+	respond := func(body string) error {
+		return json.Unmarshal([]byte(body), v)
+	}
+
+	if method != http.MethodGet {
+		c.writes = append(c.writes, method+" "+path)
+		if c.failing[path] {
+			return errors.New("service unavailable")
+		}
+		if v != nil {
+			return respond(`{}`)
+		}
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(path, "messages?"):
+		return respond(`{"totalCount": 3, "items": [
+			{"id": "sms-1", "recipients": {"items": [{"recipient": 31612345678}]}},
+			{"id": "sms-2", "recipients": {"items": [{"recipient": 31612345678}, {"recipient": 31687654321}]}},
+			{"id": "sms-3", "recipients": {"items": [{"recipient": 31687654321}]}}
+		]}`)
+	case strings.HasSuffix(path, "/calls/?page=1"):
+		return respond(fmt.Sprintf(voicePage, `
+			{"id": "call-1", "source": "+31612345678", "destination": "3197000000", "createdAt": "2026-01-01T00:00:00Z", "updatedAt": "2026-01-01T00:00:00Z"},
+			{"id": "call-2", "source": "31687654321", "destination": "3197000000", "createdAt": "2026-01-01T00:00:00Z", "updatedAt": "2026-01-01T00:00:00Z"},
+			{"id": "call-3", "source": "31687654321", "destination": "3197000000", "createdAt": "2026-01-01T00:00:00Z", "updatedAt": "2026-01-01T00:00:00Z"}`))
+	case strings.Contains(path, "/legs?page=1"):
+		callID := strings.Split(path, "/")[5]
+		destination := "3197000000"
+		if callID == "call-3" {
+			// Transferred to the data subject.
+			destination = "31612345678"
+		}
+		return respond(fmt.Sprintf(voicePage, fmt.Sprintf(`{"id": "leg-%s", "callId": %q, "destination": %q, "createdAt": "2026-01-01T00:00:00Z", "updatedAt": "2026-01-01T00:00:00Z"}`, callID, callID, destination)))
+	case strings.Contains(path, "/recordings?page=1"):
+		callID := strings.Split(path, "/")[5]
+		return respond(fmt.Sprintf(voicePage, fmt.Sprintf(`{"id": "rec-%s", "createdAt": "2026-01-01T00:00:00Z", "updatedAt": "2026-01-01T00:00:00Z"}`, callID)))
+	case strings.HasSuffix(path, "?page=2"):
+		return respond(fmt.Sprintf(voicePage, ""))
+	case strings.HasPrefix(path, "contacts?"):
+		return respond(`{"totalCount": 2, "items": [{"id": "contact-1", "msisdn": 31612345678}, {"id": "contact-2", "msisdn": 316123456789}]}`)
+	case strings.Contains(path, "/conversations/contact/contact-1?"):
+		return respond(`{"totalCount": 2, "items": ["conv-1", "conv-2"]}`)
+	}
+
+	return fmt.Errorf("unexpected %s %s", method, path)
+}
+
+func TestErase(t *testing.T) {
+	client := &eraseClient{}
+
+	report, err := Erase(context.Background(), client, "+31 6 12345678", nil)
+	assert.True(t, errors.Is(err, ErrIncomplete))
+
+	assert.Equal(t, "31612345678", report.MSISDN)
+	assert.Equal(t, []string{"sms-1"}, report.ScheduledMessages)
+	assert.Equal(t, []*ErasedRecording{
+		{CallID: "call-1", LegID: "leg-call-1", ID: "rec-call-1"},
+		{CallID: "call-3", LegID: "leg-call-3", ID: "rec-call-3"},
+	}, report.Recordings)
+	assert.Equal(t, []string{"conv-1", "conv-2"}, report.Conversations)
+	assert.Equal(t, []string{"contact-1"}, report.Contacts)
+	assert.Equal(t, []string{"scheduled SMS message sms-2 has other recipients as well"}, report.Errors)
+
+	assert.Equal(t, []string{
+		"DELETE messages/sms-1",
+		"DELETE https://voice.messagebird.com/v1/calls/call-1/legs/leg-call-1/recordings/rec-call-1",
+		"DELETE https://voice.messagebird.com/v1/calls/call-3/legs/leg-call-3/recordings/rec-call-3",
+		"PATCH https://conversations.messagebird.com/v1/conversations/conv-1",
+		"PATCH https://conversations.messagebird.com/v1/conversations/conv-2",
+		"DELETE contacts/contact-1",
+	}, client.writes)
+}
+
+func TestEraseKeepsContactOfFailedConversation(t *testing.T) {
+	client := &eraseClient{failing: map[string]bool{
+		"https://conversations.messagebird.com/v1/conversations/conv-1": true,
+	}}
+
+	report, err := Erase(context.Background(), client, "31612345678", nil)
+	assert.True(t, errors.Is(err, ErrIncomplete))
+	assert.Equal(t, []string{"conv-2"}, report.Conversations)
+	assert.Empty(t, report.Contacts)
+	assert.Contains(t, report.Errors, "archiving conversation conv-1: service unavailable")
+}
+
+func TestEraseDryRun(t *testing.T) {
+	client := &eraseClient{}
+
+	report, err := Erase(context.Background(), client, "31612345678", &EraseOptions{DryRun: true})
+	assert.True(t, errors.Is(err, ErrIncomplete))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []string{"contact-1"}, report.Contacts)
+	assert.Len(t, report.Recordings, 2)
+	assert.Empty(t, client.writes)
+
+	_, err = Erase(context.Background(), client, "bogus", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,342 @@
+// Package quiethours keeps marketing traffic out of the hours in which
+// recipients may not be contacted, in the recipient's own time zone. Several
+// countries only allow marketing messages within certain hours and on
+// certain days; messages that would be sent outside of them are scheduled
+// for the start of the next allowed window instead:
+//
+//	s := &quiethours.Scheduler{
+//		Client: client,
+//		Policy: quiethours.Policy{Windows: []quiethours.Window{quiethours.MustParseWindow("08:00-21:00")}},
+//		Countries: map[string]quiethours.Policy{
+//			"FR": {
+//				Windows: []quiethours.Window{quiethours.MustParseWindow("10:00-13:00"), quiethours.MustParseWindow("14:00-20:00")},
+//				Days:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+//			},
+//		},
+//	}
+//
+//	msg, err := s.SendSMS(&sms.SendRequest{...}, quiethours.Recipient{Country: "FR"})
+//
+// The SMS and voice messaging APIs schedule messages themselves, through
+// their scheduledDatetime. The Conversations API can not, so conversation
+// messages are held in an outbox.Outbox until they may be sent.
+//
+// This package does not know the rules of any country: check with your own
+// legal advice which windows apply to your traffic.
+package quiethours
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/outbox"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/voicemessage"
+)
+
+var (
+	// ErrUnknownCountry is returned, wrapped, for recipients in a country
+	// of which the time zones are not known. Set Recipient.Location for
+	// them.
+	ErrUnknownCountry = errors.New("unknown time zones for country")
+
+	// ErrNoWindow is returned, wrapped, when no time in the coming week is
+	// allowed in all time zones of a recipient.
+	ErrNoWindow = errors.New("no allowed send window")
+)
+
+// Window is a daily period in local time in which messages may be sent,
+// from Start up to End, both the time since midnight. End must be after
+// Start; a window that crosses midnight is written as two windows.
+type Window struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseWindow parses a window written as "HH:MM-HH:MM", e.g. "08:00-21:00".
+// An end of "24:00" is midnight at the end of the day.
+func ParseWindow(s string) (Window, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return Window{}, fmt.Errorf("invalid window %q", s)
+	}
+
+	var w Window
+	for i, part := range parts {
+		var hours, minutes int
+		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%d:%d", &hours, &minutes); err != nil || minutes < 0 || minutes > 59 {
+			return Window{}, fmt.Errorf("invalid window %q", s)
+		}
+
+		d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+		if i == 0 {
+			w.Start = d
+		} else {
+			w.End = d
+		}
+	}
+
+	if err := w.validate(); err != nil {
+		return Window{}, fmt.Errorf("invalid window %q: %w", s, err)
+	}
+
+	return w, nil
+}
+
+// MustParseWindow is like ParseWindow but panics if s can not be parsed.
+func MustParseWindow(s string) Window {
+	w, err := ParseWindow(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return w
+}
+
+func (w Window) validate() error {
+	if w.Start < 0 || w.End > 24*time.Hour || w.End <= w.Start {
+		return errors.New("end must be after start, within a day")
+	}
+
+	return nil
+}
+
+func (w Window) String() string {
+	return clock(w.Start) + "-" + clock(w.End)
+}
+
+func clock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Policy is when messages may be sent to recipients, in their local time.
+type Policy struct {
+	// Windows are the periods of the day in which messages may be sent.
+	// Messages may be sent all day if there are none.
+	Windows []Window
+
+	// Days are the days of the week on which messages may be sent. Messages
+	// may be sent every day if there are none.
+	Days []time.Weekday
+}
+
+// Next returns the earliest time at or after t at which p allows messages in
+// loc. It returns false if p allows no time in the week after t.
+func (p *Policy) Next(t time.Time, loc *time.Location) (time.Time, bool) {
+	windows := append([]Window(nil), p.Windows...)
+	if len(windows) == 0 {
+		windows = []Window{{Start: 0, End: 24 * time.Hour}}
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].Start < windows[j].Start })
+
+	local := t.In(loc)
+	for day := 0; day <= 7; day++ {
+		date := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, 0, loc)
+		if !p.allowsDay(date.Weekday()) {
+			continue
+		}
+
+		for _, w := range windows {
+			if w.validate() != nil {
+				continue
+			}
+
+			start, end := at(date, w.Start), at(date, w.End)
+			if !t.Before(end) {
+				continue
+			}
+			if t.Before(start) {
+				return start, true
+			}
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (p *Policy) allowsDay(day time.Weekday) bool {
+	if len(p.Days) == 0 {
+		return true
+	}
+
+	for _, d := range p.Days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// at returns the time of day d on date, by the clock of its location, so
+// days with a daylight saving time change are handled.
+func at(date time.Time, d time.Duration) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), int(d/time.Hour), int(d%time.Hour/time.Minute), 0, 0, date.Location())
+}
+
+// Recipient is where the recipient of a message is.
+type Recipient struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country of the
+	// recipient, e.g. NL. For countries that span several time zones,
+	// messages are only sent at times allowed in all of them.
+	Country string
+
+	// Location is the time zone of the recipient. It takes precedence over
+	// Country.
+	Location *time.Location
+}
+
+func (r Recipient) String() string {
+	if r.Location != nil {
+		return r.Location.String()
+	}
+
+	return r.Country
+}
+
+func (r Recipient) locations() ([]*time.Location, error) {
+	if r.Location != nil {
+		return []*time.Location{r.Location}, nil
+	}
+
+	zones, ok := countryZones[strings.ToUpper(r.Country)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCountry, r.Country)
+	}
+
+	locations := make([]*time.Location, 0, len(zones))
+	for _, zone := range zones {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, loc)
+	}
+
+	return locations, nil
+}
+
+// Scheduler sends messages within the windows allowed for their recipients.
+type Scheduler struct {
+	Client messagebird.Client
+
+	// Policy applies to recipients in countries without one in Countries.
+	Policy Policy
+
+	// Countries are the policies per ISO 3166-1 alpha-2 country code.
+	Countries map[string]Policy
+}
+
+// Next returns the earliest time at or after t at which messages may be sent
+// to to.
+func (s *Scheduler) Next(t time.Time, to Recipient) (time.Time, error) {
+	locations, err := to.locations()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	policy := s.policy(to.Country)
+
+	// Move to the next allowed time of every zone in turn, until a time is
+	// allowed in all of them.
+	for i := 0; i < 8*len(locations); i++ {
+		moved := false
+		for _, loc := range locations {
+			next, ok := policy.Next(t, loc)
+			if !ok {
+				return time.Time{}, fmt.Errorf("%w for %s", ErrNoWindow, to)
+			}
+			if !next.Equal(t) {
+				t, moved = next, true
+			}
+		}
+
+		if !moved {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w for %s", ErrNoWindow, to)
+}
+
+func (s *Scheduler) policy(country string) *Policy {
+	if p, ok := s.Countries[strings.ToUpper(country)]; ok {
+		return &p
+	}
+
+	return &s.Policy
+}
+
+// sendAt returns the time a message scheduled at scheduled, or sent now if it
+// is zero, may be sent to to. It returns the zero time if that is now.
+func (s *Scheduler) sendAt(scheduled time.Time, to Recipient) (time.Time, error) {
+	t := scheduled
+	if t.IsZero() {
+		t = messagebird.ClockOf(s.Client).Now()
+	}
+
+	next, err := s.Next(t, to)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if next.Equal(t) {
+		return scheduled, nil
+	}
+
+	return next, nil
+}
+
+// SendSMS sends req with sms.Send, scheduled for the next time allowed for
+// to if it is not allowed now or at its ScheduledDatetime. All recipients of
+// req must be in the same place. req is not modified.
+func (s *Scheduler) SendSMS(req *sms.SendRequest, to Recipient) (*sms.Message, error) {
+	if req == nil {
+		return sms.Send(s.Client, req)
+	}
+
+	scheduled, err := s.sendAt(req.ScheduledDatetime, to)
+	if err != nil {
+		return nil, err
+	}
+
+	shifted := *req
+	shifted.ScheduledDatetime = scheduled
+
+	return sms.Send(s.Client, &shifted)
+}
+
+// SendVoiceMessage sends req with voicemessage.Send, scheduled like SendSMS.
+func (s *Scheduler) SendVoiceMessage(req *voicemessage.SendRequest, to Recipient) (*voicemessage.VoiceMessage, error) {
+	if req == nil {
+		return voicemessage.Send(s.Client, req)
+	}
+
+	scheduled, err := s.sendAt(req.ScheduledDatetime, to)
+	if err != nil {
+		return nil, err
+	}
+
+	shifted := *req
+	shifted.ScheduledDatetime = scheduled
+
+	return voicemessage.Send(s.Client, &shifted)
+}
+
+// EnqueueConversation queues req in o, to be sent at the next time allowed
+// for to. It returns the ID of the entry.
+func (s *Scheduler) EnqueueConversation(ctx context.Context, o *outbox.Outbox, req *conversation.SendMessageRequest, to Recipient) (string, error) {
+	at, err := s.sendAt(time.Time{}, to)
+	if err != nil {
+		return "", err
+	}
+
+	return o.EnqueueConversationAt(ctx, req, at)
+}
@@ -0,0 +1,164 @@
+package quiethours
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/outbox"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/voicemessage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParseWindow(t *testing.T) {
+	w, err := ParseWindow("08:00-21:30")
+	assert.NoError(t, err)
+	assert.Equal(t, Window{Start: 8 * time.Hour, End: 21*time.Hour + 30*time.Minute}, w)
+	assert.Equal(t, "08:00-21:30", w.String())
+
+	w, err = ParseWindow("20:00-24:00")
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, w.End)
+
+	for _, s := range []string{"", "08:00", "21:00-08:00", "08:00-25:00", "8-21", "08:60-21:00"} {
+		_, err := ParseWindow(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPolicyNext(t *testing.T) {
+	amsterdam := mustLoad(t, "Europe/Amsterdam")
+	p := &Policy{
+		Windows: []Window{MustParseWindow("14:00-20:00"), MustParseWindow("10:00-13:00")},
+		Days:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected time.Time
+	}{
+		{"allowed", time.Date(2022, 3, 2, 11, 0, 0, 0, amsterdam), time.Date(2022, 3, 2, 11, 0, 0, 0, amsterdam)},
+		{"early", time.Date(2022, 3, 2, 7, 0, 0, 0, amsterdam), time.Date(2022, 3, 2, 10, 0, 0, 0, amsterdam)},
+		{"between windows", time.Date(2022, 3, 2, 13, 0, 0, 0, amsterdam), time.Date(2022, 3, 2, 14, 0, 0, 0, amsterdam)},
+		{"late", time.Date(2022, 3, 2, 20, 0, 0, 0, amsterdam), time.Date(2022, 3, 3, 10, 0, 0, 0, amsterdam)},
+		{"weekend", time.Date(2022, 3, 4, 22, 0, 0, 0, amsterdam), time.Date(2022, 3, 7, 10, 0, 0, 0, amsterdam)},
+		{"other zone", time.Date(2022, 3, 2, 8, 30, 0, 0, time.UTC), time.Date(2022, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"daylight saving time", time.Date(2022, 3, 25, 21, 0, 0, 0, amsterdam), time.Date(2022, 3, 28, 10, 0, 0, 0, amsterdam)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := p.Next(tt.t, amsterdam)
+			assert.True(t, ok)
+			assert.True(t, tt.expected.Equal(next), "expected %s, got %s", tt.expected, next)
+		})
+	}
+
+	_, ok := (&Policy{Days: []time.Weekday{}, Windows: []Window{{Start: time.Hour, End: 0}}}).Next(time.Now(), amsterdam)
+	assert.False(t, ok)
+}
+
+func TestNextCountry(t *testing.T) {
+	s := &Scheduler{Policy: Policy{Windows: []Window{MustParseWindow("08:00-21:00")}}}
+
+	// 08:00 in New York is 05:00 in Los Angeles, and 02:00 in Honolulu.
+	newYork := mustLoad(t, "America/New_York")
+	next, err := s.Next(time.Date(2022, 1, 4, 8, 0, 0, 0, newYork), Recipient{Country: "us"})
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2022, 1, 4, 8, 0, 0, 0, mustLoad(t, "Pacific/Honolulu")).Equal(next), next)
+
+	next, err = s.Next(time.Date(2022, 1, 4, 8, 0, 0, 0, newYork), Recipient{Country: "US", Location: newYork})
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2022, 1, 4, 8, 0, 0, 0, newYork).Equal(next), next)
+
+	_, err = s.Next(time.Now(), Recipient{Country: "XX"})
+	assert.True(t, errors.Is(err, ErrUnknownCountry))
+
+	s.Countries = map[string]Policy{"NL": {Windows: []Window{{Start: time.Hour, End: 0}}}}
+	_, err = s.Next(time.Now(), Recipient{Country: "NL"})
+	assert.True(t, errors.Is(err, ErrNoWindow))
+	assert.EqualError(t, err, "no allowed send window for NL")
+}
+
+func newTestScheduler(t *testing.T, now time.Time) (*Scheduler, *messagebirdtest.Server) {
+	server := messagebirdtest.NewServer(t)
+	client := server.Client()
+	client.Clock = messagebirdtest.NewFakeClock(now)
+
+	return &Scheduler{
+		Client: client,
+		Policy: Policy{Windows: []Window{MustParseWindow("08:00-21:00")}},
+	}, server
+}
+
+func TestSendSMS(t *testing.T) {
+	s, server := newTestScheduler(t, time.Date(2022, 1, 4, 22, 0, 0, 0, time.UTC))
+	req := &sms.SendRequest{Originator: "MessageBird", Recipients: []string{"31612345678"}, Body: "Sale!"}
+
+	_, err := s.SendSMS(req, Recipient{Country: "NL"})
+	assert.NoError(t, err)
+	server.AssertEndpointCalled(http.MethodPost, "/messages")
+	server.AssertBodyField("scheduledDatetime", "2022-01-05T08:00:00+01:00")
+	assert.True(t, req.ScheduledDatetime.IsZero())
+
+	_, err = s.SendSMS(req, Recipient{Location: time.UTC})
+	assert.NoError(t, err)
+	server.AssertBodyField("scheduledDatetime", "2022-01-05T08:00:00Z")
+
+	req.ScheduledDatetime = time.Date(2022, 1, 5, 12, 0, 0, 0, time.UTC)
+	_, err = s.SendSMS(req, Recipient{Country: "NL"})
+	assert.NoError(t, err)
+	server.AssertBodyField("scheduledDatetime", "2022-01-05T12:00:00Z")
+}
+
+func TestSendSMSAllowedNow(t *testing.T) {
+	s, server := newTestScheduler(t, time.Date(2022, 1, 4, 12, 0, 0, 0, time.UTC))
+
+	_, err := s.SendSMS(&sms.SendRequest{Originator: "MessageBird", Recipients: []string{"31612345678"}, Body: "Sale!"}, Recipient{Country: "NL"})
+	assert.NoError(t, err)
+	server.AssertBodyField("body", "Sale!")
+	assert.NotContains(t, string(server.LastRequest().Body), "scheduledDatetime")
+}
+
+func TestSendVoiceMessage(t *testing.T) {
+	s, server := newTestScheduler(t, time.Date(2022, 1, 4, 22, 0, 0, 0, time.UTC))
+
+	_, err := s.SendVoiceMessage(&voicemessage.SendRequest{Recipients: []string{"31612345678"}, Body: "Sale!"}, Recipient{Country: "NL"})
+	assert.NoError(t, err)
+	server.AssertEndpointCalled(http.MethodPost, "/voicemessages")
+	server.AssertBodyField("scheduledDatetime", "2022-01-05T08:00:00+01:00")
+}
+
+func TestEnqueueConversation(t *testing.T) {
+	s, server := newTestScheduler(t, time.Date(2022, 1, 4, 22, 0, 0, 0, time.UTC))
+	store := outbox.NewMemoryStore()
+	o := &outbox.Outbox{Client: s.Client, Store: store}
+
+	_, err := s.EnqueueConversation(context.Background(), o, &conversation.SendMessageRequest{
+		To:      "+31612345678",
+		From:    "channel-id",
+		Type:    conversation.MessageTypeText,
+		Content: &conversation.MessageContent{Text: "Sale!"},
+	}, Recipient{Country: "NL"})
+	assert.NoError(t, err)
+
+	entries, err := store.Due(context.Background(), time.Date(2022, 1, 5, 7, 0, 0, 0, time.UTC), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, time.Date(2022, 1, 5, 7, 0, 0, 0, time.UTC), entries[0].NextAttempt.UTC())
+	}
+	assert.Empty(t, server.Requests())
+}
@@ -0,0 +1,119 @@
+package quiethours
+
+// countryZones maps ISO 3166-1 alpha-2 country codes to the time zones of
+// the country. Countries that span several time zones list their main zones,
+// from west to east.
+var countryZones = map[string][]string{
+	"AD": {"Europe/Andorra"},
+	"AE": {"Asia/Dubai"},
+	"AF": {"Asia/Kabul"},
+	"AL": {"Europe/Tirane"},
+	"AM": {"Asia/Yerevan"},
+	"AO": {"Africa/Luanda"},
+	"AR": {"America/Argentina/Buenos_Aires"},
+	"AT": {"Europe/Vienna"},
+	"AU": {"Australia/Perth", "Australia/Darwin", "Australia/Adelaide", "Australia/Brisbane", "Australia/Sydney"},
+	"AZ": {"Asia/Baku"},
+	"BA": {"Europe/Sarajevo"},
+	"BD": {"Asia/Dhaka"},
+	"BE": {"Europe/Brussels"},
+	"BG": {"Europe/Sofia"},
+	"BH": {"Asia/Bahrain"},
+	"BO": {"America/La_Paz"},
+	"BR": {"America/Rio_Branco", "America/Manaus", "America/Sao_Paulo"},
+	"BY": {"Europe/Minsk"},
+	"CA": {"America/Vancouver", "America/Edmonton", "America/Regina", "America/Winnipeg", "America/Toronto", "America/Halifax", "America/St_Johns"},
+	"CH": {"Europe/Zurich"},
+	"CL": {"America/Santiago"},
+	"CN": {"Asia/Shanghai"},
+	"CO": {"America/Bogota"},
+	"CR": {"America/Costa_Rica"},
+	"CY": {"Asia/Nicosia"},
+	"CZ": {"Europe/Prague"},
+	"DE": {"Europe/Berlin"},
+	"DK": {"Europe/Copenhagen"},
+	"DO": {"America/Santo_Domingo"},
+	"DZ": {"Africa/Algiers"},
+	"EC": {"America/Guayaquil"},
+	"EE": {"Europe/Tallinn"},
+	"EG": {"Africa/Cairo"},
+	"ES": {"Atlantic/Canary", "Europe/Madrid"},
+	"ET": {"Africa/Addis_Ababa"},
+	"FI": {"Europe/Helsinki"},
+	"FR": {"Europe/Paris"},
+	"GB": {"Europe/London"},
+	"GE": {"Asia/Tbilisi"},
+	"GH": {"Africa/Accra"},
+	"GR": {"Europe/Athens"},
+	"GT": {"America/Guatemala"},
+	"HK": {"Asia/Hong_Kong"},
+	"HR": {"Europe/Zagreb"},
+	"HU": {"Europe/Budapest"},
+	"ID": {"Asia/Jakarta", "Asia/Makassar", "Asia/Jayapura"},
+	"IE": {"Europe/Dublin"},
+	"IL": {"Asia/Jerusalem"},
+	"IN": {"Asia/Kolkata"},
+	"IQ": {"Asia/Baghdad"},
+	"IR": {"Asia/Tehran"},
+	"IS": {"Atlantic/Reykjavik"},
+	"IT": {"Europe/Rome"},
+	"JM": {"America/Jamaica"},
+	"JO": {"Asia/Amman"},
+	"JP": {"Asia/Tokyo"},
+	"KE": {"Africa/Nairobi"},
+	"KR": {"Asia/Seoul"},
+	"KW": {"Asia/Kuwait"},
+	"KZ": {"Asia/Aqtau", "Asia/Almaty"},
+	"LB": {"Asia/Beirut"},
+	"LI": {"Europe/Vaduz"},
+	"LK": {"Asia/Colombo"},
+	"LT": {"Europe/Vilnius"},
+	"LU": {"Europe/Luxembourg"},
+	"LV": {"Europe/Riga"},
+	"MA": {"Africa/Casablanca"},
+	"MC": {"Europe/Monaco"},
+	"MD": {"Europe/Chisinau"},
+	"ME": {"Europe/Podgorica"},
+	"MK": {"Europe/Skopje"},
+	"MT": {"Europe/Malta"},
+	"MX": {"America/Tijuana", "America/Hermosillo", "America/Mexico_City", "America/Cancun"},
+	"MY": {"Asia/Kuala_Lumpur"},
+	"NG": {"Africa/Lagos"},
+	"NL": {"Europe/Amsterdam"},
+	"NO": {"Europe/Oslo"},
+	"NZ": {"Pacific/Auckland"},
+	"OM": {"Asia/Muscat"},
+	"PA": {"America/Panama"},
+	"PE": {"America/Lima"},
+	"PH": {"Asia/Manila"},
+	"PK": {"Asia/Karachi"},
+	"PL": {"Europe/Warsaw"},
+	"PR": {"America/Puerto_Rico"},
+	"PT": {"Atlantic/Azores", "Europe/Lisbon"},
+	"PY": {"America/Asuncion"},
+	"QA": {"Asia/Qatar"},
+	"RO": {"Europe/Bucharest"},
+	"RS": {"Europe/Belgrade"},
+	"RU": {"Europe/Kaliningrad", "Europe/Moscow", "Europe/Samara", "Asia/Yekaterinburg", "Asia/Omsk", "Asia/Novosibirsk", "Asia/Krasnoyarsk", "Asia/Irkutsk", "Asia/Yakutsk", "Asia/Vladivostok", "Asia/Magadan", "Asia/Kamchatka"},
+	"SA": {"Asia/Riyadh"},
+	"SE": {"Europe/Stockholm"},
+	"SG": {"Asia/Singapore"},
+	"SI": {"Europe/Ljubljana"},
+	"SK": {"Europe/Bratislava"},
+	"SN": {"Africa/Dakar"},
+	"TH": {"Asia/Bangkok"},
+	"TN": {"Africa/Tunis"},
+	"TR": {"Europe/Istanbul"},
+	"TT": {"America/Port_of_Spain"},
+	"TW": {"Asia/Taipei"},
+	"TZ": {"Africa/Dar_es_Salaam"},
+	"UA": {"Europe/Kiev"},
+	"UG": {"Africa/Kampala"},
+	"US": {"Pacific/Honolulu", "America/Anchorage", "America/Los_Angeles", "America/Phoenix", "America/Denver", "America/Chicago", "America/New_York"},
+	"UY": {"America/Montevideo"},
+	"UZ": {"Asia/Tashkent"},
+	"VE": {"America/Caracas"},
+	"VN": {"Asia/Ho_Chi_Minh"},
+	"ZA": {"Africa/Johannesburg"},
+	"ZW": {"Africa/Harare"},
+}
@@ -0,0 +1,207 @@
+package messagebird
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RedactionPolicy decides which personal data is masked in logs. Set
+// DefaultClient.Redaction to redact the output of DebugLog.
+type RedactionPolicy struct {
+	// MSISDNs masks numbers of 8 digits or more after their first 4
+	// digits, e.g. 3161*******.
+	MSISDNs bool
+
+	// Emails masks the local part of e-mail addresses after its first
+	// character, e.g. j***@example.com.
+	Emails bool
+
+	// Tokens masks the values of the JSON fields and query parameters that
+	// hold verification tokens and passwords entirely.
+	Tokens bool
+
+	// Bodies masks the values of the JSON fields that hold message
+	// contents, such as body and text, entirely.
+	Bodies bool
+
+	// Fields are the names of other JSON fields and query parameters of
+	// which the values are masked entirely.
+	Fields []string
+}
+
+// DefaultRedaction masks all personal data the SDK knows of.
+var DefaultRedaction = &RedactionPolicy{MSISDNs: true, Emails: true, Tokens: true, Bodies: true}
+
+// Names of the fields masked by RedactionPolicy.Tokens and Bodies.
+var (
+	tokenFields = []string{"token", "password", "otp", "code"}
+	bodyFields  = []string{"body", "text", "html", "caption"}
+)
+
+// redacted replaces masked values entirely.
+const redacted = "[redacted]"
+
+var emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// Text masks the MSISDNs and e-mail addresses in s.
+func (p *RedactionPolicy) Text(s string) string {
+	if p.Emails {
+		s = emailPattern.ReplaceAllString(s, "$1***@$2")
+	}
+	if p.MSISDNs {
+		s = maskNumbers(s)
+	}
+
+	return s
+}
+
+// JSON masks the personal data in the JSON document b: the values of the
+// masked fields, and the MSISDNs and e-mail addresses in all other values.
+// Documents that are not valid JSON are masked like Text. Object keys are
+// sorted in the result.
+func (p *RedactionPolicy) JSON(b []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil || dec.More() {
+		return []byte(p.Text(string(b)))
+	}
+
+	out, err := json.Marshal(p.value("", doc))
+	if err != nil {
+		return []byte(p.Text(string(b)))
+	}
+
+	return out
+}
+
+// Form masks the personal data in the URL encoded form s, like JSON.
+func (p *RedactionPolicy) Form(s string) string {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return p.Text(s)
+	}
+
+	for name, vs := range values {
+		for i, v := range vs {
+			vs[i] = p.field(name, v)
+		}
+		values[name] = vs
+	}
+
+	return values.Encode()
+}
+
+// URL masks the personal data in the path and query string of u.
+func (p *RedactionPolicy) URL(u string) string {
+	i := strings.IndexByte(u, '?')
+	if i < 0 {
+		return p.Text(u)
+	}
+
+	return p.Text(u[:i]) + "?" + p.Form(u[i+1:])
+}
+
+// value returns v, the value of the field with name, with its personal data
+// masked.
+func (p *RedactionPolicy) value(name string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = p.value(key, value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = p.value(name, value)
+		}
+		return v
+	case string:
+		return p.field(name, v)
+	case json.Number:
+		if p.MSISDNs && len(v.String()) >= 8 && strings.Trim(v.String(), "0123456789") == "" {
+			return maskNumbers(v.String())
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// field returns the string value of the field with name, masked.
+func (p *RedactionPolicy) field(name, value string) string {
+	if value != "" && p.masks(name) {
+		return redacted
+	}
+
+	return p.Text(value)
+}
+
+// masks reports whether the values of the field with name are masked
+// entirely.
+func (p *RedactionPolicy) masks(name string) bool {
+	name = strings.TrimSuffix(name, "[]")
+
+	return (p.Tokens && containsFold(tokenFields, name)) ||
+		(p.Bodies && containsFold(bodyFields, name)) ||
+		containsFold(p.Fields, name)
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maskNumbers masks numbers of 8 digits or more in s after their first 4
+// digits.
+func maskNumbers(s string) string {
+	b := []byte(s)
+	for i := 0; i < len(b); {
+		j := i
+		for j < len(b) && b[j] >= '0' && b[j] <= '9' {
+			j++
+		}
+
+		if j-i >= 8 {
+			for k := i + 4; k < j; k++ {
+				b[k] = '*'
+			}
+		}
+		if j == i {
+			j++
+		}
+		i = j
+	}
+
+	return string(b)
+}
+
+// redactURL returns u masked with c.Redaction, if set.
+func (c *DefaultClient) redactURL(u string) string {
+	if c.Redaction == nil {
+		return u
+	}
+
+	return c.Redaction.URL(u)
+}
+
+// redactBody returns body, of type ct, masked with c.Redaction, if set.
+func (c *DefaultClient) redactBody(body []byte, ct contentType) []byte {
+	switch {
+	case c.Redaction == nil:
+		return body
+	case ct == contentTypeFormURLEncoded:
+		return []byte(c.Redaction.Form(string(body)))
+	default:
+		return c.Redaction.JSON(body)
+	}
+}
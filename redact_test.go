@@ -0,0 +1,56 @@
+package messagebird
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactionPolicyText(t *testing.T) {
+	assert.Equal(t, "Call 3161******* or mail j***@example.com", DefaultRedaction.Text("Call 31612345678 or mail jane.doe@example.com"))
+	assert.Equal(t, "Call 31612345678 or mail j***@example.com", (&RedactionPolicy{Emails: true}).Text("Call 31612345678 or mail jane.doe@example.com"))
+}
+
+func TestRedactionPolicyJSON(t *testing.T) {
+	body := []byte(`{"originator":"MessageBird","body":"Your code is 123456","recipients":[31612345678],"content":{"text":"Hi"},"email":"jane@example.com","token":"123456","errors":[{"code":9}]}`)
+
+	assert.JSONEq(t, `{
+		"originator": "MessageBird",
+		"body": "[redacted]",
+		"recipients": ["3161*******"],
+		"content": {"text": "[redacted]"},
+		"email": "j***@example.com",
+		"token": "[redacted]",
+		"errors": [{"code": 9}]
+	}`, string(DefaultRedaction.JSON(body)))
+
+	p := &RedactionPolicy{Fields: []string{"originator"}}
+	assert.JSONEq(t, `{"originator": "[redacted]", "body": "Hi"}`, string(p.JSON([]byte(`{"originator":"MessageBird","body":"Hi"}`))))
+
+	assert.Equal(t, "not JSON: 3161*******", string(DefaultRedaction.JSON([]byte("not JSON: 31612345678"))))
+}
+
+func TestRedactionPolicyURL(t *testing.T) {
+	assert.Equal(t, "https://rest.messagebird.com/verify/id?token=%5Bredacted%5D", DefaultRedaction.URL("https://rest.messagebird.com/verify/id?token=123456"))
+	assert.Equal(t, "https://rest.messagebird.com/lookup/3161*******", DefaultRedaction.URL("https://rest.messagebird.com/lookup/31612345678"))
+	assert.Equal(t, "ids%5B%5D=a&msisdn=3161%2A%2A%2A%2A%2A%2A%2A", DefaultRedaction.Form("ids[]=a&msisdn=31612345678"))
+}
+
+func TestRequestDebugLogRedaction(t *testing.T) {
+	c := newTestClient(http.StatusOK, `{"id":"id","recipients":{"items":[{"recipient":31612345678}]},"body":"Hello Jane"}`)
+	c.Redaction = DefaultRedaction
+
+	var logged bytes.Buffer
+	c.DebugLog = log.New(&logged, "", 0)
+
+	data := map[string]interface{}{"body": "Hello Jane", "recipients": []string{"31612345678"}}
+	assert.NoError(t, c.Request(nil, http.MethodPost, "messages", data))
+
+	assert.NotContains(t, logged.String(), "31612345678")
+	assert.NotContains(t, logged.String(), "Hello Jane")
+	assert.Contains(t, logged.String(), `HTTP REQUEST: POST https://rest.messagebird.com/messages {"body":"[redacted]","recipients":["3161*******"]}`)
+	assert.Contains(t, logged.String(), `HTTP RESPONSE: {"body":"[redacted]","id":"id","recipients":{"items":[{"recipient":"3161*******"}]}}`)
+}
@@ -0,0 +1,195 @@
+// Package reporting reads aggregate statistics of messages and calls from the
+// MessageBird Reporting API, to build spend and deliverability dashboards:
+//
+//	report, err := reporting.Read(client, reporting.ProductSMS, &reporting.Request{
+//		Start:       time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+//		End:         time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+//		PeriodGroup: reporting.PeriodGroupDay,
+//		GroupBy:     []string{reporting.GroupByCountry, reporting.GroupByStatus},
+//	})
+package reporting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+// apiRoot is the absolute URL of the Reporting API.
+const apiRoot = "https://reporting.messagebird.com"
+
+// Product is the product a report covers.
+type Product string
+
+const (
+	ProductSMS   Product = "sms"
+	ProductVoice Product = "voice"
+)
+
+// Periods the items of a report can be grouped by.
+const (
+	PeriodGroupNone  = "none"
+	PeriodGroupDay   = "day"
+	PeriodGroupWeek  = "week"
+	PeriodGroupMonth = "month"
+)
+
+// Fields the items of a report can be grouped by.
+const (
+	GroupByOriginator = "originator"
+	GroupByCountry    = "country"
+	GroupByStatus     = "status"
+	GroupByDirection  = "direction"
+	GroupByNumber     = "number"
+)
+
+// Request selects the messages or calls a report covers and how they are
+// grouped. Start and End are required.
+type Request struct {
+	// Start is the inclusive start and End the exclusive end of the
+	// period the report covers.
+	Start time.Time
+	End   time.Time
+
+	// PeriodGroup splits the period into items per day, week or month.
+	// Defaults to PeriodGroupNone.
+	PeriodGroup string
+
+	// GroupBy splits every period into items per value of the given
+	// fields, e.g. GroupByCountry.
+	GroupBy []string
+
+	// Originator, Country, Status, Direction and Number, if set, only
+	// include matching messages or calls.
+	Originator string
+	Country    string
+	Status     string
+	Direction  string
+	Number     string
+}
+
+// QueryParams returns the query string of the report request.
+func (r *Request) QueryParams() string {
+	if r == nil {
+		return ""
+	}
+
+	query := querystring.New()
+	query.SetTime("periodStart", r.Start.UTC(), time.RFC3339)
+	query.SetTime("periodEnd", r.End.UTC(), time.RFC3339)
+
+	periodGroup := r.PeriodGroup
+	if periodGroup == "" {
+		periodGroup = PeriodGroupNone
+	}
+	query.Set("periodGroup", periodGroup)
+
+	if len(r.GroupBy) > 0 {
+		query.Set("groupBy", strings.Join(r.GroupBy, ","))
+	}
+
+	if r.Originator != "" {
+		query.Set("originator", r.Originator)
+	}
+
+	if r.Country != "" {
+		query.Set("country", r.Country)
+	}
+
+	if r.Status != "" {
+		query.Set("status", r.Status)
+	}
+
+	if r.Direction != "" {
+		query.Set("direction", r.Direction)
+	}
+
+	if r.Number != "" {
+		query.Set("number", r.Number)
+	}
+
+	return query.Encode()
+}
+
+// Report holds the statistics of a product, with an item per period and
+// combination of the fields it is grouped by.
+type Report struct {
+	Items []Item
+}
+
+// Item holds the statistics of a single period and group. Only the fields the
+// report is grouped by are set.
+type Item struct {
+	// PeriodStart is the start of the period of the item, unless the
+	// report is not grouped by period.
+	PeriodStart *time.Time
+
+	Originator string
+	Country    string
+	Status     string
+	Direction  string
+	Number     string
+
+	// Count is the number of messages or calls.
+	Count int
+
+	// Cost is the total price of the messages or calls, in Currency.
+	Cost     float64
+	Currency string
+}
+
+// Read retrieves the report of product selected by req.
+func Read(c messagebird.Client, product Product, req *Request) (*Report, error) {
+	if req == nil {
+		return nil, errors.New("request is required")
+	}
+	if !req.End.After(req.Start) {
+		return nil, errors.New("period end must be after its start")
+	}
+
+	report := &Report{}
+	if err := c.Request(report, http.MethodGet, apiRoot+"/"+string(product)+"?"+req.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Total returns the number of messages or calls in the report.
+func (r *Report) Total() int {
+	total := 0
+	for _, item := range r.Items {
+		total += item.Count
+	}
+
+	return total
+}
+
+// TotalCost returns the total cost of the report, per currency.
+func (r *Report) TotalCost() map[string]float64 {
+	costs := make(map[string]float64)
+	for _, item := range r.Items {
+		if item.Currency != "" {
+			costs[item.Currency] += item.Cost
+		}
+	}
+
+	return costs
+}
+
+// CountBy sums the counts of the items by the value key returns for them,
+// e.g. to count messages per status:
+//
+//	perStatus := report.CountBy(func(item reporting.Item) string { return item.Status })
+func (r *Report) CountBy(key func(Item) string) map[string]int {
+	counts := make(map[string]int)
+	for _, item := range r.Items {
+		counts[key(item)] += item.Count
+	}
+
+	return counts
+}
@@ -0,0 +1,73 @@
+package reporting
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+var (
+	start = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end   = time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "reportObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	report, err := Read(client, ProductSMS, &Request{
+		Start:       start,
+		End:         end,
+		PeriodGroup: PeriodGroupDay,
+		GroupBy:     []string{GroupByCountry, GroupByStatus},
+		Originator:  "MessageBird",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, report.Items, 3)
+	assert.Equal(t, start, *report.Items[0].PeriodStart)
+	assert.Equal(t, "NL", report.Items[0].Country)
+	assert.Equal(t, "delivered", report.Items[0].Status)
+
+	assert.Equal(t, 163, report.Total())
+	assert.InDelta(t, 11.81, report.TotalCost()["EUR"], 0.001)
+	assert.Equal(t, map[string]int{"delivered": 160, "delivery_failed": 3}, report.CountBy(func(item Item) string {
+		return item.Status
+	}))
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/sms")
+	mbtest.AssertQuery(t, url.Values{
+		"periodStart": {"2022-01-01T00:00:00Z"},
+		"periodEnd":   {"2022-02-01T00:00:00Z"},
+		"periodGroup": {"day"},
+		"groupBy":     {"country,status"},
+		"originator":  {"MessageBird"},
+	})
+}
+
+func TestReadDefaultPeriodGroup(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "reportObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := Read(client, ProductVoice, &Request{Start: start, End: end})
+	assert.NoError(t, err)
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/voice")
+	mbtest.AssertQueryParam(t, "periodGroup", "none")
+}
+
+func TestReadInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	_, err := Read(client, ProductSMS, nil)
+	assert.EqualError(t, err, "request is required")
+
+	_, err = Read(client, ProductSMS, &Request{Start: end, End: start})
+	assert.EqualError(t, err, "period end must be after its start")
+}
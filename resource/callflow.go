@@ -0,0 +1,154 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/voice"
+)
+
+// CallFlowSpec declares a call flow of the Voice API. Titles of call flows
+// need not be unique, but CreateCallFlow adopts the first call flow with the
+// title of the spec, so give every declared call flow its own title.
+type CallFlowSpec struct {
+	Title  string
+	Steps  []voice.CallFlowStep
+	Record bool
+}
+
+// CreateCallFlow creates the call flow of spec, or updates the call flow with
+// the same title.
+func CreateCallFlow(c messagebird.Client, spec *CallFlowSpec) (*voice.CallFlow, error) {
+	if spec == nil || spec.Title == "" {
+		return nil, errors.New("title is required")
+	}
+
+	existing, err := findCallFlow(c, spec.Title)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return UpdateCallFlow(c, existing.ID, spec)
+	}
+
+	callFlow := spec.callFlow("")
+	if err := callFlow.Create(c); err != nil {
+		return nil, err
+	}
+
+	return ReadCallFlow(c, callFlow.ID)
+}
+
+// ReadCallFlow reads the call flow with id.
+func ReadCallFlow(c messagebird.Client, id string) (*voice.CallFlow, error) {
+	callFlow, err := voice.CallFlowByID(c, id)
+	if err != nil {
+		return nil, notFound(err, "call flow", id)
+	}
+
+	return callFlow, nil
+}
+
+// UpdateCallFlow replaces the call flow with id by spec.
+func UpdateCallFlow(c messagebird.Client, id string, spec *CallFlowSpec) (*voice.CallFlow, error) {
+	if spec == nil {
+		return nil, errors.New("spec is required")
+	}
+
+	if err := spec.callFlow(id).Update(c); err != nil {
+		return nil, err
+	}
+
+	return ReadCallFlow(c, id)
+}
+
+// DeleteCallFlow deletes the call flow with id.
+func DeleteCallFlow(c messagebird.Client, id string) error {
+	callFlow := &voice.CallFlow{ID: id}
+	return ignoreNotFound(callFlow.Delete(c))
+}
+
+// Drift returns the fields of which callFlow differs from spec. The API
+// assigns IDs to steps, which are ignored for steps of spec without one.
+func (spec *CallFlowSpec) Drift(callFlow *voice.CallFlow) []Drift {
+	var drift []Drift
+	if spec.Title != callFlow.Title {
+		drift = append(drift, Drift{Field: "Title", Want: spec.Title, Got: callFlow.Title})
+	}
+	if !sameSteps(spec.Steps, callFlow.Steps) {
+		drift = append(drift, Drift{Field: "Steps", Want: spec.Steps, Got: callFlow.Steps})
+	}
+	if spec.Record != callFlow.Record {
+		drift = append(drift, Drift{Field: "Record", Want: spec.Record, Got: callFlow.Record})
+	}
+
+	return drift
+}
+
+func (spec *CallFlowSpec) callFlow(id string) *voice.CallFlow {
+	return &voice.CallFlow{ID: id, Title: spec.Title, Steps: spec.Steps, Record: spec.Record}
+}
+
+// findCallFlow returns the first call flow with title, or nil if there is
+// none.
+func findCallFlow(c messagebird.Client, title string) (*voice.CallFlow, error) {
+	pages := voice.CallFlows(c)
+	for {
+		page, err := pages.NextPage()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		for _, callFlow := range page.([]voice.CallFlow) {
+			if callFlow.Title == title {
+				return &callFlow, nil
+			}
+		}
+
+		if err == io.EOF {
+			return nil, nil
+		}
+	}
+}
+
+// sameSteps reports whether got holds the steps of want. Steps are compared
+// by their JSON representation, as they are sent to the API.
+func sameSteps(want, got []voice.CallFlowStep) bool {
+	if len(want) != len(got) {
+		return false
+	}
+
+	for i := range want {
+		w, g := stepFields(want[i]), stepFields(got[i])
+		if w == nil || g == nil {
+			return false
+		}
+		if _, ok := w["id"]; !ok {
+			delete(g, "id")
+		}
+		if !reflect.DeepEqual(w, g) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// stepFields returns the JSON fields of step, or nil if it can not be
+// encoded.
+func stepFields(step voice.CallFlowStep) map[string]interface{} {
+	b, err := json.Marshal(step)
+	if err != nil {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil
+	}
+
+	return fields
+}
@@ -0,0 +1,95 @@
+package resource
+
+import (
+	"errors"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/number"
+)
+
+// NumberSpec declares a purchased phone number. Its ID is Number.
+type NumberSpec struct {
+	// Number is the phone number, e.g. "31612345678".
+	Number string
+
+	// Country and BillingIntervalMonths are used to purchase the number.
+	// They can not be changed afterwards.
+	Country               string
+	BillingIntervalMonths int
+
+	// Tags are the tags of the number. Leave nil to not manage them.
+	Tags []string
+
+	// CallFlowID links the number to a call flow when it is created or
+	// updated. The API does not return the call flow of a number, so it is
+	// not checked for drift.
+	CallFlowID string
+}
+
+// CreateNumber purchases the number of spec, or adopts it if it was already
+// purchased, and applies the tags and call flow of spec.
+func CreateNumber(c messagebird.Client, spec *NumberSpec) (*number.Number, error) {
+	if spec == nil || spec.Number == "" {
+		return nil, errors.New("number is required")
+	}
+
+	_, err := ReadNumber(c, spec.Number)
+	if errors.Is(err, ErrNotFound) {
+		_, err = number.Purchase(c, &number.PurchaseRequest{
+			Number:                spec.Number,
+			Country:               spec.Country,
+			BillingIntervalMonths: spec.BillingIntervalMonths,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return UpdateNumber(c, spec.Number, spec)
+}
+
+// ReadNumber reads the purchased number with id.
+func ReadNumber(c messagebird.Client, id string) (*number.Number, error) {
+	n, err := number.Read(c, id)
+	if err != nil {
+		return nil, notFound(err, "number", id)
+	}
+
+	return n, nil
+}
+
+// UpdateNumber applies the tags and call flow of spec to the number with id.
+func UpdateNumber(c messagebird.Client, id string, spec *NumberSpec) (*number.Number, error) {
+	if spec == nil {
+		return nil, errors.New("spec is required")
+	}
+
+	// Without tags, the update request would remove the current ones.
+	if spec.Tags != nil || spec.CallFlowID != "" {
+		req := &number.UpdateRequest{Tags: spec.Tags, CallFlowID: spec.CallFlowID}
+		if _, err := number.Update(c, id, req); err != nil {
+			return nil, err
+		}
+	}
+
+	return ReadNumber(c, id)
+}
+
+// DeleteNumber cancels the subscription of the number with id. The returned
+// error matches number.ErrActiveSubscriptions if the number is still in use.
+func DeleteNumber(c messagebird.Client, id string) error {
+	return ignoreNotFound(number.Cancel(c, id))
+}
+
+// Drift returns the fields of which n differs from spec.
+func (spec *NumberSpec) Drift(n *number.Number) []Drift {
+	var drift []Drift
+	if spec.Number != n.Number {
+		drift = append(drift, Drift{Field: "Number", Want: spec.Number, Got: n.Number})
+	}
+	if spec.Tags != nil && !sameSet(spec.Tags, n.Tags) {
+		drift = append(drift, Drift{Field: "Tags", Want: spec.Tags, Got: n.Tags})
+	}
+
+	return drift
+}
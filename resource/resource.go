@@ -0,0 +1,137 @@
+// Package resource manages numbers, webhooks and call flows as declared
+// resources, the way infrastructure as code tools such as Terraform do. Every
+// kind of resource has a spec type that declares its desired state, and
+// functions to create, read, update and delete it:
+//
+//	spec := &resource.CallFlowSpec{Title: "Support line", Steps: steps}
+//	flow, err := resource.CreateCallFlow(client, spec)
+//	// Store flow.ID, then later:
+//	flow, err = resource.ReadCallFlow(client, id)
+//	if errors.Is(err, resource.ErrNotFound) {
+//		// The call flow was deleted outside of the tool.
+//	}
+//	if drift := spec.Drift(flow); len(drift) > 0 {
+//		flow, err = resource.UpdateCallFlow(client, id, spec)
+//	}
+//
+// The functions are safe to retry:
+//
+//   - Create adopts an existing resource with the same natural key, e.g. the
+//     URL of a webhook, instead of creating a duplicate, so a create that
+//     timed out can be repeated.
+//   - Create and Update read the resource after writing it, and return what
+//     the API stored, so computed fields are known.
+//   - Read returns an error matching ErrNotFound for deleted resources.
+//   - Delete ignores resources that do not exist.
+//
+// The ID of a resource is stable: it does not change when the resource is
+// updated. Numbers are identified by the number itself, other resources by
+// the ID the API assigned.
+//
+// Channels are installed in the Dashboard and can not be created through the
+// API. Refer to them by their ID, e.g. in ConversationWebhookSpec.
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/voice"
+)
+
+// ErrNotFound is matched by errors returned by Read functions for resources
+// that do not exist.
+var ErrNotFound = errors.New("resource not found")
+
+// errCodeNotFound is the API error code for resources that do not exist.
+const errCodeNotFound = 20
+
+// Drift is a field of a resource of which the state differs from its spec.
+type Drift struct {
+	// Field is the name of the field in the spec, e.g. "URL".
+	Field string
+
+	Want interface{}
+	Got  interface{}
+}
+
+// String returns a description of d, e.g. `URL: want "a", got "b"`.
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: want %#v, got %#v", d.Field, d.Want, d.Got)
+}
+
+// IsNotFound reports whether err was returned by the API for a resource that
+// does not exist.
+func IsNotFound(err error) bool {
+	var requestErr *messagebird.RequestError
+	if errors.As(err, &requestErr) && requestErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	var response messagebird.ErrorResponse
+	if errors.As(err, &response) {
+		for _, e := range response.Errors {
+			if e.Code == errCodeNotFound {
+				return true
+			}
+		}
+	}
+
+	// The Voice API has no dedicated error code for this, so the messages
+	// are inspected.
+	var voiceResponse voice.ErrorResponse
+	if errors.As(err, &voiceResponse) {
+		for _, e := range voiceResponse.Errors {
+			if strings.Contains(strings.ToLower(e.Message), "not found") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// notFound returns an error matching ErrNotFound for the resource of kind with
+// id if err is a not found error, and err otherwise.
+func notFound(err error, kind, id string) error {
+	if IsNotFound(err) {
+		return fmt.Errorf("%w: %s %s: %v", ErrNotFound, kind, id, err)
+	}
+
+	return err
+}
+
+// ignoreNotFound returns nil if err is a not found error, and err otherwise.
+func ignoreNotFound(err error) error {
+	if IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// sameSet reports whether a and b hold the same strings, in any order.
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a, b = sorted(a), sorted(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sorted(s []string) []string {
+	copied := append([]string(nil), s...)
+	sort.Strings(copied)
+	return copied
+}
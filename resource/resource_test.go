@@ -0,0 +1,206 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/mocks"
+	"github.com/messagebird/go-rest-api/v9/number"
+	"github.com/messagebird/go-rest-api/v9/voice"
+	"github.com/stretchr/testify/assert"
+)
+
+var errNotFoundResponse = messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 20, Description: "not found"}}}
+
+// fakeAPI returns a client that responds to requests by "METHOD path" with
+// the JSON in responses, or an error. The query string is left out of the
+// path. Other requests fail the test.
+func fakeAPI(t *testing.T, responses map[string]interface{}) *mocks.Client {
+	return &mocks.Client{
+		RequestFunc: func(v interface{}, method, path string, data interface{}) error {
+			key := method + " " + strings.SplitN(path, "?", 2)[0]
+			response, ok := responses[key]
+			if !ok {
+				t.Errorf("unexpected request %s", key)
+				return errors.New("unexpected request")
+			}
+
+			switch response := response.(type) {
+			case error:
+				return response
+			case string:
+				if v != nil {
+					return json.Unmarshal([]byte(response), v)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// requests returns the "METHOD path" of the requests sent with c.
+func requests(c *mocks.Client) []string {
+	var keys []string
+	for _, call := range c.Calls() {
+		keys = append(keys, call.Args[1].(string)+" "+strings.SplitN(call.Args[2].(string), "?", 2)[0])
+	}
+
+	return keys
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, IsNotFound(errNotFoundResponse))
+	assert.True(t, IsNotFound(&messagebird.RequestError{StatusCode: http.StatusNotFound, Err: errors.New("API errors")}))
+	assert.True(t, IsNotFound(voice.ErrorResponse{Errors: []voice.Error{{Code: 13, Message: "Call flow not found"}}}))
+
+	assert.False(t, IsNotFound(messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 9, Description: "invalid"}}}))
+	assert.False(t, IsNotFound(errors.New("connection refused")))
+}
+
+func TestCreateNumberAdopts(t *testing.T) {
+	const path = "https://numbers.messagebird.com/v1/phone-numbers/31612345678"
+	c := fakeAPI(t, map[string]interface{}{
+		"GET " + path:   `{"number": "31612345678", "tags": ["b", "a"]}`,
+		"PATCH " + path: `{"number": "31612345678", "tags": ["b", "a"]}`,
+	})
+
+	spec := &NumberSpec{Number: "31612345678", Country: "NL", Tags: []string{"a", "b"}}
+	n, err := CreateNumber(c, spec)
+	assert.NoError(t, err)
+	assert.Empty(t, spec.Drift(n))
+	assert.Equal(t, []string{"GET " + path, "PATCH " + path, "GET " + path}, requests(c))
+}
+
+func TestCreateNumberPurchases(t *testing.T) {
+	const path = "https://numbers.messagebird.com/v1/phone-numbers"
+	c := fakeAPI(t, map[string]interface{}{
+		"GET " + path + "/31612345678": errNotFoundResponse,
+		"POST " + path:                 `{"number": "31612345678"}`,
+	})
+
+	_, err := CreateNumber(c, &NumberSpec{Number: "31612345678", Country: "NL"})
+	assert.Error(t, err, "the number can not be read after the purchase")
+	assert.Equal(t, []string{"GET " + path + "/31612345678", "POST " + path, "GET " + path + "/31612345678"}, requests(c))
+}
+
+func TestDeleteNumberIgnoresNotFound(t *testing.T) {
+	c := fakeAPI(t, map[string]interface{}{
+		"DELETE https://numbers.messagebird.com/v1/phone-numbers/31612345678": errNotFoundResponse,
+	})
+
+	assert.NoError(t, DeleteNumber(c, "31612345678"))
+}
+
+func TestNumberSpecDrift(t *testing.T) {
+	spec := &NumberSpec{Number: "31612345678", Tags: []string{"a", "b"}}
+
+	assert.Empty(t, spec.Drift(&number.Number{Number: "31612345678", Tags: []string{"b", "a"}}))
+	assert.Equal(t, []Drift{{Field: "Tags", Want: []string{"a", "b"}, Got: []string{"a"}}},
+		spec.Drift(&number.Number{Number: "31612345678", Tags: []string{"a"}}))
+
+	spec.Tags = nil
+	assert.Empty(t, spec.Drift(&number.Number{Number: "31612345678", Tags: []string{"a"}}))
+}
+
+func TestCreateConversationWebhookAdopts(t *testing.T) {
+	const path = "https://conversations.messagebird.com/v1/webhooks"
+	const webhook = `{"id": "whid", "channelId": "chid", "url": "https://example.com/webhooks", "events": ["message.created"], "status": "enabled"}`
+	c := fakeAPI(t, map[string]interface{}{
+		"GET " + path:             `{"count": 1, "totalCount": 1, "items": [` + webhook + `]}`,
+		"PATCH " + path + "/whid": webhook,
+		"GET " + path + "/whid":   webhook,
+	})
+
+	spec := &ConversationWebhookSpec{
+		ChannelID: "chid",
+		URL:       "https://example.com/webhooks",
+		Events:    []conversation.WebhookEvent{conversation.WebhookEventMessageCreated},
+	}
+	wh, err := CreateConversationWebhook(c, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, "whid", wh.ID)
+	assert.Empty(t, spec.Drift(wh))
+	assert.Equal(t, []string{"GET " + path, "PATCH " + path + "/whid", "GET " + path + "/whid"}, requests(c))
+}
+
+func TestCreateConversationWebhookCreates(t *testing.T) {
+	const path = "https://conversations.messagebird.com/v1/webhooks"
+	const webhook = `{"id": "whid", "channelId": "chid", "url": "https://example.com/webhooks", "status": "enabled"}`
+	c := fakeAPI(t, map[string]interface{}{
+		"GET " + path:           `{"count": 1, "totalCount": 1, "items": [{"id": "other", "channelId": "other", "url": "https://example.com/webhooks"}]}`,
+		"POST " + path:          webhook,
+		"GET " + path + "/whid": webhook,
+	})
+
+	wh, err := CreateConversationWebhook(c, &ConversationWebhookSpec{ChannelID: "chid", URL: "https://example.com/webhooks"})
+	assert.NoError(t, err)
+	assert.Equal(t, "whid", wh.ID)
+	assert.Equal(t, []string{"GET " + path, "POST " + path, "GET " + path + "/whid"}, requests(c))
+}
+
+func TestConversationWebhookSpecDrift(t *testing.T) {
+	spec := &ConversationWebhookSpec{
+		ChannelID: "chid",
+		URL:       "https://example.com/webhooks",
+		Events:    []conversation.WebhookEvent{conversation.WebhookEventMessageCreated},
+	}
+
+	drift := spec.Drift(&conversation.Webhook{
+		ChannelID: "chid",
+		URL:       "https://example.com/webhooks",
+		Events:    []conversation.WebhookEvent{conversation.WebhookEventMessageCreated},
+		Status:    conversation.WebhookStatusDisabled,
+	})
+	assert.Equal(t, []Drift{{Field: "Status", Want: conversation.WebhookStatusEnabled, Got: conversation.WebhookStatusDisabled}}, drift)
+}
+
+func TestReadVoiceWebhookNotFound(t *testing.T) {
+	c := fakeAPI(t, map[string]interface{}{
+		"GET https://voice.messagebird.com/v1/webhooks/whid": voice.ErrorResponse{Errors: []voice.Error{{Code: 13, Message: "Webhook not found"}}},
+	})
+
+	_, err := ReadVoiceWebhook(c, "whid")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestCreateCallFlowAdopts(t *testing.T) {
+	const path = "https://voice.messagebird.com/v1/call-flows/"
+	const callFlow = `{"id": "cfid", "title": "Support", "record": false, "steps": [{"id": "s1", "action": "hangup", "options": {}}], "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z"}`
+	c := fakeAPI(t, map[string]interface{}{
+		"GET " + path:          `{"data": [` + callFlow + `], "pagination": {"totalCount": 1, "pageCount": 1, "currentPage": 1, "perPage": 10}}`,
+		"PUT " + path + "cfid": `{"data": [` + callFlow + `]}`,
+		"GET " + path + "cfid": `{"data": [` + callFlow + `]}`,
+	})
+
+	spec := &CallFlowSpec{Title: "Support", Steps: []voice.CallFlowStep{&voice.CallFlowHangupStep{}}}
+	cf, err := CreateCallFlow(c, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, "cfid", cf.ID)
+	assert.Empty(t, spec.Drift(cf), "step IDs assigned by the API are not drift")
+	assert.Equal(t, []string{"GET " + path, "PUT " + path + "cfid", "GET " + path + "cfid"}, requests(c))
+}
+
+func TestCallFlowSpecDrift(t *testing.T) {
+	spec := &CallFlowSpec{
+		Title: "Support",
+		Steps: []voice.CallFlowStep{&voice.CallFlowTransferStep{Destination: "31612345678"}},
+	}
+
+	drift := spec.Drift(&voice.CallFlow{
+		Title:  "Support",
+		Steps:  []voice.CallFlowStep{&voice.CallFlowTransferStep{Destination: "31687654321"}},
+		Record: true,
+	})
+
+	var fields []string
+	for _, d := range drift {
+		fields = append(fields, d.Field)
+	}
+	assert.Equal(t, []string{"Steps", "Record"}, fields)
+}
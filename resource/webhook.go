@@ -0,0 +1,262 @@
+package resource
+
+import (
+	"errors"
+	"io"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+	"github.com/messagebird/go-rest-api/v9/voice"
+)
+
+// webhookPageSize is the number of conversation webhooks listed per request
+// when looking for an existing webhook.
+const webhookPageSize = 20
+
+// ConversationWebhookSpec declares a webhook of the Conversations API. A
+// channel has at most one webhook per URL, which CreateConversationWebhook
+// adopts.
+type ConversationWebhookSpec struct {
+	// ChannelID is the channel the webhook is registered for. It can not be
+	// updated: delete the webhook and create a new one to move it.
+	ChannelID string
+
+	URL    string
+	Events []conversation.WebhookEvent
+
+	// Status is the status of the webhook. It is enabled if Status is empty.
+	Status conversation.WebhookStatus
+
+	// Settings are the delivery settings of the webhook. The API does not
+	// return all of them, e.g. the password, so they are not checked for
+	// drift.
+	Settings *conversation.WebhookSettings
+}
+
+// CreateConversationWebhook creates the webhook of spec, or updates the
+// webhook of its channel with the same URL.
+func CreateConversationWebhook(c messagebird.Client, spec *ConversationWebhookSpec) (*conversation.Webhook, error) {
+	if spec == nil || spec.URL == "" {
+		return nil, errors.New("url is required")
+	}
+
+	existing, err := findConversationWebhook(c, spec.ChannelID, spec.URL)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return UpdateConversationWebhook(c, existing.ID, spec)
+	}
+
+	webhook, err := conversation.CreateWebhook(c, &conversation.WebhookCreateRequest{
+		ChannelID: spec.ChannelID,
+		Events:    spec.Events,
+		URL:       spec.URL,
+		Settings:  spec.Settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Webhooks are enabled when they are created.
+	if spec.Status != "" && spec.Status != conversation.WebhookStatusEnabled {
+		return UpdateConversationWebhook(c, webhook.ID, spec)
+	}
+
+	return ReadConversationWebhook(c, webhook.ID)
+}
+
+// ReadConversationWebhook reads the conversation webhook with id.
+func ReadConversationWebhook(c messagebird.Client, id string) (*conversation.Webhook, error) {
+	webhook, err := conversation.ReadWebhook(c, id)
+	if err != nil {
+		return nil, notFound(err, "conversation webhook", id)
+	}
+
+	return webhook, nil
+}
+
+// UpdateConversationWebhook applies spec, except its ChannelID, to the
+// conversation webhook with id.
+func UpdateConversationWebhook(c messagebird.Client, id string, spec *ConversationWebhookSpec) (*conversation.Webhook, error) {
+	if spec == nil {
+		return nil, errors.New("spec is required")
+	}
+
+	_, err := conversation.UpdateWebhook(c, id, &conversation.WebhookUpdateRequest{
+		Events:   spec.Events,
+		URL:      spec.URL,
+		Status:   spec.status(),
+		Settings: spec.Settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadConversationWebhook(c, id)
+}
+
+// DeleteConversationWebhook deletes the conversation webhook with id.
+func DeleteConversationWebhook(c messagebird.Client, id string) error {
+	return ignoreNotFound(conversation.DeleteWebhook(c, id))
+}
+
+// Drift returns the fields of which webhook differs from spec.
+func (spec *ConversationWebhookSpec) Drift(webhook *conversation.Webhook) []Drift {
+	var drift []Drift
+	if spec.ChannelID != webhook.ChannelID {
+		drift = append(drift, Drift{Field: "ChannelID", Want: spec.ChannelID, Got: webhook.ChannelID})
+	}
+	if spec.URL != webhook.URL {
+		drift = append(drift, Drift{Field: "URL", Want: spec.URL, Got: webhook.URL})
+	}
+	if !sameSet(eventNames(spec.Events), eventNames(webhook.Events)) {
+		drift = append(drift, Drift{Field: "Events", Want: spec.Events, Got: webhook.Events})
+	}
+	if spec.status() != webhook.Status {
+		drift = append(drift, Drift{Field: "Status", Want: spec.status(), Got: webhook.Status})
+	}
+
+	return drift
+}
+
+func (spec *ConversationWebhookSpec) status() conversation.WebhookStatus {
+	if spec.Status == "" {
+		return conversation.WebhookStatusEnabled
+	}
+
+	return spec.Status
+}
+
+// findConversationWebhook returns the webhook of the channel with channelID
+// for url, or nil if there is none.
+func findConversationWebhook(c messagebird.Client, channelID, url string) (*conversation.Webhook, error) {
+	var found *conversation.Webhook
+	var pager paging.Pager
+	for !pager.Done() && found == nil {
+		pager.Fetch(func(offset int) (int, int, error) {
+			list, err := conversation.ListWebhooks(c, &messagebird.PaginationRequest{Limit: webhookPageSize, Offset: offset})
+			if err != nil {
+				return 0, 0, err
+			}
+
+			for _, webhook := range list.Items {
+				if webhook.ChannelID == channelID && webhook.URL == url {
+					found = webhook
+					break
+				}
+			}
+
+			return len(list.Items), list.TotalCount, nil
+		})
+	}
+
+	return found, pager.Err()
+}
+
+func eventNames(events []conversation.WebhookEvent) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+
+	return names
+}
+
+// VoiceWebhookSpec declares a webhook of the Voice API. There is at most one
+// webhook per URL, which CreateVoiceWebhook adopts.
+type VoiceWebhookSpec struct {
+	URL string
+
+	// Token is used to sign the requests of the webhook. Leave empty to not
+	// sign them.
+	Token string
+}
+
+// CreateVoiceWebhook creates the webhook of spec, or updates the webhook with
+// the same URL.
+func CreateVoiceWebhook(c messagebird.Client, spec *VoiceWebhookSpec) (*voice.Webhook, error) {
+	if spec == nil || spec.URL == "" {
+		return nil, errors.New("url is required")
+	}
+
+	existing, err := findVoiceWebhook(c, spec.URL)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return UpdateVoiceWebhook(c, existing.ID, spec)
+	}
+
+	webhook, err := voice.CreateWebHook(c, spec.URL, spec.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadVoiceWebhook(c, webhook.ID)
+}
+
+// ReadVoiceWebhook reads the voice webhook with id.
+func ReadVoiceWebhook(c messagebird.Client, id string) (*voice.Webhook, error) {
+	webhook, err := voice.WebhookByID(c, id)
+	if err != nil {
+		return nil, notFound(err, "voice webhook", id)
+	}
+
+	return webhook, nil
+}
+
+// UpdateVoiceWebhook applies spec to the voice webhook with id.
+func UpdateVoiceWebhook(c messagebird.Client, id string, spec *VoiceWebhookSpec) (*voice.Webhook, error) {
+	if spec == nil {
+		return nil, errors.New("spec is required")
+	}
+
+	webhook := &voice.Webhook{ID: id, URL: spec.URL, Token: spec.Token}
+	if err := webhook.Update(c); err != nil {
+		return nil, err
+	}
+
+	return ReadVoiceWebhook(c, id)
+}
+
+// DeleteVoiceWebhook deletes the voice webhook with id.
+func DeleteVoiceWebhook(c messagebird.Client, id string) error {
+	webhook := &voice.Webhook{ID: id}
+	return ignoreNotFound(webhook.Delete(c))
+}
+
+// Drift returns the fields of which webhook differs from spec.
+func (spec *VoiceWebhookSpec) Drift(webhook *voice.Webhook) []Drift {
+	var drift []Drift
+	if spec.URL != webhook.URL {
+		drift = append(drift, Drift{Field: "URL", Want: spec.URL, Got: webhook.URL})
+	}
+	if spec.Token != webhook.Token {
+		drift = append(drift, Drift{Field: "Token", Want: spec.Token, Got: webhook.Token})
+	}
+
+	return drift
+}
+
+// findVoiceWebhook returns the voice webhook for url, or nil if there is none.
+func findVoiceWebhook(c messagebird.Client, url string) (*voice.Webhook, error) {
+	pages := voice.Webhooks(c)
+	for {
+		page, err := pages.NextPage()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		for _, webhook := range page.([]voice.Webhook) {
+			if webhook.URL == url {
+				return &webhook, nil
+			}
+		}
+
+		if err == io.EOF {
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package messagebird
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/internal/queue"
+)
+
+// Defaults for the options of a RetryPolicy.
+const (
+	DefaultRetryMaxAttempts = 3
+	DefaultRetryDelay       = 500 * time.Millisecond
+	DefaultRetryMaxDelay    = 10 * time.Second
+)
+
+// RetryPolicy configures the retries of a DefaultClient. Requests are retried
+// when no response was received because of a network error, and when the API
+// responded with 429 Too Many Requests or a server error. Other errors, such
+// as validation errors, are returned at once.
+//
+// POST and PATCH requests are not retried unless AllMethods is set: a request
+// that timed out may still have been processed, and would then e.g. send a
+// message twice.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the
+	// first time. DefaultRetryMaxAttempts is used if it is zero.
+	MaxAttempts int `yaml:"maxAttempts"`
+
+	// Delay is the delay before the first retry. It doubles for every
+	// following one, up to MaxDelay. DefaultRetryDelay and
+	// DefaultRetryMaxDelay are used if they are zero.
+	Delay    time.Duration `yaml:"delay"`
+	MaxDelay time.Duration `yaml:"maxDelay"`
+
+	// AllMethods retries POST and PATCH requests too.
+	AllMethods bool `yaml:"allMethods"`
+}
+
+// retries reports whether the request with method, which failed with status
+// and err in attempt, is retried. A nil policy does not retry.
+func (p *RetryPolicy) retries(method string, status int, err error, attempt int) bool {
+	if p == nil || attempt >= p.maxAttempts() {
+		return false
+	}
+
+	switch method {
+	case http.MethodPost, http.MethodPatch:
+		if !p.AllMethods {
+			return false
+		}
+	}
+
+	if status == 0 {
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// delay returns the time to wait after the failed attempt.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	delay, maxDelay := p.Delay, p.MaxDelay
+	if delay == 0 {
+		delay = DefaultRetryDelay
+	}
+	if maxDelay == 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+
+	return queue.Backoff(delay, maxDelay, attempt)
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts == 0 {
+		return DefaultRetryMaxAttempts
+	}
+
+	return p.MaxAttempts
+}
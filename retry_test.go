@@ -0,0 +1,115 @@
+package messagebird
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceTransport responds to requests with the statuses in order, or with
+// a network error for status 0.
+type sequenceTransport struct {
+	statuses []int
+	requests []*http.Request
+}
+
+func (t *sequenceTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	status := t.statuses[len(t.requests)]
+	t.requests = append(t.requests, r)
+	if status == 0 {
+		return nil, errors.New("connection reset by peer")
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{}`))),
+		Request:    r,
+	}, nil
+}
+
+// instantClock records the delays it is asked to wait, without waiting.
+type instantClock struct {
+	delays []time.Duration
+}
+
+func (c *instantClock) Now() time.Time {
+	return time.Time{}
+}
+
+func (c *instantClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func newRetryClient(policy *RetryPolicy, statuses ...int) (*DefaultClient, *sequenceTransport, *instantClock) {
+	transport := &sequenceTransport{statuses: statuses}
+	clock := &instantClock{}
+
+	c := New("test_accesskey")
+	c.HTTPClient.Transport = transport
+	c.Clock = clock
+	c.Retry = policy
+
+	return c, transport, clock
+}
+
+func TestRetry(t *testing.T) {
+	c, transport, clock := newRetryClient(&RetryPolicy{}, 0, http.StatusTooManyRequests, http.StatusOK)
+
+	assert.NoError(t, c.Request(&struct{}{}, http.MethodGet, "balance", nil))
+	assert.Len(t, transport.requests, 3)
+	assert.Equal(t, []time.Duration{DefaultRetryDelay, 2 * DefaultRetryDelay}, clock.delays)
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	c, transport, _ := newRetryClient(&RetryPolicy{MaxAttempts: 2}, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+
+	assert.Error(t, c.Request(&struct{}{}, http.MethodGet, "balance", nil))
+	assert.Len(t, transport.requests, 2)
+}
+
+func TestRetryMutatingRequests(t *testing.T) {
+	c, transport, _ := newRetryClient(&RetryPolicy{}, http.StatusServiceUnavailable, http.StatusOK)
+
+	assert.Error(t, c.Request(&struct{}{}, http.MethodPost, "messages", nil))
+	assert.Len(t, transport.requests, 1, "POST requests are not retried by default")
+
+	c, transport, _ = newRetryClient(&RetryPolicy{AllMethods: true}, http.StatusServiceUnavailable, http.StatusOK)
+
+	assert.NoError(t, c.Request(&struct{}{}, http.MethodPost, "messages", nil))
+	assert.Len(t, transport.requests, 2)
+}
+
+func TestRetryClientErrors(t *testing.T) {
+	c, transport, _ := newRetryClient(&RetryPolicy{}, http.StatusUnprocessableEntity, http.StatusOK)
+
+	assert.Error(t, c.Request(&struct{}{}, http.MethodGet, "balance", nil))
+	assert.Len(t, transport.requests, 1)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{Delay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, p.delay(1))
+	assert.Equal(t, 2*time.Second, p.delay(2))
+	assert.Equal(t, 4*time.Second, p.delay(3))
+	assert.Equal(t, 5*time.Second, p.delay(4))
+}
+
+func TestBaseURL(t *testing.T) {
+	c, transport, _ := newRetryClient(nil, http.StatusOK, http.StatusOK)
+	c.BaseURL = "https://proxy.example.com/messagebird/"
+
+	assert.NoError(t, c.Request(&struct{}{}, http.MethodGet, "balance", nil))
+	assert.NoError(t, c.Request(&struct{}{}, http.MethodGet, "https://conversations.messagebird.com/v1/webhooks", nil))
+	assert.Equal(t, "https://proxy.example.com/messagebird/balance", transport.requests[0].URL.String())
+	assert.Equal(t, "https://conversations.messagebird.com/v1/webhooks", transport.requests[1].URL.String())
+}
@@ -0,0 +1,495 @@
+// Package router spreads requests over several MessageBird accounts, e.g.
+// one per country or brand. A Router is a messagebird.Client, so it can be
+// passed to the functions of the other packages. It picks the account of
+// every request by Rules, paces the requests of each account and fails over
+// to the next account of a rule when an account is unavailable:
+//
+//	r := &router.Router{
+//		Accounts: []*router.Account{
+//			{Name: "nl", Client: messagebird.New(nlKey), Rate: 50},
+//			{Name: "uk", Client: messagebird.New(ukKey), Rate: 20},
+//		},
+//		Rules: []router.Rule{
+//			{Countries: []string{"GB"}, Accounts: []string{"uk", "nl"}},
+//			{Tags: []string{"brand-b"}, Accounts: []string{"uk"}},
+//		},
+//		Default: []string{"nl"},
+//	}
+//
+//	msg, err := sms.Create(r, "MessageBird", []string{"447700900123"}, "Hello!", nil)
+//
+// Use WithTags to route by tag. To send messages with the cheapest of
+// MessageBird and other providers, and fail over between them, see Senders.
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+)
+
+// ErrNoAccount is returned for requests that no rule matches when the Router
+// has no Default accounts.
+var ErrNoAccount = errors.New("no account to route the request to")
+
+// Product is the MessageBird product a request is for. Requests to the REST
+// API are identified by the first segment of their path, e.g. "hlr" or
+// "contacts", and requests to other APIs by their host, e.g. "numbers".
+type Product string
+
+const (
+	ProductSMS           Product = "sms"
+	ProductMMS           Product = "mms"
+	ProductVoice         Product = "voice"
+	ProductConversations Product = "conversations"
+	ProductVerify        Product = "verify"
+	ProductLookup        Product = "lookup"
+	ProductEmail         Product = "email"
+	ProductNumbers       Product = "numbers"
+)
+
+// Account is a MessageBird account requests can be routed to.
+type Account struct {
+	// Name identifies the account in Rules.
+	Name string
+
+	Client messagebird.Client
+
+	// Rate is the maximum number of requests per second sent with the
+	// account, typically its rate limit. Requests wait for their turn.
+	// Zero disables pacing.
+	Rate float64
+}
+
+// Rule selects the accounts for the requests that match it. A request
+// matches if it matches every condition that is set.
+type Rule struct {
+	// Prefixes matches requests of which all destinations start with one
+	// of the prefixes, in international format without +, e.g. "31" or
+	// "4420". Requests without destinations do not match.
+	Prefixes []string
+
+	// Countries is like Prefixes, with ISO 3166-1 alpha-2 country codes,
+	// e.g. "NL", that match the calling code of the country.
+	Countries []string
+
+	// Products matches requests for one of the products.
+	Products []Product
+
+	// Tags matches requests with one of the tags, see WithTags.
+	Tags []string
+
+	// Accounts are the names of the accounts to send the matching requests
+	// with. The first one is used, unless it fails with an error for which
+	// Failover returns true. Then the next one is tried.
+	Accounts []string
+}
+
+// Router is a messagebird.Client that sends every request with the accounts
+// of the first matching rule, or the Default accounts. It is safe for
+// concurrent use. The fields must not be changed once it is used.
+type Router struct {
+	Accounts []*Account
+	Rules    []Rule
+
+	// Default are the names of the accounts for requests that match no rule.
+	Default []string
+
+	// Failover reports whether a request that failed with err is sent with
+	// the next account. IsFailoverError is used if it is nil.
+	Failover func(err error) bool
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// Request sends the request with the accounts selected by the rules.
+func (r *Router) Request(v interface{}, method, path string, data interface{}) error {
+	return r.request(nil, v, method, path, data)
+}
+
+// RequiresConsent implements messagebird.ConsentRequirer. Requests may be
+// sent with any of the accounts, so r requires consent if one of their
+// clients does.
+func (r *Router) RequiresConsent() bool {
+	for _, account := range r.Accounts {
+		if messagebird.RequiresConsent(account.Client) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unwrap implements messagebird.Wrapper. It returns the client of the first
+// account, so its Clock is used for r. Compliance mode and API versions are
+// looked up on all accounts instead, see RequiresConsent and APIVersion.
+func (r *Router) Unwrap() messagebird.Client {
+	if len(r.Accounts) == 0 {
+		return nil
+	}
+
+	return r.Accounts[0].Client
+}
+
+// APIVersion implements messagebird.APIVersioner. The version is part of the
+// path of requests, which is built before the account is picked, so it is
+// the first version selected by one of the accounts: select the same
+// versions for all accounts.
+func (r *Router) APIVersion(api string) string {
+	for _, account := range r.Accounts {
+		if version := messagebird.APIVersionOf(account.Client, api, ""); version != "" {
+			return version
+		}
+	}
+
+	return ""
+}
+
+// WithTags returns a Client that sends requests through r like Request, with
+// tags to match the Tags of rules with.
+func (r *Router) WithTags(tags ...string) messagebird.Client {
+	return &taggedClient{router: r, tags: tags}
+}
+
+// Route returns the names of the accounts a request would be sent with, in
+// order of preference.
+func (r *Router) Route(path string, data interface{}, tags ...string) ([]string, error) {
+	req := &request{path: path, data: data, tags: tags}
+	for i := range r.Rules {
+		if r.Rules[i].matches(req) {
+			return r.Rules[i].Accounts, nil
+		}
+	}
+
+	if len(r.Default) == 0 {
+		return nil, ErrNoAccount
+	}
+
+	return r.Default, nil
+}
+
+func (r *Router) request(tags []string, v interface{}, method, path string, data interface{}) error {
+	names, err := r.Route(path, data, tags...)
+	if err != nil {
+		return err
+	}
+
+	failover := r.Failover
+	if failover == nil {
+		failover = IsFailoverError
+	}
+
+	for _, name := range names {
+		account := r.account(name)
+		if account == nil {
+			err = fmt.Errorf("unknown account %q", name)
+			continue
+		}
+
+		r.wait(account)
+
+		err = account.Client.Request(v, method, path, data)
+		if err == nil || !failover(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (r *Router) account(name string) *Account {
+	for _, account := range r.Accounts {
+		if account.Name == name {
+			return account
+		}
+	}
+
+	return nil
+}
+
+// wait blocks until account may send the next request.
+func (r *Router) wait(account *Account) {
+	if account.Rate <= 0 {
+		return
+	}
+
+	clock := messagebird.ClockOf(account.Client)
+	interval := time.Duration(float64(time.Second) / account.Rate)
+
+	r.mu.Lock()
+	if r.next == nil {
+		r.next = make(map[string]time.Time)
+	}
+	now := clock.Now()
+	at := r.next[account.Name]
+	if at.Before(now) {
+		at = now
+	}
+	r.next[account.Name] = at.Add(interval)
+	r.mu.Unlock()
+
+	if d := at.Sub(now); d > 0 {
+		<-clock.After(d)
+	}
+}
+
+// IsFailoverError reports whether err means the account could not handle
+// the request, but another account might: the API is unavailable or can not
+// be reached, or it rejected the account because of its access key, balance
+// or rate limit. Errors about the request itself are not.
+//
+// A request that timed out may have been handled by the API, so failing
+// over can send a message twice.
+func IsFailoverError(err error) bool {
+	if errors.Is(err, messagebird.ErrUnexpectedResponse) {
+		return true
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	var errorResponse messagebird.ErrorResponse
+	if !errors.As(err, &errorResponse) || len(errorResponse.Errors) == 0 {
+		return false
+	}
+
+	for _, e := range errorResponse.Errors {
+		switch e.Code {
+		case 2, 25, 99, 429:
+			// Incorrect access key, not enough balance, internal error
+			// and too many requests.
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+type taggedClient struct {
+	router *Router
+	tags   []string
+}
+
+func (c *taggedClient) Request(v interface{}, method, path string, data interface{}) error {
+	return c.router.request(c.tags, v, method, path, data)
+}
+
+func (c *taggedClient) Unwrap() messagebird.Client {
+	return c.router
+}
+
+// request holds what rules match on. The product and destinations are
+// determined when a rule needs them.
+type request struct {
+	path string
+	data interface{}
+	tags []string
+
+	product      *Product
+	destinations []string
+	parsed       bool
+}
+
+func (req *request) Product() Product {
+	if req.product == nil {
+		product := ProductOf(req.path)
+		req.product = &product
+	}
+
+	return *req.product
+}
+
+func (req *request) Destinations() []string {
+	if !req.parsed {
+		req.destinations = destinations(req.data)
+		req.parsed = true
+	}
+
+	return req.destinations
+}
+
+func (rule *Rule) matches(req *request) bool {
+	if len(rule.Tags) > 0 && !containsAny(rule.Tags, req.tags) {
+		return false
+	}
+
+	if len(rule.Products) > 0 && !containsProduct(rule.Products, req.Product()) {
+		return false
+	}
+
+	if len(rule.Prefixes) == 0 && len(rule.Countries) == 0 {
+		return true
+	}
+
+	prefixes := rule.Prefixes
+	for _, country := range rule.Countries {
+		if code, ok := numberutil.CallingCode(country); ok {
+			prefixes = append(prefixes[:len(prefixes):len(prefixes)], code)
+		}
+	}
+
+	destinations := req.Destinations()
+	if len(destinations) == 0 {
+		return false
+	}
+
+	for _, destination := range destinations {
+		if !hasAnyPrefix(destination, prefixes) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ProductOf returns the product of a request to path, which is either
+// relative to messagebird.Endpoint or an absolute URL.
+func ProductOf(path string) Product {
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		u, err := url.Parse(path)
+		if err != nil {
+			return ""
+		}
+
+		if host := strings.TrimSuffix(u.Hostname(), ".messagebird.com"); host != "rest" {
+			return Product(host)
+		}
+		path = u.Path
+	}
+
+	segment := strings.TrimPrefix(path, "/")
+	if i := strings.IndexAny(segment, "/?"); i >= 0 {
+		segment = segment[:i]
+	}
+
+	switch segment {
+	case "messages":
+		return ProductSMS
+	case "voicemessages":
+		return ProductVoice
+	default:
+		return Product(segment)
+	}
+}
+
+// destinationFields are the fields of request bodies that hold phone
+// numbers messages are sent to.
+var destinationFields = []string{"recipients", "recipient", "to", "msisdn"}
+
+// destinations returns the phone numbers in the destination fields of data,
+// in international format without +.
+func destinations(data interface{}) []string {
+	var values []interface{}
+
+	switch data := data.(type) {
+	case nil:
+		return nil
+	case string:
+		form, err := url.ParseQuery(data)
+		if err != nil {
+			return nil
+		}
+		for _, field := range destinationFields {
+			for _, value := range form[field] {
+				values = append(values, value)
+			}
+		}
+	default:
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil
+		}
+
+		var fields map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			return nil
+		}
+		for _, field := range destinationFields {
+			if value, ok := fields[field]; ok {
+				values = append(values, value)
+			}
+		}
+	}
+
+	var numbers []string
+	for _, value := range values {
+		numbers = appendNumbers(numbers, value)
+	}
+
+	return numbers
+}
+
+func appendNumbers(numbers []string, value interface{}) []string {
+	switch value := value.(type) {
+	case []interface{}:
+		for _, v := range value {
+			numbers = appendNumbers(numbers, v)
+		}
+	case json.Number:
+		numbers = append(numbers, msisdn(value.String()))
+	case string:
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				numbers = append(numbers, msisdn(s))
+			}
+		}
+	}
+
+	return numbers
+}
+
+// msisdn normalizes s to international format without +. Numbers without +
+// or 00 are in international format already, as the API expects them.
+func msisdn(s string) string {
+	if n, err := numberutil.MSISDN(s, ""); err == nil {
+		return n
+	}
+
+	return strings.TrimPrefix(s, "+")
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func containsProduct(products []Product, product Product) bool {
+	for _, p := range products {
+		if p == product {
+			return true
+		}
+	}
+
+	return false
+}
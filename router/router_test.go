@@ -0,0 +1,183 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/balance"
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter(t *testing.T) (*Router, map[string]*messagebirdtest.Server) {
+	servers := map[string]*messagebirdtest.Server{}
+	r := &Router{
+		Rules: []Rule{
+			{Countries: []string{"GB"}, Accounts: []string{"uk", "nl"}},
+			{Prefixes: []string{"1"}, Products: []Product{ProductSMS}, Accounts: []string{"us"}},
+			{Tags: []string{"brand-b"}, Accounts: []string{"brand-b"}},
+		},
+		Default: []string{"nl"},
+	}
+
+	for _, name := range []string{"nl", "uk", "us", "brand-b"} {
+		servers[name] = messagebirdtest.NewServer(t)
+		r.Accounts = append(r.Accounts, &Account{Name: name, Client: servers[name].Client()})
+	}
+
+	return r, servers
+}
+
+func TestRoute(t *testing.T) {
+	r, _ := newTestRouter(t)
+
+	tests := []struct {
+		name     string
+		path     string
+		data     interface{}
+		tags     []string
+		expected []string
+	}{
+		{"country", "messages", map[string]interface{}{"recipients": []string{"447700900123", "+44 7700 900456"}}, nil, []string{"uk", "nl"}},
+		{"mixed countries", "messages", map[string]interface{}{"recipients": []string{"447700900123", "31612345678"}}, nil, []string{"nl"}},
+		{"prefix and product", "messages", map[string]interface{}{"recipients": []int{14155550100}}, nil, []string{"us"}},
+		{"other product", "voicemessages", map[string]interface{}{"recipients": []int{14155550100}}, nil, []string{"nl"}},
+		{"tag", "balance", nil, []string{"brand-a", "brand-b"}, []string{"brand-b"}},
+		{"form", "mms", "recipients=447700900123%2C447700900456", nil, []string{"uk", "nl"}},
+		{"conversation", "https://conversations.messagebird.com/v1/send", &conversation.SendMessageRequest{To: "+447700900123"}, nil, []string{"uk", "nl"}},
+		{"default", "balance", nil, nil, []string{"nl"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts, err := r.Route(tt.path, tt.data, tt.tags...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, accounts)
+		})
+	}
+}
+
+func TestRouteNoAccount(t *testing.T) {
+	r := &Router{Rules: []Rule{{Tags: []string{"brand-b"}, Accounts: []string{"brand-b"}}}}
+
+	_, err := r.Route("balance", nil)
+	assert.Equal(t, ErrNoAccount, err)
+}
+
+func TestRequest(t *testing.T) {
+	r, servers := newTestRouter(t)
+
+	_, err := sms.Create(r, "MessageBird", []string{"447700900123"}, "Hello!", nil)
+	assert.NoError(t, err)
+	servers["uk"].AssertEndpointCalled(http.MethodPost, "/messages")
+	assert.Empty(t, servers["nl"].Requests())
+
+	_, err = balance.Read(r.WithTags("brand-b"))
+	assert.NoError(t, err)
+	servers["brand-b"].AssertEndpointCalled(http.MethodGet, "/balance")
+}
+
+func TestRequiresConsent(t *testing.T) {
+	r, _ := newTestRouter(t)
+	assert.False(t, messagebird.RequiresConsent(r))
+
+	r.Accounts[1].Client.(*messagebird.DefaultClient).RequireConsent = true
+	assert.True(t, messagebird.RequiresConsent(r))
+	assert.True(t, messagebird.RequiresConsent(r.WithTags("brand-b")))
+}
+
+func TestAPIVersion(t *testing.T) {
+	r, _ := newTestRouter(t)
+	assert.Equal(t, conversation.APIVersion1, messagebird.APIVersionOf(r, conversation.APIName, conversation.APIVersion1))
+
+	r.Accounts[1].Client.(*messagebird.DefaultClient).APIVersions = map[string]string{conversation.APIName: conversation.APIVersion2}
+	assert.Equal(t, conversation.APIVersion2, messagebird.APIVersionOf(r.WithTags("brand-b"), conversation.APIName, conversation.APIVersion1))
+}
+
+func TestRequestFailover(t *testing.T) {
+	r, servers := newTestRouter(t)
+	servers["uk"].FailOnCall(1, messagebirdtest.FailWithServerError())
+	servers["uk"].FailOnCall(2, messagebirdtest.FailWithAPIError(http.StatusUnprocessableEntity, 9, "no (correct) recipients found"))
+	servers["nl"].FailOnCall(3, messagebirdtest.FailWithRateLimit(time.Second))
+
+	// The first account is unavailable, so the second one is used.
+	_, err := sms.Create(r, "MessageBird", []string{"447700900123"}, "Hello!", nil)
+	assert.NoError(t, err)
+	assert.Len(t, servers["uk"].Requests(), 1)
+	assert.Len(t, servers["nl"].Requests(), 1)
+
+	// Errors about the request are returned straight away.
+	_, err = sms.Create(r, "MessageBird", []string{"447700900123"}, "Hello!", nil)
+	assert.EqualError(t, err, "API errors: no (correct) recipients found")
+	assert.Len(t, servers["nl"].Requests(), 1)
+
+	// The error of the last account is returned if all fail.
+	servers["uk"].FailOnCall(3, messagebirdtest.FailWithServerError())
+	servers["nl"].FailOnCall(2, messagebirdtest.FailWithRateLimit(time.Second))
+	_, err = sms.Create(r, "MessageBird", []string{"447700900123"}, "Hello!", nil)
+	assert.EqualError(t, err, "API errors: Too many requests")
+}
+
+func TestRequestRate(t *testing.T) {
+	clock := messagebirdtest.NewFakeClock(time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC))
+	server := messagebirdtest.NewServer(t)
+	client := server.Client()
+	client.Clock = clock
+
+	r := &Router{Accounts: []*Account{{Name: "nl", Client: client, Rate: 2}}, Default: []string{"nl"}}
+
+	assert.NoError(t, r.Request(nil, http.MethodGet, "balance", nil))
+
+	done := make(chan error)
+	go func() { done <- r.Request(nil, http.MethodGet, "balance", nil) }()
+
+	clock.BlockUntil(1)
+	assert.Len(t, server.Requests(), 1)
+
+	clock.Advance(500 * time.Millisecond)
+	assert.NoError(t, <-done)
+	assert.Len(t, server.Requests(), 2)
+}
+
+func TestIsFailoverError(t *testing.T) {
+	assert.True(t, IsFailoverError(messagebird.ErrUnexpectedResponse))
+	assert.True(t, IsFailoverError(messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 25}}}))
+	assert.True(t, IsFailoverError(messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 429}}}))
+	assert.False(t, IsFailoverError(messagebird.ErrorResponse{Errors: []messagebird.Error{{Code: 429}, {Code: 9}}}))
+	assert.False(t, IsFailoverError(errors.New("originator is required")))
+}
+
+func TestProductOf(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected Product
+	}{
+		{"messages", ProductSMS},
+		{"messages/id", ProductSMS},
+		{"voicemessages?limit=10", ProductVoice},
+		{"lookup/31612345678/hlr", ProductLookup},
+		{"https://rest.messagebird.com/verify/id", ProductVerify},
+		{"https://conversations.messagebird.com/v1/send", ProductConversations},
+		{"https://voice.messagebird.com/v1/calls", ProductVoice},
+		{"https://numbers.messagebird.com/v1/phone-numbers", ProductNumbers},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ProductOf(tt.path), tt.path)
+	}
+}
+
+func TestClockOf(t *testing.T) {
+	r, _ := newTestRouter(t)
+	clock := messagebirdtest.NewFakeClock(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+	r.Accounts[0].Client.(*messagebird.DefaultClient).Clock = clock
+
+	assert.Equal(t, clock, messagebird.ClockOf(r))
+	assert.Equal(t, clock, messagebird.ClockOf(r.WithTags("brand-b")))
+	assert.Equal(t, messagebird.SystemClock, messagebird.ClockOf(&Router{}))
+}
@@ -0,0 +1,293 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/verify"
+)
+
+// ErrUnavailable is returned, possibly wrapped, by senders that can not send
+// at the moment, e.g. because their backend is down. Senders fails over to
+// the next provider on it.
+var ErrUnavailable = errors.New("provider unavailable")
+
+// ErrNoProvider is returned when no provider can send to a destination.
+var ErrNoProvider = errors.New("no provider for destination")
+
+// SMS is a text message to send with an SMSSender.
+type SMS struct {
+	Originator string
+	Recipients []string
+	Body       string
+
+	// Reference is a client reference, passed on to providers that
+	// support it.
+	Reference string
+}
+
+// SMSResult identifies a sent SMS.
+type SMSResult struct {
+	// Provider is the name of the provider that sent the message. It is
+	// empty for results of the senders of this package that are not used
+	// through Senders.
+	Provider string
+
+	// ID is the ID of the message at the provider.
+	ID string
+}
+
+// SMSSender sends text messages. Implement it to use other backends than
+// MessageBird with Senders.
+type SMSSender interface {
+	SendSMS(ctx context.Context, msg *SMS) (*SMSResult, error)
+}
+
+// OTPSender sends one-time passwords and checks the tokens users enter.
+type OTPSender interface {
+	// SendOTP sends a one-time password to recipient and returns an ID to
+	// check the token with.
+	SendOTP(ctx context.Context, recipient string) (string, error)
+
+	// CheckOTP returns nil if token is the one-time password sent as id,
+	// and an error otherwise, e.g. if it is wrong or expired.
+	CheckOTP(ctx context.Context, id, token string) error
+}
+
+// MessageBird sends SMS and one-time passwords with the MessageBird API.
+// Client may be a Router, to spread the messages over several accounts.
+type MessageBird struct {
+	Client messagebird.Client
+
+	// SMSParams and OTPParams are sent along with every message.
+	SMSParams *sms.Params
+	OTPParams *verify.Params
+}
+
+// SendSMS sends msg with sms.Create.
+func (m *MessageBird) SendSMS(ctx context.Context, msg *SMS) (*SMSResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	params := &sms.Params{}
+	if m.SMSParams != nil {
+		*params = *m.SMSParams
+	}
+	if msg.Reference != "" {
+		params.Reference = msg.Reference
+	}
+
+	message, err := sms.Create(m.Client, msg.Originator, msg.Recipients, msg.Body, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SMSResult{ID: message.ID}, nil
+}
+
+// SendOTP sends a one-time password with verify.Create.
+func (m *MessageBird) SendOTP(ctx context.Context, recipient string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	v, err := verify.Create(m.Client, recipient, m.OTPParams)
+	if err != nil {
+		return "", err
+	}
+
+	return v.ID, nil
+}
+
+// CheckOTP checks token with verify.VerifyToken.
+func (m *MessageBird) CheckOTP(ctx context.Context, id, token string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := verify.VerifyToken(m.Client, id, token)
+	return err
+}
+
+// Provider is a backend Senders can send messages with.
+type Provider struct {
+	// Name identifies the provider. It must not contain a colon.
+	Name string
+
+	// SMS and OTP send the messages. A provider without one is not used
+	// for the kind of message.
+	SMS SMSSender
+	OTP OTPSender
+
+	// Costs maps destination prefixes, in international format without +,
+	// to the price of a message. The longest matching prefix applies. A
+	// provider with costs only sends to the destinations it has a price
+	// for. A provider without costs sends to any destination, after the
+	// providers with a price.
+	Costs map[string]float64
+}
+
+// cost returns the price of sending to destination and whether p sends to
+// it at all.
+func (p *Provider) cost(destination string) (float64, bool) {
+	if len(p.Costs) == 0 {
+		return 0, true
+	}
+
+	best, found := -1, false
+	var cost float64
+	for prefix, c := range p.Costs {
+		if strings.HasPrefix(destination, prefix) && len(prefix) > best {
+			best, cost, found = len(prefix), c, true
+		}
+	}
+
+	return cost, found
+}
+
+// Senders sends messages with the cheapest provider for their destination
+// and fails over to the next cheapest one when a provider fails with an
+// error for which Failover returns true. It implements SMSSender and
+// OTPSender.
+type Senders struct {
+	Providers []*Provider
+
+	// Failover reports whether a message that failed with err is sent with
+	// the next provider. IsProviderError is used if it is nil.
+	Failover func(err error) bool
+}
+
+// SendSMS sends msg with the cheapest provider that sends to all recipients.
+// The price of a message is the sum of the prices of its recipients.
+func (s *Senders) SendSMS(ctx context.Context, msg *SMS) (*SMSResult, error) {
+	destinations := make([]string, len(msg.Recipients))
+	for i, recipient := range msg.Recipients {
+		destinations[i] = msisdn(recipient)
+	}
+
+	providers := s.providers(destinations, func(p *Provider) bool { return p.SMS != nil })
+	if len(providers) == 0 {
+		return nil, ErrNoProvider
+	}
+
+	var err error
+	for _, p := range providers {
+		var result *SMSResult
+		if result, err = p.SMS.SendSMS(ctx, msg); err == nil {
+			result.Provider = p.Name
+			return result, nil
+		}
+		if !s.failover(err) {
+			break
+		}
+	}
+
+	return nil, err
+}
+
+// SendOTP sends a one-time password with the cheapest provider for
+// recipient. The returned ID includes the name of the provider, so
+// CheckOTP checks the token with the same provider.
+func (s *Senders) SendOTP(ctx context.Context, recipient string) (string, error) {
+	providers := s.providers([]string{msisdn(recipient)}, func(p *Provider) bool { return p.OTP != nil })
+	if len(providers) == 0 {
+		return "", ErrNoProvider
+	}
+
+	var err error
+	for _, p := range providers {
+		var id string
+		if id, err = p.OTP.SendOTP(ctx, recipient); err == nil {
+			return p.Name + ":" + id, nil
+		}
+		if !s.failover(err) {
+			break
+		}
+	}
+
+	return "", err
+}
+
+// CheckOTP checks token with the provider that sent the one-time password.
+func (s *Senders) CheckOTP(ctx context.Context, id, token string) error {
+	i := strings.IndexByte(id, ':')
+	if i < 0 {
+		return fmt.Errorf("invalid id %q", id)
+	}
+
+	name := id[:i]
+	for _, p := range s.Providers {
+		if p.Name == name && p.OTP != nil {
+			return p.OTP.CheckOTP(ctx, id[i+1:], token)
+		}
+	}
+
+	return fmt.Errorf("unknown provider %q", name)
+}
+
+// providers returns the providers that send to all destinations and
+// satisfy ok, cheapest first.
+func (s *Senders) providers(destinations []string, ok func(p *Provider) bool) []*Provider {
+	type candidate struct {
+		provider *Provider
+		cost     float64
+		priced   bool
+	}
+
+	var candidates []candidate
+	for _, p := range s.Providers {
+		if !ok(p) {
+			continue
+		}
+
+		c := candidate{provider: p, priced: len(p.Costs) > 0}
+		sends := true
+		for _, destination := range destinations {
+			cost, found := p.cost(destination)
+			if !found {
+				sends = false
+				break
+			}
+			c.cost += cost
+		}
+
+		if sends {
+			candidates = append(candidates, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].priced != candidates[j].priced {
+			return candidates[i].priced
+		}
+		return candidates[i].cost < candidates[j].cost
+	})
+
+	providers := make([]*Provider, len(candidates))
+	for i, c := range candidates {
+		providers[i] = c.provider
+	}
+
+	return providers
+}
+
+func (s *Senders) failover(err error) bool {
+	if s.Failover != nil {
+		return s.Failover(err)
+	}
+
+	return IsProviderError(err)
+}
+
+// IsProviderError reports whether err means the provider could not send a
+// message, but another one might: it is ErrUnavailable or an error for which
+// IsFailoverError returns true.
+func IsProviderError(err error) bool {
+	return errors.Is(err, ErrUnavailable) || IsFailoverError(err)
+}
@@ -0,0 +1,152 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender records the messages it sends and fails with err if set.
+type fakeSender struct {
+	name string
+	err  error
+	sent []string
+}
+
+func (f *fakeSender) SendSMS(ctx context.Context, msg *SMS) (*SMSResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.sent = append(f.sent, msg.Body)
+	return &SMSResult{ID: fmt.Sprintf("%s-%d", f.name, len(f.sent))}, nil
+}
+
+func (f *fakeSender) SendOTP(ctx context.Context, recipient string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	f.sent = append(f.sent, recipient)
+	return "otp-id", nil
+}
+
+func (f *fakeSender) CheckOTP(ctx context.Context, id, token string) error {
+	if token != "1234" {
+		return errors.New("invalid token")
+	}
+
+	return nil
+}
+
+func newTestSenders() (*Senders, *fakeSender, *fakeSender, *fakeSender) {
+	cheap := &fakeSender{name: "cheap"}
+	expensive := &fakeSender{name: "expensive"}
+	fallback := &fakeSender{name: "fallback"}
+
+	return &Senders{Providers: []*Provider{
+		{Name: "fallback", SMS: fallback, OTP: fallback},
+		{Name: "expensive", SMS: expensive, OTP: expensive, Costs: map[string]float64{"31": 0.07, "44": 0.04}},
+		{Name: "cheap", SMS: cheap, Costs: map[string]float64{"31": 0.05, "3197": 0.09}},
+	}}, cheap, expensive, fallback
+}
+
+func TestSendersSMS(t *testing.T) {
+	s, cheap, expensive, fallback := newTestSenders()
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		recipients []string
+		provider   string
+	}{
+		{"cheapest", []string{"31612345678"}, "cheap"},
+		{"longest prefix", []string{"+31 97 12345678"}, "expensive"},
+		{"all recipients", []string{"31612345678", "447700900123"}, "expensive"},
+		{"unpriced", []string{"14155550100"}, "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.SendSMS(ctx, &SMS{Originator: "MessageBird", Recipients: tt.recipients, Body: tt.name})
+			require.NoError(t, err)
+			assert.Equal(t, tt.provider, result.Provider)
+		})
+	}
+
+	assert.Equal(t, []string{"cheapest"}, cheap.sent)
+	assert.Equal(t, []string{"longest prefix", "all recipients"}, expensive.sent)
+	assert.Equal(t, []string{"unpriced"}, fallback.sent)
+}
+
+func TestSendersSMSFailover(t *testing.T) {
+	s, cheap, expensive, fallback := newTestSenders()
+	ctx := context.Background()
+	cheap.err = fmt.Errorf("connecting: %w", ErrUnavailable)
+
+	result, err := s.SendSMS(ctx, &SMS{Recipients: []string{"31612345678"}, Body: "Hello!"})
+	require.NoError(t, err)
+	assert.Equal(t, "expensive", result.Provider)
+	assert.Equal(t, "expensive-1", result.ID)
+
+	// Other errors are returned straight away.
+	expensive.err = errors.New("originator is required")
+	_, err = s.SendSMS(ctx, &SMS{Recipients: []string{"31612345678"}, Body: "Hello!"})
+	assert.EqualError(t, err, "originator is required")
+	assert.Empty(t, fallback.sent)
+
+	_, err = (&Senders{}).SendSMS(ctx, &SMS{Recipients: []string{"31612345678"}})
+	assert.Equal(t, ErrNoProvider, err)
+}
+
+func TestSendersOTP(t *testing.T) {
+	s, _, expensive, fallback := newTestSenders()
+	ctx := context.Background()
+
+	id, err := s.SendOTP(ctx, "31612345678")
+	require.NoError(t, err)
+	assert.Equal(t, "expensive:otp-id", id)
+	assert.Equal(t, []string{"31612345678"}, expensive.sent)
+
+	assert.NoError(t, s.CheckOTP(ctx, id, "1234"))
+	assert.EqualError(t, s.CheckOTP(ctx, id, "0000"), "invalid token")
+	assert.EqualError(t, s.CheckOTP(ctx, "cheap:otp-id", "1234"), `unknown provider "cheap"`)
+	assert.EqualError(t, s.CheckOTP(ctx, "otp-id", "1234"), `invalid id "otp-id"`)
+
+	expensive.err = ErrUnavailable
+	id, err = s.SendOTP(ctx, "31612345678")
+	require.NoError(t, err)
+	assert.Equal(t, "fallback:otp-id", id)
+	assert.Equal(t, []string{"31612345678"}, fallback.sent)
+}
+
+func TestMessageBirdSender(t *testing.T) {
+	server := messagebirdtest.NewServer(t)
+	primary := messagebirdtest.NewServer(t)
+	primary.WillReturnAccessKeyError()
+
+	s := &Senders{Providers: []*Provider{
+		{Name: "primary", SMS: &MessageBird{Client: primary.Client()}},
+		{Name: "secondary", SMS: &MessageBird{Client: server.Client()}},
+	}}
+
+	server.WillReturn([]byte(`{"id": "message-id"}`), http.StatusCreated)
+	result, err := s.SendSMS(context.Background(), &SMS{
+		Originator: "MessageBird",
+		Recipients: []string{"31612345678"},
+		Body:       "Hello!",
+		Reference:  "reference",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &SMSResult{Provider: "secondary", ID: "message-id"}, result)
+
+	server.AssertEndpointCalled(http.MethodPost, "/messages")
+	server.AssertBodyField("reference", "reference")
+	assert.Len(t, primary.Requests(), 1)
+}
@@ -0,0 +1,283 @@
+// Package scheduler paces requests from several kinds of traffic that share
+// the rate limit of one account. Every request has a Priority, and every
+// priority gets a share of the rate limit, so bulk campaigns can not starve
+// one-time passwords sent through the same client:
+//
+//	s := &scheduler.Scheduler{Client: client, Rate: 50}
+//	go s.Run(ctx)
+//
+//	otp := s.WithPriority(scheduler.PriorityOTP)
+//	msg, err := sms.Create(otp, "MessageBird", []string{"31612345678"}, "Your code is 1234", nil)
+//
+// Shares only matter when the rate limit is reached: capacity that a
+// priority does not use goes to the others.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// DefaultRate is the number of requests per second of a Scheduler without
+// Rate.
+const DefaultRate = 10
+
+// Priority is the class of traffic a request belongs to.
+type Priority int
+
+const (
+	// PriorityOTP is for one-time passwords and other messages users are
+	// waiting for.
+	PriorityOTP Priority = iota
+
+	// PriorityTransactional is for notifications triggered by the user,
+	// such as order confirmations.
+	PriorityTransactional
+
+	// PriorityMarketing is for bulk campaigns.
+	PriorityMarketing
+
+	numPriorities = iota
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityOTP:
+		return "otp"
+	case PriorityTransactional:
+		return "transactional"
+	case PriorityMarketing:
+		return "marketing"
+	default:
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+}
+
+func (p Priority) valid() bool {
+	return p >= 0 && p < numPriorities
+}
+
+// DefaultShares are the shares of the rate limit of a Scheduler without
+// Shares.
+var DefaultShares = map[Priority]float64{
+	PriorityOTP:           0.6,
+	PriorityTransactional: 0.3,
+	PriorityMarketing:     0.1,
+}
+
+// Scheduler hands out requests to the API at Rate, dividing the rate between
+// priorities by their Shares. Run must be running for requests to be sent.
+// The fields must not be changed once Run is called.
+type Scheduler struct {
+	Client messagebird.Client
+
+	// Rate is the number of requests per second, typically the rate limit
+	// of the account. DefaultRate is used if it is zero.
+	Rate float64
+
+	// Shares are the relative shares of Rate each priority gets when
+	// requests of several priorities are waiting. They do not have to add up
+	// to 1. DefaultShares is used if it is nil, and the default share of a
+	// priority if it is missing or not positive.
+	Shares map[Priority]float64
+
+	mu      sync.Mutex
+	queues  [numPriorities][]*waiter
+	pass    [numPriorities]float64
+	vtime   float64
+	waiting chan struct{}
+}
+
+// waiter is a request waiting for its turn.
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// Run hands out turns to waiting requests, at most one every 1/Rate seconds.
+// It blocks until ctx is done and returns ctx.Err().
+func (s *Scheduler) Run(ctx context.Context) error {
+	clock := messagebird.ClockOf(s.Client)
+
+	rate := s.Rate
+	if rate <= 0 {
+		rate = DefaultRate
+	}
+	interval := time.Duration(float64(time.Second) / rate)
+
+	waiting := s.waitingChan()
+
+	for {
+		if !s.grant() {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-waiting:
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
+}
+
+// Do waits for a turn of priority p and then calls send with the Client. It
+// returns the error of send, or ctx.Err() if ctx is done first.
+func (s *Scheduler) Do(ctx context.Context, p Priority, send func(c messagebird.Client) error) error {
+	if !p.valid() {
+		return fmt.Errorf("unknown priority %d", int(p))
+	}
+
+	w := s.enqueue(p)
+
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		if !s.cancel(p, w) {
+			// The turn was granted at the same time. It is lost, but
+			// the request was not sent, so report that.
+			<-w.ready
+		}
+		return ctx.Err()
+	}
+
+	return send(s.Client)
+}
+
+// WithPriority returns a Client that sends every request through s with
+// priority p, for use with the functions of the other packages. Requests
+// wait for their turn without a deadline; use Do to cancel waiting.
+func (s *Scheduler) WithPriority(p Priority) messagebird.Client {
+	return &priorityClient{scheduler: s, priority: p}
+}
+
+// Waiting returns the number of requests of priority p waiting for a turn.
+func (s *Scheduler) Waiting(p Priority) int {
+	if !p.valid() {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.queues[p])
+}
+
+func (s *Scheduler) enqueue(p Priority) *waiter {
+	w := &waiter{ready: make(chan struct{})}
+
+	s.mu.Lock()
+	// A priority that was idle starts at the current virtual time, so it
+	// does not get a burst of turns for the time it did not use.
+	if len(s.queues[p]) == 0 {
+		s.pass[p] = math.Max(s.pass[p], s.vtime)
+	}
+	s.queues[p] = append(s.queues[p], w)
+	waiting := s.waitingLocked()
+	s.mu.Unlock()
+
+	select {
+	case waiting <- struct{}{}:
+	default:
+	}
+
+	return w
+}
+
+// cancel removes w from the queue of p. It returns false if w was granted a
+// turn already.
+func (s *Scheduler) cancel(p Priority, w *waiter) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w.granted {
+		return false
+	}
+
+	queue := s.queues[p]
+	for i := range queue {
+		if queue[i] == w {
+			s.queues[p] = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// grant gives a turn to the first request of the priority that is furthest
+// behind its share, using stride scheduling. It returns false if no request
+// is waiting.
+func (s *Scheduler) grant() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := -1
+	for p := 0; p < numPriorities; p++ {
+		if len(s.queues[p]) > 0 && (next < 0 || s.pass[p] < s.pass[next]) {
+			next = p
+		}
+	}
+	if next < 0 {
+		return false
+	}
+
+	w := s.queues[next][0]
+	s.queues[next] = s.queues[next][1:]
+	w.granted = true
+	close(w.ready)
+
+	s.vtime = s.pass[next]
+	s.pass[next] += 1 / s.share(Priority(next))
+
+	return true
+}
+
+func (s *Scheduler) share(p Priority) float64 {
+	if share := s.Shares[p]; share > 0 {
+		return share
+	}
+
+	return DefaultShares[p]
+}
+
+func (s *Scheduler) waitingChan() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.waitingLocked()
+}
+
+// waitingLocked returns the channel Run waits on for new requests. s.mu must
+// be held.
+func (s *Scheduler) waitingLocked() chan struct{} {
+	if s.waiting == nil {
+		s.waiting = make(chan struct{}, 1)
+	}
+
+	return s.waiting
+}
+
+type priorityClient struct {
+	scheduler *Scheduler
+	priority  Priority
+}
+
+func (c *priorityClient) Request(v interface{}, method, path string, data interface{}) error {
+	return c.scheduler.Do(context.Background(), c.priority, func(client messagebird.Client) error {
+		return client.Request(v, method, path, data)
+	})
+}
+
+func (c *priorityClient) Unwrap() messagebird.Client {
+	return c.scheduler.Client
+}
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScheduler(t *testing.T) (*Scheduler, *messagebirdtest.FakeClock) {
+	clock := messagebirdtest.NewFakeClock(time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC))
+	client := messagebird.New("test_accesskey")
+	client.Clock = clock
+
+	return &Scheduler{Client: client, Rate: 1}, clock
+}
+
+// queue starts n requests of priority p, which report their priority on
+// sent once they get a turn, and waits until all of them are waiting.
+func queue(t *testing.T, s *Scheduler, p Priority, n int, sent chan<- Priority) {
+	want := s.Waiting(p) + n
+	for i := 0; i < n; i++ {
+		go s.Do(context.Background(), p, func(messagebird.Client) error {
+			sent <- p
+			return nil
+		})
+	}
+
+	require.Eventually(t, func() bool { return s.Waiting(p) == want }, time.Second, time.Millisecond)
+}
+
+// turns returns the priorities of the next n requests that get a turn, in
+// order. The clock is advanced between turns, but not after the last one.
+func turns(clock *messagebirdtest.FakeClock, sent <-chan Priority, n int) []Priority {
+	var got []Priority
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			tick(clock)
+		}
+		got = append(got, <-sent)
+	}
+
+	return got
+}
+
+// tick waits for the scheduler to wait for the next turn, and then lets it.
+func tick(clock *messagebirdtest.FakeClock) {
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+}
+
+func run(t *testing.T, s *Scheduler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestShares(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	s.Shares = map[Priority]float64{PriorityOTP: 2, PriorityTransactional: 1, PriorityMarketing: 1}
+
+	sent := make(chan Priority)
+	queue(t, s, PriorityMarketing, 10, sent)
+	queue(t, s, PriorityTransactional, 10, sent)
+	queue(t, s, PriorityOTP, 10, sent)
+	run(t, s)
+
+	got := turns(clock, sent, 8)
+	assert.Equal(t, []Priority{
+		PriorityOTP, PriorityTransactional, PriorityMarketing, PriorityOTP,
+		PriorityOTP, PriorityTransactional, PriorityMarketing, PriorityOTP,
+	}, got)
+}
+
+func TestOTPNotStarved(t *testing.T) {
+	s, clock := newTestScheduler(t)
+
+	sent := make(chan Priority)
+	queue(t, s, PriorityMarketing, 20, sent)
+	run(t, s)
+
+	// Marketing gets all turns while nothing else is waiting.
+	assert.Equal(t, []Priority{PriorityMarketing, PriorityMarketing, PriorityMarketing}, turns(clock, sent, 3))
+
+	// An OTP gets the next turn, and the time it was idle does not give it
+	// a burst of turns after that.
+	queue(t, s, PriorityOTP, 20, sent)
+	tick(clock)
+	got := turns(clock, sent, 10)
+	assert.Equal(t, PriorityOTP, got[0])
+	assert.Contains(t, got, PriorityMarketing)
+}
+
+func TestDoCancel(t *testing.T) {
+	s, _ := newTestScheduler(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Do(ctx, PriorityMarketing, func(messagebird.Client) error {
+			t.Error("unexpected send")
+			return nil
+		})
+	}()
+
+	require.Eventually(t, func() bool { return s.Waiting(PriorityMarketing) == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Zero(t, s.Waiting(PriorityMarketing))
+}
+
+func TestDoUnknownPriority(t *testing.T) {
+	s, _ := newTestScheduler(t)
+
+	err := s.Do(context.Background(), Priority(7), func(messagebird.Client) error { return nil })
+	assert.EqualError(t, err, "unknown priority 7")
+}
+
+func TestWithPriority(t *testing.T) {
+	server := messagebirdtest.NewServer(t)
+	s := &Scheduler{Client: server.Client()}
+	run(t, s)
+
+	_, err := sms.Create(s.WithPriority(PriorityOTP), "MessageBird", []string{"31612345678"}, "Your code is 1234", nil)
+	assert.NoError(t, err)
+	server.AssertEndpointCalled(http.MethodPost, "/messages")
+}
+
+func TestPriorityString(t *testing.T) {
+	assert.Equal(t, "otp", PriorityOTP.String())
+	assert.Equal(t, "marketing", PriorityMarketing.String())
+	assert.Equal(t, "Priority(7)", Priority(7).String())
+}
+
+func TestWithPriorityUnwrap(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	s.Client.(*messagebird.DefaultClient).APIVersions = map[string]string{"conversations": "v2"}
+
+	client := s.WithPriority(PriorityTransactional)
+	assert.Equal(t, clock, messagebird.ClockOf(client))
+	assert.Equal(t, "v2", messagebird.APIVersionOf(client, "conversations", "v1"))
+}
@@ -0,0 +1,173 @@
+// Package segment selects contacts that match a predicate and adds them to a
+// group, so targeted messages can be sent to the group (see sms.Params.GroupIds).
+package segment
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/group"
+	"github.com/messagebird/go-rest-api/v9/internal/paging"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+)
+
+// DefaultPageSize is the number of contacts Materialize scans at once when no
+// page size is set in Options.
+const DefaultPageSize = 50
+
+// A Predicate reports whether a contact belongs to a segment.
+type Predicate func(c *contact.Contact) bool
+
+// Country matches contacts whose MSISDN belongs to a country, identified by
+// its ISO 3166-1 alpha-2 code (e.g. NL). Countries sharing a calling code,
+// like the US and Canada, can not be told apart.
+func Country(country string) Predicate {
+	callingCode, ok := numberutil.CallingCode(country)
+
+	return func(c *contact.Contact) bool {
+		return ok && strings.HasPrefix(strconv.FormatInt(c.MSISDN, 10), callingCode)
+	}
+}
+
+// CustomEquals matches contacts whose custom attribute field equals value.
+func CustomEquals(field contact.CustomField, value string) Predicate {
+	return func(c *contact.Contact) bool {
+		return c.CustomDetails.Get(field) == value
+	}
+}
+
+// OptedIn matches contacts that have opted in, which is stored as a boolean
+// in the custom attribute field. Contacts without a valid boolean do not
+// match.
+func OptedIn(field contact.CustomField) Predicate {
+	return func(c *contact.Contact) bool {
+		optedIn, err := c.CustomDetails.Bool(field)
+		return err == nil && optedIn
+	}
+}
+
+// All matches contacts that match all predicates.
+func All(predicates ...Predicate) Predicate {
+	return func(c *contact.Contact) bool {
+		for _, p := range predicates {
+			if !p(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any matches contacts that match at least one of the predicates.
+func Any(predicates ...Predicate) Predicate {
+	return func(c *contact.Contact) bool {
+		for _, p := range predicates {
+			if p(c) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches contacts that do not match p.
+func Not(p Predicate) Predicate {
+	return func(c *contact.Contact) bool {
+		return !p(c)
+	}
+}
+
+// Checkpoint records how far Materialize got. Pass it to a later call to
+// resume where it left off.
+type Checkpoint struct {
+	// Offset is the number of contacts that have been scanned and, if they
+	// matched, added to the group.
+	Offset int `json:"offset"`
+}
+
+// Options configures Materialize.
+type Options struct {
+	// PageSize is the number of contacts scanned at once. Defaults to
+	// DefaultPageSize.
+	PageSize int
+
+	// Checkpoint resumes a previous run. Leave nil to scan all contacts.
+	Checkpoint *Checkpoint
+
+	// OnCheckpoint, if set, is called after every page, e.g. to persist the
+	// checkpoint so an interrupted run can be resumed.
+	OnCheckpoint func(Checkpoint)
+}
+
+// Result summarizes a Materialize run.
+type Result struct {
+	Scanned    int
+	Matched    int
+	Checkpoint Checkpoint
+}
+
+// Materialize scans contacts page by page and adds the ones matching p to the
+// group identified by groupID. Contacts that are already a member are added
+// again, which has no effect. Contacts are never removed from the group.
+//
+// On error, including ctx being done, the result contains the checkpoint to
+// resume from: contacts before it have been processed.
+func Materialize(ctx context.Context, c messagebird.Client, groupID string, p Predicate, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	result := &Result{}
+	if opts.Checkpoint != nil {
+		result.Checkpoint = *opts.Checkpoint
+	}
+
+	pager := paging.Pager{Offset: result.Checkpoint.Offset}
+	for !pager.Done() {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		pager.Fetch(func(offset int) (int, int, error) {
+			page, err := contact.List(c, &messagebird.PaginationRequest{Limit: pageSize, Offset: offset})
+			if err != nil {
+				return 0, 0, err
+			}
+
+			var ids []string
+			for i := range page.Items {
+				if p(&page.Items[i]) {
+					ids = append(ids, page.Items[i].ID)
+				}
+			}
+
+			if len(ids) > 0 {
+				if err := group.AddContacts(c, groupID, ids); err != nil {
+					return 0, 0, err
+				}
+			}
+
+			result.Scanned += len(page.Items)
+			result.Matched += len(ids)
+			return len(page.Items), page.TotalCount, nil
+		})
+		if err := pager.Err(); err != nil {
+			return result, err
+		}
+
+		result.Checkpoint.Offset = pager.Offset
+		if opts.OnCheckpoint != nil {
+			opts.OnCheckpoint(result.Checkpoint)
+		}
+	}
+
+	return result, nil
+}
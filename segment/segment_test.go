@@ -0,0 +1,102 @@
+package segment
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/contact"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var contacts = []contact.Contact{
+	{ID: "nl-in", MSISDN: 31612345678, CustomDetails: contact.CustomDetails{Custom1: "true", Custom2: "gold"}},
+	{ID: "nl-out", MSISDN: 31612345679, CustomDetails: contact.CustomDetails{Custom1: "false", Custom2: "gold"}},
+	{ID: "be-in", MSISDN: 32470123456, CustomDetails: contact.CustomDetails{Custom1: "1", Custom2: "silver"}},
+	{ID: "nl-unknown", MSISDN: 31687654321},
+}
+
+func TestPredicates(t *testing.T) {
+	match := func(p Predicate) []string {
+		var ids []string
+		for i := range contacts {
+			if p(&contacts[i]) {
+				ids = append(ids, contacts[i].ID)
+			}
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"nl-in", "nl-out", "nl-unknown"}, match(Country("nl")))
+	assert.Empty(t, match(Country("XX")))
+	assert.Equal(t, []string{"nl-in", "be-in"}, match(OptedIn(contact.CustomField1)))
+	assert.Equal(t, []string{"nl-in"}, match(All(Country("NL"), OptedIn(contact.CustomField1))))
+	assert.Equal(t, []string{"nl-in", "nl-out", "be-in"}, match(Any(CustomEquals(contact.CustomField2, "gold"), Country("BE"))))
+	assert.Equal(t, []string{"be-in"}, match(Not(Country("NL"))))
+}
+
+// listClient serves contacts and records the bodies of requests adding
+// contacts to a group. It fails when failAt is requested as offset.
+func listClient(added *[]string, failAt int) mbtest.ClientFunc {
+	return func(v interface{}, method, path string, data interface{}) error {
+		if method != "GET" {
+			*added = append(*added, method+" "+path+" "+data.(string))
+			return nil
+		}
+
+		u, _ := url.Parse(path)
+		offset, _ := strconv.Atoi(u.Query().Get("offset"))
+		limit, _ := strconv.Atoi(u.Query().Get("limit"))
+		if offset == failAt {
+			return errors.New("API error")
+		}
+
+		list := v.(*contact.Contacts)
+		list.TotalCount = len(contacts)
+		for i := offset; i < offset+limit && i < len(contacts); i++ {
+			list.Items = append(list.Items, contacts[i])
+		}
+		return nil
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	var added []string
+	var checkpoints []int
+
+	result, err := Materialize(context.Background(), listClient(&added, -1), "group-id", OptedIn(contact.CustomField1), &Options{
+		PageSize:     2,
+		OnCheckpoint: func(cp Checkpoint) { checkpoints = append(checkpoints, cp.Offset) },
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &Result{Scanned: 4, Matched: 2, Checkpoint: Checkpoint{Offset: 4}}, result)
+	assert.Equal(t, []int{2, 4}, checkpoints)
+	assert.Equal(t, []string{
+		"PUT groups/group-id/contacts ids[]=nl-in",
+		"PUT groups/group-id/contacts ids[]=be-in",
+	}, added)
+}
+
+func TestMaterializeResume(t *testing.T) {
+	var added []string
+
+	result, err := Materialize(context.Background(), listClient(&added, 2), "group-id", Country("NL"), &Options{PageSize: 2})
+	assert.EqualError(t, err, "API error")
+	assert.Equal(t, 2, result.Checkpoint.Offset)
+	assert.Equal(t, []string{"PUT groups/group-id/contacts ids[]=nl-in&ids[]=nl-out"}, added)
+
+	added = nil
+	result, err = Materialize(context.Background(), listClient(&added, -1), "group-id", Country("NL"), &Options{
+		PageSize:   2,
+		Checkpoint: &result.Checkpoint,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Scanned)
+	assert.Equal(t, 4, result.Checkpoint.Offset)
+	assert.Equal(t, 1, strings.Count(strings.Join(added, ","), "nl-unknown"))
+}
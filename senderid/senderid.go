@@ -0,0 +1,311 @@
+// Package senderid checks originators against the sender ID rules of the
+// destination country before messages are sent, so messages are not
+// rejected, or sent from another originator than intended, by operators:
+//
+//	p := &senderid.Policy{
+//		Registered: map[string][]string{"IN": {"MSGBRD"}, "US": {"+18005550100"}},
+//		Fallback:   map[string]string{"US": "+18005550100"},
+//	}
+//	req, err := p.CheckSMS(&sms.SendRequest{Originator: "MessageBird", ...})
+//	if err != nil {
+//		// The originator can not be used and there is no fallback.
+//	}
+//	msg, err := sms.Send(client, req)
+//
+// The rules are kept in a Table. DefaultTable has the rules of a number of
+// countries with well known restrictions, which change over time: check them
+// against the capabilities of your account, see package capabilities, and
+// update the table with your own rules where needed.
+package senderid
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/messagebird/go-rest-api/v9/capabilities"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
+	"github.com/messagebird/go-rest-api/v9/sms"
+)
+
+// ErrOriginatorReplaced is returned, wrapped, for countries in which
+// operators replace the originator of messages, unless
+// Policy.AllowReplaced is set. Originators that are not allowed, or not
+// registered, are reported with capabilities.ErrOriginatorNotAllowed and
+// capabilities.ErrRegistrationRequired.
+var ErrOriginatorReplaced = errors.New("originator replaced by operators")
+
+//go:embed rules.json
+var defaultRules []byte
+
+// Rule is the sender ID rule of a destination country.
+type Rule struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country, e.g. "NL".
+	Country string `json:"country"`
+
+	// Allowed are the types of originators that can be used. All types can
+	// be used if it is empty.
+	Allowed []capabilities.OriginatorType `json:"allowed,omitempty"`
+
+	// RegistrationRequired are the types of originators that must be
+	// registered before they can be used.
+	RegistrationRequired []capabilities.OriginatorType `json:"registrationRequired,omitempty"`
+
+	// Forced is the originator all messages to the country are sent from,
+	// e.g. a number of the account. Any other originator is rewritten to
+	// it.
+	Forced string `json:"forced,omitempty"`
+
+	// Replaced is true if operators replace the originator of messages with
+	// one of their own, so it can not be chosen.
+	Replaced bool `json:"replaced,omitempty"`
+}
+
+func (r *Rule) allows(t capabilities.OriginatorType) bool {
+	return len(r.Allowed) == 0 || containsType(r.Allowed, t)
+}
+
+func containsType(types []capabilities.OriginatorType, t capabilities.OriginatorType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Table holds the rules per country. It is safe for concurrent use.
+type Table struct {
+	mu    sync.RWMutex
+	rules map[string]*Rule
+}
+
+var (
+	defaultTable     *Table
+	defaultTableOnce sync.Once
+)
+
+// DefaultTable returns the table with the rules embedded in this package.
+// Rules set on it apply to all policies without a Table.
+func DefaultTable() *Table {
+	defaultTableOnce.Do(func() {
+		t, err := ParseTable(bytes.NewReader(defaultRules))
+		if err != nil {
+			panic(fmt.Sprintf("senderid: invalid embedded rules: %v", err))
+		}
+		defaultTable = t
+	})
+
+	return defaultTable
+}
+
+// NewTable returns a table with rules.
+func NewTable(rules ...*Rule) *Table {
+	t := &Table{rules: make(map[string]*Rule, len(rules))}
+	for _, rule := range rules {
+		t.Set(rule)
+	}
+
+	return t
+}
+
+// ParseTable reads a table from a JSON array of rules, as in rules.json.
+func ParseTable(r io.Reader) (*Table, error) {
+	var rules []*Rule
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, err
+	}
+
+	for i, rule := range rules {
+		if rule.Country == "" {
+			return nil, fmt.Errorf("rule %d: country is required", i)
+		}
+	}
+
+	return NewTable(rules...), nil
+}
+
+// Set adds rule to the table, replacing the rule of its country.
+func (t *Table) Set(rule *Rule) {
+	copied := *rule
+	copied.Country = strings.ToUpper(rule.Country)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rules[copied.Country] = &copied
+}
+
+// Rule returns the rule of country, or false if it has none.
+func (t *Table) Rule(country string) (*Rule, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rule, ok := t.rules[strings.ToUpper(country)]
+	if !ok {
+		return nil, false
+	}
+
+	copied := *rule
+	return &copied, true
+}
+
+// Policy checks originators against the rules of a Table.
+type Policy struct {
+	// Table holds the rules. DefaultTable is used if it is nil.
+	Table *Table
+
+	// Registered are the originators registered by the account, per
+	// ISO 3166-1 alpha-2 country code.
+	Registered map[string][]string
+
+	// Fallback are the originators used, per country code, instead of
+	// originators that can not be used in the country. Originators that
+	// can not be used are rejected for countries without a fallback.
+	Fallback map[string]string
+
+	// AllowReplaced allows sending to countries in which operators replace
+	// the originator. Such messages are rejected otherwise.
+	AllowReplaced bool
+}
+
+// Decision is the outcome of checking an originator.
+type Decision struct {
+	// Country is the country of the recipient, or empty if it is unknown.
+	Country string
+
+	// Originator is the originator to send from. It differs from the
+	// checked originator if Rewritten is true.
+	Originator string
+	Rewritten  bool
+
+	// Reason explains why the originator was rewritten.
+	Reason string
+}
+
+// Check checks whether originator can be used to send to recipient, an
+// MSISDN. Messages to countries without a rule, and to recipients of which
+// the country is unknown, are sent from originator as is.
+func (p *Policy) Check(originator, recipient string) (*Decision, error) {
+	country, ok := numberutil.Country(recipient)
+	if !ok {
+		return &Decision{Originator: originator}, nil
+	}
+
+	decision := &Decision{Country: country, Originator: originator}
+
+	table := p.Table
+	if table == nil {
+		table = DefaultTable()
+	}
+
+	rule, ok := table.Rule(country)
+	if !ok {
+		return decision, nil
+	}
+
+	if rule.Forced != "" {
+		if originator != rule.Forced {
+			decision.rewrite(rule.Forced, fmt.Sprintf("messages to %s are sent from %s", country, rule.Forced))
+		}
+		return decision, nil
+	}
+
+	if rule.Replaced && !p.AllowReplaced {
+		return nil, fmt.Errorf("%w: in %s", ErrOriginatorReplaced, country)
+	}
+
+	err := p.validate(rule, originator)
+	if err == nil {
+		return decision, nil
+	}
+
+	fallback, ok := p.Fallback[country]
+	if !ok {
+		return nil, err
+	}
+	if fallbackErr := p.validate(rule, fallback); fallbackErr != nil {
+		return nil, fmt.Errorf("fallback for %s: %w", country, fallbackErr)
+	}
+
+	decision.rewrite(fallback, err.Error())
+	return decision, nil
+}
+
+func (d *Decision) rewrite(originator, reason string) {
+	d.Originator, d.Rewritten, d.Reason = originator, true, reason
+}
+
+// validate returns an error if originator can not be used under rule.
+func (p *Policy) validate(rule *Rule, originator string) error {
+	if originator == "" {
+		return errors.New("originator is required")
+	}
+
+	t := capabilities.OriginatorTypeOf(originator)
+	if !rule.allows(t) {
+		return fmt.Errorf("%w: %s originators in %s", capabilities.ErrOriginatorNotAllowed, t, rule.Country)
+	}
+
+	if containsType(rule.RegistrationRequired, t) && !p.registered(rule.Country, originator) {
+		return fmt.Errorf("%w: %q in %s", capabilities.ErrRegistrationRequired, originator, rule.Country)
+	}
+
+	return nil
+}
+
+func (p *Policy) registered(country, originator string) bool {
+	for _, r := range p.Registered[country] {
+		if strings.EqualFold(normalize(r), normalize(originator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize strips the leading + and spaces of numeric originators.
+func normalize(originator string) string {
+	return strings.Replace(strings.TrimPrefix(strings.TrimSpace(originator), "+"), " ", "", -1)
+}
+
+// CheckSMS checks the originator of req for all its recipients, see Check.
+// It returns req, or a copy with the originator rewritten. Recipients that
+// need different originators must be sent separate messages: CheckSMS
+// returns an error for them. Recipients in groups are not checked.
+func (p *Policy) CheckSMS(req *sms.SendRequest) (*sms.SendRequest, error) {
+	if req == nil {
+		return nil, errors.New("request is required")
+	}
+
+	var first *Decision
+	for _, recipient := range req.Recipients {
+		decision, err := p.Check(req.Originator, recipient)
+		if err != nil {
+			return nil, err
+		}
+
+		if first == nil {
+			first = decision
+			continue
+		}
+		if decision.Originator != first.Originator {
+			return nil, fmt.Errorf("recipients in %s and %s need different originators, send them separate messages", first.Country, decision.Country)
+		}
+	}
+
+	if first == nil || !first.Rewritten {
+		return req, nil
+	}
+
+	rewritten := *req
+	rewritten.Originator = first.Originator
+
+	return &rewritten, nil
+}
@@ -0,0 +1,104 @@
+package senderid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/capabilities"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTable(t *testing.T) {
+	rule, ok := DefaultTable().Rule("us")
+	assert.True(t, ok)
+	assert.Equal(t, []capabilities.OriginatorType{capabilities.OriginatorTypeNumeric, capabilities.OriginatorTypeShortcode}, rule.Allowed)
+
+	_, ok = DefaultTable().Rule("NL")
+	assert.False(t, ok)
+}
+
+func TestParseTable(t *testing.T) {
+	table, err := ParseTable(strings.NewReader(`[{"country": "nl", "forced": "MessageBird"}]`))
+	assert.NoError(t, err)
+
+	rule, ok := table.Rule("NL")
+	assert.True(t, ok)
+	assert.Equal(t, "MessageBird", rule.Forced)
+
+	_, err = ParseTable(strings.NewReader(`[{"forced": "MessageBird"}]`))
+	assert.EqualError(t, err, "rule 0: country is required")
+}
+
+func TestCheck(t *testing.T) {
+	p := &Policy{
+		Registered: map[string][]string{"IN": {"MSGBRD"}, "US": {"14155550100"}},
+		Fallback:   map[string]string{"US": "+1 415 555 0100"},
+	}
+
+	tt := []struct {
+		originator, recipient string
+		expected              string
+		rewritten             bool
+		err                   error
+	}{
+		{"MessageBird", "31612345678", "MessageBird", false, nil},
+		{"MSGBRD", "919876543210", "MSGBRD", false, nil},
+		{"OTHER", "919876543210", "", false, capabilities.ErrRegistrationRequired},
+		{"MessageBird", "14155552671", "+1 415 555 0100", true, nil},
+		{"31612345678", "919876543210", "", false, capabilities.ErrOriginatorNotAllowed},
+		{"MessageBird", "8613812345678", "", false, ErrOriginatorReplaced},
+	}
+
+	for _, tc := range tt {
+		decision, err := p.Check(tc.originator, tc.recipient)
+		if tc.err != nil {
+			assert.True(t, errors.Is(err, tc.err), "%s to %s: %v", tc.originator, tc.recipient, err)
+			continue
+		}
+
+		assert.NoError(t, err, tc.recipient)
+		assert.Equal(t, tc.expected, decision.Originator, tc.recipient)
+		assert.Equal(t, tc.rewritten, decision.Rewritten, tc.recipient)
+	}
+
+	decision, err := p.Check("MessageBird", "14155552671")
+	assert.NoError(t, err)
+	assert.Equal(t, "US", decision.Country)
+	assert.Equal(t, "originator type not allowed: alphanumeric originators in US", decision.Reason)
+
+	p.AllowReplaced = true
+	decision, err = p.Check("MessageBird", "8613812345678")
+	assert.NoError(t, err)
+	assert.Equal(t, "MessageBird", decision.Originator)
+}
+
+func TestCheckForced(t *testing.T) {
+	p := &Policy{Table: NewTable(&Rule{Country: "NL", Forced: "3197000000"})}
+
+	decision, err := p.Check("MessageBird", "31612345678")
+	assert.NoError(t, err)
+	assert.Equal(t, &Decision{Country: "NL", Originator: "3197000000", Rewritten: true, Reason: "messages to NL are sent from 3197000000"}, decision)
+}
+
+func TestCheckSMS(t *testing.T) {
+	p := &Policy{
+		Registered: map[string][]string{"US": {"14155550100"}},
+		Fallback:   map[string]string{"US": "14155550100"},
+	}
+
+	req := &sms.SendRequest{Originator: "MessageBird", Recipients: []string{"14155552671", "14155552672"}, Body: "Hi"}
+	rewritten, err := p.CheckSMS(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "14155550100", rewritten.Originator)
+	assert.Equal(t, "MessageBird", req.Originator)
+
+	req = &sms.SendRequest{Originator: "MessageBird", Recipients: []string{"31612345678"}}
+	unchanged, err := p.CheckSMS(req)
+	assert.NoError(t, err)
+	assert.Same(t, req, unchanged)
+
+	_, err = p.CheckSMS(&sms.SendRequest{Originator: "MessageBird", Recipients: []string{"31612345678", "14155552671"}})
+	assert.EqualError(t, err, "recipients in NL and US need different originators, send them separate messages")
+}
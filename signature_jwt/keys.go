@@ -0,0 +1,228 @@
+package signature_jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/messagebird/go-rest-api/v9/internal/queue"
+)
+
+// DefaultKeyRefreshInterval is how long RefreshingKeys caches keys when no
+// interval is set.
+const DefaultKeyRefreshInterval = 15 * time.Minute
+
+// minKeyRefreshInterval limits how often RefreshingKeys fetches keys when it
+// receives tokens signed with an unknown key ID, so such tokens can not be
+// used to flood the key source with requests.
+const minKeyRefreshInterval = 30 * time.Second
+
+// Key is a webhook signing key. ID is optional: it is matched against the kid
+// header of a token, if present.
+type Key struct {
+	ID     string `json:"id"`
+	Secret []byte `json:"secret"`
+}
+
+// KeyProvider provides the signing keys a token may be signed with.
+type KeyProvider interface {
+	// SigningKeys returns the candidate keys for a token with the given key
+	// ID, which is empty if the token does not specify one.
+	SigningKeys(ctx context.Context, kid string) ([]Key, error)
+}
+
+// StaticKeys returns a KeyProvider for a fixed set of keys. During a rotation,
+// configure both the old and the new key.
+func StaticKeys(keys ...Key) KeyProvider {
+	return staticKeys(keys)
+}
+
+type staticKeys []Key
+
+func (s staticKeys) SigningKeys(_ context.Context, kid string) ([]Key, error) {
+	return selectKeys(s, kid), nil
+}
+
+// selectKeys returns the keys with the given ID and the keys without an ID.
+// All keys are returned if kid is empty.
+func selectKeys(keys []Key, kid string) []Key {
+	if kid == "" {
+		return keys
+	}
+
+	var selected []Key
+	for _, k := range keys {
+		if k.ID == kid || k.ID == "" {
+			selected = append(selected, k)
+		}
+	}
+
+	return selected
+}
+
+// hasKey reports whether keys contains a key with ID kid.
+func hasKey(keys []Key, kid string) bool {
+	for _, k := range keys {
+		if k.ID == kid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RefreshingKeys is a KeyProvider that fetches keys from a configuration
+// source or endpoint and caches them. Keys are fetched again once Interval
+// has passed, or earlier when a token carries a key ID that is not cached.
+//
+// Only one fetch runs at a time. Meanwhile, and after failed fetches, the
+// previously fetched keys are used. Failed fetches are retried with a backoff
+// that starts at 30 seconds and doubles up to Interval.
+type RefreshingKeys struct {
+	// Fetch returns the current set of keys. See FetchKeysFromURL.
+	Fetch func(ctx context.Context) ([]Key, error)
+
+	// Interval is how long fetched keys are used. Defaults to
+	// DefaultKeyRefreshInterval.
+	Interval time.Duration
+
+	mu          sync.Mutex
+	keys        []Key
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	failures    int
+	err         error
+
+	// fetching is closed when the fetch in progress, if any, is done.
+	fetching chan struct{}
+}
+
+// SigningKeys implements KeyProvider. If fetching fails, the previously
+// fetched keys are used, if any.
+func (p *RefreshingKeys) SigningKeys(ctx context.Context, kid string) ([]Key, error) {
+	p.mu.Lock()
+	for p.fetching != nil && p.keys == nil {
+		// There is nothing to use yet: wait for the fetch in progress.
+		fetching := p.fetching
+		p.mu.Unlock()
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		p.mu.Lock()
+	}
+
+	if p.fetching != nil || !p.shouldFetch(kid) {
+		defer p.mu.Unlock()
+		return p.selectKeys(kid)
+	}
+
+	fetching := make(chan struct{})
+	p.fetching = fetching
+	p.mu.Unlock()
+
+	keys, err := p.Fetch(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case err == nil:
+		p.keys, p.fetchedAt, p.attemptedAt, p.failures, p.err = keys, TimeFunc(), TimeFunc(), 0, nil
+	case ctx.Err() == nil:
+		// Canceled requests do not delay fetching for others.
+		p.attemptedAt, p.err = TimeFunc(), err
+		p.failures++
+	}
+	p.fetching = nil
+	close(fetching)
+
+	if err != nil && p.keys == nil {
+		return nil, err
+	}
+
+	return selectKeys(p.keys, kid), nil
+}
+
+// shouldFetch reports whether the keys must be fetched for a token with key
+// ID kid. p.mu must be held.
+func (p *RefreshingKeys) shouldFetch(kid string) bool {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultKeyRefreshInterval
+	}
+
+	now := TimeFunc()
+	sinceAttempt := now.Sub(p.attemptedAt)
+	// Failed fetches are retried with a backoff.
+	if p.failures > 0 && sinceAttempt < queue.Backoff(minKeyRefreshInterval, interval, p.failures) {
+		return false
+	}
+
+	stale := p.fetchedAt.IsZero() || now.Sub(p.fetchedAt) >= interval
+	unknown := kid != "" && !hasKey(p.keys, kid) && sinceAttempt >= minKeyRefreshInterval
+
+	return stale || unknown
+}
+
+// selectKeys returns the cached keys for kid, or the error of the last fetch
+// if none were fetched yet. p.mu must be held.
+func (p *RefreshingKeys) selectKeys(kid string) ([]Key, error) {
+	if p.keys == nil && p.err != nil {
+		return nil, p.err
+	}
+
+	return selectKeys(p.keys, kid), nil
+}
+
+// FetchKeysFromURL returns a function for RefreshingKeys.Fetch that GETs the
+// keys from url. The endpoint must respond with a JSON object like
+// {"keys": [{"id": "2021-07", "secret": "base64 encoded key"}]}. A nil client
+// uses http.DefaultClient.
+func FetchKeysFromURL(client *http.Client, url string) func(ctx context.Context) ([]Key, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context) ([]Key, error) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetching signing keys: unexpected status %d", resp.StatusCode)
+		}
+
+		var body struct {
+			Keys []Key `json:"keys"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, fmt.Errorf("decoding signing keys: %w", err)
+		}
+
+		return body.Keys, nil
+	}
+}
+
+// keyID returns the kid header of a token, without validating it.
+func keyID(signature string) string {
+	token, _, err := new(jwt.Parser).ParseUnverified(signature, jwt.MapClaims{})
+	if err != nil {
+		return ""
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	return kid
+}
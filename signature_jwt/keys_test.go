@@ -0,0 +1,209 @@
+package signature_jwt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKeyURL = "https://example.com/webhook"
+
+func signTestToken(t *testing.T, secret, kid string) string {
+	now := TimeFunc().Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":      "MessageBird",
+		"nbf":      now,
+		"exp":      now + 60,
+		"jti":      "a1f6a3a4-4a4f-4f4b-9a0b-1c2d3e4f5a6b",
+		"url_hash": sha256Hash([]byte(testKeyURL)),
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString([]byte(secret))
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestValidatorWithRotatedKeys(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00Z")
+
+	v := NewValidatorWithKeys(StaticKeys(
+		Key{ID: "old", Secret: []byte("old secret")},
+		Key{ID: "new", Secret: []byte("new secret")},
+	))
+
+	for _, secret := range []string{"old secret", "new secret"} {
+		_, err := v.ValidateSignature(signTestToken(t, secret, ""), testKeyURL, nil)
+		assert.NoError(t, err, secret)
+	}
+
+	_, err := v.ValidateSignature(signTestToken(t, "new secret", "new"), testKeyURL, nil)
+	assert.NoError(t, err)
+
+	// The key ID selects which key is used.
+	_, err = v.ValidateSignature(signTestToken(t, "old secret", "new"), testKeyURL, nil)
+	assert.EqualError(t, err, "invalid jwt: signature is invalid")
+
+	_, err = v.ValidateSignature(signTestToken(t, "unknown", ""), testKeyURL, nil)
+	assert.EqualError(t, err, "invalid jwt: signature is invalid")
+}
+
+func TestValidatorStopsAtClaimErrors(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00Z")
+
+	v := NewValidatorWithKeys(StaticKeys(Key{Secret: []byte("a")}, Key{Secret: []byte("b")}))
+
+	_, err := v.ValidateSignature(signTestToken(t, "a", ""), "https://example.com/other", nil)
+	assert.EqualError(t, err, "invalid jwt: claim url_hash is invalid")
+}
+
+func TestRefreshingKeys(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00Z")
+	start := TimeFunc()
+
+	secrets := map[string]string{"k1": "czE="}
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if secrets == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body := `{"keys":[`
+		first := true
+		for id, secret := range secrets {
+			if !first {
+				body += ","
+			}
+			body += `{"id":"` + id + `","secret":"` + secret + `"}`
+			first = false
+		}
+		w.Write([]byte(body + `]}`))
+	}))
+	defer srv.Close()
+
+	p := &RefreshingKeys{Fetch: FetchKeysFromURL(srv.Client(), srv.URL), Interval: time.Hour}
+	ctx := context.Background()
+
+	keys, err := p.SigningKeys(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Key{{ID: "k1", Secret: []byte("s1")}}, keys)
+
+	// Cached keys are used within the interval.
+	p.SigningKeys(ctx, "k1")
+	assert.Equal(t, 1, fetches)
+
+	// An unknown key ID triggers a refresh, but not too often.
+	secrets["k2"] = "czI="
+	keys, _ = p.SigningKeys(ctx, "k2")
+	assert.Empty(t, keys)
+	assert.Equal(t, 1, fetches)
+
+	TimeFunc = func() time.Time { return start.Add(time.Minute) }
+	keys, _ = p.SigningKeys(ctx, "k2")
+	assert.Equal(t, []Key{{ID: "k2", Secret: []byte("s2")}}, keys)
+	assert.Equal(t, 2, fetches)
+
+	// Previously fetched keys are used when fetching fails.
+	secrets = nil
+	TimeFunc = func() time.Time { return start.Add(2 * time.Hour) }
+	keys, err = p.SigningKeys(ctx, "k1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Key{{ID: "k1", Secret: []byte("s1")}}, keys)
+	assert.Equal(t, 3, fetches)
+}
+
+func TestRefreshingKeysError(t *testing.T) {
+	p := &RefreshingKeys{Fetch: func(context.Context) ([]Key, error) {
+		return nil, errors.New("config unavailable")
+	}}
+
+	v := NewValidatorWithKeys(p)
+	_, err := v.ValidateSignature(testToken, "", nil)
+	assert.EqualError(t, err, "fetching signing keys: config unavailable")
+}
+
+func TestRefreshingKeysBackoff(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00Z")
+	start := TimeFunc()
+
+	fetches := 0
+	p := &RefreshingKeys{Fetch: func(context.Context) ([]Key, error) {
+		fetches++
+		return nil, errors.New("config unavailable")
+	}}
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		after   time.Duration
+		fetches int
+	}{
+		{0, 1},
+		{29 * time.Second, 1},
+		{30 * time.Second, 2},
+		{89 * time.Second, 2},
+		{90 * time.Second, 3},
+	} {
+		TimeFunc = func() time.Time { return start.Add(tc.after) }
+		_, err := p.SigningKeys(ctx, "k1")
+		assert.EqualError(t, err, "config unavailable")
+		assert.Equal(t, tc.fetches, fetches, "after %s", tc.after)
+	}
+}
+
+func TestRefreshingKeysConcurrentFetch(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00Z")
+	start := TimeFunc()
+
+	fetched := make(chan struct{})
+	release := make(chan struct{})
+	fetches := 0
+	p := &RefreshingKeys{
+		Fetch: func(context.Context) ([]Key, error) {
+			fetches++
+			fetched <- struct{}{}
+			<-release
+			return []Key{{ID: "k1", Secret: []byte("s1")}}, nil
+		},
+		Interval: time.Hour,
+	}
+	ctx := context.Background()
+
+	// Callers without keys wait for the first fetch.
+	results := make(chan []Key)
+	for i := 0; i < 2; i++ {
+		go func() {
+			keys, _ := p.SigningKeys(ctx, "")
+			results <- keys
+		}()
+	}
+	<-fetched
+	close(release)
+	assert.Len(t, <-results, 1)
+	assert.Len(t, <-results, 1)
+
+	// Callers do not wait for refreshes while there are keys.
+	TimeFunc = func() time.Time { return start.Add(2 * time.Hour) }
+	release = make(chan struct{})
+	go func() {
+		keys, _ := p.SigningKeys(ctx, "")
+		results <- keys
+	}()
+	<-fetched
+	keys, err := p.SigningKeys(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Key{{ID: "k1", Secret: []byte("s1")}}, keys)
+	close(release)
+	assert.Len(t, <-results, 1)
+	assert.Equal(t, 2, fetches)
+}
@@ -0,0 +1,211 @@
+package signature_jwt
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	legacyTimestampHeader = "MessageBird-Request-Timestamp"
+	legacySignatureHeader = "MessageBird-Signature"
+)
+
+// DefaultLegacyTolerance is the maximum difference between the
+// MessageBird-Request-Timestamp of a request and the time it is received.
+const DefaultLegacyTolerance = 5 * time.Second
+
+// LegacyValidator validates the older HMAC-SHA256 MessageBird-Signature
+// header that some products still send instead of a JWT. Prefer Validator
+// for products that support both.
+type LegacyValidator struct {
+	signingKey   []byte
+	tolerance    time.Duration
+	errorHandler ErrorHandler
+	nonces       NonceStore
+}
+
+type LegacyValidatorOption func(*LegacyValidator)
+
+// WithTolerance sets the maximum difference between the request timestamp
+// and the time it is received. Defaults to DefaultLegacyTolerance.
+func WithTolerance(d time.Duration) LegacyValidatorOption {
+	return func(v *LegacyValidator) {
+		if d > 0 {
+			v.tolerance = d
+		}
+	}
+}
+
+// WithLegacyErrorHandler is like WithErrorHandler, but for a LegacyValidator.
+func WithLegacyErrorHandler(h ErrorHandler) LegacyValidatorOption {
+	return func(v *LegacyValidator) {
+		if h != nil {
+			v.errorHandler = h
+		}
+	}
+}
+
+// NewLegacyValidator returns a validator for HMAC signatures, using the same
+// signing key as NewValidator.
+func NewLegacyValidator(signingKey string, opts ...LegacyValidatorOption) *LegacyValidator {
+	validator := &LegacyValidator{
+		signingKey:   []byte(signingKey),
+		tolerance:    DefaultLegacyTolerance,
+		errorHandler: unauthorized,
+	}
+
+	for _, opt := range opts {
+		opt(validator)
+	}
+
+	return validator
+}
+
+// ValidateSignature validates signature, the base64 encoded value of the
+// MessageBird-Signature header, against the timestamp, raw query string and
+// payload of a request. Signatures are compared in constant time.
+func (v *LegacyValidator) ValidateSignature(signature, timestamp, rawQuery string, payload []byte) error {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %q", timestamp)
+	}
+
+	skew := TimeFunc().Sub(time.Unix(sec, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > v.tolerance {
+		return fmt.Errorf("timestamp outside of tolerance window")
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fmt.Errorf("invalid query: %v", err)
+	}
+
+	got, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %v", err)
+	}
+
+	if !hmac.Equal(got, v.calculateSignature(timestamp, query.Encode(), payload)) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
+
+// calculateSignature calculates the expected signature:
+// HMAC_SHA_256(TIMESTAMP + \n + QUERY_PARAMS + \n + SHA_256_SUM(BODY), signing_key)
+func (v *LegacyValidator) calculateSignature(timestamp, query string, payload []byte) []byte {
+	var m bytes.Buffer
+	bh := sha256.Sum256(payload)
+	fmt.Fprintf(&m, "%s\n%s\n%s", timestamp, query, bh[:])
+
+	mac := hmac.New(sha256.New, v.signingKey)
+	mac.Write(m.Bytes())
+	return mac.Sum(nil)
+}
+
+// ValidateRequest validates the signature of an incoming request. The base
+// URL is not part of legacy signatures, so it is ignored. It is accepted so
+// LegacyValidator can be used in place of Validator.
+func (v *LegacyValidator) ValidateRequest(r *http.Request, baseURL string) error {
+	timestamp := r.Header.Get(legacyTimestampHeader)
+	signature := r.Header.Get(legacySignatureHeader)
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("signature not found")
+	}
+
+	b, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+
+	if err := v.ValidateSignature(signature, timestamp, r.URL.RawQuery, b); err != nil {
+		return fmt.Errorf("invalid signature: %s", err.Error())
+	}
+
+	// The timestamp was validated above, so it can be parsed.
+	sec, _ := strconv.ParseInt(timestamp, 10, 64)
+	expiry := time.Unix(sec, 0).Add(v.tolerance)
+	return checkNonce(r.Context(), v.nonces, "hmac:"+timestamp+":"+signature, expiry)
+}
+
+// Validate is like Validator.Validate. No claims are stored in the request
+// context, as legacy signatures do not carry any.
+func (v *LegacyValidator) Validate(h http.Handler, baseURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.ValidateRequest(r, baseURL); err != nil {
+			v.errorHandler(w, r, err)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Middleware is like Validator.Middleware.
+func (v *LegacyValidator) Middleware(baseURL string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return v.Validate(h, baseURL)
+	}
+}
+
+// CombinedValidator accepts requests signed with either a JWT or a legacy
+// HMAC signature. Requests carrying a MessageBird-Signature-JWT header are
+// always validated as JWT, so a valid legacy signature can not be used to
+// bypass an invalid JWT.
+type CombinedValidator struct {
+	jwt    *Validator
+	legacy *LegacyValidator
+}
+
+// NewCombinedValidator returns a validator that accepts signatures that are
+// valid for either v or legacy. Rejected requests are handled by the error
+// handler of v.
+func NewCombinedValidator(v *Validator, legacy *LegacyValidator) *CombinedValidator {
+	return &CombinedValidator{jwt: v, legacy: legacy}
+}
+
+// ValidateRequest validates the signature of an incoming request.
+func (v *CombinedValidator) ValidateRequest(r *http.Request, baseURL string) error {
+	_, err := v.validateRequest(r, baseURL)
+	return err
+}
+
+func (v *CombinedValidator) validateRequest(r *http.Request, baseURL string) (*Claims, error) {
+	if r.Header.Get(signatureHeader) != "" {
+		return v.jwt.validateRequest(r, baseURL)
+	}
+
+	return nil, v.legacy.ValidateRequest(r, baseURL)
+}
+
+// Validate is like Validator.Validate. Claims are only stored in the request
+// context for requests signed with a JWT.
+func (v *CombinedValidator) Validate(h http.Handler, baseURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := v.validateRequest(r, baseURL)
+		if err != nil {
+			v.jwt.errorHandler(w, r, err)
+			return
+		}
+		if claims != nil {
+			r = r.WithContext(NewContext(r.Context(), claims))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Middleware is like Validator.Middleware.
+func (v *CombinedValidator) Middleware(baseURL string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return v.Validate(h, baseURL)
+	}
+}
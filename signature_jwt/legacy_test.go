@@ -0,0 +1,114 @@
+package signature_jwt
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	legacyTestKey       = "other-secret"
+	legacyTestTimestamp = "1544544948"
+	legacyTestBody      = `{"a key":"some value"}`
+	legacyTestSignature = "orb0adPhRCYND1WCAvPBr+qjm4STGtyvNDIDNBZ4Ir4="
+)
+
+func legacyRequest(signature string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/?def=bar&abc=foo", strings.NewReader(legacyTestBody))
+	r.Header.Set(legacyTimestampHeader, legacyTestTimestamp)
+	r.Header.Set(legacySignatureHeader, signature)
+	return r
+}
+
+func TestLegacyValidateSignature(t *testing.T) {
+	at := time.Unix(1544544948, 0)
+
+	var cases = []struct {
+		name      string
+		now       time.Time
+		tolerance time.Duration
+		signature string
+		wantErr   string
+	}{
+		{"valid", at, 0, legacyTestSignature, ""},
+		{"within tolerance", at.Add(4 * time.Second), 0, legacyTestSignature, ""},
+		{"too old", at.Add(6 * time.Second), 0, legacyTestSignature, "timestamp outside of tolerance window"},
+		{"in the future", at.Add(-6 * time.Second), 0, legacyTestSignature, "timestamp outside of tolerance window"},
+		{"custom tolerance", at.Add(time.Minute), 2 * time.Minute, legacyTestSignature, ""},
+		{"wrong signature", at, 0, "LISw4Je7n0/MkYDgVSzTJm8dW6BkytKTXMZZk1IElMs=", "signature mismatch"},
+		{"bad encoding", at, 0, "%%%", "invalid signature encoding: illegal base64 data at input byte 0"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			TimeFunc = func() time.Time { return tc.now }
+			defer func() { TimeFunc = time.Now }()
+
+			v := NewLegacyValidator(legacyTestKey, WithTolerance(tc.tolerance))
+			err := v.ValidateSignature(tc.signature, legacyTestTimestamp, "abc=foo&def=bar", []byte(legacyTestBody))
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLegacyValidatePreservesBody(t *testing.T) {
+	withTime(t, "2018-12-11T16:15:48Z")
+
+	var body string
+	h := NewLegacyValidator(legacyTestKey).Middleware("")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, legacyRequest(legacyTestSignature))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, legacyTestBody, body)
+}
+
+func TestCombinedValidator(t *testing.T) {
+	v := NewCombinedValidator(NewValidator("hunter2"), NewLegacyValidator(legacyTestKey))
+
+	t.Run("jwt", func(t *testing.T) {
+		withTime(t, "2021-07-05T12:00:00+02:00")
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(signatureHeader, testToken)
+
+		var claims *Claims
+		w := httptest.NewRecorder()
+		v.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, _ = ClaimsFromContext(r.Context())
+		}), "https://example.com").ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotNil(t, claims)
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		withTime(t, "2018-12-11T16:15:48Z")
+		assert.NoError(t, v.ValidateRequest(legacyRequest(legacyTestSignature), "https://example.com"))
+	})
+
+	t.Run("invalid jwt with valid legacy signature", func(t *testing.T) {
+		withTime(t, "2018-12-11T16:15:48Z")
+
+		r := legacyRequest(legacyTestSignature)
+		r.Header.Set(signatureHeader, "invalid")
+		assert.Error(t, v.ValidateRequest(r, "https://example.com"))
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		assert.EqualError(t, v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/", nil), ""), "signature not found")
+	})
+}
@@ -0,0 +1,61 @@
+package signature_jwt
+
+import (
+	"context"
+	"net/http"
+)
+
+// ErrorHandler writes the response for a request that failed signature
+// validation. err describes why the request was rejected.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// WithErrorHandler instructs Validator to respond to requests with an invalid
+// signature using h, instead of an empty 401 Unauthorized response.
+func WithErrorHandler(h ErrorHandler) ValidatorOption {
+	return func(v *Validator) {
+		if h != nil {
+			v.errorHandler = h
+		}
+	}
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	http.Error(w, "", http.StatusUnauthorized)
+}
+
+type claimsKey struct{}
+
+// NewContext returns a copy of ctx that carries claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the verified claims stored in ctx by Validate,
+// Middleware or ValidateFunc. It reports false if the request was not
+// validated.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// Middleware returns a middleware that validates the signature of incoming
+// requests, like Validate. Its signature matches the middleware of routers
+// like chi and gorilla/mux:
+//
+//	router.Use(validator.Middleware("https://yourdomain.com"))
+func (v *Validator) Middleware(baseURL string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return v.Validate(h, baseURL)
+	}
+}
+
+// ValidateFunc is like Validate, but wraps an http.HandlerFunc. Routers that
+// register plain functions, like http.HandleFunc, can use it directly:
+//
+//	http.HandleFunc("/webhook", validator.ValidateFunc(handleWebhook, "https://yourdomain.com"))
+//
+// For routers with their own handler types, wrap the result with the router's
+// adapter, e.g. gin.WrapF or echo.WrapHandler.
+func (v *Validator) ValidateFunc(h http.HandlerFunc, baseURL string) http.HandlerFunc {
+	return v.Validate(h, baseURL).ServeHTTP
+}
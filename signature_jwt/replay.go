@@ -0,0 +1,156 @@
+package signature_jwt
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultNonceStoreCapacity is the number of nonces a MemoryNonceStore
+// remembers when no capacity is given.
+const DefaultNonceStoreCapacity = 10000
+
+// ErrReplayed is returned when a request carries a signature that has already
+// been accepted before.
+var ErrReplayed = errors.New("signature has already been used")
+
+// NonceStore records the nonces of accepted signatures: the jti claim of JWTs,
+// or the timestamp and signature of legacy HMAC signatures. Stores must be safe
+// for concurrent use.
+type NonceStore interface {
+	// Add records nonce until expiry. It reports false if nonce was already
+	// recorded and has not expired yet.
+	Add(ctx context.Context, nonce string, expiry time.Time) (bool, error)
+}
+
+// WithNonceStore enables replay protection: requests whose jti has been seen
+// before within the validity window of the token are rejected with
+// ErrReplayed. Only ValidateRequest and the handlers check the store.
+func WithNonceStore(s NonceStore) ValidatorOption {
+	return func(v *Validator) {
+		v.nonces = s
+	}
+}
+
+// WithLegacyNonceStore is like WithNonceStore, but for a LegacyValidator.
+func WithLegacyNonceStore(s NonceStore) LegacyValidatorOption {
+	return func(v *LegacyValidator) {
+		v.nonces = s
+	}
+}
+
+// checkNonce records nonce in s and returns ErrReplayed if it was recorded
+// before. A nil store disables the check.
+func checkNonce(ctx context.Context, s NonceStore, nonce string, expiry time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	added, err := s.Add(ctx, nonce, expiry)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// MemoryNonceStore is a NonceStore that keeps nonces in memory. When it is
+// full, the least recently added nonce is evicted. It does not share nonces
+// between processes: use a RedisNonceStore if the webhook endpoint runs on
+// multiple servers.
+type MemoryNonceStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type nonceEntry struct {
+	nonce  string
+	expiry time.Time
+}
+
+// NewMemoryNonceStore returns a MemoryNonceStore holding at most capacity
+// nonces, or DefaultNonceStoreCapacity if capacity is not positive.
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	if capacity <= 0 {
+		capacity = DefaultNonceStoreCapacity
+	}
+
+	return &MemoryNonceStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Add implements NonceStore.
+func (s *MemoryNonceStore) Add(_ context.Context, nonce string, expiry time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := TimeFunc()
+
+	if e, ok := s.entries[nonce]; ok {
+		if now.Before(e.Value.(*nonceEntry).expiry) {
+			return false, nil
+		}
+		s.remove(e)
+	}
+
+	for s.order.Len() >= s.capacity {
+		s.remove(s.order.Front())
+	}
+
+	s.entries[nonce] = s.order.PushBack(&nonceEntry{nonce: nonce, expiry: expiry})
+	return true, nil
+}
+
+// Len returns the number of nonces in the store, including expired ones that
+// have not been evicted yet.
+func (s *MemoryNonceStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
+
+func (s *MemoryNonceStore) remove(e *list.Element) {
+	s.order.Remove(e)
+	delete(s.entries, e.Value.(*nonceEntry).nonce)
+}
+
+// RedisClient is the subset of a Redis client used by RedisNonceStore. It
+// should run SET key 1 NX PX ttl and report whether the key was set. With
+// github.com/go-redis/redis, this is:
+//
+//	func (c adapter) SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+//		return c.Client.SetNX(ctx, key, 1, ttl).Result()
+//	}
+type RedisClient interface {
+	SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// RedisNonceStore is a NonceStore backed by Redis, so nonces are shared by all
+// servers handling webhooks.
+type RedisNonceStore struct {
+	Client RedisClient
+
+	// Prefix is prepended to every nonce to form its key, e.g.
+	// "messagebird:nonce:".
+	Prefix string
+}
+
+// Add implements NonceStore.
+func (s *RedisNonceStore) Add(ctx context.Context, nonce string, expiry time.Time) (bool, error) {
+	ttl := expiry.Sub(TimeFunc())
+	if ttl < time.Millisecond {
+		ttl = time.Millisecond
+	}
+
+	return s.Client.SetNX(ctx, s.Prefix+nonce, ttl)
+}
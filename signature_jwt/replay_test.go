@@ -0,0 +1,88 @@
+package signature_jwt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00Z")
+	now := TimeFunc()
+	ctx := context.Background()
+
+	s := NewMemoryNonceStore(2)
+
+	added, err := s.Add(ctx, "a", now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, added)
+
+	added, _ = s.Add(ctx, "a", now.Add(time.Minute))
+	assert.False(t, added)
+
+	// Expired nonces can be added again.
+	added, _ = s.Add(ctx, "b", now.Add(-time.Second))
+	assert.True(t, added)
+	added, _ = s.Add(ctx, "b", now.Add(time.Minute))
+	assert.True(t, added)
+
+	// Adding a third nonce evicts the oldest.
+	added, _ = s.Add(ctx, "c", now.Add(time.Minute))
+	assert.True(t, added)
+	assert.Equal(t, 2, s.Len())
+	added, _ = s.Add(ctx, "a", now.Add(time.Minute))
+	assert.True(t, added)
+}
+
+func TestValidateRequestRejectsReplay(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00+02:00")
+
+	v := NewValidator("hunter2", WithNonceStore(NewMemoryNonceStore(0)))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(signatureHeader, testToken)
+
+	assert.NoError(t, v.ValidateRequest(r, "https://example.com"))
+	assert.True(t, errors.Is(v.ValidateRequest(r, "https://example.com"), ErrReplayed))
+}
+
+func TestLegacyValidateRequestRejectsReplay(t *testing.T) {
+	withTime(t, "2018-12-11T16:15:48Z")
+
+	v := NewLegacyValidator(legacyTestKey, WithLegacyNonceStore(NewMemoryNonceStore(0)))
+
+	assert.NoError(t, v.ValidateRequest(legacyRequest(legacyTestSignature), ""))
+	assert.True(t, errors.Is(v.ValidateRequest(legacyRequest(legacyTestSignature), ""), ErrReplayed))
+}
+
+type fakeRedis struct {
+	keys map[string]time.Duration
+}
+
+func (f *fakeRedis) SetNX(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	if _, ok := f.keys[key]; ok {
+		return false, nil
+	}
+	f.keys[key] = ttl
+	return true, nil
+}
+
+func TestRedisNonceStore(t *testing.T) {
+	withTime(t, "2021-07-05T12:00:00Z")
+
+	redis := &fakeRedis{keys: map[string]time.Duration{}}
+	s := &RedisNonceStore{Client: redis, Prefix: "mb:"}
+
+	added, err := s.Add(context.Background(), "jwt:1", TimeFunc().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, time.Minute, redis.keys["mb:jwt:1"])
+
+	added, _ = s.Add(context.Background(), "jwt:1", TimeFunc().Add(time.Minute))
+	assert.False(t, added)
+}
@@ -0,0 +1,94 @@
+package signature_jwt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// signatureTTL is how long signatures created by NewSignature are valid.
+const signatureTTL = time.Minute
+
+// NewSignature returns a MessageBird-Signature-JWT header value for a
+// request to url with payload, signed with signingKey the way MessageBird
+// signs webhooks. It is meant for testing webhook receivers.
+func NewSignature(signingKey, url string, payload []byte) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	now := TimeFunc().Unix()
+	claims := jwt.MapClaims{
+		"iss":      "MessageBird",
+		"nbf":      now,
+		"exp":      now + int64(signatureTTL/time.Second),
+		"jti":      hex.EncodeToString(jti),
+		"url_hash": sha256Hash([]byte(url)),
+	}
+	if len(payload) != 0 {
+		claims["payload_hash"] = sha256Hash(payload)
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(signingKey))
+}
+
+// SignRequest sets the MessageBird-Signature-JWT header of r. The URL of r
+// must be absolute, and must match the base URL the receiver validates with.
+func SignRequest(r *http.Request, signingKey string) error {
+	b, err := readBody(r)
+	if err != nil {
+		return err
+	}
+
+	// The receiving server sees an empty path as /.
+	u := *r.URL
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	signature, err := NewSignature(signingKey, u.String(), b)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set(signatureHeader, signature)
+	return nil
+}
+
+// SignLegacyRequest sets the MessageBird-Request-Timestamp and
+// MessageBird-Signature headers of r, as validated by LegacyValidator.
+func SignLegacyRequest(r *http.Request, signingKey string) error {
+	b, err := readBody(r)
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(TimeFunc().Unix(), 10)
+	signature := NewLegacyValidator(signingKey).calculateSignature(timestamp, r.URL.Query().Encode(), b)
+
+	r.Header.Set(legacyTimestampHeader, timestamp)
+	r.Header.Set(legacySignatureHeader, base64.StdEncoding.EncodeToString(signature))
+	return nil
+}
+
+// readBody reads the body of r and restores it, so it can be sent.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
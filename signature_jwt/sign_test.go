@@ -0,0 +1,24 @@
+package signature_jwt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignRequest(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodPost, "https://example.com/webhook?a=1", strings.NewReader(`{"id":"1"}`))
+	assert.NoError(t, SignRequest(r, "hunter2"))
+
+	assert.NoError(t, NewValidator("hunter2").ValidateRequest(r, "https://example.com"))
+	assert.Error(t, NewValidator("other").ValidateRequest(r, "https://example.com"))
+}
+
+func TestSignLegacyRequest(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodPost, "https://example.com/webhook?b=2&a=1", strings.NewReader(`{"id":"1"}`))
+	assert.NoError(t, SignLegacyRequest(r, "hunter2"))
+
+	assert.NoError(t, NewLegacyValidator("hunter2").ValidateRequest(r, ""))
+}
@@ -17,7 +17,18 @@ Or use the handler as a middleware for your server:
 
 	http.Handle("/path", validator.Validate(YourHandler, baseUrl))
 
-It will reject the requests that contain invalid signatures.
+It will reject the requests that contain invalid signatures. The claims of
+valid requests are stored in the request context:
+
+	claims, ok := signature_jwt.ClaimsFromContext(r.Context())
+
+Routers that accept func(http.Handler) http.Handler middleware, like chi and
+gorilla/mux, can use Middleware:
+
+	router.Use(validator.Middleware(baseUrl))
+
+Products that still send the older HMAC MessageBird-Signature header can be
+verified with NewLegacyValidator. NewCombinedValidator accepts either.
 
 For more information, see https://developers.messagebird.com/docs/verify-http-requests
 */
@@ -25,8 +36,10 @@ package signature_jwt
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -54,9 +67,11 @@ var allowedMethods = []string{
 // Validator type represents a MessageBird signature validator.
 type Validator struct {
 	parser jwt.Parser
-	keyFn  jwt.Keyfunc
+	keys   KeyProvider
 
 	skipURLValidation bool
+	errorHandler      ErrorHandler
+	nonces            NonceStore
 }
 
 type ValidatorOption func(*Validator)
@@ -77,11 +92,19 @@ func SkipURLValidation() ValidatorOption {
 // https://dashboard.messagebird.com/developers/settings.
 // Note that this is NOT your API key.
 func NewValidator(signingKey string, opts ...ValidatorOption) *Validator {
+	return NewValidatorWithKeys(StaticKeys(Key{Secret: []byte(signingKey)}), opts...)
+}
+
+// NewValidatorWithKeys returns a signature validator that accepts signatures
+// made with any of the keys of provider, so signing keys can be rotated
+// without redeploying. See RefreshingKeys.
+func NewValidatorWithKeys(provider KeyProvider, opts ...ValidatorOption) *Validator {
 	validator := &Validator{
 		parser: jwt.Parser{
 			ValidMethods: allowedMethods,
 		},
-		keyFn: func(*jwt.Token) (interface{}, error) { return []byte(signingKey), nil },
+		keys:         provider,
+		errorHandler: unauthorized,
 	}
 
 	for _, opt := range opts {
@@ -96,60 +119,102 @@ func NewValidator(signingKey string, opts ...ValidatorOption) *Validator {
 // The provided url is the raw url including the protocol, hostname and
 // query string, e.g. https://example.com/?example=42.
 func (v *Validator) ValidateSignature(signature, url string, payload []byte) (jwt.Claims, error) {
-	claims := Claims{
-		receivedTime:      TimeFunc(),
-		skipURLValidation: v.skipURLValidation,
-	}
+	return v.validateSignature(context.Background(), signature, url, payload)
+}
 
-	if !v.skipURLValidation && url != "" {
-		claims.correctURLHash = sha256Hash([]byte(url))
+func (v *Validator) validateSignature(ctx context.Context, signature, url string, payload []byte) (jwt.Claims, error) {
+	keys, err := v.keys.SigningKeys(ctx, keyID(signature))
+	if err != nil {
+		return nil, fmt.Errorf("fetching signing keys: %w", err)
 	}
-	if payload != nil && len(payload) != 0 {
-		claims.correctPayloadHash = sha256Hash(payload)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys")
 	}
 
-	if token, err := v.parser.ParseWithClaims(signature, &claims, v.keyFn); err != nil {
-		return nil, fmt.Errorf("invalid jwt: %w", err)
-	} else {
-		return token.Claims, nil
+	// Every key is tried in turn, until one has a valid signature.
+	var lastErr error
+	for _, key := range keys {
+		claims := Claims{
+			receivedTime:      TimeFunc(),
+			skipURLValidation: v.skipURLValidation,
+		}
+
+		if !v.skipURLValidation && url != "" {
+			claims.correctURLHash = sha256Hash([]byte(url))
+		}
+		if payload != nil && len(payload) != 0 {
+			claims.correctPayloadHash = sha256Hash(payload)
+		}
+
+		secret := key.Secret
+		token, err := v.parser.ParseWithClaims(signature, &claims, func(*jwt.Token) (interface{}, error) { return secret, nil })
+		if err == nil {
+			return token.Claims, nil
+		}
+
+		lastErr = err
+
+		var verr *jwt.ValidationError
+		if !errors.As(err, &verr) || verr.Errors&jwt.ValidationErrorSignatureInvalid == 0 {
+			break
+		}
 	}
+
+	return nil, fmt.Errorf("invalid jwt: %w", lastErr)
 }
 
 // ValidateRequest is a method that takes care of the signature validation of
 // incoming requests.
 func (v *Validator) ValidateRequest(r *http.Request, baseURL string) error {
+	_, err := v.validateRequest(r, baseURL)
+	return err
+}
+
+// validateRequest validates the signature of r and returns its claims. The
+// body of r is restored so it can be read again by the next handler.
+func (v *Validator) validateRequest(r *http.Request, baseURL string) (*Claims, error) {
 	signature := r.Header.Get(signatureHeader)
 	if signature == "" {
-		return fmt.Errorf("signature not found")
+		return nil, fmt.Errorf("signature not found")
 	}
 
 	var fullURL string
 	if !v.skipURLValidation && baseURL != "" {
 		base, err := url.Parse(baseURL)
 		if err != nil {
-			return fmt.Errorf("error parsing base url: %v", err)
+			return nil, fmt.Errorf("error parsing base url: %v", err)
 		}
 		fullURL = base.ResolveReference(r.URL).String()
 	}
 
 	b, _ := ioutil.ReadAll(r.Body)
-	if _, err := v.ValidateSignature(signature, fullURL, b); err != nil {
-		return fmt.Errorf("invalid signature: %s", err.Error())
-	}
 	r.Body = ioutil.NopCloser(bytes.NewBuffer(b))
-	return nil
+
+	claims, err := v.validateSignature(r.Context(), signature, fullURL, b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %s", err.Error())
+	}
+
+	c := claims.(*Claims)
+	expiry := time.Unix(c.ExpirationTime, 0).Add(maxSkew)
+	if err := checkNonce(r.Context(), v.nonces, "jwt:"+c.JWTID, expiry); err != nil {
+		return nil, err
+	}
+	return c, nil
 }
 
 // Validate is a handler wrapper that takes care of the signature validation of
 // incoming requests and rejects them if invalid or pass them on to your handler
-// otherwise.
+// otherwise. The verified claims are available to your handler through
+// ClaimsFromContext.
 func (v *Validator) Validate(h http.Handler, baseURL string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if err := v.ValidateRequest(r, baseURL); err != nil {
-			http.Error(w, "", http.StatusUnauthorized)
+		claims, err := v.validateRequest(r, baseURL)
+		if err != nil {
+			v.errorHandler(w, r, err)
 			return
 		}
-		h.ServeHTTP(w, r)
+		h.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims)))
 	})
 }
 
@@ -0,0 +1,73 @@
+package sms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// InboundMessage is an SMS message received on one of your numbers, as
+// delivered to your webhook.
+type InboundMessage struct {
+	ID              string
+	Originator      string
+	Recipient       string
+	Body            string
+	CreatedDatetime *time.Time
+}
+
+type inboundJSON struct {
+	ID              string `json:"id"`
+	Originator      string `json:"originator"`
+	Recipient       string `json:"recipient"`
+	Body            string `json:"body"`
+	CreatedDatetime string `json:"createdDatetime"`
+}
+
+// ParseInbound parses the request MessageBird sends to your webhook when an
+// SMS message is received. Both query string, form and JSON encoded requests
+// are supported. Use signature_jwt to verify the request first.
+func ParseInbound(r *http.Request) (*InboundMessage, error) {
+	raw := &inboundJSON{}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(raw); err != nil {
+			return nil, fmt.Errorf("decoding inbound SMS: %w", err)
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("parsing inbound SMS: %w", err)
+		}
+
+		raw.ID = r.Form.Get("id")
+		raw.Originator = r.Form.Get("originator")
+		raw.Recipient = r.Form.Get("recipient")
+		raw.Body = r.Form.Get("body")
+		raw.CreatedDatetime = r.Form.Get("createdDatetime")
+	}
+
+	if raw.ID == "" || raw.Originator == "" {
+		return nil, errors.New("request is not an inbound SMS: id and originator are required")
+	}
+
+	msg := &InboundMessage{
+		ID:         raw.ID,
+		Originator: raw.Originator,
+		Recipient:  raw.Recipient,
+		Body:       raw.Body,
+	}
+
+	if raw.CreatedDatetime != "" {
+		t, err := time.Parse(time.RFC3339, raw.CreatedDatetime)
+		if err != nil {
+			return nil, fmt.Errorf("parsing createdDatetime: %w", err)
+		}
+		msg.CreatedDatetime = &t
+	}
+
+	return msg, nil
+}
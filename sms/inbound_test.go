@@ -0,0 +1,41 @@
+package sms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInbound(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/webhook?id=inbound-id&originator=31612345678&recipient=31687654321&body=STOP&createdDatetime=2022-06-01T12%3A00%3A00%2B00%3A00", nil)
+
+	msg, err := ParseInbound(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "inbound-id", msg.ID)
+	assert.Equal(t, "31612345678", msg.Originator)
+	assert.Equal(t, "31687654321", msg.Recipient)
+	assert.Equal(t, "STOP", msg.Body)
+	assert.True(t, msg.CreatedDatetime.Equal(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestParseInboundJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"id":"inbound-id","originator":"31612345678","body":"Hi","createdDatetime":"2022-06-01T12:00:00Z"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	msg, err := ParseInbound(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hi", msg.Body)
+	assert.NotNil(t, msg.CreatedDatetime)
+}
+
+func TestParseInboundInvalid(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("body=Hi"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	_, err := ParseInbound(r)
+	assert.Error(t, err)
+}
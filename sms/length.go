@@ -0,0 +1,72 @@
+package sms
+
+import "unicode/utf16"
+
+// Data codings of a message, see Params.DataCoding.
+const (
+	DataCodingPlain   = "plain"
+	DataCodingUnicode = "unicode"
+	DataCodingAuto    = "auto"
+)
+
+// gsmBasic are the characters of the GSM 03.38 basic character set, which
+// take one septet, and gsmExtension those of its extension table, which take
+// two.
+const (
+	gsmBasic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsmExtension = "\f^{}\\[~]|€"
+)
+
+var gsmSeptets = func() map[rune]int {
+	septets := make(map[rune]int)
+	for _, r := range gsmBasic {
+		septets[r] = 1
+	}
+	for _, r := range gsmExtension {
+		septets[r] = 2
+	}
+
+	return septets
+}()
+
+// Length describes how a body is sent as SMS.
+type Length struct {
+	// DataCoding is DataCodingPlain if the body fits the GSM 03.38
+	// alphabet, and DataCodingUnicode otherwise.
+	DataCoding string
+
+	// Characters is the length of the body in the units of its data coding:
+	// septets for plain bodies, in which characters of the extension table,
+	// such as €, count twice, and UTF-16 code units for unicode bodies.
+	Characters int
+
+	// Parts is the number of messages the body is split into.
+	Parts int
+}
+
+// LengthOf returns the length of body when it is sent with
+// DataCodingAuto, which is the number of parts it is charged for.
+func LengthOf(body string) Length {
+	septets := 0
+	for _, r := range body {
+		n, ok := gsmSeptets[r]
+		if !ok {
+			units := len(utf16.Encode([]rune(body)))
+			return Length{DataCoding: DataCodingUnicode, Characters: units, Parts: parts(units, 70, 67)}
+		}
+		septets += n
+	}
+
+	return Length{DataCoding: DataCodingPlain, Characters: septets, Parts: parts(septets, 160, 153)}
+}
+
+// parts returns the number of parts for n characters, of which a single part
+// holds single and every part of a concatenated message holds multi.
+func parts(n, single, multi int) int {
+	if n <= single {
+		return 1
+	}
+
+	return (n + multi - 1) / multi
+}
@@ -0,0 +1,31 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLengthOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected Length
+	}{
+		{"empty", "", Length{DataCodingPlain, 0, 1}},
+		{"plain", "Hello World", Length{DataCodingPlain, 11, 1}},
+		{"single part", strings.Repeat("a", 160), Length{DataCodingPlain, 160, 1}},
+		{"two parts", strings.Repeat("a", 161), Length{DataCodingPlain, 161, 2}},
+		{"extension", strings.Repeat("€", 80) + "a", Length{DataCodingPlain, 161, 2}},
+		{"accents", "Hé Zoë, ça va?", Length{DataCodingUnicode, 14, 1}},
+		{"unicode", strings.Repeat("ł", 71), Length{DataCodingUnicode, 71, 2}},
+		{"surrogates", "👋", Length{DataCodingUnicode, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LengthOf(tt.body))
+		})
+	}
+}
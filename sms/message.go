@@ -3,11 +3,11 @@ package sms
 import (
 	"errors"
 	"net/http"
-	"net/url"
-	"strconv"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
 )
 
 // TypeDetails is a hash with extra information.
@@ -56,6 +56,32 @@ type Params struct {
 	ReportURL         string
 	ScheduledDatetime time.Time
 	ShortenURLs       bool
+
+	// Pool is the name of a number pool (see package number) to send the
+	// message from. The API picks an originator from the pool, so the
+	// originator passed to Create may be left empty.
+	Pool string
+}
+
+// SendRequest is a message to send with Send. Originator or Pool, at least
+// one recipient and Body are required. The other fields match those of
+// Params.
+type SendRequest struct {
+	Originator string
+	Recipients []string
+	Body       string
+
+	GroupIds          []string
+	Type              string
+	Reference         string
+	Validity          int
+	Gateway           int
+	TypeDetails       TypeDetails
+	DataCoding        string
+	ReportURL         string
+	ScheduledDatetime time.Time
+	ShortenURLs       bool
+	Pool              string
 }
 
 // ListParams provides additional message list options.
@@ -73,7 +99,7 @@ func (lp *ListParams) QueryParams() string {
 		return ""
 	}
 
-	query := url.Values{}
+	query := querystring.New()
 
 	if len(lp.Originator) > 0 {
 		query.Set("originator", lp.Originator)
@@ -92,11 +118,11 @@ func (lp *ListParams) QueryParams() string {
 	}
 
 	if lp.Limit > 0 {
-		query.Set("limit", strconv.Itoa(lp.Limit))
+		query.SetInt("limit", lp.Limit)
 	}
 
 	if lp.Offset > 0 {
-		query.Set("offset", strconv.Itoa(lp.Offset))
+		query.SetInt("offset", lp.Offset)
 	}
 
 	return query.Encode()
@@ -117,6 +143,7 @@ type messageRequest struct {
 	ShortenURLs       bool        `json:"shortenUrls"`
 	ReportURL         string      `json:"reportUrl,omitempty"`
 	ScheduledDatetime string      `json:"scheduledDatetime,omitempty"`
+	Pool              string      `json:"pool,omitempty"`
 }
 
 // path represents the path to the Message resource.
@@ -149,9 +176,22 @@ func List(c messagebird.Client, params *ListParams) (*MessageList, error) {
 	return messageList, nil
 }
 
-// Create creates a new message for one or more recipients.
+// Create creates a new message for one or more recipients. It is like Send,
+// with the fields of the SendRequest as arguments.
 func Create(c messagebird.Client, originator string, recipients []string, body string, msgParams *Params) (*Message, error) {
-	requestData, err := paramsToRequest(originator, recipients, body, msgParams)
+	return Send(c, newSendRequest(originator, recipients, body, msgParams))
+}
+
+// Send creates a new message for one or more recipients:
+//
+//	message, err := sms.Send(client, &sms.SendRequest{
+//		Originator: "MessageBird",
+//		Recipients: []string{"31612345678"},
+//		Body:       "Hello World",
+//		Reference:  "order-1234",
+//	})
+func Send(c messagebird.Client, req *SendRequest) (*Message, error) {
+	requestData, err := req.request()
 	if err != nil {
 		return nil, err
 	}
@@ -164,46 +204,82 @@ func Create(c messagebird.Client, originator string, recipients []string, body s
 	return message, nil
 }
 
+func newSendRequest(originator string, recipients []string, body string, params *Params) *SendRequest {
+	req := &SendRequest{
+		Originator: originator,
+		Recipients: recipients,
+		Body:       body,
+	}
+
+	if params == nil {
+		return req
+	}
+
+	req.GroupIds = params.GroupIds
+	req.Type = params.Type
+	req.Reference = params.Reference
+	req.Validity = params.Validity
+	req.Gateway = params.Gateway
+	req.TypeDetails = params.TypeDetails
+	req.DataCoding = params.DataCoding
+	req.ReportURL = params.ReportURL
+	req.ScheduledDatetime = params.ScheduledDatetime
+	req.ShortenURLs = params.ShortenURLs
+	req.Pool = params.Pool
+
+	return req
+}
+
 func paramsToRequest(originator string, recipients []string, body string, params *Params) (*messageRequest, error) {
-	if originator == "" {
-		return nil, errors.New("originator is required")
+	return newSendRequest(originator, recipients, body, params).request()
+}
+
+// request validates req and converts it to the request body.
+func (req *SendRequest) request() (*messageRequest, error) {
+	if req == nil {
+		return nil, errors.New("send request should not be nil")
+	}
+	if req.Originator == "" && req.Pool == "" {
+		return nil, errors.New("originator or pool is required")
 	}
-	if len(recipients) == 0 {
+	if len(req.Recipients) == 0 {
 		return nil, errors.New("at least 1 recipient is required")
 	}
-	if body == "" {
+	if req.Body == "" {
 		return nil, errors.New("body is required")
 	}
 
-	request := &messageRequest{
-		Originator: originator,
-		Recipients: recipients,
-		Body:       body,
+	// Recipients are normalized to MSISDNs, so e.g. +31 6 12345678 is
+	// accepted.
+	recipients := make([]string, len(req.Recipients))
+	for i, recipient := range req.Recipients {
+		recipients[i] = numberutil.Recipient(recipient)
 	}
 
-	if params == nil {
-		return request, nil
+	request := &messageRequest{
+		Originator:  req.Originator,
+		Recipients:  recipients,
+		Body:        req.Body,
+		GroupIds:    req.GroupIds,
+		Type:        req.Type,
+		Reference:   req.Reference,
+		Validity:    req.Validity,
+		Gateway:     req.Gateway,
+		TypeDetails: req.TypeDetails,
+		DataCoding:  req.DataCoding,
+		ReportURL:   req.ReportURL,
+		ShortenURLs: req.ShortenURLs,
+		Pool:        req.Pool,
 	}
 
-	request.Type = params.Type
-	if request.Type == "flash" {
-		request.MClass = 0
-	} else {
+	// Flash messages are class 0, all others class 1.
+	if req.Type != "flash" {
 		request.MClass = 1
 	}
 
-	if !params.ScheduledDatetime.IsZero() {
-		request.ScheduledDatetime = params.ScheduledDatetime.Format(time.RFC3339)
+	if !req.ScheduledDatetime.IsZero() {
+		request.ScheduledDatetime = req.ScheduledDatetime.Format(time.RFC3339)
 	}
 
-	request.GroupIds = params.GroupIds
-	request.Reference = params.Reference
-	request.Validity = params.Validity
-	request.Gateway = params.Gateway
-	request.TypeDetails = params.TypeDetails
-	request.DataCoding = params.DataCoding
-	request.ReportURL = params.ReportURL
-	request.ShortenURLs = params.ShortenURLs
-
 	return request, nil
 }
@@ -76,6 +76,45 @@ func TestCreate(t *testing.T) {
 	assertMessageObject(t, message, "sent")
 }
 
+func TestSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := Send(client, &SendRequest{
+		Originator: "TestName",
+		Recipients: []string{"+31 6 1234 5678"},
+		Body:       "Hello World",
+		Type:       "flash",
+		Reference:  "order-1234",
+	})
+	assert.NoError(t, err)
+	assertMessageObject(t, message, "sent")
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/messages")
+	mbtest.AssertBodyField(t, "reference", "order-1234")
+	mbtest.AssertBodyField(t, "type", "flash")
+	mbtest.AssertBodyField(t, "recipients", []string{"31612345678"})
+}
+
+func TestSendValidation(t *testing.T) {
+	client := mbtest.Client(t)
+
+	tests := []struct {
+		req      *SendRequest
+		expected string
+	}{
+		{nil, "send request should not be nil"},
+		{&SendRequest{Recipients: []string{"31612345678"}, Body: "Hello World"}, "originator or pool is required"},
+		{&SendRequest{Originator: "TestName", Body: "Hello World"}, "at least 1 recipient is required"},
+		{&SendRequest{Originator: "TestName", Recipients: []string{"31612345678"}}, "body is required"},
+	}
+
+	for _, tt := range tests {
+		_, err := Send(client, tt.req)
+		assert.EqualError(t, err, tt.expected)
+	}
+}
+
 func TestCreateError(t *testing.T) {
 	mbtest.WillReturnAccessKeyError()
 	client := mbtest.Client(t)
@@ -285,3 +324,26 @@ func TestDeleteNotFound(t *testing.T) {
 	err := Delete(client, "6fe65f90454aa61536e6a88b88972670")
 	assert.EqualError(t, err, "API errors: message not found")
 }
+
+func TestCreateFromPool(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := Create(client, "", []string{"31612345678"}, "Hello World", &Params{Pool: "my-pool"})
+	assert.NoError(t, err)
+
+	mbtest.AssertBodyField(t, "pool", "my-pool")
+	mbtest.AssertBodyField(t, "originator", "")
+
+	_, err = Create(client, "", []string{"31612345678"}, "Hello World", nil)
+	assert.EqualError(t, err, "originator or pool is required")
+}
+
+func BenchmarkListParamsQueryParams(b *testing.B) {
+	params := &ListParams{Originator: "MessageBird", Direction: "mt", Status: "delivered", Limit: 20, Offset: 40}
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = params.QueryParams()
+	}
+}
@@ -0,0 +1,46 @@
+package sms
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Service is the SMS API bound to a client. The functions of this package
+// can not be replaced in tests; code that depends on Service can be given a
+// fake instead, e.g. one generated by mockgen or moq.
+type Service interface {
+	Read(id string) (*Message, error)
+	Delete(id string) error
+	List(params *ListParams) (*MessageList, error)
+	Create(originator string, recipients []string, body string, msgParams *Params) (*Message, error)
+	Send(req *SendRequest) (*Message, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Read(id string) (*Message, error) {
+	return Read(s.c, id)
+}
+
+func (s *service) Delete(id string) error {
+	return Delete(s.c, id)
+}
+
+func (s *service) List(params *ListParams) (*MessageList, error) {
+	return List(s.c, params)
+}
+
+func (s *service) Create(originator string, recipients []string, body string, msgParams *Params) (*Message, error) {
+	return Create(s.c, originator, recipients, body, msgParams)
+}
+
+func (s *service) Send(req *SendRequest) (*Message, error) {
+	return Send(s.c, req)
+}
@@ -0,0 +1,20 @@
+package sms
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "readMessageObject.json", http.StatusOK)
+	var s Service = NewService(mbtest.Client(t))
+
+	message, err := s.Read("6fe65f90454aa61536e6a88b88972670")
+	assert.NoError(t, err)
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/messages/6fe65f90454aa61536e6a88b88972670")
+
+	assertExtendedMessageObject(t, message)
+}
@@ -0,0 +1,123 @@
+package sms
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Shortener shortens the URLs in message bodies, e.g. with a link
+// shortening service that tracks clicks.
+type Shortener interface {
+	Shorten(ctx context.Context, longURL string) (string, error)
+}
+
+// ShortenerFunc is a function that implements Shortener.
+type ShortenerFunc func(ctx context.Context, longURL string) (string, error)
+
+// Shorten calls f.
+func (f ShortenerFunc) Shorten(ctx context.Context, longURL string) (string, error) {
+	return f(ctx, longURL)
+}
+
+// DefaultShortener has MessageBird shorten URLs. It leaves the URLs in the
+// body and SendShortened sends the message with ShortenURLs, so the API
+// replaces them by links on its tracking domain and reports clicks on them
+// with webhooks (see webhooks.SMSClick).
+var DefaultShortener Shortener = apiShortener{}
+
+// TrackedURLLength is the length of the links the API shortens URLs to, used
+// to estimate the length of bodies shortened by DefaultShortener.
+var TrackedURLLength = 23
+
+type apiShortener struct{}
+
+func (apiShortener) Shorten(ctx context.Context, longURL string) (string, error) {
+	return longURL, nil
+}
+
+// urlPattern matches http and https URLs up to the next whitespace.
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// FindURLs returns the http and https URLs in body. Punctuation that ends a
+// sentence is not considered part of a URL.
+func FindURLs(body string) []string {
+	var urls []string
+	for _, loc := range urlLocations(body) {
+		urls = append(urls, body[loc[0]:loc[1]])
+	}
+
+	return urls
+}
+
+func urlLocations(body string) [][]int {
+	locs := urlPattern.FindAllStringIndex(body, -1)
+	for _, loc := range locs {
+		loc[1] = loc[0] + len(strings.TrimRight(body[loc[0]:loc[1]], ".,;:!?)'"))
+	}
+
+	return locs
+}
+
+// ShortenBody replaces the URLs in body by the ones s shortens them to. It
+// returns the new body and its length. A nil s is DefaultShortener, which
+// leaves body as is, but returns the length it has once the API shortened
+// its URLs.
+func ShortenBody(ctx context.Context, s Shortener, body string) (string, Length, error) {
+	if s == nil {
+		s = DefaultShortener
+	}
+
+	var shortened, estimate strings.Builder
+	last := 0
+	for _, loc := range urlLocations(body) {
+		short, err := s.Shorten(ctx, body[loc[0]:loc[1]])
+		if err != nil {
+			return "", Length{}, err
+		}
+
+		shortened.WriteString(body[last:loc[0]])
+		shortened.WriteString(short)
+
+		estimate.WriteString(body[last:loc[0]])
+		if _, ok := s.(apiShortener); ok {
+			short = strings.Repeat("x", TrackedURLLength)
+		}
+		estimate.WriteString(short)
+
+		last = loc[1]
+	}
+	shortened.WriteString(body[last:])
+	estimate.WriteString(body[last:])
+
+	return shortened.String(), LengthOf(estimate.String()), nil
+}
+
+// SendShortened shortens the URLs in the body of req with s and sends the
+// message. A nil s is DefaultShortener, for which the message is sent with
+// ShortenURLs instead. req is not modified.
+func SendShortened(ctx context.Context, c messagebird.Client, s Shortener, req *SendRequest) (*Message, error) {
+	if req == nil {
+		return Send(c, req)
+	}
+
+	if s == nil {
+		s = DefaultShortener
+	}
+
+	shortened := *req
+	if _, ok := s.(apiShortener); ok {
+		shortened.ShortenURLs = true
+		return Send(c, &shortened)
+	}
+
+	body, _, err := ShortenBody(ctx, s, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	shortened.Body = body
+
+	return Send(c, &shortened)
+}
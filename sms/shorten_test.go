@@ -0,0 +1,66 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var testShortener = ShortenerFunc(func(ctx context.Context, longURL string) (string, error) {
+	return "https://sho.rt/" + strings.Repeat("x", 4), nil
+})
+
+func TestFindURLs(t *testing.T) {
+	assert.Nil(t, FindURLs("Hello World"))
+	assert.Equal(t, []string{"https://example.com/a?b=c", "http://example.org"},
+		FindURLs("See https://example.com/a?b=c, or (http://example.org)."))
+}
+
+func TestShortenBody(t *testing.T) {
+	long := "Your order shipped: https://example.com/track?order=1234567890&utm_source=sms&utm_medium=text&utm_campaign=shipping-notifications-" + strings.Repeat("a", 60) + ". Thanks!"
+
+	body, length, err := ShortenBody(context.Background(), testShortener, long)
+	assert.NoError(t, err)
+	assert.Equal(t, "Your order shipped: https://sho.rt/xxxx. Thanks!", body)
+	assert.Equal(t, LengthOf(body), length)
+
+	body, length, err = ShortenBody(context.Background(), nil, long)
+	assert.NoError(t, err)
+	assert.Equal(t, long, body)
+	assert.Equal(t, 2, LengthOf(long).Parts)
+	assert.Equal(t, Length{DataCodingPlain, 20 + TrackedURLLength + 9, 1}, length)
+
+	failing := ShortenerFunc(func(ctx context.Context, longURL string) (string, error) {
+		return "", errors.New("unavailable")
+	})
+	_, _, err = ShortenBody(context.Background(), failing, long)
+	assert.EqualError(t, err, "unavailable")
+}
+
+func TestSendShortened(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	req := &SendRequest{
+		Originator: "TestName",
+		Recipients: []string{"31612345678"},
+		Body:       "Track it at https://example.com/track?order=1234",
+	}
+
+	_, err := SendShortened(context.Background(), client, testShortener, req)
+	assert.NoError(t, err)
+	mbtest.AssertBodyField(t, "body", "Track it at https://sho.rt/xxxx")
+	mbtest.AssertBodyField(t, "shortenUrls", false)
+	assert.Equal(t, "Track it at https://example.com/track?order=1234", req.Body)
+
+	_, err = SendShortened(context.Background(), client, nil, req)
+	assert.NoError(t, err)
+	mbtest.AssertBodyField(t, "body", "Track it at https://example.com/track?order=1234")
+	mbtest.AssertBodyField(t, "shortenUrls", true)
+	assert.False(t, req.ShortenURLs)
+}
@@ -0,0 +1,160 @@
+package sms
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/capabilities"
+)
+
+// OriginatorStore remembers the originator messages to each recipient were
+// sent from. Implementations must be safe for concurrent use.
+type OriginatorStore interface {
+	// Originator returns the originator messages to recipient were sent
+	// from, or an empty string if none were.
+	Originator(recipient string) (string, error)
+
+	// SetOriginator records that messages to recipient are sent from
+	// originator.
+	SetOriginator(recipient, originator string) error
+}
+
+// MemoryOriginatorStore is an OriginatorStore that keeps originators in
+// memory. They are lost when the process exits.
+type MemoryOriginatorStore struct {
+	mu          sync.RWMutex
+	originators map[string]string
+}
+
+// NewMemoryOriginatorStore creates an empty MemoryOriginatorStore.
+func NewMemoryOriginatorStore() *MemoryOriginatorStore {
+	return &MemoryOriginatorStore{originators: make(map[string]string)}
+}
+
+// Originator implements OriginatorStore.
+func (s *MemoryOriginatorStore) Originator(recipient string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.originators[normalize(recipient)], nil
+}
+
+// SetOriginator implements OriginatorStore.
+func (s *MemoryOriginatorStore) SetOriginator(recipient, originator string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.originators[normalize(recipient)] = originator
+	return nil
+}
+
+// StickyClient is a messagebird.Client that sends SMS messages to a
+// recipient from the same number every time, so replies thread into one
+// conversation on their phone. The originator of the first message to a
+// recipient, the one picked from the pool if the message was sent from a
+// pool, is remembered in Store. It replaces the originator and pool of later
+// messages to the recipient:
+//
+//	client := &sms.StickyClient{Client: messagebird.New(accessKey), Store: store}
+//	sms.Send(client, &sms.SendRequest{Pool: "support", Recipients: recipients, Body: body})
+//
+// Alphanumeric originators can not be replied to, so messages from them are
+// sent unchanged and not remembered. A message to several recipients is only
+// sent from a remembered originator if it is the same for all recipients
+// that have one; send separate messages otherwise. All other requests are
+// passed to Client unchanged.
+type StickyClient struct {
+	Client messagebird.Client
+	Store  OriginatorStore
+
+	// ErrorLog, if set, is called when the originator of a message that was
+	// sent can not be stored. The message is not sent again, so the error is
+	// not returned.
+	ErrorLog func(err error)
+}
+
+// Request implements messagebird.Client.
+func (sc *StickyClient) Request(v interface{}, method, p string, data interface{}) error {
+	req, ok := data.(*messageRequest)
+	if method != http.MethodPost || p != path || !ok {
+		return sc.Client.Request(v, method, p, data)
+	}
+	if req.Originator != "" && capabilities.OriginatorTypeOf(req.Originator) == capabilities.OriginatorTypeAlphanumeric {
+		return sc.Client.Request(v, method, p, data)
+	}
+
+	sticky, unknown, err := sc.lookup(req.Recipients)
+	if err != nil {
+		return err
+	}
+
+	if sticky != "" {
+		copied := *req
+		copied.Originator, copied.Pool = sticky, ""
+		req = &copied
+	}
+
+	if err := sc.Client.Request(v, method, p, req); err != nil {
+		return err
+	}
+
+	// The API picks the originator of messages sent from a pool.
+	originator := req.Originator
+	if message, ok := v.(*Message); ok && message.Originator != "" {
+		originator = message.Originator
+	}
+	if originator == "" || capabilities.OriginatorTypeOf(originator) == capabilities.OriginatorTypeAlphanumeric {
+		return nil
+	}
+
+	for _, recipient := range unknown {
+		if err := sc.Store.SetOriginator(recipient, originator); err != nil && sc.ErrorLog != nil {
+			sc.ErrorLog(err)
+		}
+	}
+
+	return nil
+}
+
+// Unwrap implements messagebird.Wrapper.
+func (sc *StickyClient) Unwrap() messagebird.Client {
+	return sc.Client
+}
+
+// lookup returns the originator remembered for recipients, or an empty
+// string if there is none or they differ, and the recipients without one.
+func (sc *StickyClient) lookup(recipients []string) (string, []string, error) {
+	var sticky string
+	var mixed bool
+	var unknown []string
+
+	for _, recipient := range recipients {
+		originator, err := sc.Store.Originator(recipient)
+		if err != nil {
+			return "", nil, err
+		}
+
+		switch {
+		case originator == "":
+			unknown = append(unknown, recipient)
+		case sticky == "":
+			sticky = originator
+		case originator != sticky:
+			mixed = true
+		}
+	}
+
+	if mixed {
+		return "", unknown, nil
+	}
+
+	return sticky, unknown, nil
+}
+
+// normalize removes the + and spaces from MSISDNs, so recipients in
+// different formats are remembered once.
+func normalize(msisdn string) string {
+	return strings.TrimPrefix(strings.ReplaceAll(strings.TrimSpace(msisdn), " ", ""), "+")
+}
@@ -0,0 +1,147 @@
+package sms
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// poolClient sends messages from the originator of the request, or from
+// poolNumber if they are sent from a pool.
+type poolClient struct {
+	poolNumber string
+	requests   []*messageRequest
+}
+
+func (pc *poolClient) Request(v interface{}, method, path string, data interface{}) error {
+	req := data.(*messageRequest)
+	pc.requests = append(pc.requests, req)
+
+	message := v.(*Message)
+	message.Originator = req.Originator
+	if req.Pool != "" {
+		message.Originator = pc.poolNumber
+	}
+
+	return nil
+}
+
+func (pc *poolClient) last() *messageRequest {
+	return pc.requests[len(pc.requests)-1]
+}
+
+func TestStickyClient(t *testing.T) {
+	pc := &poolClient{poolNumber: "31970000001"}
+	store := NewMemoryOriginatorStore()
+	client := &StickyClient{Client: pc, Store: store}
+
+	message, err := Send(client, &SendRequest{Pool: "support", Recipients: []string{"+31612345678"}, Body: "Hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, "31970000001", message.Originator)
+
+	originator, _ := store.Originator("31612345678")
+	assert.Equal(t, "31970000001", originator, "the number picked from the pool is remembered")
+
+	pc.poolNumber = "31970000002"
+	message, err = Send(client, &SendRequest{Pool: "support", Recipients: []string{"31612345678"}, Body: "Are you there?"})
+	assert.NoError(t, err)
+	assert.Equal(t, "31970000001", message.Originator)
+	assert.Equal(t, "31970000001", pc.last().Originator)
+	assert.Empty(t, pc.last().Pool)
+
+	_, err = Send(client, &SendRequest{Originator: "31970000003", Recipients: []string{"31612345678", "31687654321"}, Body: "Hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, "31970000001", pc.last().Originator, "the remembered originator replaces the one of the request")
+
+	originator, _ = store.Originator("31687654321")
+	assert.Equal(t, "31970000001", originator, "new recipients are remembered with the originator used")
+}
+
+func TestStickyClientAlphanumeric(t *testing.T) {
+	pc := &poolClient{}
+	store := NewMemoryOriginatorStore()
+	store.SetOriginator("31612345678", "31970000001")
+	client := &StickyClient{Client: pc, Store: store}
+
+	_, err := Send(client, &SendRequest{Originator: "Acme", Recipients: []string{"31612345678", "31687654321"}, Body: "Hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme", pc.last().Originator)
+
+	originator, _ := store.Originator("31687654321")
+	assert.Empty(t, originator, "alphanumeric originators are not remembered")
+}
+
+func TestStickyClientMixed(t *testing.T) {
+	pc := &poolClient{}
+	store := NewMemoryOriginatorStore()
+	store.SetOriginator("31612345678", "31970000001")
+	store.SetOriginator("31687654321", "31970000002")
+	client := &StickyClient{Client: pc, Store: store}
+
+	_, err := Send(client, &SendRequest{Originator: "31970000003", Recipients: []string{"31612345678", "31687654321", "31611111111"}, Body: "Hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, "31970000003", pc.last().Originator)
+
+	originator, _ := store.Originator("31612345678")
+	assert.Equal(t, "31970000001", originator)
+	originator, _ = store.Originator("31611111111")
+	assert.Equal(t, "31970000003", originator)
+}
+
+type failingOriginatorStore struct {
+	*MemoryOriginatorStore
+}
+
+func (s failingOriginatorStore) SetOriginator(recipient, originator string) error {
+	return errors.New("store unavailable")
+}
+
+func TestStickyClientStoreError(t *testing.T) {
+	var logged []error
+	client := &StickyClient{
+		Client:   &poolClient{},
+		Store:    failingOriginatorStore{NewMemoryOriginatorStore()},
+		ErrorLog: func(err error) { logged = append(logged, err) },
+	}
+
+	_, err := Send(client, &SendRequest{Originator: "31970000001", Recipients: []string{"31612345678"}, Body: "Hello"})
+	assert.NoError(t, err, "the message was sent")
+	assert.Len(t, logged, 1)
+}
+
+func TestStickyClientOtherRequests(t *testing.T) {
+	var called bool
+	client := &StickyClient{
+		Client: clientFunc(func(v interface{}, method, path string, data interface{}) error {
+			called = true
+			assert.Equal(t, http.MethodGet, method)
+			return nil
+		}),
+		Store: NewMemoryOriginatorStore(),
+	}
+
+	_, err := Read(client, "6fe65f90454aa61536e6a88b88972670")
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+type clientFunc func(v interface{}, method, path string, data interface{}) error
+
+func (f clientFunc) Request(v interface{}, method, path string, data interface{}) error {
+	return f(v, method, path, data)
+}
+
+func TestStickyClientUnwrap(t *testing.T) {
+	c := messagebird.New("test_accesskey")
+	c.RequireConsent = true
+	c.Clock = messagebirdtest.NewFakeClock(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+	client := &StickyClient{Client: c, Store: NewMemoryOriginatorStore()}
+
+	assert.True(t, messagebird.RequiresConsent(client))
+	assert.Equal(t, c.Clock, messagebird.ClockOf(client))
+}
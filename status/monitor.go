@@ -0,0 +1,133 @@
+package status
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// DefaultInterval is the interval of a Monitor without Interval. The status
+// page is updated by hand, so checking it more often does not help.
+const DefaultInterval = time.Minute
+
+// Report is the result of a check of a Monitor.
+type Report struct {
+	// Healthy is true if the API responded and none of the watched
+	// components has an outage.
+	Healthy bool
+
+	// ProbeErr is the reason the API did not respond, or nil.
+	ProbeErr error
+
+	// Summary is the summary of the status page, or nil if it could not be
+	// read, in which case FeedErr is the reason. The status page being
+	// unavailable does not make the API unhealthy.
+	Summary *Summary
+	FeedErr error
+
+	// Outages are the watched components with an outage.
+	Outages []*Component
+
+	CheckedAt time.Time
+}
+
+// Monitor periodically checks the health of the MessageBird platform: it
+// requests the API like a messagebird.Pinger, and reads the status page for
+// outages. The fields must not be changed once Run is called.
+type Monitor struct {
+	Client *messagebird.DefaultClient
+
+	// Interval is the time between checks. DefaultInterval is used if it is
+	// zero.
+	Interval time.Duration
+
+	// Components are the names of the components on the status page the
+	// application depends on, e.g. "SMS". An outage of any component makes
+	// the platform unhealthy if it is empty.
+	Components []string
+
+	// URLs are requested to check the API is reachable, see
+	// messagebird.Pinger.
+	URLs []string
+
+	// FeedURL is the URL of the status page summary. The package-level
+	// FeedURL is used if it is empty.
+	FeedURL string
+
+	// OnChange is called when the platform becomes healthy or unhealthy,
+	// and after the first check.
+	OnChange func(r *Report)
+
+	mu     sync.Mutex
+	report *Report
+}
+
+// Run checks every Interval, starting straight away. It blocks until ctx is
+// done and returns ctx.Err().
+func (m *Monitor) Run(ctx context.Context) error {
+	clock := messagebird.ClockOf(m.Client)
+
+	interval := m.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	for {
+		m.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
+}
+
+// Check checks the API and the status page once, and returns the report.
+func (m *Monitor) Check(ctx context.Context) *Report {
+	r := &Report{CheckedAt: messagebird.ClockOf(m.Client).Now()}
+
+	probe := &messagebird.Pinger{Client: m.Client, URLs: m.URLs}
+	r.ProbeErr = probe.Ping(ctx)
+
+	feedURL := m.FeedURL
+	if feedURL == "" {
+		feedURL = FeedURL
+	}
+	r.Summary, r.FeedErr = read(ctx, m.Client.HTTPClient, feedURL)
+	if r.Summary != nil {
+		r.Outages = r.Summary.Outages(m.Components...)
+	}
+
+	r.Healthy = r.ProbeErr == nil && len(r.Outages) == 0
+
+	m.mu.Lock()
+	changed := m.report == nil || m.report.Healthy != r.Healthy
+	m.report = r
+	m.mu.Unlock()
+
+	if changed && m.OnChange != nil {
+		m.OnChange(r)
+	}
+
+	return r
+}
+
+// Healthy reports whether the platform was healthy at the last check. It is
+// false until the first check completes.
+func (m *Monitor) Healthy() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.report != nil && m.report.Healthy
+}
+
+// Report returns the report of the last check, or nil if there was none.
+func (m *Monitor) Report() *Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.report
+}
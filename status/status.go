@@ -0,0 +1,152 @@
+// Package status follows the health of the MessageBird platform. Read fetches
+// the public status page, which reports outages and maintenance per component,
+// e.g. "SMS" or "Voice". Monitor combines the status page with a request to
+// the API, and can be set as the Health of a client:
+//
+//	m := &status.Monitor{Client: client, Components: []string{"SMS"}}
+//	go m.Run(ctx)
+//	client.Health = m
+//
+//	if !client.Healthy() {
+//		// Fail over, or alert.
+//	}
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// FeedURL is the URL of the summary of the MessageBird status page.
+const FeedURL = "https://status.messagebird.com/api/v2/summary.json"
+
+// Indicator is the overall status of the platform.
+type Indicator string
+
+const (
+	IndicatorNone     Indicator = "none"
+	IndicatorMinor    Indicator = "minor"
+	IndicatorMajor    Indicator = "major"
+	IndicatorCritical Indicator = "critical"
+)
+
+// ComponentStatus is the status of a component of the platform.
+type ComponentStatus string
+
+const (
+	ComponentOperational         ComponentStatus = "operational"
+	ComponentDegradedPerformance ComponentStatus = "degraded_performance"
+	ComponentPartialOutage       ComponentStatus = "partial_outage"
+	ComponentMajorOutage         ComponentStatus = "major_outage"
+	ComponentUnderMaintenance    ComponentStatus = "under_maintenance"
+)
+
+// IsOutage reports whether s means the component is not available, or only
+// in part.
+func (s ComponentStatus) IsOutage() bool {
+	return s == ComponentPartialOutage || s == ComponentMajorOutage
+}
+
+// Summary is the summary of the status page.
+type Summary struct {
+	Status struct {
+		Indicator   Indicator `json:"indicator"`
+		Description string    `json:"description"`
+	} `json:"status"`
+
+	Components []*Component `json:"components"`
+
+	// Incidents are the unresolved incidents.
+	Incidents []*Incident `json:"incidents"`
+}
+
+// Component is a part of the platform, e.g. "SMS".
+type Component struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Status ComponentStatus `json:"status"`
+}
+
+// Incident is an incident reported on the status page.
+type Incident struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Impact    Indicator `json:"impact"`
+	Shortlink string    `json:"shortlink"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Component returns the component with name, or nil if there is none.
+func (s *Summary) Component(name string) *Component {
+	for _, c := range s.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Outages returns the components with an outage. Only the components with
+// names are considered, or all if names is empty.
+func (s *Summary) Outages(names ...string) []*Component {
+	var outages []*Component
+	for _, c := range s.Components {
+		if c.Status.IsOutage() && (len(names) == 0 || contains(names, c.Name)) {
+			outages = append(outages, c)
+		}
+	}
+
+	return outages
+}
+
+// Read fetches the summary of the status page at FeedURL with hc, or
+// http.DefaultClient if it is nil. The status page is not part of the API, so
+// no access key is sent.
+func Read(ctx context.Context, hc *http.Client) (*Summary, error) {
+	return read(ctx, hc, FeedURL)
+}
+
+func read(ctx context.Context, hc *http.Client, url string) (*Summary, error) {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := hc.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status page %s: %s", url, response.Status)
+	}
+
+	summary := &Summary{}
+	if err := json.NewDecoder(response.Body).Decode(summary); err != nil {
+		return nil, fmt.Errorf("status page %s: %v", url, err)
+	}
+
+	return summary, nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
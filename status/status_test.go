@@ -0,0 +1,132 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/messagebirdtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnFile("summary.json", http.StatusOK)
+
+	summary, err := Read(context.Background(), s.Client().HTTPClient)
+	assert.NoError(t, err)
+	assert.Equal(t, IndicatorMajor, summary.Status.Indicator)
+	assert.Len(t, summary.Components, 3)
+	assert.Equal(t, ComponentPartialOutage, summary.Component("Voice").Status)
+	assert.Nil(t, summary.Component("Email"))
+
+	if assert.Len(t, summary.Incidents, 1) {
+		assert.Equal(t, IndicatorMajor, summary.Incidents[0].Impact)
+		assert.Equal(t, time.Date(2024, 5, 31, 9, 58, 11, 0, time.UTC), summary.Incidents[0].CreatedAt)
+	}
+
+	request := s.LastRequest()
+	assert.Equal(t, "status.messagebird.com", request.Host)
+	assert.Empty(t, request.Header.Get("Authorization"), "the access key is not sent to the status page")
+}
+
+func TestReadError(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnStatus(http.StatusBadGateway)
+
+	_, err := Read(context.Background(), s.Client().HTTPClient)
+	assert.EqualError(t, err, "status page "+FeedURL+": 502 Bad Gateway")
+}
+
+func TestSummaryOutages(t *testing.T) {
+	summary := &Summary{Components: []*Component{
+		{Name: "SMS", Status: ComponentOperational},
+		{Name: "Voice", Status: ComponentMajorOutage},
+		{Name: "WhatsApp", Status: ComponentUnderMaintenance},
+	}}
+
+	assert.Len(t, summary.Outages(), 1)
+	assert.Len(t, summary.Outages("Voice"), 1)
+	assert.Empty(t, summary.Outages("SMS", "WhatsApp"))
+}
+
+func TestMonitor(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnFile("summary.json", http.StatusOK)
+	s.FailOnCall(3, messagebirdtest.FailWithServerError())
+
+	client := s.Client()
+	var reports []*Report
+	m := &Monitor{
+		Client:     client,
+		Components: []string{"SMS"},
+		OnChange:   func(r *Report) { reports = append(reports, r) },
+	}
+	client.Health = m
+	assert.False(t, client.Healthy(), "unhealthy until the first check")
+
+	// Only Voice has an outage.
+	r := m.Check(context.Background())
+	assert.True(t, r.Healthy)
+	assert.NoError(t, r.ProbeErr)
+	assert.NoError(t, r.FeedErr)
+	assert.Empty(t, r.Outages)
+	assert.True(t, client.Healthy())
+
+	// The probe fails.
+	r = m.Check(context.Background())
+	assert.False(t, r.Healthy)
+	assert.Error(t, r.ProbeErr)
+	assert.False(t, client.Healthy())
+	assert.Same(t, r, m.Report())
+
+	// The status page reports an outage of a watched component.
+	m.Components = nil
+	r = m.Check(context.Background())
+	assert.False(t, r.Healthy)
+	assert.NoError(t, r.ProbeErr)
+	if assert.Len(t, r.Outages, 1) {
+		assert.Equal(t, "Voice", r.Outages[0].Name)
+	}
+
+	assert.Len(t, reports, 2, "OnChange is only called when the health changes")
+	assert.Len(t, s.Requests(), 6)
+}
+
+func TestMonitorFeedUnavailable(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnFile("summary.json", http.StatusOK)
+	s.FailOnCall(2, messagebirdtest.FailWithServerError())
+
+	m := &Monitor{Client: s.Client()}
+	r := m.Check(context.Background())
+
+	assert.Error(t, r.FeedErr)
+	assert.Nil(t, r.Summary)
+	assert.True(t, r.Healthy, "the status page being unavailable does not make the API unhealthy")
+}
+
+func TestMonitorRun(t *testing.T) {
+	s := messagebirdtest.NewServer(t)
+	s.WillReturnFile("summary.json", http.StatusOK)
+
+	clock := messagebirdtest.NewFakeClock(time.Now())
+	client := s.Client()
+	client.Clock = clock
+
+	m := &Monitor{Client: client, Components: []string{"SMS"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	clock.BlockUntil(1)
+	clock.Advance(DefaultInterval)
+	clock.BlockUntil(1)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Len(t, s.Requests(), 4)
+	assert.True(t, m.Healthy())
+}
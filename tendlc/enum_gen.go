@@ -0,0 +1,69 @@
+// Code generated by enumgen -type BrandStatus,CampaignStatus; DO NOT EDIT.
+
+package tendlc
+
+// BrandStatusValues returns the known values of BrandStatus.
+func BrandStatusValues() []BrandStatus {
+	return []BrandStatus{
+		BrandStatusPending,
+		BrandStatusVerified,
+		BrandStatusUnverified,
+	}
+}
+
+// IsValid reports whether b is one of BrandStatusValues.
+func (b BrandStatus) IsValid() bool {
+	switch b {
+	case BrandStatusPending,
+		BrandStatusVerified,
+		BrandStatusUnverified:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as BrandStatusUnknown.
+func (b *BrandStatus) UnmarshalText(text []byte) error {
+	*b = BrandStatus(text)
+	if *b != "" && !b.IsValid() {
+		*b = BrandStatusUnknown
+	}
+
+	return nil
+}
+
+// CampaignStatusValues returns the known values of CampaignStatus.
+func CampaignStatusValues() []CampaignStatus {
+	return []CampaignStatus{
+		CampaignStatusPending,
+		CampaignStatusActive,
+		CampaignStatusRejected,
+		CampaignStatusExpired,
+	}
+}
+
+// IsValid reports whether c is one of CampaignStatusValues.
+func (c CampaignStatus) IsValid() bool {
+	switch c {
+	case CampaignStatusPending,
+		CampaignStatusActive,
+		CampaignStatusRejected,
+		CampaignStatusExpired:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as CampaignStatusUnknown.
+func (c *CampaignStatus) UnmarshalText(text []byte) error {
+	*c = CampaignStatus(text)
+	if *c != "" && !c.IsValid() {
+		*c = CampaignStatusUnknown
+	}
+
+	return nil
+}
@@ -0,0 +1,311 @@
+// Package tendlc manages US 10DLC (10-digit long code) registrations. Carriers
+// require A2P (application-to-person) traffic sent from US long codes to be
+// registered: a Brand identifies the business sending messages, a Campaign
+// describes the use case, and numbers are linked to a campaign before they can
+// be used to send messages.
+package tendlc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+//go:generate go run ../internal/enumgen -type BrandStatus,CampaignStatus
+
+const (
+	// apiRoot is the absolute URL of the 10DLC resources of the Numbers API.
+	apiRoot = "https://numbers.messagebird.com/v1/10dlc"
+
+	pathBrands = "brands"
+
+	pathCampaigns = "campaigns"
+
+	pathNumbers = "numbers"
+)
+
+type EntityType string
+
+const (
+	EntityTypePrivateProfit  EntityType = "PRIVATE_PROFIT"
+	EntityTypePublicProfit   EntityType = "PUBLIC_PROFIT"
+	EntityTypeNonProfit      EntityType = "NON_PROFIT"
+	EntityTypeGovernment     EntityType = "GOVERNMENT"
+	EntityTypeSoleProprietor EntityType = "SOLE_PROPRIETOR"
+)
+
+type BrandStatus string
+
+const (
+	BrandStatusPending    BrandStatus = "pending"
+	BrandStatusVerified   BrandStatus = "verified"
+	BrandStatusUnverified BrandStatus = "unverified"
+	BrandStatusUnknown    BrandStatus = "unknown"
+)
+
+type CampaignStatus string
+
+const (
+	CampaignStatusPending  CampaignStatus = "pending"
+	CampaignStatusActive   CampaignStatus = "active"
+	CampaignStatusRejected CampaignStatus = "rejected"
+	CampaignStatusExpired  CampaignStatus = "expired"
+	CampaignStatusUnknown  CampaignStatus = "unknown"
+)
+
+// UseCase is the kind of traffic a Campaign is registered for.
+type UseCase string
+
+const (
+	UseCase2FA                  UseCase = "2FA"
+	UseCaseAccountNotification  UseCase = "ACCOUNT_NOTIFICATION"
+	UseCaseCustomerCare         UseCase = "CUSTOMER_CARE"
+	UseCaseDeliveryNotification UseCase = "DELIVERY_NOTIFICATION"
+	UseCaseMarketing            UseCase = "MARKETING"
+	UseCaseMixed                UseCase = "MIXED"
+)
+
+// Brand is the business on whose behalf messages are sent.
+type Brand struct {
+	ID          string
+	EntityType  EntityType
+	CompanyName string
+	DisplayName string
+	TaxID       string
+	Vertical    string
+	Website     string
+	Email       string
+	Phone       string
+	Street      string
+	City        string
+	State       string
+	PostalCode  string
+	Country     string
+	Status      BrandStatus
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
+
+// IsVerified reports whether the brand has been verified, which is required
+// before campaigns can be activated.
+func (b *Brand) IsVerified() bool {
+	return b.Status == BrandStatusVerified
+}
+
+// Brands is a list of Brand objects.
+type Brands struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Brand
+}
+
+// Campaign is a registered use case of a Brand.
+type Campaign struct {
+	ID             string
+	BrandID        string
+	UseCase        UseCase
+	Description    string
+	SampleMessages []string
+	Numbers        []string
+	Status         CampaignStatus
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+}
+
+// IsActive reports whether messages can be sent for the campaign.
+func (c *Campaign) IsActive() bool {
+	return c.Status == CampaignStatusActive
+}
+
+// Campaigns is a list of Campaign objects.
+type Campaigns struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Campaign
+}
+
+// CreateBrandRequest contains the details to register a Brand with.
+type CreateBrandRequest struct {
+	EntityType  EntityType `json:"entityType"`
+	CompanyName string     `json:"companyName"`
+	DisplayName string     `json:"displayName,omitempty"`
+	TaxID       string     `json:"taxId,omitempty"`
+	Vertical    string     `json:"vertical,omitempty"`
+	Website     string     `json:"website,omitempty"`
+	Email       string     `json:"email"`
+	Phone       string     `json:"phone"`
+	Street      string     `json:"street,omitempty"`
+	City        string     `json:"city,omitempty"`
+	State       string     `json:"state,omitempty"`
+	PostalCode  string     `json:"postalCode,omitempty"`
+	Country     string     `json:"country"`
+}
+
+// CreateCampaignRequest contains the details to register a Campaign with.
+type CreateCampaignRequest struct {
+	BrandID        string   `json:"brandId"`
+	UseCase        UseCase  `json:"useCase"`
+	Description    string   `json:"description"`
+	SampleMessages []string `json:"sampleMessages"`
+}
+
+// ListRequest can be used to set query params in ListBrands() and
+// ListCampaigns().
+type ListRequest struct {
+	Limit  int
+	Offset int
+
+	// BrandID only returns campaigns of the given brand. It is ignored by
+	// ListBrands.
+	BrandID string
+}
+
+func (lr *ListRequest) QueryParams() string {
+	if lr == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
+
+	if lr.BrandID != "" {
+		query.Set("brandId", lr.BrandID)
+	}
+
+	return query.Encode()
+}
+
+// CreateBrand registers a new brand. Brands are verified asynchronously:
+// use ReadBrand to check its status.
+func CreateBrand(c messagebird.Client, req *CreateBrandRequest) (*Brand, error) {
+	if req == nil {
+		return nil, errors.New("create brand request should not be nil")
+	}
+	if req.CompanyName == "" || req.EntityType == "" {
+		return nil, errors.New("companyName and entityType are required")
+	}
+
+	brand := &Brand{}
+	if err := request(c, brand, http.MethodPost, pathBrands, req); err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+// ReadBrand retrieves a single brand.
+func ReadBrand(c messagebird.Client, id string) (*Brand, error) {
+	brand := &Brand{}
+	if err := request(c, brand, http.MethodGet, pathBrands+"/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+// ListBrands retrieves the registered brands.
+func ListBrands(c messagebird.Client, params *ListRequest) (*Brands, error) {
+	// Brands can not be filtered by brand.
+	var query string
+	if params != nil {
+		query = (&ListRequest{Limit: params.Limit, Offset: params.Offset}).QueryParams()
+	}
+
+	brands := &Brands{}
+	if err := request(c, brands, http.MethodGet, pathBrands+"?"+query, nil); err != nil {
+		return nil, err
+	}
+
+	return brands, nil
+}
+
+// CreateCampaign registers a new campaign for a brand.
+func CreateCampaign(c messagebird.Client, req *CreateCampaignRequest) (*Campaign, error) {
+	if req == nil {
+		return nil, errors.New("create campaign request should not be nil")
+	}
+	if req.BrandID == "" || req.UseCase == "" {
+		return nil, errors.New("brandId and useCase are required")
+	}
+	if len(req.SampleMessages) == 0 {
+		return nil, errors.New("at least one sample message is required")
+	}
+
+	campaign := &Campaign{}
+	if err := request(c, campaign, http.MethodPost, pathCampaigns, req); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// ReadCampaign retrieves a single campaign.
+func ReadCampaign(c messagebird.Client, id string) (*Campaign, error) {
+	campaign := &Campaign{}
+	if err := request(c, campaign, http.MethodGet, pathCampaigns+"/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// ListCampaigns retrieves the registered campaigns.
+func ListCampaigns(c messagebird.Client, params *ListRequest) (*Campaigns, error) {
+	campaigns := &Campaigns{}
+	if err := request(c, campaigns, http.MethodGet, pathCampaigns+"?"+params.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return campaigns, nil
+}
+
+// LinkNumbers links purchased numbers to a campaign, so they can be used to
+// send messages for it.
+func LinkNumbers(c messagebird.Client, campaignID string, numbers []string) error {
+	if len(numbers) == 0 {
+		return errors.New("at least one number is required")
+	}
+
+	data := &struct {
+		Numbers []string `json:"numbers"`
+	}{numbers}
+
+	return request(c, nil, http.MethodPost, fmt.Sprintf("%s/%s/%s", pathCampaigns, campaignID, pathNumbers), data)
+}
+
+// UnlinkNumbers removes numbers from a campaign.
+func UnlinkNumbers(c messagebird.Client, campaignID string, numbers []string) error {
+	if len(numbers) == 0 {
+		return errors.New("at least one number is required")
+	}
+
+	query := url.Values{}
+	query.Set("numbers", strings.Join(numbers, ","))
+
+	uri := fmt.Sprintf("%s/%s/%s?%s", pathCampaigns, campaignID, pathNumbers, query.Encode())
+	return request(c, nil, http.MethodDelete, uri, nil)
+}
+
+// request does the exact same thing as DefaultClient.Request. It does, however,
+// prefix the path with the 10DLC API's root. This ensures the client
+// doesn't "handle" this for us: by default, it uses the REST API.
+func request(c messagebird.Client, v interface{}, method, path string, data interface{}) error {
+	return c.Request(v, method, fmt.Sprintf("%s/%s", apiRoot, path), data)
+}
@@ -0,0 +1,91 @@
+package tendlc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestCreateBrand(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "brand.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	brand, err := CreateBrand(client, &CreateBrandRequest{
+		EntityType:  EntityTypePrivateProfit,
+		CompanyName: "Acme Inc.",
+		Email:       "compliance@acme.example.com",
+		Phone:       "+12025550123",
+		Country:     "US",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "B1A2C3D", brand.ID)
+	assert.Equal(t, "12-3456789", brand.TaxID)
+	assert.True(t, brand.IsVerified())
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/10dlc/brands")
+	assert.JSONEq(t, `{"entityType":"PRIVATE_PROFIT","companyName":"Acme Inc.","email":"compliance@acme.example.com","phone":"+12025550123","country":"US"}`, string(mbtest.LastRequest().Body))
+}
+
+func TestCreateBrandInvalid(t *testing.T) {
+	_, err := CreateBrand(mbtest.Client(t), &CreateBrandRequest{CompanyName: "Acme Inc."})
+	assert.Error(t, err)
+}
+
+func TestReadCampaign(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "campaign.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	campaign, err := ReadCampaign(client, "C4E5F6G")
+	assert.NoError(t, err)
+	assert.Equal(t, UseCase2FA, campaign.UseCase)
+	assert.Equal(t, []string{"12025550199"}, campaign.Numbers)
+	assert.False(t, campaign.IsActive())
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/10dlc/campaigns/C4E5F6G")
+}
+
+func TestCreateCampaignWithoutSamples(t *testing.T) {
+	_, err := CreateCampaign(mbtest.Client(t), &CreateCampaignRequest{BrandID: "B1A2C3D", UseCase: UseCase2FA})
+	assert.Error(t, err)
+}
+
+func TestListCampaigns(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "campaignList.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	campaigns, err := ListCampaigns(client, &ListRequest{BrandID: "B1A2C3D", Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, campaigns.Items, 1)
+	assert.True(t, campaigns.Items[0].IsActive())
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/10dlc/campaigns")
+	assert.Equal(t, "brandId=B1A2C3D&limit=10", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestLinkNumbers(t *testing.T) {
+	mbtest.WillReturnOnlyStatus(http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := LinkNumbers(client, "C4E5F6G", []string{"12025550199", "12025550198"})
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/10dlc/campaigns/C4E5F6G/numbers")
+	assert.JSONEq(t, `{"numbers":["12025550199","12025550198"]}`, string(mbtest.LastRequest().Body))
+}
+
+func TestUnlinkNumbers(t *testing.T) {
+	mbtest.WillReturnOnlyStatus(http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	err := UnlinkNumbers(client, "C4E5F6G", []string{"12025550199", "12025550198"})
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/10dlc/campaigns/C4E5F6G/numbers")
+	assert.Equal(t, "numbers=12025550199%2C12025550198", mbtest.LastRequest().URL.RawQuery)
+}
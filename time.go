@@ -0,0 +1,106 @@
+package messagebird
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeLayouts are the layouts ParseTime accepts, in the order they are
+// tried. The API returns RFC 3339 timestamps, but some endpoints omit the
+// time zone or the time.
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseTime parses a timestamp returned by the API using TimeLayouts. Empty
+// values, "null" and zero dates, such as 0000-00-00 00:00:00, give the zero
+// time.
+func ParseTime(s string) (time.Time, error) {
+	if isZeroTime(s) {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range TimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse time %q", s)
+}
+
+func isZeroTime(s string) bool {
+	if s == "" || s == "null" {
+		return true
+	}
+
+	return strings.Trim(s, "0-: T") == "" || strings.HasPrefix(s, "0000-00-00")
+}
+
+// Time is a timestamp in an API response that may be empty, null or in one
+// of TimeLayouts. Empty timestamps are decoded as the zero time and encoded
+// as null.
+type Time struct {
+	time.Time
+
+	// Err is the reason the timestamp could not be parsed, if any.
+	Err error
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It does not fail
+// on timestamps that can not be parsed, so the rest of the response is still
+// decoded, but records the error in Err instead.
+func (t *Time) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if !bytes.Equal(b, []byte("null")) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			*t = Time{Err: fmt.Errorf("unable to parse time %s", b)}
+			return nil
+		}
+	}
+
+	parsed, err := ParseTime(s)
+	*t = Time{Time: parsed, Err: err}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return t.Time.MarshalJSON()
+}
+
+// Ptr returns a pointer to the time, or nil if it is zero, as used by the
+// optional timestamps of most structs.
+func (t Time) Ptr() *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	tt := t.Time
+	return &tt
+}
+
+// LenientTimesOf reports whether timestamps that can not be parsed in the
+// responses to requests with c are decoded as the zero time instead of
+// failing the request, i.e. whether c is a *DefaultClient with LenientTimes
+// set, or a Wrapper of one.
+func LenientTimesOf(c Client) bool {
+	for ; c != nil; c = unwrap(c) {
+		if dc, ok := c.(*DefaultClient); ok {
+			return dc.LenientTimes
+		}
+	}
+
+	return false
+}
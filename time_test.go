@@ -0,0 +1,73 @@
+package messagebird
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2022-01-02T15:04:05Z", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02T15:04:05.5+00:00", time.Date(2022, 1, 2, 15, 4, 5, 5e8, time.UTC)},
+		{"2022-01-02T15:04:05+0000", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02 15:04:05", time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2022-01-02", time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"", time.Time{}},
+		{"null", time.Time{}},
+		{"0000-00-00 00:00:00", time.Time{}},
+		{"0000-00-00T00:00:00Z", time.Time{}},
+		{"0001-01-01T00:00:00Z", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		actual, err := ParseTime(tt.input)
+		assert.NoError(t, err, tt.input)
+		assert.True(t, tt.expected.Equal(actual), "%s: %s", tt.input, actual)
+	}
+
+	_, err := ParseTime("yesterday")
+	assert.EqualError(t, err, `unable to parse time "yesterday"`)
+}
+
+func TestTimeJSON(t *testing.T) {
+	var v struct {
+		Created Time
+		Updated Time
+		Deleted Time
+	}
+
+	err := json.Unmarshal([]byte(`{"Created":"2022-01-02T15:04:05Z","Updated":"","Deleted":null}`), &v)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC), v.Created.Time)
+	assert.True(t, v.Updated.IsZero())
+	assert.Nil(t, v.Updated.Ptr())
+	assert.True(t, v.Deleted.IsZero())
+	assert.Equal(t, v.Created.Time, *v.Created.Ptr())
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Created":"2022-01-02T15:04:05Z","Updated":null,"Deleted":null}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"Created":"yesterday","Updated":42}`), &v)
+	require.NoError(t, err)
+	assert.True(t, v.Created.IsZero())
+	assert.EqualError(t, v.Created.Err, `unable to parse time "yesterday"`)
+	assert.EqualError(t, v.Updated.Err, "unable to parse time 42")
+}
+
+func TestLenientTimesOf(t *testing.T) {
+	client := New("key")
+	assert.False(t, LenientTimesOf(client))
+
+	client.LenientTimes = true
+	assert.True(t, LenientTimesOf(client))
+	assert.True(t, LenientTimesOf(&wrappingClient{Client: client}))
+	assert.False(t, LenientTimesOf(&wrappingClient{}))
+}
@@ -0,0 +1,131 @@
+// Package transaction provides access to the billing history of an account:
+// every debit and credit that changed its balance.
+package transaction
+
+import (
+	"net/http"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/querystring"
+)
+
+// path represents the path to the Transactions resource.
+const path = "transactions"
+
+// Type indicates whether a transaction added to or subtracted from the balance.
+type Type string
+
+const (
+	// TypeDebit is a transaction that was charged to the account, e.g. for
+	// sending a message.
+	TypeDebit Type = "debit"
+
+	// TypeCredit is a transaction that added to the balance, e.g. a top-up or
+	// a refund.
+	TypeCredit Type = "credit"
+)
+
+// Transaction is a single change to the balance of an account.
+type Transaction struct {
+	ID              string
+	HRef            string
+	Type            Type
+	Product         string
+	Description     string
+	Reference       string
+	Amount          float64
+	Currency        string
+	CreatedDatetime *time.Time
+}
+
+// Transactions represents a list of Transactions.
+type Transactions struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []Transaction
+}
+
+// ListRequest can be used to filter the transactions returned by List.
+type ListRequest struct {
+	messagebird.PaginationRequest
+
+	// From and Until limit the results to transactions created in this
+	// period. Both are optional.
+	From  *time.Time
+	Until *time.Time
+
+	Type Type
+
+	// Product limits the results to the transactions of a single product,
+	// e.g. sms or voice.
+	Product string
+}
+
+func (lr *ListRequest) QueryParams() string {
+	if lr == nil {
+		return ""
+	}
+
+	query := querystring.New()
+
+	if lr.Limit > 0 {
+		query.SetInt("limit", lr.Limit)
+	}
+	if lr.Offset > 0 {
+		query.SetInt("offset", lr.Offset)
+	}
+	if lr.From != nil {
+		query.SetTime("from", *lr.From, time.RFC3339)
+	}
+	if lr.Until != nil {
+		query.SetTime("until", *lr.Until, time.RFC3339)
+	}
+	if lr.Type != "" {
+		query.Set("type", string(lr.Type))
+	}
+	if lr.Product != "" {
+		query.Set("product", lr.Product)
+	}
+
+	return query.Encode()
+}
+
+// List retrieves a paginated list of transactions, most recent first.
+func List(c messagebird.Client, options *ListRequest) (*Transactions, error) {
+	transactions := &Transactions{}
+	if err := c.Request(transactions, http.MethodGet, path+"?"+options.QueryParams(), nil); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// Read retrieves a single transaction.
+func Read(c messagebird.Client, id string) (*Transaction, error) {
+	transaction := &Transaction{}
+	if err := c.Request(transaction, http.MethodGet, path+"/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// TotalsByProduct sums the amounts of transactions per product. Debits are
+// counted as negative amounts, so a product's total is its net effect on the
+// balance.
+func TotalsByProduct(transactions []Transaction) map[string]float64 {
+	totals := make(map[string]float64)
+	for _, t := range transactions {
+		amount := t.Amount
+		if t.Type == TypeDebit {
+			amount = -amount
+		}
+
+		totals[t.Product] += amount
+	}
+
+	return totals
+}
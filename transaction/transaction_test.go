@@ -0,0 +1,75 @@
+package transaction
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "transactionObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	transaction, err := Read(client, "tr-id")
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/transactions/tr-id")
+
+	assert.Equal(t, "tr-id", transaction.ID)
+	assert.Equal(t, TypeDebit, transaction.Type)
+	assert.Equal(t, "sms", transaction.Product)
+	assert.Equal(t, 0.07, transaction.Amount)
+	assert.Equal(t, "EUR", transaction.Currency)
+	assert.Equal(t, "2022-01-05T10:02:59Z", transaction.CreatedDatetime.Format(time.RFC3339))
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "transactionListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	list, err := List(client, &ListRequest{
+		PaginationRequest: messagebird.PaginationRequest{Limit: 20},
+		From:              &from,
+		Until:             &until,
+		Product:           "sms",
+	})
+	assert.NoError(t, err)
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/transactions")
+	assert.Equal(t, "from=2022-01-01T00%3A00%3A00Z&limit=20&product=sms&until=2022-02-01T00%3A00%3A00Z", mbtest.LastRequest().URL.RawQuery)
+
+	assert.Equal(t, 3, list.TotalCount)
+	assert.Len(t, list.Items, 3)
+	assert.Equal(t, TypeCredit, list.Items[2].Type)
+}
+
+func TestListWithoutOptions(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "transactionListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := List(client, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", mbtest.LastRequest().URL.RawQuery)
+}
+
+func TestTotalsByProduct(t *testing.T) {
+	totals := TotalsByProduct([]Transaction{
+		{Type: TypeDebit, Product: "sms", Amount: 0.5},
+		{Type: TypeDebit, Product: "sms", Amount: 0.25},
+		{Type: TypeCredit, Product: "sms", Amount: 0.25},
+		{Type: TypeDebit, Product: "voice", Amount: 1},
+	})
+
+	assert.Equal(t, map[string]float64{"sms": -0.5, "voice": -1}, totals)
+}
@@ -0,0 +1,49 @@
+package verify
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Service is the Verify API bound to a client, see sms.Service.
+type Service interface {
+	Create(recipient string, params *Params) (*Verify, error)
+	Send(req *SendRequest) (*Verify, error)
+	Delete(id string) error
+	Read(id string) (*Verify, error)
+	VerifyToken(id, token string) (*Verify, error)
+	ReadVerifyEmailMessage(id string) (*VerifyMessage, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Create(recipient string, params *Params) (*Verify, error) {
+	return Create(s.c, recipient, params)
+}
+
+func (s *service) Send(req *SendRequest) (*Verify, error) {
+	return Send(s.c, req)
+}
+
+func (s *service) Delete(id string) error {
+	return Delete(s.c, id)
+}
+
+func (s *service) Read(id string) (*Verify, error) {
+	return Read(s.c, id)
+}
+
+func (s *service) VerifyToken(id, token string) (*Verify, error) {
+	return VerifyToken(s.c, id, token)
+}
+
+func (s *service) ReadVerifyEmailMessage(id string) (*VerifyMessage, error) {
+	return ReadVerifyEmailMessage(s.c, id)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/numberutil"
 )
 
 const (
@@ -38,6 +39,24 @@ type Params struct {
 	Subject     string
 }
 
+// SendRequest is a verification to create with Send. Recipient is required.
+// The other fields match those of Params.
+type SendRequest struct {
+	Recipient string
+
+	Originator  string
+	Reference   string
+	Type        string
+	Template    string
+	DataCoding  string
+	ReportURL   string
+	Voice       string
+	Language    string
+	Timeout     int
+	TokenLength int
+	Subject     string
+}
+
 type verifyRequest struct {
 	Recipient   string `json:"recipient"`
 	Originator  string `json:"originator,omitempty"`
@@ -53,9 +72,21 @@ type verifyRequest struct {
 	Subject     string `json:"subject,omitempty"`
 }
 
-// Create generates a new One-Time-Password for one recipient.
+// Create generates a new One-Time-Password for one recipient. It is like
+// Send, with the fields of the SendRequest as arguments.
 func Create(c messagebird.Client, recipient string, params *Params) (*Verify, error) {
-	requestData, err := paramsToVerifyRequest(recipient, params)
+	return Send(c, newSendRequest(recipient, params))
+}
+
+// Send generates a new One-Time-Password and sends it to req.Recipient:
+//
+//	v, err := verify.Send(client, &verify.SendRequest{
+//		Recipient:   "31612345678",
+//		Originator:  "MessageBird",
+//		TokenLength: 8,
+//	})
+func Send(c messagebird.Client, req *SendRequest) (*Verify, error) {
+	requestData, err := req.request()
 	if err != nil {
 		return nil, err
 	}
@@ -105,32 +136,55 @@ func ReadVerifyEmailMessage(c messagebird.Client, id string) (*VerifyMessage, er
 	return verifyMessage, nil
 }
 
-func paramsToVerifyRequest(recipient string, params *Params) (*verifyRequest, error) {
-	if recipient == "" {
-		return nil, errors.New("recipient is required")
-	}
+func newSendRequest(recipient string, params *Params) *SendRequest {
+	req := &SendRequest{Recipient: recipient}
 
-	request := &verifyRequest{
-		Recipient: recipient,
+	if params == nil {
+		return req
 	}
 
-	if params == nil {
-		return request, nil
+	req.Originator = params.Originator
+	req.Reference = params.Reference
+	req.Type = params.Type
+	req.Template = params.Template
+	req.DataCoding = params.DataCoding
+	req.ReportURL = params.ReportURL
+	req.Voice = params.Voice
+	req.Language = params.Language
+	req.Timeout = params.Timeout
+	req.TokenLength = params.TokenLength
+	req.Subject = params.Subject
+
+	return req
+}
+
+func paramsToVerifyRequest(recipient string, params *Params) (*verifyRequest, error) {
+	return newSendRequest(recipient, params).request()
+}
+
+// request validates req and converts it to the request body.
+func (req *SendRequest) request() (*verifyRequest, error) {
+	if req == nil {
+		return nil, errors.New("send request should not be nil")
+	}
+	if req.Recipient == "" {
+		return nil, errors.New("recipient is required")
 	}
 
-	request.Originator = params.Originator
-	request.Reference = params.Reference
-	request.Type = params.Type
-	request.Template = params.Template
-	request.DataCoding = params.DataCoding
-	request.ReportURL = params.ReportURL
-	request.Voice = params.Voice
-	request.Language = params.Language
-	request.Timeout = params.Timeout
-	request.TokenLength = params.TokenLength
-	request.Subject = params.Subject
-
-	return request, nil
+	return &verifyRequest{
+		Recipient:   numberutil.Recipient(req.Recipient),
+		Originator:  req.Originator,
+		Reference:   req.Reference,
+		Type:        req.Type,
+		Template:    req.Template,
+		DataCoding:  req.DataCoding,
+		ReportURL:   req.ReportURL,
+		Voice:       req.Voice,
+		Language:    req.Language,
+		Timeout:     req.Timeout,
+		TokenLength: req.TokenLength,
+		Subject:     req.Subject,
+	}, nil
 }
 
 // Verify object represents MessageBird server response.
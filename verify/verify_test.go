@@ -38,6 +38,22 @@ func TestCreate(t *testing.T) {
 	assertVerifyObject(t, v)
 }
 
+func TestSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "verifyObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	v, err := Send(client, &SendRequest{Recipient: "+31 6 1234 5678", Reference: "MyReference", TokenLength: 8})
+	assert.NoError(t, err)
+	assertVerifyObject(t, v)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/verify")
+	mbtest.AssertBodyField(t, "recipient", "31612345678")
+	mbtest.AssertBodyField(t, "tokenLength", 8)
+
+	_, err = Send(client, &SendRequest{})
+	assert.EqualError(t, err, "recipient is required")
+}
+
 func TestDelete(t *testing.T) {
 	mbtest.WillReturn([]byte(""), http.StatusNoContent)
 	client := mbtest.Client(t)
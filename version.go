@@ -0,0 +1,32 @@
+package messagebird
+
+// APIVersioner is implemented by clients that select the versions of APIs.
+// Clients that wrap a single client need not implement it: APIVersionOf asks
+// the wrapped client of a Wrapper.
+type APIVersioner interface {
+	// APIVersion returns the version of api, e.g. "conversations", or an
+	// empty string if the client does not select one.
+	APIVersion(api string) string
+}
+
+// APIVersionOf returns the version of api, e.g. "conversations", that
+// requests with c use. It is fallback unless c is an APIVersioner that
+// selects a version, like a *DefaultClient with the api in its APIVersions,
+// or a Wrapper of one.
+func APIVersionOf(c Client, api, fallback string) string {
+	for ; c != nil; c = unwrap(c) {
+		if v, ok := c.(APIVersioner); ok {
+			if version := v.APIVersion(api); version != "" {
+				return version
+			}
+			break
+		}
+	}
+
+	return fallback
+}
+
+// APIVersion implements APIVersioner.
+func (c *DefaultClient) APIVersion(api string) string {
+	return c.APIVersions[api]
+}
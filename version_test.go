@@ -0,0 +1,17 @@
+package messagebird
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIVersionOf(t *testing.T) {
+	client := New("key")
+	assert.Equal(t, "v1", APIVersionOf(client, "conversations", "v1"))
+
+	client.APIVersions = map[string]string{"conversations": "v2", "voice": ""}
+	assert.Equal(t, "v2", APIVersionOf(client, "conversations", "v1"))
+	assert.Equal(t, "v1", APIVersionOf(client, "voice", "v1"))
+	assert.Equal(t, "v2", APIVersionOf(&wrappingClient{Client: client}, "conversations", "v1"))
+}
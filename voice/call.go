@@ -20,6 +20,8 @@ const (
 	CallStatusOngoing CallStatus = "ongoing"
 	// CallStatusEnded indicates that a call has been terminated.
 	CallStatusEnded CallStatus = "ended"
+	// CallStatusUnknown is decoded for statuses this package does not know.
+	CallStatusUnknown CallStatus = "unknown"
 )
 
 // A Call describes a voice call which is  made to a number.
@@ -36,17 +38,20 @@ type Call struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	EndedAt     *time.Time
+
+	// timeErr is the first timestamp that could not be parsed, if any.
+	timeErr error
 }
 
 type jsonCall struct {
-	ID          string `json:"id"`
-	Status      string `json:"status"`
-	Source      string `json:"source"`
-	Destination string `json:"destination"`
-	NumberID    string `json:"numberId"`
-	CreatedAt   string `json:"createdAt"`
-	UpdatedAt   string `json:"updatedAt"`
-	EndedAt     string `json:"endedAt,omitempty"`
+	ID          string     `json:"id"`
+	Status      CallStatus `json:"status"`
+	Source      string     `json:"source"`
+	Destination string     `json:"destination"`
+	NumberID    string     `json:"numberId"`
+	CreatedAt   string     `json:"createdAt"`
+	UpdatedAt   string     `json:"updatedAt"`
+	EndedAt     string     `json:"endedAt,omitempty"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -57,7 +62,7 @@ func (call Call) MarshalJSON() ([]byte, error) {
 	}
 	data := jsonCall{
 		ID:          call.ID,
-		Status:      string(call.Status),
+		Status:      call.Status,
 		Source:      call.Source,
 		Destination: call.Destination,
 		NumberID:    call.NumberID,
@@ -74,31 +79,24 @@ func (call *Call) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	createdAt, err := time.Parse(time.RFC3339, raw.CreatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Call CreatedAt: %v", err)
-	}
-	updatedAt, err := time.Parse(time.RFC3339, raw.UpdatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Call UpdatedAt: %v", err)
-	}
+	times := timeParser{resource: "Call"}
+	createdAt := times.parse("CreatedAt", raw.CreatedAt)
+	updatedAt := times.parse("UpdatedAt", raw.UpdatedAt)
 	var endedAt *time.Time
 	if raw.EndedAt != "" {
-		eat, err := time.Parse(time.RFC3339, raw.EndedAt)
-		if err != nil {
-			return fmt.Errorf("unable to parse Call EndedAt: %v", err)
-		}
+		eat := times.parse("EndedAt", raw.EndedAt)
 		endedAt = &eat
 	}
 	*call = Call{
 		ID:          raw.ID,
-		Status:      CallStatus(raw.Status),
+		Status:      raw.Status,
 		Source:      raw.Source,
 		Destination: raw.Destination,
 		NumberID:    raw.NumberID,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 		EndedAt:     endedAt,
+		timeErr:     times.err,
 	}
 	return nil
 }
@@ -128,6 +126,9 @@ func CallByID(client messagebird.Client, id string) (*Call, error) {
 	if err := client.Request(&resp, http.MethodGet, apiRoot+"/calls/"+id, nil); err != nil {
 		return nil, err
 	}
+	if err := checkTimes(client, resp.Data); err != nil {
+		return nil, err
+	}
 
 	return &resp.Data[0], nil
 }
@@ -158,6 +159,9 @@ func InitiateCall(client messagebird.Client, source, destination string, callflo
 	if err := client.Request(&resp, http.MethodPost, fmt.Sprintf("%s/%s", apiRoot, callsPath), req); err != nil {
 		return nil, err
 	}
+	if err := checkTimes(client, resp.Data); err != nil {
+		return nil, err
+	}
 	return &resp.Data[0], nil
 }
 
@@ -172,3 +176,7 @@ func (call *Call) Delete(client messagebird.Client) error {
 func (call *Call) Legs(client messagebird.Client) *Paginator {
 	return newPaginator(client, fmt.Sprintf("%s/%s/%s/%s", apiRoot, callsPath, call.ID, legsPath), reflect.TypeOf(Leg{}))
 }
+
+func (call *Call) timeError() error {
+	return call.timeErr
+}
@@ -29,6 +29,9 @@ type CallFlow struct {
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// timeErr is the first timestamp that could not be parsed, if any.
+	timeErr error
 }
 
 type jsonCallFlow struct {
@@ -91,14 +94,9 @@ func (callflow *CallFlow) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	createdAt, err := time.Parse(time.RFC3339, raw.CreatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse CallFlow CreatedAt: %v", err)
-	}
-	updatedAt, err := time.Parse(time.RFC3339, raw.UpdatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse CallFlow UpdatedAt: %v", err)
-	}
+	times := timeParser{resource: "CallFlow"}
+	createdAt := times.parse("CreatedAt", raw.CreatedAt)
+	updatedAt := times.parse("UpdatedAt", raw.UpdatedAt)
 	*callflow = CallFlow{
 		ID:        raw.ID,
 		Title:     raw.Title,
@@ -106,6 +104,7 @@ func (callflow *CallFlow) UnmarshalJSON(data []byte) error {
 		Record:    raw.Record,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+		timeErr:   times.err,
 	}
 	return nil
 }
@@ -120,6 +119,9 @@ func CallFlowByID(client messagebird.Client, id string) (*CallFlow, error) {
 	if err := client.Request(&data, http.MethodGet, apiRoot+"/call-flows/"+id, nil); err != nil {
 		return nil, err
 	}
+	if err := checkTimes(client, data.Data); err != nil {
+		return nil, err
+	}
 	return &data.Data[0], nil
 }
 
@@ -138,6 +140,9 @@ func (callflow *CallFlow) Create(client messagebird.Client) error {
 	if err := client.Request(&data, http.MethodPost, apiRoot+"/call-flows/", callflow); err != nil {
 		return err
 	}
+	if err := checkTimes(client, data.Data); err != nil {
+		return err
+	}
 	*callflow = data.Data[0]
 	return nil
 }
@@ -152,6 +157,9 @@ func (callflow *CallFlow) Update(client messagebird.Client) error {
 	if err := client.Request(&data, http.MethodPut, apiRoot+"/call-flows/"+callflow.ID, callflow); err != nil {
 		return err
 	}
+	if err := checkTimes(client, data.Data); err != nil {
+		return err
+	}
 	*callflow = data.Data[0]
 	return nil
 }
@@ -576,3 +584,7 @@ func (step *CallFlowHangupStep) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+func (callflow *CallFlow) timeError() error {
+	return callflow.timeErr
+}
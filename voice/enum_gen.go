@@ -0,0 +1,139 @@
+// Code generated by enumgen -type CallStatus,LegStatus,LegDirection,RecordingStatus; DO NOT EDIT.
+
+package voice
+
+// CallStatusValues returns the known values of CallStatus.
+func CallStatusValues() []CallStatus {
+	return []CallStatus{
+		CallStatusStarting,
+		CallStatusOngoing,
+		CallStatusEnded,
+	}
+}
+
+// IsValid reports whether c is one of CallStatusValues.
+func (c CallStatus) IsValid() bool {
+	switch c {
+	case CallStatusStarting,
+		CallStatusOngoing,
+		CallStatusEnded:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as CallStatusUnknown.
+func (c *CallStatus) UnmarshalText(text []byte) error {
+	*c = CallStatus(text)
+	if *c != "" && !c.IsValid() {
+		*c = CallStatusUnknown
+	}
+
+	return nil
+}
+
+// LegStatusValues returns the known values of LegStatus.
+func LegStatusValues() []LegStatus {
+	return []LegStatus{
+		LegStatusStarting,
+		LegStatusRinging,
+		LegStatusOngoing,
+		LegStatusBusy,
+		LegStatusNoAnswer,
+		LegStatusFailed,
+		LegStatusHangup,
+	}
+}
+
+// IsValid reports whether l is one of LegStatusValues.
+func (l LegStatus) IsValid() bool {
+	switch l {
+	case LegStatusStarting,
+		LegStatusRinging,
+		LegStatusOngoing,
+		LegStatusBusy,
+		LegStatusNoAnswer,
+		LegStatusFailed,
+		LegStatusHangup:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as LegStatusUnknown.
+func (l *LegStatus) UnmarshalText(text []byte) error {
+	*l = LegStatus(text)
+	if *l != "" && !l.IsValid() {
+		*l = LegStatusUnknown
+	}
+
+	return nil
+}
+
+// LegDirectionValues returns the known values of LegDirection.
+func LegDirectionValues() []LegDirection {
+	return []LegDirection{
+		LegDirectionOutgoing,
+		LegDirectionIncoming,
+	}
+}
+
+// IsValid reports whether l is one of LegDirectionValues.
+func (l LegDirection) IsValid() bool {
+	switch l {
+	case LegDirectionOutgoing,
+		LegDirectionIncoming:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as LegDirectionUnknown.
+func (l *LegDirection) UnmarshalText(text []byte) error {
+	*l = LegDirection(text)
+	if *l != "" && !l.IsValid() {
+		*l = LegDirectionUnknown
+	}
+
+	return nil
+}
+
+// RecordingStatusValues returns the known values of RecordingStatus.
+func RecordingStatusValues() []RecordingStatus {
+	return []RecordingStatus{
+		RecordingStatusInitialised,
+		RecordingStatusRecording,
+		RecordingStatusDone,
+		RecordingStatusFailed,
+	}
+}
+
+// IsValid reports whether r is one of RecordingStatusValues.
+func (r RecordingStatus) IsValid() bool {
+	switch r {
+	case RecordingStatusInitialised,
+		RecordingStatusRecording,
+		RecordingStatusDone,
+		RecordingStatusFailed:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Values
+// that are not empty or valid are decoded as RecordingStatusUnknown.
+func (r *RecordingStatus) UnmarshalText(text []byte) error {
+	*r = RecordingStatus(text)
+	if *r != "" && !r.IsValid() {
+		*r = RecordingStatusUnknown
+	}
+
+	return nil
+}
@@ -30,6 +30,8 @@ const (
 	LegStatusFailed LegStatus = "failed"
 	// LegStatusHangup indicates that a leg has been hung up.
 	LegStatusHangup LegStatus = "hangup"
+	// LegStatusUnknown is decoded for statuses this package does not know.
+	LegStatusUnknown LegStatus = "unknown"
 )
 
 // LegDirection indicates the direction of some leg in a call.
@@ -42,6 +44,9 @@ const (
 	// LegDirectionIncoming is the direction of a leg that is created when a
 	// number is called.
 	LegDirectionIncoming LegDirection = "incoming"
+	// LegDirectionUnknown is decoded for directions this package does not
+	// know.
+	LegDirectionUnknown LegDirection = "unknown"
 )
 
 // A Leg describes a leg object (inbound or outbound) that belongs to a call.
@@ -82,22 +87,53 @@ type Leg struct {
 	AnsweredAt *time.Time
 	// The date-time the leg ended.
 	EndedAt *time.Time
+
+	// timeErr is the first timestamp that could not be parsed, if any.
+	timeErr error
 }
 
 type jsonLeg struct {
-	ID          string  `json:"id"`
-	CallID      string  `json:"callID"`
-	Source      string  `json:"source"`
-	Destination string  `json:"destination"`
-	Status      string  `json:"status"`
-	Direction   string  `json:"direction"`
-	Cost        float64 `json:"cost"`
-	Currency    string  `json:"currency"`
-	Duration    int     `json:"duration"`
-	CreatedAt   string  `json:"createdAt"`
-	UpdatedAt   string  `json:"updatedAt"`
-	AnsweredAt  string  `json:"answeredAt,omitempty"`
-	EndedAt     string  `json:"endedAt,omitempty"`
+	ID          string       `json:"id"`
+	CallID      string       `json:"callID"`
+	Source      string       `json:"source"`
+	Destination string       `json:"destination"`
+	Status      LegStatus    `json:"status"`
+	Direction   LegDirection `json:"direction"`
+	Cost        float64      `json:"cost"`
+	Currency    string       `json:"currency"`
+	Duration    int          `json:"duration"`
+	CreatedAt   string       `json:"createdAt"`
+	UpdatedAt   string       `json:"updatedAt"`
+	AnsweredAt  string       `json:"answeredAt,omitempty"`
+	EndedAt     string       `json:"endedAt,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (leg Leg) MarshalJSON() ([]byte, error) {
+	answeredAt := ""
+	if leg.AnsweredAt != nil {
+		answeredAt = leg.AnsweredAt.Format(time.RFC3339)
+	}
+	endedAt := ""
+	if leg.EndedAt != nil {
+		endedAt = leg.EndedAt.Format(time.RFC3339)
+	}
+	data := jsonLeg{
+		ID:          leg.ID,
+		CallID:      leg.CallID,
+		Source:      leg.Source,
+		Destination: leg.Destination,
+		Status:      leg.Status,
+		Direction:   leg.Direction,
+		Cost:        leg.Cost,
+		Currency:    leg.Currency,
+		Duration:    int(leg.Duration / time.Second),
+		CreatedAt:   leg.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   leg.UpdatedAt.Format(time.RFC3339),
+		AnsweredAt:  answeredAt,
+		EndedAt:     endedAt,
+	}
+	return json.Marshal(data)
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -106,28 +142,17 @@ func (leg *Leg) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	createdAt, err := time.Parse(time.RFC3339, raw.CreatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Leg CreatedAt: %v", err)
-	}
-	updatedAt, err := time.Parse(time.RFC3339, raw.UpdatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Leg UpdatedAt: %v", err)
-	}
+	times := timeParser{resource: "Leg"}
+	createdAt := times.parse("CreatedAt", raw.CreatedAt)
+	updatedAt := times.parse("UpdatedAt", raw.UpdatedAt)
 	var answeredAt *time.Time
-	if raw.EndedAt != "" {
-		aat, err := time.Parse(time.RFC3339, raw.EndedAt)
-		if err != nil {
-			return fmt.Errorf("unable to parse Leg AnsweredAt: %v", err)
-		}
+	if raw.AnsweredAt != "" {
+		aat := times.parse("AnsweredAt", raw.AnsweredAt)
 		answeredAt = &aat
 	}
 	var endedAt *time.Time
 	if raw.EndedAt != "" {
-		eat, err := time.Parse(time.RFC3339, raw.EndedAt)
-		if err != nil {
-			return fmt.Errorf("unable to parse Leg EndedAt: %v", err)
-		}
+		eat := times.parse("EndedAt", raw.EndedAt)
 		endedAt = &eat
 	}
 	*leg = Leg{
@@ -135,8 +160,8 @@ func (leg *Leg) UnmarshalJSON(data []byte) error {
 		CallID:      raw.CallID,
 		Source:      raw.Source,
 		Destination: raw.Destination,
-		Status:      LegStatus(raw.Status),
-		Direction:   LegDirection(raw.Direction),
+		Status:      raw.Status,
+		Direction:   raw.Direction,
 		Cost:        raw.Cost,
 		Currency:    raw.Currency,
 		Duration:    time.Second * time.Duration(raw.Duration),
@@ -144,6 +169,7 @@ func (leg *Leg) UnmarshalJSON(data []byte) error {
 		UpdatedAt:   updatedAt,
 		AnsweredAt:  answeredAt,
 		EndedAt:     endedAt,
+		timeErr:     times.err,
 	}
 	return nil
 }
@@ -152,3 +178,7 @@ func (leg *Leg) UnmarshalJSON(data []byte) error {
 func (leg *Leg) Recordings(client messagebird.Client) *Paginator {
 	return newPaginator(client, fmt.Sprintf("%s/calls/%s/legs/%s/recordings", apiRoot, leg.CallID, leg.ID), reflect.TypeOf(Recording{}))
 }
+
+func (leg *Leg) timeError() error {
+	return leg.timeErr
+}
@@ -0,0 +1,64 @@
+package voice
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegUnmarshalJSON(t *testing.T) {
+	var leg Leg
+	err := json.Unmarshal([]byte(`{
+		"id": "leg-id",
+		"createdAt": "2022-01-02T15:04:05Z",
+		"updatedAt": "",
+		"answeredAt": "2022-01-02T15:04:10Z",
+		"endedAt": "2022-01-02T15:05:00Z"
+	}`), &leg)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC), leg.CreatedAt)
+	assert.True(t, leg.UpdatedAt.IsZero())
+	if assert.NotNil(t, leg.AnsweredAt) {
+		assert.Equal(t, time.Date(2022, 1, 2, 15, 4, 10, 0, time.UTC), *leg.AnsweredAt)
+	}
+	if assert.NotNil(t, leg.EndedAt) {
+		assert.Equal(t, time.Date(2022, 1, 2, 15, 5, 0, 0, time.UTC), *leg.EndedAt)
+	}
+}
+
+func TestLegUnmarshalJSONUnknownStatus(t *testing.T) {
+	var leg Leg
+	err := json.Unmarshal([]byte(`{
+		"id": "leg-id",
+		"status": "transferred",
+		"direction": "incoming",
+		"createdAt": "2022-01-02T15:04:05Z",
+		"updatedAt": "2022-01-02T15:04:05Z"
+	}`), &leg)
+	require.NoError(t, err)
+
+	assert.Equal(t, LegStatusUnknown, leg.Status)
+	assert.False(t, leg.Status.IsValid())
+	assert.Equal(t, LegDirectionIncoming, leg.Direction)
+	assert.True(t, leg.Direction.IsValid())
+	assert.Len(t, LegStatusValues(), 7)
+}
+
+func TestLegUnmarshalJSONInvalidTime(t *testing.T) {
+	var leg Leg
+	err := json.Unmarshal([]byte(`{
+		"id": "leg-id",
+		"createdAt": "yesterday",
+		"updatedAt": "2022-01-02T15:04:05Z"
+	}`), &leg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "leg-id", leg.ID)
+	assert.True(t, leg.CreatedAt.IsZero())
+	assert.Equal(t, time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC), leg.UpdatedAt)
+	assert.EqualError(t, leg.timeError(), `unable to parse Leg CreatedAt: unable to parse time "yesterday"`)
+}
@@ -67,6 +67,9 @@ func (pag *Paginator) NextPage() (interface{}, error) {
 	}
 
 	data := rawVal.Elem().FieldByName("Data").Interface()
+	if err := checkTimes(pag.client, data); err != nil {
+		return nil, err
+	}
 	pageInfo := rawVal.Elem().FieldByName("Pagination").Interface().(pagination)
 
 	// If no more items are available, a page with 0 elements is returned.
@@ -26,6 +26,9 @@ const (
 	// RecordingStatusFailed indicates that something went wrong while
 	// recording a leg.
 	RecordingStatusFailed RecordingStatus = "failed"
+	// RecordingStatusUnknown is decoded for statuses this package does not
+	// know.
+	RecordingStatusUnknown RecordingStatus = "unknown"
 )
 
 // A Recording describes a voice recording of a leg.
@@ -53,13 +56,16 @@ type Recording struct {
 	// A hash with HATEOAS links related to the object. This includes the file
 	// link that has the URI for downloading the wave file of the recording.
 	Links map[string]string
+
+	// timeErr is the first timestamp that could not be parsed, if any.
+	timeErr error
 }
 
 type jsonRecording struct {
 	ID        string            `json:"id"`
 	Format    string            `json:"format"`
 	LegID     string            `json:"legID"`
-	Status    string            `json:"status"`
+	Status    RecordingStatus   `json:"status"`
 	Duration  int               `json:"duration"`
 	CreatedAt string            `json:"createdAt"`
 	UpdatedAt string            `json:"updatedAt"`
@@ -76,6 +82,9 @@ func ReadRecording(c messagebird.Client, callID, legID, id string) (*Recording,
 		apiRoot, callID, legID, id), nil); err != nil {
 		return nil, err
 	}
+	if err := checkTimes(c, json.Data); err != nil {
+		return nil, err
+	}
 
 	return json.Data[0], nil
 }
@@ -135,23 +144,23 @@ func (rec *Recording) DownloadFile(client *messagebird.DefaultClient) (io.ReadCl
 }
 
 func parseJSON(recording *jsonRecording) (*Recording, error) {
-	createdAt, err := time.Parse(time.RFC3339, recording.CreatedAt)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse Recording CreatedAt: %v", err)
-	}
-	updatedAt, err := time.Parse(time.RFC3339, recording.UpdatedAt)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse Recording UpdatedAt: %v", err)
-	}
+	times := timeParser{resource: "Recording"}
+	createdAt := times.parse("CreatedAt", recording.CreatedAt)
+	updatedAt := times.parse("UpdatedAt", recording.UpdatedAt)
 
 	return &Recording{
 		ID:        recording.ID,
 		Format:    recording.Format,
 		LegID:     recording.LegID,
-		Status:    RecordingStatus(recording.Status),
+		Status:    recording.Status,
 		Duration:  time.Second * time.Duration(recording.Duration),
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
 		Links:     recording.Links,
+		timeErr:   times.err,
 	}, nil
 }
+
+func (rec *Recording) timeError() error {
+	return rec.timeErr
+}
@@ -70,3 +70,17 @@ func TestRecordings(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/calls/callid/legs/legid/recordings")
 }
+
+func TestReadRecordingInvalidTime(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"data":[{"id":"recid","createdAt":"yesterday"}]}`), http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := ReadRecording(client, "callid", "legid", "recid")
+	assert.EqualError(t, err, `unable to parse Recording CreatedAt: unable to parse time "yesterday"`)
+
+	client.LenientTimes = true
+	recording, err := ReadRecording(client, "callid", "legid", "recid")
+	assert.NoError(t, err)
+	assert.Equal(t, "recid", recording.ID)
+	assert.True(t, recording.CreatedAt.IsZero())
+}
@@ -0,0 +1,153 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// RetentionPolicy deletes the recordings of all calls once they are older
+// than MaxAge, e.g. from a daily job:
+//
+//	p := &voice.RetentionPolicy{MaxAge: 30 * 24 * time.Hour}
+//	report, err := p.Apply(ctx, client)
+type RetentionPolicy struct {
+	// MaxAge is how long recordings are kept, counting from their creation.
+	MaxAge time.Duration
+
+	// DryRun reports the recordings that would be deleted without deleting
+	// them.
+	DryRun bool
+
+	// Progress, if set, is called after every call of which the recordings
+	// were checked, with the report so far.
+	Progress func(r *RetentionReport)
+}
+
+// RetentionReport is the result of applying a RetentionPolicy.
+type RetentionReport struct {
+	// Calls and Recordings are the number of calls and recordings checked.
+	Calls      int
+	Recordings int
+
+	// Deleted are the recordings that were deleted, or would have been in a
+	// dry run.
+	Deleted []*ExpiredRecording
+}
+
+// ExpiredRecording is a recording older than the maximum age of a
+// RetentionPolicy.
+type ExpiredRecording struct {
+	CallID    string
+	LegID     string
+	ID        string
+	CreatedAt time.Time
+}
+
+// Apply deletes the recordings of all calls that are older than p.MaxAge.
+// Calls are only checked if they started before then, as their recordings
+// can not be older. If an error occurs, the report holds the recordings that
+// were deleted so far; applying the policy again continues where it stopped.
+func (p *RetentionPolicy) Apply(ctx context.Context, c messagebird.Client) (*RetentionReport, error) {
+	if p.MaxAge <= 0 {
+		return nil, errors.New("MaxAge is required")
+	}
+
+	cutoff := messagebird.ClockOf(c).Now().Add(-p.MaxAge)
+	report := &RetentionReport{}
+
+	calls := Calls(c)
+	for {
+		page, err := calls.NextPage()
+		if err != nil && err != io.EOF {
+			return report, fmt.Errorf("listing calls: %w", err)
+		}
+
+		for _, call := range page.([]Call) {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+
+			if call.CreatedAt.Before(cutoff) {
+				if err := p.applyToCall(ctx, c, &call, cutoff, report); err != nil {
+					return report, err
+				}
+			}
+
+			report.Calls++
+			if p.Progress != nil {
+				p.Progress(report)
+			}
+		}
+
+		if err == io.EOF {
+			return report, nil
+		}
+	}
+}
+
+// applyToCall deletes the recordings of the legs of call created before
+// cutoff. The recordings of a leg are listed before any is deleted, so
+// deleting does not shift the pages being read.
+func (p *RetentionPolicy) applyToCall(ctx context.Context, c messagebird.Client, call *Call, cutoff time.Time, report *RetentionReport) error {
+	legs, err := allPages(call.Legs(c))
+	if err != nil {
+		return fmt.Errorf("listing legs of call %s: %w", call.ID, err)
+	}
+
+	for _, leg := range legs.([]Leg) {
+		recordings, err := allPages(leg.Recordings(c))
+		if err != nil {
+			return fmt.Errorf("listing recordings of leg %s: %w", leg.ID, err)
+		}
+
+		for _, rec := range recordings.([]Recording) {
+			report.Recordings++
+			if !rec.CreatedAt.Before(cutoff) {
+				continue
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if !p.DryRun {
+				if err := Delete(c, call.ID, leg.ID, rec.ID); err != nil {
+					return fmt.Errorf("deleting recording %s: %w", rec.ID, err)
+				}
+			}
+
+			report.Deleted = append(report.Deleted, &ExpiredRecording{
+				CallID:    call.ID,
+				LegID:     leg.ID,
+				ID:        rec.ID,
+				CreatedAt: rec.CreatedAt,
+			})
+		}
+	}
+
+	return nil
+}
+
+// allPages returns the items of all pages of pag, as a slice of the type of
+// its items.
+func allPages(pag *Paginator) (interface{}, error) {
+	all := reflect.MakeSlice(reflect.SliceOf(pag.structType), 0, 0)
+	for {
+		page, err := pag.NextPage()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		all = reflect.AppendSlice(all, reflect.ValueOf(page))
+
+		if err == io.EOF {
+			return all.Interface(), nil
+		}
+	}
+}
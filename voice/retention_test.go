@@ -0,0 +1,102 @@
+package voice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// retentionClient serves two pages of calls, with one leg each, of which the
+// recordings are in recordings by call ID. Deleted recordings are recorded.
+type retentionClient struct {
+	recordings map[string][]string
+	deleted    []string
+}
+
+func (c *retentionClient) Request(v interface{}, method, path string, data interface{}) error {
+	path = strings.TrimPrefix(path, apiRoot+"/")
+
+	page := func(pageCount int, items ...string) error {
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"data": [%s], "pagination": {"pageCount": %d}}`, strings.Join(items, ","), pageCount)), v)
+	}
+
+	switch {
+	case method == http.MethodGet && path == "calls/?page=1":
+		return page(2, call("old", 40), call("new", 1))
+	case method == http.MethodGet && path == "calls/?page=2":
+		return page(2, call("older", 90))
+	case method == http.MethodGet && path == "calls/?page=3":
+		return page(2)
+	case method == http.MethodGet && strings.HasSuffix(path, "/legs?page=1"):
+		callID := strings.Split(path, "/")[1]
+		return page(1, fmt.Sprintf(`{"id": "leg-%s", "callId": %q, "createdAt": "2026-01-01T00:00:00Z", "updatedAt": "2026-01-01T00:00:00Z"}`, callID, callID))
+	case method == http.MethodGet && strings.HasSuffix(path, "/recordings?page=1"):
+		return page(1, c.recordings[strings.Split(path, "/")[1]]...)
+	case method == http.MethodGet && strings.HasSuffix(path, "?page=2"):
+		return page(1)
+	case method == http.MethodDelete && strings.Contains(path, "/recordings/"):
+		parts := strings.Split(path, "/")
+		c.deleted = append(c.deleted, parts[1]+"/"+parts[5])
+		return nil
+	}
+
+	return fmt.Errorf("unexpected %s %s", method, path)
+}
+
+func daysAgo(days int) string {
+	return time.Now().Add(-time.Duration(days) * 24 * time.Hour).UTC().Format(time.RFC3339)
+}
+
+func call(id string, days int) string {
+	return fmt.Sprintf(`{"id": %q, "createdAt": %q, "updatedAt": %q}`, id, daysAgo(days), daysAgo(days))
+}
+
+func recording(id string, days int) string {
+	return fmt.Sprintf(`{"id": %q, "createdAt": %q, "updatedAt": %q}`, id, daysAgo(days), daysAgo(days))
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	newClient := func() *retentionClient {
+		return &retentionClient{recordings: map[string][]string{
+			"old":   {recording("rec-1", 40), recording("rec-2", 20)},
+			"older": {recording("rec-3", 89)},
+			"new":   {recording("rec-4", 1)},
+		}}
+	}
+
+	client := newClient()
+	var progress []int
+	p := &RetentionPolicy{
+		MaxAge: 30 * 24 * time.Hour,
+		Progress: func(r *RetentionReport) {
+			progress = append(progress, r.Calls)
+		},
+	}
+
+	report, err := p.Apply(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"old/rec-1", "older/rec-3"}, client.deleted)
+	assert.Equal(t, 3, report.Calls)
+	assert.Equal(t, 3, report.Recordings)
+	if assert.Len(t, report.Deleted, 2) {
+		assert.Equal(t, &ExpiredRecording{CallID: "old", LegID: "leg-old", ID: "rec-1", CreatedAt: report.Deleted[0].CreatedAt}, report.Deleted[0])
+	}
+	assert.Equal(t, []int{1, 2, 3}, progress)
+
+	client = newClient()
+	p.DryRun = true
+
+	report, err = p.Apply(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Empty(t, client.deleted)
+	assert.Len(t, report.Deleted, 2)
+
+	_, err = (&RetentionPolicy{}).Apply(context.Background(), client)
+	assert.EqualError(t, err, "MaxAge is required")
+}
@@ -31,6 +31,9 @@ type Transcription struct {
 	// link that has the URI for downloading the text transcription of a
 	// recording.
 	links map[string]string
+
+	// timeErr is the first timestamp that could not be parsed, if any.
+	timeErr error
 }
 
 type jsonTranscription struct {
@@ -48,14 +51,9 @@ func (trans *Transcription) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	createdAt, err := time.Parse(time.RFC3339, raw.CreatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Transcription CreatedAt: %v", err)
-	}
-	updatedAt, err := time.Parse(time.RFC3339, raw.UpdatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Transcription UpdatedAt: %v", err)
-	}
+	times := timeParser{resource: "Transcription"}
+	createdAt := times.parse("CreatedAt", raw.CreatedAt)
+	updatedAt := times.parse("UpdatedAt", raw.UpdatedAt)
 	*trans = Transcription{
 		ID:          raw.ID,
 		RecordingID: raw.RecordingID,
@@ -63,6 +61,7 @@ func (trans *Transcription) UnmarshalJSON(data []byte) error {
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 		links:       raw.Links,
+		timeErr:     times.err,
 	}
 	return nil
 }
@@ -102,9 +101,16 @@ func CreateTranscription(client messagebird.Client, callID string, legID string,
 	if err := client.Request(&resp, http.MethodPost, apiRoot+path, body); err != nil {
 		return nil, err
 	}
+	if err := checkTimes(client, resp.Data); err != nil {
+		return nil, err
+	}
 	if len(resp.Data) == 0 {
 		return nil, fmt.Errorf("empty response")
 	}
 
 	return &resp.Data[0], nil
 }
+
+func (trans *Transcription) timeError() error {
+	return trans.timeErr
+}
@@ -3,11 +3,15 @@ package voice
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	messagebird "github.com/messagebird/go-rest-api/v9"
 )
 
+//go:generate go run ../internal/enumgen -type CallStatus,LegStatus,LegDirection,RecordingStatus
+
 const (
 	apiRoot = "https://voice.messagebird.com/v1"
 
@@ -37,14 +41,48 @@ func init() {
 
 // errorReader takes a []byte representation of a Voice API JSON error and
 // parses it to a voice.ErrorResponse.
+// Errors of other APIs, which have a description instead of a message, are
+// returned as messagebird.ErrorResponse: importing package voice must not
+// change the errors returned by other packages.
 func errorReader(b []byte) error {
 	var er ErrorResponse
 	if err := json.Unmarshal(b, &er); err != nil {
 		return fmt.Errorf("encoding/json: Unmarshal: %v", err)
 	}
+
+	if isRESTError(b) {
+		var rest messagebird.ErrorResponse
+		if err := json.Unmarshal(b, &rest); err != nil {
+			return fmt.Errorf("encoding/json: Unmarshal: %v", err)
+		}
+		return rest
+	}
+
 	return er
 }
 
+// isRESTError reports whether b is an error in the format of the REST API,
+// i.e. its errors have a description and no message.
+func isRESTError(b []byte) bool {
+	var raw struct {
+		Errors []struct {
+			Message     *string
+			Description *string
+		}
+	}
+	if err := json.Unmarshal(b, &raw); err != nil || len(raw.Errors) == 0 {
+		return false
+	}
+
+	for _, e := range raw.Errors {
+		if e.Message != nil || e.Description == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (e ErrorResponse) Error() string {
 	errStrings := make([]string, len(e.Errors))
 	for i, v := range e.Errors {
@@ -56,3 +94,59 @@ func (e ErrorResponse) Error() string {
 func (e Error) Error() string {
 	return fmt.Sprintf("code: %d, message: %q", e.Code, e.Message)
 }
+
+// timeParser parses the timestamps of a resource while it is decoded. It
+// keeps the first error instead of failing, so the rest of the resource is
+// still decoded: the request fails with it unless the client is lenient, see
+// checkTimes.
+type timeParser struct {
+	resource string
+	err      error
+}
+
+// parse parses the timestamp s of field, or returns the zero time if it can
+// not be parsed.
+func (p *timeParser) parse(field, s string) time.Time {
+	t, err := messagebird.ParseTime(s)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("unable to parse %s %s: %v", p.resource, field, err)
+	}
+
+	return t
+}
+
+// timeErrorer is implemented by the resources that keep the first timestamp
+// they could not parse.
+type timeErrorer interface {
+	timeError() error
+}
+
+// checkTimes returns the first timestamp error of v, a resource or a slice
+// of resources, unless c parses timestamps leniently.
+func checkTimes(c messagebird.Client, v interface{}) error {
+	if messagebird.LenientTimesOf(c) {
+		return nil
+	}
+
+	if r, ok := v.(timeErrorer); ok {
+		return r.timeError()
+	}
+
+	items := reflect.ValueOf(v)
+	if items.Kind() != reflect.Slice {
+		return nil
+	}
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i)
+		if item.Kind() != reflect.Ptr {
+			item = item.Addr()
+		}
+		if r, ok := item.Interface().(timeErrorer); ok && !item.IsNil() {
+			if err := r.timeError(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
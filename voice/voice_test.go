@@ -3,6 +3,7 @@ package voice
 import (
 	"testing"
 
+	messagebird "github.com/messagebird/go-rest-api/v9"
 	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,6 +29,16 @@ func TestErrorReader(t *testing.T) {
 		assert.Equal(t, "other-error", err.Errors[1].Message)
 	})
 
+	t.Run("REST API error", func(t *testing.T) {
+		b := []byte(`{"errors":[{"code":2,"description":"Request not allowed","parameter":"access_key"}]}`)
+		err, ok := errorReader(b).(messagebird.ErrorResponse)
+
+		if assert.True(t, ok) {
+			assert.Equal(t, 2, err.Errors[0].Code)
+			assert.Equal(t, "access_key", err.Errors[0].Parameter)
+		}
+	})
+
 	t.Run("Invalid JSON", func(t *testing.T) {
 		b := []byte("clearly not json")
 		_, ok := errorReader(b).(ErrorResponse)
@@ -18,6 +18,9 @@ type Webhook struct {
 	Token     string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// timeErr is the first timestamp that could not be parsed, if any.
+	timeErr error
 }
 
 type jsonWebhook struct {
@@ -46,24 +49,39 @@ func (wh *Webhook) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	createdAt, err := time.Parse(time.RFC3339, raw.CreatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Webhook CreatedAt: %v", err)
-	}
-	updatedAt, err := time.Parse(time.RFC3339, raw.UpdatedAt)
-	if err != nil {
-		return fmt.Errorf("unable to parse Webhook UpdatedAt: %v", err)
-	}
+	times := timeParser{resource: "Webhook"}
+	createdAt := times.parse("CreatedAt", raw.CreatedAt)
+	updatedAt := times.parse("UpdatedAt", raw.UpdatedAt)
 	*wh = Webhook{
 		ID:        raw.ID,
 		URL:       raw.URL,
 		Token:     raw.Token,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+		timeErr:   times.err,
 	}
 	return nil
 }
 
+// WebhookByID fetches a webhook by its ID.
+//
+// An error is returned if no such webhook exists or is accessible.
+func WebhookByID(client messagebird.Client, id string) (*Webhook, error) {
+	var data struct {
+		Data []Webhook `json:"data"`
+	}
+	if err := client.Request(&data, http.MethodGet, apiRoot+"/webhooks/"+id, nil); err != nil {
+		return nil, err
+	}
+	if err := checkTimes(client, data.Data); err != nil {
+		return nil, err
+	}
+	if len(data.Data) == 0 {
+		return nil, fmt.Errorf("no webhook with ID %q in response", id)
+	}
+	return &data.Data[0], nil
+}
+
 // Webhooks returns a paginator over all webhooks.
 func Webhooks(client messagebird.Client) *Paginator {
 	return newPaginator(client, apiRoot+"/webhooks/", reflect.TypeOf(Webhook{}))
@@ -85,6 +103,9 @@ func CreateWebHook(client messagebird.Client, url, token string) (*Webhook, erro
 	if err := client.Request(&resp, http.MethodPost, apiRoot+"/webhooks/", data); err != nil {
 		return nil, err
 	}
+	if err := checkTimes(client, resp.Data); err != nil {
+		return nil, err
+	}
 	return &resp.Data[0], nil
 }
 
@@ -96,6 +117,9 @@ func (wh *Webhook) Update(client messagebird.Client) error {
 	if err := client.Request(&data, http.MethodPut, apiRoot+"/webhooks/"+wh.ID, wh); err != nil {
 		return err
 	}
+	if err := checkTimes(client, data.Data); err != nil {
+		return err
+	}
 	*wh = data.Data[0]
 	return nil
 }
@@ -104,3 +128,7 @@ func (wh *Webhook) Update(client messagebird.Client) error {
 func (wh *Webhook) Delete(client messagebird.Client) error {
 	return client.Request(nil, http.MethodDelete, apiRoot+"/webhooks/"+wh.ID, nil)
 }
+
+func (wh *Webhook) timeError() error {
+	return wh.timeErr
+}
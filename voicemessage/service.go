@@ -0,0 +1,39 @@
+package voicemessage
+
+import (
+	messagebird "github.com/messagebird/go-rest-api/v9"
+)
+
+// Service is the voice messaging API bound to a client, see sms.Service.
+type Service interface {
+	Read(id string) (*VoiceMessage, error)
+	List() (*VoiceMessageList, error)
+	Create(recipients []string, body string, params *Params) (*VoiceMessage, error)
+	Send(req *SendRequest) (*VoiceMessage, error)
+}
+
+// NewService returns the Service that calls the functions of this package
+// with c.
+func NewService(c messagebird.Client) Service {
+	return &service{c: c}
+}
+
+type service struct {
+	c messagebird.Client
+}
+
+func (s *service) Read(id string) (*VoiceMessage, error) {
+	return Read(s.c, id)
+}
+
+func (s *service) List() (*VoiceMessageList, error) {
+	return List(s.c)
+}
+
+func (s *service) Create(recipients []string, body string, params *Params) (*VoiceMessage, error) {
+	return Create(s.c, recipients, body, params)
+}
+
+func (s *service) Send(req *SendRequest) (*VoiceMessage, error) {
+	return Send(s.c, req)
+}
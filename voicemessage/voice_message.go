@@ -44,6 +44,27 @@ type Params struct {
 	Repeat            int
 	IfMachine         string
 	ScheduledDatetime time.Time
+
+	// Pool is the name of a number pool (see package number) to call from.
+	// The API picks an originator from the pool. Originator takes precedence
+	// if both are set.
+	Pool string
+}
+
+// SendRequest is a voice message to send with Send. At least one recipient
+// and Body are required. The other fields match those of Params.
+type SendRequest struct {
+	Recipients []string
+	Body       string
+
+	Originator        string
+	Reference         string
+	Language          string
+	Voice             string
+	Repeat            int
+	IfMachine         string
+	ScheduledDatetime time.Time
+	Pool              string
 }
 
 type voiceMessageRequest struct {
@@ -56,6 +77,7 @@ type voiceMessageRequest struct {
 	Repeat            int      `json:"repeat,omitempty"`
 	IfMachine         string   `json:"ifMachine,omitempty"`
 	ScheduledDatetime string   `json:"scheduledDatetime,omitempty"`
+	Pool              string   `json:"pool,omitempty"`
 }
 
 // path represents the path to the VoiceMessage resource.
@@ -81,9 +103,21 @@ func List(c messagebird.Client) (*VoiceMessageList, error) {
 	return messageList, nil
 }
 
-// Create a new voice message for one or more recipients.
+// Create a new voice message for one or more recipients. It is like Send,
+// with the fields of the SendRequest as arguments.
 func Create(c messagebird.Client, recipients []string, body string, params *Params) (*VoiceMessage, error) {
-	requestData, err := paramsToRequest(recipients, body, params)
+	return Send(c, newSendRequest(recipients, body, params))
+}
+
+// Send creates a new voice message for one or more recipients:
+//
+//	message, err := voicemessage.Send(client, &voicemessage.SendRequest{
+//		Recipients: []string{"31612345678"},
+//		Body:       "Your code is 1 2 3 4",
+//		Language:   "en-gb",
+//	})
+func Send(c messagebird.Client, req *SendRequest) (*VoiceMessage, error) {
+	requestData, err := req.request()
 	if err != nil {
 		return nil, err
 	}
@@ -96,31 +130,57 @@ func Create(c messagebird.Client, recipients []string, body string, params *Para
 	return message, nil
 }
 
+func newSendRequest(recipients []string, body string, params *Params) *SendRequest {
+	req := &SendRequest{
+		Recipients: recipients,
+		Body:       body,
+	}
+
+	if params == nil {
+		return req
+	}
+
+	req.Originator = params.Originator
+	req.Reference = params.Reference
+	req.Language = params.Language
+	req.Voice = params.Voice
+	req.Repeat = params.Repeat
+	req.IfMachine = params.IfMachine
+	req.ScheduledDatetime = params.ScheduledDatetime
+	req.Pool = params.Pool
+
+	return req
+}
+
 func paramsToRequest(recipients []string, body string, params *Params) (*voiceMessageRequest, error) {
-	if len(recipients) == 0 {
+	return newSendRequest(recipients, body, params).request()
+}
+
+// request validates req and converts it to the request body.
+func (req *SendRequest) request() (*voiceMessageRequest, error) {
+	if req == nil {
+		return nil, errors.New("send request should not be nil")
+	}
+	if len(req.Recipients) == 0 {
 		return nil, errors.New("at least 1 recipient is required")
 	}
-	if body == "" {
+	if req.Body == "" {
 		return nil, errors.New("body is required")
 	}
 
 	request := &voiceMessageRequest{
-		Recipients: recipients,
-		Body:       body,
+		Recipients: req.Recipients,
+		Body:       req.Body,
+		Originator: req.Originator,
+		Reference:  req.Reference,
+		Language:   req.Language,
+		Voice:      req.Voice,
+		Repeat:     req.Repeat,
+		IfMachine:  req.IfMachine,
+		Pool:       req.Pool,
 	}
-
-	if params == nil {
-		return request, nil
-	}
-
-	request.Originator = params.Originator
-	request.Reference = params.Reference
-	request.Language = params.Language
-	request.Voice = params.Voice
-	request.Repeat = params.Repeat
-	request.IfMachine = params.IfMachine
-	if !params.ScheduledDatetime.IsZero() {
-		request.ScheduledDatetime = params.ScheduledDatetime.Format(time.RFC3339)
+	if !req.ScheduledDatetime.IsZero() {
+		request.ScheduledDatetime = req.ScheduledDatetime.Format(time.RFC3339)
 	}
 
 	return request, nil
@@ -49,6 +49,25 @@ func TestCreate(t *testing.T) {
 	assertVoiceMessageObject(t, message)
 }
 
+func TestSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "voiceMessageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := Send(client, &SendRequest{
+		Recipients: []string{"31612345678"},
+		Body:       "Hello World",
+		Language:   "en-gb",
+	})
+	assert.NoError(t, err)
+	assertVoiceMessageObject(t, message)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/voicemessages")
+	mbtest.AssertBodyField(t, "language", "en-gb")
+
+	_, err = Send(client, &SendRequest{Body: "Hello World"})
+	assert.EqualError(t, err, "at least 1 recipient is required")
+}
+
 func TestCreateWithParams(t *testing.T) {
 	mbtest.WillReturnTestdata(t, "voiceMessageObjectWithParams.json", http.StatusOK)
 	client := mbtest.Client(t)
@@ -134,3 +153,12 @@ func TestRequestDataForVoiceMessageZeroScheduledDatetime(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "", request.ScheduledDatetime, "Uninitialized ScheduledDatetime should default to empty string")
 }
+
+func TestCreateFromPool(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "voiceMessageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := Create(client, []string{"31612345678"}, "Hello World", &Params{Pool: "my-pool"})
+	assert.NoError(t, err)
+	mbtest.AssertBodyJSONEq(t, `{"recipients":["31612345678"],"body":"Hello World","pool":"my-pool"}`)
+}
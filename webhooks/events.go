@@ -0,0 +1,247 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/conversation"
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/voice"
+)
+
+// EventType identifies the product and kind of a webhook request.
+type EventType string
+
+const (
+	EventUnknown      EventType = ""
+	EventConversation EventType = "conversation"
+	EventSMSStatus    EventType = "sms.status"
+	EventSMSInbound   EventType = "sms.inbound"
+	EventSMSClick     EventType = "sms.click"
+	EventVoice        EventType = "voice"
+	EventVerify       EventType = "verify"
+	EventHLR          EventType = "hlr"
+)
+
+// ConversationEvent is sent by the Conversations API for the events a
+// conversation.Webhook subscribes to.
+type ConversationEvent struct {
+	Type         conversation.WebhookEvent  `json:"type"`
+	Conversation *conversation.Conversation `json:"conversation"`
+	Message      *conversation.Message      `json:"message"`
+	Contact      *conversation.Contact      `json:"contact"`
+}
+
+// SMSStatusReport is a delivery report for an SMS message sent to a single
+// recipient.
+type SMSStatusReport struct {
+	ID               string
+	Reference        string
+	Recipient        string
+	Status           string
+	StatusReason     string
+	StatusErrorCode  int
+	StatusDatetime   *time.Time
+	MCCMNC           string
+	MessagePartCount int
+}
+
+// SMSClick is sent when a recipient opens a link in an SMS message of which
+// MessageBird shortened the URLs, see sms.Params.ShortenURLs.
+type SMSClick struct {
+	ID        string
+	Reference string
+	Recipient string
+
+	// URL is the URL the link redirected to.
+	URL string
+
+	// ShortURL is the link on MessageBird's tracking domain that was opened.
+	ShortURL string
+
+	ClickedDatetime *time.Time
+}
+
+// VoiceEvent is a change to a call or one of its legs. Depending on Type,
+// either Call or Leg is set.
+type VoiceEvent struct {
+	Timestamp *time.Time
+	Type      string
+	Event     string
+	Call      *voice.Call
+	Leg       *voice.Leg
+}
+
+type voiceJSON struct {
+	Timestamp *time.Time `json:"timestamp"`
+	Items     []struct {
+		Type    string          `json:"type"`
+		Event   string          `json:"event"`
+		Payload json.RawMessage `json:"payload"`
+	} `json:"items"`
+}
+
+func decodeVoiceEvents(body []byte) ([]*VoiceEvent, error) {
+	raw := &voiceJSON{}
+	if err := json.Unmarshal(body, raw); err != nil {
+		return nil, err
+	}
+
+	events := make([]*VoiceEvent, 0, len(raw.Items))
+	for _, item := range raw.Items {
+		event := &VoiceEvent{Timestamp: raw.Timestamp, Type: item.Type, Event: item.Event}
+
+		switch item.Type {
+		case "call":
+			event.Call = &voice.Call{}
+			if err := json.Unmarshal(item.Payload, event.Call); err != nil {
+				return nil, err
+			}
+		case "leg":
+			event.Leg = &voice.Leg{}
+			if err := json.Unmarshal(item.Payload, event.Leg); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown voice item type %q", item.Type)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func decodeSMSStatusReport(f fields) (*SMSStatusReport, error) {
+	report := &SMSStatusReport{
+		ID:           f["id"],
+		Reference:    f["reference"],
+		Recipient:    f["recipient"],
+		Status:       f["status"],
+		StatusReason: f["statusReason"],
+		MCCMNC:       f["mccmnc"],
+	}
+
+	var err error
+	if report.StatusErrorCode, err = f.int("statusErrorCode"); err != nil {
+		return nil, err
+	}
+	if report.MessagePartCount, err = f.int("messagePartCount"); err != nil {
+		return nil, err
+	}
+	if report.StatusDatetime, err = f.time("statusDatetime"); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func decodeSMSClick(f fields) (*SMSClick, error) {
+	click := &SMSClick{
+		ID:        f["id"],
+		Reference: f["reference"],
+		Recipient: f["recipient"],
+		URL:       f["url"],
+		ShortURL:  f["shortUrl"],
+	}
+
+	var err error
+	if click.ClickedDatetime, err = f.time("clickedDatetime"); err != nil {
+		return nil, err
+	}
+
+	return click, nil
+}
+
+func decodeHLR(f fields) (*hlr.HLR, error) {
+	h := &hlr.HLR{
+		ID:        f["id"],
+		HRef:      f["href"],
+		Reference: f["reference"],
+		Status:    f["status"],
+	}
+
+	var err error
+	if h.MSISDN, err = f.int("msisdn"); err != nil {
+		return nil, err
+	}
+	if h.Network, err = f.int("network"); err != nil {
+		return nil, err
+	}
+	if h.CreatedDatetime, err = f.time("createdDatetime"); err != nil {
+		return nil, err
+	}
+	if h.StatusDatetime, err = f.time("statusDatetime"); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// fields holds the top-level scalar values of a webhook request, from either
+// its query string, form or JSON body.
+type fields map[string]string
+
+func (f fields) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := f[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f fields) int(name string) (int, error) {
+	if f[name] == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(f[name])
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	return n, nil
+}
+
+func (f fields) time(name string) (*time.Time, error) {
+	if f[name] == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, f[name])
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", name, err)
+	}
+
+	return &t, nil
+}
+
+// jsonFields returns the top-level values of a JSON object. Strings, numbers
+// and booleans are kept as text, while objects and arrays are kept as raw JSON
+// so their presence can be detected.
+func jsonFields(body []byte) (fields, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	f := make(fields, len(raw))
+	for name, value := range raw {
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			f[name] = s
+			continue
+		}
+		if string(value) == "null" {
+			f[name] = ""
+			continue
+		}
+		f[name] = string(value)
+	}
+
+	return f, nil
+}
@@ -0,0 +1,204 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api/v9"
+	"github.com/messagebird/go-rest-api/v9/internal/queue"
+)
+
+// Defaults for the options of a RetryQueue.
+const (
+	DefaultRetryPollInterval = time.Second
+	DefaultRetryMaxAttempts  = 10
+	DefaultRetryDelay        = time.Second
+	DefaultRetryMaxDelay     = 10 * time.Minute
+)
+
+// QueuedEvent is a webhook of which the handler returned an error, queued
+// for redelivery. Its Kind is the EventType, and its Payload the webhook
+// request, from which the event is decoded again when it is redelivered.
+// Attempts includes the webhook itself.
+type QueuedEvent = queue.Entry
+
+// queuedRequest is the Payload of a QueuedEvent.
+type queuedRequest struct {
+	Method      string
+	Query       string
+	ContentType string
+	Body        []byte
+}
+
+// RetryStore persists the events of a RetryQueue. It is the same as
+// outbox.Store, so the stores of package outbox, like outbox.FileStore, can
+// be used for it.
+type RetryStore = queue.Store
+
+// MemoryRetryStore is a RetryStore that keeps events in memory. Events do
+// not survive restarts, so it only protects against failures that pass
+// while the process runs.
+type MemoryRetryStore = queue.MemoryStore
+
+// NewMemoryRetryStore returns an empty MemoryRetryStore.
+func NewMemoryRetryStore() *MemoryRetryStore {
+	return queue.NewMemoryStore()
+}
+
+// EncryptedRetryStore is a RetryStore that encrypts the Payload of events
+// with Cipher before they are put in Store, as they hold the content of
+// messages, and decrypts them when they are due. The encrypted payload is
+// bound to the ID of its event.
+//
+// Events that can not be decrypted, e.g. because their key was removed or
+// they were changed or moved to another ID, are returned nonetheless, so they do not block the
+// queue: they fail permanently, see RetryQueue.OnFailed.
+type EncryptedRetryStore = queue.EncryptedStore
+
+// RetryQueue configures the redelivery of webhooks of which the handler
+// returned an error, see Dispatcher.Retry. Its fields must not be changed
+// once RunRetries is called.
+type RetryQueue struct {
+	Store RetryStore
+
+	// Clock is used to schedule redeliveries. SystemClock is used if it is
+	// nil.
+	Clock messagebird.Clock
+
+	// PollInterval is the time between checks for due events when the
+	// queue is empty. DefaultRetryPollInterval is used if it is zero.
+	PollInterval time.Duration
+
+	// MaxAttempts is the number of times an event is redelivered before it
+	// fails permanently. DefaultRetryMaxAttempts is used if it is zero.
+	MaxAttempts int
+
+	// RetryDelay is the delay before the first redelivery. It doubles for
+	// every following one, up to MaxDelay. DefaultRetryDelay and
+	// DefaultRetryMaxDelay are used if they are zero.
+	RetryDelay time.Duration
+	MaxDelay   time.Duration
+
+	// OnFailed is called when an event fails permanently, after which it is
+	// removed from the Store.
+	OnFailed func(e *QueuedEvent, err error)
+}
+
+// enqueue stores the webhook request r with body, of which the handler
+// returned err.
+func (d *Dispatcher) enqueue(ctx context.Context, eventType EventType, r *http.Request, body []byte, err error) error {
+	payload, jsonErr := json.Marshal(&queuedRequest{
+		Method:      r.Method,
+		Query:       r.URL.RawQuery,
+		ContentType: r.Header.Get("Content-Type"),
+		Body:        body,
+	})
+	if jsonErr != nil {
+		return jsonErr
+	}
+
+	q := d.retryQueue()
+	_, queueErr := q.Enqueue(ctx, &QueuedEvent{
+		Kind:        string(eventType),
+		Payload:     payload,
+		Attempts:    1,
+		LastError:   err.Error(),
+		NextAttempt: q.Clock.Now().Add(queue.Backoff(q.RetryDelay, q.MaxDelay, 1)),
+	})
+	return queueErr
+}
+
+// RunRetries redelivers the events queued in d.Retry until ctx is done, and
+// returns ctx.Err(). Errors of the Store are retried after PollInterval.
+func (d *Dispatcher) RunRetries(ctx context.Context) error {
+	if d.Retry == nil {
+		return errors.New("retry is required")
+	}
+
+	return d.retryQueue().Run(ctx)
+}
+
+// DrainRetries redelivers a batch of due events once and returns how many it
+// handled. Use it instead of RunRetries to redeliver from a scheduled job.
+func (d *Dispatcher) DrainRetries(ctx context.Context) (int, error) {
+	if d.Retry == nil {
+		return 0, errors.New("retry is required")
+	}
+
+	return d.retryQueue().Drain(ctx)
+}
+
+// retryQueue returns the queue that redelivers the events in d.Retry.Store.
+func (d *Dispatcher) retryQueue() *queue.Queue {
+	r := d.Retry
+	q := &queue.Queue{
+		Store:        r.Store,
+		Clock:        r.Clock,
+		PollInterval: r.PollInterval,
+		MaxAttempts:  r.MaxAttempts,
+		RetryDelay:   r.RetryDelay,
+		MaxDelay:     r.MaxDelay,
+		Handle: func(ctx context.Context, e *QueuedEvent) (interface{}, error) {
+			return nil, d.replay(ctx, e)
+		},
+		IsPermanent: func(err error) bool {
+			var decodeErr *decodeError
+			return errors.As(err, &decodeErr)
+		},
+		OnRetry: func(_ *QueuedEvent, err error) {
+			if d.ErrorLog != nil {
+				d.ErrorLog(err)
+			}
+		},
+		OnFailed: r.OnFailed,
+	}
+	if q.Clock == nil {
+		q.Clock = messagebird.SystemClock
+	}
+	if q.PollInterval == 0 {
+		q.PollInterval = DefaultRetryPollInterval
+	}
+	if q.MaxAttempts == 0 {
+		q.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	// The webhook itself is the first attempt.
+	q.MaxAttempts++
+	if q.RetryDelay == 0 {
+		q.RetryDelay = DefaultRetryDelay
+	}
+	if q.MaxDelay == 0 {
+		q.MaxDelay = DefaultRetryMaxDelay
+	}
+
+	return q
+}
+
+// replay rebuilds the webhook request of e and passes it to its handler.
+func (d *Dispatcher) replay(ctx context.Context, e *QueuedEvent) error {
+	eventType := EventType(e.Kind)
+
+	var req queuedRequest
+	if err := json.Unmarshal(e.Payload, &req); err != nil {
+		return &decodeError{eventType: eventType, err: err}
+	}
+
+	r, err := http.NewRequest(req.Method, "/?"+req.Query, bytes.NewReader(req.Body))
+	if err != nil {
+		return &decodeError{eventType: eventType, err: err}
+	}
+	r = r.WithContext(ctx)
+	if req.ContentType != "" {
+		r.Header.Set("Content-Type", req.ContentType)
+	}
+
+	f, _, err := requestFields(r, req.Body)
+	if err != nil {
+		return &decodeError{eventType: eventType, err: err}
+	}
+
+	return d.handle(r, eventType, f, req.Body)
+}
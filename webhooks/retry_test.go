@@ -0,0 +1,234 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+// testClock is a messagebird.Clock that only moves when advanced. The
+// messagebirdtest package can not be used, as it imports this package.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestRetryConversation(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	store := NewMemoryRetryStore()
+
+	failing := true
+	var got []*ConversationEvent
+	d := &Dispatcher{
+		OnConversation: func(ctx context.Context, e *ConversationEvent) error {
+			if failing {
+				return errors.New("database down")
+			}
+			got = append(got, e)
+			return nil
+		},
+		Retry: &RetryQueue{Store: store, Clock: clock},
+	}
+
+	assert.Equal(t, http.StatusOK, serve(d, jsonRequest(t, "conversation.json")))
+	assert.Equal(t, 1, store.Len())
+
+	// Events are not redelivered before they are due.
+	n, err := d.DrainRetries(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+
+	clock.Advance(DefaultRetryDelay)
+	n, err = d.DrainRetries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	due, _ := store.Due(context.Background(), clock.Now().Add(time.Hour), 10)
+	if assert.Len(t, due, 1) {
+		// The webhook itself was the first attempt.
+		assert.Equal(t, 2, due[0].Attempts)
+		assert.Equal(t, "database down", due[0].LastError)
+		assert.Equal(t, clock.Now().Add(2*DefaultRetryDelay), due[0].NextAttempt)
+	}
+
+	failing = false
+	clock.Advance(2 * DefaultRetryDelay)
+	n, err = d.DrainRetries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Zero(t, store.Len())
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "2e15efafec384e1c82e9842075e87beb", got[0].Conversation.ID)
+	}
+}
+
+func TestRetrySMSStatus(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	var got *SMSStatusReport
+	d := &Dispatcher{
+		OnSMSStatus: func(ctx context.Context, r *SMSStatusReport) error {
+			if got == nil {
+				got = &SMSStatusReport{}
+				return errors.New("database down")
+			}
+			got = r
+			return nil
+		},
+		Retry: &RetryQueue{Store: NewMemoryRetryStore(), Clock: clock},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/webhooks?id=efa6405d518d4c0c88cce11f7db775fb&recipient=31612345678&status=delivered&statusDatetime=2017-09-01T10%3A00%3A05%2B00%3A00", nil)
+	assert.Equal(t, http.StatusOK, serve(d, r))
+
+	clock.Advance(time.Minute)
+	n, err := d.DrainRetries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "delivered", got.Status)
+}
+
+func TestRetryFailed(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	var failed *QueuedEvent
+	d := &Dispatcher{
+		OnConversation: func(ctx context.Context, e *ConversationEvent) error {
+			return errors.New("database down")
+		},
+		Retry: &RetryQueue{
+			Store:       NewMemoryRetryStore(),
+			Clock:       clock,
+			MaxAttempts: 2,
+			OnFailed: func(e *QueuedEvent, err error) {
+				failed = e
+			},
+		},
+	}
+
+	assert.Equal(t, http.StatusOK, serve(d, jsonRequest(t, "conversation.json")))
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Minute)
+		_, err := d.DrainRetries(context.Background())
+		assert.NoError(t, err)
+	}
+
+	if assert.NotNil(t, failed) {
+		assert.Equal(t, EventConversation, EventType(failed.Kind))
+		assert.Equal(t, 3, failed.Attempts)
+	}
+	assert.Zero(t, d.Retry.Store.(*MemoryRetryStore).Len())
+}
+
+type failingRetryStore struct {
+	*MemoryRetryStore
+}
+
+func (failingRetryStore) Put(context.Context, *QueuedEvent) error {
+	return errors.New("disk full")
+}
+
+func TestRetryStoreFailure(t *testing.T) {
+	var logged []error
+	d := &Dispatcher{
+		OnConversation: func(ctx context.Context, e *ConversationEvent) error {
+			return errors.New("database down")
+		},
+		ErrorLog: func(err error) { logged = append(logged, err) },
+		Retry:    &RetryQueue{Store: failingRetryStore{NewMemoryRetryStore()}},
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, serve(d, jsonRequest(t, "conversation.json")))
+	assert.Len(t, logged, 2)
+}
+
+func TestEncryptedRetryStore(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	key, err := encryption.GenerateKey()
+	assert.NoError(t, err)
+	cipher, err := encryption.NewAESGCM(key)
+	assert.NoError(t, err)
+
+	memory := NewMemoryRetryStore()
+	var got *SMSStatusReport
+	d := &Dispatcher{
+		OnSMSStatus: func(ctx context.Context, r *SMSStatusReport) error {
+			if got == nil {
+				got = &SMSStatusReport{}
+				return errors.New("database down")
+			}
+			got = r
+			return nil
+		},
+		Retry: &RetryQueue{Store: &EncryptedRetryStore{Store: memory, Cipher: cipher}, Clock: clock},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/webhooks?id=efa6405d518d4c0c88cce11f7db775fb&recipient=31612345678&status=delivered&statusDatetime=2017-09-01T10%3A00%3A05%2B00%3A00", nil)
+	assert.Equal(t, http.StatusOK, serve(d, r))
+
+	stored, err := memory.Due(context.Background(), clock.Now().Add(time.Hour), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, stored, 1) {
+		var sealed string
+		assert.NoError(t, json.Unmarshal(stored[0].Payload, &sealed))
+		assert.True(t, encryption.IsSealed(sealed))
+		assert.NotContains(t, string(stored[0].Payload), "31612345678")
+	}
+
+	// An event copied to another ID can not be opened.
+	var failed error
+	d.Retry.OnFailed = func(e *QueuedEvent, err error) { failed = err }
+	moved := *stored[0]
+	moved.ID = "moved"
+	assert.NoError(t, memory.Put(context.Background(), &moved))
+
+	clock.Advance(time.Minute)
+	n, err := d.DrainRetries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.True(t, errors.Is(failed, encryption.ErrDecrypt))
+	assert.Equal(t, "31612345678", got.Recipient)
+	assert.Zero(t, memory.Len())
+
+	// An event injected into the store without encryption fails.
+	d.Retry.OnFailed = func(e *QueuedEvent, err error) { failed = err }
+	payload, err := json.Marshal(&queuedRequest{
+		Method: http.MethodGet,
+		Query:  "id=efa6405d518d4c0c88cce11f7db775fb&recipient=31600000000&status=delivered",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, memory.Put(context.Background(), &QueuedEvent{
+		ID:      "injected",
+		Kind:    string(EventSMSStatus),
+		Payload: payload,
+	}))
+
+	n, err = d.DrainRetries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.True(t, errors.Is(failed, encryption.ErrNotSealed))
+	assert.Equal(t, "31612345678", got.Recipient, "the event is not handled")
+	assert.Zero(t, memory.Len())
+}
@@ -0,0 +1,209 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/signature_jwt"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/verify"
+)
+
+// NewRequest returns a request like the webhook MessageBird sends to
+// targetURL for event, which must be one of *ConversationEvent,
+// *SMSStatusReport, *sms.InboundMessage, *SMSClick, *VoiceEvent,
+// *verify.Verify or *hlr.HLR. The request is not signed: use NewSignedRequest, or sign it with
+// package signature_jwt. This lets you test your webhook receivers without
+// real traffic:
+//
+//	r, _ := webhooks.NewSignedRequest("http://localhost:8080/webhooks", "your signing key", &webhooks.SMSStatusReport{
+//		ID:     "efa6405d518d4c0c88cce11f7db775fb",
+//		Status: "delivered",
+//	})
+//	resp, err := http.DefaultClient.Do(r)
+func NewRequest(targetURL string, event interface{}) (*http.Request, error) {
+	switch e := event.(type) {
+	case *SMSStatusReport:
+		return getRequest(targetURL, smsStatusValues(e))
+	case *sms.InboundMessage:
+		return getRequest(targetURL, smsInboundValues(e))
+	case *SMSClick:
+		return getRequest(targetURL, smsClickValues(e))
+	case *hlr.HLR:
+		return getRequest(targetURL, hlrValues(e))
+	case *ConversationEvent:
+		return postRequest(targetURL, e)
+	case *VoiceEvent:
+		return postRequest(targetURL, voiceBody(e))
+	case *verify.Verify:
+		return postRequest(targetURL, verifyBody(e))
+	}
+
+	return nil, fmt.Errorf("unsupported webhook event type %T", event)
+}
+
+// NewSignedRequest is like NewRequest, but signs the request with a JWT using
+// signingKey. See signature_jwt.SignRequest.
+func NewSignedRequest(targetURL, signingKey string, event interface{}) (*http.Request, error) {
+	r, err := NewRequest(targetURL, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := signature_jwt.SignRequest(r, signingKey); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func getRequest(targetURL string, values url.Values) (*http.Request, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	for name, v := range values {
+		query[name] = v
+	}
+	u.RawQuery = query.Encode()
+
+	return http.NewRequest(http.MethodGet, u.String(), nil)
+}
+
+func postRequest(targetURL string, body interface{}) (*http.Request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequest(http.MethodPost, targetURL, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/json")
+
+	return r, nil
+}
+
+// values builds url.Values, skipping empty values.
+type values url.Values
+
+func (v values) set(name, value string) {
+	if value != "" {
+		url.Values(v).Set(name, value)
+	}
+}
+
+func (v values) setInt(name string, value int) {
+	if value != 0 {
+		v.set(name, strconv.Itoa(value))
+	}
+}
+
+func (v values) setTime(name string, value *time.Time) {
+	if value != nil {
+		v.set(name, value.Format(time.RFC3339))
+	}
+}
+
+func smsStatusValues(r *SMSStatusReport) url.Values {
+	v := values{}
+	v.set("id", r.ID)
+	v.set("reference", r.Reference)
+	v.set("recipient", r.Recipient)
+	v.set("status", r.Status)
+	v.set("statusReason", r.StatusReason)
+	v.setInt("statusErrorCode", r.StatusErrorCode)
+	v.set("mccmnc", r.MCCMNC)
+	v.setInt("messagePartCount", r.MessagePartCount)
+
+	// statusDatetime is required to recognize status reports.
+	statusDatetime := r.StatusDatetime
+	if statusDatetime == nil {
+		now := time.Now()
+		statusDatetime = &now
+	}
+	v.setTime("statusDatetime", statusDatetime)
+
+	return url.Values(v)
+}
+
+func smsInboundValues(m *sms.InboundMessage) url.Values {
+	v := values{}
+	v.set("id", m.ID)
+	v.set("originator", m.Originator)
+	v.set("recipient", m.Recipient)
+	url.Values(v).Set("body", m.Body)
+	v.setTime("createdDatetime", m.CreatedDatetime)
+
+	return url.Values(v)
+}
+
+func smsClickValues(c *SMSClick) url.Values {
+	v := values{}
+	v.set("id", c.ID)
+	v.set("reference", c.Reference)
+	v.set("recipient", c.Recipient)
+	v.set("shortUrl", c.ShortURL)
+
+	// url and clickedDatetime are required to recognize clicks.
+	url.Values(v).Set("url", c.URL)
+	clickedDatetime := c.ClickedDatetime
+	if clickedDatetime == nil {
+		now := time.Now()
+		clickedDatetime = &now
+	}
+	v.setTime("clickedDatetime", clickedDatetime)
+
+	return url.Values(v)
+}
+
+func hlrValues(h *hlr.HLR) url.Values {
+	v := values{}
+	v.set("id", h.ID)
+	v.set("href", h.HRef)
+	v.set("reference", h.Reference)
+	v.set("status", h.Status)
+	url.Values(v).Set("msisdn", strconv.Itoa(h.MSISDN))
+	url.Values(v).Set("network", strconv.Itoa(h.Network))
+	v.setTime("createdDatetime", h.CreatedDatetime)
+	v.setTime("statusDatetime", h.StatusDatetime)
+
+	return url.Values(v)
+}
+
+func voiceBody(e *VoiceEvent) interface{} {
+	item := map[string]interface{}{"type": e.Type, "event": e.Event}
+	switch {
+	case e.Call != nil:
+		item["type"], item["payload"] = "call", e.Call
+	case e.Leg != nil:
+		item["type"], item["payload"] = "leg", e.Leg
+	}
+
+	return map[string]interface{}{
+		"timestamp": e.Timestamp,
+		"items":     []interface{}{item},
+	}
+}
+
+func verifyBody(v *verify.Verify) interface{} {
+	return map[string]interface{}{
+		"id":                 v.ID,
+		"href":               v.HRef,
+		"reference":          v.Reference,
+		"status":             v.Status,
+		"messages":           v.Messages,
+		"createdDatetime":    v.CreatedDatetime,
+		"validUntilDatetime": v.ValidUntilDatetime,
+		"recipient":          v.Recipient,
+	}
+}
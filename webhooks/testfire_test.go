@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/signature_jwt"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/verify"
+	"github.com/messagebird/go-rest-api/v9/voice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSignedRequestRoundTrip(t *testing.T) {
+	const signingKey = "hunter2"
+
+	var got []interface{}
+	record := func(e interface{}) error {
+		got = append(got, e)
+		return nil
+	}
+
+	d := &Dispatcher{
+		Validator:    signature_jwt.NewValidator(signingKey),
+		OnSMSStatus:  func(ctx context.Context, r *SMSStatusReport) error { return record(r) },
+		OnSMSInbound: func(ctx context.Context, m *sms.InboundMessage) error { return record(m) },
+		OnSMSClick:   func(ctx context.Context, c *SMSClick) error { return record(c) },
+		OnHLR:        func(ctx context.Context, h *hlr.HLR) error { return record(h) },
+		OnVoice:      func(ctx context.Context, e *VoiceEvent) error { return record(e) },
+		OnVerify:     func(ctx context.Context, v *verify.Verify) error { return record(v) },
+	}
+	srv := httptest.NewServer(d)
+	defer srv.Close()
+	d.BaseURL = srv.URL
+
+	created := time.Date(2021, 7, 5, 12, 0, 0, 0, time.UTC)
+	events := []interface{}{
+		&SMSStatusReport{ID: "1", Recipient: "31612345678", Status: "delivered", StatusDatetime: &created, MessagePartCount: 1},
+		&sms.InboundMessage{ID: "2", Originator: "31612345678", Recipient: "3197000000", Body: "STOP", CreatedDatetime: &created},
+		&hlr.HLR{ID: "3", MSISDN: 31612345678, Network: 20406, Status: hlr.StatusActive, StatusDatetime: &created},
+		&VoiceEvent{Timestamp: &created, Type: "leg", Event: "legUpdated", Leg: &voice.Leg{ID: "4", CallID: "5", Status: "ongoing", CreatedAt: created, UpdatedAt: created}},
+		&verify.Verify{ID: "6", Recipient: "31612345678", Status: "verified", CreatedDatetime: &created, ValidUntilDatetime: &created},
+		&SMSClick{ID: "7", Recipient: "31612345678", URL: "https://example.com/offer", ClickedDatetime: &created},
+	}
+
+	for _, event := range events {
+		r, err := NewSignedRequest(srv.URL+"/webhooks?source=test", signingKey, event)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		resp, err := srv.Client().Do(r)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "%T", event)
+		}
+	}
+
+	assert.Equal(t, events, got)
+}
+
+func TestNewRequestIsRejectedWithWrongKey(t *testing.T) {
+	srv := httptest.NewServer(&Dispatcher{Validator: signature_jwt.NewValidator("hunter2")})
+	defer srv.Close()
+
+	r, err := NewSignedRequest(srv.URL, "wrong", &SMSStatusReport{ID: "1", Recipient: "31612345678", Status: "delivered"})
+	assert.NoError(t, err)
+
+	resp, err := srv.Client().Do(r)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestNewRequestUnsupported(t *testing.T) {
+	_, err := NewRequest("https://example.com", "hello")
+	assert.EqualError(t, err, "unsupported webhook event type string")
+}
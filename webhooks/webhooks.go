@@ -0,0 +1,304 @@
+// Package webhooks receives the webhooks of all MessageBird products on a
+// single endpoint. A Dispatcher verifies the signature of every request,
+// detects which product sent it, decodes the payload into the matching type
+// and calls the handler registered for it:
+//
+//	d := &webhooks.Dispatcher{
+//		Validator: signature_jwt.NewValidator("your signing key"),
+//		BaseURL:   "https://yourdomain.com",
+//		OnSMSStatus: func(ctx context.Context, r *webhooks.SMSStatusReport) error {
+//			log.Printf("message %s is %s", r.ID, r.Status)
+//			return nil
+//		},
+//	}
+//	http.Handle("/webhooks", d)
+//
+// MessageBird retries webhooks that fail for a limited time only. Set Retry
+// to queue them and redeliver them yourself instead:
+//
+//	d.Retry = &webhooks.RetryQueue{Store: store}
+//	go d.RunRetries(ctx)
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/verify"
+)
+
+// ErrUnknownEvent is returned by Detect when a request does not look like a
+// webhook of any supported product.
+var ErrUnknownEvent = errors.New("unknown webhook event")
+
+// Validator validates the signature of webhook requests and passes valid
+// requests on to h. It is implemented by the validators of package
+// signature_jwt.
+type Validator interface {
+	Validate(h http.Handler, baseURL string) http.Handler
+}
+
+// Dispatcher is an http.Handler that dispatches webhooks to the handler
+// registered for their event type. Requests for event types without a handler
+// are acknowledged and ignored. Handlers that return an error cause a 500
+// response, so MessageBird retries the webhook, unless Retry is set.
+type Dispatcher struct {
+	// Validator verifies request signatures. Requests are not verified if it
+	// is nil, which is only suitable for testing.
+	Validator Validator
+
+	// BaseURL is the public URL of your server, used to validate the URL the
+	// webhook was sent to. See signature_jwt.Validator.ValidateRequest.
+	BaseURL string
+
+	OnConversation func(ctx context.Context, e *ConversationEvent) error
+	OnSMSStatus    func(ctx context.Context, r *SMSStatusReport) error
+	OnSMSInbound   func(ctx context.Context, m *sms.InboundMessage) error
+	OnSMSClick     func(ctx context.Context, c *SMSClick) error
+	OnVoice        func(ctx context.Context, e *VoiceEvent) error
+	OnVerify       func(ctx context.Context, v *verify.Verify) error
+	OnHLR          func(ctx context.Context, h *hlr.HLR) error
+
+	// ErrorLog, if set, is called when a handler returns an error.
+	ErrorLog func(err error)
+
+	// Retry, if set, queues webhooks of which the handler returned an
+	// error in Retry.Store and acknowledges them, instead of relying on the
+	// limited retries of MessageBird. RunRetries redelivers them with
+	// backoff. Webhooks that can not be queued still cause a 500 response.
+	// Handlers must be idempotent: all voice events of a webhook are
+	// redelivered if one of them failed.
+	Retry *RetryQueue
+}
+
+// ServeHTTP implements http.Handler.
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if d.Validator == nil {
+		d.dispatch(w, r)
+		return
+	}
+
+	d.Validator.Validate(http.HandlerFunc(d.dispatch), d.BaseURL).ServeHTTP(w, r)
+}
+
+func (d *Dispatcher) dispatch(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	eventType, f, err := detect(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = d.handle(r, eventType, f, body)
+
+	var decodeErr *decodeError
+	switch {
+	case errors.As(err, &decodeErr):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		if d.ErrorLog != nil {
+			d.ErrorLog(err)
+		}
+		if d.Retry != nil {
+			queueErr := d.enqueue(r.Context(), eventType, r, body, err)
+			if queueErr == nil {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			if d.ErrorLog != nil {
+				d.ErrorLog(queueErr)
+			}
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// decodeError is returned by handle when the payload can not be decoded, as
+// opposed to errors returned by the registered handlers.
+type decodeError struct {
+	eventType EventType
+	err       error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("decoding %s webhook: %v", e.eventType, e.err)
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+func (d *Dispatcher) handle(r *http.Request, eventType EventType, f fields, body []byte) error {
+	ctx := r.Context()
+	fail := func(err error) error {
+		return &decodeError{eventType: eventType, err: err}
+	}
+
+	switch eventType {
+	case EventConversation:
+		if d.OnConversation == nil {
+			return nil
+		}
+		e := &ConversationEvent{}
+		if err := json.Unmarshal(body, e); err != nil {
+			return fail(err)
+		}
+		return d.OnConversation(ctx, e)
+
+	case EventVoice:
+		if d.OnVoice == nil {
+			return nil
+		}
+		events, err := decodeVoiceEvents(body)
+		if err != nil {
+			return fail(err)
+		}
+		for _, e := range events {
+			if err := d.OnVoice(ctx, e); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case EventVerify:
+		if d.OnVerify == nil {
+			return nil
+		}
+		v := &verify.Verify{}
+		if err := json.Unmarshal(body, v); err != nil {
+			return fail(err)
+		}
+		return d.OnVerify(ctx, v)
+
+	case EventHLR:
+		if d.OnHLR == nil {
+			return nil
+		}
+		h, err := decodeHLR(f)
+		if err != nil {
+			return fail(err)
+		}
+		return d.OnHLR(ctx, h)
+
+	case EventSMSInbound:
+		if d.OnSMSInbound == nil {
+			return nil
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		m, err := sms.ParseInbound(r)
+		if err != nil {
+			return fail(err)
+		}
+		return d.OnSMSInbound(ctx, m)
+
+	case EventSMSStatus:
+		if d.OnSMSStatus == nil {
+			return nil
+		}
+		report, err := decodeSMSStatusReport(f)
+		if err != nil {
+			return fail(err)
+		}
+		return d.OnSMSStatus(ctx, report)
+
+	case EventSMSClick:
+		if d.OnSMSClick == nil {
+			return nil
+		}
+		click, err := decodeSMSClick(f)
+		if err != nil {
+			return fail(err)
+		}
+		return d.OnSMSClick(ctx, click)
+	}
+
+	return nil
+}
+
+// Detect reports which product and kind of event r is a webhook for, based on
+// the fields it carries. The body of r is restored, so it can still be read.
+// It returns ErrUnknownEvent if r matches none of them.
+func Detect(r *http.Request) (EventType, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return EventUnknown, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	eventType, _, err := detect(r, body)
+	return eventType, err
+}
+
+func detect(r *http.Request, body []byte) (EventType, fields, error) {
+	f, isJSON, err := requestFields(r, body)
+	if err != nil {
+		return EventUnknown, nil, err
+	}
+
+	switch {
+	case isJSON && f.has("type") && (f.has("message") || f.has("conversation")):
+		return EventConversation, f, nil
+	case isJSON && f.has("items"):
+		return EventVoice, f, nil
+	case f.has("msisdn", "network"):
+		return EventHLR, f, nil
+	case isJSON && f.has("id", "recipient") && (f.has("validUntilDatetime") || f.has("messages")):
+		return EventVerify, f, nil
+	case f.has("originator", "body"):
+		return EventSMSInbound, f, nil
+	case f.has("id", "recipient", "url", "clickedDatetime"):
+		return EventSMSClick, f, nil
+	case f.has("id", "recipient", "status", "statusDatetime"):
+		return EventSMSStatus, f, nil
+	}
+
+	return EventUnknown, nil, ErrUnknownEvent
+}
+
+// requestFields returns the fields of a JSON body, or the query string and
+// form values otherwise.
+func requestFields(r *http.Request, body []byte) (fields, bool, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" || (mediaType == "" && bytes.HasPrefix(bytes.TrimSpace(body), []byte("{"))) {
+		f, err := jsonFields(body)
+		if err != nil {
+			return nil, true, fmt.Errorf("decoding webhook: %w", err)
+		}
+		return f, true, nil
+	}
+
+	values := r.URL.Query()
+	if mediaType == "application/x-www-form-urlencoded" {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, false, fmt.Errorf("parsing webhook: %w", err)
+		}
+		for name, v := range form {
+			values[name] = v
+		}
+	}
+
+	f := make(fields, len(values))
+	for name := range values {
+		f[strings.TrimSuffix(name, "[]")] = values.Get(name)
+	}
+
+	return f, false, nil
+}
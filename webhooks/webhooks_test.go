@@ -0,0 +1,199 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/v9/hlr"
+	"github.com/messagebird/go-rest-api/v9/internal/mbtest"
+	"github.com/messagebird/go-rest-api/v9/signature_jwt"
+	"github.com/messagebird/go-rest-api/v9/sms"
+	"github.com/messagebird/go-rest-api/v9/verify"
+	"github.com/stretchr/testify/assert"
+)
+
+func jsonRequest(t *testing.T, file string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(string(mbtest.Testdata(t, file))))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func serve(d *Dispatcher, r *http.Request) int {
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestDispatchConversation(t *testing.T) {
+	var got *ConversationEvent
+	d := &Dispatcher{OnConversation: func(ctx context.Context, e *ConversationEvent) error {
+		got = e
+		return nil
+	}}
+
+	assert.Equal(t, http.StatusOK, serve(d, jsonRequest(t, "conversation.json")))
+	if assert.NotNil(t, got) {
+		assert.EqualValues(t, "message.created", got.Type)
+		assert.Equal(t, "2e15efafec384e1c82e9842075e87beb", got.Conversation.ID)
+		assert.Equal(t, "Hello", got.Message.Content.Text)
+	}
+}
+
+func TestDispatchVoice(t *testing.T) {
+	var got []*VoiceEvent
+	d := &Dispatcher{OnVoice: func(ctx context.Context, e *VoiceEvent) error {
+		got = append(got, e)
+		return nil
+	}}
+
+	assert.Equal(t, http.StatusOK, serve(d, jsonRequest(t, "voice.json")))
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "callUpdated", got[0].Event)
+		assert.Equal(t, "f1aa71c0-8f2a-4fe8-b5ef-9a330454ef58", got[0].Call.ID)
+		assert.Nil(t, got[0].Leg)
+	}
+}
+
+func TestDispatchVerify(t *testing.T) {
+	var got *verify.Verify
+	d := &Dispatcher{OnVerify: func(ctx context.Context, v *verify.Verify) error {
+		got = v
+		return nil
+	}}
+
+	assert.Equal(t, http.StatusOK, serve(d, jsonRequest(t, "verify.json")))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "verified", got.Status)
+		assert.Equal(t, "31612345678", got.Recipient)
+	}
+}
+
+func TestDispatchSMSStatus(t *testing.T) {
+	var got *SMSStatusReport
+	d := &Dispatcher{OnSMSStatus: func(ctx context.Context, r *SMSStatusReport) error {
+		got = r
+		return nil
+	}}
+
+	r := httptest.NewRequest(http.MethodGet, "/webhooks?id=efa6405d518d4c0c88cce11f7db775fb&reference=ref&recipient=31612345678&status=delivery_failed&statusReason=unknown%20subscriber&statusErrorCode=1&statusDatetime=2017-09-01T10%3A00%3A05%2B00%3A00&mccmnc=20408&messagePartCount=2", nil)
+	assert.Equal(t, http.StatusOK, serve(d, r))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "efa6405d518d4c0c88cce11f7db775fb", got.ID)
+		assert.Equal(t, "delivery_failed", got.Status)
+		assert.Equal(t, 1, got.StatusErrorCode)
+		assert.Equal(t, 2, got.MessagePartCount)
+		assert.Equal(t, 2017, got.StatusDatetime.Year())
+	}
+}
+
+func TestDispatchSMSInbound(t *testing.T) {
+	var got *sms.InboundMessage
+	d := &Dispatcher{OnSMSInbound: func(ctx context.Context, m *sms.InboundMessage) error {
+		got = m
+		return nil
+	}}
+
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader("id=1&originator=31612345678&recipient=3197000000&body=STOP"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.Equal(t, http.StatusOK, serve(d, r))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "STOP", got.Body)
+	}
+}
+
+func TestDispatchSMSClick(t *testing.T) {
+	var got *SMSClick
+	d := &Dispatcher{OnSMSClick: func(ctx context.Context, c *SMSClick) error {
+		got = c
+		return nil
+	}}
+
+	r := httptest.NewRequest(http.MethodGet, "/webhooks?id=efa6405d518d4c0c88cce11f7db775fb&recipient=31612345678&url=https%3A%2F%2Fexample.com%2Foffer&shortUrl=https%3A%2F%2Fexample.link%2Fab12&clickedDatetime=2017-09-01T10%3A00%3A05%2B00%3A00", nil)
+	assert.Equal(t, http.StatusOK, serve(d, r))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "efa6405d518d4c0c88cce11f7db775fb", got.ID)
+		assert.Equal(t, "https://example.com/offer", got.URL)
+		assert.Equal(t, "https://example.link/ab12", got.ShortURL)
+		assert.Equal(t, 2017, got.ClickedDatetime.Year())
+	}
+}
+
+func TestDispatchHLR(t *testing.T) {
+	var got *hlr.HLR
+	d := &Dispatcher{OnHLR: func(ctx context.Context, h *hlr.HLR) error {
+		got = h
+		return nil
+	}}
+
+	r := httptest.NewRequest(http.MethodGet, "/webhooks?id=27978c50&msisdn=31612345678&network=20406&status=active&reference=ref", nil)
+	assert.Equal(t, http.StatusOK, serve(d, r))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, 31612345678, got.MSISDN)
+		assert.Equal(t, 20406, got.Network)
+		assert.True(t, got.IsFinal())
+	}
+}
+
+func TestDispatchErrors(t *testing.T) {
+	var logged error
+	d := &Dispatcher{
+		OnSMSInbound: func(ctx context.Context, m *sms.InboundMessage) error {
+			return errors.New("database down")
+		},
+		ErrorLog: func(err error) { logged = err },
+	}
+
+	unknown := httptest.NewRequest(http.MethodGet, "/webhooks?foo=bar", nil)
+	assert.Equal(t, http.StatusBadRequest, serve(d, unknown))
+
+	// Events without a handler are acknowledged.
+	assert.Equal(t, http.StatusOK, serve(d, jsonRequest(t, "voice.json")))
+
+	inbound := httptest.NewRequest(http.MethodGet, "/webhooks?id=1&originator=31612345678&body=hi", nil)
+	assert.Equal(t, http.StatusInternalServerError, serve(d, inbound))
+	assert.EqualError(t, logged, "database down")
+}
+
+// The validators of package signature_jwt can be used by a Dispatcher.
+var (
+	_ Validator = signature_jwt.NewValidator("")
+	_ Validator = signature_jwt.NewLegacyValidator("")
+	_ Validator = signature_jwt.NewCombinedValidator(nil, nil)
+)
+
+type rejectAll struct{}
+
+func (rejectAll) Validate(h http.Handler, baseURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+}
+
+func TestDispatchValidates(t *testing.T) {
+	called := false
+	d := &Dispatcher{
+		Validator: rejectAll{},
+		OnVerify: func(ctx context.Context, v *verify.Verify) error {
+			called = true
+			return nil
+		},
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve(d, jsonRequest(t, "verify.json")))
+	assert.False(t, called)
+}
+
+func TestDetect(t *testing.T) {
+	r := jsonRequest(t, "conversation.json")
+
+	eventType, err := Detect(r)
+	assert.NoError(t, err)
+	assert.Equal(t, EventConversation, eventType)
+
+	// The body can still be read.
+	assert.Equal(t, http.StatusOK, serve(&Dispatcher{}, r))
+}
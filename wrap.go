@@ -0,0 +1,18 @@
+package messagebird
+
+// Wrapper is implemented by clients that wrap another client, e.g. a
+// blacklist.FilteringClient. ClockOf, RequiresConsent and APIVersionOf look
+// through wrappers, so the options of a wrapped *DefaultClient are kept.
+type Wrapper interface {
+	// Unwrap returns the wrapped client.
+	Unwrap() Client
+}
+
+// unwrap returns the client c wraps, or nil if c is not a Wrapper.
+func unwrap(c Client) Client {
+	if w, ok := c.(Wrapper); ok {
+		return w.Unwrap()
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package messagebird
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stoppedClock time.Time
+
+func (c stoppedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func (c stoppedClock) After(d time.Duration) <-chan time.Time {
+	return nil
+}
+
+func TestClockOf(t *testing.T) {
+	client := New("key")
+	assert.Equal(t, SystemClock, ClockOf(client))
+	assert.Equal(t, SystemClock, ClockOf(&wrappingClient{Client: client}))
+
+	clock := stoppedClock(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+	client.Clock = clock
+	assert.Equal(t, clock, ClockOf(client))
+	assert.Equal(t, clock, ClockOf(&wrappingClient{Client: &wrappingClient{Client: client}}))
+	assert.Equal(t, SystemClock, ClockOf(&wrappingClient{}))
+}